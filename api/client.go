@@ -41,6 +41,13 @@ const EnvVaultToken = "VAULT_TOKEN"
 const EnvVaultMFA = "VAULT_MFA"
 const EnvRateLimit = "VAULT_RATE_LIMIT"
 
+// DefaultMinRetryWait and DefaultMaxRetryWait are the default bounds of the
+// time to wait between retries
+const (
+	DefaultMinRetryWait = 1000 * time.Millisecond
+	DefaultMaxRetryWait = 1500 * time.Millisecond
+)
+
 // WrappingLookupFunc is a function that, given an HTTP verb and a path,
 // returns an optional string duration to be used for response wrapping (e.g.
 // "15s", or simply "15"). The path will not begin with "/v1/" or "v1/" or "/",
@@ -74,6 +81,18 @@ type Config struct {
 	// of three tries).
 	MaxRetries int
 
+	// MinRetryWait and MaxRetryWait bound the time to wait between retries,
+	// which is computed by the Backoff function. They default to 1s and
+	// 1.5s.
+	MinRetryWait time.Duration
+	MaxRetryWait time.Duration
+
+	// CheckRetry decides whether a request is retried after a response or
+	// an error. By default connection errors and 5xx responses other than
+	// 501 are retried; RetryOnStatusCodes builds a policy retrying other
+	// status codes, such as 429.
+	CheckRetry retryablehttp.CheckRetry
+
 	// Timeout is for setting custom timeout parameter in the HttpClient
 	Timeout time.Duration
 
@@ -81,7 +100,8 @@ type Config struct {
 	// error
 	Error error
 
-	// The Backoff function to use; a default is used if not provided
+	// The Backoff function to use; a default is used if not provided. Use
+	// retryablehttp.DefaultBackoff for exponential backoff.
 	Backoff retryablehttp.Backoff
 
 	// Limiter is the rate limiter used by the client.
@@ -125,6 +145,26 @@ type TLSConfig struct {
 	Insecure bool
 }
 
+// RetryOnStatusCodes returns a retry policy that retries connection errors
+// and responses with any of the given status codes, such as 429, 500, 502
+// and 503. Requests whose context is done are not retried.
+func RetryOnStatusCodes(codes ...int) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if err != nil {
+			return true, err
+		}
+		for _, code := range codes {
+			if resp.StatusCode == code {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
 // DefaultConfig returns a default configuration for the client. It is
 // safe to modify the return value of this function.
 //
@@ -168,6 +208,9 @@ func DefaultConfig() *Config {
 
 	config.Backoff = retryablehttp.LinearJitterBackoff
 	config.MaxRetries = 2
+	config.MinRetryWait = DefaultMinRetryWait
+	config.MaxRetryWait = DefaultMaxRetryWait
+	config.CheckRetry = retryablehttp.DefaultRetryPolicy
 
 	return config
 }
@@ -475,6 +518,36 @@ func (c *Client) SetMaxRetries(retries int) {
 	c.config.MaxRetries = retries
 }
 
+// SetMinRetryWait sets the minimum time to wait between retries
+func (c *Client) SetMinRetryWait(retryWait time.Duration) {
+	c.modifyLock.RLock()
+	c.config.modifyLock.Lock()
+	defer c.config.modifyLock.Unlock()
+	c.modifyLock.RUnlock()
+
+	c.config.MinRetryWait = retryWait
+}
+
+// SetMaxRetryWait sets the maximum time to wait between retries
+func (c *Client) SetMaxRetryWait(retryWait time.Duration) {
+	c.modifyLock.RLock()
+	c.config.modifyLock.Lock()
+	defer c.config.modifyLock.Unlock()
+	c.modifyLock.RUnlock()
+
+	c.config.MaxRetryWait = retryWait
+}
+
+// SetCheckRetry sets the policy deciding which requests are retried
+func (c *Client) SetCheckRetry(checkRetry retryablehttp.CheckRetry) {
+	c.modifyLock.RLock()
+	c.config.modifyLock.Lock()
+	defer c.config.modifyLock.Unlock()
+	c.modifyLock.RUnlock()
+
+	c.config.CheckRetry = checkRetry
+}
+
 // SetClientTimeout sets the client request timeout
 func (c *Client) SetClientTimeout(timeout time.Duration) {
 	c.modifyLock.RLock()
@@ -625,12 +698,15 @@ func (c *Client) Clone() (*Client, error) {
 	c.modifyLock.RUnlock()
 
 	newConfig := &Config{
-		Address:    config.Address,
-		HttpClient: config.HttpClient,
-		MaxRetries: config.MaxRetries,
-		Timeout:    config.Timeout,
-		Backoff:    config.Backoff,
-		Limiter:    config.Limiter,
+		Address:      config.Address,
+		HttpClient:   config.HttpClient,
+		MaxRetries:   config.MaxRetries,
+		MinRetryWait: config.MinRetryWait,
+		MaxRetryWait: config.MaxRetryWait,
+		CheckRetry:   config.CheckRetry,
+		Timeout:      config.Timeout,
+		Backoff:      config.Backoff,
+		Limiter:      config.Limiter,
 	}
 	config.modifyLock.RUnlock()
 
@@ -727,6 +803,9 @@ func (c *Client) RawRequestWithContext(ctx context.Context, r *Request) (*Respon
 	c.config.modifyLock.RLock()
 	limiter := c.config.Limiter
 	maxRetries := c.config.MaxRetries
+	minRetryWait := c.config.MinRetryWait
+	maxRetryWait := c.config.MaxRetryWait
+	checkRetry := c.config.CheckRetry
 	backoff := c.config.Backoff
 	httpClient := c.config.HttpClient
 	timeout := c.config.Timeout
@@ -770,13 +849,22 @@ START:
 	if backoff == nil {
 		backoff = retryablehttp.LinearJitterBackoff
 	}
+	if minRetryWait == 0 {
+		minRetryWait = DefaultMinRetryWait
+	}
+	if maxRetryWait == 0 {
+		maxRetryWait = DefaultMaxRetryWait
+	}
+	if checkRetry == nil {
+		checkRetry = retryablehttp.DefaultRetryPolicy
+	}
 
 	client := &retryablehttp.Client{
 		HTTPClient:   httpClient,
-		RetryWaitMin: 1000 * time.Millisecond,
-		RetryWaitMax: 1500 * time.Millisecond,
+		RetryWaitMin: minRetryWait,
+		RetryWaitMax: maxRetryWait,
 		RetryMax:     maxRetries,
-		CheckRetry:   retryablehttp.DefaultRetryPolicy,
+		CheckRetry:   checkRetry,
 		Backoff:      backoff,
 		ErrorHandler: retryablehttp.PassthroughErrorHandler,
 	}
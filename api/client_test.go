@@ -2,12 +2,15 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"github.com/hashicorp/vault/sdk/helper/consts"
 	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func init() {
@@ -308,3 +311,68 @@ func TestClone(t *testing.T) {
 
 	_ = client2
 }
+
+func TestClientRetry(t *testing.T) {
+	var calls int32
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+	config, ln := testHTTPServer(t, http.HandlerFunc(handler))
+	defer ln.Close()
+
+	config.MinRetryWait = time.Millisecond
+	config.MaxRetryWait = 2 * time.Millisecond
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 429 is not retried by default
+	resp, err := client.RawRequest(client.NewRequest("GET", "/"))
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 response, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+
+	client.SetCheckRetry(RetryOnStatusCodes(http.StatusTooManyRequests))
+	if _, err := client.RawRequest(client.NewRequest("GET", "/")); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestClientContextCancel(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-done:
+		}
+	}
+	config, ln := testHTTPServer(t, http.HandlerFunc(handler))
+	defer ln.Close()
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Logical().ReadWithContext(ctx, "secret/foo"); err == nil {
+		t.Fatal("expected error")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("request was not canceled")
+	}
+}
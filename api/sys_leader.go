@@ -3,9 +3,13 @@ package api
 import "context"
 
 func (c *Sys) Leader() (*LeaderResponse, error) {
+	return c.LeaderWithContext(context.Background())
+}
+
+func (c *Sys) LeaderWithContext(ctx context.Context) (*LeaderResponse, error) {
 	r := c.c.NewRequest("GET", "/v1/sys/leader")
 
-	ctx, cancelFunc := context.WithCancel(context.Background())
+	ctx, cancelFunc := context.WithCancel(ctx)
 	defer cancelFunc()
 	resp, err := c.c.RawRequestWithContext(ctx, r)
 	if err != nil {
@@ -0,0 +1,189 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/vault/helper/namespace"
+	glob "github.com/ryanuber/go-glob"
+)
+
+// Filter decides whether an audit device should log a given entry. It is
+// parsed from an expression made up of one or more clauses joined by "and",
+// where each clause compares a field of the request against a value:
+//
+//	operation == update and path == "sys/*"
+//
+// Values may contain "*" wildcards. The supported fields are:
+//
+//	path        the request path, relative to the namespace
+//	mount_path  the path of the mount handling the request
+//	mount_type  the type of the mount handling the request
+//	operation   the request operation, e.g. "read" or "update"
+//	namespace   the path of the request's namespace; "" for the root
+//	policy      matches if any of the policies attached to the request does
+type Filter struct {
+	clauses []filterClause
+}
+
+type filterClause struct {
+	field  string
+	negate bool
+	value  string
+}
+
+var filterFields = map[string]bool{
+	"path":       true,
+	"mount_path": true,
+	"mount_type": true,
+	"operation":  true,
+	"namespace":  true,
+	"policy":     true,
+}
+
+// ParseFilter parses a filter expression. An empty expression returns a nil
+// filter, which matches everything.
+func ParseFilter(expr string) (*Filter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	f := &Filter{}
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return nil, fmt.Errorf("incomplete filter clause %q", strings.Join(tokens, " "))
+		}
+
+		clause := filterClause{
+			field: strings.ToLower(tokens[0]),
+			value: tokens[2],
+		}
+		if !filterFields[clause.field] {
+			return nil, fmt.Errorf("unknown filter field %q", tokens[0])
+		}
+		switch tokens[1] {
+		case "==":
+		case "!=":
+			clause.negate = true
+		default:
+			return nil, fmt.Errorf("unknown filter operator %q; must be == or !=", tokens[1])
+		}
+		f.clauses = append(f.clauses, clause)
+
+		tokens = tokens[3:]
+		if len(tokens) > 0 {
+			if !strings.EqualFold(tokens[0], "and") {
+				return nil, fmt.Errorf("expected \"and\" between filter clauses, got %q", tokens[0])
+			}
+			tokens = tokens[1:]
+			if len(tokens) == 0 {
+				return nil, fmt.Errorf("filter cannot end with \"and\"")
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// tokenizeFilter splits a filter expression on whitespace and around
+// comparison operators, honoring double-quoted values.
+func tokenizeFilter(expr string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuote := false
+	quoted := false
+
+	flush := func() {
+		if cur.Len() > 0 || quoted {
+			tokens = append(tokens, cur.String())
+		}
+		cur.Reset()
+		quoted = false
+	}
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case inQuote:
+			if r == '"' {
+				inQuote = false
+				continue
+			}
+			cur.WriteRune(r)
+		case r == '"':
+			inQuote = true
+			quoted = true
+		case unicode.IsSpace(r):
+			flush()
+		case (r == '=' || r == '!') && i+1 < len(runes) && runes[i+1] == '=':
+			flush()
+			tokens = append(tokens, string(r)+"=")
+			i++
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote in filter %q", expr)
+	}
+	flush()
+
+	return tokens, nil
+}
+
+// Match returns whether the given input passes the filter. A nil filter
+// matches everything.
+func (f *Filter) Match(ctx context.Context, in *LogInput) bool {
+	if f == nil {
+		return true
+	}
+	if in == nil || in.Request == nil {
+		return false
+	}
+
+	var nsPath string
+	if ns, err := namespace.FromContext(ctx); err == nil && ns != nil {
+		nsPath = ns.Path
+	}
+
+	for _, c := range f.clauses {
+		if c.match(nsPath, in) == c.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (c filterClause) match(nsPath string, in *LogInput) bool {
+	req := in.Request
+
+	switch c.field {
+	case "path":
+		return glob.Glob(c.value, req.Path)
+	case "mount_path":
+		return glob.Glob(c.value, strings.TrimPrefix(req.MountPoint, nsPath))
+	case "mount_type":
+		return glob.Glob(c.value, req.MountType)
+	case "operation":
+		return glob.Glob(c.value, string(req.Operation))
+	case "namespace":
+		return glob.Glob(c.value, nsPath)
+	case "policy":
+		if in.Auth == nil {
+			return false
+		}
+		for _, p := range in.Auth.Policies {
+			if glob.Glob(c.value, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func TestParseFilter(t *testing.T) {
+	valid := []string{
+		"",
+		"path == sys/*",
+		`operation==update and path != "sys/audit/*"`,
+		`namespace == "" AND policy == root`,
+	}
+	for _, expr := range valid {
+		if _, err := ParseFilter(expr); err != nil {
+			t.Fatalf("expected %q to parse: %v", expr, err)
+		}
+	}
+
+	invalid := []string{
+		"path",
+		"path ==",
+		"foo == bar",
+		"path = sys/",
+		"path == sys/ or operation == read",
+		"path == sys/ and",
+		`path == "sys/`,
+	}
+	for _, expr := range invalid {
+		if _, err := ParseFilter(expr); err == nil {
+			t.Fatalf("expected %q to fail to parse", expr)
+		}
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	ctx := namespace.RootContext(context.Background())
+	in := &LogInput{
+		Auth: &logical.Auth{
+			Policies: []string{"default", "root"},
+		},
+		Request: &logical.Request{
+			Operation:  logical.UpdateOperation,
+			Path:       "sys/policy/foo",
+			MountPoint: "sys/",
+			MountType:  "system",
+		},
+	}
+
+	cases := map[string]bool{
+		"":                 true,
+		"path == sys/*":    true,
+		"path == secret/*": false,
+		"path != secret/*": true,
+		"operation == update and mount_type == system": true,
+		"operation == update and mount_path != sys/":   false,
+		"policy == root":  true,
+		"policy == admin": false,
+		`namespace == ""`: true,
+	}
+	for expr, expected := range cases {
+		f, err := ParseFilter(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := f.Match(ctx, in); actual != expected {
+			t.Fatalf("filter %q: expected %t, got %t", expr, expected, actual)
+		}
+	}
+
+	// Requests without auth never match a policy
+	f, err := ParseFilter("policy != root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	in.Auth = nil
+	if !f.Match(ctx, in) {
+		t.Fatal("expected unauthenticated request to match")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	"github.com/hashicorp/vault/sdk/helper/salt"
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/mitchellh/copystructure"
@@ -188,7 +190,7 @@ func (f *AuditFormatter) FormatResponse(ctx context.Context, w io.Writer, config
 	// Set these to the input values at first
 	auth := in.Auth
 	req := in.Request
-	resp := in.Response
+	resp := elideResponseData(config, in.Request, in.Response)
 
 	if !config.Raw {
 		// Before we copy the structure we must nil out some data
@@ -216,8 +218,8 @@ func (f *AuditFormatter) FormatResponse(ctx context.Context, w io.Writer, config
 		}
 		req = cp.(*logical.Request)
 
-		if in.Response != nil {
-			cp, err := copystructure.Copy(in.Response)
+		if resp != nil {
+			cp, err := copystructure.Copy(resp)
 			if err != nil {
 				return err
 			}
@@ -467,6 +469,91 @@ type AuditNamespace struct {
 	Path string `json:"path"`
 }
 
+// ParseResponseElision parses the elide_list_responses and
+// max_response_data_size options of an audit device config, which control
+// how response data is elided before it is logged.
+func ParseResponseElision(config map[string]string) (elideListResponses bool, maxResponseDataSize int, err error) {
+	if elideRaw, ok := config["elide_list_responses"]; ok {
+		elideListResponses, err = strconv.ParseBool(elideRaw)
+		if err != nil {
+			return false, 0, err
+		}
+	}
+
+	if sizeRaw, ok := config["max_response_data_size"]; ok {
+		maxResponseDataSize, err = strconv.Atoi(sizeRaw)
+		if err != nil {
+			return false, 0, err
+		}
+		if maxResponseDataSize < 0 {
+			return false, 0, fmt.Errorf("max_response_data_size cannot be negative")
+		}
+	}
+
+	return elideListResponses, maxResponseDataSize, nil
+}
+
+// elideResponseData returns the response with its data reduced according to
+// the formatter config: list responses have their keys replaced by a count,
+// and data larger than the configured maximum is replaced by its top-level
+// field names with null values. The given response is never modified; if
+// anything is elided a shallow copy is returned.
+func elideResponseData(config FormatterConfig, req *logical.Request, resp *logical.Response) *logical.Response {
+	if resp == nil || len(resp.Data) == 0 {
+		return resp
+	}
+
+	var data map[string]interface{}
+
+	if config.ElideListResponses && req != nil && req.Operation == logical.ListOperation {
+		data = make(map[string]interface{}, len(resp.Data))
+		for k, v := range resp.Data {
+			data[k] = v
+		}
+		if keys, ok := data["keys"]; ok {
+			data["keys"] = elidedCount(keys)
+		}
+		if keyInfo, ok := data["key_info"]; ok {
+			data["key_info"] = elidedCount(keyInfo)
+		}
+	}
+
+	if config.MaxResponseDataSize > 0 {
+		current := data
+		if current == nil {
+			current = resp.Data
+		}
+		if encoded, err := jsonutil.EncodeJSON(current); err != nil || len(encoded) > config.MaxResponseDataSize {
+			data = make(map[string]interface{}, len(current))
+			for k := range current {
+				data[k] = nil
+			}
+		}
+	}
+
+	if data == nil {
+		return resp
+	}
+
+	cp := *resp
+	cp.Data = data
+	return &cp
+}
+
+// elidedCount returns the number of elements in a list or map value, or the
+// value itself if it is neither
+func elidedCount(v interface{}) interface{} {
+	switch t := v.(type) {
+	case []string:
+		return len(t)
+	case []interface{}:
+		return len(t)
+	case map[string]interface{}:
+		return len(t)
+	}
+	return v
+}
+
 // getRemoteAddr safely gets the remote address avoiding a nil pointer
 func getRemoteAddr(req *logical.Request) string {
 	if req != nil && req.Connection != nil {
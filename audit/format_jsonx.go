@@ -39,7 +39,9 @@ func (f *JSONxFormatWriter) WriteRequest(w io.Writer, req *AuditRequestEntry) er
 		return err
 	}
 
-	_, err = w.Write(xmlBytes)
+	// Terminate each record with a newline, as the JSON encoder does, so
+	// that consumers can split the stream into entries
+	_, err = w.Write(append(xmlBytes, '\n'))
 	return err
 }
 
@@ -65,7 +67,9 @@ func (f *JSONxFormatWriter) WriteResponse(w io.Writer, resp *AuditResponseEntry)
 		return err
 	}
 
-	_, err = w.Write(xmlBytes)
+	// Terminate each record with a newline, as the JSON encoder does, so
+	// that consumers can split the stream into entries
+	_, err = w.Write(append(xmlBytes, '\n'))
 	return err
 }
 
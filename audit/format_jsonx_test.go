@@ -3,6 +3,8 @@ package audit
 import (
 	"bytes"
 	"context"
+	"encoding/xml"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -116,6 +118,10 @@ func TestFormatJSONx_formatRequest(t *testing.T) {
 			t.Fatalf("no prefix: %s \n log: %s\nprefix: %s", name, tc.Result, tc.Prefix)
 		}
 
+		if !strings.HasSuffix(buf.String(), "\n") {
+			t.Fatalf("no trailing newline: %s\n log: %q", name, buf.String())
+		}
+
 		if !strings.HasSuffix(strings.TrimSpace(buf.String()), string(tc.ExpectedStr)) {
 			t.Fatalf(
 				"bad: %s\nResult:\n\n'%s'\n\nExpected:\n\n'%s'",
@@ -123,3 +129,66 @@ func TestFormatJSONx_formatRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatJSONx_framing(t *testing.T) {
+	salter, err := salt.NewSalt(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saltFunc := func(context.Context) (*salt.Salt, error) {
+		return salter, nil
+	}
+
+	var buf bytes.Buffer
+	formatter := AuditFormatter{
+		AuditFormatWriter: &JSONxFormatWriter{
+			Prefix:   "@cee: ",
+			SaltFunc: saltFunc,
+		},
+	}
+	config := FormatterConfig{
+		OmitTime: true,
+	}
+	in := &LogInput{
+		Request: &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "/foo",
+		},
+		Response: &logical.Response{
+			Data: map[string]interface{}{
+				"foo": "bar",
+			},
+		},
+	}
+	if err := formatter.FormatRequest(namespace.RootContext(nil), &buf, config, in); err != nil {
+		t.Fatal(err)
+	}
+	if err := formatter.FormatResponse(namespace.RootContext(nil), &buf, config, in); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each entry is its own line: the prefix, a single XML document and a
+	// trailing newline
+	if !strings.HasSuffix(buf.String(), "\n") {
+		t.Fatalf("no trailing newline: %q", buf.String())
+	}
+	records := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d: %q", len(records), buf.String())
+	}
+	for i, record := range records {
+		if !strings.HasPrefix(record, "@cee: ") {
+			t.Fatalf("record %d has no prefix: %q", i, record)
+		}
+		dec := xml.NewDecoder(strings.NewReader(strings.TrimPrefix(record, "@cee: ")))
+		for {
+			_, err := dec.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("record %d is not well-formed XML: %v\n%q", i, err, record)
+			}
+		}
+	}
+}
@@ -70,3 +70,76 @@ func TestFormatResponseErrors(t *testing.T) {
 		t.Fatal("expected error due to nil writer")
 	}
 }
+
+func TestElideResponseData(t *testing.T) {
+	listReq := &logical.Request{Operation: logical.ListOperation}
+	readReq := &logical.Request{Operation: logical.ReadOperation}
+
+	listResp := &logical.Response{
+		Data: map[string]interface{}{
+			"keys": []string{"foo", "bar", "baz"},
+			"key_info": map[string]interface{}{
+				"foo": map[string]interface{}{"a": "b"},
+			},
+		},
+	}
+
+	// Not configured, nothing changes
+	if resp := elideResponseData(FormatterConfig{}, listReq, listResp); resp != listResp {
+		t.Fatal("expected response to be unchanged")
+	}
+
+	// List responses are reduced to counts
+	resp := elideResponseData(FormatterConfig{ElideListResponses: true}, listReq, listResp)
+	if resp.Data["keys"] != 3 || resp.Data["key_info"] != 1 {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+	if _, ok := listResp.Data["keys"].([]string); !ok {
+		t.Fatal("original response was modified")
+	}
+
+	// Only list operations are affected
+	if resp := elideResponseData(FormatterConfig{ElideListResponses: true}, readReq, listResp); resp != listResp {
+		t.Fatal("expected response to be unchanged")
+	}
+
+	// Large data is reduced to field names
+	readResp := &logical.Response{
+		Data: map[string]interface{}{
+			"certificate": "-----BEGIN CERTIFICATE-----...",
+			"serial":      "1a:2b",
+		},
+	}
+	resp = elideResponseData(FormatterConfig{MaxResponseDataSize: 10}, readReq, readResp)
+	if len(resp.Data) != 2 || resp.Data["certificate"] != nil || resp.Data["serial"] != nil {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+	if resp := elideResponseData(FormatterConfig{MaxResponseDataSize: 1024}, readReq, readResp); resp != readResp {
+		t.Fatal("expected response to be unchanged")
+	}
+}
+
+func TestParseResponseElision(t *testing.T) {
+	elide, size, err := ParseResponseElision(map[string]string{})
+	if err != nil || elide || size != 0 {
+		t.Fatalf("bad: %t %d %v", elide, size, err)
+	}
+
+	elide, size, err = ParseResponseElision(map[string]string{
+		"elide_list_responses":   "true",
+		"max_response_data_size": "4096",
+	})
+	if err != nil || !elide || size != 4096 {
+		t.Fatalf("bad: %t %d %v", elide, size, err)
+	}
+
+	for _, config := range []map[string]string{
+		{"elide_list_responses": "sometimes"},
+		{"max_response_data_size": "large"},
+		{"max_response_data_size": "-1"},
+	} {
+		if _, _, err := ParseResponseElision(config); err == nil {
+			t.Fatalf("expected error for %v", config)
+		}
+	}
+}
@@ -19,6 +19,13 @@ type FormatterConfig struct {
 	Raw          bool
 	HMACAccessor bool
 
+	// ElideListResponses replaces the keys in list responses with their count
+	ElideListResponses bool
+
+	// MaxResponseDataSize is the size in bytes of the JSON-encoded response
+	// data above which only the field names are logged, or zero for no limit
+	MaxResponseDataSize int
+
 	// This should only ever be used in a testing context
 	OmitTime bool
 }
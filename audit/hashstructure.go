@@ -135,7 +135,8 @@ type hashWalker struct {
 	// immediately and the error returned.
 	Callback HashCallback
 
-	// IgnoreKeys are the keys that wont have the HashCallback applied
+	// IgnoreKeys are the keys that wont have the HashCallback applied. A key
+	// matches either by name at any depth or by its dotted path.
 	IgnoredKeys []string
 
 	key         []string
@@ -251,11 +252,15 @@ func (w *hashWalker) Primitive(v reflect.Value) error {
 		return nil
 	}
 
-	// See if the current key is part of the ignored keys
+	// See if the current key, or its full dotted path from the top of the
+	// structure (e.g. "data.serial_number"), is part of the ignored keys
 	currentKey := w.key[len(w.key)-1]
 	if strutil.StrListContains(w.IgnoredKeys, currentKey) {
 		return nil
 	}
+	if len(w.key) > 1 && strutil.StrListContains(w.IgnoredKeys, strings.Join(w.key, ".")) {
+		return nil
+	}
 
 	replaceVal := w.Callback(v.String())
 
@@ -264,3 +264,41 @@ func TestHashWalker(t *testing.T) {
 		}
 	}
 }
+
+func TestHashWalker_ignoredKeys(t *testing.T) {
+	replaceText := "foo"
+
+	input := map[string]interface{}{
+		"serial_number": "1a:2b",
+		"data": map[string]interface{}{
+			"serial_number": "3c:4d",
+			"username":      "bob",
+			"password":      "hunter2",
+		},
+		"other": map[string]interface{}{
+			"username": "alice",
+		},
+	}
+
+	expected := map[string]interface{}{
+		"serial_number": "1a:2b",
+		"data": map[string]interface{}{
+			"serial_number": "3c:4d",
+			"username":      "bob",
+			"password":      replaceText,
+		},
+		"other": map[string]interface{}{
+			"username": replaceText,
+		},
+	}
+
+	output, err := HashStructure(input, func(string) string {
+		return replaceText
+	}, []string{"serial_number", "data.username"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Fatalf("bad:\n\n%#v\n\n%#v", expected, output)
+	}
+}
@@ -3,15 +3,19 @@ package file
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/vault/audit"
+	"github.com/hashicorp/vault/sdk/helper/parseutil"
 	"github.com/hashicorp/vault/sdk/helper/salt"
 	"github.com/hashicorp/vault/sdk/logical"
 )
@@ -70,6 +74,12 @@ func Factory(ctx context.Context, conf *audit.BackendConfig) (audit.Backend, err
 		logRaw = b
 	}
 
+	// Check if list responses or large response data should be elided
+	elideListResponses, maxResponseDataSize, err := audit.ParseResponseElision(conf.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if mode is provided
 	mode := os.FileMode(0600)
 	if modeRaw, ok := conf.Config["mode"]; ok {
@@ -82,14 +92,60 @@ func Factory(ctx context.Context, conf *audit.BackendConfig) (audit.Backend, err
 		}
 	}
 
+	// Check if self-rotation is configured
+	var rotateBytes int64
+	if rotateBytesRaw, ok := conf.Config["rotate_bytes"]; ok {
+		v, err := strconv.ParseInt(rotateBytesRaw, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 {
+			return nil, fmt.Errorf("rotate_bytes cannot be negative")
+		}
+		rotateBytes = v
+	}
+
+	var rotateDuration time.Duration
+	if rotateDurationRaw, ok := conf.Config["rotate_duration"]; ok {
+		v, err := parseutil.ParseDurationSecond(rotateDurationRaw)
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 {
+			return nil, fmt.Errorf("rotate_duration cannot be negative")
+		}
+		rotateDuration = v
+	}
+
+	var rotateMaxFiles int
+	if rotateMaxFilesRaw, ok := conf.Config["rotate_max_files"]; ok {
+		v, err := strconv.Atoi(rotateMaxFilesRaw)
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 {
+			return nil, fmt.Errorf("rotate_max_files cannot be negative")
+		}
+		rotateMaxFiles = v
+	}
+
+	if (rotateBytes > 0 || rotateDuration > 0) && (path == "stdout" || path == "discard") {
+		return nil, fmt.Errorf("rotation is not supported when file_path is %q", path)
+	}
+
 	b := &Backend{
-		path:       path,
-		mode:       mode,
-		saltConfig: conf.SaltConfig,
-		saltView:   conf.SaltView,
+		path:           path,
+		mode:           mode,
+		rotateBytes:    rotateBytes,
+		rotateDuration: rotateDuration,
+		rotateMaxFiles: rotateMaxFiles,
+		saltConfig:     conf.SaltConfig,
+		saltView:       conf.SaltView,
 		formatConfig: audit.FormatterConfig{
-			Raw:          logRaw,
-			HMACAccessor: hmacAccessor,
+			Raw:                 logRaw,
+			HMACAccessor:        hmacAccessor,
+			ElideListResponses:  elideListResponses,
+			MaxResponseDataSize: maxResponseDataSize,
 		},
 	}
 
@@ -123,9 +179,9 @@ func Factory(ctx context.Context, conf *audit.BackendConfig) (audit.Backend, err
 
 // Backend is the audit backend for the file-based audit store.
 //
-// NOTE: This audit backend is currently very simple: it appends to a file.
-// It doesn't do anything more at the moment to assist with rotation
-// or reset the write cursor, this should be done in the future.
+// It appends to a file, which is reopened on reload (e.g. SIGHUP) to support
+// external rotation. It can also rotate the file itself once it reaches a
+// configured size or age, optionally pruning old rotated files.
 type Backend struct {
 	path string
 
@@ -136,6 +192,14 @@ type Backend struct {
 	f        *os.File
 	mode     os.FileMode
 
+	// Self-rotation settings and the state of the current file, protected
+	// by fileLock
+	rotateBytes    int64
+	rotateDuration time.Duration
+	rotateMaxFiles int
+	size           int64
+	openedAt       time.Time
+
 	saltMutex  sync.RWMutex
 	salt       *salt.Salt
 	saltConfig *salt.Config
@@ -183,11 +247,15 @@ func (b *Backend) LogRequest(ctx context.Context, in *audit.LogInput) error {
 		return b.formatter.FormatRequest(ctx, ioutil.Discard, b.formatConfig, in)
 	}
 
+	if err := b.rotateIfNeeded(); err != nil {
+		return err
+	}
+
 	if err := b.open(); err != nil {
 		return err
 	}
 
-	if err := b.formatter.FormatRequest(ctx, b.f, b.formatConfig, in); err == nil {
+	if err := b.formatter.FormatRequest(ctx, b.writer(), b.formatConfig, in); err == nil {
 		return nil
 	}
 
@@ -199,7 +267,7 @@ func (b *Backend) LogRequest(ctx context.Context, in *audit.LogInput) error {
 		return err
 	}
 
-	return b.formatter.FormatRequest(ctx, b.f, b.formatConfig, in)
+	return b.formatter.FormatRequest(ctx, b.writer(), b.formatConfig, in)
 }
 
 func (b *Backend) LogResponse(ctx context.Context, in *audit.LogInput) error {
@@ -214,11 +282,15 @@ func (b *Backend) LogResponse(ctx context.Context, in *audit.LogInput) error {
 		return b.formatter.FormatResponse(ctx, ioutil.Discard, b.formatConfig, in)
 	}
 
+	if err := b.rotateIfNeeded(); err != nil {
+		return err
+	}
+
 	if err := b.open(); err != nil {
 		return err
 	}
 
-	if err := b.formatter.FormatResponse(ctx, b.f, b.formatConfig, in); err == nil {
+	if err := b.formatter.FormatResponse(ctx, b.writer(), b.formatConfig, in); err == nil {
 		return nil
 	}
 
@@ -230,7 +302,7 @@ func (b *Backend) LogResponse(ctx context.Context, in *audit.LogInput) error {
 		return err
 	}
 
-	return b.formatter.FormatResponse(ctx, b.f, b.formatConfig, in)
+	return b.formatter.FormatResponse(ctx, b.writer(), b.formatConfig, in)
 }
 
 // The file lock must be held before calling this
@@ -248,6 +320,20 @@ func (b *Backend) open() error {
 		return err
 	}
 
+	// Track the current size so that size-based rotation accounts for any
+	// existing content
+	info, err := b.f.Stat()
+	if err != nil {
+		return err
+	}
+	b.size = info.Size()
+
+	// Re-opening the same file, such as on reload or after a write error,
+	// keeps the original open time so that age-based rotation still happens
+	if b.openedAt.IsZero() {
+		b.openedAt = time.Now()
+	}
+
 	// Change the file mode in case the log file already existed. We special
 	// case /dev/null since we can't chmod it and bypass if the mode is zero
 	switch b.path {
@@ -264,6 +350,78 @@ func (b *Backend) open() error {
 	return nil
 }
 
+// writer returns a writer for the current file that keeps track of the
+// number of bytes written. The file lock must be held before calling this.
+func (b *Backend) writer() io.Writer {
+	return &countingWriter{w: b.f, n: &b.size}
+}
+
+// rotateIfNeeded rotates the current file if it has exceeded the configured
+// size or age. The file lock must be held before calling this.
+func (b *Backend) rotateIfNeeded() error {
+	if b.f == nil {
+		return nil
+	}
+
+	switch {
+	case b.rotateBytes > 0 && b.size >= b.rotateBytes:
+	case b.rotateDuration > 0 && time.Since(b.openedAt) >= b.rotateDuration:
+	default:
+		return nil
+	}
+
+	return b.rotate()
+}
+
+// rotate moves the current file aside with a timestamp suffix, opens a fresh
+// file, and prunes old rotated files beyond the configured retention. The
+// file lock must be held before calling this.
+func (b *Backend) rotate() error {
+	if b.f != nil {
+		err := b.f.Close()
+		b.f = nil
+		if err != nil {
+			return err
+		}
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", b.path, time.Now().UTC().Format(rotateTimeFormat))
+	if err := os.Rename(b.path, rotatedPath); err != nil && !os.IsNotExist(err) {
+		return errwrap.Wrapf("failed to rotate audit file: {{err}}", err)
+	}
+	b.openedAt = time.Time{}
+
+	if err := b.open(); err != nil {
+		return err
+	}
+
+	if b.rotateMaxFiles == 0 {
+		return nil
+	}
+
+	// The timestamp suffix sorts lexically, so the oldest files come first
+	matches, err := filepath.Glob(b.path + ".*")
+	if err != nil {
+		return err
+	}
+	var rotated []string
+	for _, m := range matches {
+		if _, err := time.Parse(rotateTimeFormat, strings.TrimPrefix(m, b.path+".")); err == nil {
+			rotated = append(rotated, m)
+		}
+	}
+	sort.Strings(rotated)
+
+	for len(rotated) > b.rotateMaxFiles {
+		if err := os.Remove(rotated[0]); err != nil && !os.IsNotExist(err) {
+			return errwrap.Wrapf("failed to remove rotated audit file: {{err}}", err)
+		}
+		rotated = rotated[1:]
+	}
+
+	return nil
+}
+
 func (b *Backend) Reload(_ context.Context) error {
 	switch b.path {
 	case "stdout", "discard":
@@ -293,3 +451,18 @@ func (b *Backend) Invalidate(_ context.Context) {
 	defer b.saltMutex.Unlock()
 	b.salt = nil
 }
+
+// rotateTimeFormat is the timestamp suffix appended to rotated files
+const rotateTimeFormat = "20060102T150405.000000000Z"
+
+// countingWriter adds the number of bytes written through it to n
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
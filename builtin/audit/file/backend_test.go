@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/vault/audit"
+	"github.com/hashicorp/vault/helper/namespace"
 	"github.com/hashicorp/vault/sdk/helper/salt"
 	"github.com/hashicorp/vault/sdk/logical"
 )
@@ -90,3 +92,113 @@ func TestAuditFile_fileModeExisting(t *testing.T) {
 		t.Fatalf("File mode does not match.")
 	}
 }
+
+func TestAuditFile_rotate(t *testing.T) {
+	path, err := ioutil.TempDir("", "vault-test_audit_file-rotate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	file := filepath.Join(path, "audit.log")
+
+	b, err := Factory(context.Background(), &audit.BackendConfig{
+		SaltConfig: &salt.Config{},
+		SaltView:   &logical.InmemStorage{},
+		Config: map[string]string{
+			"path":             file,
+			"rotate_bytes":     "1",
+			"rotate_max_files": "2",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := &audit.LogInput{
+		Request: &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "secret/foo",
+		},
+	}
+	for i := 0; i < 5; i++ {
+		if err := b.LogRequest(namespace.RootContext(nil), in); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rotated, err := filepath.Glob(file + ".*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rotated) != 2 {
+		t.Fatalf("expected 2 rotated files, got %d: %v", len(rotated), rotated)
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() == 0 {
+		t.Fatal("expected current audit file to have content")
+	}
+}
+
+func TestAuditFile_rotateAfterReopen(t *testing.T) {
+	path, err := ioutil.TempDir("", "vault-test_audit_file-rotate_reopen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	file := filepath.Join(path, "audit.log")
+
+	be, err := Factory(context.Background(), &audit.BackendConfig{
+		SaltConfig: &salt.Config{},
+		SaltView:   &logical.InmemStorage{},
+		Config: map[string]string{
+			"path":            file,
+			"rotate_duration": "1h",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := be.(*Backend)
+
+	in := &audit.LogInput{
+		Request: &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "secret/foo",
+		},
+	}
+	if err := b.LogRequest(namespace.RootContext(nil), in); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-opening the file repeatedly does not reset its age
+	openedAt := time.Now().Add(-2 * time.Hour)
+	b.openedAt = openedAt
+	for i := 0; i < 2; i++ {
+		if err := b.Reload(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if !b.openedAt.Equal(openedAt) {
+			t.Fatalf("expected open time %v to be kept, got %v", openedAt, b.openedAt)
+		}
+	}
+
+	if err := b.LogRequest(namespace.RootContext(nil), in); err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := filepath.Glob(file + ".*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rotated) != 1 {
+		t.Fatalf("expected 1 rotated file, got %d: %v", len(rotated), rotated)
+	}
+	if time.Since(b.openedAt) > time.Minute {
+		t.Fatalf("expected open time to be reset by rotation, got %v", b.openedAt)
+	}
+}
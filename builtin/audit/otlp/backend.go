@@ -0,0 +1,272 @@
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/vault/audit"
+	"github.com/hashicorp/vault/sdk/helper/parseutil"
+	"github.com/hashicorp/vault/sdk/helper/salt"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// defaultLogsPath is the path OTLP/HTTP collectors accept log records on
+const defaultLogsPath = "/v1/logs"
+
+// OTLP severity number for INFO records
+const severityInfo = 9
+
+func Factory(ctx context.Context, conf *audit.BackendConfig) (audit.Backend, error) {
+	if conf.SaltConfig == nil {
+		return nil, fmt.Errorf("nil salt config")
+	}
+	if conf.SaltView == nil {
+		return nil, fmt.Errorf("nil salt view")
+	}
+
+	endpoint, ok := conf.Config["endpoint"]
+	if !ok {
+		return nil, fmt.Errorf("endpoint is required")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("endpoint must be an http or https URL")
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = defaultLogsPath
+	}
+
+	timeout, ok := conf.Config["timeout"]
+	if !ok {
+		timeout = "5s"
+	}
+	timeoutDuration, err := parseutil.ParseDurationSecond(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName, ok := conf.Config["service_name"]
+	if !ok {
+		serviceName = "vault"
+	}
+
+	// Check if hashing of accessor is disabled
+	hmacAccessor := true
+	if hmacAccessorRaw, ok := conf.Config["hmac_accessor"]; ok {
+		value, err := strconv.ParseBool(hmacAccessorRaw)
+		if err != nil {
+			return nil, err
+		}
+		hmacAccessor = value
+	}
+
+	// Check if raw logging is enabled
+	logRaw := false
+	if raw, ok := conf.Config["log_raw"]; ok {
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, err
+		}
+		logRaw = b
+	}
+
+	// Check if list responses or large response data should be elided
+	elideListResponses, maxResponseDataSize, err := audit.ParseResponseElision(conf.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	client := cleanhttp.DefaultPooledClient()
+	client.Timeout = timeoutDuration
+
+	b := &Backend{
+		saltConfig: conf.SaltConfig,
+		saltView:   conf.SaltView,
+		formatConfig: audit.FormatterConfig{
+			Raw:                 logRaw,
+			HMACAccessor:        hmacAccessor,
+			ElideListResponses:  elideListResponses,
+			MaxResponseDataSize: maxResponseDataSize,
+		},
+
+		endpoint:    u.String(),
+		serviceName: serviceName,
+		client:      client,
+	}
+	b.formatter.AuditFormatWriter = &audit.JSONFormatWriter{
+		SaltFunc: b.Salt,
+	}
+
+	return b, nil
+}
+
+// Backend is the audit backend that exports entries as OpenTelemetry log
+// records over OTLP/HTTP.
+type Backend struct {
+	formatter    audit.AuditFormatter
+	formatConfig audit.FormatterConfig
+
+	endpoint    string
+	serviceName string
+	client      *http.Client
+
+	saltMutex  sync.RWMutex
+	salt       *salt.Salt
+	saltConfig *salt.Config
+	saltView   logical.Storage
+}
+
+var _ audit.Backend = (*Backend)(nil)
+
+func (b *Backend) GetHash(ctx context.Context, data string) (string, error) {
+	salt, err := b.Salt(ctx)
+	if err != nil {
+		return "", err
+	}
+	return audit.HashString(salt, data), nil
+}
+
+func (b *Backend) LogRequest(ctx context.Context, in *audit.LogInput) error {
+	var buf bytes.Buffer
+	if err := b.formatter.FormatRequest(ctx, &buf, b.formatConfig, in); err != nil {
+		return err
+	}
+
+	return b.export(ctx, "request", in, buf.Bytes())
+}
+
+func (b *Backend) LogResponse(ctx context.Context, in *audit.LogInput) error {
+	var buf bytes.Buffer
+	if err := b.formatter.FormatResponse(ctx, &buf, b.formatConfig, in); err != nil {
+		return err
+	}
+
+	return b.export(ctx, "response", in, buf.Bytes())
+}
+
+// export wraps the formatted entry in an OTLP log record and sends it to the
+// collector
+func (b *Backend) export(ctx context.Context, entryType string, in *audit.LogInput, entry []byte) error {
+	record := logRecord{
+		TimeUnixNano:   strconv.FormatInt(time.Now().UnixNano(), 10),
+		SeverityNumber: severityInfo,
+		SeverityText:   "INFO",
+		Body:           stringValue(strings.TrimSpace(string(entry))),
+		Attributes: []keyValue{
+			{Key: "vault.audit.type", Value: stringValue(entryType)},
+		},
+	}
+	if in.Request != nil {
+		record.TraceID, record.SpanID = parseTraceParent(in.Request.Headers)
+	}
+
+	payload := exportLogsRequest{
+		ResourceLogs: []resourceLogs{{
+			Resource: resource{
+				Attributes: []keyValue{
+					{Key: "service.name", Value: stringValue(b.serviceName)},
+				},
+			},
+			ScopeLogs: []scopeLogs{{
+				Scope:      scope{Name: "vault.audit"},
+				LogRecords: []logRecord{record},
+			}},
+		}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", b.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// parseTraceParent extracts the trace and span IDs from a W3C traceparent
+// header. Invalid or HMAC'd values are ignored.
+func parseTraceParent(headers map[string][]string) (string, string) {
+	vals := headers["traceparent"]
+	if len(vals) == 0 {
+		return "", ""
+	}
+
+	// version-traceid-parentid-flags
+	parts := strings.Split(strings.TrimSpace(vals[0]), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", ""
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil {
+		return "", ""
+	}
+	if _, err := hex.DecodeString(parts[2]); err != nil {
+		return "", ""
+	}
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return "", ""
+	}
+
+	return strings.ToLower(parts[1]), strings.ToLower(parts[2])
+}
+
+func (b *Backend) Reload(_ context.Context) error {
+	return nil
+}
+
+func (b *Backend) Salt(ctx context.Context) (*salt.Salt, error) {
+	b.saltMutex.RLock()
+	if b.salt != nil {
+		defer b.saltMutex.RUnlock()
+		return b.salt, nil
+	}
+	b.saltMutex.RUnlock()
+	b.saltMutex.Lock()
+	defer b.saltMutex.Unlock()
+	if b.salt != nil {
+		return b.salt, nil
+	}
+	salt, err := salt.NewSalt(ctx, b.saltView, b.saltConfig)
+	if err != nil {
+		return nil, err
+	}
+	b.salt = salt
+	return salt, nil
+}
+
+func (b *Backend) Invalidate(_ context.Context) {
+	b.saltMutex.Lock()
+	defer b.saltMutex.Unlock()
+	b.salt = nil
+}
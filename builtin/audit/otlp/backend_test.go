@@ -0,0 +1,145 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/audit"
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/helper/salt"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func TestAuditOTLP_export(t *testing.T) {
+	received := make(chan exportLogsRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != defaultLogsPath {
+			t.Errorf("bad path: %s", r.URL.Path)
+		}
+		var payload exportLogsRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		received <- payload
+	}))
+	defer srv.Close()
+
+	b, err := Factory(context.Background(), &audit.BackendConfig{
+		SaltConfig: &salt.Config{},
+		SaltView:   &logical.InmemStorage{},
+		Config: map[string]string{
+			"endpoint":     srv.URL,
+			"service_name": "vault-test",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := &audit.LogInput{
+		Request: &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "secret/foo",
+			Headers: map[string][]string{
+				"traceparent": []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			},
+		},
+	}
+	if err := b.LogRequest(namespace.RootContext(nil), in); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := <-received
+	if len(payload.ResourceLogs) != 1 || len(payload.ResourceLogs[0].ScopeLogs) != 1 {
+		t.Fatalf("bad: %#v", payload)
+	}
+	if v := payload.ResourceLogs[0].Resource.Attributes[0].Value.StringValue; v != "vault-test" {
+		t.Fatalf("bad service name: %q", v)
+	}
+	records := payload.ResourceLogs[0].ScopeLogs[0].LogRecords
+	if len(records) != 1 {
+		t.Fatalf("bad: %#v", records)
+	}
+	record := records[0]
+	if record.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || record.SpanID != "00f067aa0ba902b7" {
+		t.Fatalf("bad trace context: %q %q", record.TraceID, record.SpanID)
+	}
+	if !strings.Contains(record.Body.StringValue, `"path":"secret/foo"`) {
+		t.Fatalf("bad body: %s", record.Body.StringValue)
+	}
+}
+
+func TestAuditOTLP_badConfig(t *testing.T) {
+	cases := map[string]map[string]string{
+		"missing endpoint": {},
+		"bad scheme":       {"endpoint": "tcp://127.0.0.1:4318"},
+		"bad timeout":      {"endpoint": "http://127.0.0.1:4318", "timeout": "soon"},
+		"bad url":          {"endpoint": "http://[::1"},
+		"bad hmac":         {"endpoint": "http://127.0.0.1:4318", "hmac_accessor": "maybe"},
+		"bad log_raw":      {"endpoint": "http://127.0.0.1:4318", "log_raw": "maybe"},
+	}
+	for name, conf := range cases {
+		_, err := Factory(context.Background(), &audit.BackendConfig{
+			SaltConfig: &salt.Config{},
+			SaltView:   &logical.InmemStorage{},
+			Config:     conf,
+		})
+		if err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestAuditOTLP_factoryDefaults(t *testing.T) {
+	b, err := Factory(context.Background(), &audit.BackendConfig{
+		SaltConfig: &salt.Config{},
+		SaltView:   &logical.InmemStorage{},
+		Config: map[string]string{
+			"endpoint": "https://collector.example.com:4318",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend := b.(*Backend)
+	if backend.endpoint != "https://collector.example.com:4318"+defaultLogsPath {
+		t.Fatalf("bad endpoint: %s", backend.endpoint)
+	}
+	if backend.serviceName != "vault" {
+		t.Fatalf("bad service name: %s", backend.serviceName)
+	}
+	if backend.client.Timeout != 5*time.Second {
+		t.Fatalf("bad timeout: %s", backend.client.Timeout)
+	}
+	if !backend.formatConfig.HMACAccessor || backend.formatConfig.Raw {
+		t.Fatalf("bad format config: %#v", backend.formatConfig)
+	}
+
+	// Nil salt configuration is rejected
+	if _, err := Factory(context.Background(), &audit.BackendConfig{
+		SaltView: &logical.InmemStorage{},
+		Config:   map[string]string{"endpoint": "http://127.0.0.1:4318"},
+	}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestParseTraceParent(t *testing.T) {
+	cases := map[string][2]string{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": {"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"},
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01": {"", ""},
+		"hmac-sha256:abcdef": {"", ""},
+	}
+	for header, expected := range cases {
+		traceID, spanID := parseTraceParent(map[string][]string{"traceparent": {header}})
+		if traceID != expected[0] || spanID != expected[1] {
+			t.Fatalf("%s: bad: %q %q", header, traceID, spanID)
+		}
+	}
+}
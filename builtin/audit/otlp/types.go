@@ -0,0 +1,49 @@
+package otlp
+
+// The types below mirror the JSON encoding of the OTLP
+// ExportLogsServiceRequest message, limited to the fields Vault sets.
+
+type exportLogsRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type logRecord struct {
+	TimeUnixNano   string     `json:"timeUnixNano"`
+	SeverityNumber int        `json:"severityNumber"`
+	SeverityText   string     `json:"severityText"`
+	Body           anyValue   `json:"body"`
+	Attributes     []keyValue `json:"attributes,omitempty"`
+	TraceID        string     `json:"traceId,omitempty"`
+	SpanID         string     `json:"spanId,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func stringValue(s string) anyValue {
+	return anyValue{StringValue: s}
+}
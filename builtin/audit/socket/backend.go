@@ -43,6 +43,28 @@ func Factory(ctx context.Context, conf *audit.BackendConfig) (audit.Backend, err
 		return nil, err
 	}
 
+	// The dial timeout defaults to the write timeout so that an unreachable
+	// server doesn't block requests for longer than a stalled write would
+	dialDuration := writeDuration
+	if dialTimeout, ok := conf.Config["dial_timeout"]; ok {
+		dialDuration, err = parseutil.ParseDurationSecond(dialTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Number of times to reconnect and retry a failed write before giving up
+	reconnectAttempts := 1
+	if attemptsRaw, ok := conf.Config["reconnect_attempts"]; ok {
+		reconnectAttempts, err = strconv.Atoi(attemptsRaw)
+		if err != nil {
+			return nil, err
+		}
+		if reconnectAttempts < 0 {
+			return nil, fmt.Errorf("reconnect_attempts cannot be negative")
+		}
+	}
+
 	format, ok := conf.Config["format"]
 	if !ok {
 		format = "json"
@@ -73,17 +95,27 @@ func Factory(ctx context.Context, conf *audit.BackendConfig) (audit.Backend, err
 		logRaw = b
 	}
 
+	// Check if list responses or large response data should be elided
+	elideListResponses, maxResponseDataSize, err := audit.ParseResponseElision(conf.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	b := &Backend{
 		saltConfig: conf.SaltConfig,
 		saltView:   conf.SaltView,
 		formatConfig: audit.FormatterConfig{
-			Raw:          logRaw,
-			HMACAccessor: hmacAccessor,
+			Raw:                 logRaw,
+			HMACAccessor:        hmacAccessor,
+			ElideListResponses:  elideListResponses,
+			MaxResponseDataSize: maxResponseDataSize,
 		},
 
-		writeDuration: writeDuration,
-		address:       address,
-		socketType:    socketType,
+		writeDuration:     writeDuration,
+		dialDuration:      dialDuration,
+		reconnectAttempts: reconnectAttempts,
+		address:           address,
+		socketType:        socketType,
 	}
 
 	switch format {
@@ -109,9 +141,11 @@ type Backend struct {
 	formatter    audit.AuditFormatter
 	formatConfig audit.FormatterConfig
 
-	writeDuration time.Duration
-	address       string
-	socketType    string
+	writeDuration     time.Duration
+	dialDuration      time.Duration
+	reconnectAttempts int
+	address           string
+	socketType        string
 
 	sync.Mutex
 
@@ -137,21 +171,7 @@ func (b *Backend) LogRequest(ctx context.Context, in *audit.LogInput) error {
 		return err
 	}
 
-	b.Lock()
-	defer b.Unlock()
-
-	err := b.write(ctx, buf.Bytes())
-	if err != nil {
-		rErr := b.reconnect(ctx)
-		if rErr != nil {
-			err = multierror.Append(err, rErr)
-		} else {
-			// Try once more after reconnecting
-			err = b.write(ctx, buf.Bytes())
-		}
-	}
-
-	return err
+	return b.writeWithReconnect(ctx, buf.Bytes())
 }
 
 func (b *Backend) LogResponse(ctx context.Context, in *audit.LogInput) error {
@@ -160,18 +180,28 @@ func (b *Backend) LogResponse(ctx context.Context, in *audit.LogInput) error {
 		return err
 	}
 
+	return b.writeWithReconnect(ctx, buf.Bytes())
+}
+
+// writeWithReconnect writes the entry, reconnecting and retrying up to the
+// configured number of attempts if the write fails.
+func (b *Backend) writeWithReconnect(ctx context.Context, buf []byte) error {
 	b.Lock()
 	defer b.Unlock()
 
-	err := b.write(ctx, buf.Bytes())
-	if err != nil {
-		rErr := b.reconnect(ctx)
-		if rErr != nil {
+	err := b.write(ctx, buf)
+	for i := 0; err != nil && i < b.reconnectAttempts; i++ {
+		if rErr := b.reconnect(ctx); rErr != nil {
 			err = multierror.Append(err, rErr)
-		} else {
-			// Try once more after reconnecting
-			err = b.write(ctx, buf.Bytes())
+			continue
 		}
+
+		// Try again after reconnecting
+		if wErr := b.write(ctx, buf); wErr != nil {
+			err = multierror.Append(err, wErr)
+			continue
+		}
+		err = nil
 	}
 
 	return err
@@ -203,7 +233,9 @@ func (b *Backend) reconnect(ctx context.Context) error {
 		b.connection = nil
 	}
 
-	dialer := net.Dialer{}
+	dialer := net.Dialer{
+		Timeout: b.dialDuration,
+	}
 	conn, err := dialer.DialContext(ctx, b.socketType, b.address)
 	if err != nil {
 		return err
@@ -0,0 +1,96 @@
+package socket
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/audit"
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/helper/salt"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func TestAuditSocket_reconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+			}(conn)
+		}
+	}()
+
+	b, err := Factory(context.Background(), &audit.BackendConfig{
+		SaltConfig: &salt.Config{},
+		SaltView:   &logical.InmemStorage{},
+		Config: map[string]string{
+			"address":            ln.Addr().String(),
+			"reconnect_attempts": "3",
+			"dial_timeout":       "1s",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := &audit.LogInput{
+		Request: &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "sys/mounts",
+		},
+	}
+
+	ctx := namespace.RootContext(nil)
+	for i := 0; i < 2; i++ {
+		if err := b.LogRequest(ctx, in); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case line := <-lines:
+			if !strings.Contains(line, "sys/mounts") {
+				t.Fatalf("bad: %s", line)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for audit entry")
+		}
+
+		// Drop the connection to force a reconnect on the next write
+		sb := b.(*Backend)
+		sb.Lock()
+		sb.connection.Close()
+		sb.Unlock()
+	}
+}
+
+func TestAuditSocket_badConfig(t *testing.T) {
+	_, err := Factory(context.Background(), &audit.BackendConfig{
+		SaltConfig: &salt.Config{},
+		SaltView:   &logical.InmemStorage{},
+		Config: map[string]string{
+			"address":            "127.0.0.1:9090",
+			"reconnect_attempts": "-1",
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error for negative reconnect_attempts")
+	}
+}
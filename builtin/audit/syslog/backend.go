@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 
 	gsyslog "github.com/hashicorp/go-syslog"
@@ -33,6 +35,16 @@ func Factory(ctx context.Context, conf *audit.BackendConfig) (audit.Backend, err
 		tag = "vault"
 	}
 
+	// Get priority or default to INFO
+	priority := gsyslog.LOG_INFO
+	if priorityRaw, ok := conf.Config["priority"]; ok {
+		p, err := parsePriority(priorityRaw)
+		if err != nil {
+			return nil, err
+		}
+		priority = p
+	}
+
 	format, ok := conf.Config["format"]
 	if !ok {
 		format = "json"
@@ -63,19 +75,40 @@ func Factory(ctx context.Context, conf *audit.BackendConfig) (audit.Backend, err
 		logRaw = b
 	}
 
-	// Get the logger
-	logger, err := gsyslog.NewLogger(gsyslog.LOG_INFO, facility, tag)
+	// Check if list responses or large response data should be elided
+	elideListResponses, maxResponseDataSize, err := audit.ParseResponseElision(conf.Config)
 	if err != nil {
 		return nil, err
 	}
 
+	// Get the logger, dialing the configured syslog server if one was given
+	// rather than the local agent
+	var logger gsyslog.Syslogger
+	if address, ok := conf.Config["address"]; ok && address != "" {
+		network, raddr, err := parseAddress(address)
+		if err != nil {
+			return nil, err
+		}
+		logger, err = gsyslog.DialLogger(network, raddr, priority, facility, tag)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		logger, err = gsyslog.NewLogger(priority, facility, tag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	b := &Backend{
 		logger:     logger,
 		saltConfig: conf.SaltConfig,
 		saltView:   conf.SaltView,
 		formatConfig: audit.FormatterConfig{
-			Raw:          logRaw,
-			HMACAccessor: hmacAccessor,
+			Raw:                 logRaw,
+			HMACAccessor:        hmacAccessor,
+			ElideListResponses:  elideListResponses,
+			MaxResponseDataSize: maxResponseDataSize,
 		},
 	}
 
@@ -95,6 +128,57 @@ func Factory(ctx context.Context, conf *audit.BackendConfig) (audit.Backend, err
 	return b, nil
 }
 
+// parsePriority converts a syslog severity name such as "info" or "LOG_NOTICE"
+// into its priority value.
+func parsePriority(priority string) (gsyslog.Priority, error) {
+	switch strings.TrimPrefix(strings.ToUpper(priority), "LOG_") {
+	case "EMERG":
+		return gsyslog.LOG_EMERG, nil
+	case "ALERT":
+		return gsyslog.LOG_ALERT, nil
+	case "CRIT":
+		return gsyslog.LOG_CRIT, nil
+	case "ERR", "ERROR":
+		return gsyslog.LOG_ERR, nil
+	case "WARNING", "WARN":
+		return gsyslog.LOG_WARNING, nil
+	case "NOTICE":
+		return gsyslog.LOG_NOTICE, nil
+	case "INFO":
+		return gsyslog.LOG_INFO, nil
+	case "DEBUG":
+		return gsyslog.LOG_DEBUG, nil
+	}
+	return 0, fmt.Errorf("unknown syslog priority %q", priority)
+}
+
+// parseAddress splits a syslog server address of the form
+// "<network>://<address>" into its network and address parts. Unix socket
+// addresses use the URL path, e.g. "unixgram:///dev/log".
+func parseAddress(address string) (string, string, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("error parsing syslog address %q: %v", address, err)
+	}
+
+	switch u.Scheme {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+		if u.Host == "" {
+			return "", "", fmt.Errorf("syslog address %q is missing a host", address)
+		}
+		return u.Scheme, u.Host, nil
+	case "unix", "unixgram":
+		if u.Path == "" {
+			return "", "", fmt.Errorf("syslog address %q is missing a socket path", address)
+		}
+		return u.Scheme, u.Path, nil
+	case "":
+		return "", "", fmt.Errorf("syslog address %q is missing a network type", address)
+	default:
+		return "", "", fmt.Errorf("unsupported syslog network type %q", u.Scheme)
+	}
+}
+
 // Backend is the audit backend for the syslog-based audit store.
 type Backend struct {
 	logger gsyslog.Syslogger
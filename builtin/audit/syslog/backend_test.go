@@ -0,0 +1,62 @@
+package syslog
+
+import (
+	"testing"
+
+	gsyslog "github.com/hashicorp/go-syslog"
+)
+
+func TestParseAddress(t *testing.T) {
+	cases := []struct {
+		address string
+		network string
+		raddr   string
+		err     bool
+	}{
+		{"tcp://127.0.0.1:514", "tcp", "127.0.0.1:514", false},
+		{"udp://syslog.example.com:514", "udp", "syslog.example.com:514", false},
+		{"unixgram:///dev/log", "unixgram", "/dev/log", false},
+		{"127.0.0.1:514", "", "", true},
+		{"tcp://", "", "", true},
+		{"unix://", "", "", true},
+		{"http://127.0.0.1:514", "", "", true},
+	}
+
+	for _, tc := range cases {
+		network, raddr, err := parseAddress(tc.address)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("%s: expected error", tc.address)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.address, err)
+		}
+		if network != tc.network || raddr != tc.raddr {
+			t.Fatalf("%s: bad: got %q %q", tc.address, network, raddr)
+		}
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	cases := map[string]gsyslog.Priority{
+		"info":       gsyslog.LOG_INFO,
+		"LOG_NOTICE": gsyslog.LOG_NOTICE,
+		"warn":       gsyslog.LOG_WARNING,
+		"err":        gsyslog.LOG_ERR,
+	}
+	for in, expected := range cases {
+		p, err := parsePriority(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p != expected {
+			t.Fatalf("%s: expected %d, got %d", in, expected, p)
+		}
+	}
+
+	if _, err := parsePriority("loud"); err == nil {
+		t.Fatal("expected error for unknown priority")
+	}
+}
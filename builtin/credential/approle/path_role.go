@@ -13,13 +13,17 @@ import (
 	"github.com/hashicorp/vault/sdk/helper/cidrutil"
 	"github.com/hashicorp/vault/sdk/helper/consts"
 	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/helper/parseutil"
 	"github.com/hashicorp/vault/sdk/helper/policyutil"
 	"github.com/hashicorp/vault/sdk/helper/strutil"
+	"github.com/hashicorp/vault/sdk/helper/tokenutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
 // roleStorageEntry stores all the options that are set on an role
 type roleStorageEntry struct {
+	tokenutil.TokenParams
+
 	// Name of the role. This field is not persisted on disk. After the role is
 	// read out of disk, the sanitized version of name is set in this field for
 	// subsequent use of role name elsewhere.
@@ -33,9 +37,6 @@ type roleStorageEntry struct {
 	// of the role
 	HMACKey string `json:"hmac_key" mapstructure:"hmac_key"`
 
-	// Policies that are to be required by the token to access this role
-	Policies []string `json:"policies" mapstructure:"policies"`
-
 	// Number of times the SecretID generated against this role can be
 	// used to perform login operation
 	SecretIDNumUses int `json:"secret_id_num_uses" mapstructure:"secret_id_num_uses"`
@@ -44,15 +45,6 @@ type roleStorageEntry struct {
 	// SecretID generated against the role will expire
 	SecretIDTTL time.Duration `json:"secret_id_ttl" mapstructure:"secret_id_ttl"`
 
-	// TokenNumUses defines the number of allowed uses of the token issued
-	TokenNumUses int `json:"token_num_uses" mapstructure:"token_num_uses"`
-
-	// Duration before which an issued token must be renewed
-	TokenTTL time.Duration `json:"token_ttl" mapstructure:"token_ttl"`
-
-	// Duration after which an issued token should not be allowed to be renewed
-	TokenMaxTTL time.Duration `json:"token_max_ttl" mapstructure:"token_max_ttl"`
-
 	// A constraint, if set, requires 'secret_id' credential to be presented during login
 	BindSecretID bool `json:"bind_secret_id" mapstructure:"bind_secret_id"`
 
@@ -67,16 +59,6 @@ type roleStorageEntry struct {
 	// A constraint, if set, specifies the CIDR blocks from which logins should be allowed
 	SecretIDBoundCIDRs []string `json:"secret_id_bound_cidrs" mapstructure:"secret_id_bound_cidrs"`
 
-	// A constraint, if set, specifies the CIDR blocks from which token use should be allowed
-	TokenBoundCIDRs []string `json:"token_bound_cidrs" mapstructure:"token_bound_cidrs"`
-
-	// Period, if set, indicates that the token generated using this role
-	// should never expire. The token should be renewed within the duration
-	// specified by this value. The renewal duration will be fixed if the
-	// value is not modified on the role. If the `Period` in the role is modified,
-	// a token will pick up the new value during its next renewal.
-	Period time.Duration `json:"period" mapstructure:"period"`
-
 	// LowerCaseRoleName enforces the lower casing of role names for all the
 	// roles that get created since this field was introduced.
 	LowerCaseRoleName bool `json:"lower_case_role_name" mapstructure:"lower_case_role_name"`
@@ -85,8 +67,11 @@ type roleStorageEntry struct {
 	// differs based on whether the secret IDs are cluster local or not.
 	SecretIDPrefix string `json:"secret_id_prefix" mapstructure:"secret_id_prefix"`
 
-	// TokenType is the type of token to generate
-	TokenType string `json:"token_type" mapstructure:"token_type"`
+	// Deprecated: use TokenPolicies instead
+	Policies []string `json:"policies" mapstructure:"policies"`
+
+	// Deprecated: use TokenPeriod instead
+	Period time.Duration `json:"period" mapstructure:"period"`
 }
 
 // roleIDStorageEntry represents the reverse mapping from RoleID to Role
@@ -116,105 +101,84 @@ type roleIDStorageEntry struct {
 // role/<role_name>/secret-id-accessor/lookup - For reading secret_id using accessor
 // role/<role_name>/secret-id-accessor/destroy - For deleting secret_id using accessor
 func rolePaths(b *backend) []*framework.Path {
-	return []*framework.Path{
-		&framework.Path{
-			Pattern: "role/?",
-			Callbacks: map[logical.Operation]framework.OperationFunc{
-				logical.ListOperation: b.pathRoleList,
+	defTokenFields := tokenutil.TokenFields()
+
+	p := &framework.Path{
+		Pattern: "role/" + framework.GenericNameRegex("role_name"),
+		Fields: map[string]*framework.FieldSchema{
+			"role_name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the role.",
 			},
-			HelpSynopsis:    strings.TrimSpace(roleHelp["role-list"][0]),
-			HelpDescription: strings.TrimSpace(roleHelp["role-list"][1]),
-		},
-		&framework.Path{
-			Pattern: "role/" + framework.GenericNameRegex("role_name"),
-			Fields: map[string]*framework.FieldSchema{
-				"role_name": &framework.FieldSchema{
-					Type:        framework.TypeString,
-					Description: "Name of the role.",
-				},
-				"bind_secret_id": &framework.FieldSchema{
-					Type:        framework.TypeBool,
-					Default:     true,
-					Description: "Impose secret_id to be presented when logging in using this role. Defaults to 'true'.",
-				},
-				// Deprecated
-				"bound_cidr_list": &framework.FieldSchema{
-					Type: framework.TypeCommaStringSlice,
-					Description: `Deprecated: Please use "secret_id_bound_cidrs" instead. Comma separated string or list 
+			"bind_secret_id": &framework.FieldSchema{
+				Type:        framework.TypeBool,
+				Default:     true,
+				Description: "Impose secret_id to be presented when logging in using this role. Defaults to 'true'.",
+			},
+			// Deprecated
+			"bound_cidr_list": &framework.FieldSchema{
+				Type: framework.TypeCommaStringSlice,
+				Description: `Deprecated: Please use "secret_id_bound_cidrs" instead. Comma separated string or list 
 of CIDR blocks. If set, specifies the blocks of IP addresses which can perform the login operation.`,
-				},
-				"secret_id_bound_cidrs": &framework.FieldSchema{
-					Type: framework.TypeCommaStringSlice,
-					Description: `Comma separated string or list of CIDR blocks. If set, specifies the blocks of
+			},
+			"secret_id_bound_cidrs": &framework.FieldSchema{
+				Type: framework.TypeCommaStringSlice,
+				Description: `Comma separated string or list of CIDR blocks. If set, specifies the blocks of
 IP addresses which can perform the login operation.`,
-				},
-				"token_bound_cidrs": &framework.FieldSchema{
-					Type: framework.TypeCommaStringSlice,
-					Description: `Comma separated string or list of CIDR blocks. If set, specifies the blocks of
-IP addresses which can use the returned token.`,
-				},
-				"policies": &framework.FieldSchema{
-					Type:        framework.TypeCommaStringSlice,
-					Default:     "default",
-					Description: "Comma separated list of policies on the role.",
-				},
-				"secret_id_num_uses": &framework.FieldSchema{
-					Type: framework.TypeInt,
-					Description: `Number of times a SecretID can access the role, after which the SecretID
+			},
+			"secret_id_num_uses": &framework.FieldSchema{
+				Type: framework.TypeInt,
+				Description: `Number of times a SecretID can access the role, after which the SecretID
 will expire. Defaults to 0 meaning that the the secret_id is of unlimited use.`,
-				},
-				"secret_id_ttl": &framework.FieldSchema{
-					Type: framework.TypeDurationSecond,
-					Description: `Duration in seconds after which the issued SecretID should expire. Defaults
+			},
+			"secret_id_ttl": &framework.FieldSchema{
+				Type: framework.TypeDurationSecond,
+				Description: `Duration in seconds after which the issued SecretID should expire. Defaults
 to 0, meaning no expiration.`,
-				},
-				"token_num_uses": &framework.FieldSchema{
-					Type:        framework.TypeInt,
-					Description: `Number of times issued tokens can be used`,
-				},
-				"token_ttl": &framework.FieldSchema{
-					Type: framework.TypeDurationSecond,
-					Description: `Duration in seconds after which the issued token should expire. Defaults
-to 0, in which case the value will fall back to the system/mount defaults.`,
-				},
-				"token_max_ttl": &framework.FieldSchema{
-					Type: framework.TypeDurationSecond,
-					Description: `Duration in seconds after which the issued token should not be allowed to
-be renewed. Defaults to 0, in which case the value will fall back to the system/mount defaults.`,
-				},
-				"period": &framework.FieldSchema{
-					Type:    framework.TypeDurationSecond,
-					Default: 0,
-					Description: `If set, indicates that the token generated using this role
-should never expire. The token should be renewed within the
-duration specified by this value. At each renewal, the token's
-TTL will be set to the value of this parameter.`,
-				},
-				"role_id": &framework.FieldSchema{
-					Type:        framework.TypeString,
-					Description: "Identifier of the role. Defaults to a UUID.",
-				},
-				"local_secret_ids": &framework.FieldSchema{
-					Type: framework.TypeBool,
-					Description: `If set, the secret IDs generated using this role will be cluster local. This
+			},
+			"policies": &framework.FieldSchema{
+				Type:        framework.TypeCommaStringSlice,
+				Description: tokenutil.DeprecationText("token_policies"),
+				Deprecated:  true,
+			},
+			"period": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: tokenutil.DeprecationText("token_period"),
+				Deprecated:  true,
+			},
+			"role_id": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Identifier of the role. Defaults to a UUID.",
+			},
+			"local_secret_ids": &framework.FieldSchema{
+				Type: framework.TypeBool,
+				Description: `If set, the secret IDs generated using this role will be cluster local. This
 can only be set during role creation and once set, it can't be reset later.`,
-				},
-				"token_type": &framework.FieldSchema{
-					Type:        framework.TypeString,
-					Default:     "default",
-					Description: `The type of token to generate ("service" or "batch"), or "default" to use the default`,
-				},
 			},
-			ExistenceCheck: b.pathRoleExistenceCheck,
+		},
+		ExistenceCheck: b.pathRoleExistenceCheck,
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.CreateOperation: b.pathRoleCreateUpdate,
+			logical.UpdateOperation: b.pathRoleCreateUpdate,
+			logical.ReadOperation:   b.pathRoleRead,
+			logical.DeleteOperation: b.pathRoleDelete,
+		},
+		HelpSynopsis:    strings.TrimSpace(roleHelp["role"][0]),
+		HelpDescription: strings.TrimSpace(roleHelp["role"][1]),
+	}
+
+	tokenutil.AddTokenFields(p.Fields)
+
+	return []*framework.Path{
+		&framework.Path{
+			Pattern: "role/?",
 			Callbacks: map[logical.Operation]framework.OperationFunc{
-				logical.CreateOperation: b.pathRoleCreateUpdate,
-				logical.UpdateOperation: b.pathRoleCreateUpdate,
-				logical.ReadOperation:   b.pathRoleRead,
-				logical.DeleteOperation: b.pathRoleDelete,
+				logical.ListOperation: b.pathRoleList,
 			},
-			HelpSynopsis:    strings.TrimSpace(roleHelp["role"][0]),
-			HelpDescription: strings.TrimSpace(roleHelp["role"][1]),
+			HelpSynopsis:    strings.TrimSpace(roleHelp["role-list"][0]),
+			HelpDescription: strings.TrimSpace(roleHelp["role-list"][1]),
 		},
+		p,
 		&framework.Path{
 			Pattern: "role/" + framework.GenericNameRegex("role_name") + "/local-secret-ids$",
 			Fields: map[string]*framework.FieldSchema{
@@ -238,9 +202,10 @@ can only be set during role creation and once set, it can't be reset later.`,
 				},
 				"policies": &framework.FieldSchema{
 					Type:        framework.TypeCommaStringSlice,
-					Default:     "default",
-					Description: "Comma separated list of policies on the role.",
+					Description: tokenutil.DeprecationText("token_policies"),
+					Deprecated:  true,
 				},
+				"token_policies": defTokenFields["token_policies"],
 			},
 			Callbacks: map[logical.Operation]framework.OperationFunc{
 				logical.UpdateOperation: b.pathRolePoliciesUpdate,
@@ -383,13 +348,11 @@ to 0, meaning no expiration.`,
 					Description: "Name of the role.",
 				},
 				"period": &framework.FieldSchema{
-					Type:    framework.TypeDurationSecond,
-					Default: 0,
-					Description: `If set, indicates that the token generated using this role
-should never expire. The token should be renewed within the
-duration specified by this value. At each renewal, the token's
-TTL will be set to the value of this parameter.`,
+					Type:        framework.TypeDurationSecond,
+					Description: tokenutil.DeprecationText("token_period"),
+					Deprecated:  true,
 				},
+				"token_period": defTokenFields["token_period"],
 			},
 			Callbacks: map[logical.Operation]framework.OperationFunc{
 				logical.UpdateOperation: b.pathRolePeriodUpdate,
@@ -504,6 +467,18 @@ the role.`,
 					Description: `Comma separated string or list of CIDR blocks. If set, specifies the blocks of
 IP addresses which can use the returned token. Should be a subset of the token CIDR blocks listed on the role, if any.`,
 				},
+				"num_uses": &framework.FieldSchema{
+					Type: framework.TypeInt,
+					Description: `Number of times this SecretID can be used, after which the SecretID expires.
+Overrides secret_id_num_uses of the role, and cannot be higher than it unless
+the role allows unlimited uses. A value of zero allows unlimited uses.`,
+				},
+				"ttl": &framework.FieldSchema{
+					Type: framework.TypeDurationSecond,
+					Description: `Duration in seconds after which this SecretID expires. Overrides
+secret_id_ttl of the role, and cannot be higher than it unless the role allows
+an unlimited TTL. A value of zero never expires the SecretID.`,
+				},
 			},
 			Callbacks: map[logical.Operation]framework.OperationFunc{
 				logical.UpdateOperation: b.pathRoleSecretIDUpdate,
@@ -614,6 +589,18 @@ the role.`,
 					Description: `Comma separated string or list of CIDR blocks. If set, specifies the blocks of
 IP addresses which can use the returned token. Should be a subset of the token CIDR blocks listed on the role, if any.`,
 				},
+				"num_uses": &framework.FieldSchema{
+					Type: framework.TypeInt,
+					Description: `Number of times this SecretID can be used, after which the SecretID expires.
+Overrides secret_id_num_uses of the role, and cannot be higher than it unless
+the role allows unlimited uses. A value of zero allows unlimited uses.`,
+				},
+				"ttl": &framework.FieldSchema{
+					Type: framework.TypeDurationSecond,
+					Description: `Duration in seconds after which this SecretID expires. Overrides
+secret_id_ttl of the role, and cannot be higher than it unless the role allows
+an unlimited TTL. A value of zero never expires the SecretID.`,
+				},
 			},
 			Callbacks: map[logical.Operation]framework.OperationFunc{
 				logical.UpdateOperation: b.pathRoleCustomSecretIDUpdate,
@@ -847,6 +834,16 @@ func (b *backend) roleEntry(ctx context.Context, s logical.Storage, roleName str
 		needsUpgrade = true
 	}
 
+	if len(role.TokenPolicies) == 0 && len(role.Policies) > 0 {
+		role.TokenPolicies = role.Policies
+		needsUpgrade = true
+	}
+
+	if role.TokenPeriod == 0 && role.Period > 0 {
+		role.TokenPeriod = role.Period
+		needsUpgrade = true
+	}
+
 	if needsUpgrade && (b.System().LocalMount() || !b.System().ReplicationState().HasState(consts.ReplicationPerformanceSecondary|consts.ReplicationPerformanceStandby)) {
 		entry, err := logical.StorageEntryJSON("role/"+strings.ToLower(roleName), &role)
 		if err != nil {
@@ -949,34 +946,23 @@ func (b *backend) pathRoleCreateUpdate(ctx context.Context, req *logical.Request
 		}
 	}
 
-	if boundCIDRListRaw, ok := data.GetOk("token_bound_cidrs"); ok {
-		role.TokenBoundCIDRs = boundCIDRListRaw.([]string)
+	if err := role.ParseTokenFields(req, data); err != nil {
+		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
 	}
 
-	if len(role.TokenBoundCIDRs) != 0 {
-		valid, err := cidrutil.ValidateCIDRListSlice(role.TokenBoundCIDRs)
-		if err != nil {
-			return nil, errwrap.Wrapf("failed to validate CIDR blocks: {{err}}", err)
+	// Handle upgrade cases
+	{
+		if err := tokenutil.UpgradeValue(data, "policies", "token_policies", &role.Policies, &role.TokenPolicies); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
 		}
-		if !valid {
-			return logical.ErrorResponse("invalid CIDR blocks"), nil
-		}
-	}
 
-	if policiesRaw, ok := data.GetOk("policies"); ok {
-		role.Policies = policyutil.ParsePolicies(policiesRaw)
-	} else if req.Operation == logical.CreateOperation {
-		role.Policies = policyutil.ParsePolicies(data.Get("policies"))
+		if err := tokenutil.UpgradeValue(data, "period", "token_period", &role.Period, &role.TokenPeriod); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
 	}
 
-	periodRaw, ok := data.GetOk("period")
-	if ok {
-		role.Period = time.Second * time.Duration(periodRaw.(int))
-	} else if req.Operation == logical.CreateOperation {
-		role.Period = time.Second * time.Duration(data.Get("period").(int))
-	}
-	if role.Period > b.System().MaxLeaseTTL() {
-		return logical.ErrorResponse(fmt.Sprintf("period of %q is greater than the backend's maximum lease TTL of %q", role.Period.String(), b.System().MaxLeaseTTL().String())), nil
+	if role.TokenPeriod > b.System().MaxLeaseTTL() {
+		return logical.ErrorResponse(fmt.Sprintf("period of %q is greater than the backend's maximum lease TTL of %q", role.TokenPeriod.String(), b.System().MaxLeaseTTL().String())), nil
 	}
 
 	if secretIDNumUsesRaw, ok := data.GetOk("secret_id_num_uses"); ok {
@@ -994,58 +980,6 @@ func (b *backend) pathRoleCreateUpdate(ctx context.Context, req *logical.Request
 		role.SecretIDTTL = time.Second * time.Duration(data.Get("secret_id_ttl").(int))
 	}
 
-	if tokenNumUsesRaw, ok := data.GetOk("token_num_uses"); ok {
-		role.TokenNumUses = tokenNumUsesRaw.(int)
-	} else if req.Operation == logical.CreateOperation {
-		role.TokenNumUses = data.Get("token_num_uses").(int)
-	}
-	if role.TokenNumUses < 0 {
-		return logical.ErrorResponse("token_num_uses cannot be negative"), nil
-	}
-
-	if tokenTTLRaw, ok := data.GetOk("token_ttl"); ok {
-		role.TokenTTL = time.Second * time.Duration(tokenTTLRaw.(int))
-	} else if req.Operation == logical.CreateOperation {
-		role.TokenTTL = time.Second * time.Duration(data.Get("token_ttl").(int))
-	}
-
-	if tokenMaxTTLRaw, ok := data.GetOk("token_max_ttl"); ok {
-		role.TokenMaxTTL = time.Second * time.Duration(tokenMaxTTLRaw.(int))
-	} else if req.Operation == logical.CreateOperation {
-		role.TokenMaxTTL = time.Second * time.Duration(data.Get("token_max_ttl").(int))
-	}
-
-	tokenType := role.TokenType
-	if tokenTypeRaw, ok := data.GetOk("token_type"); ok {
-		tokenType = tokenTypeRaw.(string)
-		switch tokenType {
-		case "":
-			tokenType = "default"
-		case "service", "batch", "default":
-		default:
-			return logical.ErrorResponse(fmt.Sprintf("invalid 'token_type' value %q", tokenType)), nil
-		}
-	} else if req.Operation == logical.CreateOperation {
-		tokenType = data.Get("token_type").(string)
-	}
-	role.TokenType = tokenType
-
-	if role.TokenType == "batch" {
-		if role.Period != 0 {
-			return logical.ErrorResponse("'token_type' cannot be 'batch' when role is set to generate periodic tokens"), nil
-		}
-		if role.TokenNumUses != 0 {
-			return logical.ErrorResponse("'token_type' cannot be 'batch' when role is set to generate tokens with limited use count"), nil
-		}
-	}
-
-	// Check that the TokenTTL value provided is less than the TokenMaxTTL.
-	// Sanitizing the TTL and MaxTTL is not required now and can be performed
-	// at credential issue time.
-	if role.TokenMaxTTL > time.Duration(0) && role.TokenTTL > role.TokenMaxTTL {
-		return logical.ErrorResponse("token_ttl should not be greater than token_max_ttl"), nil
-	}
-
 	var resp *logical.Response
 	if role.TokenMaxTTL > b.System().MaxLeaseTTL() {
 		resp = &logical.Response{}
@@ -1084,16 +1018,17 @@ func (b *backend) pathRoleRead(ctx context.Context, req *logical.Request, data *
 		// and its associated warning below.
 		"bound_cidr_list":       role.SecretIDBoundCIDRs,
 		"secret_id_bound_cidrs": role.SecretIDBoundCIDRs,
-		"token_bound_cidrs":     role.TokenBoundCIDRs,
-		"period":                role.Period / time.Second,
-		"policies":              role.Policies,
 		"secret_id_num_uses":    role.SecretIDNumUses,
 		"secret_id_ttl":         role.SecretIDTTL / time.Second,
-		"token_max_ttl":         role.TokenMaxTTL / time.Second,
-		"token_num_uses":        role.TokenNumUses,
-		"token_ttl":             role.TokenTTL / time.Second,
 		"local_secret_ids":      false,
-		"token_type":            role.TokenType,
+	}
+	role.PopulateTokenData(respData)
+
+	if len(role.Policies) > 0 {
+		respData["policies"] = respData["token_policies"]
+	}
+	if role.Period > 0 {
+		respData["period"] = int64(role.Period.Seconds())
 	}
 
 	if role.SecretIDPrefix == secretIDLocalPrefix {
@@ -1465,21 +1400,29 @@ func (b *backend) pathRoleBoundCIDRUpdate(ctx context.Context, req *logical.Requ
 	var cidrs []string
 	if cidrsIfc, ok := data.GetFirst("secret_id_bound_cidrs", "bound_cidr_list"); ok {
 		cidrs = cidrsIfc.([]string)
+		if len(cidrs) == 0 {
+			return logical.ErrorResponse("missing bound_cidr_list"), nil
+		}
+		valid, err := cidrutil.ValidateCIDRListSlice(cidrs)
+		if err != nil {
+			return nil, errwrap.Wrapf("failed to validate CIDR blocks: {{err}}", err)
+		}
+		if !valid {
+			return logical.ErrorResponse("failed to validate CIDR blocks"), nil
+		}
 		role.SecretIDBoundCIDRs = cidrs
 	} else if cidrsIfc, ok := data.GetOk("token_bound_cidrs"); ok {
 		cidrs = cidrsIfc.([]string)
-		role.TokenBoundCIDRs = cidrs
-	}
-	if len(cidrs) == 0 {
+		if len(cidrs) == 0 {
+			return logical.ErrorResponse("missing token_bound_cidrs"), nil
+		}
+		role.TokenBoundCIDRs, err = parseutil.ParseAddrs(cidrs)
+		if err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	} else {
 		return logical.ErrorResponse("missing bound_cidr_list"), nil
 	}
-	valid, err := cidrutil.ValidateCIDRListSlice(cidrs)
-	if err != nil {
-		return nil, errwrap.Wrapf("failed to validate CIDR blocks: {{err}}", err)
-	}
-	if !valid {
-		return logical.ErrorResponse("failed to validate CIDR blocks"), nil
-	}
 
 	return nil, b.setRoleEntry(ctx, req.Storage, role.name, role, "")
 }
@@ -1523,7 +1466,7 @@ func (b *backend) pathRoleFieldRead(ctx context.Context, req *logical.Request, d
 		case "token_bound_cidrs":
 			return &logical.Response{
 				Data: map[string]interface{}{
-					"token_bound_cidrs": role.TokenBoundCIDRs,
+					"token_bound_cidrs": cidrStrings(role.TokenBoundCIDRs),
 				},
 			}, nil
 		case "bound_cidr_list":
@@ -1566,7 +1509,7 @@ func (b *backend) pathRoleBoundCIDRDelete(ctx context.Context, req *logical.Requ
 	case "secret_id_bound_cidrs":
 		role.SecretIDBoundCIDRs = data.GetDefaultOrZero("secret_id_bound_cidrs").([]string)
 	case "token_bound_cidrs":
-		role.TokenBoundCIDRs = data.GetDefaultOrZero("token_bound_cidrs").([]string)
+		role.TokenBoundCIDRs = nil
 	}
 	return nil, b.setRoleEntry(ctx, req.Storage, roleName, role, "")
 }
@@ -1706,12 +1649,19 @@ func (b *backend) pathRolePoliciesUpdate(ctx context.Context, req *logical.Reque
 		return nil, logical.ErrUnsupportedPath
 	}
 
-	policiesRaw, ok := data.GetOk("policies")
+	policiesRaw, ok := data.GetOk("token_policies")
 	if !ok {
-		return logical.ErrorResponse("missing policies"), nil
+		policiesRaw, ok = data.GetOk("policies")
+		if !ok {
+			return logical.ErrorResponse("missing token_policies"), nil
+		}
 	}
 
-	role.Policies = policyutil.ParsePolicies(policiesRaw)
+	role.TokenPolicies = policyutil.ParsePolicies(policiesRaw)
+
+	if err := tokenutil.UpgradeValue(data, "policies", "token_policies", &role.Policies, &role.TokenPolicies); err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
 
 	return nil, b.setRoleEntry(ctx, req.Storage, role.name, role, "")
 }
@@ -1734,10 +1684,20 @@ func (b *backend) pathRolePoliciesRead(ctx context.Context, req *logical.Request
 		return nil, nil
 	}
 
+	p := role.TokenPolicies
+	if p == nil {
+		p = []string{}
+	}
+	d := map[string]interface{}{
+		"token_policies": p,
+	}
+
+	if len(role.Policies) > 0 {
+		d["policies"] = role.Policies
+	}
+
 	return &logical.Response{
-		Data: map[string]interface{}{
-			"policies": role.Policies,
-		},
+		Data: d,
 	}, nil
 }
 
@@ -1759,7 +1719,8 @@ func (b *backend) pathRolePoliciesDelete(ctx context.Context, req *logical.Reque
 		return nil, nil
 	}
 
-	role.Policies = []string{}
+	role.TokenPolicies = nil
+	role.Policies = nil
 
 	return nil, b.setRoleEntry(ctx, req.Storage, role.name, role, "")
 }
@@ -1985,15 +1946,24 @@ func (b *backend) pathRolePeriodUpdate(ctx context.Context, req *logical.Request
 		return nil, logical.ErrUnsupportedPath
 	}
 
-	if periodRaw, ok := data.GetOk("period"); ok {
-		role.Period = time.Second * time.Duration(periodRaw.(int))
-		if role.Period > b.System().MaxLeaseTTL() {
-			return logical.ErrorResponse(fmt.Sprintf("period of %q is greater than the backend's maximum lease TTL of %q", role.Period.String(), b.System().MaxLeaseTTL().String())), nil
+	periodRaw, ok := data.GetOk("token_period")
+	if !ok {
+		periodRaw, ok = data.GetOk("period")
+		if !ok {
+			return logical.ErrorResponse("missing period"), nil
 		}
-		return nil, b.setRoleEntry(ctx, req.Storage, role.name, role, "")
-	} else {
-		return logical.ErrorResponse("missing period"), nil
 	}
+
+	role.TokenPeriod = time.Second * time.Duration(periodRaw.(int))
+	if role.TokenPeriod > b.System().MaxLeaseTTL() {
+		return logical.ErrorResponse(fmt.Sprintf("period of %q is greater than the backend's maximum lease TTL of %q", role.TokenPeriod.String(), b.System().MaxLeaseTTL().String())), nil
+	}
+
+	if err := tokenutil.UpgradeValue(data, "period", "token_period", &role.Period, &role.TokenPeriod); err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	return nil, b.setRoleEntry(ctx, req.Storage, role.name, role, "")
 }
 
 func (b *backend) pathRolePeriodRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
@@ -2014,10 +1984,16 @@ func (b *backend) pathRolePeriodRead(ctx context.Context, req *logical.Request,
 		return nil, nil
 	}
 
+	d := map[string]interface{}{
+		"token_period": int64(role.TokenPeriod.Seconds()),
+	}
+
+	if role.Period > 0 {
+		d["period"] = role.Period / time.Second
+	}
+
 	return &logical.Response{
-		Data: map[string]interface{}{
-			"period": role.Period / time.Second,
-		},
+		Data: d,
 	}, nil
 }
 
@@ -2039,7 +2015,8 @@ func (b *backend) pathRolePeriodDelete(ctx context.Context, req *logical.Request
 		return nil, nil
 	}
 
-	role.Period = time.Second * time.Duration(data.GetDefaultOrZero("period").(int))
+	role.TokenPeriod = 0
+	role.Period = 0
 
 	return nil, b.setRoleEntry(ctx, req.Storage, role.name, role, "")
 }
@@ -2338,13 +2315,37 @@ func (b *backend) handleRoleSecretIDCommon(ctx context.Context, req *logical.Req
 		}
 	}
 	// Ensure that the token CIDRs on the secret ID are a subset of that of role's
-	if err := verifyCIDRRoleSecretIDSubset(secretIDTokenCIDRs, role.TokenBoundCIDRs); err != nil {
+	if err := verifyCIDRRoleSecretIDSubset(secretIDTokenCIDRs, cidrStrings(role.TokenBoundCIDRs)); err != nil {
 		return nil, err
 	}
 
+	// The use count and TTL of the secret ID default to the role's values
+	// and may only be tightened
+	numUses := role.SecretIDNumUses
+	if numUsesRaw, ok := data.GetOk("num_uses"); ok {
+		numUses = numUsesRaw.(int)
+		switch {
+		case numUses < 0:
+			return logical.ErrorResponse("num_uses cannot be negative"), nil
+		case role.SecretIDNumUses > 0 && (numUses == 0 || numUses > role.SecretIDNumUses):
+			return logical.ErrorResponse("num_uses cannot be higher than the role's secret_id_num_uses"), nil
+		}
+	}
+
+	ttl := role.SecretIDTTL
+	if ttlRaw, ok := data.GetOk("ttl"); ok {
+		ttl = time.Duration(ttlRaw.(int)) * time.Second
+		switch {
+		case ttl < 0:
+			return logical.ErrorResponse("ttl cannot be negative"), nil
+		case role.SecretIDTTL > 0 && (ttl == 0 || ttl > role.SecretIDTTL):
+			return logical.ErrorResponse("ttl cannot be longer than the role's secret_id_ttl"), nil
+		}
+	}
+
 	secretIDStorage := &secretIDStorageEntry{
-		SecretIDNumUses: role.SecretIDNumUses,
-		SecretIDTTL:     role.SecretIDTTL,
+		SecretIDNumUses: numUses,
+		SecretIDTTL:     ttl,
 		Metadata:        make(map[string]string),
 		CIDRList:        secretIDCIDRs,
 		TokenBoundCIDRs: secretIDTokenCIDRs,
@@ -2362,6 +2363,8 @@ func (b *backend) handleRoleSecretIDCommon(ctx context.Context, req *logical.Req
 		Data: map[string]interface{}{
 			"secret_id":          secretID,
 			"secret_id_accessor": secretIDStorage.SecretIDAccessor,
+			"secret_id_num_uses": secretIDStorage.SecretIDNumUses,
+			"secret_id_ttl":      secretIDStorage.SecretIDTTL / time.Second,
 		},
 	}, nil
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	sockaddr "github.com/hashicorp/go-sockaddr"
 	"github.com/hashicorp/vault/sdk/helper/policyutil"
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/mitchellh/mapstructure"
@@ -1116,6 +1117,62 @@ func TestAppRole_RoleSecretID(t *testing.T) {
 	}
 }
 
+func TestAppRole_SecretIDNumUsesTTLOverride(t *testing.T) {
+	b, storage := createBackendWithStorage(t)
+	createRole(t, b, storage, "role1", "p,q")
+
+	secretIDReq := &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "role/role1/secret-id",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"num_uses": 2,
+			"ttl":      60,
+		},
+	}
+	resp, err := b.HandleRequest(context.Background(), secretIDReq)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err:%v resp:%#v", err, resp)
+	}
+	if resp.Data["secret_id_num_uses"].(int) != 2 || resp.Data["secret_id_ttl"].(time.Duration) != 60 {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	lookupReq := &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "role/role1/secret-id/lookup",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"secret_id": resp.Data["secret_id"],
+		},
+	}
+	resp, err = b.HandleRequest(context.Background(), lookupReq)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err:%v resp:%#v", err, resp)
+	}
+	if resp.Data["secret_id_num_uses"].(int) != 2 || resp.Data["secret_id_ttl"].(time.Duration) != 60 {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	// Overrides cannot loosen the role's limits
+	for _, data := range []map[string]interface{}{
+		{"num_uses": 0},
+		{"num_uses": 11},
+		{"num_uses": -1},
+		{"ttl": 0},
+		{"ttl": 301},
+	} {
+		secretIDReq.Data = data
+		resp, err = b.HandleRequest(context.Background(), secretIDReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp == nil || !resp.IsError() {
+			t.Fatalf("expected error for %v", data)
+		}
+	}
+}
+
 func TestAppRole_RoleCRUD(t *testing.T) {
 	var resp *logical.Response
 	var err error
@@ -1179,6 +1236,14 @@ func TestAppRole_RoleCRUD(t *testing.T) {
 		t.Fatalf("bad:\nexpected:%#v\nactual:%#v\n", expectedStruct, actualStruct)
 	}
 
+	if resp.Data["token_ttl"].(int64) != 400 ||
+		resp.Data["token_max_ttl"].(int64) != 500 ||
+		resp.Data["token_num_uses"].(int) != 600 ||
+		resp.Data["token_type"].(string) != "default" ||
+		!policyutil.EquivalentPolicies(resp.Data["token_policies"].([]string), []string{"p", "q", "r", "s"}) {
+		t.Fatalf("bad: token fields: %#v", resp.Data)
+	}
+
 	roleData = map[string]interface{}{
 		"role_id":            "test_role_id",
 		"policies":           "a,b,c,d",
@@ -1325,10 +1390,11 @@ func TestAppRole_RoleCRUD(t *testing.T) {
 		t.Fatalf("err:%v resp:%#v", err, resp)
 	}
 
-	expectedPolicies := []string{"default"}
-	actualPolicies := resp.Data["policies"].([]string)
-	if !policyutil.EquivalentPolicies(expectedPolicies, actualPolicies) {
-		t.Fatalf("bad: policies: expected:%s actual:%s", expectedPolicies, actualPolicies)
+	if _, ok := resp.Data["policies"]; ok {
+		t.Fatalf("bad: policies: expected deprecated field to be cleared, actual:%#v", resp.Data)
+	}
+	if len(resp.Data["token_policies"].([]string)) != 0 {
+		t.Fatalf("bad: token_policies: expected: empty actual:%s", resp.Data["token_policies"].([]string))
 	}
 
 	// RUD for secret-id-num-uses field
@@ -1476,8 +1542,8 @@ func TestAppRole_RoleCRUD(t *testing.T) {
 		t.Fatalf("err:%v resp:%#v", err, resp)
 	}
 
-	if resp.Data["period"].(time.Duration) != 9001 {
-		t.Fatalf("bad: period: expected:9001 actual:%d\n", resp.Data["9001"].(time.Duration))
+	if resp.Data["period"].(time.Duration) != 9001 || resp.Data["token_period"].(int64) != 9001 {
+		t.Fatalf("bad: period: expected:9001 actual:%#v\n", resp.Data)
 	}
 	roleReq.Operation = logical.DeleteOperation
 	resp, err = b.HandleRequest(context.Background(), roleReq)
@@ -1491,7 +1557,7 @@ func TestAppRole_RoleCRUD(t *testing.T) {
 		t.Fatalf("err:%v resp:%#v", err, resp)
 	}
 
-	if resp.Data["period"].(time.Duration) != 0 {
+	if _, ok := resp.Data["period"]; ok || resp.Data["token_period"].(int64) != 0 {
 		t.Fatalf("expected value to be reset")
 	}
 
@@ -1658,6 +1724,17 @@ func TestAppRole_RoleWithTokenBoundCIDRsCRUD(t *testing.T) {
 		t.Fatalf("bad:\nexpected:%#v\nactual:%#v\n", expectedStruct, actualStruct)
 	}
 
+	if resp.Data["token_ttl"].(int64) != 400 ||
+		resp.Data["token_max_ttl"].(int64) != 500 ||
+		resp.Data["token_num_uses"].(int) != 600 ||
+		resp.Data["token_type"].(string) != "default" ||
+		!policyutil.EquivalentPolicies(resp.Data["token_policies"].([]string), []string{"p", "q", "r", "s"}) {
+		t.Fatalf("bad: token fields: %#v", resp.Data)
+	}
+	if len(resp.Data["token_bound_cidrs"].([]*sockaddr.SockAddrMarshaler)) != 2 {
+		t.Fatalf("bad: token_bound_cidrs: %#v", resp.Data["token_bound_cidrs"])
+	}
+
 	roleData = map[string]interface{}{
 		"role_id":            "test_role_id",
 		"policies":           "a,b,c,d",
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/errwrap"
+	sockaddr "github.com/hashicorp/go-sockaddr"
 	uuid "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/sdk/helper/cidrutil"
 	"github.com/hashicorp/vault/sdk/helper/locksutil"
@@ -87,6 +88,21 @@ func verifyCIDRRoleSecretIDSubset(secretIDCIDRs []string, roleBoundCIDRList []st
 	return nil
 }
 
+// cidrStrings converts the token bound CIDRs of a role back into CIDR
+// notation, including the mask of single addresses, so that they can be
+// compared using cidrutil.
+func cidrStrings(addrs []*sockaddr.SockAddrMarshaler) []string {
+	cidrs := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if ip := sockaddr.ToIPAddr(addr.SockAddr); ip != nil {
+			cidrs = append(cidrs, fmt.Sprintf("%s/%d", (*ip).NetIP().String(), (*ip).Maskbits()))
+			continue
+		}
+		cidrs = append(cidrs, addr.String())
+	}
+	return cidrs
+}
+
 // Creates a SHA256 HMAC of the given 'value' using the given 'key' and returns
 // a hex encoded string.
 func createHMAC(key, value string) (string, error) {
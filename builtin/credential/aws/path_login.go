@@ -24,6 +24,7 @@ import (
 	uuid "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/helper/awsutil"
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/cidrutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	"github.com/hashicorp/vault/sdk/helper/strutil"
 	"github.com/hashicorp/vault/sdk/logical"
@@ -609,6 +610,16 @@ func (b *backend) pathLoginUpdateEc2(ctx context.Context, req *logical.Request,
 		return logical.ErrorResponse(fmt.Sprintf("auth method ec2 not allowed for role %s", roleName)), nil
 	}
 
+	if len(roleEntry.TokenBoundCIDRs) > 0 {
+		if req.Connection == nil {
+			b.Logger().Warn("token bound CIDRs found but no connection information available for validation")
+			return nil, logical.ErrPermissionDenied
+		}
+		if !cidrutil.RemoteAddrIsOk(req.Connection.RemoteAddr, roleEntry.TokenBoundCIDRs) {
+			return nil, logical.ErrPermissionDenied
+		}
+	}
+
 	identityConfigEntry, err := identityConfigEntry(ctx, req.Storage)
 	if err != nil {
 		return nil, err
@@ -729,14 +740,14 @@ func (b *backend) pathLoginUpdateEc2(ctx context.Context, req *logical.Request,
 	// attacks.
 	shortestMaxTTL := b.System().MaxLeaseTTL()
 	longestMaxTTL := b.System().MaxLeaseTTL()
-	if roleEntry.MaxTTL > time.Duration(0) && roleEntry.MaxTTL < shortestMaxTTL {
-		shortestMaxTTL = roleEntry.MaxTTL
+	if roleEntry.TokenMaxTTL > time.Duration(0) && roleEntry.TokenMaxTTL < shortestMaxTTL {
+		shortestMaxTTL = roleEntry.TokenMaxTTL
 	}
-	if roleEntry.MaxTTL > longestMaxTTL {
-		longestMaxTTL = roleEntry.MaxTTL
+	if roleEntry.TokenMaxTTL > longestMaxTTL {
+		longestMaxTTL = roleEntry.TokenMaxTTL
 	}
 
-	policies := roleEntry.Policies
+	policies := roleEntry.TokenPolicies
 	rTagMaxTTL := time.Duration(0)
 	var roleTagResp *roleTagLoginResponse
 	if roleEntry.RoleTag != "" {
@@ -812,28 +823,26 @@ func (b *backend) pathLoginUpdateEc2(ctx context.Context, req *logical.Request,
 		return nil, err
 	}
 
-	resp := &logical.Response{
-		Auth: &logical.Auth{
-			Period:   roleEntry.Period,
-			Policies: policies,
-			Metadata: map[string]string{
-				"instance_id":      identityDocParsed.InstanceID,
-				"region":           identityDocParsed.Region,
-				"account_id":       identityDocParsed.AccountID,
-				"role_tag_max_ttl": rTagMaxTTL.String(),
-				"role":             roleName,
-				"ami_id":           identityDocParsed.AmiID,
-			},
-			LeaseOptions: logical.LeaseOptions{
-				Renewable: true,
-				TTL:       roleEntry.TTL,
-				MaxTTL:    shortestMaxTTL,
-			},
-			Alias: &logical.Alias{
-				Name: identityAlias,
-			},
+	auth := &logical.Auth{
+		Metadata: map[string]string{
+			"instance_id":      identityDocParsed.InstanceID,
+			"region":           identityDocParsed.Region,
+			"account_id":       identityDocParsed.AccountID,
+			"role_tag_max_ttl": rTagMaxTTL.String(),
+			"role":             roleName,
+			"ami_id":           identityDocParsed.AmiID,
+		},
+		Alias: &logical.Alias{
+			Name: identityAlias,
 		},
 	}
+	roleEntry.PopulateTokenAuth(auth)
+	auth.Policies = policies
+	auth.MaxTTL = shortestMaxTTL
+
+	resp := &logical.Response{
+		Auth: auth,
+	}
 
 	// Return the nonce only if reauthentication is allowed and if the nonce
 	// was not supplied by the user.
@@ -909,7 +918,7 @@ func (b *backend) handleRoleTagLogin(ctx context.Context, s logical.Storage, rol
 	}
 
 	// Ensure that the policies on the RoleTag is a subset of policies on the role
-	if !strutil.StrListSubset(roleEntry.Policies, rTag.Policies) {
+	if !strutil.StrListSubset(roleEntry.TokenPolicies, rTag.Policies) {
 		return nil, fmt.Errorf("policies on the role tag must be subset of policies on the role")
 	}
 
@@ -1039,9 +1048,9 @@ func (b *backend) pathLoginRenewIam(ctx context.Context, req *logical.Request, d
 	}
 
 	resp := &logical.Response{Auth: req.Auth}
-	resp.Auth.TTL = roleEntry.TTL
-	resp.Auth.MaxTTL = roleEntry.MaxTTL
-	resp.Auth.Period = roleEntry.Period
+	resp.Auth.TTL = roleEntry.TokenTTL
+	resp.Auth.MaxTTL = roleEntry.TokenMaxTTL
+	resp.Auth.Period = roleEntry.TokenPeriod
 	return resp, nil
 }
 
@@ -1098,11 +1107,11 @@ func (b *backend) pathLoginRenewEc2(ctx context.Context, req *logical.Request, d
 	// Re-evaluate the maxTTL bounds
 	shortestMaxTTL := b.System().MaxLeaseTTL()
 	longestMaxTTL := b.System().MaxLeaseTTL()
-	if roleEntry.MaxTTL > time.Duration(0) && roleEntry.MaxTTL < shortestMaxTTL {
-		shortestMaxTTL = roleEntry.MaxTTL
+	if roleEntry.TokenMaxTTL > time.Duration(0) && roleEntry.TokenMaxTTL < shortestMaxTTL {
+		shortestMaxTTL = roleEntry.TokenMaxTTL
 	}
-	if roleEntry.MaxTTL > longestMaxTTL {
-		longestMaxTTL = roleEntry.MaxTTL
+	if roleEntry.TokenMaxTTL > longestMaxTTL {
+		longestMaxTTL = roleEntry.TokenMaxTTL
 	}
 	if rTagMaxTTL > time.Duration(0) && rTagMaxTTL < shortestMaxTTL {
 		shortestMaxTTL = rTagMaxTTL
@@ -1123,9 +1132,9 @@ func (b *backend) pathLoginRenewEc2(ctx context.Context, req *logical.Request, d
 	}
 
 	resp := &logical.Response{Auth: req.Auth}
-	resp.Auth.TTL = roleEntry.TTL
+	resp.Auth.TTL = roleEntry.TokenTTL
 	resp.Auth.MaxTTL = shortestMaxTTL
-	resp.Auth.Period = roleEntry.Period
+	resp.Auth.Period = roleEntry.TokenPeriod
 	return resp, nil
 }
 
@@ -1165,6 +1174,9 @@ func (b *backend) pathLoginUpdateIam(ctx context.Context, req *logical.Request,
 		return logical.ErrorResponse("failed to base64 decode iam_request_body"), nil
 	}
 	body := string(bodyRaw)
+	if err := validateCallerIdentityRequest(parsedUrl, body); err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
 
 	headers := data.Get("iam_request_headers").(http.Header)
 	if len(headers) == 0 {
@@ -1217,6 +1229,16 @@ func (b *backend) pathLoginUpdateIam(ctx context.Context, req *logical.Request,
 		return logical.ErrorResponse(fmt.Sprintf("auth method iam not allowed for role %s", roleName)), nil
 	}
 
+	if len(roleEntry.TokenBoundCIDRs) > 0 {
+		if req.Connection == nil {
+			b.Logger().Warn("token bound CIDRs found but no connection information available for validation")
+			return nil, logical.ErrPermissionDenied
+		}
+		if !cidrutil.RemoteAddrIsOk(req.Connection.RemoteAddr, roleEntry.TokenBoundCIDRs) {
+			return nil, logical.ErrPermissionDenied
+		}
+	}
+
 	identityConfigEntry, err := identityConfigEntry(ctx, req.Storage)
 	if err != nil {
 		return nil, err
@@ -1285,8 +1307,6 @@ func (b *backend) pathLoginUpdateIam(ctx context.Context, req *logical.Request,
 		}
 	}
 
-	policies := roleEntry.Policies
-
 	inferredEntityType := ""
 	inferredEntityID := ""
 	if roleEntry.InferredEntityType == ec2EntityType {
@@ -1317,36 +1337,32 @@ func (b *backend) pathLoginUpdateIam(ctx context.Context, req *logical.Request,
 		inferredEntityID = entity.SessionInfo
 	}
 
-	resp := &logical.Response{
-		Auth: &logical.Auth{
-			Period:   roleEntry.Period,
-			Policies: policies,
-			Metadata: map[string]string{
-				"client_arn":           callerID.Arn,
-				"canonical_arn":        entity.canonicalArn(),
-				"client_user_id":       callerUniqueId,
-				"auth_type":            iamAuthType,
-				"inferred_entity_type": inferredEntityType,
-				"inferred_entity_id":   inferredEntityID,
-				"inferred_aws_region":  roleEntry.InferredAWSRegion,
-				"account_id":           entity.AccountNumber,
-				"role_id":              roleEntry.RoleID,
-			},
-			InternalData: map[string]interface{}{
-				"role_name": roleName,
-				"role_id":   roleEntry.RoleID,
-			},
-			DisplayName: entity.FriendlyName,
-			LeaseOptions: logical.LeaseOptions{
-				Renewable: true,
-				TTL:       roleEntry.TTL,
-				MaxTTL:    roleEntry.MaxTTL,
-			},
-			Alias: &logical.Alias{
-				Name: identityAlias,
-			},
+	auth := &logical.Auth{
+		Metadata: map[string]string{
+			"client_arn":           callerID.Arn,
+			"canonical_arn":        entity.canonicalArn(),
+			"client_user_id":       callerUniqueId,
+			"auth_type":            iamAuthType,
+			"inferred_entity_type": inferredEntityType,
+			"inferred_entity_id":   inferredEntityID,
+			"inferred_aws_region":  roleEntry.InferredAWSRegion,
+			"account_id":           entity.AccountNumber,
+			"role_id":              roleEntry.RoleID,
+		},
+		InternalData: map[string]interface{}{
+			"role_name": roleName,
+			"role_id":   roleEntry.RoleID,
+		},
+		DisplayName: entity.FriendlyName,
+		Alias: &logical.Alias{
+			Name: identityAlias,
 		},
 	}
+	roleEntry.PopulateTokenAuth(auth)
+
+	resp := &logical.Response{
+		Auth: auth,
+	}
 
 	return resp, nil
 }
@@ -1455,6 +1471,39 @@ func validateVaultHeaderValue(headers http.Header, requestUrl *url.URL, required
 	return fmt.Errorf("missing Authorization header")
 }
 
+// validateCallerIdentityRequest ensures the signed request forwarded to STS
+// is a plain sts:GetCallerIdentity call, so that a client cannot use Vault to
+// submit arbitrary signed requests on its behalf
+func validateCallerIdentityRequest(parsedUrl *url.URL, body string) error {
+	if parsedUrl.Path != "" && parsedUrl.Path != "/" {
+		return fmt.Errorf("invalid iam_request_url; path must be /")
+	}
+	if len(parsedUrl.Query()) > 0 {
+		return fmt.Errorf("invalid iam_request_url; query parameters are not allowed")
+	}
+
+	params, err := url.ParseQuery(body)
+	if err != nil {
+		return fmt.Errorf("error parsing iam_request_body: %v", err)
+	}
+	for k, v := range params {
+		switch k {
+		case "Action":
+			if len(v) != 1 || v[0] != "GetCallerIdentity" {
+				return fmt.Errorf("invalid iam_request_body; only the GetCallerIdentity action is allowed")
+			}
+		case "Version":
+		default:
+			return fmt.Errorf("invalid iam_request_body; unexpected parameter %q", k)
+		}
+	}
+	if _, ok := params["Action"]; !ok {
+		return fmt.Errorf("invalid iam_request_body; missing Action")
+	}
+
+	return nil
+}
+
 func buildHttpRequest(method, endpoint string, parsedUrl *url.URL, body string, headers http.Header) *http.Request {
 	// This is all a bit complicated because the AWS signature algorithm requires that
 	// the Host header be included in the signed headers. See
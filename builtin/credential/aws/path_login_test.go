@@ -174,6 +174,36 @@ func TestBackend_validateVaultHeaderValue(t *testing.T) {
 	}
 }
 
+func TestBackend_validateCallerIdentityRequest(t *testing.T) {
+	stsURL, _ := url.Parse("https://sts.amazonaws.com/")
+	queryURL, _ := url.Parse("https://sts.amazonaws.com/?Action=AssumeRole")
+	pathURL, _ := url.Parse("https://sts.amazonaws.com/foo")
+
+	cases := []struct {
+		url   *url.URL
+		body  string
+		valid bool
+	}{
+		{stsURL, "Action=GetCallerIdentity&Version=2011-06-15", true},
+		{stsURL, "Action=GetCallerIdentity", true},
+		{stsURL, "Version=2011-06-15", false},
+		{stsURL, "Action=AssumeRole&Version=2011-06-15", false},
+		{stsURL, "Action=GetCallerIdentity&Action=AssumeRole", false},
+		{stsURL, "Action=GetCallerIdentity&RoleArn=foo", false},
+		{queryURL, "Action=GetCallerIdentity&Version=2011-06-15", false},
+		{pathURL, "Action=GetCallerIdentity&Version=2011-06-15", false},
+	}
+	for _, tc := range cases {
+		err := validateCallerIdentityRequest(tc.url, tc.body)
+		if tc.valid && err != nil {
+			t.Errorf("expected %s %q to be valid: %v", tc.url, tc.body, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("expected %s %q to be invalid", tc.url, tc.body)
+		}
+	}
+}
+
 // TestBackend_pathLogin_IAMHeaders tests login with iam_request_headers,
 // supporting both base64 encoded string and JSON headers
 func TestBackend_pathLogin_IAMHeaders(t *testing.T) {
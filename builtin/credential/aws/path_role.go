@@ -10,16 +10,16 @@ import (
 	uuid "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/consts"
-	"github.com/hashicorp/vault/sdk/helper/policyutil"
+	"github.com/hashicorp/vault/sdk/helper/tokenutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
 var (
-	currentRoleStorageVersion = 3
+	currentRoleStorageVersion = 4
 )
 
 func pathRole(b *backend) *framework.Path {
-	return &framework.Path{
+	p := &framework.Path{
 		Pattern: "role/" + framework.GenericNameRegex("role"),
 		Fields: map[string]*framework.FieldSchema{
 			"role": {
@@ -131,28 +131,24 @@ Defaults to an empty string, meaning that role tags are disabled. This
 is only allowed if auth_type is ec2.`,
 			},
 			"period": &framework.FieldSchema{
-				Type:    framework.TypeDurationSecond,
-				Default: 0,
-				Description: `
-If set, indicates that the token generated using this role should never expire.
-The token should be renewed within the duration specified by this value. At
-each renewal, the token's TTL will be set to the value of this parameter.`,
+				Type:        framework.TypeDurationSecond,
+				Description: tokenutil.DeprecationText("token_period"),
+				Deprecated:  true,
 			},
 			"ttl": {
-				Type:    framework.TypeDurationSecond,
-				Default: 0,
-				Description: `Duration in seconds after which the issued token should expire. Defaults
-to 0, in which case the value will fallback to the system/mount defaults.`,
+				Type:        framework.TypeDurationSecond,
+				Description: tokenutil.DeprecationText("token_ttl"),
+				Deprecated:  true,
 			},
 			"max_ttl": {
 				Type:        framework.TypeDurationSecond,
-				Default:     0,
-				Description: "The maximum allowed lifetime of tokens issued using this role.",
+				Description: tokenutil.DeprecationText("token_max_ttl"),
+				Deprecated:  true,
 			},
 			"policies": {
 				Type:        framework.TypeCommaStringSlice,
-				Default:     "default",
-				Description: "Policies to be set on tokens issued using this role.",
+				Description: tokenutil.DeprecationText("token_policies"),
+				Deprecated:  true,
 			},
 			"allow_instance_migration": {
 				Type:    framework.TypeBool,
@@ -187,6 +183,9 @@ auth_type is ec2.`,
 		HelpSynopsis:    pathRoleSyn,
 		HelpDescription: pathRoleDesc,
 	}
+
+	tokenutil.AddTokenFields(p.Fields)
+	return p
 }
 
 func pathListRole(b *backend) *framework.Path {
@@ -411,6 +410,22 @@ func (b *backend) upgradeRoleEntry(ctx context.Context, s logical.Storage, roleE
 		roleEntry.RoleID = roleID
 		fallthrough
 
+	case 3:
+		// Copy the legacy token settings into the shared token parameters
+		if roleEntry.TokenTTL == 0 && roleEntry.TTL > 0 {
+			roleEntry.TokenTTL = roleEntry.TTL
+		}
+		if roleEntry.TokenMaxTTL == 0 && roleEntry.MaxTTL > 0 {
+			roleEntry.TokenMaxTTL = roleEntry.MaxTTL
+		}
+		if roleEntry.TokenPeriod == 0 && roleEntry.Period > 0 {
+			roleEntry.TokenPeriod = roleEntry.Period
+		}
+		if len(roleEntry.TokenPolicies) == 0 && len(roleEntry.Policies) > 0 {
+			roleEntry.TokenPolicies = roleEntry.Policies
+		}
+		fallthrough
+
 	case currentRoleStorageVersion:
 		roleEntry.Version = currentRoleStorageVersion
 
@@ -709,13 +724,6 @@ func (b *backend) pathRoleCreateUpdate(ctx context.Context, req *logical.Request
 		return logical.ErrorResponse("at least one bound parameter should be specified on the role"), nil
 	}
 
-	policiesRaw, ok := data.GetOk("policies")
-	if ok {
-		roleEntry.Policies = policyutil.ParsePolicies(policiesRaw)
-	} else if req.Operation == logical.CreateOperation {
-		roleEntry.Policies = []string{}
-	}
-
 	disallowReauthenticationBool, ok := data.GetOk("disallow_reauthentication")
 	if ok {
 		if roleEntry.AuthType != ec2AuthType {
@@ -742,48 +750,42 @@ func (b *backend) pathRoleCreateUpdate(ctx context.Context, req *logical.Request
 
 	var resp logical.Response
 
-	ttlRaw, ok := data.GetOk("ttl")
-	if ok {
-		ttl := time.Duration(ttlRaw.(int)) * time.Second
-		defaultLeaseTTL := b.System().DefaultLeaseTTL()
-		if ttl > defaultLeaseTTL {
-			resp.AddWarning(fmt.Sprintf("Given ttl of %d seconds greater than current mount/system default of %d seconds; ttl will be capped at login time", ttl/time.Second, defaultLeaseTTL/time.Second))
-		}
-		roleEntry.TTL = ttl
-	} else if req.Operation == logical.CreateOperation {
-		roleEntry.TTL = time.Duration(data.Get("ttl").(int)) * time.Second
+	if err := roleEntry.ParseTokenFields(req, data); err != nil {
+		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
 	}
 
-	maxTTLInt, ok := data.GetOk("max_ttl")
-	if ok {
-		maxTTL := time.Duration(maxTTLInt.(int)) * time.Second
-		systemMaxTTL := b.System().MaxLeaseTTL()
-		if maxTTL > systemMaxTTL {
-			resp.AddWarning(fmt.Sprintf("Given max_ttl of %d seconds greater than current mount/system default of %d seconds; max_ttl will be capped at login time", maxTTL/time.Second, systemMaxTTL/time.Second))
+	// Handle upgrade cases
+	{
+		if err := tokenutil.UpgradeValue(data, "policies", "token_policies", &roleEntry.Policies, &roleEntry.TokenPolicies); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
 		}
 
-		if maxTTL < time.Duration(0) {
-			return logical.ErrorResponse("max_ttl cannot be negative"), nil
+		if err := tokenutil.UpgradeValue(data, "ttl", "token_ttl", &roleEntry.TTL, &roleEntry.TokenTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
 		}
 
-		roleEntry.MaxTTL = maxTTL
-	} else if req.Operation == logical.CreateOperation {
-		roleEntry.MaxTTL = time.Duration(data.Get("max_ttl").(int)) * time.Second
-	}
+		if err := tokenutil.UpgradeValue(data, "max_ttl", "token_max_ttl", &roleEntry.MaxTTL, &roleEntry.TokenMaxTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
 
-	if roleEntry.MaxTTL != 0 && roleEntry.MaxTTL < roleEntry.TTL {
-		return logical.ErrorResponse("ttl should be shorter than max_ttl"), nil
+		if err := tokenutil.UpgradeValue(data, "period", "token_period", &roleEntry.Period, &roleEntry.TokenPeriod); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
 	}
 
-	periodRaw, ok := data.GetOk("period")
-	if ok {
-		roleEntry.Period = time.Second * time.Duration(periodRaw.(int))
-	} else if req.Operation == logical.CreateOperation {
-		roleEntry.Period = time.Second * time.Duration(data.Get("period").(int))
+	defaultLeaseTTL := b.System().DefaultLeaseTTL()
+	systemMaxTTL := b.System().MaxLeaseTTL()
+	if roleEntry.TokenTTL > defaultLeaseTTL {
+		resp.AddWarning(fmt.Sprintf("Given token_ttl of %d seconds greater than current mount/system default of %d seconds; token_ttl will be capped at login time", roleEntry.TokenTTL/time.Second, defaultLeaseTTL/time.Second))
 	}
-
-	if roleEntry.Period > b.System().MaxLeaseTTL() {
-		return logical.ErrorResponse(fmt.Sprintf("'period' of '%s' is greater than the backend's maximum lease TTL of '%s'", roleEntry.Period.String(), b.System().MaxLeaseTTL().String())), nil
+	if roleEntry.TokenMaxTTL > systemMaxTTL {
+		resp.AddWarning(fmt.Sprintf("Given token_max_ttl of %d seconds greater than current mount/system default of %d seconds; token_max_ttl will be capped at login time", roleEntry.TokenMaxTTL/time.Second, systemMaxTTL/time.Second))
+	}
+	if roleEntry.TokenMaxTTL != 0 && roleEntry.TokenMaxTTL < roleEntry.TokenTTL {
+		return logical.ErrorResponse("token_ttl should be shorter than token_max_ttl"), nil
+	}
+	if roleEntry.TokenPeriod > systemMaxTTL {
+		return logical.ErrorResponse(fmt.Sprintf("'token_period' of '%s' is greater than the backend's maximum lease TTL of '%s'", roleEntry.TokenPeriod.String(), systemMaxTTL.String())), nil
 	}
 
 	roleTagStr, ok := data.GetOk("role_tag")
@@ -821,30 +823,41 @@ func (b *backend) pathRoleCreateUpdate(ctx context.Context, req *logical.Request
 
 // Struct to hold the information associated with a Vault role
 type awsRoleEntry struct {
-	RoleID                      string        `json:"role_id"`
-	AuthType                    string        `json:"auth_type"`
-	BoundAmiIDs                 []string      `json:"bound_ami_id_list"`
-	BoundAccountIDs             []string      `json:"bound_account_id_list"`
-	BoundEc2InstanceIDs         []string      `json:"bound_ec2_instance_id_list"`
-	BoundIamPrincipalARNs       []string      `json:"bound_iam_principal_arn_list"`
-	BoundIamPrincipalIDs        []string      `json:"bound_iam_principal_id_list"`
-	BoundIamRoleARNs            []string      `json:"bound_iam_role_arn_list"`
-	BoundIamInstanceProfileARNs []string      `json:"bound_iam_instance_profile_arn_list"`
-	BoundRegions                []string      `json:"bound_region_list"`
-	BoundSubnetIDs              []string      `json:"bound_subnet_id_list"`
-	BoundVpcIDs                 []string      `json:"bound_vpc_id_list"`
-	InferredEntityType          string        `json:"inferred_entity_type"`
-	InferredAWSRegion           string        `json:"inferred_aws_region"`
-	ResolveAWSUniqueIDs         bool          `json:"resolve_aws_unique_ids"`
-	RoleTag                     string        `json:"role_tag"`
-	AllowInstanceMigration      bool          `json:"allow_instance_migration"`
-	TTL                         time.Duration `json:"ttl"`
-	MaxTTL                      time.Duration `json:"max_ttl"`
-	Policies                    []string      `json:"policies"`
-	DisallowReauthentication    bool          `json:"disallow_reauthentication"`
-	HMACKey                     string        `json:"hmac_key"`
-	Period                      time.Duration `json:"period"`
-	Version                     int           `json:"version"`
+	tokenutil.TokenParams
+
+	RoleID                      string   `json:"role_id"`
+	AuthType                    string   `json:"auth_type"`
+	BoundAmiIDs                 []string `json:"bound_ami_id_list"`
+	BoundAccountIDs             []string `json:"bound_account_id_list"`
+	BoundEc2InstanceIDs         []string `json:"bound_ec2_instance_id_list"`
+	BoundIamPrincipalARNs       []string `json:"bound_iam_principal_arn_list"`
+	BoundIamPrincipalIDs        []string `json:"bound_iam_principal_id_list"`
+	BoundIamRoleARNs            []string `json:"bound_iam_role_arn_list"`
+	BoundIamInstanceProfileARNs []string `json:"bound_iam_instance_profile_arn_list"`
+	BoundRegions                []string `json:"bound_region_list"`
+	BoundSubnetIDs              []string `json:"bound_subnet_id_list"`
+	BoundVpcIDs                 []string `json:"bound_vpc_id_list"`
+	InferredEntityType          string   `json:"inferred_entity_type"`
+	InferredAWSRegion           string   `json:"inferred_aws_region"`
+	ResolveAWSUniqueIDs         bool     `json:"resolve_aws_unique_ids"`
+	RoleTag                     string   `json:"role_tag"`
+	AllowInstanceMigration      bool     `json:"allow_instance_migration"`
+	DisallowReauthentication    bool     `json:"disallow_reauthentication"`
+	HMACKey                     string   `json:"hmac_key"`
+	Version                     int      `json:"version"`
+
+	// Deprecated: use TokenTTL instead
+	TTL time.Duration `json:"ttl"`
+
+	// Deprecated: use TokenMaxTTL instead
+	MaxTTL time.Duration `json:"max_ttl"`
+
+	// Deprecated: use TokenPeriod instead
+	Period time.Duration `json:"period"`
+
+	// Deprecated: use TokenPolicies instead
+	Policies []string `json:"policies"`
+
 	// DEPRECATED -- these are the old fields before we supported lists and exist for backwards compatibility
 	BoundAmiID                 string `json:"bound_ami_id,omitempty" `
 	BoundAccountID             string `json:"bound_account_id,omitempty"`
@@ -876,11 +889,21 @@ func (r *awsRoleEntry) ToResponseData() map[string]interface{} {
 		"role_id":                        r.RoleID,
 		"role_tag":                       r.RoleTag,
 		"allow_instance_migration":       r.AllowInstanceMigration,
-		"ttl":                            r.TTL / time.Second,
-		"max_ttl":                        r.MaxTTL / time.Second,
-		"policies":                       r.Policies,
 		"disallow_reauthentication":      r.DisallowReauthentication,
-		"period":                         r.Period / time.Second,
+	}
+
+	r.PopulateTokenData(responseData)
+	if r.TTL > 0 {
+		responseData["ttl"] = int64(r.TTL.Seconds())
+	}
+	if r.MaxTTL > 0 {
+		responseData["max_ttl"] = int64(r.MaxTTL.Seconds())
+	}
+	if r.Period > 0 {
+		responseData["period"] = int64(r.Period.Seconds())
+	}
+	if len(r.Policies) > 0 {
+		responseData["policies"] = responseData["token_policies"]
 	}
 
 	convertNilToEmptySlice := func(data map[string]interface{}, field string) {
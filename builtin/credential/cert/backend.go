@@ -2,6 +2,7 @@ package cert
 
 import (
 	"context"
+	"crypto/x509/pkix"
 	"strings"
 	"sync"
 
@@ -49,6 +50,10 @@ type backend struct {
 
 	crls           map[string]CRLInfo
 	crlUpdateMutex *sync.RWMutex
+
+	// fetchedCRLs caches CRLs retrieved from distribution points, keyed by URL
+	fetchedCRLs     map[string]*pkix.CertificateList
+	fetchedCRLsLock sync.Mutex
 }
 
 func (b *backend) invalidate(_ context.Context, key string) {
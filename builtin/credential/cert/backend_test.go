@@ -8,6 +8,7 @@ import (
 	"encoding/pem"
 	mathrand "math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"path/filepath"
 
@@ -42,6 +43,7 @@ import (
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/hashicorp/vault/vault"
 	"github.com/mitchellh/mapstructure"
+	"golang.org/x/crypto/ocsp"
 )
 
 const (
@@ -1233,6 +1235,53 @@ func TestBackend_organizationalUnit_singleCert(t *testing.T) {
 	})
 }
 
+// Test a client with organizations that is trusted
+func TestBackend_organization_singleCert(t *testing.T) {
+	certTemplate := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   "example.com",
+			Organization: []string{"Example Corp", "Example Subsidiary"},
+		},
+		DNSNames:    []string{"example.com"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageKeyAgreement,
+		SerialNumber: big.NewInt(mathrand.Int63()),
+		NotBefore:    time.Now().Add(-30 * time.Second),
+		NotAfter:     time.Now().Add(262980 * time.Hour),
+	}
+
+	tempDir, connState, err := generateTestCertAndConnState(t, certTemplate)
+	if tempDir != "" {
+		defer os.RemoveAll(tempDir)
+	}
+	if err != nil {
+		t.Fatalf("error testing connection state: %v", err)
+	}
+	ca, err := ioutil.ReadFile(filepath.Join(tempDir, "ca_cert.pem"))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	logicaltest.Test(t, logicaltest.TestCase{
+		CredentialBackend: testFactory(t),
+		Steps: []logicaltest.TestStep{
+			testAccStepCert(t, "web", ca, "foo", allowed{organizations: "Example Corp"}, false),
+			testAccStepLogin(t, connState),
+			testAccStepCert(t, "web", ca, "foo", allowed{organizations: "Example Sub*"}, false),
+			testAccStepLogin(t, connState),
+			testAccStepCert(t, "web", ca, "foo", allowed{organizations: "Other Corp,Example Corp"}, false),
+			testAccStepLogin(t, connState),
+			testAccStepCert(t, "web", ca, "foo", allowed{organizations: "Other Corp"}, false),
+			testAccStepLoginInvalid(t, connState),
+			testAccStepCert(t, "web", ca, "foo", allowed{organizations: "Example Corp", dns: "other.com"}, false),
+			testAccStepLoginInvalid(t, connState),
+		},
+	})
+}
+
 // Test a self-signed client with URI alt names (root CA) that is trusted
 func TestBackend_uri_singleCert(t *testing.T) {
 	u, err := url.Parse("spiffe://example.com/host")
@@ -1628,6 +1677,7 @@ type allowed struct {
 	emails               string // allowed email names in SAN extension of the certificate
 	uris                 string // allowed uris in SAN extension of the certificate
 	organizational_units string // allowed OUs in the certificate
+	organizations        string // allowed organizations in the certificate
 	ext                  string // required extensions in the certificate
 }
 
@@ -1647,6 +1697,7 @@ func testAccStepCert(
 			"allowed_email_sans":           testData.emails,
 			"allowed_uri_sans":             testData.uris,
 			"allowed_organizational_units": testData.organizational_units,
+			"allowed_organizations":        testData.organizations,
 			"required_extensions":          testData.ext,
 			"lease":                        1000,
 		},
@@ -1949,3 +2000,258 @@ func Test_Renew(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestBackend_CRLDistributionPoints(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "root.example.com"},
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caBytes, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var crlBytes []byte
+	available := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(crlBytes)
+	}))
+	defer srv.Close()
+
+	issue := func(serial int64) *x509.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			Subject:               pkix.Name{CommonName: "client.example.com"},
+			SerialNumber:          big.NewInt(serial),
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			CRLDistributionPoints: []string{srv.URL + "/crl-" + fmt.Sprint(serial)},
+		}
+		certBytes, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(certBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert
+	}
+	good, revoked, unreachable := issue(2), issue(3), issue(4)
+
+	crlBytes, err = ca.CreateCRL(rand.Reader, caKey, []pkix.RevokedCertificate{
+		{SerialNumber: revoked.SerialNumber, RevocationTime: time.Now()},
+	}, time.Now(), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storage := &logical.InmemStorage{}
+	b, err := Factory(context.Background(), &logical.BackendConfig{
+		System:      &logical.StaticSystemView{},
+		StorageView: storage,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := b.(*backend)
+
+	writeConfig := func(data map[string]interface{}) {
+		resp, err := b.HandleRequest(context.Background(), &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "config",
+			Storage:   storage,
+			Data:      data,
+		})
+		if err != nil || (resp != nil && resp.IsError()) {
+			t.Fatalf("bad: resp: %#v err: %v", resp, err)
+		}
+	}
+	check := func(cert *x509.Certificate, expectDenied bool) {
+		t.Helper()
+		resp, err := backend.checkRevocation(context.Background(), storage, []*x509.Certificate{cert, ca})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if denied := resp != nil && resp.IsError(); denied != expectDenied {
+			t.Fatalf("serial %s: expected denied=%t, got resp: %#v", cert.SerialNumber, expectDenied, resp)
+		}
+	}
+
+	// Disabled by default
+	check(revoked, false)
+
+	writeConfig(map[string]interface{}{"check_crl_distribution_points": true})
+	check(good, false)
+	check(revoked, true)
+
+	available = false
+	check(unreachable, true)
+
+	writeConfig(map[string]interface{}{"crl_fail_mode": "soft"})
+	check(unreachable, false)
+	// Previously fetched CRLs are served from the cache
+	check(revoked, true)
+
+	resp, err := b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "config",
+		Storage:   storage,
+		Data:      map[string]interface{}{"crl_fail_mode": "sometimes"},
+	})
+	if err != nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error for invalid fail mode, got resp: %#v err: %v", resp, err)
+	}
+}
+
+func TestBackend_OCSP(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "root.example.com"},
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caBytes, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revokedSerial := big.NewInt(3)
+	available := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		req, err := ocsp.ParseRequest(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		template := ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}
+		if req.SerialNumber.Cmp(revokedSerial) == 0 {
+			template.Status = ocsp.Revoked
+			template.RevokedAt = time.Now().Add(-time.Minute)
+		}
+		resp, err := ocsp.CreateResponse(ca, ca, template, caKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(resp)
+	}))
+	defer srv.Close()
+
+	issue := func(serial *big.Int) *x509.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			Subject:      pkix.Name{CommonName: "client.example.com"},
+			SerialNumber: serial,
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			OCSPServer:   []string{srv.URL},
+		}
+		certBytes, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(certBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert
+	}
+	good, revoked := issue(big.NewInt(2)), issue(revokedSerial)
+
+	storage := &logical.InmemStorage{}
+	b, err := Factory(context.Background(), &logical.BackendConfig{
+		System:      &logical.StaticSystemView{},
+		StorageView: storage,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := b.(*backend)
+
+	writeConfig := func(data map[string]interface{}) {
+		resp, err := b.HandleRequest(context.Background(), &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "config",
+			Storage:   storage,
+			Data:      data,
+		})
+		if err != nil || (resp != nil && resp.IsError()) {
+			t.Fatalf("bad: resp: %#v err: %v", resp, err)
+		}
+	}
+	check := func(cert *x509.Certificate, expectDenied bool) {
+		t.Helper()
+		resp, err := backend.checkRevocation(context.Background(), storage, []*x509.Certificate{cert, ca})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if denied := resp != nil && resp.IsError(); denied != expectDenied {
+			t.Fatalf("serial %s: expected denied=%t, got resp: %#v", cert.SerialNumber, expectDenied, resp)
+		}
+	}
+
+	// Disabled by default
+	check(revoked, false)
+
+	writeConfig(map[string]interface{}{"check_ocsp": true})
+	check(good, false)
+	check(revoked, true)
+
+	available = false
+	check(good, true)
+
+	writeConfig(map[string]interface{}{"crl_fail_mode": "soft"})
+	check(good, false)
+	check(revoked, false)
+}
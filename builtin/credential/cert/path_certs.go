@@ -9,8 +9,7 @@ import (
 
 	sockaddr "github.com/hashicorp/go-sockaddr"
 	"github.com/hashicorp/vault/sdk/framework"
-	"github.com/hashicorp/vault/sdk/helper/parseutil"
-	"github.com/hashicorp/vault/sdk/helper/policyutil"
+	"github.com/hashicorp/vault/sdk/helper/tokenutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
@@ -28,7 +27,7 @@ func pathListCerts(b *backend) *framework.Path {
 }
 
 func pathCerts(b *backend) *framework.Path {
-	return &framework.Path{
+	p := &framework.Path{
 		Pattern: "certs/" + framework.GenericNameRegex("name"),
 		Fields: map[string]*framework.FieldSchema{
 			"name": &framework.FieldSchema{
@@ -80,6 +79,12 @@ At least one must exist in the SANs. Supports globbing.`,
 At least one must exist in the OU field.`,
 			},
 
+			"allowed_organizations": &framework.FieldSchema{
+				Type: framework.TypeCommaStringSlice,
+				Description: `A comma-separated list of Organization names.
+At least one must exist in the O field. Supports globbing.`,
+			},
+
 			"required_extensions": &framework.FieldSchema{
 				Type: framework.TypeCommaStringSlice,
 				Description: `A comma-separated string or array of extensions
@@ -95,39 +100,38 @@ certificate.`,
 
 			"policies": &framework.FieldSchema{
 				Type:        framework.TypeCommaStringSlice,
-				Description: "Comma-separated list of policies.",
+				Description: tokenutil.DeprecationText("token_policies"),
+				Deprecated:  true,
 			},
 
 			"lease": &framework.FieldSchema{
-				Type: framework.TypeInt,
-				Description: `Deprecated: use "ttl" instead. TTL time in
-seconds. Defaults to system/backend default TTL.`,
+				Type:        framework.TypeInt,
+				Description: tokenutil.DeprecationText("token_ttl"),
+				Deprecated:  true,
 			},
 
 			"ttl": &framework.FieldSchema{
-				Type: framework.TypeDurationSecond,
-				Description: `TTL for tokens issued by this backend.
-Defaults to system/backend default TTL time.`,
+				Type:        framework.TypeDurationSecond,
+				Description: tokenutil.DeprecationText("token_ttl"),
+				Deprecated:  true,
 			},
 
 			"max_ttl": &framework.FieldSchema{
-				Type: framework.TypeDurationSecond,
-				Description: `Duration in either an integer number of seconds (3600) or
-an integer time unit (60m) after which the
-issued token can no longer be renewed.`,
+				Type:        framework.TypeDurationSecond,
+				Description: tokenutil.DeprecationText("token_max_ttl"),
+				Deprecated:  true,
 			},
 
 			"period": &framework.FieldSchema{
-				Type: framework.TypeDurationSecond,
-				Description: `If set, indicates that the token generated using this role
-should never expire. The token should be renewed within the
-duration specified by this value. At each renewal, the token's
-TTL will be set to the value of this parameter.`,
+				Type:        framework.TypeDurationSecond,
+				Description: tokenutil.DeprecationText("token_period"),
+				Deprecated:  true,
 			},
+
 			"bound_cidrs": &framework.FieldSchema{
-				Type: framework.TypeCommaStringSlice,
-				Description: `Comma separated string or list of CIDR blocks. If set, specifies the blocks of
-IP addresses which can perform the login operation.`,
+				Type:        framework.TypeCommaStringSlice,
+				Description: tokenutil.DeprecationText("token_bound_cidrs"),
+				Deprecated:  true,
 			},
 		},
 
@@ -140,6 +144,9 @@ IP addresses which can perform the login operation.`,
 		HelpSynopsis:    pathCertHelpSyn,
 		HelpDescription: pathCertHelpDesc,
 	}
+
+	tokenutil.AddTokenFields(p.Fields)
+	return p
 }
 
 func (b *backend) Cert(ctx context.Context, s logical.Storage, n string) (*CertEntry, error) {
@@ -155,6 +162,25 @@ func (b *backend) Cert(ctx context.Context, s logical.Storage, n string) (*CertE
 	if err := entry.DecodeJSON(&result); err != nil {
 		return nil, err
 	}
+
+	// Certificates registered before the token fields were shared only have
+	// the deprecated values set
+	if result.TokenTTL == 0 && result.TTL > 0 {
+		result.TokenTTL = result.TTL
+	}
+	if result.TokenMaxTTL == 0 && result.MaxTTL > 0 {
+		result.TokenMaxTTL = result.MaxTTL
+	}
+	if result.TokenPeriod == 0 && result.Period > 0 {
+		result.TokenPeriod = result.Period
+	}
+	if len(result.TokenPolicies) == 0 && len(result.Policies) > 0 {
+		result.TokenPolicies = result.Policies
+	}
+	if len(result.TokenBoundCIDRs) == 0 && len(result.BoundCIDRs) > 0 {
+		result.TokenBoundCIDRs = result.BoundCIDRs
+	}
+
 	return &result, nil
 }
 
@@ -183,23 +209,39 @@ func (b *backend) pathCertRead(ctx context.Context, req *logical.Request, d *fra
 		return nil, nil
 	}
 
+	data := map[string]interface{}{
+		"certificate":                  cert.Certificate,
+		"display_name":                 cert.DisplayName,
+		"allowed_names":                cert.AllowedNames,
+		"allowed_common_names":         cert.AllowedCommonNames,
+		"allowed_dns_sans":             cert.AllowedDNSSANs,
+		"allowed_email_sans":           cert.AllowedEmailSANs,
+		"allowed_uri_sans":             cert.AllowedURISANs,
+		"allowed_organizational_units": cert.AllowedOrganizationalUnits,
+		"allowed_organizations":        cert.AllowedOrganizations,
+		"required_extensions":          cert.RequiredExtensions,
+	}
+	cert.PopulateTokenData(data)
+
+	// Add backwards compat data
+	if cert.TTL > 0 {
+		data["ttl"] = int64(cert.TTL.Seconds())
+	}
+	if cert.MaxTTL > 0 {
+		data["max_ttl"] = int64(cert.MaxTTL.Seconds())
+	}
+	if cert.Period > 0 {
+		data["period"] = int64(cert.Period.Seconds())
+	}
+	if len(cert.Policies) > 0 {
+		data["policies"] = data["token_policies"]
+	}
+	if len(cert.BoundCIDRs) > 0 {
+		data["bound_cidrs"] = data["token_bound_cidrs"]
+	}
+
 	return &logical.Response{
-		Data: map[string]interface{}{
-			"certificate":                  cert.Certificate,
-			"display_name":                 cert.DisplayName,
-			"policies":                     cert.Policies,
-			"ttl":                          cert.TTL / time.Second,
-			"max_ttl":                      cert.MaxTTL / time.Second,
-			"period":                       cert.Period / time.Second,
-			"allowed_names":                cert.AllowedNames,
-			"allowed_common_names":         cert.AllowedCommonNames,
-			"allowed_dns_sans":             cert.AllowedDNSSANs,
-			"allowed_email_sans":           cert.AllowedEmailSANs,
-			"allowed_uri_sans":             cert.AllowedURISANs,
-			"allowed_organizational_units": cert.AllowedOrganizationalUnits,
-			"required_extensions":          cert.RequiredExtensions,
-			"bound_cidrs":                  cert.BoundCIDRs,
-		},
+		Data: data,
 	}, nil
 }
 
@@ -207,56 +249,15 @@ func (b *backend) pathCertWrite(ctx context.Context, req *logical.Request, d *fr
 	name := strings.ToLower(d.Get("name").(string))
 	certificate := d.Get("certificate").(string)
 	displayName := d.Get("display_name").(string)
-	policies := policyutil.ParsePolicies(d.Get("policies"))
 	allowedNames := d.Get("allowed_names").([]string)
 	allowedCommonNames := d.Get("allowed_common_names").([]string)
 	allowedDNSSANs := d.Get("allowed_dns_sans").([]string)
 	allowedEmailSANs := d.Get("allowed_email_sans").([]string)
 	allowedURISANs := d.Get("allowed_uri_sans").([]string)
 	allowedOrganizationalUnits := d.Get("allowed_organizational_units").([]string)
+	allowedOrganizations := d.Get("allowed_organizations").([]string)
 	requiredExtensions := d.Get("required_extensions").([]string)
 
-	var resp logical.Response
-
-	// Parse the ttl (or lease duration)
-	systemDefaultTTL := b.System().DefaultLeaseTTL()
-	ttl := time.Duration(d.Get("ttl").(int)) * time.Second
-	if ttl == 0 {
-		ttl = time.Duration(d.Get("lease").(int)) * time.Second
-	}
-	if ttl > systemDefaultTTL {
-		resp.AddWarning(fmt.Sprintf("Given ttl of %d seconds is greater than current mount/system default of %d seconds", ttl/time.Second, systemDefaultTTL/time.Second))
-	}
-
-	if ttl < time.Duration(0) {
-		return logical.ErrorResponse("ttl cannot be negative"), nil
-	}
-
-	// Parse max_ttl
-	systemMaxTTL := b.System().MaxLeaseTTL()
-	maxTTL := time.Duration(d.Get("max_ttl").(int)) * time.Second
-	if maxTTL > systemMaxTTL {
-		resp.AddWarning(fmt.Sprintf("Given max_ttl of %d seconds is greater than current mount/system default of %d seconds", maxTTL/time.Second, systemMaxTTL/time.Second))
-	}
-
-	if maxTTL < time.Duration(0) {
-		return logical.ErrorResponse("max_ttl cannot be negative"), nil
-	}
-
-	if maxTTL != 0 && ttl > maxTTL {
-		return logical.ErrorResponse("ttl should be shorter than max_ttl"), nil
-	}
-
-	// Parse period
-	period := time.Duration(d.Get("period").(int)) * time.Second
-	if period > systemMaxTTL {
-		resp.AddWarning(fmt.Sprintf("Given period of %d seconds is greater than the backend's maximum TTL of %d seconds", period/time.Second, systemMaxTTL/time.Second))
-	}
-
-	if period < time.Duration(0) {
-		return logical.ErrorResponse("period cannot be negative"), nil
-	}
-
 	// Default the display name to the certificate name if not given
 	if displayName == "" {
 		displayName = name
@@ -281,27 +282,74 @@ func (b *backend) pathCertWrite(ctx context.Context, req *logical.Request, d *fr
 		}
 	}
 
-	parsedCIDRs, err := parseutil.ParseAddrs(d.Get("bound_cidrs"))
-	if err != nil {
-		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
-	}
-
 	certEntry := &CertEntry{
 		Name:                       name,
 		Certificate:                certificate,
 		DisplayName:                displayName,
-		Policies:                   policies,
 		AllowedNames:               allowedNames,
 		AllowedCommonNames:         allowedCommonNames,
 		AllowedDNSSANs:             allowedDNSSANs,
 		AllowedEmailSANs:           allowedEmailSANs,
 		AllowedURISANs:             allowedURISANs,
 		AllowedOrganizationalUnits: allowedOrganizationalUnits,
+		AllowedOrganizations:       allowedOrganizations,
 		RequiredExtensions:         requiredExtensions,
-		TTL:                        ttl,
-		MaxTTL:                     maxTTL,
-		Period:                     period,
-		BoundCIDRs:                 parsedCIDRs,
+	}
+
+	if err := certEntry.ParseTokenFields(req, d); err != nil {
+		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
+	}
+
+	// Handle upgrade cases
+	{
+		if err := tokenutil.UpgradeValue(d, "policies", "token_policies", &certEntry.Policies, &certEntry.TokenPolicies); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+
+		if err := tokenutil.UpgradeValue(d, "ttl", "token_ttl", &certEntry.TTL, &certEntry.TokenTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+		// Special case here for old lease value
+		if _, ok := d.GetOk("token_ttl"); !ok {
+			if _, ok := d.GetOk("ttl"); !ok {
+				if leaseRaw, ok := d.GetOk("lease"); ok {
+					certEntry.TTL = time.Duration(leaseRaw.(int)) * time.Second
+					certEntry.TokenTTL = certEntry.TTL
+				}
+			}
+		}
+
+		if err := tokenutil.UpgradeValue(d, "max_ttl", "token_max_ttl", &certEntry.MaxTTL, &certEntry.TokenMaxTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+
+		if err := tokenutil.UpgradeValue(d, "period", "token_period", &certEntry.Period, &certEntry.TokenPeriod); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+
+		if err := tokenutil.UpgradeValue(d, "bound_cidrs", "token_bound_cidrs", &certEntry.BoundCIDRs, &certEntry.TokenBoundCIDRs); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	var resp logical.Response
+
+	systemDefaultTTL := b.System().DefaultLeaseTTL()
+	if certEntry.TokenTTL > systemDefaultTTL {
+		resp.AddWarning(fmt.Sprintf("Given ttl of %d seconds is greater than current mount/system default of %d seconds", certEntry.TokenTTL/time.Second, systemDefaultTTL/time.Second))
+	}
+	systemMaxTTL := b.System().MaxLeaseTTL()
+	if certEntry.TokenMaxTTL > systemMaxTTL {
+		resp.AddWarning(fmt.Sprintf("Given max_ttl of %d seconds is greater than current mount/system default of %d seconds", certEntry.TokenMaxTTL/time.Second, systemMaxTTL/time.Second))
+	}
+	if certEntry.TokenMaxTTL != 0 && certEntry.TokenTTL > certEntry.TokenMaxTTL {
+		return logical.ErrorResponse("ttl should be shorter than max_ttl"), nil
+	}
+	if certEntry.TokenPeriod > systemMaxTTL {
+		resp.AddWarning(fmt.Sprintf("Given period of %d seconds is greater than the backend's maximum TTL of %d seconds", certEntry.TokenPeriod/time.Second, systemMaxTTL/time.Second))
+	}
+	if certEntry.TokenTTL < 0 || certEntry.TokenMaxTTL < 0 || certEntry.TokenPeriod < 0 {
+		return logical.ErrorResponse("ttl, max_ttl, and period cannot be negative"), nil
 	}
 
 	// Store it
@@ -321,21 +369,27 @@ func (b *backend) pathCertWrite(ctx context.Context, req *logical.Request, d *fr
 }
 
 type CertEntry struct {
-	Name                       string
-	Certificate                string
-	DisplayName                string
-	Policies                   []string
-	TTL                        time.Duration
-	MaxTTL                     time.Duration
-	Period                     time.Duration
+	tokenutil.TokenParams
+
+	Name        string
+	Certificate string
+	DisplayName string
+
+	// Deprecated: use the values in TokenParams instead
+	Policies   []string
+	TTL        time.Duration
+	MaxTTL     time.Duration
+	Period     time.Duration
+	BoundCIDRs []*sockaddr.SockAddrMarshaler
+
 	AllowedNames               []string
 	AllowedCommonNames         []string
 	AllowedDNSSANs             []string
 	AllowedEmailSANs           []string
 	AllowedURISANs             []string
 	AllowedOrganizationalUnits []string
+	AllowedOrganizations       []string
 	RequiredExtensions         []string
-	BoundCIDRs                 []*sockaddr.SockAddrMarshaler
 }
 
 const pathCertHelpSyn = `
@@ -2,6 +2,7 @@ package cert
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/vault/sdk/framework"
@@ -17,6 +18,21 @@ func pathConfig(b *backend) *framework.Path {
 				Default:     false,
 				Description: `If set, during renewal, skips the matching of presented client identity with the client identity used during login. Defaults to false.`,
 			},
+			"check_crl_distribution_points": &framework.FieldSchema{
+				Type:        framework.TypeBool,
+				Default:     false,
+				Description: `If set, during login, the CRLs published at the CRL distribution points of each certificate in the client's chain are fetched and checked. Defaults to false.`,
+			},
+			"check_ocsp": &framework.FieldSchema{
+				Type:        framework.TypeBool,
+				Default:     false,
+				Description: `If set, during login, the OCSP responders named in each certificate in the client's chain are queried for its revocation status. Defaults to false.`,
+			},
+			"crl_fail_mode": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Default:     crlFailModeHard,
+				Description: `Behavior when the revocation status of a certificate cannot be checked, because no CRL distribution point or OCSP responder gave a verifiable answer. With "hard" the login is denied; with "soft" the failure is logged and the login proceeds. Defaults to "hard".`,
+			},
 		},
 
 		Callbacks: map[logical.Operation]framework.OperationFunc{
@@ -26,11 +42,32 @@ func pathConfig(b *backend) *framework.Path {
 }
 
 func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-	disableBinding := data.Get("disable_binding").(bool)
+	cfg, err := b.Config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
 
-	entry, err := logical.StorageEntryJSON("config", config{
-		DisableBinding: disableBinding,
-	})
+	if disableBindingRaw, ok := data.GetOk("disable_binding"); ok {
+		cfg.DisableBinding = disableBindingRaw.(bool)
+	}
+	if checkCDPRaw, ok := data.GetOk("check_crl_distribution_points"); ok {
+		cfg.CheckCRLDistributionPoints = checkCDPRaw.(bool)
+	}
+	if checkOCSPRaw, ok := data.GetOk("check_ocsp"); ok {
+		cfg.CheckOCSP = checkOCSPRaw.(bool)
+	}
+	if failModeRaw, ok := data.GetOk("crl_fail_mode"); ok {
+		cfg.CRLFailMode = failModeRaw.(string)
+	}
+	switch cfg.CRLFailMode {
+	case "":
+		cfg.CRLFailMode = crlFailModeHard
+	case crlFailModeHard, crlFailModeSoft:
+	default:
+		return logical.ErrorResponse(fmt.Sprintf("invalid crl_fail_mode %q; must be %q or %q", cfg.CRLFailMode, crlFailModeHard, crlFailModeSoft)), nil
+	}
+
+	entry, err := logical.StorageEntryJSON("config", cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +95,14 @@ func (b *backend) Config(ctx context.Context, s logical.Storage) (*config, error
 	return &result, nil
 }
 
+const (
+	crlFailModeHard = "hard"
+	crlFailModeSoft = "soft"
+)
+
 type config struct {
-	DisableBinding bool `json:"disable_binding"`
+	DisableBinding             bool   `json:"disable_binding"`
+	CheckCRLDistributionPoints bool   `json:"check_crl_distribution_points"`
+	CheckOCSP                  bool   `json:"check_ocsp"`
+	CRLFailMode                string `json:"crl_fail_mode"`
 }
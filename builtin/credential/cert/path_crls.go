@@ -1,17 +1,25 @@
 package cert
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math/big"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/fatih/structs"
 	"github.com/hashicorp/errwrap"
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
 	"github.com/hashicorp/vault/sdk/logical"
+	"golang.org/x/crypto/ocsp"
 )
 
 func pathCRLs(b *backend) *framework.Path {
@@ -226,6 +234,248 @@ func (b *backend) pathCRLWrite(ctx context.Context, req *logical.Request, d *fra
 	return nil, nil
 }
 
+const (
+	// crlFetchTimeout bounds the time spent retrieving a CRL from a
+	// distribution point during login
+	crlFetchTimeout = 10 * time.Second
+
+	// maxCRLSize is the largest CRL that will be read from a distribution point
+	maxCRLSize = 16 * 1024 * 1024
+
+	// ocspTimeout bounds the time spent querying an OCSP responder during
+	// login
+	ocspTimeout = 10 * time.Second
+
+	// maxOCSPResponseSize is the largest response that will be read from an
+	// OCSP responder
+	maxOCSPResponseSize = 1024 * 1024
+)
+
+// checkRevocation checks each certificate in the chain against the OCSP
+// responders and the CRLs published at the CRL distribution points named in
+// it, as enabled in the config. A non-nil response means the login must be
+// denied.
+func (b *backend) checkRevocation(ctx context.Context, s logical.Storage, chain []*x509.Certificate) (*logical.Response, error) {
+	cfg, err := b.Config(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.CheckCRLDistributionPoints && !cfg.CheckOCSP {
+		return nil, nil
+	}
+
+	for i, cert := range chain {
+		// The last certificate in a verified chain is the self-signed root
+		issuer := cert
+		if i+1 < len(chain) {
+			issuer = chain[i+1]
+		}
+
+		if cfg.CheckOCSP && len(cert.OCSPServer) > 0 {
+			revoked, err := b.checkCertAgainstOCSP(ctx, cert, issuer)
+			if resp := b.revocationResponse(cfg, cert, revoked, err); resp != nil {
+				return resp, nil
+			}
+		}
+
+		if cfg.CheckCRLDistributionPoints && len(cert.CRLDistributionPoints) > 0 {
+			revoked, err := b.checkCertAgainstDistributionPoints(ctx, cert, issuer)
+			if resp := b.revocationResponse(cfg, cert, revoked, err); resp != nil {
+				return resp, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// revocationResponse returns the error response denying the login, if any,
+// for the outcome of a revocation check of cert. Failures to check are
+// handled according to the configured fail mode.
+func (b *backend) revocationResponse(cfg *config, cert *x509.Certificate, revoked bool, err error) *logical.Response {
+	switch {
+	case err != nil && cfg.CRLFailMode == crlFailModeSoft:
+		b.Logger().Warn("unable to check certificate revocation status, allowing login", "serial_number", cert.SerialNumber.String(), "error", err)
+	case err != nil:
+		b.Logger().Error("unable to check certificate revocation status", "serial_number", cert.SerialNumber.String(), "error", err)
+		return logical.ErrorResponse("unable to check certificate revocation status")
+	case revoked:
+		return logical.ErrorResponse(fmt.Sprintf("certificate with serial number %s has been revoked", certutil.GetHexFormatted(cert.SerialNumber.Bytes(), ":")))
+	}
+	return nil
+}
+
+// checkCertAgainstDistributionPoints returns whether cert is listed in the
+// CRL published at any of its HTTP(S) distribution points. Only one CRL needs
+// to be retrieved successfully; an error is returned if none could be.
+func (b *backend) checkCertAgainstDistributionPoints(ctx context.Context, cert, issuer *x509.Certificate) (bool, error) {
+	var lastErr error
+	checked := false
+	for _, url := range cert.CRLDistributionPoints {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			continue
+		}
+
+		crl, err := b.fetchCRL(ctx, url, issuer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		checked = true
+
+		for _, revokedCert := range crl.TBSCertList.RevokedCertificates {
+			if revokedCert.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return true, nil
+			}
+		}
+	}
+
+	if !checked {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no supported CRL distribution points found")
+		}
+		return false, lastErr
+	}
+	return false, nil
+}
+
+// fetchCRL retrieves and verifies the CRL at the given URL. CRLs are cached
+// until their next update time.
+func (b *backend) fetchCRL(ctx context.Context, url string, issuer *x509.Certificate) (*pkix.CertificateList, error) {
+	now := time.Now()
+
+	b.fetchedCRLsLock.Lock()
+	crl, ok := b.fetchedCRLs[url]
+	b.fetchedCRLsLock.Unlock()
+	if ok && now.Before(crl.TBSCertList.NextUpdate) {
+		if err := issuer.CheckCRLSignature(crl); err == nil {
+			return crl, nil
+		}
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, crlFetchTimeout)
+	defer cancel()
+
+	resp, err := cleanhttp.DefaultClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("error fetching CRL from %q: {{err}}", url), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching CRL from %q", resp.StatusCode, url)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxCRLSize+1))
+	if err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("error reading CRL from %q: {{err}}", url), err)
+	}
+	if len(body) > maxCRLSize {
+		return nil, fmt.Errorf("CRL from %q exceeds the maximum size of %d bytes", url, maxCRLSize)
+	}
+
+	crl, err = x509.ParseCRL(body)
+	if err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("error parsing CRL from %q: {{err}}", url), err)
+	}
+	if err := issuer.CheckCRLSignature(crl); err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("error verifying CRL from %q: {{err}}", url), err)
+	}
+	if crl.HasExpired(now) {
+		return nil, fmt.Errorf("CRL from %q has expired", url)
+	}
+
+	b.fetchedCRLsLock.Lock()
+	if b.fetchedCRLs == nil {
+		b.fetchedCRLs = map[string]*pkix.CertificateList{}
+	}
+	b.fetchedCRLs[url] = crl
+	b.fetchedCRLsLock.Unlock()
+
+	return crl, nil
+}
+
+// checkCertAgainstOCSP returns whether cert has been revoked according to
+// the HTTP(S) OCSP responders named in it. The first responder that gives a
+// verified good or revoked status decides; an error is returned if none do.
+func (b *backend) checkCertAgainstOCSP(ctx context.Context, cert, issuer *x509.Certificate) (bool, error) {
+	ocspReq, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return false, errwrap.Wrapf("error creating OCSP request: {{err}}", err)
+	}
+
+	var lastErr error
+	for _, url := range cert.OCSPServer {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			continue
+		}
+
+		ocspResp, err := b.queryOCSP(ctx, url, ocspReq, cert, issuer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		switch ocspResp.Status {
+		case ocsp.Good:
+			return false, nil
+		case ocsp.Revoked:
+			return true, nil
+		default:
+			lastErr = fmt.Errorf("OCSP responder %q does not know the certificate", url)
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no supported OCSP responders found")
+	}
+	return false, lastErr
+}
+
+// queryOCSP sends the OCSP request to the responder at the given URL and
+// returns its response once verified against the issuer.
+func (b *backend) queryOCSP(ctx context.Context, url string, ocspReq []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(ocspReq))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	ctx, cancel := context.WithTimeout(ctx, ocspTimeout)
+	defer cancel()
+
+	resp, err := cleanhttp.DefaultClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("error querying OCSP responder %q: {{err}}", url), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from OCSP responder %q", resp.StatusCode, url)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxOCSPResponseSize+1))
+	if err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("error reading response from OCSP responder %q: {{err}}", url), err)
+	}
+	if len(body) > maxOCSPResponseSize {
+		return nil, fmt.Errorf("response from OCSP responder %q exceeds the maximum size of %d bytes", url, maxOCSPResponseSize)
+	}
+
+	// The signature is checked against the issuer, or against a delegated
+	// responder certificate issued by it
+	ocspResp, err := ocsp.ParseResponseForCert(body, cert, issuer)
+	if err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("error parsing response from OCSP responder %q: {{err}}", url), err)
+	}
+	if !ocspResp.NextUpdate.IsZero() && time.Now().After(ocspResp.NextUpdate) {
+		return nil, fmt.Errorf("response from OCSP responder %q has expired", url)
+	}
+
+	return ocspResp, nil
+}
+
 type CRLInfo struct {
 	Serials map[string]RevokedSerialInfo `json:"serials" structs:"serials" mapstructure:"serials"`
 }
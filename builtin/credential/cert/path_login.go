@@ -83,33 +83,28 @@ func (b *backend) pathLogin(ctx context.Context, req *logical.Request, data *fra
 	skid := base64.StdEncoding.EncodeToString(clientCerts[0].SubjectKeyId)
 	akid := base64.StdEncoding.EncodeToString(clientCerts[0].AuthorityKeyId)
 
-	resp := &logical.Response{
-		Auth: &logical.Auth{
-			Period: matched.Entry.Period,
-			InternalData: map[string]interface{}{
-				"subject_key_id":   skid,
-				"authority_key_id": akid,
-			},
-			Policies:    matched.Entry.Policies,
-			DisplayName: matched.Entry.DisplayName,
-			Metadata: map[string]string{
-				"cert_name":        matched.Entry.Name,
-				"common_name":      clientCerts[0].Subject.CommonName,
-				"serial_number":    clientCerts[0].SerialNumber.String(),
-				"subject_key_id":   certutil.GetHexFormatted(clientCerts[0].SubjectKeyId, ":"),
-				"authority_key_id": certutil.GetHexFormatted(clientCerts[0].AuthorityKeyId, ":"),
-			},
-			LeaseOptions: logical.LeaseOptions{
-				Renewable: true,
-				TTL:       matched.Entry.TTL,
-				MaxTTL:    matched.Entry.MaxTTL,
-			},
-			Alias: &logical.Alias{
-				Name: clientCerts[0].Subject.CommonName,
-			},
-			BoundCIDRs: matched.Entry.BoundCIDRs,
+	auth := &logical.Auth{
+		InternalData: map[string]interface{}{
+			"subject_key_id":   skid,
+			"authority_key_id": akid,
+		},
+		DisplayName: matched.Entry.DisplayName,
+		Metadata: map[string]string{
+			"cert_name":        matched.Entry.Name,
+			"common_name":      clientCerts[0].Subject.CommonName,
+			"serial_number":    clientCerts[0].SerialNumber.String(),
+			"subject_key_id":   certutil.GetHexFormatted(clientCerts[0].SubjectKeyId, ":"),
+			"authority_key_id": certutil.GetHexFormatted(clientCerts[0].AuthorityKeyId, ":"),
+		},
+		Alias: &logical.Alias{
+			Name: clientCerts[0].Subject.CommonName,
 		},
 	}
+	matched.Entry.PopulateTokenAuth(auth)
+
+	resp := &logical.Response{
+		Auth: auth,
+	}
 
 	// Generate a response
 	return resp, nil
@@ -159,14 +154,14 @@ func (b *backend) pathLoginRenew(ctx context.Context, req *logical.Request, d *f
 		return nil, nil
 	}
 
-	if !policyutil.EquivalentPolicies(cert.Policies, req.Auth.TokenPolicies) {
+	if !policyutil.EquivalentPolicies(cert.TokenPolicies, req.Auth.TokenPolicies) {
 		return nil, fmt.Errorf("policies have changed, not renewing")
 	}
 
 	resp := &logical.Response{Auth: req.Auth}
-	resp.Auth.TTL = cert.TTL
-	resp.Auth.MaxTTL = cert.MaxTTL
-	resp.Auth.Period = cert.Period
+	resp.Auth.TTL = cert.TokenTTL
+	resp.Auth.MaxTTL = cert.TokenMaxTTL
+	resp.Auth.Period = cert.TokenPeriod
 	return resp, nil
 }
 
@@ -222,6 +217,7 @@ func (b *backend) verifyCredentials(ctx context.Context, req *logical.Request, d
 
 	// Search for a ParsedCert that intersects with the validated chains and any additional constraints
 	matches := make([]*ParsedCert, 0)
+	matchedChains := make([][]*x509.Certificate, 0)
 	for _, trust := range trusted { // For each ParsedCert in the config
 		for _, tCert := range trust.Certificates { // For each certificate in the entry
 			for _, chain := range trustedChains { // For each root chain that we matched
@@ -230,6 +226,7 @@ func (b *backend) verifyCredentials(ctx context.Context, req *logical.Request, d
 						b.matchesConstraints(clientCert, chain, trust) { // validate client cert + matched chain against the config
 						// Add the match to the list
 						matches = append(matches, trust)
+						matchedChains = append(matchedChains, chain)
 					}
 				}
 			}
@@ -241,6 +238,11 @@ func (b *backend) verifyCredentials(ctx context.Context, req *logical.Request, d
 		return nil, logical.ErrorResponse("no chain matching all constraints could be found for this login certificate"), nil
 	}
 
+	// Check the matched chain against the OCSP responders and CRLs of its issuers
+	if resp, err := b.checkRevocation(ctx, req.Storage, matchedChains[0]); resp != nil || err != nil {
+		return nil, resp, err
+	}
+
 	// Return the first matching entry (for backwards compatibility, we continue to just pick one if multiple match)
 	return matches[0], nil, nil
 }
@@ -253,6 +255,7 @@ func (b *backend) matchesConstraints(clientCert *x509.Certificate, trustedChain
 		b.matchesEmailSANs(clientCert, config) &&
 		b.matchesURISANs(clientCert, config) &&
 		b.matchesOrganizationalUnits(clientCert, config) &&
+		b.matchesOrganizations(clientCert, config) &&
 		b.matchesCertificateExtensions(clientCert, config)
 }
 
@@ -378,6 +381,26 @@ func (b *backend) matchesOrganizationalUnits(clientCert *x509.Certificate, confi
 	return false
 }
 
+// matchesOrganizations verifies that the certificate matches at least one
+// configured allowed organization
+func (b *backend) matchesOrganizations(clientCert *x509.Certificate, config *ParsedCert) bool {
+	// Default behavior (no organizations) is to allow all organizations
+	if len(config.Entry.AllowedOrganizations) == 0 {
+		return true
+	}
+
+	// At least one pattern must match at least one name if any patterns are specified
+	for _, allowedOrganization := range config.Entry.AllowedOrganizations {
+		for _, o := range clientCert.Subject.Organization {
+			if glob.Glob(allowedOrganization, o) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // matchesCertificateExtensions verifies that the certificate matches configured
 // required extensions
 func (b *backend) matchesCertificateExtensions(clientCert *x509.Certificate, config *ParsedCert) bool {
@@ -478,7 +501,7 @@ func (b *backend) checkForValidChain(chains [][]*x509.Certificate) bool {
 }
 
 func (b *backend) checkCIDR(cert *CertEntry, req *logical.Request) error {
-	if cidrutil.RemoteAddrIsOk(req.Connection.RemoteAddr, cert.BoundCIDRs) {
+	if cidrutil.RemoteAddrIsOk(req.Connection.RemoteAddr, cert.TokenBoundCIDRs) {
 		return nil
 	}
 	return logical.ErrPermissionDenied
@@ -3,6 +3,8 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -199,3 +201,108 @@ func testAccLogin(t *testing.T, policies []string) logicaltest.TestStep {
 		Check: logicaltest.TestCheckAuth(policies),
 	}
 }
+
+func TestBackend_OrganizationID(t *testing.T) {
+	// A fake GitHub API where the configured organization has been renamed
+	// and its old name taken over by another organization
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/acme":
+			fmt.Fprint(w, `{"login": "acme", "id": 12345}`)
+		case "/user":
+			fmt.Fprint(w, `{"login": "alice"}`)
+		case "/user/orgs":
+			fmt.Fprint(w, `[{"login": "acme", "id": 99999}, {"login": "acme-renamed", "id": 12345}]`)
+		case "/user/teams":
+			fmt.Fprint(w, `[{"name": "Ops", "slug": "ops", "organization": {"id": 12345}}, {"name": "Dev", "slug": "dev", "organization": {"id": 99999}}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	b, err := Factory(context.Background(), &logical.BackendConfig{
+		System: &logical.StaticSystemView{
+			DefaultLeaseTTLVal: time.Hour,
+			MaxLeaseTTLVal:     time.Hour,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage := &logical.InmemStorage{}
+
+	resp, err := b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "config",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"organization": "acme",
+			"base_url":     ts.URL + "/",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v resp: %#v", err, resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "config",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v resp: %#v", err, resp)
+	}
+	if resp.Data["organization_id"].(int64) != 12345 {
+		t.Fatalf("bad: organization_id: %#v", resp.Data["organization_id"])
+	}
+
+	for team, policy := range map[string]string{"ops": "ops-policy", "dev": "dev-policy"} {
+		resp, err = b.HandleRequest(context.Background(), &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "map/teams/" + team,
+			Storage:   storage,
+			Data: map[string]interface{}{
+				"value": policy,
+			},
+		})
+		if err != nil || (resp != nil && resp.IsError()) {
+			t.Fatalf("err: %v resp: %#v", err, resp)
+		}
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "login",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"token": "token",
+		},
+	})
+	if err != nil || resp == nil || resp.IsError() {
+		t.Fatalf("err: %v resp: %#v", err, resp)
+	}
+	if resp.Auth.Metadata["org"] != "acme-renamed" {
+		t.Fatalf("bad: org: %q", resp.Auth.Metadata["org"])
+	}
+	if len(resp.Auth.Policies) != 1 || resp.Auth.Policies[0] != "ops-policy" {
+		t.Fatalf("bad: policies: %v", resp.Auth.Policies)
+	}
+
+	// An organization that cannot be looked up needs an explicit ID
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "config",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"organization": "missing",
+			"base_url":     ts.URL + "/",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got: %#v", resp)
+	}
+}
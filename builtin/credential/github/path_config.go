@@ -6,13 +6,15 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/google/go-github/github"
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/tokenutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
 func pathConfig(b *backend) *framework.Path {
-	return &framework.Path{
+	p := &framework.Path{
 		Pattern: "config",
 		Fields: map[string]*framework.FieldSchema{
 			"organization": &framework.FieldSchema{
@@ -20,6 +22,11 @@ func pathConfig(b *backend) *framework.Path {
 				Description: "The organization users must be part of",
 			},
 
+			"organization_id": &framework.FieldSchema{
+				Type:        framework.TypeInt,
+				Description: "The ID of the organization users must be part of. If not set, it is looked up from the organization name when the configuration is written.",
+			},
+
 			"base_url": &framework.FieldSchema{
 				Type: framework.TypeString,
 				Description: `The API endpoint to use. Useful if you
@@ -28,14 +35,14 @@ API-compatible authentication server.`,
 				DisplayName: "Base URL",
 			},
 			"ttl": &framework.FieldSchema{
-				Type:        framework.TypeString,
-				Description: `Duration after which authentication will be expired`,
-				DisplayName: "TTL",
+				Type:        framework.TypeDurationSecond,
+				Description: tokenutil.DeprecationText("token_ttl"),
+				Deprecated:  true,
 			},
 			"max_ttl": &framework.FieldSchema{
-				Type:        framework.TypeString,
-				Description: `Maximum duration after which authentication will be expired`,
-				DisplayName: "Max TTL",
+				Type:        framework.TypeDurationSecond,
+				Description: tokenutil.DeprecationText("token_max_ttl"),
+				Deprecated:  true,
 			},
 		},
 
@@ -44,6 +51,9 @@ API-compatible authentication server.`,
 			logical.ReadOperation:   b.pathConfigRead,
 		},
 	}
+
+	tokenutil.AddTokenFields(p.Fields)
+	return p
 }
 
 func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
@@ -56,36 +66,52 @@ func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, dat
 		}
 	}
 
-	var ttl time.Duration
-	var err error
-	ttlRaw, ok := data.GetOk("ttl")
-	if !ok || len(ttlRaw.(string)) == 0 {
-		ttl = 0
-	} else {
-		ttl, err = time.ParseDuration(ttlRaw.(string))
+	c := &config{
+		Organization: organization,
+		BaseURL:      baseURL,
+	}
+
+	// Pin the organization by ID so that logins keep working against the
+	// same organization if it is renamed, and do not start matching a
+	// different organization that takes over the old name.
+	if organizationIDRaw, ok := data.GetOk("organization_id"); ok {
+		c.OrganizationID = int64(organizationIDRaw.(int))
+	}
+	if c.OrganizationID < 0 {
+		return logical.ErrorResponse("organization_id cannot be negative"), nil
+	}
+	if c.OrganizationID == 0 && organization != "" {
+		client, err := b.Client("")
 		if err != nil {
-			return logical.ErrorResponse(fmt.Sprintf("Invalid 'ttl':%s", err)), nil
+			return nil, err
+		}
+		if err := c.setBaseURL(client); err != nil {
+			return nil, err
 		}
-	}
 
-	var maxTTL time.Duration
-	maxTTLRaw, ok := data.GetOk("max_ttl")
-	if !ok || len(maxTTLRaw.(string)) == 0 {
-		maxTTL = 0
-	} else {
-		maxTTL, err = time.ParseDuration(maxTTLRaw.(string))
+		org, _, err := client.Organizations.Get(ctx, organization)
 		if err != nil {
-			return logical.ErrorResponse(fmt.Sprintf("Invalid 'max_ttl':%s", err)), nil
+			return logical.ErrorResponse(fmt.Sprintf("unable to fetch the ID of organization %q, set organization_id manually: %s", organization, err)), nil
 		}
+		c.OrganizationID = org.GetID()
 	}
 
-	entry, err := logical.StorageEntryJSON("config", config{
-		Organization: organization,
-		BaseURL:      baseURL,
-		TTL:          ttl,
-		MaxTTL:       maxTTL,
-	})
+	if err := c.ParseTokenFields(req, data); err != nil {
+		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
+	}
+
+	// Handle upgrade cases
+	{
+		if err := tokenutil.UpgradeValue(data, "ttl", "token_ttl", &c.TTL, &c.TokenTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
 
+		if err := tokenutil.UpgradeValue(data, "max_ttl", "token_max_ttl", &c.MaxTTL, &c.TokenMaxTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON("config", c)
 	if err != nil {
 		return nil, err
 	}
@@ -107,16 +133,22 @@ func (b *backend) pathConfigRead(ctx context.Context, req *logical.Request, data
 		return nil, fmt.Errorf("configuration object not found")
 	}
 
-	config.TTL /= time.Second
-	config.MaxTTL /= time.Second
+	d := map[string]interface{}{
+		"organization":    config.Organization,
+		"organization_id": config.OrganizationID,
+		"base_url":        config.BaseURL,
+	}
+	config.PopulateTokenData(d)
+
+	if config.TTL > 0 {
+		d["ttl"] = int64(config.TTL.Seconds())
+	}
+	if config.MaxTTL > 0 {
+		d["max_ttl"] = int64(config.MaxTTL.Seconds())
+	}
 
 	resp := &logical.Response{
-		Data: map[string]interface{}{
-			"organization": config.Organization,
-			"base_url":     config.BaseURL,
-			"ttl":          config.TTL,
-			"max_ttl":      config.MaxTTL,
-		},
+		Data: d,
 	}
 	return resp, nil
 }
@@ -133,14 +165,38 @@ func (b *backend) Config(ctx context.Context, s logical.Storage) (*config, error
 		if err := entry.DecodeJSON(&result); err != nil {
 			return nil, errwrap.Wrapf("error reading configuration: {{err}}", err)
 		}
+
+		if result.TokenTTL == 0 && result.TTL > 0 {
+			result.TokenTTL = result.TTL
+		}
+		if result.TokenMaxTTL == 0 && result.MaxTTL > 0 {
+			result.TokenMaxTTL = result.MaxTTL
+		}
 	}
 
 	return &result, nil
 }
 
 type config struct {
-	Organization string        `json:"organization" structs:"organization" mapstructure:"organization"`
-	BaseURL      string        `json:"base_url" structs:"base_url" mapstructure:"base_url"`
-	TTL          time.Duration `json:"ttl" structs:"ttl" mapstructure:"ttl"`
-	MaxTTL       time.Duration `json:"max_ttl" structs:"max_ttl" mapstructure:"max_ttl"`
+	tokenutil.TokenParams
+
+	Organization   string        `json:"organization" structs:"organization" mapstructure:"organization"`
+	OrganizationID int64         `json:"organization_id" structs:"organization_id" mapstructure:"organization_id"`
+	BaseURL        string        `json:"base_url" structs:"base_url" mapstructure:"base_url"`
+	TTL            time.Duration `json:"ttl" structs:"ttl" mapstructure:"ttl"`
+	MaxTTL         time.Duration `json:"max_ttl" structs:"max_ttl" mapstructure:"max_ttl"`
+}
+
+// setBaseURL points the client at the configured API endpoint, if any.
+func (c *config) setBaseURL(client *github.Client) error {
+	if c.BaseURL == "" {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return errwrap.Wrapf("successfully parsed base_url when set but failing to parse now: {{err}}", err)
+	}
+	client.BaseURL = parsedURL
+	return nil
 }
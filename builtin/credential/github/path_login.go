@@ -3,11 +3,9 @@ package github
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strings"
 
 	"github.com/google/go-github/github"
-	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/policyutil"
 	"github.com/hashicorp/vault/sdk/logical"
@@ -68,27 +66,29 @@ func (b *backend) pathLogin(ctx context.Context, req *logical.Request, data *fra
 		return nil, err
 	}
 
-	resp := &logical.Response{
-		Auth: &logical.Auth{
-			InternalData: map[string]interface{}{
-				"token": token,
-			},
-			Policies: verifyResp.Policies,
-			Metadata: map[string]string{
-				"username": *verifyResp.User.Login,
-				"org":      *verifyResp.Org.Login,
-			},
-			DisplayName: *verifyResp.User.Login,
-			LeaseOptions: logical.LeaseOptions{
-				TTL:       config.TTL,
-				MaxTTL:    config.MaxTTL,
-				Renewable: true,
-			},
-			Alias: &logical.Alias{
-				Name: *verifyResp.User.Login,
-			},
+	auth := &logical.Auth{
+		InternalData: map[string]interface{}{
+			"token": token,
+		},
+		Metadata: map[string]string{
+			"username": *verifyResp.User.Login,
+			"org":      *verifyResp.Org.Login,
+		},
+		DisplayName: *verifyResp.User.Login,
+		Alias: &logical.Alias{
+			Name: *verifyResp.User.Login,
 		},
 	}
+	config.PopulateTokenAuth(auth)
+
+	// Add in configured policies from user/group mapping
+	if len(verifyResp.Policies) > 0 {
+		auth.Policies = append(auth.Policies, verifyResp.Policies...)
+	}
+
+	resp := &logical.Response{
+		Auth: auth,
+	}
 
 	for _, teamName := range verifyResp.TeamNames {
 		if teamName == "" {
@@ -121,18 +121,20 @@ func (b *backend) pathLoginRenew(ctx context.Context, req *logical.Request, d *f
 	} else {
 		verifyResp = verifyResponse
 	}
-	if !policyutil.EquivalentPolicies(verifyResp.Policies, req.Auth.TokenPolicies) {
-		return nil, fmt.Errorf("policies do not match")
-	}
-
 	config, err := b.Config(ctx, req.Storage)
 	if err != nil {
 		return nil, err
 	}
 
+	finalPolicies := append(append([]string{}, config.TokenPolicies...), verifyResp.Policies...)
+	if !policyutil.EquivalentPolicies(finalPolicies, req.Auth.TokenPolicies) {
+		return nil, fmt.Errorf("policies do not match")
+	}
+
 	resp := &logical.Response{Auth: req.Auth}
-	resp.Auth.TTL = config.TTL
-	resp.Auth.MaxTTL = config.MaxTTL
+	resp.Auth.Period = config.TokenPeriod
+	resp.Auth.TTL = config.TokenTTL
+	resp.Auth.MaxTTL = config.TokenMaxTTL
 
 	// Remove old aliases
 	resp.Auth.GroupAliases = nil
@@ -161,12 +163,8 @@ func (b *backend) verifyCredentials(ctx context.Context, req *logical.Request, t
 		return nil, nil, err
 	}
 
-	if config.BaseURL != "" {
-		parsedURL, err := url.Parse(config.BaseURL)
-		if err != nil {
-			return nil, nil, errwrap.Wrapf("successfully parsed base_url when set but failing to parse now: {{err}}", err)
-		}
-		client.BaseURL = parsedURL
+	if err := config.setBaseURL(client); err != nil {
+		return nil, nil, err
 	}
 
 	// Get the user
@@ -196,6 +194,15 @@ func (b *backend) verifyCredentials(ctx context.Context, req *logical.Request, t
 	}
 
 	for _, o := range allOrgs {
+		// Configurations written before organization IDs were pinned fall
+		// back to matching on the name
+		if config.OrganizationID != 0 {
+			if o.GetID() == config.OrganizationID {
+				org = o
+				break
+			}
+			continue
+		}
 		if strings.EqualFold(*o.Login, config.Organization) {
 			org = o
 			break
@@ -6,11 +6,12 @@ import (
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/consts"
 	"github.com/hashicorp/vault/sdk/helper/ldaputil"
+	"github.com/hashicorp/vault/sdk/helper/tokenutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
 func pathConfig(b *backend) *framework.Path {
-	return &framework.Path{
+	p := &framework.Path{
 		Pattern: `config`,
 		Fields:  ldaputil.ConfigFields(),
 
@@ -22,12 +23,22 @@ func pathConfig(b *backend) *framework.Path {
 		HelpSynopsis:    pathConfigHelpSyn,
 		HelpDescription: pathConfigHelpDesc,
 	}
+
+	tokenutil.AddTokenFields(p.Fields)
+	return p
+}
+
+// ldapConfigEntry is the stored configuration of the backend, which adds the
+// parameters of issued tokens to the shared LDAP connection settings
+type ldapConfigEntry struct {
+	tokenutil.TokenParams
+	*ldaputil.ConfigEntry
 }
 
 /*
  * Construct ConfigEntry struct using stored configuration.
  */
-func (b *backend) Config(ctx context.Context, req *logical.Request) (*ldaputil.ConfigEntry, error) {
+func (b *backend) Config(ctx context.Context, req *logical.Request) (*ldapConfigEntry, error) {
 	// Schema for ConfigEntry
 	fd, err := b.getConfigFieldData()
 	if err != nil {
@@ -35,10 +46,13 @@ func (b *backend) Config(ctx context.Context, req *logical.Request) (*ldaputil.C
 	}
 
 	// Create a new ConfigEntry, filling in defaults where appropriate
-	result, err := ldaputil.NewConfigEntry(fd)
+	configEntry, err := ldaputil.NewConfigEntry(fd)
 	if err != nil {
 		return nil, err
 	}
+	result := &ldapConfigEntry{
+		ConfigEntry: configEntry,
+	}
 
 	storedConfig, err := req.Storage.Get(ctx, "config")
 	if err != nil {
@@ -55,7 +69,7 @@ func (b *backend) Config(ctx context.Context, req *logical.Request) (*ldaputil.C
 
 	// Deserialize stored configuration.
 	// Fields not specified in storedConfig will retain their defaults.
-	if err := storedConfig.DecodeJSON(&result); err != nil {
+	if err := storedConfig.DecodeJSON(result); err != nil {
 		return nil, err
 	}
 
@@ -89,18 +103,27 @@ func (b *backend) pathConfigRead(ctx context.Context, req *logical.Request, d *f
 		return nil, nil
 	}
 
+	data := cfg.PasswordlessMap()
+	cfg.PopulateTokenData(data)
+
 	resp := &logical.Response{
-		Data: cfg.PasswordlessMap(),
+		Data: data,
 	}
 	return resp, nil
 }
 
 func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
 	// Build a ConfigEntry struct out of the supplied FieldData
-	cfg, err := ldaputil.NewConfigEntry(d)
+	configEntry, err := ldaputil.NewConfigEntry(d)
 	if err != nil {
 		return logical.ErrorResponse(err.Error()), nil
 	}
+	cfg := &ldapConfigEntry{
+		ConfigEntry: configEntry,
+	}
+	if err := cfg.ParseTokenFields(req, d); err != nil {
+		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
+	}
 
 	// On write, if not specified, use false. We do this here so upgrade logic
 	// works since it calls the same newConfigEntry function
@@ -56,7 +56,35 @@ type backend struct {
 	*framework.Backend
 }
 
-func (b *backend) Login(ctx context.Context, req *logical.Request, username string, password string) ([]string, *logical.Response, []string, error) {
+type mfaFactor struct {
+	Id       string `json:"id"`
+	Type     string `json:"factorType"`
+	Provider string `json:"provider"`
+}
+
+// selectMFAFactor picks the factor used to satisfy an MFA_REQUIRED
+// transaction. When a TOTP passcode is supplied an Okta or Google TOTP
+// factor is preferred, otherwise Okta Verify push is used.
+func selectMFAFactor(factors []mfaFactor, passcode string) (mfaFactor, bool) {
+	for _, v := range factors {
+		if passcode != "" {
+			if v.Type == "token:software:totp" && (v.Provider == "OKTA" || v.Provider == "GOOGLE") {
+				return v, true
+			}
+			continue
+		}
+		if v.Type == "push" && v.Provider == "OKTA" {
+			return v, true
+		}
+	}
+	return mfaFactor{}, false
+}
+
+// Login verifies the user's credentials with Okta and returns the user's
+// policies and groups. If skipMFA is set, a pending MFA challenge is not
+// performed; renewals use this, as they only need to re-check the user and
+// their groups.
+func (b *backend) Login(ctx context.Context, req *logical.Request, username, password, passcode string, skipMFA bool) ([]string, *logical.Response, []string, error) {
 	cfg, err := b.Config(ctx, req.Storage)
 	if err != nil {
 		return nil, nil, nil, err
@@ -67,12 +95,6 @@ func (b *backend) Login(ctx context.Context, req *logical.Request, username stri
 
 	client := cfg.OktaClient()
 
-	type mfaFactor struct {
-		Id       string `json:"id"`
-		Type     string `json:"factorType"`
-		Provider string `json:"provider"`
-	}
-
 	type embeddedResult struct {
 		User    okta.User   `json:"user"`
 		Factors []mfaFactor `json:"factors"`
@@ -142,23 +164,16 @@ func (b *backend) Login(ctx context.Context, req *logical.Request, username stri
 		// active factor enrollment). This bypass removes visibility
 		// into the authenticating user's password expiry, but still ensures the
 		// credentials are valid and the user is not locked out.
-		if cfg.BypassOktaMFA {
+		if cfg.BypassOktaMFA || skipMFA {
 			result.Status = "SUCCESS"
 			break
 		}
 
-		factorAvailable := false
-
-		var selectedFactor mfaFactor
-		// only okta push is currently supported
-		for _, v := range result.Embedded.Factors {
-			if v.Type == "push" && v.Provider == "OKTA" {
-				factorAvailable = true
-				selectedFactor = v
-			}
-		}
-
+		selectedFactor, factorAvailable := selectMFAFactor(result.Embedded.Factors, passcode)
 		if !factorAvailable {
+			if passcode != "" {
+				return nil, logical.ErrorResponse("a TOTP factor is required in order to perform MFA with a passcode"), nil, nil
+			}
 			return nil, logical.ErrorResponse("Okta Verify Push factor is required in order to perform MFA"), nil, nil
 		}
 
@@ -166,6 +181,9 @@ func (b *backend) Login(ctx context.Context, req *logical.Request, username stri
 		payload := map[string]interface{}{
 			"stateToken": result.StateToken,
 		}
+		if passcode != "" {
+			payload["passCode"] = passcode
+		}
 		verifyReq, err := client.NewRequest("POST", requestPath, payload)
 		if err != nil {
 			return nil, nil, nil, err
@@ -195,3 +195,28 @@ func testAccLogin(t *testing.T, user, password string, keys []string) logicaltes
 		Check: logicaltest.TestCheckAuth(keys),
 	}
 }
+
+func TestOkta_SelectMFAFactor(t *testing.T) {
+	factors := []mfaFactor{
+		{Id: "sms", Type: "sms", Provider: "OKTA"},
+		{Id: "push", Type: "push", Provider: "OKTA"},
+		{Id: "totp", Type: "token:software:totp", Provider: "GOOGLE"},
+	}
+
+	f, ok := selectMFAFactor(factors, "")
+	if !ok || f.Id != "push" {
+		t.Fatalf("expected push factor, got %#v", f)
+	}
+
+	f, ok = selectMFAFactor(factors, "123456")
+	if !ok || f.Id != "totp" {
+		t.Fatalf("expected totp factor, got %#v", f)
+	}
+
+	if _, ok := selectMFAFactor(factors[:2], "123456"); ok {
+		t.Fatal("expected no factor when TOTP is not enrolled")
+	}
+	if _, ok := selectMFAFactor(factors[2:], ""); ok {
+		t.Fatal("expected no factor when push is not enrolled")
+	}
+}
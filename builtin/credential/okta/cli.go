@@ -75,8 +75,16 @@ Usage: vault login -method=okta [CONFIG K=V...]
 
       $ vault login -method=okta username=bob password=password
 
+  Authenticate as "sally" using an Okta Verify TOTP code for MFA:
+
+      $ vault login -method=okta username=sally passcode=123456
+
 Configuration:
 
+  passcode=<string>
+      TOTP passcode to use when Okta requires MFA. If not provided, an Okta
+      Verify push notification is sent instead.
+
   password=<string>
       Okta password to use for authentication. If not provided, the CLI will
       prompt for this on stdin.
@@ -10,6 +10,7 @@ import (
 	"github.com/chrismalek/oktasdk-go/okta"
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/tokenutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
@@ -19,7 +20,7 @@ const (
 )
 
 func pathConfig(b *backend) *framework.Path {
-	return &framework.Path{
+	p := &framework.Path{
 		Pattern: `config`,
 		Fields: map[string]*framework.FieldSchema{
 			"organization": &framework.FieldSchema{
@@ -54,11 +55,13 @@ func pathConfig(b *backend) *framework.Path {
 			},
 			"ttl": &framework.FieldSchema{
 				Type:        framework.TypeDurationSecond,
-				Description: `Duration after which authentication will be expired`,
+				Description: tokenutil.DeprecationText("token_ttl"),
+				Deprecated:  true,
 			},
 			"max_ttl": &framework.FieldSchema{
 				Type:        framework.TypeDurationSecond,
-				Description: `Maximum duration after which authentication will be expired`,
+				Description: tokenutil.DeprecationText("token_max_ttl"),
+				Deprecated:  true,
 			},
 			"bypass_okta_mfa": &framework.FieldSchema{
 				Type:        framework.TypeBool,
@@ -77,6 +80,9 @@ func pathConfig(b *backend) *framework.Path {
 
 		HelpSynopsis: pathConfigHelp,
 	}
+
+	tokenutil.AddTokenFields(p.Fields)
+	return p
 }
 
 // Config returns the configuration for this backend.
@@ -96,6 +102,13 @@ func (b *backend) Config(ctx context.Context, s logical.Storage) (*ConfigEntry,
 		}
 	}
 
+	if result.TokenTTL == 0 && result.TTL > 0 {
+		result.TokenTTL = result.TTL
+	}
+	if result.TokenMaxTTL == 0 && result.MaxTTL > 0 {
+		result.TokenMaxTTL = result.MaxTTL
+	}
+
 	return &result, nil
 }
 
@@ -108,14 +121,22 @@ func (b *backend) pathConfigRead(ctx context.Context, req *logical.Request, d *f
 		return nil, nil
 	}
 
+	data := map[string]interface{}{
+		"organization":    cfg.Org,
+		"org_name":        cfg.Org,
+		"bypass_okta_mfa": cfg.BypassOktaMFA,
+	}
+	cfg.PopulateTokenData(data)
+
+	if cfg.TTL > 0 {
+		data["ttl"] = int64(cfg.TTL.Seconds())
+	}
+	if cfg.MaxTTL > 0 {
+		data["max_ttl"] = int64(cfg.MaxTTL.Seconds())
+	}
+
 	resp := &logical.Response{
-		Data: map[string]interface{}{
-			"organization":    cfg.Org,
-			"org_name":        cfg.Org,
-			"ttl":             cfg.TTL.Seconds(),
-			"max_ttl":         cfg.MaxTTL.Seconds(),
-			"bypass_okta_mfa": cfg.BypassOktaMFA,
-		},
+		Data: data,
 	}
 	if cfg.BaseURL != "" {
 		resp.Data["base_url"] = cfg.BaseURL
@@ -192,18 +213,19 @@ func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, d *
 		cfg.BypassOktaMFA = bypass.(bool)
 	}
 
-	ttl, ok := d.GetOk("ttl")
-	if ok {
-		cfg.TTL = time.Duration(ttl.(int)) * time.Second
-	} else if req.Operation == logical.CreateOperation {
-		cfg.TTL = time.Duration(d.Get("ttl").(int)) * time.Second
+	if err := cfg.ParseTokenFields(req, d); err != nil {
+		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
 	}
 
-	maxTTL, ok := d.GetOk("max_ttl")
-	if ok {
-		cfg.MaxTTL = time.Duration(maxTTL.(int)) * time.Second
-	} else if req.Operation == logical.CreateOperation {
-		cfg.MaxTTL = time.Duration(d.Get("max_ttl").(int)) * time.Second
+	// Handle upgrade cases
+	{
+		if err := tokenutil.UpgradeValue(d, "ttl", "token_ttl", &cfg.TTL, &cfg.TokenTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+
+		if err := tokenutil.UpgradeValue(d, "max_ttl", "token_max_ttl", &cfg.MaxTTL, &cfg.TokenMaxTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
 	}
 
 	jsonCfg, err := logical.StorageEntryJSON("config", cfg)
@@ -251,6 +273,8 @@ func (c *ConfigEntry) OktaClient() *okta.Client {
 
 // ConfigEntry for Okta
 type ConfigEntry struct {
+	tokenutil.TokenParams
+
 	Org           string        `json:"organization"`
 	Token         string        `json:"token"`
 	BaseURL       string        `json:"base_url"`
@@ -9,6 +9,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/policyutil"
+	"github.com/hashicorp/vault/sdk/helper/strutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
@@ -55,8 +56,11 @@ func (b *backend) pathLoginAliasLookahead(ctx context.Context, req *logical.Requ
 func (b *backend) pathLogin(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
+	// The passcode field is added by the MFA wrapper. Without a Vault MFA
+	// method configured, it is used for Okta's own TOTP factors.
+	passcode := d.Get("passcode").(string)
 
-	policies, resp, groupNames, err := b.Login(ctx, req, username, password)
+	policies, resp, groupNames, err := b.Login(ctx, req, username, password, passcode, false)
 	// Handle an internal error
 	if err != nil {
 		return nil, err
@@ -70,32 +74,35 @@ func (b *backend) pathLogin(ctx context.Context, req *logical.Request, d *framew
 		resp = &logical.Response{}
 	}
 
-	sort.Strings(policies)
-
 	cfg, err := b.getConfig(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	resp.Auth = &logical.Auth{
-		Policies: policies,
-		Metadata: map[string]string{
-			"username": username,
-			"policies": strings.Join(policies, ","),
-		},
+	auth := &logical.Auth{
 		InternalData: map[string]interface{}{
 			"password": password,
 		},
 		DisplayName: username,
-		LeaseOptions: logical.LeaseOptions{
-			TTL:       cfg.TTL,
-			MaxTTL:    cfg.MaxTTL,
-			Renewable: true,
-		},
 		Alias: &logical.Alias{
 			Name: username,
 		},
 	}
+	cfg.PopulateTokenAuth(auth)
+
+	// Add in configured policies from mappings
+	if len(policies) > 0 {
+		auth.Policies = append(auth.Policies, policies...)
+	}
+	auth.Policies = strutil.RemoveDuplicates(auth.Policies, false)
+	sort.Strings(auth.Policies)
+
+	auth.Metadata = map[string]string{
+		"username": username,
+		"policies": strings.Join(auth.Policies, ","),
+	}
+
+	resp.Auth = auth
 
 	for _, groupName := range groupNames {
 		if groupName == "" {
@@ -113,23 +120,29 @@ func (b *backend) pathLoginRenew(ctx context.Context, req *logical.Request, d *f
 	username := req.Auth.Metadata["username"]
 	password := req.Auth.InternalData["password"].(string)
 
-	loginPolicies, resp, groupNames, err := b.Login(ctx, req, username, password)
-	if len(loginPolicies) == 0 {
-		return resp, err
+	cfg, err := b.getConfig(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	if !policyutil.EquivalentPolicies(loginPolicies, req.Auth.TokenPolicies) {
-		return nil, fmt.Errorf("policies have changed, not renewing")
+	// Renewals only re-check the user and their groups, so MFA is skipped
+	loginPolicies, resp, groupNames, err := b.Login(ctx, req, username, password, "", true)
+	if len(loginPolicies) == 0 && len(cfg.TokenPolicies) == 0 {
+		return resp, err
+	}
+	if err != nil || (resp != nil && resp.IsError()) {
+		return resp, err
 	}
 
-	cfg, err := b.getConfig(ctx, req)
-	if err != nil {
-		return nil, err
+	finalPolicies := append(append([]string{}, cfg.TokenPolicies...), loginPolicies...)
+	if !policyutil.EquivalentPolicies(finalPolicies, req.Auth.TokenPolicies) {
+		return nil, fmt.Errorf("policies have changed, not renewing")
 	}
 
 	resp.Auth = req.Auth
-	resp.Auth.TTL = cfg.TTL
-	resp.Auth.MaxTTL = cfg.MaxTTL
+	resp.Auth.Period = cfg.TokenPeriod
+	resp.Auth.TTL = cfg.TokenTTL
+	resp.Auth.MaxTTL = cfg.TokenMaxTTL
 
 	// Remove old aliases
 	resp.Auth.GroupAliases = nil
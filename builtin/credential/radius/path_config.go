@@ -5,11 +5,12 @@ import (
 	"strings"
 
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/tokenutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
 func pathConfig(b *backend) *framework.Path {
-	return &framework.Path{
+	p := &framework.Path{
 		Pattern: "config",
 		Fields: map[string]*framework.FieldSchema{
 			"host": &framework.FieldSchema{
@@ -68,6 +69,9 @@ func pathConfig(b *backend) *framework.Path {
 		HelpSynopsis:    pathConfigHelpSyn,
 		HelpDescription: pathConfigHelpDesc,
 	}
+
+	tokenutil.AddTokenFields(p.Fields)
+	return p
 }
 
 // Establishes dichotomy of request operation between CreateOperation and UpdateOperation.
@@ -111,16 +115,19 @@ func (b *backend) pathConfigRead(ctx context.Context, req *logical.Request, d *f
 		return nil, nil
 	}
 
+	data := map[string]interface{}{
+		"host":                       cfg.Host,
+		"port":                       cfg.Port,
+		"unregistered_user_policies": cfg.UnregisteredUserPolicies,
+		"dial_timeout":               cfg.DialTimeout,
+		"read_timeout":               cfg.ReadTimeout,
+		"nas_port":                   cfg.NasPort,
+		"nas_identifier":             cfg.NasIdentifier,
+	}
+	cfg.PopulateTokenData(data)
+
 	resp := &logical.Response{
-		Data: map[string]interface{}{
-			"host":                       cfg.Host,
-			"port":                       cfg.Port,
-			"unregistered_user_policies": cfg.UnregisteredUserPolicies,
-			"dial_timeout":               cfg.DialTimeout,
-			"read_timeout":               cfg.ReadTimeout,
-			"nas_port":                   cfg.NasPort,
-			"nas_identifier":             cfg.NasIdentifier,
-		},
+		Data: data,
 	}
 	return resp, nil
 }
@@ -207,6 +214,10 @@ func (b *backend) pathConfigCreateUpdate(ctx context.Context, req *logical.Reque
 		cfg.NasIdentifier = d.Get("nas_identifier").(string)
 	}
 
+	if err := cfg.ParseTokenFields(req, d); err != nil {
+		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
+	}
+
 	entry, err := logical.StorageEntryJSON("config", cfg)
 	if err != nil {
 		return nil, err
@@ -219,6 +230,8 @@ func (b *backend) pathConfigCreateUpdate(ctx context.Context, req *logical.Reque
 }
 
 type ConfigEntry struct {
+	tokenutil.TokenParams
+
 	Host                     string   `json:"host" structs:"host" mapstructure:"host"`
 	Port                     int      `json:"port" structs:"port" mapstructure:"port"`
 	Secret                   string   `json:"secret" structs:"secret" mapstructure:"secret"`
@@ -2,6 +2,7 @@ package userpass
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hashicorp/vault/helper/mfa"
 	"github.com/hashicorp/vault/sdk/framework"
@@ -49,6 +50,16 @@ type backend struct {
 	*framework.Backend
 }
 
+// passwordPolicies returns the system view as a PasswordPolicySystemView, or
+// an error if the Vault running the backend doesn't support password policies
+func (b *backend) passwordPolicies() (logical.PasswordPolicySystemView, error) {
+	sys, ok := b.System().(logical.PasswordPolicySystemView)
+	if !ok {
+		return nil, errors.New("password policies are not supported by this version of Vault")
+	}
+	return sys, nil
+}
+
 const backendHelp = `
 The "userpass" credential provider allows authentication using
 a combination of a username and password. No additional factors
@@ -59,7 +59,7 @@ func TestBackend_TTL(t *testing.T) {
 	if err != nil || (resp != nil && resp.IsError()) {
 		t.Fatalf("bad: resp: %#v\nerr: %v\n", resp, err)
 	}
-	if resp.Data["ttl"].(float64) != 0 && resp.Data["max_ttl"].(float64) != 0 {
+	if resp.Data["token_ttl"].(int64) != 0 || resp.Data["token_max_ttl"].(int64) != 0 {
 		t.Fatalf("bad: ttl and max_ttl are not set correctly")
 	}
 
@@ -84,11 +84,104 @@ func TestBackend_TTL(t *testing.T) {
 	if err != nil || (resp != nil && resp.IsError()) {
 		t.Fatalf("bad: resp: %#v\nerr: %v\n", resp, err)
 	}
-	if resp.Data["ttl"].(float64) != 300 && resp.Data["max_ttl"].(float64) != 600 {
+	if resp.Data["token_ttl"].(int64) != 300 || resp.Data["token_max_ttl"].(int64) != 600 {
 		t.Fatalf("bad: ttl and max_ttl are not set correctly")
 	}
 }
 
+func TestBackend_passwordPolicy(t *testing.T) {
+	storage := &logical.InmemStorage{}
+
+	config := logical.TestBackendConfig()
+	config.StorageView = storage
+	config.System = &logical.StaticSystemView{
+		PasswordPolicies: map[string]string{
+			"digits": `
+length = 8
+rule "charset" {
+  charset = "abcdefghijklmnopqrstuvwxyz"
+}
+rule "charset" {
+  charset = "0123456789"
+  min_chars = 2
+}`,
+		},
+	}
+
+	ctx := context.Background()
+
+	b, err := Factory(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(path string, data map[string]interface{}, op logical.Operation) *logical.Response {
+		resp, err := b.HandleRequest(ctx, &logical.Request{
+			Path:      path,
+			Operation: op,
+			Storage:   storage,
+			Data:      data,
+			Connection: &logical.Connection{
+				RemoteAddr: "127.0.0.1",
+			},
+		})
+		if err != nil && err != logical.ErrInvalidRequest {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	// Passwords not satisfying the policy are rejected
+	resp := write("users/testuser", map[string]interface{}{
+		"password":        "password",
+		"password_policy": "digits",
+	}, logical.CreateOperation)
+	if resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got: %#v", resp)
+	}
+
+	// A password is generated when none is given
+	resp = write("users/testuser", map[string]interface{}{
+		"password_policy": "digits",
+	}, logical.CreateOperation)
+	if resp == nil || resp.IsError() {
+		t.Fatalf("bad: %#v", resp)
+	}
+	generated := resp.Data["password"].(string)
+	if len(generated) != 8 {
+		t.Fatalf("bad generated password: %q", generated)
+	}
+
+	resp = write("login/testuser", map[string]interface{}{
+		"password": generated,
+	}, logical.UpdateOperation)
+	if resp == nil || resp.IsError() || resp.Auth == nil {
+		t.Fatalf("failed to log in with generated password: %#v", resp)
+	}
+
+	// Password updates are checked against the policy
+	resp = write("users/testuser/password", map[string]interface{}{
+		"password": "password1",
+	}, logical.UpdateOperation)
+	if resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got: %#v", resp)
+	}
+	resp = write("users/testuser/password", map[string]interface{}{
+		"password": "password12",
+	}, logical.UpdateOperation)
+	if resp != nil && resp.IsError() {
+		t.Fatalf("bad: %#v", resp)
+	}
+
+	// Unknown policies fail generation
+	resp = write("users/otheruser", map[string]interface{}{
+		"password_policy": "unknown",
+	}, logical.CreateOperation)
+	if resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got: %#v", resp)
+	}
+}
+
 func TestBackend_basic(t *testing.T) {
 	b, err := Factory(context.Background(), &logical.BackendConfig{
 		Logger: nil,
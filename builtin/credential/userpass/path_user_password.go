@@ -45,9 +45,9 @@ func (b *backend) pathUserPasswordUpdate(ctx context.Context, req *logical.Reque
 		return nil, fmt.Errorf("username does not exist")
 	}
 
-	userErr, intErr := b.updateUserPassword(req, d, userEntry)
+	userErr, intErr := b.updateUserPassword(ctx, req, d, userEntry)
 	if intErr != nil {
-		return nil, err
+		return nil, intErr
 	}
 	if userErr != nil {
 		return logical.ErrorResponse(userErr.Error()), logical.ErrInvalidRequest
@@ -56,18 +56,31 @@ func (b *backend) pathUserPasswordUpdate(ctx context.Context, req *logical.Reque
 	return nil, b.setUser(ctx, req.Storage, username, userEntry)
 }
 
-func (b *backend) updateUserPassword(req *logical.Request, d *framework.FieldData, userEntry *UserEntry) (error, error) {
+func (b *backend) updateUserPassword(ctx context.Context, req *logical.Request, d *framework.FieldData, userEntry *UserEntry) (error, error) {
 	password := d.Get("password").(string)
 	if password == "" {
 		return fmt.Errorf("missing password"), nil
 	}
-	// Generate a hash of the password
+	if userEntry.PasswordPolicy != "" {
+		policies, err := b.passwordPolicies()
+		if err != nil {
+			return err, nil
+		}
+		if err := policies.ValidatePasswordFromPolicy(ctx, userEntry.PasswordPolicy, password); err != nil {
+			return fmt.Errorf("password does not satisfy password policy %q: %v", userEntry.PasswordPolicy, err), nil
+		}
+	}
+	return nil, setUserPasswordHash(userEntry, password)
+}
+
+// setUserPasswordHash stores a bcrypt hash of the password on the user entry
+func setUserPasswordHash(userEntry *UserEntry, password string) error {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	userEntry.PasswordHash = hash
-	return nil, nil
+	return nil
 }
 
 const pathUserPasswordHelpSyn = `
@@ -8,8 +8,7 @@ import (
 
 	sockaddr "github.com/hashicorp/go-sockaddr"
 	"github.com/hashicorp/vault/sdk/framework"
-	"github.com/hashicorp/vault/sdk/helper/parseutil"
-	"github.com/hashicorp/vault/sdk/helper/policyutil"
+	"github.com/hashicorp/vault/sdk/helper/tokenutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
@@ -27,7 +26,7 @@ func pathUsersList(b *backend) *framework.Path {
 }
 
 func pathUsers(b *backend) *framework.Path {
-	return &framework.Path{
+	p := &framework.Path{
 		Pattern: "users/" + framework.GenericNameRegex("username"),
 		Fields: map[string]*framework.FieldSchema{
 			"username": &framework.FieldSchema{
@@ -42,23 +41,33 @@ func pathUsers(b *backend) *framework.Path {
 
 			"policies": &framework.FieldSchema{
 				Type:        framework.TypeCommaStringSlice,
-				Description: "Comma-separated list of policies",
+				Description: tokenutil.DeprecationText("token_policies"),
+				Deprecated:  true,
+			},
+
+			"password_policy": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `Name of the password policy that passwords for this user must satisfy.
+If set and no password is given when creating the user, a password is
+generated from the policy and returned.`,
 			},
 
 			"ttl": &framework.FieldSchema{
 				Type:        framework.TypeDurationSecond,
-				Description: "Duration after which authentication will be expired",
+				Description: tokenutil.DeprecationText("token_ttl"),
+				Deprecated:  true,
 			},
 
 			"max_ttl": &framework.FieldSchema{
 				Type:        framework.TypeDurationSecond,
-				Description: "Maximum duration after which authentication will be expired",
+				Description: tokenutil.DeprecationText("token_max_ttl"),
+				Deprecated:  true,
 			},
 
 			"bound_cidrs": &framework.FieldSchema{
-				Type: framework.TypeCommaStringSlice,
-				Description: `Comma separated string or list of CIDR blocks. If set, specifies the blocks of
-IP addresses which can perform the login operation.`,
+				Type:        framework.TypeCommaStringSlice,
+				Description: tokenutil.DeprecationText("token_bound_cidrs"),
+				Deprecated:  true,
 			},
 		},
 
@@ -74,6 +83,9 @@ IP addresses which can perform the login operation.`,
 		HelpSynopsis:    pathUserHelpSyn,
 		HelpDescription: pathUserHelpDesc,
 	}
+
+	tokenutil.AddTokenFields(p.Fields)
+	return p
 }
 
 func (b *backend) userExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
@@ -103,6 +115,21 @@ func (b *backend) user(ctx context.Context, s logical.Storage, username string)
 		return nil, err
 	}
 
+	// Users created before the token fields were shared only have the
+	// deprecated values set
+	if result.TokenTTL == 0 && result.TTL > 0 {
+		result.TokenTTL = result.TTL
+	}
+	if result.TokenMaxTTL == 0 && result.MaxTTL > 0 {
+		result.TokenMaxTTL = result.MaxTTL
+	}
+	if len(result.TokenPolicies) == 0 && len(result.Policies) > 0 {
+		result.TokenPolicies = result.Policies
+	}
+	if len(result.TokenBoundCIDRs) == 0 && len(result.BoundCIDRs) > 0 {
+		result.TokenBoundCIDRs = result.BoundCIDRs
+	}
+
 	return &result, nil
 }
 
@@ -141,13 +168,27 @@ func (b *backend) pathUserRead(ctx context.Context, req *logical.Request, d *fra
 		return nil, nil
 	}
 
+	data := map[string]interface{}{
+		"password_policy": user.PasswordPolicy,
+	}
+	user.PopulateTokenData(data)
+
+	// Add backwards compat data
+	if user.TTL > 0 {
+		data["ttl"] = int64(user.TTL.Seconds())
+	}
+	if user.MaxTTL > 0 {
+		data["max_ttl"] = int64(user.MaxTTL.Seconds())
+	}
+	if len(user.Policies) > 0 {
+		data["policies"] = data["token_policies"]
+	}
+	if len(user.BoundCIDRs) > 0 {
+		data["bound_cidrs"] = data["token_bound_cidrs"]
+	}
+
 	return &logical.Response{
-		Data: map[string]interface{}{
-			"policies":    user.Policies,
-			"ttl":         user.TTL.Seconds(),
-			"max_ttl":     user.MaxTTL.Seconds(),
-			"bound_cidrs": user.BoundCIDRs,
-		},
+		Data: data,
 	}, nil
 }
 
@@ -162,48 +203,78 @@ func (b *backend) userCreateUpdate(ctx context.Context, req *logical.Request, d
 		userEntry = &UserEntry{}
 	}
 
+	if passwordPolicyRaw, ok := d.GetOk("password_policy"); ok {
+		userEntry.PasswordPolicy = passwordPolicyRaw.(string)
+	}
+
+	var resp *logical.Response
 	if _, ok := d.GetOk("password"); ok {
-		userErr, intErr := b.updateUserPassword(req, d, userEntry)
+		userErr, intErr := b.updateUserPassword(ctx, req, d, userEntry)
 		if intErr != nil {
-			return nil, err
+			return nil, intErr
 		}
 		if userErr != nil {
 			return logical.ErrorResponse(userErr.Error()), logical.ErrInvalidRequest
 		}
+	} else if req.Operation == logical.CreateOperation {
+		// Generate a password from the user's password policy, which is
+		// returned only in this response
+		policies, err := b.passwordPolicies()
+		if err != nil {
+			return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
+		}
+		password, err := policies.GeneratePasswordFromPolicy(ctx, userEntry.PasswordPolicy)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprintf("failed to generate password: %v", err)), logical.ErrInvalidRequest
+		}
+		if err := setUserPasswordHash(userEntry, password); err != nil {
+			return nil, err
+		}
+		resp = &logical.Response{
+			Data: map[string]interface{}{
+				"password": password,
+			},
+		}
 	}
 
-	if policiesRaw, ok := d.GetOk("policies"); ok {
-		userEntry.Policies = policyutil.ParsePolicies(policiesRaw)
+	if err := userEntry.ParseTokenFields(req, d); err != nil {
+		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
 	}
 
-	ttl, ok := d.GetOk("ttl")
-	if ok {
-		userEntry.TTL = time.Duration(ttl.(int)) * time.Second
-	}
+	// Handle upgrade cases
+	{
+		if err := tokenutil.UpgradeValue(d, "policies", "token_policies", &userEntry.Policies, &userEntry.TokenPolicies); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
 
-	maxTTL, ok := d.GetOk("max_ttl")
-	if ok {
-		userEntry.MaxTTL = time.Duration(maxTTL.(int)) * time.Second
-	}
+		if err := tokenutil.UpgradeValue(d, "ttl", "token_ttl", &userEntry.TTL, &userEntry.TokenTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
 
-	boundCIDRs, err := parseutil.ParseAddrs(d.Get("bound_cidrs"))
-	if err != nil {
-		return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
+		if err := tokenutil.UpgradeValue(d, "max_ttl", "token_max_ttl", &userEntry.MaxTTL, &userEntry.TokenMaxTTL); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+
+		if err := tokenutil.UpgradeValue(d, "bound_cidrs", "token_bound_cidrs", &userEntry.BoundCIDRs, &userEntry.TokenBoundCIDRs); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
 	}
-	userEntry.BoundCIDRs = boundCIDRs
 
-	return nil, b.setUser(ctx, req.Storage, username, userEntry)
+	return resp, b.setUser(ctx, req.Storage, username, userEntry)
 }
 
 func (b *backend) pathUserWrite(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
 	password := d.Get("password").(string)
-	if req.Operation == logical.CreateOperation && password == "" {
+	passwordPolicy := d.Get("password_policy").(string)
+	if req.Operation == logical.CreateOperation && password == "" && passwordPolicy == "" {
 		return logical.ErrorResponse("missing password"), logical.ErrInvalidRequest
 	}
 	return b.userCreateUpdate(ctx, req, d)
 }
 
 type UserEntry struct {
+	tokenutil.TokenParams
+
 	// Password is deprecated in Vault 0.2 in favor of
 	// PasswordHash, but is retained for backwards compatibility.
 	Password string
@@ -212,15 +283,21 @@ type UserEntry struct {
 	// used instead of the actual password in Vault 0.2+.
 	PasswordHash []byte
 
+	// Deprecated: use TokenPolicies instead
 	Policies []string
 
-	// Duration after which the user will be revoked unless renewed
+	// Deprecated: use TokenTTL instead
 	TTL time.Duration
 
-	// Maximum duration for which user can be valid
+	// Deprecated: use TokenMaxTTL instead
 	MaxTTL time.Duration
 
+	// Deprecated: use TokenBoundCIDRs instead
 	BoundCIDRs []*sockaddr.SockAddrMarshaler
+
+	// PasswordPolicy is the name of the password policy that the user's
+	// password must satisfy
+	PasswordPolicy string
 }
 
 const pathUserHelpSyn = `
@@ -101,6 +101,27 @@ or IAM role to assume`,
 				DisplayName: "User Path",
 				Default:     "/",
 			},
+
+			"permissions_boundary_arn": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "ARN of an IAM policy to attach as a permissions boundary on IAM users. Only valid when credential_type is " + iamUserCred,
+				DisplayName: "Permissions Boundary ARN",
+			},
+
+			"iam_groups": &framework.FieldSchema{
+				Type:        framework.TypeCommaStringSlice,
+				Description: "Names of IAM groups that generated IAM users will be added to. Only valid when credential_type is " + iamUserCred,
+				DisplayName: "IAM Groups",
+			},
+
+			"username_template": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `Template used to generate IAM user names. Only valid when credential_type is
+iam_user. The template may reference {{.DisplayName}}, {{.RoleName}},
+{{.UnixTime}} and {{.RandomSuffix}}. Defaults to a name derived from the
+token display name and role name.`,
+				DisplayName: "Username Template",
+			},
 		},
 
 		Callbacks: map[logical.Operation]framework.OperationFunc{
@@ -271,6 +292,45 @@ func (b *backend) pathRolesWrite(ctx context.Context, req *logical.Request, d *f
 		roleEntry.UserPath = userPathRaw.(string)
 	}
 
+	if permissionsBoundaryARNRaw, ok := d.GetOk("permissions_boundary_arn"); ok {
+		if legacyRole != "" {
+			return logical.ErrorResponse("cannot supply deprecated role or policy parameters with permissions_boundary_arn"), nil
+		}
+		if !strutil.StrListContains(roleEntry.CredentialTypes, iamUserCred) {
+			return logical.ErrorResponse(fmt.Sprintf("permissions_boundary_arn parameter only valid for %s credential type", iamUserCred)), nil
+		}
+		permissionsBoundaryARN := permissionsBoundaryARNRaw.(string)
+		if permissionsBoundaryARN != "" {
+			if err := validateAWSManagedPolicy(permissionsBoundaryARN); err != nil {
+				return logical.ErrorResponse(fmt.Sprintf("invalid permissions_boundary_arn parameter: %v", err)), nil
+			}
+		}
+		roleEntry.PermissionsBoundaryARN = permissionsBoundaryARN
+	}
+
+	if iamGroupsRaw, ok := d.GetOk("iam_groups"); ok {
+		if legacyRole != "" {
+			return logical.ErrorResponse("cannot supply deprecated role or policy parameters with iam_groups"), nil
+		}
+		roleEntry.IAMGroups = iamGroupsRaw.([]string)
+	}
+
+	if usernameTemplateRaw, ok := d.GetOk("username_template"); ok {
+		if legacyRole != "" {
+			return logical.ErrorResponse("cannot supply deprecated role or policy parameters with username_template"), nil
+		}
+		if !strutil.StrListContains(roleEntry.CredentialTypes, iamUserCred) {
+			return logical.ErrorResponse(fmt.Sprintf("username_template parameter only valid for %s credential type", iamUserCred)), nil
+		}
+		usernameTemplate := usernameTemplateRaw.(string)
+		if usernameTemplate != "" {
+			if _, err := genUsernameFromTemplate(usernameTemplate, "display-name", roleName); err != nil {
+				return logical.ErrorResponse(fmt.Sprintf("invalid username_template parameter: %v", err)), nil
+			}
+		}
+		roleEntry.UsernameTemplate = usernameTemplate
+	}
+
 	if roleEntry.MaxSTSTTL > 0 &&
 		roleEntry.DefaultSTSTTL > 0 &&
 		roleEntry.DefaultSTSTTL > roleEntry.MaxSTSTTL {
@@ -297,6 +357,9 @@ func (b *backend) pathRolesWrite(ctx context.Context, req *logical.Request, d *f
 	if len(roleEntry.PolicyArns) > 0 && !strutil.StrListContains(roleEntry.CredentialTypes, iamUserCred) {
 		return logical.ErrorResponse(fmt.Sprintf("cannot supply policy_arns when credential_type isn't %s", iamUserCred)), nil
 	}
+	if len(roleEntry.IAMGroups) > 0 && !strutil.StrListContains(roleEntry.CredentialTypes, iamUserCred) {
+		return logical.ErrorResponse(fmt.Sprintf("cannot supply iam_groups when credential_type isn't %s", iamUserCred)), nil
+	}
 
 	err = setAwsRole(ctx, req.Storage, roleName, roleEntry)
 	if err != nil {
@@ -459,17 +522,23 @@ type awsRoleEntry struct {
 	DefaultSTSTTL            time.Duration `json:"default_sts_ttl"`                       // Default TTL for STS credentials
 	MaxSTSTTL                time.Duration `json:"max_sts_ttl"`                           // Max allowed TTL for STS credentials
 	UserPath                 string        `json:"user_path"`                             // The path for the IAM user when using "iam_user" credential type
+	PermissionsBoundaryARN   string        `json:"permissions_boundary_arn"`              // ARN of an IAM policy to attach as a permissions boundary
+	IAMGroups                []string      `json:"iam_groups"`                            // Names of IAM groups to add IAM users to
+	UsernameTemplate         string        `json:"username_template"`                     // Template for the names of generated IAM users
 }
 
 func (r *awsRoleEntry) toResponseData() map[string]interface{} {
 	respData := map[string]interface{}{
-		"credential_type": strings.Join(r.CredentialTypes, ","),
-		"policy_arns":     r.PolicyArns,
-		"role_arns":       r.RoleArns,
-		"policy_document": r.PolicyDocument,
-		"default_sts_ttl": int64(r.DefaultSTSTTL.Seconds()),
-		"max_sts_ttl":     int64(r.MaxSTSTTL.Seconds()),
-		"user_path":       r.UserPath,
+		"credential_type":          strings.Join(r.CredentialTypes, ","),
+		"policy_arns":              r.PolicyArns,
+		"role_arns":                r.RoleArns,
+		"policy_document":          r.PolicyDocument,
+		"default_sts_ttl":          int64(r.DefaultSTSTTL.Seconds()),
+		"max_sts_ttl":              int64(r.MaxSTSTTL.Seconds()),
+		"user_path":                r.UserPath,
+		"permissions_boundary_arn": r.PermissionsBoundaryARN,
+		"iam_groups":               r.IAMGroups,
+		"username_template":        r.UsernameTemplate,
 	}
 
 	if r.InvalidData != "" {
@@ -478,6 +547,20 @@ func (r *awsRoleEntry) toResponseData() map[string]interface{} {
 	return respData
 }
 
+func validateAWSManagedPolicy(policyARN string) error {
+	parsedARN, err := arn.Parse(policyARN)
+	if err != nil {
+		return err
+	}
+	if parsedARN.Service != "iam" {
+		return fmt.Errorf("expected a service of iam but got %s", parsedARN.Service)
+	}
+	if !strings.HasPrefix(parsedARN.Resource, "policy/") {
+		return fmt.Errorf("expected a resource type of policy but got %s", parsedARN.Resource)
+	}
+	return nil
+}
+
 func compactJSON(input string) (string, error) {
 	var compacted bytes.Buffer
 	err := json.Compact(&compacted, []byte(input))
@@ -213,3 +213,80 @@ func TestUserPathValidity(t *testing.T) {
 		})
 	}
 }
+
+func TestRoleIAMUserOptions(t *testing.T) {
+	config := logical.TestBackendConfig()
+	config.StorageView = &logical.InmemStorage{}
+
+	b := Backend()
+	if err := b.Setup(context.Background(), config); err != nil {
+		t.Fatal(err)
+	}
+
+	writeRole := func(data map[string]interface{}) *logical.Response {
+		t.Helper()
+		resp, err := b.HandleRequest(context.Background(), &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "roles/test",
+			Storage:   config.StorageView,
+			Data:      data,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	invalid := []map[string]interface{}{
+		{
+			"credential_type":          assumedRoleCred,
+			"role_arns":                "arn:aws:iam::123456789012:role/RoleName",
+			"permissions_boundary_arn": "arn:aws:iam::123456789012:policy/Boundary",
+		},
+		{
+			"credential_type": federationTokenCred,
+			"iam_groups":      "group1",
+		},
+		{
+			"credential_type":   federationTokenCred,
+			"username_template": "{{.RoleName}}",
+		},
+		{
+			"credential_type":          iamUserCred,
+			"permissions_boundary_arn": "arn:aws:iam::123456789012:role/RoleName",
+		},
+		{
+			"credential_type":   iamUserCred,
+			"username_template": "{{.Unknown}}",
+		},
+	}
+	for _, data := range invalid {
+		if resp := writeRole(data); resp == nil || !resp.IsError() {
+			t.Fatalf("expected error for %#v, got %#v", data, resp)
+		}
+	}
+
+	resp := writeRole(map[string]interface{}{
+		"credential_type":          iamUserCred,
+		"permissions_boundary_arn": "arn:aws:iam::123456789012:policy/Boundary",
+		"iam_groups":               "group1,group2",
+		"username_template":        "vault-{{.DisplayName}}-{{.UnixTime}}",
+	})
+	if resp != nil && resp.IsError() {
+		t.Fatalf("bad: %#v", resp)
+	}
+
+	resp, err := b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "roles/test",
+		Storage:   config.StorageView,
+	})
+	if err != nil || resp == nil || resp.IsError() {
+		t.Fatalf("bad: resp:%#v err:%v", resp, err)
+	}
+	if resp.Data["permissions_boundary_arn"] != "arn:aws:iam::123456789012:policy/Boundary" ||
+		!reflect.DeepEqual(resp.Data["iam_groups"], []string{"group1", "group2"}) ||
+		resp.Data["username_template"] != "vault-{{.DisplayName}}-{{.UnixTime}}" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+}
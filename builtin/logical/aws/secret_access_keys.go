@@ -1,10 +1,12 @@
 package aws
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand"
 	"regexp"
+	"text/template"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -65,6 +67,47 @@ func genUsername(displayName, policyName, userType string) (ret string, warning
 	return
 }
 
+// usernameTemplateData holds the values available to a role's
+// username_template
+type usernameTemplateData struct {
+	DisplayName  string
+	RoleName     string
+	UnixTime     int64
+	RandomSuffix string
+}
+
+// genUsernameFromTemplate renders an IAM user name from the given template,
+// returning an error if the result is not a valid IAM user name
+func genUsernameFromTemplate(usernameTemplate, displayName, roleName string) (string, error) {
+	tmpl, err := template.New("username").Option("missingkey=error").Parse(usernameTemplate)
+	if err != nil {
+		return "", errwrap.Wrapf("unable to parse template: {{err}}", err)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, usernameTemplateData{
+		DisplayName:  normalizeDisplayName(displayName),
+		RoleName:     normalizeDisplayName(roleName),
+		UnixTime:     time.Now().Unix(),
+		RandomSuffix: fmt.Sprintf("%d", rand.Int31n(10000)),
+	})
+	if err != nil {
+		return "", errwrap.Wrapf("unable to render template: {{err}}", err)
+	}
+
+	username := buf.String()
+	switch {
+	case username == "":
+		return "", fmt.Errorf("template rendered an empty username")
+	case len(username) > 64:
+		return "", fmt.Errorf("rendered username %q exceeds the IAM limit of 64 characters", username)
+	case normalizeDisplayName(username) != username:
+		return "", fmt.Errorf("rendered username %q contains characters not allowed in IAM user names", username)
+	}
+
+	return username, nil
+}
+
 func (b *backend) secretTokenCreate(ctx context.Context, s logical.Storage,
 	displayName, policyName, policy string,
 	lifeTimeInSeconds int64) (*logical.Response, error) {
@@ -167,7 +210,15 @@ func (b *backend) secretAccessKeysCreate(
 		return logical.ErrorResponse(err.Error()), nil
 	}
 
-	username, usernameWarning := genUsername(displayName, policyName, "iam_user")
+	var username, usernameWarning string
+	if role.UsernameTemplate != "" {
+		username, err = genUsernameFromTemplate(role.UsernameTemplate, displayName, policyName)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprintf("error generating username: %s", err)), nil
+		}
+	} else {
+		username, usernameWarning = genUsername(displayName, policyName, "iam_user")
+	}
 
 	// Write to the WAL that this user will be created. We do this before
 	// the user is created because if switch the order then the WAL put
@@ -185,11 +236,16 @@ func (b *backend) secretAccessKeysCreate(
 		userPath = "/"
 	}
 
-	// Create the user
-	_, err = iamClient.CreateUser(&iam.CreateUserInput{
+	createUserInput := &iam.CreateUserInput{
 		UserName: aws.String(username),
 		Path:     aws.String(userPath),
-	})
+	}
+	if role.PermissionsBoundaryARN != "" {
+		createUserInput.PermissionsBoundary = aws.String(role.PermissionsBoundaryARN)
+	}
+
+	// Create the user
+	_, err = iamClient.CreateUser(createUserInput)
 	if err != nil {
 		if walErr := framework.DeleteWAL(ctx, s, walID); walErr != nil {
 			iamErr := errwrap.Wrapf("error creating IAM user: {{err}}", err)
@@ -224,6 +280,18 @@ func (b *backend) secretAccessKeysCreate(
 		}
 	}
 
+	for _, group := range role.IAMGroups {
+		// Add user to IAM group
+		_, err = iamClient.AddUserToGroup(&iam.AddUserToGroupInput{
+			UserName:  aws.String(username),
+			GroupName: aws.String(group),
+		})
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprintf(
+				"Error adding user to group: %s", err)), awsutil.CheckAWSError(err)
+		}
+	}
+
 	// Create the keys
 	keyResp, err := iamClient.CreateAccessKey(&iam.CreateAccessKeyInput{
 		UserName: aws.String(username),
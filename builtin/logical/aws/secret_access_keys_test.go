@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -42,3 +43,65 @@ func TestNormalizeDisplayName_NormNotRequired(t *testing.T) {
 		}
 	}
 }
+
+func TestGenUsernameFromTemplate(t *testing.T) {
+	testCases := []struct {
+		description string
+		template    string
+		expected    string
+		isValid     bool
+	}{
+		{
+			description: "Display and role name",
+			template:    "vault-{{.DisplayName}}-{{.RoleName}}",
+			expected:    "vault-token_name-myrole",
+			isValid:     true,
+		},
+		{
+			description: "Static name",
+			template:    "svc-deploy",
+			expected:    "svc-deploy",
+			isValid:     true,
+		},
+		{
+			description: "Unknown field",
+			template:    "{{.Unknown}}",
+			isValid:     false,
+		},
+		{
+			description: "Malformed template",
+			template:    "{{.DisplayName",
+			isValid:     false,
+		},
+		{
+			description: "Invalid characters",
+			template:    "vault/{{.RoleName}}",
+			isValid:     false,
+		},
+		{
+			description: "Too long",
+			template:    strings.Repeat("a", 65),
+			isValid:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			username, err := genUsernameFromTemplate(tc.template, "token name", "myrole")
+			if tc.isValid != (err == nil) {
+				t.Fatalf("bad: expected valid %t, got err %v", tc.isValid, err)
+			}
+			if tc.isValid && username != tc.expected {
+				t.Fatalf("bad: expected %q, got %q", tc.expected, username)
+			}
+		})
+	}
+
+	username, err := genUsernameFromTemplate("{{.RoleName}}-{{.UnixTime}}-{{.RandomSuffix}}", "token", "myrole")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(username, "myrole-") || len(strings.Split(username, "-")) != 3 {
+		t.Fatalf("bad: %q", username)
+	}
+}
@@ -14,11 +14,16 @@ import (
 	"github.com/hashicorp/vault/sdk/database/dbplugin"
 	"github.com/hashicorp/vault/sdk/database/helper/dbutil"
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
 	"github.com/hashicorp/vault/sdk/helper/strutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
-const databaseConfigPath = "database/config/"
+const (
+	databaseConfigPath     = "database/config/"
+	databaseRolePath       = "role/"
+	databaseStaticRolePath = "static-role/"
+)
 
 type dbPluginInstance struct {
 	sync.RWMutex
@@ -65,21 +70,27 @@ func Backend(conf *logical.BackendConfig) *databaseBackend {
 			pathConfigurePluginConnection(&b),
 			pathListRoles(&b),
 			pathRoles(&b),
+			pathListStaticRoles(&b),
+			pathStaticRoles(&b),
 			pathCredsCreate(&b),
+			pathStaticCredsCreate(&b),
 			pathResetConnection(&b),
 			pathRotateCredentials(&b),
+			pathRotateRoleCredentials(&b),
 		},
 
 		Secrets: []*framework.Secret{
 			secretCreds(&b),
 		},
-		Clean:       b.closeAllDBs,
-		Invalidate:  b.invalidate,
-		BackendType: logical.TypeLogical,
+		Clean:        b.closeAllDBs,
+		Invalidate:   b.invalidate,
+		PeriodicFunc: b.periodicFunc,
+		BackendType:  logical.TypeLogical,
 	}
 
 	b.logger = conf.Logger
 	b.connections = make(map[string]*dbPluginInstance)
+	b.roleLocks = locksutil.CreateLocks()
 	return &b
 }
 
@@ -87,6 +98,9 @@ type databaseBackend struct {
 	connections map[string]*dbPluginInstance
 	logger      log.Logger
 
+	// roleLocks serialize password rotations of static roles
+	roleLocks []*locksutil.LockEntry
+
 	*framework.Backend
 	sync.RWMutex
 }
@@ -124,7 +138,15 @@ type upgradeCheck struct {
 }
 
 func (b *databaseBackend) Role(ctx context.Context, s logical.Storage, roleName string) (*roleEntry, error) {
-	entry, err := s.Get(ctx, "role/"+roleName)
+	return b.roleAtPath(ctx, s, roleName, databaseRolePath)
+}
+
+func (b *databaseBackend) StaticRole(ctx context.Context, s logical.Storage, roleName string) (*roleEntry, error) {
+	return b.roleAtPath(ctx, s, roleName, databaseStaticRolePath)
+}
+
+func (b *databaseBackend) roleAtPath(ctx context.Context, s logical.Storage, roleName string, pathPrefix string) (*roleEntry, error) {
+	entry, err := s.Get(ctx, pathPrefix+roleName)
 	if err != nil {
 		return nil, err
 	}
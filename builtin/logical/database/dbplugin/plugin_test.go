@@ -65,6 +65,22 @@ func (m *mockPlugin) RevokeUser(_ context.Context, statements dbplugin.Statement
 func (m *mockPlugin) RotateRootCredentials(_ context.Context, statements []string) (map[string]interface{}, error) {
 	return nil, nil
 }
+func (m *mockPlugin) SetCredentials(_ context.Context, statements dbplugin.Statements, staticConfig dbplugin.StaticUserConfig) (username string, password string, err error) {
+	err = errors.New("err")
+	if staticConfig.Username == "" || staticConfig.Password == "" {
+		return "", "", err
+	}
+
+	if _, ok := m.users[staticConfig.Username]; !ok {
+		return "", "", err
+	}
+
+	m.users[staticConfig.Username] = []string{staticConfig.Password}
+	return staticConfig.Username, staticConfig.Password, nil
+}
+func (m *mockPlugin) GenerateCredentials(_ context.Context) (string, error) {
+	return "generated", nil
+}
 func (m *mockPlugin) Init(_ context.Context, conf map[string]interface{}, _ bool) (map[string]interface{}, error) {
 	err := errors.New("err")
 	if len(conf) != 1 {
@@ -257,3 +273,60 @@ func TestPlugin_RevokeUser(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestPlugin_SetCredentials(t *testing.T) {
+	cluster, sys := getCluster(t)
+	defer cluster.Cleanup()
+
+	db, err := dbplugin.PluginFactory(namespace.RootContext(nil), "test-plugin", sys, log.NewNullLogger())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer db.Close()
+
+	connectionDetails := map[string]interface{}{
+		"test": 1,
+	}
+	_, err = db.Init(context.Background(), connectionDetails, true)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	usernameConf := dbplugin.UsernameConfig{
+		DisplayName: "test",
+		RoleName:    "test",
+	}
+
+	us, _, err := db.CreateUser(context.Background(), dbplugin.Statements{}, usernameConf, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	password, err := db.GenerateCredentials(context.Background())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if password != "generated" {
+		t.Fatalf("bad password: %q", password)
+	}
+
+	username, newPassword, err := db.SetCredentials(context.Background(), dbplugin.Statements{}, dbplugin.StaticUserConfig{
+		Username: us,
+		Password: password,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if username != us || newPassword != password {
+		t.Fatalf("bad credentials: %q %q", username, newPassword)
+	}
+
+	// Setting credentials on an unknown user fails
+	_, _, err = db.SetCredentials(context.Background(), dbplugin.Statements{}, dbplugin.StaticUserConfig{
+		Username: "unknown",
+		Password: password,
+	})
+	if err == nil {
+		t.Fatal("expected error setting credentials of an unknown user")
+	}
+}
@@ -30,6 +30,25 @@ func pathCredsCreate(b *databaseBackend) *framework.Path {
 	}
 }
 
+func pathStaticCredsCreate(b *databaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "static-creds/" + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the static role.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.pathStaticCredsRead(),
+		},
+
+		HelpSynopsis:    pathStaticCredsReadHelpSyn,
+		HelpDescription: pathStaticCredsReadHelpDesc,
+	}
+}
+
 func (b *databaseBackend) pathCredsCreateRead() framework.OperationFunc {
 	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 		name := data.Get("name").(string)
@@ -99,6 +118,41 @@ func (b *databaseBackend) pathCredsCreateRead() framework.OperationFunc {
 	}
 }
 
+func (b *databaseBackend) pathStaticCredsRead() framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		name := data.Get("name").(string)
+
+		role, err := b.StaticRole(ctx, req.Storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			return logical.ErrorResponse(fmt.Sprintf("unknown static role: %s", name)), nil
+		}
+
+		dbConfig, err := b.DatabaseConfig(ctx, req.Storage, role.DBName)
+		if err != nil {
+			return nil, err
+		}
+
+		// If role name isn't in the database's allowed roles, send back a
+		// permission denied.
+		if !strutil.StrListContains(dbConfig.AllowedRoles, "*") && !strutil.StrListContainsGlob(dbConfig.AllowedRoles, name) {
+			return nil, fmt.Errorf("%q is not an allowed role", name)
+		}
+
+		return &logical.Response{
+			Data: map[string]interface{}{
+				"username":            role.StaticAccount.Username,
+				"password":            role.StaticAccount.Password,
+				"ttl":                 role.StaticAccount.CredentialTTL().Seconds(),
+				"rotation_period":     role.StaticAccount.RotationPeriod.Seconds(),
+				"last_vault_rotation": role.StaticAccount.LastVaultRotation,
+			},
+		}, nil
+	}
+}
+
 const pathCredsCreateReadHelpSyn = `
 Request database credentials for a certain role.
 `
@@ -108,3 +162,13 @@ This path reads database credentials for a certain role. The
 database credentials will be generated on demand and will be automatically
 revoked when the lease is up.
 `
+
+const pathStaticCredsReadHelpSyn = `
+Request database credentials for a certain static role.
+`
+
+const pathStaticCredsReadHelpDesc = `
+This path reads the current database credentials of a static role. The
+password is rotated by Vault at the role's rotation period; "ttl" is the time
+remaining until the next rotation. These credentials are not leased.
+`
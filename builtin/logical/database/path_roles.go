@@ -2,10 +2,13 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/vault/sdk/database/dbplugin"
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
 	"github.com/hashicorp/vault/sdk/helper/strutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
@@ -88,6 +91,64 @@ func pathRoles(b *databaseBackend) *framework.Path {
 	}
 }
 
+func pathListStaticRoles(b *databaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "static-roles/?$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathStaticRoleList(),
+		},
+
+		HelpSynopsis:    pathStaticRoleHelpSyn,
+		HelpDescription: pathStaticRoleHelpDesc,
+	}
+}
+
+func pathStaticRoles(b *databaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "static-roles/" + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": {
+				Type:        framework.TypeString,
+				Description: "Name of the role.",
+			},
+
+			"db_name": {
+				Type:        framework.TypeString,
+				Description: "Name of the database this role acts on.",
+			},
+			"username": {
+				Type: framework.TypeString,
+				Description: `Name of the existing database user whose password
+				is managed by this role. Cannot be changed after creation.`,
+			},
+			"rotation_period": {
+				Type: framework.TypeDurationSecond,
+				Description: `Period between password rotations of the
+				database user. Must be at least one minute.`,
+			},
+			"rotation_statements": {
+				Type: framework.TypeStringSlice,
+				Description: `Specifies the database statements to be executed
+				to rotate the password of the database user. See the plugin's
+				API page for more information on support and formatting for this
+				parameter.`,
+			},
+		},
+
+		ExistenceCheck: b.pathStaticRoleExistenceCheck(),
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathStaticRoleRead(),
+			logical.CreateOperation: b.pathStaticRoleCreateUpdate(),
+			logical.UpdateOperation: b.pathStaticRoleCreateUpdate(),
+			logical.DeleteOperation: b.pathStaticRoleDelete(),
+		},
+
+		HelpSynopsis:    pathStaticRoleHelpSyn,
+		HelpDescription: pathStaticRoleHelpDesc,
+	}
+}
+
 func (b *databaseBackend) pathRoleExistenceCheck() framework.ExistenceFunc {
 	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
 		role, err := b.Role(ctx, req.Storage, data.Get("name").(string))
@@ -101,7 +162,7 @@ func (b *databaseBackend) pathRoleExistenceCheck() framework.ExistenceFunc {
 
 func (b *databaseBackend) pathRoleDelete() framework.OperationFunc {
 	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-		err := req.Storage.Delete(ctx, "role/"+data.Get("name").(string))
+		err := req.Storage.Delete(ctx, databaseRolePath+data.Get("name").(string))
 		if err != nil {
 			return nil, err
 		}
@@ -150,7 +211,7 @@ func (b *databaseBackend) pathRoleRead() framework.OperationFunc {
 
 func (b *databaseBackend) pathRoleList() framework.OperationFunc {
 	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-		entries, err := req.Storage.List(ctx, "role/")
+		entries, err := req.Storage.List(ctx, databaseRolePath)
 		if err != nil {
 			return nil, err
 		}
@@ -171,6 +232,13 @@ func (b *databaseBackend) pathRoleCreateUpdate() framework.OperationFunc {
 			return nil, err
 		}
 		if role == nil {
+			staticRole, err := b.StaticRole(ctx, req.Storage, name)
+			if err != nil {
+				return nil, err
+			}
+			if staticRole != nil {
+				return logical.ErrorResponse("static role exists with the same name"), nil
+			}
 			role = &roleEntry{}
 		}
 
@@ -236,7 +304,173 @@ func (b *databaseBackend) pathRoleCreateUpdate() framework.OperationFunc {
 		role.Statements.Revocation = strutil.RemoveEmpty(role.Statements.Revocation)
 
 		// Store it
-		entry, err := logical.StorageEntryJSON("role/"+name, role)
+		entry, err := logical.StorageEntryJSON(databaseRolePath+name, role)
+		if err != nil {
+			return nil, err
+		}
+		if err := req.Storage.Put(ctx, entry); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+}
+
+func (b *databaseBackend) pathStaticRoleExistenceCheck() framework.ExistenceFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+		role, err := b.StaticRole(ctx, req.Storage, data.Get("name").(string))
+		if err != nil {
+			return false, err
+		}
+
+		return role != nil, nil
+	}
+}
+
+func (b *databaseBackend) pathStaticRoleDelete() framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		name := data.Get("name").(string)
+
+		lock := locksutil.LockForKey(b.roleLocks, name)
+		lock.Lock()
+		defer lock.Unlock()
+
+		if err := req.Storage.Delete(ctx, databaseStaticRolePath+name); err != nil {
+			return nil, err
+		}
+
+		// Remove any pending rotation, the password is no longer managed
+		if err := b.deleteStaticWALs(ctx, req.Storage, name); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+}
+
+func (b *databaseBackend) pathStaticRoleRead() framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+		role, err := b.StaticRole(ctx, req.Storage, d.Get("name").(string))
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			return nil, nil
+		}
+
+		data := map[string]interface{}{
+			"db_name":             role.DBName,
+			"username":            role.StaticAccount.Username,
+			"rotation_period":     role.StaticAccount.RotationPeriod.Seconds(),
+			"rotation_statements": role.Statements.Rotation,
+			"last_vault_rotation": role.StaticAccount.LastVaultRotation,
+		}
+		if len(role.Statements.Rotation) == 0 {
+			data["rotation_statements"] = []string{}
+		}
+
+		return &logical.Response{
+			Data: data,
+		}, nil
+	}
+}
+
+func (b *databaseBackend) pathStaticRoleList() framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		entries, err := req.Storage.List(ctx, databaseStaticRolePath)
+		if err != nil {
+			return nil, err
+		}
+
+		return logical.ListResponse(entries), nil
+	}
+}
+
+func (b *databaseBackend) pathStaticRoleCreateUpdate() framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		name := data.Get("name").(string)
+		if name == "" {
+			return logical.ErrorResponse("empty role name attribute given"), nil
+		}
+
+		lock := locksutil.LockForKey(b.roleLocks, name)
+		lock.Lock()
+		defer lock.Unlock()
+
+		role, err := b.StaticRole(ctx, req.Storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			dynamicRole, err := b.Role(ctx, req.Storage, name)
+			if err != nil {
+				return nil, err
+			}
+			if dynamicRole != nil {
+				return logical.ErrorResponse("role exists with the same name"), nil
+			}
+
+			role = &roleEntry{
+				StaticAccount: &staticAccount{},
+			}
+		}
+
+		// DB Attributes
+		{
+			if dbNameRaw, ok := data.GetOk("db_name"); ok {
+				role.DBName = dbNameRaw.(string)
+			}
+			if role.DBName == "" {
+				return logical.ErrorResponse("empty database name attribute"), nil
+			}
+
+			if usernameRaw, ok := data.GetOk("username"); ok {
+				username := usernameRaw.(string)
+				if req.Operation == logical.UpdateOperation && username != role.StaticAccount.Username {
+					return logical.ErrorResponse("cannot update static account username"), nil
+				}
+				role.StaticAccount.Username = username
+			}
+			if role.StaticAccount.Username == "" {
+				return logical.ErrorResponse("username is a required field to create a static account"), nil
+			}
+		}
+
+		// Rotation period
+		{
+			if rotationPeriodRaw, ok := data.GetOk("rotation_period"); ok {
+				role.StaticAccount.RotationPeriod = time.Duration(rotationPeriodRaw.(int)) * time.Second
+			}
+			if role.StaticAccount.RotationPeriod < minRotationPeriod {
+				return logical.ErrorResponse(fmt.Sprintf("rotation_period must be at least %s", minRotationPeriod)), nil
+			}
+		}
+
+		// Statements
+		{
+			if rotationStmtsRaw, ok := data.GetOk("rotation_statements"); ok {
+				role.Statements.Rotation = rotationStmtsRaw.([]string)
+			}
+		}
+
+		dbConfig, err := b.DatabaseConfig(ctx, req.Storage, role.DBName)
+		if err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+		if !strutil.StrListContains(dbConfig.AllowedRoles, "*") && !strutil.StrListContainsGlob(dbConfig.AllowedRoles, name) {
+			return logical.ErrorResponse(fmt.Sprintf("%q is not an allowed role", name)), nil
+		}
+
+		// Vault needs to know the password of a new static account, so rotate
+		// it right away. This also stores the role.
+		if req.Operation == logical.CreateOperation {
+			if err := b.rotateStaticRole(ctx, req.Storage, name, role); err != nil {
+				return nil, errwrap.Wrapf("failed to set the initial password of the static account: {{err}}", err)
+			}
+			return nil, nil
+		}
+
+		entry, err := logical.StorageEntryJSON(databaseStaticRolePath+name, role)
 		if err != nil {
 			return nil, err
 		}
@@ -249,10 +483,34 @@ func (b *databaseBackend) pathRoleCreateUpdate() framework.OperationFunc {
 }
 
 type roleEntry struct {
-	DBName     string              `json:"db_name"`
-	Statements dbplugin.Statements `json:"statements"`
-	DefaultTTL time.Duration       `json:"default_ttl"`
-	MaxTTL     time.Duration       `json:"max_ttl"`
+	DBName        string              `json:"db_name"`
+	Statements    dbplugin.Statements `json:"statements"`
+	DefaultTTL    time.Duration       `json:"default_ttl"`
+	MaxTTL        time.Duration       `json:"max_ttl"`
+	StaticAccount *staticAccount      `json:"static_account,omitempty"`
+}
+
+// staticAccount is the existing database user managed by a static role
+type staticAccount struct {
+	Username          string        `json:"username"`
+	Password          string        `json:"password"`
+	LastVaultRotation time.Time     `json:"last_vault_rotation"`
+	RotationPeriod    time.Duration `json:"rotation_period"`
+}
+
+// NextRotationTime returns the time at which the password is due to be
+// rotated
+func (s *staticAccount) NextRotationTime() time.Time {
+	return s.LastVaultRotation.Add(s.RotationPeriod)
+}
+
+// CredentialTTL returns the time remaining until the next rotation
+func (s *staticAccount) CredentialTTL() time.Duration {
+	ttl := time.Until(s.NextRotationTime())
+	if ttl < 0 {
+		return 0
+	}
+	return ttl
 }
 
 const pathRoleHelpSyn = `
@@ -299,3 +557,30 @@ user.
 The "rollback_statements' parameter customizes the statement string used to
 rollback a change if needed.
 `
+
+const pathStaticRoleHelpSyn = `
+Manage the static roles that can be created with this backend.
+`
+
+const pathStaticRoleHelpDesc = `
+This path lets you manage the static roles that can be created with this
+backend. A static role manages the password of an existing database user
+instead of creating users on demand.
+
+The "db_name" parameter is required and configures the name of the database
+connection to use.
+
+The "username" parameter is required and names the existing database user. It
+cannot be changed after the role is created.
+
+The "rotation_period" parameter is required and sets how often the password is
+rotated. The password is also rotated when the role is created, and can be
+rotated on demand using the "rotate-role" endpoint.
+
+The "rotation_statements" parameter customizes the statement string used to
+rotate the password. The "name" (or "username") and "password" keys are
+substituted. Example of a decent rotation_statements for a postgresql database
+plugin:
+
+	ALTER USER "{{name}}" WITH PASSWORD '{{password}}';
+`
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
@@ -27,6 +28,25 @@ func pathRotateCredentials(b *databaseBackend) *framework.Path {
 	}
 }
 
+func pathRotateRoleCredentials(b *databaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "rotate-role/" + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the static role",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathRotateRoleCredentialsUpdate(),
+		},
+
+		HelpSynopsis:    pathRotateRoleCredentialsUpdateHelpSyn,
+		HelpDescription: pathRotateRoleCredentialsUpdateHelpDesc,
+	}
+}
+
 func (b *databaseBackend) pathRotateCredentialsUpdate() framework.OperationFunc {
 	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 		name := data.Get("name").(string)
@@ -78,6 +98,33 @@ func (b *databaseBackend) pathRotateCredentialsUpdate() framework.OperationFunc
 	}
 }
 
+func (b *databaseBackend) pathRotateRoleCredentialsUpdate() framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		name := data.Get("name").(string)
+		if name == "" {
+			return logical.ErrorResponse(respErrEmptyName), nil
+		}
+
+		lock := locksutil.LockForKey(b.roleLocks, name)
+		lock.Lock()
+		defer lock.Unlock()
+
+		role, err := b.StaticRole(ctx, req.Storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			return logical.ErrorResponse(fmt.Sprintf("unknown static role: %s", name)), nil
+		}
+
+		if err := b.rotateStaticRole(ctx, req.Storage, name, role); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+}
+
 const pathRotateCredentialsUpdateHelpSyn = `
 Request to rotate the root credentials for a certain database connection.
 `
@@ -85,3 +132,12 @@ Request to rotate the root credentials for a certain database connection.
 const pathRotateCredentialsUpdateHelpDesc = `
 This path attempts to rotate the root credentials for the given database. 
 `
+
+const pathRotateRoleCredentialsUpdateHelpSyn = `
+Request to rotate the credentials of a static role.
+`
+
+const pathRotateRoleCredentialsUpdateHelpDesc = `
+This path rotates the password of the database user managed by the given static
+role, independently of its rotation period.
+`
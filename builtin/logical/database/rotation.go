@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/vault/sdk/database/dbplugin"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/consts"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	// minRotationPeriod is the shortest allowed rotation period of a static
+	// role. Rotations are checked for once a minute.
+	minRotationPeriod = time.Minute
+
+	staticWALKey = "staticRotationKey"
+)
+
+// setCredentialsWAL records a password before it is set in the database, so
+// that an interrupted rotation can be retried with the same password
+type setCredentialsWAL struct {
+	RoleName    string `json:"role_name" mapstructure:"role_name"`
+	Username    string `json:"username" mapstructure:"username"`
+	NewPassword string `json:"new_password" mapstructure:"new_password"`
+}
+
+// periodicFunc is invoked once a minute by the RollbackManager and rotates the
+// passwords of static roles whose rotation period has elapsed
+func (b *databaseBackend) periodicFunc(ctx context.Context, req *logical.Request) error {
+	if !b.System().LocalMount() && b.System().ReplicationState().HasState(consts.ReplicationPerformanceSecondary|consts.ReplicationPerformanceStandby) {
+		return nil
+	}
+
+	names, err := req.Storage.List(ctx, databaseStaticRolePath)
+	if err != nil {
+		return err
+	}
+
+	var errs *multierror.Error
+	for _, name := range names {
+		if err := b.rotateIfRequired(ctx, req.Storage, name); err != nil {
+			errs = multierror.Append(errs, errwrap.Wrapf(fmt.Sprintf("error rotating password of static role %q: {{err}}", name), err))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// rotateIfRequired rotates the password of the named static role if its
+// rotation period has elapsed
+func (b *databaseBackend) rotateIfRequired(ctx context.Context, s logical.Storage, name string) error {
+	lock := locksutil.LockForKey(b.roleLocks, name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	role, err := b.StaticRole(ctx, s, name)
+	if err != nil {
+		return err
+	}
+	if role == nil || time.Now().Before(role.StaticAccount.NextRotationTime()) {
+		return nil
+	}
+
+	return b.rotateStaticRole(ctx, s, name, role)
+}
+
+// rotateStaticRole sets a new password for the database user of a static
+// role and stores the role. The caller must hold the role's lock.
+func (b *databaseBackend) rotateStaticRole(ctx context.Context, s logical.Storage, name string, role *roleEntry) error {
+	db, err := b.GetConnection(ctx, s, role.DBName)
+	if err != nil {
+		return err
+	}
+
+	db.RLock()
+	defer db.RUnlock()
+
+	// Reuse the password of an interrupted rotation, as it may already have
+	// been set in the database
+	walID, wal, err := b.findStaticWAL(ctx, s, name)
+	if err != nil {
+		return err
+	}
+	if wal != nil && wal.Username != role.StaticAccount.Username {
+		if err := framework.DeleteWAL(ctx, s, walID); err != nil {
+			return err
+		}
+		walID, wal = "", nil
+	}
+
+	if wal == nil {
+		password, err := db.GenerateCredentials(ctx)
+		if err != nil {
+			b.CloseIfShutdown(db, err)
+			return err
+		}
+
+		wal = &setCredentialsWAL{
+			RoleName:    name,
+			Username:    role.StaticAccount.Username,
+			NewPassword: password,
+		}
+		walID, err = framework.PutWAL(ctx, s, staticWALKey, wal)
+		if err != nil {
+			return errwrap.Wrapf("error writing WAL entry: {{err}}", err)
+		}
+	}
+
+	_, password, err := db.SetCredentials(ctx, role.Statements, dbplugin.StaticUserConfig{
+		Username: role.StaticAccount.Username,
+		Password: wal.NewPassword,
+	})
+	if err != nil {
+		b.CloseIfShutdown(db, err)
+		return err
+	}
+
+	role.StaticAccount.Password = password
+	role.StaticAccount.LastVaultRotation = time.Now()
+
+	entry, err := logical.StorageEntryJSON(databaseStaticRolePath+name, role)
+	if err != nil {
+		return err
+	}
+	if err := s.Put(ctx, entry); err != nil {
+		return err
+	}
+
+	if err := framework.DeleteWAL(ctx, s, walID); err != nil {
+		b.Logger().Warn("error deleting WAL entry after rotating static role", "role", name, "error", err)
+	}
+
+	return nil
+}
+
+// findStaticWAL returns the pending rotation of the named static role, if any
+func (b *databaseBackend) findStaticWAL(ctx context.Context, s logical.Storage, name string) (string, *setCredentialsWAL, error) {
+	ids, err := framework.ListWAL(ctx, s)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, id := range ids {
+		entry, err := framework.GetWAL(ctx, s, id)
+		if err != nil {
+			return "", nil, err
+		}
+		if entry == nil || entry.Kind != staticWALKey {
+			continue
+		}
+
+		var wal setCredentialsWAL
+		if err := mapstructure.Decode(entry.Data, &wal); err != nil {
+			return "", nil, err
+		}
+		if wal.RoleName == name {
+			return id, &wal, nil
+		}
+	}
+
+	return "", nil, nil
+}
+
+// deleteStaticWALs removes the pending rotation of the named static role
+func (b *databaseBackend) deleteStaticWALs(ctx context.Context, s logical.Storage, name string) error {
+	id, wal, err := b.findStaticWAL(ctx, s, name)
+	if err != nil || wal == nil {
+		return err
+	}
+
+	return framework.DeleteWAL(ctx, s, id)
+}
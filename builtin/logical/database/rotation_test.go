@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func TestBackend_StaticRole_Rotate(t *testing.T) {
+	cluster, sys := getCluster(t)
+	defer cluster.Cleanup()
+
+	config := logical.TestBackendConfig()
+	config.StorageView = &logical.InmemStorage{}
+	config.System = sys
+
+	lb, err := Factory(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := lb.(*databaseBackend)
+	defer b.Cleanup(context.Background())
+
+	cleanup, connURL := preparePostgresTestContainer(t, config.StorageView, b)
+	defer cleanup()
+
+	handle := func(op logical.Operation, path string, data map[string]interface{}) (*logical.Response, error) {
+		return b.HandleRequest(namespace.RootContext(nil), &logical.Request{
+			Operation: op,
+			Path:      path,
+			Storage:   config.StorageView,
+			Data:      data,
+		})
+	}
+
+	resp, err := handle(logical.UpdateOperation, "config/plugin-test", map[string]interface{}{
+		"connection_url": connURL,
+		"plugin_name":    "postgresql-database-plugin",
+		"allowed_roles":  []string{"*"},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err:%s resp:%#v\n", err, resp)
+	}
+
+	// Create the database user managed by the static role
+	db, err := sql.Open("postgres", connURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE ROLE "statictest" WITH LOGIN PASSWORD 'password';`); err != nil {
+		t.Fatal(err)
+	}
+
+	// The rotation period has a minimum
+	resp, err = handle(logical.CreateOperation, "static-roles/plugin-role-test", map[string]interface{}{
+		"db_name":         "plugin-test",
+		"username":        "statictest",
+		"rotation_period": "5s",
+	})
+	if err != nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got err:%s resp:%#v\n", err, resp)
+	}
+
+	resp, err = handle(logical.CreateOperation, "static-roles/plugin-role-test", map[string]interface{}{
+		"db_name":         "plugin-test",
+		"username":        "statictest",
+		"rotation_period": "1h",
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err:%s resp:%#v\n", err, resp)
+	}
+
+	// A dynamic role cannot share the name of a static role
+	resp, err = handle(logical.CreateOperation, "roles/plugin-role-test", map[string]interface{}{
+		"db_name":             "plugin-test",
+		"creation_statements": testRole,
+	})
+	if err != nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got err:%s resp:%#v\n", err, resp)
+	}
+
+	// The username cannot be changed
+	resp, err = handle(logical.UpdateOperation, "static-roles/plugin-role-test", map[string]interface{}{
+		"username": "other",
+	})
+	if err != nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got err:%s resp:%#v\n", err, resp)
+	}
+
+	readCreds := func() string {
+		t.Helper()
+		resp, err := handle(logical.ReadOperation, "static-creds/plugin-role-test", nil)
+		if err != nil || resp == nil || resp.IsError() {
+			t.Fatalf("err:%s resp:%#v\n", err, resp)
+		}
+		if resp.Data["username"] != "statictest" || resp.Data["rotation_period"].(float64) != 3600 {
+			t.Fatalf("bad: %#v", resp.Data)
+		}
+		if ttl := resp.Data["ttl"].(float64); ttl <= 0 || ttl > 3600 {
+			t.Fatalf("bad ttl: %v", ttl)
+		}
+		return resp.Data["password"].(string)
+	}
+
+	password := readCreds()
+	if password == "password" {
+		t.Fatal("expected password to be rotated on role creation")
+	}
+	verifyStaticLogin(t, connURL, "statictest", password, true)
+
+	// Rotate on demand
+	resp, err = handle(logical.UpdateOperation, "rotate-role/plugin-role-test", nil)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err:%s resp:%#v\n", err, resp)
+	}
+	newPassword := readCreds()
+	if newPassword == password {
+		t.Fatal("expected password to be rotated")
+	}
+	verifyStaticLogin(t, connURL, "statictest", password, false)
+	verifyStaticLogin(t, connURL, "statictest", newPassword, true)
+
+	// Rotation is not due yet
+	if err := b.periodicFunc(namespace.RootContext(nil), &logical.Request{Storage: config.StorageView}); err != nil {
+		t.Fatal(err)
+	}
+	if readCreds() != newPassword {
+		t.Fatal("expected password not to be rotated before the rotation period elapses")
+	}
+
+	// Make the rotation due
+	role, err := b.StaticRole(context.Background(), config.StorageView, "plugin-role-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	role.StaticAccount.LastVaultRotation = time.Now().Add(-2 * time.Hour)
+	entry, err := logical.StorageEntryJSON(databaseStaticRolePath+"plugin-role-test", role)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.StorageView.Put(context.Background(), entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.periodicFunc(namespace.RootContext(nil), &logical.Request{Storage: config.StorageView}); err != nil {
+		t.Fatal(err)
+	}
+	rotatedPassword := readCreds()
+	if rotatedPassword == newPassword {
+		t.Fatal("expected password to be rotated by the periodic function")
+	}
+	verifyStaticLogin(t, connURL, "statictest", rotatedPassword, true)
+
+	resp, err = handle(logical.DeleteOperation, "static-roles/plugin-role-test", nil)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err:%s resp:%#v\n", err, resp)
+	}
+	resp, err = handle(logical.ReadOperation, "static-creds/plugin-role-test", nil)
+	if err != nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got err:%s resp:%#v\n", err, resp)
+	}
+}
+
+func verifyStaticLogin(t *testing.T, connURL, username, password string, expectSuccess bool) {
+	t.Helper()
+
+	u, err := url.Parse(connURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.User = url.UserPassword(username, password)
+
+	db, err := sql.Open("postgres", u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = db.Ping()
+	if expectSuccess && err != nil {
+		t.Fatalf("expected login to succeed: %s", err)
+	}
+	if !expectSuccess && err == nil {
+		t.Fatal("expected login to fail")
+	}
+}
@@ -0,0 +1,102 @@
+package kmip
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/consts"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func Factory(ctx context.Context, conf *logical.BackendConfig) (logical.Backend, error) {
+	b := Backend()
+	if err := b.Setup(ctx, conf); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func Backend() *backend {
+	var b backend
+	b.Backend = &framework.Backend{
+		Help: strings.TrimSpace(backendHelp),
+
+		PathsSpecial: &logical.Paths{
+			SealWrapStorage: []string{
+				caPath,
+				objectPath,
+			},
+		},
+
+		Paths: framework.PathAppend(
+			[]*framework.Path{
+				pathConfig(&b),
+				pathCA(&b),
+				pathListScopes(&b),
+				pathScopes(&b),
+				pathListRoles(&b),
+				pathRoles(&b),
+			},
+			pathCredentials(&b),
+		),
+
+		InitializeFunc: b.initialize,
+		PeriodicFunc:   b.periodicFunc,
+		Clean:          b.cleanup,
+		BackendType:    logical.TypeLogical,
+	}
+
+	return &b
+}
+
+type backend struct {
+	*framework.Backend
+
+	// caLock guards the generation of the CA
+	caLock sync.Mutex
+
+	// serverLock guards starting and stopping the KMIP listener
+	serverLock sync.Mutex
+	server     *server
+
+	// objectLock is held for writing while a scope is deleted, so that
+	// objects cannot be created in it concurrently
+	objectLock sync.RWMutex
+}
+
+// initialize starts the KMIP listener as soon as the mount is loaded, if the
+// backend is configured
+func (b *backend) initialize(ctx context.Context, req *logical.InitializationRequest) error {
+	return b.ensureListenerIfActive(ctx, req.Storage)
+}
+
+// periodicFunc retries starting the KMIP listener, in case it could not be
+// started when the mount was loaded
+func (b *backend) periodicFunc(ctx context.Context, req *logical.Request) error {
+	return b.ensureListenerIfActive(ctx, req.Storage)
+}
+
+// ensureListenerIfActive starts the KMIP listener unless this cluster or node
+// does not serve the mount's requests
+func (b *backend) ensureListenerIfActive(ctx context.Context, s logical.Storage) error {
+	if !b.System().LocalMount() && b.System().ReplicationState().HasState(consts.ReplicationPerformanceSecondary|consts.ReplicationPerformanceStandby) {
+		return nil
+	}
+
+	if err := b.ensureListener(ctx, s); err != nil {
+		return errwrap.Wrapf("error starting KMIP listener: {{err}}", err)
+	}
+	return nil
+}
+
+const backendHelp = `
+The KMIP backend runs a KMIP server, allowing KMIP clients such as storage
+and database appliances to use Vault as their key manager.
+
+Managed objects are kept in scopes. Clients authenticate to the KMIP
+listener with TLS certificates generated for a role of a scope, which
+determines the objects they can access and the operations they can perform.
+`
@@ -0,0 +1,483 @@
+package kmip
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func createBackendWithStorage(t *testing.T) (*backend, logical.Storage) {
+	config := logical.TestBackendConfig()
+	config.StorageView = &logical.InmemStorage{}
+
+	b := Backend()
+	err := b.Setup(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, config.StorageView
+}
+
+// testClient is a KMIP client connected to the backend's listener
+type testClient struct {
+	t    *testing.T
+	conn *tls.Conn
+}
+
+func newTestClient(t *testing.T, b *backend, storage logical.Storage, scope, role string) (*testClient, string) {
+	t.Helper()
+
+	creds, err := b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/" + scope + "/role/" + role + "/credential/generate",
+		Storage:   storage,
+	})
+	if err != nil || (creds != nil && creds.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, creds)
+	}
+	cert, err := tls.X509KeyPair([]byte(creds.Data["certificate"].(string)), []byte(creds.Data["private_key"].(string)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	for _, ca := range creds.Data["ca_chain"].([]string) {
+		pool.AppendCertsFromPEM([]byte(ca))
+	}
+
+	addrs := b.listenAddrs()
+	if len(addrs) == 0 {
+		t.Fatal("listener is not running")
+	}
+	conn, err := tls.Dial("tcp", addrs[0], &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testClient{t: t, conn: conn}, creds.Data["serial_number"].(string)
+}
+
+// request performs a single operation, returning the response batch item
+func (c *testClient) request(operation int32, payload ...*item) *item {
+	c.t.Helper()
+
+	msg := structure(tagRequestMessage,
+		structure(tagRequestHeader,
+			protocolVersion(1, 2),
+			integer(tagBatchCount, 1)),
+		structure(tagBatchItem,
+			enumeration(tagOperation, operation),
+			structure(tagRequestPayload, payload...)))
+	b, err := msg.marshal()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if _, err := c.conn.Write(b); err != nil {
+		c.t.Fatal(err)
+	}
+
+	resp, err := readMessage(c.conn)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if resp.Tag != tagResponseMessage {
+		c.t.Fatalf("unexpected response tag 0x%06x", resp.Tag)
+	}
+	batchItems := resp.children(tagBatchItem)
+	if len(batchItems) != 1 {
+		c.t.Fatalf("expected 1 batch item, got %d", len(batchItems))
+	}
+	return batchItems[0]
+}
+
+// mustSucceed performs an operation that must succeed, returning the response
+// payload
+func (c *testClient) mustSucceed(operation int32, payload ...*item) *item {
+	c.t.Helper()
+	batchItem := c.request(operation, payload...)
+	if status, _ := batchItem.int(tagResultStatus); status != resultStatusSuccess {
+		message, _ := batchItem.text(tagResultMessage)
+		c.t.Fatalf("operation 0x%02x failed: %s", operation, message)
+	}
+	return batchItem.child(tagResponsePayload)
+}
+
+// mustFail performs an operation that must fail with the given reason
+func (c *testClient) mustFail(reason int32, operation int32, payload ...*item) {
+	c.t.Helper()
+	batchItem := c.request(operation, payload...)
+	if status, _ := batchItem.int(tagResultStatus); status != resultStatusOperationFailed {
+		c.t.Fatalf("expected operation 0x%02x to fail", operation)
+	}
+	if got, _ := batchItem.int(tagResultReason); got != reason {
+		c.t.Fatalf("expected result reason 0x%02x, got 0x%02x", reason, got)
+	}
+}
+
+func TestBackend_Roles(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	b, storage := createBackendWithStorage(t)
+	defer b.Cleanup(context.Background())
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.CreateOperation,
+		Path:      "scope/missing/role/admin",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"operation_all": true,
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance/role/reader",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"operation_get":    true,
+			"operation_locate": true,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "scope/finance/role/reader",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp.Data["operation_get"] != true || resp.Data["operation_locate"] != true || resp.Data["operation_create"] != nil {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance/role/reader",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"operation_none":   true,
+			"operation_create": true,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "scope/finance/role/reader",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp.Data["operation_get"] != nil || resp.Data["operation_create"] != true {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ListOperation,
+		Path:      "scope/finance/role/",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if keys := resp.Data["keys"].([]string); len(keys) != 1 || keys[0] != "reader" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	// Scopes with roles can only be deleted with force
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.DeleteOperation,
+		Path:      "scope/finance",
+		Storage:   storage,
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.DeleteOperation,
+		Path:      "scope/finance",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"force": true,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "scope/finance/role/reader",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp != nil {
+		t.Fatalf("expected role to be deleted: %#v", resp)
+	}
+}
+
+func TestBackend_Credentials(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	b, storage := createBackendWithStorage(t)
+	defer b.Cleanup(context.Background())
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance/role/admin",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"operation_all":  true,
+			"tls_client_ttl": "1h",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance/role/admin/credential/generate",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"format": "der",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	serial := resp.Data["serial_number"].(string)
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ListOperation,
+		Path:      "scope/finance/role/admin/credential/",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if keys := resp.Data["keys"].([]string); len(keys) != 1 || keys[0] != serial {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "scope/finance/role/admin/credential/lookup",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"serial_number": serial,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp == nil || resp.Data["serial_number"] != serial {
+		t.Fatalf("bad: %#v", resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance/role/admin/credential/revoke",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"serial_number": serial,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "scope/finance/role/admin/credential/lookup",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"serial_number": serial,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp != nil {
+		t.Fatalf("expected credential to be revoked: %#v", resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance/role/missing/credential/generate",
+		Storage:   storage,
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+}
+
+func TestBackend_KMIP(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	b, storage := createBackendWithStorage(t)
+	defer b.Cleanup(context.Background())
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "config",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"listen_addrs":     "127.0.0.1:0",
+			"server_hostnames": "127.0.0.1",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance/role/admin",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"operation_all": true,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance/role/reader",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"operation_get":    true,
+			"operation_locate": true,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/marketing",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/marketing/role/admin",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"operation_all": true,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+
+	admin, adminSerial := newTestClient(t, b, storage, "finance", "admin")
+	reader, _ := newTestClient(t, b, storage, "finance", "reader")
+	other, _ := newTestClient(t, b, storage, "marketing", "admin")
+	defer admin.conn.Close()
+	defer reader.conn.Close()
+	defer other.conn.Close()
+
+	versions := admin.mustSucceed(operationDiscoverVersions,
+		protocolVersion(2, 0),
+		protocolVersion(1, 2))
+	if v := versions.children(tagProtocolVersion); len(v) != 1 {
+		t.Fatalf("expected 1 version, got %d", len(v))
+	}
+
+	payload := admin.mustSucceed(operationCreate,
+		enumeration(tagObjectType, objectTypeSymmetricKey),
+		structure(tagTemplateAttribute,
+			attribute(attributeCryptographicAlgorithm, enumeration(0, cryptographicAlgorithmAES)),
+			attribute(attributeCryptographicLength, integer(0, 256)),
+			attribute(attributeName, nameValue("disk-key"))))
+	id, _ := payload.text(tagUniqueIdentifier)
+	if id == "" {
+		t.Fatal("expected unique identifier")
+	}
+	uid := textString(tagUniqueIdentifier, id)
+
+	// The reader can get keys, but not create them
+	reader.mustFail(resultReasonPermissionDenied, operationCreate,
+		enumeration(tagObjectType, objectTypeSymmetricKey))
+	payload = reader.mustSucceed(operationGet, uid)
+	key := payload.child(tagSymmetricKey).child(tagKeyBlock).child(tagKeyValue).child(tagKeyMaterial)
+	if len(key.Value.([]byte)) != 32 {
+		t.Fatalf("expected 32 byte key, got %d", len(key.Value.([]byte)))
+	}
+
+	payload = reader.mustSucceed(operationLocate,
+		attribute(attributeName, nameValue("disk-key")))
+	if ids := payload.children(tagUniqueIdentifier); len(ids) != 1 || ids[0].Value != id {
+		t.Fatalf("bad locate response: %#v", ids)
+	}
+
+	// Objects of other scopes are not visible
+	other.mustFail(resultReasonItemNotFound, operationGet, uid)
+
+	admin.mustSucceed(operationActivate, uid)
+	payload = admin.mustSucceed(operationGetAttributes, uid, textString(tagAttributeName, attributeState))
+	if attrs := payload.children(tagAttribute); len(attrs) != 1 || attrs[0].child(tagAttributeValue).Value != stateActive {
+		t.Fatalf("bad attributes: %#v", attrs)
+	}
+
+	admin.mustFail(resultReasonIllegalOperation, operationDestroy, uid)
+	admin.mustSucceed(operationRevoke, uid,
+		structure(tagRevocationReason, enumeration(tagRevocationReasonCode, 1)))
+	admin.mustSucceed(operationDestroy, uid)
+	admin.mustFail(resultReasonItemNotFound, operationGet, uid)
+
+	// Revoked credentials are rejected on existing connections
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "scope/finance/role/admin/credential/revoke",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"serial_number": adminSerial,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	admin.mustFail(resultReasonPermissionDenied, operationQuery,
+		enumeration(tagQueryFunction, queryFunctionOperations))
+}
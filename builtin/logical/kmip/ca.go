@@ -0,0 +1,232 @@
+package kmip
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/sdk/helper/certutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	caPath = "ca"
+
+	// caValidity is the lifetime of the generated CA
+	caValidity = 10 * 365 * 24 * time.Hour
+
+	// serverCertValidity is the lifetime of the listener's certificate,
+	// which is reissued every time the listener starts
+	serverCertValidity = 365 * 24 * time.Hour
+)
+
+// caEntry is the CA that issues the certificates of the listener and of
+// KMIP clients
+type caEntry struct {
+	Certificate []byte `json:"certificate"`
+	PrivateKey  []byte `json:"private_key"`
+}
+
+// parsedCA is a caEntry ready for signing
+type parsedCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func (c *parsedCA) pem() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}))
+}
+
+// ca returns the stored CA, generating it if it does not exist yet
+func (b *backend) ca(ctx context.Context, s logical.Storage) (*parsedCA, error) {
+	b.caLock.Lock()
+	defer b.caLock.Unlock()
+
+	entry, err := s.Get(ctx, caPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var ca caEntry
+	if entry != nil {
+		if err := entry.DecodeJSON(&ca); err != nil {
+			return nil, err
+		}
+	} else {
+		ca, err = generateCA()
+		if err != nil {
+			return nil, errwrap.Wrapf("error generating CA: {{err}}", err)
+		}
+		entry, err := logical.StorageEntryJSON(caPath, ca)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Put(ctx, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := x509.ParseCertificate(ca.Certificate)
+	if err != nil {
+		return nil, errwrap.Wrapf("error parsing CA certificate: {{err}}", err)
+	}
+	key, err := x509.ParseECPrivateKey(ca.PrivateKey)
+	if err != nil {
+		return nil, errwrap.Wrapf("error parsing CA private key: {{err}}", err)
+	}
+
+	return &parsedCA{
+		cert: cert,
+		key:  key,
+	}, nil
+}
+
+func generateCA() (caEntry, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return caEntry{}, err
+	}
+	serial, err := certutil.GenerateSerialNumber()
+	if err != nil {
+		return caEntry{}, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "Vault KMIP CA"},
+		NotBefore:             now.Add(-30 * time.Second),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return caEntry{}, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return caEntry{}, err
+	}
+
+	return caEntry{
+		Certificate: der,
+		PrivateKey:  keyDER,
+	}, nil
+}
+
+// issuedCert is a certificate issued by the CA along with its private key
+type issuedCert struct {
+	cert    *x509.Certificate
+	keyDER  []byte
+	certDER []byte
+	key     crypto.Signer
+}
+
+func (c *issuedCert) serialNumber() string {
+	return certutil.GetHexFormatted(c.cert.SerialNumber.Bytes(), ":")
+}
+
+// issueClient signs a new certificate for a KMIP client of a role. The scope
+// and role are recorded in the subject of the certificate.
+func (c *parsedCA) issueClient(scope, role string, ttl time.Duration) (*issuedCert, error) {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	subject := pkix.Name{
+		CommonName:         id,
+		OrganizationalUnit: []string{scope},
+		Organization:       []string{role},
+	}
+	return c.issue(subject, nil, ttl, false)
+}
+
+// issueServer signs a new certificate for the listener
+func (c *parsedCA) issueServer(hostnames []string) (*issuedCert, error) {
+	return c.issue(pkix.Name{CommonName: hostnames[0]}, hostnames, serverCertValidity, true)
+}
+
+func (c *parsedCA) issue(subject pkix.Name, hostnames []string, ttl time.Duration, server bool) (*issuedCert, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := certutil.GenerateSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    now.Add(-30 * time.Second),
+		NotAfter:     now.Add(ttl),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if server {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		for _, hostname := range hostnames {
+			if ip := net.ParseIP(hostname); ip != nil {
+				template.IPAddresses = append(template.IPAddresses, ip)
+			} else {
+				template.DNSNames = append(template.DNSNames, hostname)
+			}
+		}
+	}
+	if template.NotAfter.After(c.cert.NotAfter) {
+		template.NotAfter = c.cert.NotAfter
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, c.cert, key.Public(), c.key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &issuedCert{
+		cert:    cert,
+		certDER: der,
+		keyDER:  keyDER,
+		key:     key,
+	}, nil
+}
+
+// encode returns the certificate and private key in the given format
+func (c *issuedCert) encode(format string) (string, string, error) {
+	switch format {
+	case "pem", "pem_bundle":
+		cert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.certDER}))
+		key := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: c.keyDER}))
+		if format == "pem_bundle" {
+			cert = key + cert
+		}
+		return cert, key, nil
+	case "der":
+		return base64.StdEncoding.EncodeToString(c.certDER), base64.StdEncoding.EncodeToString(c.keyDER), nil
+	default:
+		return "", "", fmt.Errorf("unsupported format %q", format)
+	}
+}
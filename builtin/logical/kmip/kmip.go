@@ -0,0 +1,126 @@
+package kmip
+
+// Tags of the KMIP 1.x items used by the server
+const (
+	tagAttribute              uint32 = 0x420008
+	tagAttributeIndex         uint32 = 0x420009
+	tagAttributeName          uint32 = 0x42000a
+	tagAttributeValue         uint32 = 0x42000b
+	tagBatchCount             uint32 = 0x42000d
+	tagBatchItem              uint32 = 0x42000f
+	tagCryptographicAlgorithm uint32 = 0x420028
+	tagCryptographicLength    uint32 = 0x42002a
+	tagCryptographicUsageMask uint32 = 0x42002c
+	tagKeyBlock               uint32 = 0x420040
+	tagKeyFormatType          uint32 = 0x420042
+	tagKeyMaterial            uint32 = 0x420043
+	tagKeyValue               uint32 = 0x420045
+	tagMaximumItems           uint32 = 0x42004f
+	tagName                   uint32 = 0x420053
+	tagNameType               uint32 = 0x420054
+	tagNameValue              uint32 = 0x420055
+	tagObjectType             uint32 = 0x420057
+	tagOperation              uint32 = 0x42005c
+	tagProtocolVersion        uint32 = 0x420069
+	tagProtocolVersionMajor   uint32 = 0x42006a
+	tagProtocolVersionMinor   uint32 = 0x42006b
+	tagQueryFunction          uint32 = 0x420074
+	tagRequestHeader          uint32 = 0x420077
+	tagRequestMessage         uint32 = 0x420078
+	tagRequestPayload         uint32 = 0x420079
+	tagResponseHeader         uint32 = 0x42007a
+	tagResponseMessage        uint32 = 0x42007b
+	tagResponsePayload        uint32 = 0x42007c
+	tagResultMessage          uint32 = 0x42007d
+	tagResultReason           uint32 = 0x42007e
+	tagResultStatus           uint32 = 0x42007f
+	tagRevocationReason       uint32 = 0x420081
+	tagRevocationReasonCode   uint32 = 0x420082
+	tagState                  uint32 = 0x42008d
+	tagSymmetricKey           uint32 = 0x42008f
+	tagTemplateAttribute      uint32 = 0x420091
+	tagTimeStamp              uint32 = 0x420092
+	tagUniqueBatchItemID      uint32 = 0x420093
+	tagUniqueIdentifier       uint32 = 0x420094
+	tagVendorIdentification   uint32 = 0x42009d
+)
+
+// Operations
+const (
+	operationCreate           int32 = 0x01
+	operationLocate           int32 = 0x08
+	operationGet              int32 = 0x0a
+	operationGetAttributes    int32 = 0x0b
+	operationActivate         int32 = 0x12
+	operationRevoke           int32 = 0x13
+	operationDestroy          int32 = 0x14
+	operationQuery            int32 = 0x18
+	operationDiscoverVersions int32 = 0x1e
+)
+
+// Result statuses and reasons
+const (
+	resultStatusSuccess         int32 = 0x00
+	resultStatusOperationFailed int32 = 0x01
+
+	resultReasonItemNotFound          int32 = 0x01
+	resultReasonInvalidMessage        int32 = 0x04
+	resultReasonOperationNotSupported int32 = 0x05
+	resultReasonMissingData           int32 = 0x06
+	resultReasonInvalidField          int32 = 0x07
+	resultReasonIllegalOperation      int32 = 0x0b
+	resultReasonPermissionDenied      int32 = 0x0c
+	resultReasonGeneralFailure        int32 = 0x100
+)
+
+// Object types, algorithms and key formats
+const (
+	objectTypeSymmetricKey int32 = 0x02
+
+	cryptographicAlgorithmAES int32 = 0x03
+
+	keyFormatTypeRaw int32 = 0x01
+
+	nameTypeUninterpretedTextString int32 = 0x01
+)
+
+// Object states
+const (
+	statePreActive   int32 = 0x01
+	stateActive      int32 = 0x02
+	stateDeactivated int32 = 0x03
+	stateCompromised int32 = 0x04
+)
+
+// Query functions and revocation reasons
+const (
+	queryFunctionOperations  int32 = 0x01
+	queryFunctionObjects     int32 = 0x02
+	queryFunctionInformation int32 = 0x03
+
+	revocationReasonKeyCompromise int32 = 0x02
+)
+
+// Names of the attributes supported in templates and attribute requests
+const (
+	attributeCryptographicAlgorithm = "Cryptographic Algorithm"
+	attributeCryptographicLength    = "Cryptographic Length"
+	attributeCryptographicUsageMask = "Cryptographic Usage Mask"
+	attributeName                   = "Name"
+	attributeObjectType             = "Object Type"
+	attributeState                  = "State"
+	attributeUniqueIdentifier       = "Unique Identifier"
+)
+
+// supportedVersions are the KMIP protocol versions the server speaks, most
+// preferred first
+var supportedVersions = [][2]int32{
+	{1, 4},
+	{1, 3},
+	{1, 2},
+	{1, 1},
+	{1, 0},
+}
+
+// vendorIdentification is returned by Query operations
+const vendorIdentification = "HashiCorp Vault"
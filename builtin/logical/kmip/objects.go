@@ -0,0 +1,48 @@
+package kmip
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const objectPath = "objects/"
+
+// objectEntry is a managed object of a scope. Only symmetric keys are
+// supported.
+type objectEntry struct {
+	ObjectType     int32     `json:"object_type"`
+	Algorithm      int32     `json:"algorithm"`
+	Length         int32     `json:"length"`
+	UsageMask      int32     `json:"usage_mask"`
+	Key            []byte    `json:"key"`
+	State          int32     `json:"state"`
+	Names          []string  `json:"names"`
+	CreationTime   time.Time `json:"creation_time"`
+	ActivationTime time.Time `json:"activation_time"`
+}
+
+func (b *backend) object(ctx context.Context, s logical.Storage, scope, id string) (*objectEntry, error) {
+	entry, err := s.Get(ctx, objectPath+scope+"/"+id)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	obj := new(objectEntry)
+	if err := entry.DecodeJSON(obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (b *backend) putObject(ctx context.Context, s logical.Storage, scope, id string, obj *objectEntry) error {
+	entry, err := logical.StorageEntryJSON(objectPath+scope+"/"+id, obj)
+	if err != nil {
+		return err
+	}
+	return s.Put(ctx, entry)
+}
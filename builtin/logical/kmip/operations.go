@@ -0,0 +1,403 @@
+package kmip
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"time"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/sdk/helper/strutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// kmipError is the failure of a batch item, reported to the client with a
+// result reason
+type kmipError struct {
+	reason  int32
+	message string
+}
+
+func (e *kmipError) Error() string {
+	return e.message
+}
+
+func newKMIPError(reason int32, format string, args ...interface{}) *kmipError {
+	return &kmipError{
+		reason:  reason,
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// operationFunc performs an operation on the objects of a scope, returning
+// the children of the response payload
+type operationFunc func(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error)
+
+func (b *backend) operations() map[int32]operationFunc {
+	return map[int32]operationFunc{
+		operationCreate:           b.opCreate,
+		operationLocate:           b.opLocate,
+		operationGet:              b.opGet,
+		operationGetAttributes:    b.opGetAttributes,
+		operationActivate:         b.opActivate,
+		operationRevoke:           b.opRevoke,
+		operationDestroy:          b.opDestroy,
+		operationQuery:            b.opQuery,
+		operationDiscoverVersions: b.opDiscoverVersions,
+	}
+}
+
+// attributes returns the values of the attributes of a Create template or
+// Locate request by attribute name
+func attributes(parent *item) map[string][]*item {
+	attrs := make(map[string][]*item)
+	if parent == nil {
+		return attrs
+	}
+	for _, attr := range parent.children(tagAttribute) {
+		name, ok := attr.text(tagAttributeName)
+		if !ok {
+			continue
+		}
+		if value := attr.child(tagAttributeValue); value != nil {
+			attrs[name] = append(attrs[name], value)
+		}
+	}
+	return attrs
+}
+
+func attribute(name string, value *item) *item {
+	value.Tag = tagAttributeValue
+	return structure(tagAttribute, textString(tagAttributeName, name), value)
+}
+
+func nameValue(name string) *item {
+	return structure(tagAttributeValue,
+		textString(tagNameValue, name),
+		enumeration(tagNameType, nameTypeUninterpretedTextString))
+}
+
+// intAttribute returns the value of a single valued Integer or Enumeration
+// attribute
+func intAttribute(attrs map[string][]*item, name string) (int32, bool) {
+	values := attrs[name]
+	if len(values) == 0 {
+		return 0, false
+	}
+	v, ok := values[0].Value.(int32)
+	return v, ok
+}
+
+// names returns the values of Name attributes
+func names(attrs map[string][]*item) []string {
+	var names []string
+	for _, value := range attrs[attributeName] {
+		if name, ok := value.text(tagNameValue); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func uniqueIdentifier(payload *item) (string, error) {
+	id, ok := payload.text(tagUniqueIdentifier)
+	if !ok || id == "" {
+		return "", newKMIPError(resultReasonMissingData, "unique identifier is required")
+	}
+	return id, nil
+}
+
+// loadObject returns the object identified in the payload
+func (b *backend) loadObject(ctx context.Context, s logical.Storage, scope string, payload *item) (string, *objectEntry, error) {
+	id, err := uniqueIdentifier(payload)
+	if err != nil {
+		return "", nil, err
+	}
+	obj, err := b.object(ctx, s, scope, id)
+	if err != nil {
+		return "", nil, err
+	}
+	if obj == nil {
+		return "", nil, newKMIPError(resultReasonItemNotFound, "object %q not found", id)
+	}
+	return id, obj, nil
+}
+
+func (b *backend) opCreate(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error) {
+	objectType, ok := payload.int(tagObjectType)
+	if !ok {
+		return nil, newKMIPError(resultReasonMissingData, "object type is required")
+	}
+	if objectType != objectTypeSymmetricKey {
+		return nil, newKMIPError(resultReasonInvalidField, "only symmetric keys can be created")
+	}
+
+	attrs := attributes(payload.child(tagTemplateAttribute))
+	algorithm, ok := intAttribute(attrs, attributeCryptographicAlgorithm)
+	if !ok {
+		return nil, newKMIPError(resultReasonMissingData, "cryptographic algorithm is required")
+	}
+	if algorithm != cryptographicAlgorithmAES {
+		return nil, newKMIPError(resultReasonInvalidField, "only AES keys are supported")
+	}
+	length, ok := intAttribute(attrs, attributeCryptographicLength)
+	if !ok {
+		return nil, newKMIPError(resultReasonMissingData, "cryptographic length is required")
+	}
+	switch length {
+	case 128, 192, 256:
+	default:
+		return nil, newKMIPError(resultReasonInvalidField, "unsupported cryptographic length %d", length)
+	}
+	usageMask, _ := intAttribute(attrs, attributeCryptographicUsageMask)
+
+	key := make([]byte, length/8)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &objectEntry{
+		ObjectType:   objectType,
+		Algorithm:    algorithm,
+		Length:       length,
+		UsageMask:    usageMask,
+		Key:          key,
+		State:        statePreActive,
+		Names:        names(attrs),
+		CreationTime: time.Now().UTC(),
+	}
+	if err := b.putObject(ctx, s, scope, id, obj); err != nil {
+		return nil, err
+	}
+
+	return []*item{
+		enumeration(tagObjectType, objectType),
+		textString(tagUniqueIdentifier, id),
+	}, nil
+}
+
+func (b *backend) opGet(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error) {
+	id, obj, err := b.loadObject(ctx, s, scope, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*item{
+		enumeration(tagObjectType, obj.ObjectType),
+		textString(tagUniqueIdentifier, id),
+		structure(tagSymmetricKey,
+			structure(tagKeyBlock,
+				enumeration(tagKeyFormatType, keyFormatTypeRaw),
+				structure(tagKeyValue,
+					byteString(tagKeyMaterial, obj.Key)),
+				enumeration(tagCryptographicAlgorithm, obj.Algorithm),
+				integer(tagCryptographicLength, obj.Length))),
+	}, nil
+}
+
+func (b *backend) opGetAttributes(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error) {
+	id, obj, err := b.loadObject(ctx, s, scope, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := []*item{
+		attribute(attributeUniqueIdentifier, textString(0, id)),
+		attribute(attributeObjectType, enumeration(0, obj.ObjectType)),
+		attribute(attributeCryptographicAlgorithm, enumeration(0, obj.Algorithm)),
+		attribute(attributeCryptographicLength, integer(0, obj.Length)),
+		attribute(attributeCryptographicUsageMask, integer(0, obj.UsageMask)),
+		attribute(attributeState, enumeration(0, obj.State)),
+	}
+	for _, name := range obj.Names {
+		attrs = append(attrs, attribute(attributeName, nameValue(name)))
+	}
+
+	// Only return the requested attributes, if any
+	var requested []string
+	for _, name := range payload.children(tagAttributeName) {
+		if n, ok := name.Value.(string); ok {
+			requested = append(requested, n)
+		}
+	}
+
+	resp := []*item{textString(tagUniqueIdentifier, id)}
+	for _, attr := range attrs {
+		name, _ := attr.text(tagAttributeName)
+		if len(requested) == 0 || strutil.StrListContains(requested, name) {
+			resp = append(resp, attr)
+		}
+	}
+	return resp, nil
+}
+
+func (b *backend) opLocate(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error) {
+	attrs := attributes(payload)
+	maxItems, _ := payload.int(tagMaximumItems)
+
+	ids, err := s.List(ctx, objectPath+scope+"/")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(ids)
+
+	var resp []*item
+	for _, id := range ids {
+		if maxItems > 0 && int32(len(resp)) >= maxItems {
+			break
+		}
+
+		obj, err := b.object(ctx, s, scope, id)
+		if err != nil {
+			return nil, err
+		}
+		if obj == nil || !obj.matches(attrs) {
+			continue
+		}
+		resp = append(resp, textString(tagUniqueIdentifier, id))
+	}
+	return resp, nil
+}
+
+// matches returns whether the object has all the given attributes
+func (obj *objectEntry) matches(attrs map[string][]*item) bool {
+	for name, values := range attrs {
+		for _, value := range values {
+			var match bool
+			switch name {
+			case attributeName:
+				n, _ := value.text(tagNameValue)
+				match = strutil.StrListContains(obj.Names, n)
+			case attributeObjectType:
+				match = value.Value == obj.ObjectType
+			case attributeCryptographicAlgorithm:
+				match = value.Value == obj.Algorithm
+			case attributeCryptographicLength:
+				match = value.Value == obj.Length
+			case attributeState:
+				match = value.Value == obj.State
+			}
+			if !match {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (b *backend) opActivate(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error) {
+	id, obj, err := b.loadObject(ctx, s, scope, payload)
+	if err != nil {
+		return nil, err
+	}
+	if obj.State != statePreActive {
+		return nil, newKMIPError(resultReasonIllegalOperation, "only pre-active objects can be activated")
+	}
+
+	obj.State = stateActive
+	obj.ActivationTime = time.Now().UTC()
+	if err := b.putObject(ctx, s, scope, id, obj); err != nil {
+		return nil, err
+	}
+
+	return []*item{textString(tagUniqueIdentifier, id)}, nil
+}
+
+func (b *backend) opRevoke(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error) {
+	id, obj, err := b.loadObject(ctx, s, scope, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := payload.child(tagRevocationReason)
+	if reason == nil {
+		return nil, newKMIPError(resultReasonMissingData, "revocation reason is required")
+	}
+	code, _ := reason.int(tagRevocationReasonCode)
+
+	switch {
+	case code == revocationReasonKeyCompromise && obj.State != stateCompromised:
+		obj.State = stateCompromised
+	case obj.State == statePreActive || obj.State == stateActive:
+		obj.State = stateDeactivated
+	default:
+		return nil, newKMIPError(resultReasonIllegalOperation, "object is already revoked")
+	}
+	if err := b.putObject(ctx, s, scope, id, obj); err != nil {
+		return nil, err
+	}
+
+	return []*item{textString(tagUniqueIdentifier, id)}, nil
+}
+
+func (b *backend) opDestroy(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error) {
+	id, obj, err := b.loadObject(ctx, s, scope, payload)
+	if err != nil {
+		return nil, err
+	}
+	if obj.State == stateActive {
+		return nil, newKMIPError(resultReasonIllegalOperation, "active objects must be revoked before they are destroyed")
+	}
+
+	if err := s.Delete(ctx, objectPath+scope+"/"+id); err != nil {
+		return nil, err
+	}
+
+	return []*item{textString(tagUniqueIdentifier, id)}, nil
+}
+
+func (b *backend) opQuery(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error) {
+	var resp []*item
+	for _, function := range payload.children(tagQueryFunction) {
+		switch function.Value {
+		case queryFunctionOperations:
+			var ops []int
+			for op := range b.operations() {
+				ops = append(ops, int(op))
+			}
+			sort.Ints(ops)
+			for _, op := range ops {
+				resp = append(resp, enumeration(tagOperation, int32(op)))
+			}
+		case queryFunctionObjects:
+			resp = append(resp, enumeration(tagObjectType, objectTypeSymmetricKey))
+		case queryFunctionInformation:
+			resp = append(resp, textString(tagVendorIdentification, vendorIdentification))
+		}
+	}
+	return resp, nil
+}
+
+func (b *backend) opDiscoverVersions(ctx context.Context, s logical.Storage, scope string, payload *item) ([]*item, error) {
+	var resp []*item
+	requested := payload.children(tagProtocolVersion)
+	if len(requested) == 0 {
+		for _, v := range supportedVersions {
+			resp = append(resp, protocolVersion(v[0], v[1]))
+		}
+		return resp, nil
+	}
+
+	// Return the supported versions in the client's order of preference
+	for _, version := range requested {
+		major, _ := version.int(tagProtocolVersionMajor)
+		minor, _ := version.int(tagProtocolVersionMinor)
+		for _, v := range supportedVersions {
+			if v[0] == major && v[1] == minor {
+				resp = append(resp, protocolVersion(major, minor))
+			}
+		}
+	}
+	return resp, nil
+}
+
+func protocolVersion(major, minor int32) *item {
+	return structure(tagProtocolVersion,
+		integer(tagProtocolVersionMajor, major),
+		integer(tagProtocolVersionMinor, minor))
+}
@@ -0,0 +1,191 @@
+package kmip
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	configPath = "config"
+
+	defaultListenAddr     = "127.0.0.1:5696"
+	defaultServerHostname = "localhost"
+	defaultTLSClientTTL   = 14 * 24 * time.Hour
+)
+
+// kmipConfig configures the KMIP listener
+type kmipConfig struct {
+	ListenAddrs         []string      `json:"listen_addrs"`
+	ServerHostnames     []string      `json:"server_hostnames"`
+	DefaultTLSClientTTL time.Duration `json:"default_tls_client_ttl"`
+}
+
+func pathConfig(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: configPath,
+		Fields: map[string]*framework.FieldSchema{
+			"listen_addrs": &framework.FieldSchema{
+				Type:        framework.TypeCommaStringSlice,
+				Default:     []string{defaultListenAddr},
+				Description: "Addresses the KMIP listener binds to.",
+			},
+			"server_hostnames": &framework.FieldSchema{
+				Type:        framework.TypeCommaStringSlice,
+				Default:     []string{defaultServerHostname},
+				Description: "Hostnames and IP addresses included in the listener's certificate.",
+			},
+			"default_tls_client_ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Default:     int(defaultTLSClientTTL.Seconds()),
+				Description: "Default lifetime of client certificates.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathConfigRead,
+			logical.UpdateOperation: b.pathConfigWrite,
+		},
+
+		HelpSynopsis:    pathConfigHelpSyn,
+		HelpDescription: pathConfigHelpDesc,
+	}
+}
+
+// config returns the stored configuration, or the defaults if the backend
+// has not been configured
+func (b *backend) config(ctx context.Context, s logical.Storage) (*kmipConfig, error) {
+	cfg := &kmipConfig{
+		ListenAddrs:         []string{defaultListenAddr},
+		ServerHostnames:     []string{defaultServerHostname},
+		DefaultTLSClientTTL: defaultTLSClientTTL,
+	}
+
+	entry, err := s.Get(ctx, configPath)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return cfg, nil
+	}
+
+	if err := entry.DecodeJSON(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (b *backend) pathConfigRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"listen_addrs":           cfg.ListenAddrs,
+			"server_hostnames":       cfg.ServerHostnames,
+			"default_tls_client_ttl": int64(cfg.DefaultTLSClientTTL.Seconds()),
+		},
+	}, nil
+}
+
+func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw, ok := data.GetOk("listen_addrs"); ok {
+		cfg.ListenAddrs = raw.([]string)
+	}
+	if raw, ok := data.GetOk("server_hostnames"); ok {
+		cfg.ServerHostnames = raw.([]string)
+	}
+	if raw, ok := data.GetOk("default_tls_client_ttl"); ok {
+		cfg.DefaultTLSClientTTL = time.Duration(raw.(int)) * time.Second
+	}
+
+	if len(cfg.ListenAddrs) == 0 {
+		return logical.ErrorResponse("at least one listen address is required"), nil
+	}
+	for _, addr := range cfg.ListenAddrs {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return logical.ErrorResponse(errwrap.Wrapf("invalid listen address: {{err}}", err).Error()), nil
+		}
+	}
+	if len(cfg.ServerHostnames) == 0 {
+		return logical.ErrorResponse("at least one server hostname is required"), nil
+	}
+	if cfg.DefaultTLSClientTTL <= 0 {
+		return logical.ErrorResponse("default_tls_client_ttl must be positive"), nil
+	}
+
+	entry, err := logical.StorageEntryJSON(configPath, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	// Restart the listener with the new configuration
+	if err := b.restartListener(ctx, req.Storage); err != nil {
+		return nil, errwrap.Wrapf("error starting KMIP listener: {{err}}", err)
+	}
+
+	return nil, nil
+}
+
+func pathCA(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: caPath,
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.pathCARead,
+		},
+
+		HelpSynopsis:    pathCAHelpSyn,
+		HelpDescription: pathCAHelpDesc,
+	}
+}
+
+func (b *backend) pathCARead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	ca, err := b.ca(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"ca_pem": ca.pem(),
+		},
+	}, nil
+}
+
+const pathConfigHelpSyn = `
+Configure the KMIP listener.
+`
+
+const pathConfigHelpDesc = `
+The KMIP listener accepts TLS connections from KMIP clients on the
+configured addresses. Its certificate is issued by the backend's CA for the
+configured server hostnames, and clients authenticate with certificates
+generated for a role.
+
+Changing the configuration restarts the listener.
+`
+
+const pathCAHelpSyn = `
+Read the CA certificate of the KMIP listener and clients.
+`
+
+const pathCAHelpDesc = `
+Returns the PEM encoded certificate of the CA that issues the listener's
+certificate and the client certificates. KMIP clients should trust it to
+verify the listener.
+`
@@ -0,0 +1,225 @@
+package kmip
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const credentialPath = "credentials/"
+
+// credentialEntry records a client certificate issued for a role. Removing
+// the entry revokes the certificate.
+type credentialEntry struct {
+	Certificate []byte    `json:"certificate"`
+	Expiration  time.Time `json:"expiration"`
+}
+
+func credentialFields() map[string]*framework.FieldSchema {
+	return map[string]*framework.FieldSchema{
+		"scope": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: "Name of the scope",
+		},
+		"role": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: "Name of the role",
+		},
+		"serial_number": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: "Serial number of the client certificate.",
+		},
+		"format": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Default:     "pem",
+			Description: `Format of the certificate and private key, one of "pem", "pem_bundle" or "der".`,
+		},
+	}
+}
+
+func credentialPattern(suffix string) string {
+	return "scope/" + framework.GenericNameRegex("scope") + "/role/" + framework.GenericNameRegex("role") + "/credential/" + suffix
+}
+
+func pathCredentials(b *backend) []*framework.Path {
+	return []*framework.Path{
+		&framework.Path{
+			Pattern: credentialPattern("?$"),
+			Fields:  credentialFields(),
+
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.ListOperation: b.pathCredentialList,
+			},
+
+			HelpSynopsis:    pathCredentialsHelpSyn,
+			HelpDescription: pathCredentialsHelpDesc,
+		},
+		&framework.Path{
+			Pattern: credentialPattern("generate$"),
+			Fields:  credentialFields(),
+
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.UpdateOperation: b.pathCredentialGenerate,
+			},
+
+			HelpSynopsis:    pathCredentialsHelpSyn,
+			HelpDescription: pathCredentialsHelpDesc,
+		},
+		&framework.Path{
+			Pattern: credentialPattern("lookup$"),
+			Fields:  credentialFields(),
+
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.ReadOperation: b.pathCredentialLookup,
+			},
+
+			HelpSynopsis:    pathCredentialsHelpSyn,
+			HelpDescription: pathCredentialsHelpDesc,
+		},
+		&framework.Path{
+			Pattern: credentialPattern("revoke$"),
+			Fields:  credentialFields(),
+
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.UpdateOperation: b.pathCredentialRevoke,
+			},
+
+			HelpSynopsis:    pathCredentialsHelpSyn,
+			HelpDescription: pathCredentialsHelpDesc,
+		},
+	}
+}
+
+func (b *backend) credential(ctx context.Context, s logical.Storage, scope, role, serial string) (*credentialEntry, error) {
+	entry, err := s.Get(ctx, credentialPath+scope+"/"+role+"/"+serial)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	cred := new(credentialEntry)
+	if err := entry.DecodeJSON(cred); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+func (b *backend) pathCredentialList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	serials, err := req.Storage.List(ctx, credentialPath+data.Get("scope").(string)+"/"+data.Get("role").(string)+"/")
+	if err != nil {
+		return nil, err
+	}
+	return logical.ListResponse(serials), nil
+}
+
+func (b *backend) pathCredentialGenerate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	scopeName := data.Get("scope").(string)
+	roleName := data.Get("role").(string)
+
+	role, err := b.role(ctx, req.Storage, scopeName, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse(fmt.Sprintf("role %q does not exist in scope %q", roleName, scopeName)), nil
+	}
+
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	ttl := cfg.DefaultTLSClientTTL
+	if role.TLSClientTTL > 0 {
+		ttl = role.TLSClientTTL
+	}
+
+	ca, err := b.ca(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := ca.issueClient(scopeName, roleName, ttl)
+	if err != nil {
+		return nil, errwrap.Wrapf("error issuing client certificate: {{err}}", err)
+	}
+
+	format := data.Get("format").(string)
+	certificate, privateKey, err := cert.encode(format)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	serial := cert.serialNumber()
+	entry, err := logical.StorageEntryJSON(credentialPath+scopeName+"/"+roleName+"/"+serial, &credentialEntry{
+		Certificate: cert.certDER,
+		Expiration:  cert.cert.NotAfter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"certificate":   certificate,
+			"private_key":   privateKey,
+			"ca_chain":      []string{ca.pem()},
+			"serial_number": serial,
+			"expiration":    cert.cert.NotAfter.Unix(),
+		},
+	}, nil
+}
+
+func (b *backend) pathCredentialLookup(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	serial := data.Get("serial_number").(string)
+	if serial == "" {
+		return logical.ErrorResponse("serial_number is required"), nil
+	}
+
+	cred, err := b.credential(ctx, req.Storage, data.Get("scope").(string), data.Get("role").(string), serial)
+	if err != nil {
+		return nil, err
+	}
+	if cred == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"certificate":   string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cred.Certificate})),
+			"serial_number": serial,
+			"expiration":    cred.Expiration.Unix(),
+		},
+	}, nil
+}
+
+func (b *backend) pathCredentialRevoke(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	serial := data.Get("serial_number").(string)
+	if serial == "" {
+		return logical.ErrorResponse("serial_number is required"), nil
+	}
+
+	if err := req.Storage.Delete(ctx, credentialPath+data.Get("scope").(string)+"/"+data.Get("role").(string)+"/"+serial); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+const pathCredentialsHelpSyn = `
+Manage the client certificates of a role.
+`
+
+const pathCredentialsHelpDesc = `
+KMIP clients authenticate to the listener with a TLS client certificate
+generated for a role, which determines the scope and operations available to
+the client. Revoking a certificate immediately prevents new connections and
+requests made with it.
+`
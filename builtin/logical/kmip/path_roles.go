@@ -0,0 +1,270 @@
+package kmip
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/strutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const rolePath = "roles/"
+
+// roleOperations maps the names of the operation fields of roles to the
+// KMIP operations they allow
+var roleOperations = map[string]int32{
+	"create":            operationCreate,
+	"locate":            operationLocate,
+	"get":               operationGet,
+	"get_attributes":    operationGetAttributes,
+	"activate":          operationActivate,
+	"revoke":            operationRevoke,
+	"destroy":           operationDestroy,
+	"query":             operationQuery,
+	"discover_versions": operationDiscoverVersions,
+}
+
+// roleEntry lists the KMIP operations that clients of a role may perform
+type roleEntry struct {
+	OperationAll bool          `json:"operation_all"`
+	Operations   []string      `json:"operations"`
+	TLSClientTTL time.Duration `json:"tls_client_ttl"`
+}
+
+// allowed returns whether the role may perform the operation
+func (r *roleEntry) allowed(operation int32) bool {
+	if r.OperationAll {
+		return true
+	}
+	for _, name := range r.Operations {
+		if roleOperations[name] == operation {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *roleEntry) toResponseData() map[string]interface{} {
+	data := map[string]interface{}{
+		"tls_client_ttl": int64(r.TLSClientTTL.Seconds()),
+	}
+	if r.OperationAll {
+		data["operation_all"] = true
+	}
+	for _, name := range r.Operations {
+		data["operation_"+name] = true
+	}
+	return data
+}
+
+func pathListRoles(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "scope/" + framework.GenericNameRegex("scope") + "/role/?$",
+		Fields: map[string]*framework.FieldSchema{
+			"scope": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the scope",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathRoleList,
+		},
+
+		HelpSynopsis:    pathRolesHelpSyn,
+		HelpDescription: pathRolesHelpDesc,
+	}
+}
+
+func pathRoles(b *backend) *framework.Path {
+	fields := map[string]*framework.FieldSchema{
+		"scope": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: "Name of the scope",
+		},
+		"role": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: "Name of the role",
+		},
+		"operation_all": &framework.FieldSchema{
+			Type:        framework.TypeBool,
+			Description: "Allow all KMIP operations.",
+		},
+		"operation_none": &framework.FieldSchema{
+			Type:        framework.TypeBool,
+			Description: "Remove all allowed KMIP operations.",
+		},
+		"tls_client_ttl": &framework.FieldSchema{
+			Type:        framework.TypeDurationSecond,
+			Description: "Lifetime of client certificates. Defaults to the configured default_tls_client_ttl.",
+		},
+	}
+	for name := range roleOperations {
+		fields["operation_"+name] = &framework.FieldSchema{
+			Type:        framework.TypeBool,
+			Description: fmt.Sprintf("Allow the KMIP %s operation.", name),
+		}
+	}
+
+	return &framework.Path{
+		Pattern: "scope/" + framework.GenericNameRegex("scope") + "/role/" + framework.GenericNameRegex("role"),
+		Fields:  fields,
+
+		ExistenceCheck: b.pathRoleExistenceCheck,
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathRoleRead,
+			logical.CreateOperation: b.pathRoleWrite,
+			logical.UpdateOperation: b.pathRoleWrite,
+			logical.DeleteOperation: b.pathRoleDelete,
+		},
+
+		HelpSynopsis:    pathRolesHelpSyn,
+		HelpDescription: pathRolesHelpDesc,
+	}
+}
+
+func (b *backend) role(ctx context.Context, s logical.Storage, scope, name string) (*roleEntry, error) {
+	entry, err := s.Get(ctx, rolePath+scope+"/"+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	role := new(roleEntry)
+	if err := entry.DecodeJSON(role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// deleteRole deletes a role and its credentials
+func (b *backend) deleteRole(ctx context.Context, s logical.Storage, scope, name string) error {
+	prefix := credentialPath + scope + "/" + name + "/"
+	serials, err := s.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	for _, serial := range serials {
+		if err := s.Delete(ctx, prefix+serial); err != nil {
+			return err
+		}
+	}
+
+	return s.Delete(ctx, rolePath+scope+"/"+name)
+}
+
+func (b *backend) pathRoleExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+	role, err := b.role(ctx, req.Storage, data.Get("scope").(string), data.Get("role").(string))
+	if err != nil {
+		return false, err
+	}
+	return role != nil, nil
+}
+
+func (b *backend) pathRoleList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, rolePath+data.Get("scope").(string)+"/")
+	if err != nil {
+		return nil, err
+	}
+	return logical.ListResponse(roles), nil
+}
+
+func (b *backend) pathRoleRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	role, err := b.role(ctx, req.Storage, data.Get("scope").(string), data.Get("role").(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: role.toResponseData(),
+	}, nil
+}
+
+func (b *backend) pathRoleWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	scopeName := data.Get("scope").(string)
+	name := data.Get("role").(string)
+
+	scope, err := b.scope(ctx, req.Storage, scopeName)
+	if err != nil {
+		return nil, err
+	}
+	if scope == nil {
+		return logical.ErrorResponse(fmt.Sprintf("scope %q does not exist", scopeName)), nil
+	}
+
+	role, err := b.role(ctx, req.Storage, scopeName, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = new(roleEntry)
+	}
+
+	if data.Get("operation_none").(bool) {
+		role.OperationAll = false
+		role.Operations = nil
+	}
+	if raw, ok := data.GetOk("operation_all"); ok {
+		role.OperationAll = raw.(bool)
+	}
+	for op := range roleOperations {
+		raw, ok := data.GetOk("operation_" + op)
+		if !ok {
+			continue
+		}
+		if raw.(bool) {
+			role.Operations = append(role.Operations, op)
+		} else {
+			role.Operations = strutil.StrListDelete(role.Operations, op)
+		}
+	}
+	role.Operations = strutil.RemoveDuplicates(role.Operations, false)
+	sort.Strings(role.Operations)
+
+	if raw, ok := data.GetOk("tls_client_ttl"); ok {
+		role.TLSClientTTL = time.Duration(raw.(int)) * time.Second
+	}
+	if role.TLSClientTTL < 0 {
+		return logical.ErrorResponse("tls_client_ttl cannot be negative"), nil
+	}
+
+	entry, err := logical.StorageEntryJSON(rolePath+scopeName+"/"+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathRoleDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if err := b.deleteRole(ctx, req.Storage, data.Get("scope").(string), data.Get("role").(string)); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+const pathRolesHelpSyn = `
+Manage the roles that KMIP clients authenticate as.
+`
+
+const pathRolesHelpDesc = `
+A role lists the KMIP operations its clients may perform on the managed
+objects of its scope, with "operation_all" or with individual operation
+fields such as "operation_create" and "operation_get". "operation_none"
+removes all previously allowed operations.
+
+Clients authenticate with certificates generated for the role. Deleting a
+role revokes its certificates.
+`
@@ -0,0 +1,136 @@
+package kmip
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const scopePath = "scopes/"
+
+// scopeEntry is a namespace of managed objects. Clients only see the objects
+// of the scope of their role.
+type scopeEntry struct{}
+
+func pathListScopes(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "scope/?$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathScopeList,
+		},
+
+		HelpSynopsis:    pathScopesHelpSyn,
+		HelpDescription: pathScopesHelpDesc,
+	}
+}
+
+func pathScopes(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "scope/" + framework.GenericNameRegex("scope"),
+		Fields: map[string]*framework.FieldSchema{
+			"scope": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the scope",
+			},
+			"force": &framework.FieldSchema{
+				Type:        framework.TypeBool,
+				Description: "Delete the scope along with its roles, credentials and managed objects.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathScopeWrite,
+			logical.DeleteOperation: b.pathScopeDelete,
+		},
+
+		HelpSynopsis:    pathScopesHelpSyn,
+		HelpDescription: pathScopesHelpDesc,
+	}
+}
+
+func (b *backend) scope(ctx context.Context, s logical.Storage, name string) (*scopeEntry, error) {
+	entry, err := s.Get(ctx, scopePath+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	scope := new(scopeEntry)
+	if err := entry.DecodeJSON(scope); err != nil {
+		return nil, err
+	}
+	return scope, nil
+}
+
+func (b *backend) pathScopeList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	scopes, err := req.Storage.List(ctx, scopePath)
+	if err != nil {
+		return nil, err
+	}
+	return logical.ListResponse(scopes), nil
+}
+
+func (b *backend) pathScopeWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	entry, err := logical.StorageEntryJSON(scopePath+data.Get("scope").(string), &scopeEntry{})
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (b *backend) pathScopeDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("scope").(string)
+
+	b.objectLock.Lock()
+	defer b.objectLock.Unlock()
+
+	roles, err := req.Storage.List(ctx, rolePath+name+"/")
+	if err != nil {
+		return nil, err
+	}
+	objects, err := req.Storage.List(ctx, objectPath+name+"/")
+	if err != nil {
+		return nil, err
+	}
+	if (len(roles) > 0 || len(objects) > 0) && !data.Get("force").(bool) {
+		return logical.ErrorResponse(fmt.Sprintf("scope %q has %d roles and %d managed objects; set force to delete them", name, len(roles), len(objects))), nil
+	}
+
+	for _, role := range roles {
+		if err := b.deleteRole(ctx, req.Storage, name, strings.TrimSuffix(role, "/")); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range objects {
+		if err := req.Storage.Delete(ctx, objectPath+name+"/"+id); err != nil {
+			return nil, err
+		}
+	}
+	if err := req.Storage.Delete(ctx, scopePath+name); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+const pathScopesHelpSyn = `
+Manage the scopes of managed objects.
+`
+
+const pathScopesHelpDesc = `
+A scope is a namespace of managed objects, such as keys. KMIP clients
+authenticate as a role of a scope, and can only see the objects of that
+scope.
+
+A scope that has roles or managed objects can only be deleted with "force",
+which deletes its roles, their credentials and the managed objects.
+`
@@ -0,0 +1,347 @@
+package kmip
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/helper/certutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// connIdleTimeout closes client connections that have not sent a request
+// for this long
+const connIdleTimeout = 5 * time.Minute
+
+// server is a running KMIP listener
+type server struct {
+	listeners []net.Listener
+
+	connLock sync.Mutex
+	conns    map[net.Conn]struct{}
+
+	wg sync.WaitGroup
+}
+
+// ensureListener starts the listener if the backend has been configured and
+// the listener is not running
+func (b *backend) ensureListener(ctx context.Context, s logical.Storage) error {
+	b.serverLock.Lock()
+	defer b.serverLock.Unlock()
+
+	if b.server != nil {
+		return nil
+	}
+
+	entry, err := s.Get(ctx, configPath)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return nil
+	}
+
+	return b.startListener(ctx, s)
+}
+
+// restartListener stops the listener if it is running, and starts it with
+// the current configuration
+func (b *backend) restartListener(ctx context.Context, s logical.Storage) error {
+	b.serverLock.Lock()
+	defer b.serverLock.Unlock()
+
+	b.stopListener()
+	return b.startListener(ctx, s)
+}
+
+func (b *backend) cleanup(ctx context.Context) {
+	b.serverLock.Lock()
+	defer b.serverLock.Unlock()
+
+	b.stopListener()
+}
+
+// startListener starts the listener. The caller must hold serverLock.
+func (b *backend) startListener(ctx context.Context, s logical.Storage) error {
+	cfg, err := b.config(ctx, s)
+	if err != nil {
+		return err
+	}
+	ca, err := b.ca(ctx, s)
+	if err != nil {
+		return err
+	}
+	cert, err := ca.issueServer(cfg.ServerHostnames)
+	if err != nil {
+		return errwrap.Wrapf("error issuing listener certificate: {{err}}", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{cert.certDER},
+				PrivateKey:  cert.key,
+				Leaf:        cert.cert,
+			},
+		},
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	srv := &server{
+		conns: make(map[net.Conn]struct{}),
+	}
+	for _, addr := range cfg.ListenAddrs {
+		ln, err := tls.Listen("tcp", addr, tlsConfig)
+		if err != nil {
+			for _, ln := range srv.listeners {
+				ln.Close()
+			}
+			return errwrap.Wrapf(fmt.Sprintf("error listening on %q: {{err}}", addr), err)
+		}
+		srv.listeners = append(srv.listeners, ln)
+	}
+
+	for _, ln := range srv.listeners {
+		srv.wg.Add(1)
+		go b.serve(srv, ln, s)
+	}
+
+	b.server = srv
+	b.Logger().Info("KMIP listener started", "addrs", cfg.ListenAddrs)
+	return nil
+}
+
+// stopListener closes the listener and all client connections, and waits for
+// them to finish. The caller must hold serverLock.
+func (b *backend) stopListener() {
+	if b.server == nil {
+		return
+	}
+
+	srv := b.server
+	b.server = nil
+
+	for _, ln := range srv.listeners {
+		ln.Close()
+	}
+	srv.connLock.Lock()
+	for conn := range srv.conns {
+		conn.Close()
+	}
+	srv.connLock.Unlock()
+
+	srv.wg.Wait()
+}
+
+// listenAddrs returns the addresses the listener is bound to
+func (b *backend) listenAddrs() []string {
+	b.serverLock.Lock()
+	defer b.serverLock.Unlock()
+
+	if b.server == nil {
+		return nil
+	}
+	var addrs []string
+	for _, ln := range b.server.listeners {
+		addrs = append(addrs, ln.Addr().String())
+	}
+	return addrs
+}
+
+func (b *backend) serve(srv *server, ln net.Listener, s logical.Storage) {
+	defer srv.wg.Done()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			// The listener was closed
+			return
+		}
+
+		srv.connLock.Lock()
+		srv.conns[conn] = struct{}{}
+		srv.connLock.Unlock()
+
+		srv.wg.Add(1)
+		go func() {
+			defer srv.wg.Done()
+			defer func() {
+				srv.connLock.Lock()
+				delete(srv.conns, conn)
+				srv.connLock.Unlock()
+				conn.Close()
+			}()
+
+			if err := b.handleConn(conn.(*tls.Conn), s); err != nil && err != io.EOF {
+				b.Logger().Debug("KMIP connection closed", "remote_addr", conn.RemoteAddr(), "error", err)
+			}
+		}()
+	}
+}
+
+// clientIdentity is the scope, role and certificate serial number of an
+// authenticated client
+type clientIdentity struct {
+	scope  string
+	role   string
+	serial string
+}
+
+func (b *backend) handleConn(conn *tls.Conn, s logical.Storage) error {
+	conn.SetDeadline(time.Now().Add(connIdleTimeout))
+	if err := conn.Handshake(); err != nil {
+		return err
+	}
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return fmt.Errorf("no client certificate")
+	}
+	cert := certs[0]
+	if len(cert.Subject.OrganizationalUnit) != 1 || len(cert.Subject.Organization) != 1 {
+		return fmt.Errorf("client certificate does not identify a scope and role")
+	}
+	client := &clientIdentity{
+		scope:  cert.Subject.OrganizationalUnit[0],
+		role:   cert.Subject.Organization[0],
+		serial: certutil.GetHexFormatted(cert.SerialNumber.Bytes(), ":"),
+	}
+
+	for {
+		conn.SetDeadline(time.Now().Add(connIdleTimeout))
+		msg, err := readMessage(conn)
+		if err != nil {
+			return err
+		}
+
+		resp := b.handleMessage(context.Background(), s, client, msg)
+		out, err := resp.marshal()
+		if err != nil {
+			return err
+		}
+		if _, err := conn.Write(out); err != nil {
+			return err
+		}
+	}
+}
+
+// authorize returns whether the client's credential is still valid and its
+// role allows the operation
+func (b *backend) authorize(ctx context.Context, s logical.Storage, client *clientIdentity, operation int32) error {
+	cred, err := b.credential(ctx, s, client.scope, client.role, client.serial)
+	if err != nil {
+		return err
+	}
+	if cred == nil || time.Now().After(cred.Expiration) {
+		return newKMIPError(resultReasonPermissionDenied, "client certificate has been revoked")
+	}
+
+	role, err := b.role(ctx, s, client.scope, client.role)
+	if err != nil {
+		return err
+	}
+	if role == nil || !role.allowed(operation) {
+		return newKMIPError(resultReasonPermissionDenied, "operation not allowed by role %q", client.role)
+	}
+	return nil
+}
+
+// handleMessage processes the batch items of a request message
+func (b *backend) handleMessage(ctx context.Context, s logical.Storage, client *clientIdentity, msg *item) *item {
+	version := protocolVersion(1, 0)
+	var batchItems []*item
+
+	header := msg.child(tagRequestHeader)
+	if msg.Tag != tagRequestMessage || header == nil {
+		batchItems = append(batchItems, b.failedBatchItem(nil, newKMIPError(resultReasonInvalidMessage, "invalid request message")))
+	} else {
+		if v := header.child(tagProtocolVersion); v != nil {
+			version = v
+		}
+		for _, batchItem := range msg.children(tagBatchItem) {
+			batchItems = append(batchItems, b.handleBatchItem(ctx, s, client, batchItem))
+		}
+	}
+
+	return structure(tagResponseMessage, append([]*item{
+		structure(tagResponseHeader,
+			version,
+			dateTime(tagTimeStamp, time.Now()),
+			integer(tagBatchCount, int32(len(batchItems)))),
+	}, batchItems...)...)
+}
+
+func (b *backend) handleBatchItem(ctx context.Context, s logical.Storage, client *clientIdentity, batchItem *item) *item {
+	operation, ok := batchItem.int(tagOperation)
+	if !ok {
+		return b.failedBatchItem(batchItem, newKMIPError(resultReasonInvalidMessage, "operation is required"))
+	}
+	op, ok := b.operations()[operation]
+	if !ok {
+		return b.failedBatchItem(batchItem, newKMIPError(resultReasonOperationNotSupported, "operation 0x%02x is not supported", operation))
+	}
+
+	if err := b.authorize(ctx, s, client, operation); err != nil {
+		return b.failedBatchItem(batchItem, err)
+	}
+
+	payload := batchItem.child(tagRequestPayload)
+	if payload == nil {
+		payload = structure(tagRequestPayload)
+	}
+
+	b.objectLock.RLock()
+	resp, err := op(ctx, s, client.scope, payload)
+	b.objectLock.RUnlock()
+	if err != nil {
+		return b.failedBatchItem(batchItem, err)
+	}
+
+	items := []*item{enumeration(tagOperation, operation)}
+	if id := batchItem.child(tagUniqueBatchItemID); id != nil {
+		items = append(items, id)
+	}
+	items = append(items,
+		enumeration(tagResultStatus, resultStatusSuccess),
+		structure(tagResponsePayload, resp...))
+	return structure(tagBatchItem, items...)
+}
+
+// failedBatchItem returns the response batch item of a failed request batch
+// item, which may be nil if the request could not be parsed. Internal errors
+// are logged rather than returned to the client.
+func (b *backend) failedBatchItem(batchItem *item, err error) *item {
+	reason := resultReasonGeneralFailure
+	message := "internal error"
+	if kerr, ok := err.(*kmipError); ok {
+		reason = kerr.reason
+		message = kerr.message
+	} else {
+		b.Logger().Error("error handling KMIP request", "error", err)
+	}
+
+	var items []*item
+	if batchItem != nil {
+		if op := batchItem.child(tagOperation); op != nil {
+			items = append(items, op)
+		}
+		if id := batchItem.child(tagUniqueBatchItemID); id != nil {
+			items = append(items, id)
+		}
+	}
+	items = append(items,
+		enumeration(tagResultStatus, resultStatusOperationFailed),
+		enumeration(tagResultReason, reason),
+		textString(tagResultMessage, message))
+	return structure(tagBatchItem, items...)
+}
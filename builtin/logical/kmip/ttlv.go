@@ -0,0 +1,302 @@
+package kmip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+)
+
+// Item types of the TTLV encoding
+const (
+	typeStructure   byte = 0x01
+	typeInteger     byte = 0x02
+	typeLongInteger byte = 0x03
+	typeBigInteger  byte = 0x04
+	typeEnumeration byte = 0x05
+	typeBoolean     byte = 0x06
+	typeTextString  byte = 0x07
+	typeByteString  byte = 0x08
+	typeDateTime    byte = 0x09
+	typeInterval    byte = 0x0a
+)
+
+const (
+	// ttlvHeaderSize is the size of the tag, type and length of an item
+	ttlvHeaderSize = 8
+
+	// maxMessageSize bounds the size of request messages read from clients
+	maxMessageSize = 1 << 20
+)
+
+// item is a KMIP Tag-Type-Length-Value item. Structures hold their children
+// in Items, all other types hold their value in Value as an int32 (Integer,
+// Enumeration and Interval), int64, *big.Int, bool, string, []byte or
+// time.Time.
+type item struct {
+	Tag   uint32
+	Type  byte
+	Value interface{}
+	Items []*item
+}
+
+func structure(tag uint32, items ...*item) *item {
+	return &item{Tag: tag, Type: typeStructure, Items: items}
+}
+
+func integer(tag uint32, v int32) *item {
+	return &item{Tag: tag, Type: typeInteger, Value: v}
+}
+
+func enumeration(tag uint32, v int32) *item {
+	return &item{Tag: tag, Type: typeEnumeration, Value: v}
+}
+
+func textString(tag uint32, v string) *item {
+	return &item{Tag: tag, Type: typeTextString, Value: v}
+}
+
+func byteString(tag uint32, v []byte) *item {
+	return &item{Tag: tag, Type: typeByteString, Value: v}
+}
+
+func dateTime(tag uint32, v time.Time) *item {
+	return &item{Tag: tag, Type: typeDateTime, Value: v}
+}
+
+// child returns the first child of a structure with the given tag
+func (i *item) child(tag uint32) *item {
+	for _, c := range i.Items {
+		if c.Tag == tag {
+			return c
+		}
+	}
+	return nil
+}
+
+// children returns the children of a structure with the given tag
+func (i *item) children(tag uint32) []*item {
+	var items []*item
+	for _, c := range i.Items {
+		if c.Tag == tag {
+			items = append(items, c)
+		}
+	}
+	return items
+}
+
+// int returns the value of an Integer, Enumeration or Interval child
+func (i *item) int(tag uint32) (int32, bool) {
+	c := i.child(tag)
+	if c == nil {
+		return 0, false
+	}
+	v, ok := c.Value.(int32)
+	return v, ok
+}
+
+// text returns the value of a Text String child
+func (i *item) text(tag uint32) (string, bool) {
+	c := i.child(tag)
+	if c == nil {
+		return "", false
+	}
+	v, ok := c.Value.(string)
+	return v, ok
+}
+
+// marshal encodes the item in TTLV
+func (i *item) marshal() ([]byte, error) {
+	var value []byte
+	switch i.Type {
+	case typeStructure:
+		var buf bytes.Buffer
+		for _, c := range i.Items {
+			b, err := c.marshal()
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(b)
+		}
+		value = buf.Bytes()
+
+	case typeInteger, typeEnumeration, typeInterval:
+		v, ok := i.Value.(int32)
+		if !ok {
+			return nil, fmt.Errorf("invalid value for tag 0x%06x", i.Tag)
+		}
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, uint32(v))
+
+	case typeLongInteger:
+		v, ok := i.Value.(int64)
+		if !ok {
+			return nil, fmt.Errorf("invalid value for tag 0x%06x", i.Tag)
+		}
+		value = make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(v))
+
+	case typeBigInteger:
+		v, ok := i.Value.(*big.Int)
+		if !ok || v.Sign() < 0 {
+			return nil, fmt.Errorf("invalid value for tag 0x%06x", i.Tag)
+		}
+		b := v.Bytes()
+		value = make([]byte, (len(b)+7)/8*8)
+		copy(value[len(value)-len(b):], b)
+
+	case typeBoolean:
+		v, ok := i.Value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid value for tag 0x%06x", i.Tag)
+		}
+		value = make([]byte, 8)
+		if v {
+			value[7] = 1
+		}
+
+	case typeTextString:
+		v, ok := i.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value for tag 0x%06x", i.Tag)
+		}
+		value = []byte(v)
+
+	case typeByteString:
+		v, ok := i.Value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("invalid value for tag 0x%06x", i.Tag)
+		}
+		value = v
+
+	case typeDateTime:
+		v, ok := i.Value.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("invalid value for tag 0x%06x", i.Tag)
+		}
+		value = make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(v.Unix()))
+
+	default:
+		return nil, fmt.Errorf("unsupported type 0x%02x for tag 0x%06x", i.Type, i.Tag)
+	}
+
+	padding := (8 - len(value)%8) % 8
+	buf := make([]byte, ttlvHeaderSize, ttlvHeaderSize+len(value)+padding)
+	buf[0] = byte(i.Tag >> 16)
+	buf[1] = byte(i.Tag >> 8)
+	buf[2] = byte(i.Tag)
+	buf[3] = i.Type
+	binary.BigEndian.PutUint32(buf[4:], uint32(len(value)))
+	buf = append(buf, value...)
+	return append(buf, make([]byte, padding)...), nil
+}
+
+// readMessage reads a single TTLV encoded item
+func readMessage(r io.Reader) (*item, error) {
+	header := make([]byte, ttlvHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[4:])
+	if length > maxMessageSize {
+		return nil, fmt.Errorf("message of %d bytes exceeds the maximum size", length)
+	}
+
+	buf := make([]byte, ttlvHeaderSize+int(length))
+	copy(buf, header)
+	if _, err := io.ReadFull(r, buf[ttlvHeaderSize:]); err != nil {
+		return nil, err
+	}
+
+	i, rest, err := unmarshal(buf)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("unexpected data after message")
+	}
+	return i, nil
+}
+
+// unmarshal decodes the first TTLV item of b, returning the remaining bytes
+func unmarshal(b []byte) (*item, []byte, error) {
+	if len(b) < ttlvHeaderSize {
+		return nil, nil, fmt.Errorf("truncated item header")
+	}
+
+	i := &item{
+		Tag:  uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2]),
+		Type: b[3],
+	}
+	length := int(binary.BigEndian.Uint32(b[4:]))
+	padded := length
+	if i.Type != typeStructure {
+		padded += (8 - length%8) % 8
+	}
+	if length < 0 || len(b)-ttlvHeaderSize < padded {
+		return nil, nil, fmt.Errorf("truncated value for tag 0x%06x", i.Tag)
+	}
+	value := b[ttlvHeaderSize : ttlvHeaderSize+length]
+	rest := b[ttlvHeaderSize+padded:]
+
+	fixedLength := func(n int) error {
+		if length != n {
+			return fmt.Errorf("invalid length %d for tag 0x%06x", length, i.Tag)
+		}
+		return nil
+	}
+
+	switch i.Type {
+	case typeStructure:
+		for len(value) > 0 {
+			c, r, err := unmarshal(value)
+			if err != nil {
+				return nil, nil, err
+			}
+			i.Items = append(i.Items, c)
+			value = r
+		}
+
+	case typeInteger, typeEnumeration, typeInterval:
+		if err := fixedLength(4); err != nil {
+			return nil, nil, err
+		}
+		i.Value = int32(binary.BigEndian.Uint32(value))
+
+	case typeLongInteger:
+		if err := fixedLength(8); err != nil {
+			return nil, nil, err
+		}
+		i.Value = int64(binary.BigEndian.Uint64(value))
+
+	case typeBigInteger:
+		i.Value = new(big.Int).SetBytes(value)
+
+	case typeBoolean:
+		if err := fixedLength(8); err != nil {
+			return nil, nil, err
+		}
+		i.Value = binary.BigEndian.Uint64(value) != 0
+
+	case typeTextString:
+		i.Value = string(value)
+
+	case typeByteString:
+		i.Value = append([]byte(nil), value...)
+
+	case typeDateTime:
+		if err := fixedLength(8); err != nil {
+			return nil, nil, err
+		}
+		i.Value = time.Unix(int64(binary.BigEndian.Uint64(value)), 0).UTC()
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported type 0x%02x for tag 0x%06x", i.Type, i.Tag)
+	}
+
+	return i, rest, nil
+}
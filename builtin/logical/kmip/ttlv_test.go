@@ -0,0 +1,92 @@
+package kmip
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Encodings from the examples of the KMIP 1.4 specification
+func TestTTLV_Encoding(t *testing.T) {
+	cases := []struct {
+		item     *item
+		expected string
+	}{
+		{integer(0x420020, 8), "42002002000000040000000800000000"},
+		{&item{Tag: 0x420020, Type: typeLongInteger, Value: int64(123456789000000000)}, "420020030000000801b69b4ba5749200"},
+		{enumeration(0x420020, 255), "4200200500000004000000ff00000000"},
+		{&item{Tag: 0x420020, Type: typeBoolean, Value: true}, "42002006000000080000000000000001"},
+		{textString(0x420020, "Hello World"), "420020070000000b48656c6c6f20576f726c640000000000"},
+		{byteString(0x420020, []byte{1, 2, 3}), "42002008000000030102030000000000"},
+		{dateTime(0x420020, time.Date(2008, time.March, 14, 11, 56, 40, 0, time.UTC)), "42002009000000080000000047da67f8"},
+		{&item{Tag: 0x420020, Type: typeInterval, Value: int32(864000)}, "4200200a00000004000d2f0000000000"},
+		{structure(0x420020, enumeration(0x420004, 254), integer(0x420005, 255)), "42002001000000204200040500000004000000fe000000004200050200000004000000ff00000000"},
+	}
+
+	for _, tc := range cases {
+		b, err := tc.item.marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(b); got != tc.expected {
+			t.Fatalf("expected %s, got %s", tc.expected, got)
+		}
+
+		decoded, rest, err := unmarshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rest) != 0 {
+			t.Fatalf("unexpected trailing data: %x", rest)
+		}
+		if !reflect.DeepEqual(decoded, tc.item) {
+			t.Fatalf("expected %#v, got %#v", tc.item, decoded)
+		}
+	}
+}
+
+func TestTTLV_BigInteger(t *testing.T) {
+	v, _ := new(big.Int).SetString("1234567890000000000000000000", 10)
+	b, err := (&item{Tag: 0x420020, Type: typeBigInteger, Value: v}).marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(b); got != "42002004000000100000000003fd35eb6bc2df4618080000" {
+		t.Fatalf("bad: %s", got)
+	}
+
+	decoded, _, err := unmarshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Value.(*big.Int).Cmp(v) != 0 {
+		t.Fatalf("expected %s, got %s", v, decoded.Value)
+	}
+}
+
+func TestTTLV_Invalid(t *testing.T) {
+	for _, in := range []string{
+		// Truncated header
+		"420020",
+		// Truncated value
+		"4200200200000004000000",
+		// Invalid integer length
+		"42002002000000080000000000000008",
+		// Unknown type
+		"420020ff000000000000000000000000",
+	} {
+		b, _ := hex.DecodeString(in)
+		if _, _, err := unmarshal(b); err == nil {
+			t.Fatalf("expected error for %s", in)
+		}
+	}
+
+	// Messages larger than the maximum size are rejected without being read
+	header, _ := hex.DecodeString("4200780100100000")
+	if _, err := readMessage(bytes.NewReader(header)); err == nil {
+		t.Fatal("expected error for oversized message")
+	}
+}
@@ -0,0 +1,114 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func Factory(ctx context.Context, conf *logical.BackendConfig) (logical.Backend, error) {
+	b := Backend()
+	if err := b.Setup(ctx, conf); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func Backend() *backend {
+	var b backend
+	b.Backend = &framework.Backend{
+		Help: strings.TrimSpace(backendHelp),
+
+		PathsSpecial: &logical.Paths{
+			SealWrapStorage: []string{
+				configPath,
+			},
+		},
+
+		Paths: []*framework.Path{
+			pathConfig(&b),
+			pathListRoles(&b),
+			pathRoles(&b),
+			pathCreds(&b),
+		},
+
+		Secrets: []*framework.Secret{
+			secretServiceAccountToken(&b),
+		},
+
+		WALRollback:       b.walRollback,
+		WALRollbackMinAge: walRollbackMinAge,
+		Invalidate:        b.invalidate,
+		BackendType:       logical.TypeLogical,
+	}
+
+	b.clientFactory = kubeAPIFactory
+
+	return &b
+}
+
+type backend struct {
+	*framework.Backend
+
+	// clientFactory creates Kubernetes API clients, and is replaced when
+	// running tests
+	clientFactory kubeClientFactory
+
+	client     kubeClient
+	clientLock sync.RWMutex
+}
+
+func (b *backend) invalidate(ctx context.Context, key string) {
+	switch key {
+	case configPath:
+		b.reset()
+	}
+}
+
+func (b *backend) reset() {
+	b.clientLock.Lock()
+	defer b.clientLock.Unlock()
+	b.client = nil
+}
+
+// getClient returns the Kubernetes API client, creating it from the stored
+// configuration if necessary
+func (b *backend) getClient(ctx context.Context, s logical.Storage) (kubeClient, error) {
+	b.clientLock.RLock()
+	client := b.client
+	b.clientLock.RUnlock()
+	if client != nil {
+		return client, nil
+	}
+
+	b.clientLock.Lock()
+	defer b.clientLock.Unlock()
+	if b.client != nil {
+		return b.client, nil
+	}
+
+	config, err := b.config(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, errBackendNotConfigured
+	}
+
+	b.client, err = b.clientFactory(config)
+	if err != nil {
+		return nil, err
+	}
+	return b.client, nil
+}
+
+const backendHelp = `
+The Kubernetes backend generates short-lived Kubernetes service account tokens.
+
+Roles either issue tokens for an existing service account, or create an
+ephemeral service account bound to an existing Role or ClusterRole. Ephemeral
+service accounts and their bindings are deleted when the lease is revoked.
+`
@@ -0,0 +1,504 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// mockKube records the objects created through the kubeClient interface
+type mockKube struct {
+	sync.Mutex
+	serviceAccounts map[string]bool
+	bindings        map[string]roleRef
+	tokens          map[string]time.Duration
+	failBinding     bool
+}
+
+func newMockKube() *mockKube {
+	return &mockKube{
+		serviceAccounts: map[string]bool{
+			"default/existing": true,
+		},
+		bindings: make(map[string]roleRef),
+		tokens:   make(map[string]time.Duration),
+	}
+}
+
+func bindingKey(namespace, name string, clusterWide bool) string {
+	if clusterWide {
+		return "cluster/" + name
+	}
+	return namespace + "/" + name
+}
+
+func (m *mockKube) CreateServiceAccount(namespace, name string) error {
+	m.Lock()
+	defer m.Unlock()
+	m.serviceAccounts[namespace+"/"+name] = true
+	return nil
+}
+
+func (m *mockKube) DeleteServiceAccount(namespace, name string) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.serviceAccounts, namespace+"/"+name)
+	return nil
+}
+
+func (m *mockKube) CreateRoleBinding(namespace, name string, ref roleRef, saNamespace, saName string) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.failBinding {
+		return fmt.Errorf("forbidden")
+	}
+	m.bindings[bindingKey(namespace, name, ref.ClusterWide)] = ref
+	return nil
+}
+
+func (m *mockKube) DeleteRoleBinding(namespace, name string, clusterWide bool) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.bindings, bindingKey(namespace, name, clusterWide))
+	return nil
+}
+
+func (m *mockKube) CreateToken(namespace, serviceAccount string, ttl time.Duration, audiences []string) (string, time.Time, error) {
+	m.Lock()
+	defer m.Unlock()
+	if !m.serviceAccounts[namespace+"/"+serviceAccount] {
+		return "", time.Time{}, kubeerrors.NewNotFound(schema.GroupResource{Resource: "serviceaccounts"}, serviceAccount)
+	}
+	token := fmt.Sprintf("token-%s-%s-%s", namespace, serviceAccount, strings.Join(audiences, ","))
+	m.tokens[token] = ttl
+	return token, time.Now().Add(ttl), nil
+}
+
+func createBackendWithStorage(t *testing.T, kube *mockKube) (*backend, logical.Storage) {
+	config := logical.TestBackendConfig()
+	config.StorageView = &logical.InmemStorage{}
+	config.System = &logical.StaticSystemView{
+		DefaultLeaseTTLVal: time.Hour,
+		MaxLeaseTTLVal:     24 * time.Hour,
+	}
+
+	b := Backend()
+	b.clientFactory = func(*kubeConfig) (kubeClient, error) {
+		return kube, nil
+	}
+	err := b.Setup(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, config.StorageView
+}
+
+func testConfigure(t *testing.T, b *backend, storage logical.Storage) {
+	resp, err := b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "config",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"kubernetes_host":     "https://127.0.0.1:8443",
+			"service_account_jwt": "jwt",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+}
+
+func TestBackend_Config(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	b, storage := createBackendWithStorage(t, newMockKube())
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "config",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"kubernetes_host": "https://127.0.0.1:8443",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+	testConfigure(t, b, storage)
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "config",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp.Data["kubernetes_host"] != "https://127.0.0.1:8443" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+	if _, ok := resp.Data["service_account_jwt"]; ok {
+		t.Fatal("expected service_account_jwt to be omitted")
+	}
+
+	// Credentials cannot be issued once the configuration is removed
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.DeleteOperation,
+		Path:      "config",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.CreateOperation,
+		Path:      "roles/existing",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"allowed_kubernetes_namespaces": "default",
+			"service_account_name":          "existing",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "creds/existing",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"kubernetes_namespace": "default",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+}
+
+func TestBackend_Roles(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	b, storage := createBackendWithStorage(t, newMockKube())
+
+	for _, data := range []map[string]interface{}{
+		{"service_account_name": "existing"},
+		{"allowed_kubernetes_namespaces": "default"},
+		{"allowed_kubernetes_namespaces": "default", "service_account_name": "existing", "kubernetes_role_name": "edit"},
+		{"allowed_kubernetes_namespaces": "default", "kubernetes_role_name": "edit", "kubernetes_role_type": "Group"},
+		{"allowed_kubernetes_namespaces": "default", "kubernetes_role_name": "edit", "token_default_ttl": "1m"},
+		{"allowed_kubernetes_namespaces": "default", "kubernetes_role_name": "edit", "token_default_ttl": "2h", "token_max_ttl": "1h"},
+	} {
+		resp, err = b.HandleRequest(context.Background(), &logical.Request{
+			Operation: logical.CreateOperation,
+			Path:      "roles/invalid",
+			Storage:   storage,
+			Data:      data,
+		})
+		if err == nil && (resp == nil || !resp.IsError()) {
+			t.Fatalf("expected error, resp: %#v", resp)
+		}
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.CreateOperation,
+		Path:      "roles/edit",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"allowed_kubernetes_namespaces": "dev,staging",
+			"kubernetes_role_name":          "edit",
+			"kubernetes_role_type":          "clusterrole",
+			"token_default_ttl":             "1h",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "roles/edit",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"token_max_ttl": "2h",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "roles/edit",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	expected := map[string]interface{}{
+		"allowed_kubernetes_namespaces": []string{"dev", "staging"},
+		"service_account_name":          "",
+		"kubernetes_role_name":          "edit",
+		"kubernetes_role_type":          kindClusterRole,
+		"token_default_ttl":             int64(3600),
+		"token_max_ttl":                 int64(7200),
+		"token_default_audiences":       []string(nil),
+	}
+	if fmt.Sprint(resp.Data) != fmt.Sprint(expected) {
+		t.Fatalf("bad: expected %#v, got %#v", expected, resp.Data)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ListOperation,
+		Path:      "roles/",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if keys := resp.Data["keys"].([]string); len(keys) != 1 || keys[0] != "edit" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.DeleteOperation,
+		Path:      "roles/edit",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "roles/edit",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp != nil {
+		t.Fatalf("expected role to be deleted, got %#v", resp)
+	}
+}
+
+func TestBackend_ExistingServiceAccount(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	kube := newMockKube()
+	b, storage := createBackendWithStorage(t, kube)
+	testConfigure(t, b, storage)
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.CreateOperation,
+		Path:      "roles/existing",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"allowed_kubernetes_namespaces": "*",
+			"service_account_name":          "existing",
+			"token_max_ttl":                 "2h",
+			"token_default_audiences":       "vault",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "creds/existing",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"kubernetes_namespace": "default",
+			"ttl":                  "3h",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp.Data["service_account_token"] != "token-default-existing-vault" || resp.Data["service_account_name"] != "existing" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+	if resp.Secret.Renewable || resp.Secret.TTL > 2*time.Hour || resp.Secret.TTL < 2*time.Hour-time.Minute {
+		t.Fatalf("expected non-renewable lease capped at the role's max TTL, got %#v", resp.Secret)
+	}
+	if len(resp.Warnings) == 0 {
+		t.Fatal("expected a warning about the capped TTL")
+	}
+
+	// Revoking does not touch the existing service account
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.RevokeOperation,
+		Storage:   storage,
+		Secret:    resp.Secret,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if !kube.serviceAccounts["default/existing"] {
+		t.Fatal("expected existing service account to be kept")
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "creds/existing",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"kubernetes_namespace": "default",
+			"ttl":                  "5m",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "creds/existing",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"kubernetes_namespace": "default",
+			"cluster_role_binding": true,
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+}
+
+func TestBackend_EphemeralServiceAccount(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	kube := newMockKube()
+	b, storage := createBackendWithStorage(t, kube)
+	testConfigure(t, b, storage)
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.CreateOperation,
+		Path:      "roles/view",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"allowed_kubernetes_namespaces": "dev",
+			"kubernetes_role_name":          "view",
+			"kubernetes_role_type":          "ClusterRole",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "creds/view",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"kubernetes_namespace": "prod",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+
+	for _, clusterWide := range []bool{false, true} {
+		resp, err = b.HandleRequest(context.Background(), &logical.Request{
+			Operation:   logical.UpdateOperation,
+			Path:        "creds/view",
+			Storage:     storage,
+			DisplayName: "ci-Job",
+			Data: map[string]interface{}{
+				"kubernetes_namespace": "dev",
+				"cluster_role_binding": clusterWide,
+			},
+		})
+		if err != nil || (resp != nil && resp.IsError()) {
+			t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+		}
+		name := resp.Data["service_account_name"].(string)
+		if !strings.HasPrefix(name, "v-ci-job-view-") || resp.Secret.TTL > time.Hour {
+			t.Fatalf("bad: %#v", resp)
+		}
+		if !kube.serviceAccounts["dev/"+name] {
+			t.Fatal("expected ephemeral service account to be created")
+		}
+		ref, ok := kube.bindings[bindingKey("dev", name, clusterWide)]
+		if !ok || ref.Kind != kindClusterRole || ref.Name != "view" {
+			t.Fatalf("expected binding to be created, got %#v", kube.bindings)
+		}
+
+		resp, err = b.HandleRequest(context.Background(), &logical.Request{
+			Operation: logical.RevokeOperation,
+			Storage:   storage,
+			Secret:    resp.Secret,
+		})
+		if err != nil || (resp != nil && resp.IsError()) {
+			t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+		}
+		if kube.serviceAccounts["dev/"+name] || len(kube.bindings) != 0 {
+			t.Fatal("expected ephemeral service account and binding to be deleted")
+		}
+	}
+
+	// A failed binding removes the service account again
+	kube.failBinding = true
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "creds/view",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"kubernetes_namespace": "dev",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+	if len(kube.serviceAccounts) != 1 {
+		t.Fatalf("expected only the existing service account, got %v", kube.serviceAccounts)
+	}
+}
+
+func TestBackend_WALRollback(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	kube := newMockKube()
+	b, storage := createBackendWithStorage(t, kube)
+	testConfigure(t, b, storage)
+
+	kube.CreateServiceAccount("dev", "partial")
+	kube.CreateRoleBinding("dev", "partial", roleRef{Kind: kindRole, Name: "edit"}, "dev", "partial")
+
+	if _, err := framework.PutWAL(context.Background(), storage, ephemeralServiceAccountWALKind, &ephemeralServiceAccountWAL{
+		Namespace: "dev",
+		Name:      "partial",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.RollbackOperation,
+		Path:      "",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"immediate": true,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if kube.serviceAccounts["dev/partial"] || len(kube.bindings) != 0 {
+		t.Fatal("expected partially created service account to be rolled back")
+	}
+}
+
+func TestGenServiceAccountName(t *testing.T) {
+	name, err := genServiceAccountName(strings.Repeat("Display_Name.", 10), "role")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(name) > maxServiceAccountNameLength || invalidNameChars.MatchString(name) || strings.Contains(name, "--") || !strings.HasPrefix(name, "v-display-name-") {
+		t.Fatalf("invalid name %q", name)
+	}
+}
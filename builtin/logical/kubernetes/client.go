@@ -0,0 +1,241 @@
+package kubernetes
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	authv1 "k8s.io/api/authentication/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// kubeClient is the subset of the Kubernetes API used by the backend. This
+// exists so we can use a mock client when running tests.
+type kubeClient interface {
+	CreateServiceAccount(namespace, name string) error
+	DeleteServiceAccount(namespace, name string) error
+	CreateRoleBinding(namespace, name string, roleRef roleRef, saNamespace, saName string) error
+	DeleteRoleBinding(namespace, name string, clusterWide bool) error
+	CreateToken(namespace, serviceAccount string, ttl time.Duration, audiences []string) (string, time.Time, error)
+}
+
+type kubeClientFactory func(*kubeConfig) (kubeClient, error)
+
+// roleRef references the Role or ClusterRole granted to a service account.
+// Bindings to a ClusterRole are cluster wide if ClusterWide is set.
+type roleRef struct {
+	Kind        string
+	Name        string
+	ClusterWide bool
+}
+
+// serviceAccount and roleBinding mirror the fields of the core/v1 and rbac/v1
+// objects that the backend creates
+type serviceAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+type roleBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Subjects          []rbacSubject `json:"subjects"`
+	RoleRef           rbacRoleRef   `json:"roleRef"`
+}
+
+type rbacSubject struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type rbacRoleRef struct {
+	APIGroup string `json:"apiGroup"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+}
+
+// managedByLabels mark the objects created by Vault
+var managedByLabels = map[string]string{
+	"app.kubernetes.io/managed-by": "HashiCorp-Vault",
+}
+
+// kubeAPI is the real implementation that calls the Kubernetes API
+type kubeAPI struct {
+	client *http.Client
+	config *kubeConfig
+}
+
+func kubeAPIFactory(config *kubeConfig) (kubeClient, error) {
+	client := cleanhttp.DefaultClient()
+
+	if len(config.CACert) > 0 {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(config.CACert)) {
+			return nil, fmt.Errorf("no valid certificates found in kubernetes_ca_cert")
+		}
+
+		client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    certPool,
+		}
+	}
+
+	return &kubeAPI{
+		client: client,
+		config: config,
+	}, nil
+}
+
+func (k *kubeAPI) CreateServiceAccount(namespace, name string) error {
+	sa := &serviceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    managedByLabels,
+		},
+	}
+
+	return k.do("POST", fmt.Sprintf("/api/v1/namespaces/%s/serviceaccounts", namespace), sa, nil)
+}
+
+func (k *kubeAPI) DeleteServiceAccount(namespace, name string) error {
+	return ignoreNotFound(k.do("DELETE", fmt.Sprintf("/api/v1/namespaces/%s/serviceaccounts/%s", namespace, name), nil, nil))
+}
+
+func (k *kubeAPI) CreateRoleBinding(namespace, name string, ref roleRef, saNamespace, saName string) error {
+	binding := &roleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    managedByLabels,
+		},
+		Subjects: []rbacSubject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      saName,
+				Namespace: saNamespace,
+			},
+		},
+		RoleRef: rbacRoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     ref.Kind,
+			Name:     ref.Name,
+		},
+	}
+
+	path := fmt.Sprintf("/apis/rbac.authorization.k8s.io/v1/namespaces/%s/rolebindings", namespace)
+	if ref.ClusterWide {
+		binding.Kind = "ClusterRoleBinding"
+		binding.Namespace = ""
+		path = "/apis/rbac.authorization.k8s.io/v1/clusterrolebindings"
+	}
+
+	return k.do("POST", path, binding, nil)
+}
+
+func (k *kubeAPI) DeleteRoleBinding(namespace, name string, clusterWide bool) error {
+	path := fmt.Sprintf("/apis/rbac.authorization.k8s.io/v1/namespaces/%s/rolebindings/%s", namespace, name)
+	if clusterWide {
+		path = fmt.Sprintf("/apis/rbac.authorization.k8s.io/v1/clusterrolebindings/%s", name)
+	}
+
+	return ignoreNotFound(k.do("DELETE", path, nil, nil))
+}
+
+func (k *kubeAPI) CreateToken(namespace, serviceAccount string, ttl time.Duration, audiences []string) (string, time.Time, error) {
+	expirationSeconds := int64(ttl.Seconds())
+	tokenReq := &authv1.TokenRequest{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "TokenRequest",
+			APIVersion: "authentication.k8s.io/v1",
+		},
+		Spec: authv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+
+	tokenResp := &authv1.TokenRequest{}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/serviceaccounts/%s/token", namespace, serviceAccount)
+	if err := k.do("POST", path, tokenReq, tokenResp); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenResp.Status.Token, tokenResp.Status.ExpirationTimestamp.Time, nil
+}
+
+// do sends a request to the Kubernetes API, decoding the response into out if
+// it is set
+func (k *kubeAPI) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		inJSON, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(inJSON)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(k.config.Host, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(k.config.ServiceAccountJWT))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// If the request was not a success create a kubernetes error, preferring
+	// the Status object returned by the API server
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusPartialContent {
+		errStatus := &metav1.Status{}
+		if err := json.Unmarshal(respBody, errStatus); err == nil && errStatus.Status == metav1.StatusFailure {
+			return kubeerrors.FromObject(runtime.Object(errStatus))
+		}
+		return kubeerrors.NewGenericServerResponse(resp.StatusCode, method, schema.GroupResource{}, "", strings.TrimSpace(string(respBody)), 0, true)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// ignoreNotFound treats deleting an object that no longer exists as success,
+// so that revocations and rollbacks can be retried
+func ignoreNotFound(err error) error {
+	if kubeerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const configPath = "config"
+
+var errBackendNotConfigured = errors.New("the kubernetes backend is not configured")
+
+// kubeConfig contains the information needed to call the Kubernetes API
+type kubeConfig struct {
+	// Host is the url string for the kubernetes API
+	Host string `json:"host"`
+	// CACert is the CA Cert to use to call into the kubernetes API
+	CACert string `json:"ca_cert"`
+	// ServiceAccountJWT is the bearer token Vault uses to call the API
+	ServiceAccountJWT string `json:"service_account_jwt"`
+}
+
+func pathConfig(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: configPath,
+		Fields: map[string]*framework.FieldSchema{
+			"kubernetes_host": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "URL of the Kubernetes API server, e.g. https://192.168.99.100:8443",
+			},
+			"kubernetes_ca_cert": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "PEM encoded CA certificate used to verify the Kubernetes API server certificate.",
+			},
+			"service_account_jwt": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `Bearer token Vault uses to call the Kubernetes API. It must be allowed
+to manage service accounts, role bindings and service account tokens.`,
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathConfigRead,
+			logical.UpdateOperation: b.pathConfigWrite,
+			logical.DeleteOperation: b.pathConfigDelete,
+		},
+
+		HelpSynopsis:    pathConfigHelpSyn,
+		HelpDescription: pathConfigHelpDesc,
+	}
+}
+
+func (b *backend) config(ctx context.Context, s logical.Storage) (*kubeConfig, error) {
+	entry, err := s.Get(ctx, configPath)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	config := new(kubeConfig)
+	if err := entry.DecodeJSON(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (b *backend) pathConfigRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"kubernetes_host":    config.Host,
+			"kubernetes_ca_cert": config.CACert,
+		},
+	}, nil
+}
+
+func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config := &kubeConfig{
+		Host:              data.Get("kubernetes_host").(string),
+		CACert:            data.Get("kubernetes_ca_cert").(string),
+		ServiceAccountJWT: data.Get("service_account_jwt").(string),
+	}
+	if config.Host == "" {
+		return logical.ErrorResponse("kubernetes_host is required"), nil
+	}
+	if config.ServiceAccountJWT == "" {
+		return logical.ErrorResponse("service_account_jwt is required"), nil
+	}
+
+	// Verify that a client can be created from the configuration
+	if _, err := b.clientFactory(config); err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	entry, err := logical.StorageEntryJSON(configPath, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	b.reset()
+	return nil, nil
+}
+
+func (b *backend) pathConfigDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, configPath); err != nil {
+		return nil, err
+	}
+
+	b.reset()
+	return nil, nil
+}
+
+const pathConfigHelpSyn = `
+Configure the connection to the Kubernetes API.
+`
+
+const pathConfigHelpDesc = `
+This path configures the Kubernetes API server and the bearer token Vault uses
+to manage service accounts and request service account tokens. The token is
+never returned when reading the configuration.
+`
@@ -0,0 +1,234 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// maxServiceAccountNameLength keeps generated names valid as labels as well
+// as object names
+const maxServiceAccountNameLength = 63
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+func pathCreds(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "creds/" + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the role",
+			},
+			"kubernetes_namespace": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Kubernetes namespace in which to issue the token.",
+			},
+			"cluster_role_binding": &framework.FieldSchema{
+				Type: framework.TypeBool,
+				Description: `Bind the ephemeral service account with a ClusterRoleBinding instead of
+a RoleBinding in kubernetes_namespace. Only valid for roles with a
+kubernetes_role_type of "ClusterRole".`,
+			},
+			"ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "Requested lifetime of the token. Defaults to the role's token_default_ttl.",
+			},
+			"audiences": &framework.FieldSchema{
+				Type:        framework.TypeCommaStringSlice,
+				Description: "Audiences of the token. Defaults to the role's token_default_audiences.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathCredsCreate,
+		},
+
+		HelpSynopsis:    pathCredsHelpSyn,
+		HelpDescription: pathCredsHelpDesc,
+	}
+}
+
+func (b *backend) pathCredsCreate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	roleName := data.Get("name").(string)
+
+	role, err := b.role(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse(fmt.Sprintf("role %q not found", roleName)), nil
+	}
+
+	namespace := data.Get("kubernetes_namespace").(string)
+	if namespace == "" {
+		return logical.ErrorResponse("kubernetes_namespace is required"), nil
+	}
+	if !role.namespaceAllowed(namespace) {
+		return logical.ErrorResponse(fmt.Sprintf("kubernetes_namespace %q is not allowed by role %q", namespace, roleName)), nil
+	}
+
+	clusterWide := data.Get("cluster_role_binding").(bool)
+	if clusterWide && (role.ServiceAccountName != "" || role.KubernetesRoleType != kindClusterRole) {
+		return logical.ErrorResponse(`cluster_role_binding is only valid for roles with a kubernetes_role_type of "ClusterRole"`), nil
+	}
+
+	requestedTTL := time.Duration(data.Get("ttl").(int)) * time.Second
+	ttl, warnings, err := framework.CalculateTTL(b.System(), requestedTTL, role.TokenDefaultTTL, 0, role.TokenMaxTTL, 0, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if ttl < minTokenTTL {
+		return logical.ErrorResponse(fmt.Sprintf("token TTL of %s is below the minimum of %s", ttl, minTokenTTL)), nil
+	}
+
+	audiences := role.TokenAudiences
+	if raw, ok := data.GetOk("audiences"); ok {
+		audiences = raw.([]string)
+	}
+
+	client, err := b.getClient(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	internalData := map[string]interface{}{
+		"role":                      roleName,
+		"service_account_namespace": namespace,
+		"service_account_name":      role.ServiceAccountName,
+		"ephemeral":                 false,
+	}
+
+	serviceAccount := role.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount, err = genServiceAccountName(req.DisplayName, roleName)
+		if err != nil {
+			return nil, err
+		}
+
+		walEntry := &ephemeralServiceAccountWAL{
+			Namespace:   namespace,
+			Name:        serviceAccount,
+			ClusterWide: clusterWide,
+		}
+		walID, err := framework.PutWAL(ctx, req.Storage, ephemeralServiceAccountWALKind, walEntry)
+		if err != nil {
+			return nil, errwrap.Wrapf("error writing WAL entry: {{err}}", err)
+		}
+
+		if err := b.createEphemeralServiceAccount(client, role, walEntry); err != nil {
+			return nil, err
+		}
+
+		if err := framework.DeleteWAL(ctx, req.Storage, walID); err != nil {
+			return nil, errwrap.Wrapf("failed to commit WAL entry: {{err}}", err)
+		}
+
+		internalData["service_account_name"] = serviceAccount
+		internalData["ephemeral"] = true
+		internalData["cluster_role_binding"] = clusterWide
+	}
+
+	token, expiration, err := client.CreateToken(namespace, serviceAccount, ttl, audiences)
+	if err != nil {
+		if internalData["ephemeral"].(bool) {
+			b.cleanupEphemeralServiceAccount(client, namespace, serviceAccount, clusterWide)
+		}
+		return nil, errwrap.Wrapf("error creating service account token: {{err}}", err)
+	}
+	if !expiration.IsZero() {
+		ttl = time.Until(expiration)
+	}
+
+	resp := b.Secret(secretServiceAccountTokenType).Response(map[string]interface{}{
+		"service_account_name":      serviceAccount,
+		"service_account_namespace": namespace,
+		"service_account_token":     token,
+	}, internalData)
+	resp.Secret.TTL = ttl
+	resp.Secret.MaxTTL = ttl
+	resp.Secret.Renewable = false
+	for _, warning := range warnings {
+		resp.AddWarning(warning)
+	}
+
+	return resp, nil
+}
+
+// createEphemeralServiceAccount creates a service account and binds it to the
+// role's Kubernetes role, removing the service account again if the binding
+// fails
+func (b *backend) createEphemeralServiceAccount(client kubeClient, role *roleEntry, entry *ephemeralServiceAccountWAL) error {
+	if err := client.CreateServiceAccount(entry.Namespace, entry.Name); err != nil {
+		return errwrap.Wrapf("error creating service account: {{err}}", err)
+	}
+
+	ref := roleRef{
+		Kind:        role.KubernetesRoleType,
+		Name:        role.KubernetesRoleName,
+		ClusterWide: entry.ClusterWide,
+	}
+	if err := client.CreateRoleBinding(entry.Namespace, entry.Name, ref, entry.Namespace, entry.Name); err != nil {
+		b.cleanupEphemeralServiceAccount(client, entry.Namespace, entry.Name, entry.ClusterWide)
+		return errwrap.Wrapf("error creating role binding: {{err}}", err)
+	}
+
+	return nil
+}
+
+// cleanupEphemeralServiceAccount makes a best effort attempt at deleting an
+// ephemeral service account after a failed request. Anything left behind is
+// removed by the WAL rollback.
+func (b *backend) cleanupEphemeralServiceAccount(client kubeClient, namespace, name string, clusterWide bool) {
+	if err := deleteEphemeralServiceAccount(client, namespace, name, clusterWide); err != nil {
+		b.Logger().Warn("failed to clean up ephemeral service account", "namespace", namespace, "name", name, "error", err)
+	}
+}
+
+// deleteEphemeralServiceAccount removes an ephemeral service account and its
+// binding. Deleting the service account invalidates all of its tokens.
+func deleteEphemeralServiceAccount(client kubeClient, namespace, name string, clusterWide bool) error {
+	if err := client.DeleteRoleBinding(namespace, name, clusterWide); err != nil {
+		return errwrap.Wrapf("error deleting role binding: {{err}}", err)
+	}
+	if err := client.DeleteServiceAccount(namespace, name); err != nil {
+		return errwrap.Wrapf("error deleting service account: {{err}}", err)
+	}
+	return nil
+}
+
+// genServiceAccountName generates a unique, valid Kubernetes object name for
+// an ephemeral service account
+func genServiceAccountName(displayName, roleName string) (string, error) {
+	random, err := uuid.GenerateUUID()
+	if err != nil {
+		return "", err
+	}
+	suffix := fmt.Sprintf("-%d-%s", time.Now().Unix(), random[:8])
+
+	prefix := invalidNameChars.ReplaceAllString(strings.ToLower(fmt.Sprintf("v-%s-%s", displayName, roleName)), "-")
+	if len(prefix)+len(suffix) > maxServiceAccountNameLength {
+		prefix = prefix[:maxServiceAccountNameLength-len(suffix)]
+	}
+
+	return strings.TrimRight(prefix, "-") + suffix, nil
+}
+
+const pathCredsHelpSyn = `
+Request a Kubernetes service account token from a role.
+`
+
+const pathCredsHelpDesc = `
+This path issues a service account token in the given namespace. For roles
+with a "kubernetes_role_name", an ephemeral service account bound to that role
+is created first, and deleted again when the lease is revoked.
+
+Tokens of existing service accounts cannot be revoked before they expire.
+`
@@ -0,0 +1,256 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/strutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	rolePath = "roles/"
+
+	kindRole        = "Role"
+	kindClusterRole = "ClusterRole"
+
+	// minTokenTTL is the shortest token lifetime accepted by the Kubernetes
+	// TokenRequest API
+	minTokenTTL = 10 * time.Minute
+)
+
+// roleEntry describes the tokens issued by a role. Exactly one of
+// ServiceAccountName and KubernetesRoleName is set.
+type roleEntry struct {
+	AllowedNamespaces  []string      `json:"allowed_kubernetes_namespaces"`
+	ServiceAccountName string        `json:"service_account_name"`
+	KubernetesRoleName string        `json:"kubernetes_role_name"`
+	KubernetesRoleType string        `json:"kubernetes_role_type"`
+	TokenDefaultTTL    time.Duration `json:"token_default_ttl"`
+	TokenMaxTTL        time.Duration `json:"token_max_ttl"`
+	TokenAudiences     []string      `json:"token_default_audiences"`
+}
+
+func (r *roleEntry) toResponseData() map[string]interface{} {
+	return map[string]interface{}{
+		"allowed_kubernetes_namespaces": r.AllowedNamespaces,
+		"service_account_name":          r.ServiceAccountName,
+		"kubernetes_role_name":          r.KubernetesRoleName,
+		"kubernetes_role_type":          r.KubernetesRoleType,
+		"token_default_ttl":             int64(r.TokenDefaultTTL.Seconds()),
+		"token_max_ttl":                 int64(r.TokenMaxTTL.Seconds()),
+		"token_default_audiences":       r.TokenAudiences,
+	}
+}
+
+// namespaceAllowed returns whether tokens may be issued in the namespace
+func (r *roleEntry) namespaceAllowed(namespace string) bool {
+	return strutil.StrListContains(r.AllowedNamespaces, "*") || strutil.StrListContains(r.AllowedNamespaces, namespace)
+}
+
+func pathListRoles(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: rolePath + "?$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathRoleList,
+		},
+
+		HelpSynopsis:    pathRolesHelpSyn,
+		HelpDescription: pathRolesHelpDesc,
+	}
+}
+
+func pathRoles(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: rolePath + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the role",
+			},
+			"allowed_kubernetes_namespaces": &framework.FieldSchema{
+				Type:        framework.TypeCommaStringSlice,
+				Description: `Kubernetes namespaces in which tokens may be issued. "*" allows all namespaces.`,
+			},
+			"service_account_name": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `Existing service account to issue tokens for. Mutually exclusive with
+kubernetes_role_name.`,
+			},
+			"kubernetes_role_name": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `Existing Role or ClusterRole to bind to an ephemeral service account
+created for each lease. Mutually exclusive with service_account_name.`,
+			},
+			"kubernetes_role_type": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Default:     kindRole,
+				Description: `Kind of kubernetes_role_name, either "Role" or "ClusterRole".`,
+			},
+			"token_default_ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "Default lifetime of issued tokens. Defaults to the mount's default lease TTL.",
+			},
+			"token_max_ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "Maximum lifetime of issued tokens. Defaults to the mount's maximum lease TTL.",
+			},
+			"token_default_audiences": &framework.FieldSchema{
+				Type:        framework.TypeCommaStringSlice,
+				Description: "Default audiences of issued tokens. Defaults to the API server's audiences.",
+			},
+		},
+
+		ExistenceCheck: b.pathRoleExistenceCheck,
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathRoleRead,
+			logical.CreateOperation: b.pathRoleWrite,
+			logical.UpdateOperation: b.pathRoleWrite,
+			logical.DeleteOperation: b.pathRoleDelete,
+		},
+
+		HelpSynopsis:    pathRolesHelpSyn,
+		HelpDescription: pathRolesHelpDesc,
+	}
+}
+
+func (b *backend) role(ctx context.Context, s logical.Storage, name string) (*roleEntry, error) {
+	entry, err := s.Get(ctx, rolePath+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	role := new(roleEntry)
+	if err := entry.DecodeJSON(role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (b *backend) pathRoleExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+	role, err := b.role(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return false, err
+	}
+	return role != nil, nil
+}
+
+func (b *backend) pathRoleList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, rolePath)
+	if err != nil {
+		return nil, err
+	}
+	return logical.ListResponse(roles), nil
+}
+
+func (b *backend) pathRoleRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	role, err := b.role(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: role.toResponseData(),
+	}, nil
+}
+
+func (b *backend) pathRoleWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	role, err := b.role(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = new(roleEntry)
+	}
+
+	if raw, ok := data.GetOk("allowed_kubernetes_namespaces"); ok {
+		role.AllowedNamespaces = raw.([]string)
+	}
+	if raw, ok := data.GetOk("service_account_name"); ok {
+		role.ServiceAccountName = raw.(string)
+	}
+	if raw, ok := data.GetOk("kubernetes_role_name"); ok {
+		role.KubernetesRoleName = raw.(string)
+	}
+	if _, ok := data.GetOk("kubernetes_role_type"); ok || role.KubernetesRoleType == "" {
+		role.KubernetesRoleType = data.Get("kubernetes_role_type").(string)
+	}
+	if raw, ok := data.GetOk("token_default_ttl"); ok {
+		role.TokenDefaultTTL = time.Duration(raw.(int)) * time.Second
+	}
+	if raw, ok := data.GetOk("token_max_ttl"); ok {
+		role.TokenMaxTTL = time.Duration(raw.(int)) * time.Second
+	}
+	if raw, ok := data.GetOk("token_default_audiences"); ok {
+		role.TokenAudiences = raw.([]string)
+	}
+
+	if len(role.AllowedNamespaces) == 0 {
+		return logical.ErrorResponse("allowed_kubernetes_namespaces is required"), nil
+	}
+	if (role.ServiceAccountName == "") == (role.KubernetesRoleName == "") {
+		return logical.ErrorResponse("exactly one of service_account_name and kubernetes_role_name must be set"), nil
+	}
+	switch strings.ToLower(role.KubernetesRoleType) {
+	case strings.ToLower(kindRole):
+		role.KubernetesRoleType = kindRole
+	case strings.ToLower(kindClusterRole):
+		role.KubernetesRoleType = kindClusterRole
+	default:
+		return logical.ErrorResponse(fmt.Sprintf("kubernetes_role_type must be %q or %q", kindRole, kindClusterRole)), nil
+	}
+	if role.TokenDefaultTTL != 0 && role.TokenDefaultTTL < minTokenTTL {
+		return logical.ErrorResponse(fmt.Sprintf("token_default_ttl must be at least %s", minTokenTTL)), nil
+	}
+	if role.TokenMaxTTL != 0 && role.TokenMaxTTL < minTokenTTL {
+		return logical.ErrorResponse(fmt.Sprintf("token_max_ttl must be at least %s", minTokenTTL)), nil
+	}
+	if role.TokenMaxTTL != 0 && role.TokenDefaultTTL > role.TokenMaxTTL {
+		return logical.ErrorResponse("token_default_ttl cannot be greater than token_max_ttl"), nil
+	}
+
+	entry, err := logical.StorageEntryJSON(rolePath+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathRoleDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, rolePath+data.Get("name").(string)); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+const pathRolesHelpSyn = `
+Manage the roles that can issue Kubernetes service account tokens.
+`
+
+const pathRolesHelpDesc = `
+A role either issues tokens for an existing service account, set with
+"service_account_name", or creates an ephemeral service account for each lease
+and binds it to an existing Role or ClusterRole, set with
+"kubernetes_role_name" and "kubernetes_role_type".
+
+Tokens may only be issued in the namespaces listed in
+"allowed_kubernetes_namespaces". Kubernetes does not issue tokens valid for
+less than 10 minutes.
+`
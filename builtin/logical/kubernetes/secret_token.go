@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	secretServiceAccountTokenType = "service_account_token"
+
+	ephemeralServiceAccountWALKind = "ephemeral_service_account"
+
+	// walRollbackMinAge is how long to wait before rolling back the creation
+	// of an ephemeral service account
+	walRollbackMinAge = 5 * time.Minute
+)
+
+// ephemeralServiceAccountWAL records an ephemeral service account so that it
+// can be deleted if the request creating it does not complete
+type ephemeralServiceAccountWAL struct {
+	Namespace   string `json:"namespace" mapstructure:"namespace"`
+	Name        string `json:"name" mapstructure:"name"`
+	ClusterWide bool   `json:"cluster_wide" mapstructure:"cluster_wide"`
+}
+
+func secretServiceAccountToken(b *backend) *framework.Secret {
+	return &framework.Secret{
+		Type: secretServiceAccountTokenType,
+		Fields: map[string]*framework.FieldSchema{
+			"service_account_name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the service account",
+			},
+			"service_account_namespace": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Namespace of the service account",
+			},
+			"service_account_token": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Service account token",
+			},
+		},
+
+		Revoke: b.secretServiceAccountTokenRevoke,
+	}
+}
+
+func (b *backend) secretServiceAccountTokenRevoke(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	// Tokens of existing service accounts cannot be revoked; they expire
+	// with the lease
+	ephemeral, _ := req.Secret.InternalData["ephemeral"].(bool)
+	if !ephemeral {
+		return nil, nil
+	}
+
+	namespace, ok := req.Secret.InternalData["service_account_namespace"].(string)
+	if !ok {
+		return nil, fmt.Errorf("secret is missing service_account_namespace internal data")
+	}
+	name, ok := req.Secret.InternalData["service_account_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("secret is missing service_account_name internal data")
+	}
+	clusterWide, _ := req.Secret.InternalData["cluster_role_binding"].(bool)
+
+	client, err := b.getClient(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, deleteEphemeralServiceAccount(client, namespace, name, clusterWide)
+}
+
+func (b *backend) walRollback(ctx context.Context, req *logical.Request, kind string, data interface{}) error {
+	if kind != ephemeralServiceAccountWALKind {
+		return fmt.Errorf("unknown rollback type %q", kind)
+	}
+
+	var entry ephemeralServiceAccountWAL
+	if err := mapstructure.Decode(data, &entry); err != nil {
+		return err
+	}
+
+	client, err := b.getClient(ctx, req.Storage)
+	if err != nil {
+		return err
+	}
+
+	return deleteEphemeralServiceAccount(client, entry.Namespace, entry.Name, entry.ClusterWide)
+}
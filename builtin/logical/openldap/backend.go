@@ -0,0 +1,94 @@
+package openldap
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/ldaputil"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func Factory(ctx context.Context, conf *logical.BackendConfig) (logical.Backend, error) {
+	b := Backend(conf)
+	if err := b.Setup(ctx, conf); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func Backend(conf *logical.BackendConfig) *backend {
+	var b backend
+	b.Backend = &framework.Backend{
+		Help: strings.TrimSpace(backendHelp),
+
+		PathsSpecial: &logical.Paths{
+			SealWrapStorage: []string{
+				configPath,
+				staticRolePath,
+				accountPath,
+			},
+		},
+
+		Paths: []*framework.Path{
+			pathConfig(&b),
+			pathRotateRoot(&b),
+			pathListStaticRoles(&b),
+			pathStaticRoles(&b),
+			pathStaticCreds(&b),
+			pathRotateStaticRole(&b),
+			pathListDynamicRoles(&b),
+			pathDynamicRoles(&b),
+			pathDynamicCreds(&b),
+			pathListLibrary(&b),
+			pathLibrary(&b),
+			pathLibraryCheckOut(&b),
+			pathLibraryCheckIn(&b),
+			pathLibraryManageCheckIn(&b),
+			pathLibraryStatus(&b),
+		},
+
+		Secrets: []*framework.Secret{
+			secretDynamicCreds(&b),
+			secretCheckOut(&b),
+		},
+
+		WALRollback:       b.walRollback,
+		WALRollbackMinAge: walRollbackMinAge,
+		PeriodicFunc:      b.periodicFunc,
+		BackendType:       logical.TypeLogical,
+	}
+
+	b.client = &ldaputil.Client{
+		Logger: conf.Logger,
+		LDAP:   ldaputil.NewLDAP(),
+	}
+	b.roleLocks = locksutil.CreateLocks()
+
+	return &b
+}
+
+type backend struct {
+	*framework.Backend
+
+	client *ldaputil.Client
+
+	// roleLocks guard static roles and library sets against concurrent
+	// password rotations
+	roleLocks []*locksutil.LockEntry
+
+	// configLock guards the stored configuration against concurrent root
+	// credential rotations
+	configLock sync.RWMutex
+}
+
+const backendHelp = `
+The OpenLDAP backend manages credentials of accounts in an LDAP directory,
+such as OpenLDAP or Active Directory.
+
+Static roles rotate the password of an existing account on a schedule,
+dynamic roles create and delete accounts from LDIF templates, and libraries
+allow shared service accounts to be checked out and checked in.
+`
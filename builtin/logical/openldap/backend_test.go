@@ -0,0 +1,566 @@
+package openldap
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/ldaputil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	testBindDN = "cn=admin,dc=example,dc=org"
+	testUserDN = "ou=users,dc=example,dc=org"
+)
+
+// fakeDirectory is an in-memory LDAP directory keyed by DN
+type fakeDirectory struct {
+	sync.Mutex
+	entries map[string]map[string][]string
+}
+
+func newFakeDirectory(users ...string) *fakeDirectory {
+	d := &fakeDirectory{
+		entries: map[string]map[string][]string{
+			testBindDN: {"userPassword": {"admin"}},
+		},
+	}
+	for _, user := range users {
+		d.entries[fmt.Sprintf("cn=%s,%s", user, testUserDN)] = map[string][]string{
+			"cn":           {user},
+			"userPassword": {"initial"},
+		}
+	}
+	return d
+}
+
+func (d *fakeDirectory) password(dn string) string {
+	d.Lock()
+	defer d.Unlock()
+	entry, ok := d.entries[dn]
+	if !ok || len(entry["userPassword"]) == 0 {
+		return ""
+	}
+	return entry["userPassword"][0]
+}
+
+func (d *fakeDirectory) exists(dn string) bool {
+	d.Lock()
+	defer d.Unlock()
+	_, ok := d.entries[dn]
+	return ok
+}
+
+func (d *fakeDirectory) Dial(network, addr string) (ldaputil.Connection, error) {
+	return &fakeConn{dir: d}, nil
+}
+
+func (d *fakeDirectory) DialTLS(network, addr string, config *tls.Config) (ldaputil.Connection, error) {
+	return &fakeConn{dir: d}, nil
+}
+
+type fakeConn struct {
+	dir *fakeDirectory
+}
+
+func (c *fakeConn) Add(req *ldap.AddRequest) error {
+	c.dir.Lock()
+	defer c.dir.Unlock()
+	if _, ok := c.dir.entries[req.DN]; ok {
+		return ldap.NewError(ldap.LDAPResultEntryAlreadyExists, fmt.Errorf("entry exists"))
+	}
+	entry := make(map[string][]string)
+	for _, attr := range req.Attributes {
+		entry[attr.Type] = attr.Vals
+	}
+	c.dir.entries[req.DN] = entry
+	return nil
+}
+
+func (c *fakeConn) Bind(username, password string) error {
+	c.dir.Lock()
+	defer c.dir.Unlock()
+	entry, ok := c.dir.entries[username]
+	if !ok || len(entry["userPassword"]) == 0 || entry["userPassword"][0] != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, fmt.Errorf("invalid credentials"))
+	}
+	return nil
+}
+
+func (c *fakeConn) Close() {}
+
+func (c *fakeConn) Del(req *ldap.DelRequest) error {
+	c.dir.Lock()
+	defer c.dir.Unlock()
+	if _, ok := c.dir.entries[req.DN]; !ok {
+		return ldap.NewError(ldap.LDAPResultNoSuchObject, fmt.Errorf("no such object"))
+	}
+	delete(c.dir.entries, req.DN)
+	return nil
+}
+
+func (c *fakeConn) Modify(req *ldap.ModifyRequest) error {
+	c.dir.Lock()
+	defer c.dir.Unlock()
+	entry, ok := c.dir.entries[req.DN]
+	if !ok {
+		return ldap.NewError(ldap.LDAPResultNoSuchObject, fmt.Errorf("no such object"))
+	}
+	for _, change := range req.Changes {
+		attr := change.Modification.Type
+		switch change.Operation {
+		case ldap.AddAttribute:
+			entry[attr] = append(entry[attr], change.Modification.Vals...)
+		case ldap.DeleteAttribute:
+			delete(entry, attr)
+		case ldap.ReplaceAttribute:
+			entry[attr] = change.Modification.Vals
+		}
+	}
+	return nil
+}
+
+func (c *fakeConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	c.dir.Lock()
+	defer c.dir.Unlock()
+	filter := strings.TrimSuffix(strings.TrimPrefix(req.Filter, "("), ")")
+	parts := strings.SplitN(filter, "=", 2)
+	result := &ldap.SearchResult{}
+	for dn, entry := range c.dir.entries {
+		if !strings.HasSuffix(dn, req.BaseDN) {
+			continue
+		}
+		for _, value := range entry[parts[0]] {
+			if value == parts[1] {
+				result.Entries = append(result.Entries, ldap.NewEntry(dn, nil))
+			}
+		}
+	}
+	return result, nil
+}
+
+func (c *fakeConn) StartTLS(config *tls.Config) error {
+	return nil
+}
+
+func (c *fakeConn) UnauthenticatedBind(username string) error {
+	return nil
+}
+
+func getBackend(t *testing.T, dir *fakeDirectory) (*backend, logical.Storage) {
+	t.Helper()
+	config := logical.TestBackendConfig()
+	config.StorageView = &logical.InmemStorage{}
+
+	b := Backend(config)
+	if err := b.Setup(context.Background(), config); err != nil {
+		t.Fatal(err)
+	}
+	b.client.LDAP = dir
+
+	return b, config.StorageView
+}
+
+type testHandler struct {
+	t       *testing.T
+	b       *backend
+	storage logical.Storage
+}
+
+func (h *testHandler) request(req *logical.Request) *logical.Response {
+	h.t.Helper()
+	req.Storage = h.storage
+	resp, err := h.b.HandleRequest(context.Background(), req)
+	if err != nil || (resp != nil && resp.IsError()) {
+		h.t.Fatalf("bad: path: %s err: %v resp: %#v", req.Path, err, resp)
+	}
+	return resp
+}
+
+func (h *testHandler) handle(op logical.Operation, path string, data map[string]interface{}) *logical.Response {
+	h.t.Helper()
+	return h.request(&logical.Request{
+		Operation: op,
+		Path:      path,
+		Data:      data,
+	})
+}
+
+func (h *testHandler) expectError(op logical.Operation, path string, data map[string]interface{}) {
+	h.t.Helper()
+	resp, err := h.b.HandleRequest(context.Background(), &logical.Request{
+		Operation: op,
+		Path:      path,
+		Storage:   h.storage,
+		Data:      data,
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		h.t.Fatalf("expected error: path: %s resp: %#v", path, resp)
+	}
+}
+
+func configure(h *testHandler) {
+	h.t.Helper()
+	h.handle(logical.UpdateOperation, "config", map[string]interface{}{
+		"url":      "ldap://127.0.0.1",
+		"binddn":   testBindDN,
+		"bindpass": "admin",
+		"userdn":   testUserDN,
+		"userattr": "cn",
+	})
+}
+
+func TestBackend_Config(t *testing.T) {
+	dir := newFakeDirectory()
+	b, storage := getBackend(t, dir)
+	h := &testHandler{t: t, b: b, storage: storage}
+
+	// Invalid bind credentials are rejected
+	h.expectError(logical.UpdateOperation, "config", map[string]interface{}{
+		"url":      "ldap://127.0.0.1",
+		"binddn":   testBindDN,
+		"bindpass": "wrong",
+	})
+	h.expectError(logical.UpdateOperation, "config", map[string]interface{}{
+		"url":             "ldap://127.0.0.1",
+		"binddn":          testBindDN,
+		"bindpass":        "admin",
+		"password_length": 8,
+	})
+
+	configure(h)
+	resp := h.handle(logical.ReadOperation, "config", nil)
+	if _, ok := resp.Data["bindpass"]; ok {
+		t.Fatal("expected bindpass to be omitted")
+	}
+	if resp.Data["schema"] != schemaOpenLDAP || resp.Data["password_length"] != defaultPasswordLength {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	h.handle(logical.UpdateOperation, "rotate-root", nil)
+	password := dir.password(testBindDN)
+	if password == "admin" || len(password) != defaultPasswordLength {
+		t.Fatalf("expected bind password to be rotated, got %q", password)
+	}
+
+	// The backend keeps working with the rotated password
+	h.handle(logical.UpdateOperation, "rotate-root", nil)
+	if dir.password(testBindDN) == password {
+		t.Fatal("expected bind password to be rotated again")
+	}
+}
+
+func TestBackend_StaticRole(t *testing.T) {
+	dir := newFakeDirectory("alice")
+	b, storage := getBackend(t, dir)
+	h := &testHandler{t: t, b: b, storage: storage}
+	configure(h)
+
+	aliceDN := "cn=alice," + testUserDN
+
+	h.expectError(logical.CreateOperation, "static-role/alice", map[string]interface{}{
+		"username":        "alice",
+		"rotation_period": "10s",
+	})
+	h.expectError(logical.CreateOperation, "static-role/bob", map[string]interface{}{
+		"username":        "bob",
+		"rotation_period": "1h",
+	})
+
+	h.handle(logical.CreateOperation, "static-role/alice", map[string]interface{}{
+		"username":        "alice",
+		"rotation_period": "1h",
+	})
+	resp := h.handle(logical.ReadOperation, "static-role/alice", nil)
+	if resp.Data["dn"] != aliceDN || resp.Data["rotation_period"] != int64(3600) {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	readCreds := func() string {
+		t.Helper()
+		resp := h.handle(logical.ReadOperation, "static-cred/alice", nil)
+		password := resp.Data["password"].(string)
+		if password != dir.password(aliceDN) {
+			t.Fatalf("expected stored password to match the directory")
+		}
+		return password
+	}
+
+	password := readCreds()
+	if password == "initial" {
+		t.Fatal("expected password to be rotated on role creation")
+	}
+
+	h.expectError(logical.UpdateOperation, "static-role/alice", map[string]interface{}{
+		"username": "bob",
+	})
+
+	h.handle(logical.UpdateOperation, "rotate-role/alice", nil)
+	newPassword := readCreds()
+	if newPassword == password {
+		t.Fatal("expected password to be rotated")
+	}
+
+	// Rotation is not due yet
+	if err := b.periodicFunc(context.Background(), &logical.Request{Storage: storage}); err != nil {
+		t.Fatal(err)
+	}
+	if readCreds() != newPassword {
+		t.Fatal("expected password not to be rotated before the rotation period elapses")
+	}
+
+	role, err := b.staticRole(context.Background(), storage, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	role.LastVaultRotation = time.Now().Add(-2 * time.Hour)
+	entry, err := logical.StorageEntryJSON(staticRolePath+"alice", role)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.Put(context.Background(), entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.periodicFunc(context.Background(), &logical.Request{Storage: storage}); err != nil {
+		t.Fatal(err)
+	}
+	if readCreds() == newPassword {
+		t.Fatal("expected password to be rotated by the periodic function")
+	}
+
+	resp = h.handle(logical.ListOperation, "static-role/", nil)
+	if keys := resp.Data["keys"].([]string); len(keys) != 1 || keys[0] != "alice" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	h.handle(logical.DeleteOperation, "static-role/alice", nil)
+	h.expectError(logical.ReadOperation, "static-cred/alice", nil)
+}
+
+const testCreationLDIF = `
+dn: cn={{.Username}},ou=users,dc=example,dc=org
+objectClass: person
+cn: {{.Username}}
+sn: {{.Username}}
+userPassword: {{.Password}}
+
+dn: cn=devs,ou=groups,dc=example,dc=org
+changetype: modify
+add: member
+member: cn={{.Username}},ou=users,dc=example,dc=org
+-
+`
+
+const testDeletionLDIF = `
+dn: cn={{.Username}},ou=users,dc=example,dc=org
+changetype: delete
+`
+
+func TestBackend_DynamicRole(t *testing.T) {
+	dir := newFakeDirectory()
+	dir.entries["cn=devs,ou=groups,dc=example,dc=org"] = map[string][]string{"cn": {"devs"}}
+	b, storage := getBackend(t, dir)
+	h := &testHandler{t: t, b: b, storage: storage}
+	configure(h)
+
+	h.expectError(logical.CreateOperation, "role/dev", map[string]interface{}{
+		"creation_ldif": testCreationLDIF,
+	})
+	h.expectError(logical.CreateOperation, "role/dev", map[string]interface{}{
+		"creation_ldif": "dn: cn={{.Unknown}}\nchangetype: delete\n",
+		"deletion_ldif": testDeletionLDIF,
+	})
+	h.expectError(logical.CreateOperation, "role/dev", map[string]interface{}{
+		"creation_ldif": testCreationLDIF,
+		"deletion_ldif": "cn: {{.Username}}\n",
+	})
+
+	h.handle(logical.CreateOperation, "role/dev", map[string]interface{}{
+		"creation_ldif":     testCreationLDIF,
+		"deletion_ldif":     testDeletionLDIF,
+		"username_template": "v_{{.RoleName}}_{{.RandomSuffix}}",
+		"default_ttl":       "1h",
+		"max_ttl":           "2h",
+	})
+
+	resp := h.request(&logical.Request{
+		Operation:   logical.ReadOperation,
+		Path:        "creds/dev",
+		DisplayName: "token",
+	})
+	username := resp.Data["username"].(string)
+	password := resp.Data["password"].(string)
+	userDN := fmt.Sprintf("cn=%s,%s", username, testUserDN)
+	if !strings.HasPrefix(username, "v_dev_") || resp.Secret.TTL != time.Hour {
+		t.Fatalf("bad: %#v", resp)
+	}
+	if dir.password(userDN) != password {
+		t.Fatal("expected user to be created with the returned password")
+	}
+	if members := dir.entries["cn=devs,ou=groups,dc=example,dc=org"]["member"]; len(members) != 1 || members[0] != userDN {
+		t.Fatalf("expected user to be added to group, got %v", members)
+	}
+
+	// No WAL entries remain after a successful creation
+	wals, err := framework.ListWAL(context.Background(), storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wals) != 0 {
+		t.Fatalf("expected no WAL entries, got %v", wals)
+	}
+
+	// Revocation deletes the user, and can be retried
+	for i := 0; i < 2; i++ {
+		h.request(&logical.Request{
+			Operation: logical.RevokeOperation,
+			Secret:    resp.Secret,
+		})
+	}
+	if dir.exists(userDN) {
+		t.Fatal("expected user to be deleted")
+	}
+
+	// Interrupted creations are rolled back
+	if _, err := framework.PutWAL(context.Background(), storage, dynamicUserWALKind, &dynamicUserWAL{
+		Username:     "partial",
+		RollbackLDIF: "dn: cn=partial," + testUserDN + "\nchangetype: delete\n",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	dir.entries["cn=partial,"+testUserDN] = map[string][]string{"cn": {"partial"}}
+	h.request(&logical.Request{
+		Operation: logical.RollbackOperation,
+		Path:      "",
+		Data: map[string]interface{}{
+			"immediate": true,
+		},
+	})
+	if dir.exists("cn=partial," + testUserDN) {
+		t.Fatal("expected partially created user to be rolled back")
+	}
+}
+
+func TestBackend_Library(t *testing.T) {
+	dir := newFakeDirectory("svc1", "svc2", "svc3")
+	b, storage := getBackend(t, dir)
+	h := &testHandler{t: t, b: b, storage: storage}
+	configure(h)
+
+	svc1DN := "cn=svc1," + testUserDN
+
+	h.expectError(logical.CreateOperation, "library/test", map[string]interface{}{
+		"service_account_names": "svc1,missing",
+	})
+	h.handle(logical.CreateOperation, "library/test", map[string]interface{}{
+		"service_account_names": "svc1,svc2",
+		"ttl":                   "1h",
+		"max_ttl":               "2h",
+	})
+	if dir.password(svc1DN) == "initial" {
+		t.Fatal("expected password to be rotated when the account joins the set")
+	}
+
+	// Accounts may only belong to one set
+	h.expectError(logical.CreateOperation, "library/other", map[string]interface{}{
+		"service_account_names": "svc2,svc3",
+	})
+
+	checkOut := func(entityID string) *logical.Response {
+		t.Helper()
+		return h.request(&logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "library/test/check-out",
+			EntityID:  entityID,
+		})
+	}
+
+	first := checkOut("entity1")
+	if first.Data["service_account_name"] != "svc1" || first.Data["password"] != dir.password(svc1DN) || first.Secret.TTL != time.Hour {
+		t.Fatalf("bad: %#v", first)
+	}
+	second := checkOut("entity2")
+	if second.Data["service_account_name"] != "svc2" {
+		t.Fatalf("bad: %#v", second)
+	}
+	h.expectError(logical.UpdateOperation, "library/test/check-out", nil)
+
+	resp := h.handle(logical.ReadOperation, "library/test/status", nil)
+	if status := resp.Data["svc1"].(map[string]interface{}); status["available"] != false || status["borrower_entity_id"] != "entity1" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	// Only the borrower may check an account in
+	resp, err := b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "library/test/check-in",
+		Storage:   storage,
+		EntityID:  "entity2",
+		Data: map[string]interface{}{
+			"service_account_names": "svc1",
+		},
+	})
+	if err != nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got err: %v resp: %#v", err, resp)
+	}
+
+	checkedOutPassword := dir.password(svc1DN)
+	resp = h.request(&logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "library/test/check-in",
+		EntityID:  "entity1",
+	})
+	if checkIns := resp.Data["check_ins"].([]string); len(checkIns) != 1 || checkIns[0] != "svc1" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+	if dir.password(svc1DN) == checkedOutPassword {
+		t.Fatal("expected password to be rotated on check-in")
+	}
+
+	// The account is checked out again; revoking the stale lease must not
+	// check it in
+	third := checkOut("entity3")
+	if third.Data["service_account_name"] != "svc1" {
+		t.Fatalf("bad: %#v", third)
+	}
+	h.request(&logical.Request{
+		Operation: logical.RevokeOperation,
+		Secret:    first.Secret,
+	})
+	resp = h.handle(logical.ReadOperation, "library/test/status", nil)
+	if status := resp.Data["svc1"].(map[string]interface{}); status["available"] != false {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	// Revoking the current lease checks the account in
+	h.request(&logical.Request{
+		Operation: logical.RevokeOperation,
+		Secret:    third.Secret,
+	})
+
+	// Sets with checked out accounts cannot be deleted
+	h.expectError(logical.DeleteOperation, "library/test", nil)
+	h.handle(logical.UpdateOperation, "library/manage/test/check-in", map[string]interface{}{
+		"service_account_names": "svc2",
+	})
+
+	resp = h.handle(logical.ReadOperation, "library/test/status", nil)
+	for _, name := range []string{"svc1", "svc2"} {
+		if status := resp.Data[name].(map[string]interface{}); status["available"] != true {
+			t.Fatalf("bad: %#v", resp.Data)
+		}
+	}
+
+	h.handle(logical.DeleteOperation, "library/test", nil)
+	h.handle(logical.CreateOperation, "library/other", map[string]interface{}{
+		"service_account_names": "svc2,svc3",
+	})
+}
@@ -0,0 +1,119 @@
+package openldap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+
+	"github.com/go-ldap/ldap"
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/helper/ldaputil"
+)
+
+// conn dials the directory and binds with the configured credentials
+func (b *backend) conn(cfg *config) (ldaputil.Connection, error) {
+	conn, err := b.client.DialLDAP(cfg.LDAP)
+	if err != nil {
+		return nil, errwrap.Wrapf("error connecting to LDAP server: {{err}}", err)
+	}
+	if conn == nil {
+		return nil, fmt.Errorf("invalid connection returned from LDAP dial")
+	}
+
+	if err := conn.Bind(cfg.LDAP.BindDN, cfg.LDAP.BindPassword); err != nil {
+		conn.Close()
+		return nil, errwrap.Wrapf("error binding to LDAP server: {{err}}", err)
+	}
+
+	return conn, nil
+}
+
+// updatePassword replaces the password of the entry with the given DN
+func (b *backend) updatePassword(cfg *config, dn, password string) error {
+	conn, err := b.conn(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	modifyReq := ldap.NewModifyRequest(dn, nil)
+	switch cfg.Schema {
+	case schemaAD:
+		modifyReq.Replace("unicodePwd", []string{encodeADPassword(password)})
+	default:
+		modifyReq.Replace("userPassword", []string{password})
+	}
+
+	return conn.Modify(modifyReq)
+}
+
+// findDN returns the DN of the entry whose user attribute matches the given
+// name, searching below the configured user DN
+func (b *backend) findDN(cfg *config, name string) (string, error) {
+	if cfg.LDAP.UserDN == "" {
+		return "", fmt.Errorf("userdn must be configured to look up entries by name")
+	}
+
+	conn, err := b.conn(cfg)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	result, err := conn.Search(&ldap.SearchRequest{
+		BaseDN:     cfg.LDAP.UserDN,
+		Scope:      ldap.ScopeWholeSubtree,
+		Filter:     fmt.Sprintf("(%s=%s)", cfg.LDAP.UserAttr, ldap.EscapeFilter(name)),
+		Attributes: []string{"dn"},
+		SizeLimit:  2,
+	})
+	if err != nil {
+		return "", errwrap.Wrapf(fmt.Sprintf("error searching for %q: {{err}}", name), err)
+	}
+	if len(result.Entries) != 1 {
+		return "", fmt.Errorf("expected exactly one entry matching %q, found %d", name, len(result.Entries))
+	}
+
+	return result.Entries[0].DN, nil
+}
+
+// execute applies the changes of the given LDIF entries in order. Deleting
+// an entry that does not exist is not an error, so that revocations and
+// rollbacks can be retried.
+func (b *backend) execute(cfg *config, entries []*ldifEntry) error {
+	conn, err := b.conn(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	for _, entry := range entries {
+		switch {
+		case entry.Add != nil:
+			err = conn.Add(entry.Add)
+		case entry.Del != nil:
+			err = conn.Del(entry.Del)
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				err = nil
+			}
+		case entry.Modify != nil:
+			err = conn.Modify(entry.Modify)
+		}
+		if err != nil {
+			return errwrap.Wrapf(fmt.Sprintf("error applying LDIF entry %q: {{err}}", entry.DN), err)
+		}
+	}
+
+	return nil
+}
+
+// encodeADPassword encodes a password for the unicodePwd attribute, which
+// holds the quoted password in UTF-16LE
+func encodeADPassword(password string) string {
+	encoded := utf16.Encode([]rune(`"` + password + `"`))
+	buf := make([]byte, len(encoded)*2)
+	for i, r := range encoded {
+		binary.LittleEndian.PutUint16(buf[i*2:], r)
+	}
+	return string(buf)
+}
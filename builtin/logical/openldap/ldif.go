@@ -0,0 +1,220 @@
+package openldap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/go-ldap/ldap"
+	"github.com/hashicorp/errwrap"
+)
+
+// ldifEntry is a single change record of an LDIF document. Exactly one of
+// Add, Del and Modify is set.
+type ldifEntry struct {
+	DN     string
+	Add    *ldap.AddRequest
+	Del    *ldap.DelRequest
+	Modify *ldap.ModifyRequest
+}
+
+// ldifTemplateData holds the values available to LDIF templates
+type ldifTemplateData struct {
+	Username string
+	Password string
+}
+
+// renderTemplate executes an LDIF template
+func renderTemplate(ldifTemplate string, data ldifTemplateData) (string, error) {
+	tmpl, err := template.New("ldif").Option("missingkey=error").Parse(ldifTemplate)
+	if err != nil {
+		return "", errwrap.Wrapf("unable to parse template: {{err}}", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errwrap.Wrapf("unable to render template: {{err}}", err)
+	}
+
+	return buf.String(), nil
+}
+
+// renderLDIF executes an LDIF template and parses the result
+func renderLDIF(ldifTemplate string, data ldifTemplateData) ([]*ldifEntry, error) {
+	ldif, err := renderTemplate(ldifTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLDIF(ldif)
+}
+
+// parseLDIF parses an LDIF document (RFC 2849) of change records. Records
+// without a changetype are treated as additions.
+func parseLDIF(ldif string) ([]*ldifEntry, error) {
+	var entries []*ldifEntry
+	for _, record := range splitLDIFRecords(ldif) {
+		entry, err := parseLDIFRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// splitLDIFRecords unfolds continuation lines, drops comments and the
+// version line, and groups the remaining lines into blank-line separated
+// records
+func splitLDIFRecords(ldif string) [][]string {
+	var records [][]string
+	var record []string
+
+	scanner := bufio.NewScanner(strings.NewReader(ldif))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case strings.HasPrefix(line, " "):
+			if len(record) > 0 {
+				record[len(record)-1] += line[1:]
+			}
+		case strings.HasPrefix(line, "#"):
+		case strings.TrimSpace(line) == "":
+			if len(record) > 0 {
+				records = append(records, record)
+				record = nil
+			}
+		case len(records) == 0 && len(record) == 0 && strings.HasPrefix(line, "version:"):
+		default:
+			record = append(record, line)
+		}
+	}
+	if len(record) > 0 {
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// parseLDIFLine splits an "attr: value" or base64 encoded "attr:: value" line
+func parseLDIFLine(line string) (string, string, error) {
+	idx := strings.Index(line, ":")
+	if idx < 1 {
+		return "", "", fmt.Errorf("invalid LDIF line %q", line)
+	}
+	attr, value := line[:idx], line[idx+1:]
+
+	if strings.HasPrefix(value, ":") {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:]))
+		if err != nil {
+			return "", "", errwrap.Wrapf(fmt.Sprintf("invalid base64 value for attribute %q: {{err}}", attr), err)
+		}
+		return attr, string(decoded), nil
+	}
+
+	return attr, strings.TrimLeft(value, " "), nil
+}
+
+func parseLDIFRecord(lines []string) (*ldifEntry, error) {
+	attr, dn, err := parseLDIFLine(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(attr, "dn") || dn == "" {
+		return nil, fmt.Errorf("LDIF record must start with a dn, got %q", lines[0])
+	}
+	lines = lines[1:]
+
+	changeType := "add"
+	if len(lines) > 0 {
+		attr, value, err := parseLDIFLine(lines[0])
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(attr, "changetype") {
+			changeType = strings.ToLower(value)
+			lines = lines[1:]
+		}
+	}
+
+	entry := &ldifEntry{
+		DN: dn,
+	}
+
+	switch changeType {
+	case "add":
+		if len(lines) == 0 {
+			return nil, fmt.Errorf("LDIF add record for %q has no attributes", dn)
+		}
+		entry.Add = ldap.NewAddRequest(dn, nil)
+		var order []string
+		values := make(map[string][]string)
+		for _, line := range lines {
+			attr, value, err := parseLDIFLine(line)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := values[attr]; !ok {
+				order = append(order, attr)
+			}
+			values[attr] = append(values[attr], value)
+		}
+		for _, attr := range order {
+			entry.Add.Attribute(attr, values[attr])
+		}
+
+	case "delete":
+		if len(lines) != 0 {
+			return nil, fmt.Errorf("LDIF delete record for %q must not have attributes", dn)
+		}
+		entry.Del = ldap.NewDelRequest(dn, nil)
+
+	case "modify":
+		entry.Modify = ldap.NewModifyRequest(dn, nil)
+		for len(lines) > 0 {
+			op, attr, err := parseLDIFLine(lines[0])
+			if err != nil {
+				return nil, err
+			}
+			lines = lines[1:]
+
+			var values []string
+			for len(lines) > 0 && lines[0] != "-" {
+				valueAttr, value, err := parseLDIFLine(lines[0])
+				if err != nil {
+					return nil, err
+				}
+				if !strings.EqualFold(valueAttr, attr) {
+					return nil, fmt.Errorf("LDIF modify record for %q has value for %q in %s of %q", dn, valueAttr, op, attr)
+				}
+				values = append(values, value)
+				lines = lines[1:]
+			}
+			if len(lines) > 0 {
+				lines = lines[1:]
+			}
+
+			switch strings.ToLower(op) {
+			case "add":
+				entry.Modify.Add(attr, values)
+			case "delete":
+				entry.Modify.Delete(attr, values)
+			case "replace":
+				entry.Modify.Replace(attr, values)
+			default:
+				return nil, fmt.Errorf("unsupported LDIF modify operation %q for %q", op, dn)
+			}
+		}
+		if len(entry.Modify.Changes) == 0 {
+			return nil, fmt.Errorf("LDIF modify record for %q has no changes", dn)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported LDIF changetype %q for %q", changeType, dn)
+	}
+
+	return entry, nil
+}
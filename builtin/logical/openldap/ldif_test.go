@@ -0,0 +1,94 @@
+package openldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap"
+)
+
+func TestParseLDIF(t *testing.T) {
+	ldif := `version: 1
+# A user
+dn: cn=alice,ou=users,dc=example,dc=org
+objectClass: person
+objectClass: inetOrgPerson
+cn: alice
+description:: aGVsbG8gd29y
+ bGQ=
+
+dn: cn=devs,ou=groups,dc=example,dc=org
+changetype: modify
+add: member
+member: cn=alice,ou=users,dc=example,dc=org
+-
+replace: description
+description: developers
+-
+delete: owner
+
+dn: cn=bob,ou=users,dc=example,dc=org
+changetype: delete
+`
+	entries, err := parseLDIF(ldif)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	add := entries[0].Add
+	if add == nil || add.DN != "cn=alice,ou=users,dc=example,dc=org" || len(add.Attributes) != 3 {
+		t.Fatalf("bad: %#v", entries[0])
+	}
+	if vals := add.Attributes[0].Vals; len(vals) != 2 || vals[1] != "inetOrgPerson" {
+		t.Fatalf("bad: %#v", add.Attributes[0])
+	}
+	if vals := add.Attributes[2].Vals; len(vals) != 1 || vals[0] != "hello world" {
+		t.Fatalf("bad: %#v", add.Attributes[2])
+	}
+
+	modify := entries[1].Modify
+	if modify == nil || len(modify.Changes) != 3 {
+		t.Fatalf("bad: %#v", entries[1])
+	}
+	for i, op := range []uint{ldap.AddAttribute, ldap.ReplaceAttribute, ldap.DeleteAttribute} {
+		if modify.Changes[i].Operation != op {
+			t.Fatalf("bad operation for change %d: %#v", i, modify.Changes[i])
+		}
+	}
+
+	if entries[2].Del == nil || entries[2].Del.DN != "cn=bob,ou=users,dc=example,dc=org" {
+		t.Fatalf("bad: %#v", entries[2])
+	}
+}
+
+func TestParseLDIF_Invalid(t *testing.T) {
+	for name, ldif := range map[string]string{
+		"missing dn":         "cn: alice\n",
+		"empty add":          "dn: cn=alice\n",
+		"delete attributes":  "dn: cn=alice\nchangetype: delete\ncn: alice\n",
+		"unknown changetype": "dn: cn=alice\nchangetype: moddn\n",
+		"mismatched modify":  "dn: cn=alice\nchangetype: modify\nadd: member\ncn: alice\n",
+		"invalid line":       "dn: cn=alice\ncn\n",
+		"invalid base64":     "dn: cn=alice\ncn:: %%%\n",
+	} {
+		if _, err := parseLDIF(ldif); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestRenderLDIF(t *testing.T) {
+	entries, err := renderLDIF(testDeletionLDIF, ldifTemplateData{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].DN != "cn=alice,ou=users,dc=example,dc=org" {
+		t.Fatalf("bad: %#v", entries)
+	}
+
+	if _, err := renderLDIF("dn: cn={{.Missing}}\n", ldifTemplateData{}); err == nil {
+		t.Fatal("expected error for unknown template field")
+	}
+}
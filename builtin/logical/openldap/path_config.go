@@ -0,0 +1,234 @@
+package openldap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+	"github.com/hashicorp/vault/sdk/helper/ldaputil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	configPath = "config"
+
+	schemaOpenLDAP = "openldap"
+	schemaAD       = "ad"
+
+	defaultPasswordLength = 64
+	minPasswordLength     = 14
+)
+
+type config struct {
+	LDAP                     *ldaputil.ConfigEntry `json:"ldap"`
+	Schema                   string                `json:"schema"`
+	PasswordLength           int                   `json:"password_length"`
+	LastBindPasswordRotation time.Time             `json:"last_bind_password_rotation"`
+}
+
+// generatePassword returns a random password of the configured length
+func (c *config) generatePassword() (string, error) {
+	return base62.Random(c.PasswordLength)
+}
+
+func pathConfig(b *backend) *framework.Path {
+	fields := ldaputil.ConfigFields()
+	fields["schema"] = &framework.FieldSchema{
+		Type:    framework.TypeString,
+		Default: schemaOpenLDAP,
+		Description: `The schema of the directory, which determines the attribute
+passwords are written to. Must be "openldap" (userPassword) or "ad"
+(unicodePwd). Defaults to "openldap".`,
+		AllowedValues: []interface{}{schemaOpenLDAP, schemaAD},
+	}
+	fields["password_length"] = &framework.FieldSchema{
+		Type:        framework.TypeInt,
+		Default:     defaultPasswordLength,
+		Description: "The length of generated passwords. Defaults to 64.",
+	}
+
+	return &framework.Path{
+		Pattern: configPath,
+		Fields:  fields,
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathConfigRead,
+			logical.UpdateOperation: b.pathConfigWrite,
+			logical.DeleteOperation: b.pathConfigDelete,
+		},
+
+		HelpSynopsis:    pathConfigHelpSyn,
+		HelpDescription: pathConfigHelpDesc,
+	}
+}
+
+func (b *backend) config(ctx context.Context, s logical.Storage) (*config, error) {
+	entry, err := s.Get(ctx, configPath)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var result config
+	if err := entry.DecodeJSON(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (b *backend) pathConfigRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	b.configLock.RLock()
+	defer b.configLock.RUnlock()
+
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+
+	respData := cfg.LDAP.PasswordlessMap()
+	respData["schema"] = cfg.Schema
+	respData["password_length"] = cfg.PasswordLength
+	if !cfg.LastBindPasswordRotation.IsZero() {
+		respData["last_bind_password_rotation"] = cfg.LastBindPasswordRotation
+	}
+
+	return &logical.Response{
+		Data: respData,
+	}, nil
+}
+
+func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	ldapConf, err := ldaputil.NewConfigEntry(data)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+	if ldapConf.BindDN == "" || ldapConf.BindPassword == "" {
+		return logical.ErrorResponse("binddn and bindpass are required"), nil
+	}
+	if err := ldapConf.Validate(); err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	schema := data.Get("schema").(string)
+	switch schema {
+	case schemaOpenLDAP, schemaAD:
+	default:
+		return logical.ErrorResponse(fmt.Sprintf("schema must be %q or %q", schemaOpenLDAP, schemaAD)), nil
+	}
+
+	passwordLength := data.Get("password_length").(int)
+	if passwordLength < minPasswordLength {
+		return logical.ErrorResponse(fmt.Sprintf("password_length must be at least %d", minPasswordLength)), nil
+	}
+
+	cfg := &config{
+		LDAP:           ldapConf,
+		Schema:         schema,
+		PasswordLength: passwordLength,
+	}
+
+	// Ensure the bind credentials work before storing them
+	conn, err := b.conn(cfg)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+	conn.Close()
+
+	b.configLock.Lock()
+	defer b.configLock.Unlock()
+
+	entry, err := logical.StorageEntryJSON(configPath, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathConfigDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	b.configLock.Lock()
+	defer b.configLock.Unlock()
+
+	if err := req.Storage.Delete(ctx, configPath); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func pathRotateRoot(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "rotate-root",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathRotateRootUpdate,
+		},
+
+		HelpSynopsis:    pathRotateRootHelpSyn,
+		HelpDescription: pathRotateRootHelpDesc,
+	}
+}
+
+func (b *backend) pathRotateRootUpdate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	b.configLock.Lock()
+	defer b.configLock.Unlock()
+
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return logical.ErrorResponse("the backend is not configured"), nil
+	}
+
+	password, err := cfg.generatePassword()
+	if err != nil {
+		return nil, err
+	}
+	if err := b.updatePassword(cfg, cfg.LDAP.BindDN, password); err != nil {
+		return nil, errwrap.Wrapf("error updating bind password: {{err}}", err)
+	}
+
+	cfg.LDAP.BindPassword = password
+	cfg.LastBindPasswordRotation = time.Now()
+
+	entry, err := logical.StorageEntryJSON(configPath, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, errwrap.Wrapf("the bind password was rotated in the directory but could not be stored: {{err}}", err)
+	}
+
+	return nil, nil
+}
+
+const pathConfigHelpSyn = `
+Configure the connection to the LDAP directory.
+`
+
+const pathConfigHelpDesc = `
+This endpoint configures the LDAP server to manage credentials in, and the
+DN and password Vault binds with. The bind account must be able to change the
+passwords of managed accounts, and to execute the LDIF of dynamic roles.
+`
+
+const pathRotateRootHelpSyn = `
+Rotate the bind password.
+`
+
+const pathRotateRootHelpDesc = `
+This endpoint sets a new random password for the configured bind DN, so that
+only Vault knows it.
+`
@@ -0,0 +1,405 @@
+package openldap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	dynamicRolePath = "role/"
+
+	defaultUsernameTemplate = "v_{{.DisplayName}}_{{.RoleName}}_{{.RandomSuffix}}_{{.UnixTime}}"
+)
+
+var usernameDisallowedChars = regexp.MustCompile("[^a-zA-Z0-9_.-]")
+
+type dynamicRole struct {
+	CreationLDIF     string        `json:"creation_ldif"`
+	DeletionLDIF     string        `json:"deletion_ldif"`
+	RollbackLDIF     string        `json:"rollback_ldif"`
+	UsernameTemplate string        `json:"username_template"`
+	DefaultTTL       time.Duration `json:"default_ttl"`
+	MaxTTL           time.Duration `json:"max_ttl"`
+}
+
+// usernameTemplateData holds the values available to a role's
+// username_template
+type usernameTemplateData struct {
+	DisplayName  string
+	RoleName     string
+	UnixTime     int64
+	RandomSuffix string
+}
+
+func pathListDynamicRoles(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: dynamicRolePath + "?$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathDynamicRoleList,
+		},
+
+		HelpSynopsis:    pathDynamicRoleHelpSyn,
+		HelpDescription: pathDynamicRoleHelpDesc,
+	}
+}
+
+func pathDynamicRoles(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: dynamicRolePath + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the role.",
+			},
+			"creation_ldif": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `LDIF template executed to create a user. May reference
+{{.Username}} and {{.Password}}.`,
+			},
+			"deletion_ldif": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `LDIF template executed to delete a user when its lease is
+revoked. May reference {{.Username}}.`,
+			},
+			"rollback_ldif": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `LDIF template executed to remove a partially created user.
+May reference {{.Username}}. Defaults to deletion_ldif.`,
+			},
+			"username_template": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `Template used to generate usernames. May reference
+{{.DisplayName}}, {{.RoleName}}, {{.UnixTime}} and {{.RandomSuffix}}.`,
+				Default: defaultUsernameTemplate,
+			},
+			"default_ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "Default TTL for generated credentials.",
+			},
+			"max_ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "Maximum TTL for generated credentials.",
+			},
+		},
+
+		ExistenceCheck: b.pathDynamicRoleExistenceCheck,
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathDynamicRoleRead,
+			logical.CreateOperation: b.pathDynamicRoleCreateUpdate,
+			logical.UpdateOperation: b.pathDynamicRoleCreateUpdate,
+			logical.DeleteOperation: b.pathDynamicRoleDelete,
+		},
+
+		HelpSynopsis:    pathDynamicRoleHelpSyn,
+		HelpDescription: pathDynamicRoleHelpDesc,
+	}
+}
+
+func pathDynamicCreds(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "creds/" + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the role.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.pathDynamicCredsRead,
+		},
+
+		HelpSynopsis:    pathDynamicCredsHelpSyn,
+		HelpDescription: pathDynamicCredsHelpDesc,
+	}
+}
+
+func (b *backend) dynamicRole(ctx context.Context, s logical.Storage, name string) (*dynamicRole, error) {
+	entry, err := s.Get(ctx, dynamicRolePath+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var result dynamicRole
+	if err := entry.DecodeJSON(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (b *backend) pathDynamicRoleExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+	role, err := b.dynamicRole(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return false, err
+	}
+	return role != nil, nil
+}
+
+func (b *backend) pathDynamicRoleList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	entries, err := req.Storage.List(ctx, dynamicRolePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(entries), nil
+}
+
+func (b *backend) pathDynamicRoleRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	role, err := b.dynamicRole(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"creation_ldif":     role.CreationLDIF,
+			"deletion_ldif":     role.DeletionLDIF,
+			"rollback_ldif":     role.RollbackLDIF,
+			"username_template": role.UsernameTemplate,
+			"default_ttl":       int64(role.DefaultTTL.Seconds()),
+			"max_ttl":           int64(role.MaxTTL.Seconds()),
+		},
+	}, nil
+}
+
+func (b *backend) pathDynamicRoleCreateUpdate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	role, err := b.dynamicRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &dynamicRole{}
+	}
+
+	if creationLDIFRaw, ok := data.GetOk("creation_ldif"); ok {
+		role.CreationLDIF = creationLDIFRaw.(string)
+	}
+	if deletionLDIFRaw, ok := data.GetOk("deletion_ldif"); ok {
+		role.DeletionLDIF = deletionLDIFRaw.(string)
+	}
+	if rollbackLDIFRaw, ok := data.GetOk("rollback_ldif"); ok {
+		role.RollbackLDIF = rollbackLDIFRaw.(string)
+	}
+	if usernameTemplateRaw, ok := data.GetOk("username_template"); ok {
+		role.UsernameTemplate = usernameTemplateRaw.(string)
+	} else if req.Operation == logical.CreateOperation {
+		role.UsernameTemplate = data.Get("username_template").(string)
+	}
+	if defaultTTLRaw, ok := data.GetOk("default_ttl"); ok {
+		role.DefaultTTL = time.Duration(defaultTTLRaw.(int)) * time.Second
+	}
+	if maxTTLRaw, ok := data.GetOk("max_ttl"); ok {
+		role.MaxTTL = time.Duration(maxTTLRaw.(int)) * time.Second
+	}
+
+	if role.CreationLDIF == "" {
+		return logical.ErrorResponse("creation_ldif is required"), nil
+	}
+	if role.DeletionLDIF == "" {
+		return logical.ErrorResponse("deletion_ldif is required"), nil
+	}
+	if role.MaxTTL > 0 && role.DefaultTTL > role.MaxTTL {
+		return logical.ErrorResponse("default_ttl cannot be greater than max_ttl"), nil
+	}
+
+	// Validate the templates by rendering them with sample values
+	username, err := genUsername(role.UsernameTemplate, "display-name", name)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprintf("invalid username_template: %s", err)), nil
+	}
+	sample := ldifTemplateData{
+		Username: username,
+		Password: "password",
+	}
+	for field, ldifTemplate := range map[string]string{
+		"creation_ldif": role.CreationLDIF,
+		"deletion_ldif": role.DeletionLDIF,
+		"rollback_ldif": role.RollbackLDIF,
+	} {
+		if ldifTemplate == "" {
+			continue
+		}
+		entries, err := renderLDIF(ldifTemplate, sample)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprintf("invalid %s: %s", field, err)), nil
+		}
+		if len(entries) == 0 {
+			return logical.ErrorResponse(fmt.Sprintf("%s has no LDIF entries", field)), nil
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON(dynamicRolePath+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathDynamicRoleDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, dynamicRolePath+data.Get("name").(string)); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (b *backend) pathDynamicCredsRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	role, err := b.dynamicRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse(fmt.Sprintf("unknown role: %s", name)), nil
+	}
+
+	b.configLock.RLock()
+	defer b.configLock.RUnlock()
+
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return logical.ErrorResponse("the backend is not configured"), nil
+	}
+
+	username, err := genUsername(role.UsernameTemplate, req.DisplayName, name)
+	if err != nil {
+		return nil, err
+	}
+	password, err := cfg.generatePassword()
+	if err != nil {
+		return nil, err
+	}
+	templateData := ldifTemplateData{
+		Username: username,
+		Password: password,
+	}
+
+	creationEntries, err := renderLDIF(role.CreationLDIF, templateData)
+	if err != nil {
+		return nil, errwrap.Wrapf("error rendering creation_ldif: {{err}}", err)
+	}
+
+	// Render the LDIF needed to remove the user now, so that it is not
+	// affected by later changes to the role
+	deletionLDIF, err := renderTemplate(role.DeletionLDIF, templateData)
+	if err != nil {
+		return nil, errwrap.Wrapf("error rendering deletion_ldif: {{err}}", err)
+	}
+	rollbackLDIF := deletionLDIF
+	if role.RollbackLDIF != "" {
+		rollbackLDIF, err = renderTemplate(role.RollbackLDIF, templateData)
+		if err != nil {
+			return nil, errwrap.Wrapf("error rendering rollback_ldif: {{err}}", err)
+		}
+	}
+
+	walID, err := framework.PutWAL(ctx, req.Storage, dynamicUserWALKind, &dynamicUserWAL{
+		Username:     username,
+		RollbackLDIF: rollbackLDIF,
+	})
+	if err != nil {
+		return nil, errwrap.Wrapf("error writing WAL entry: {{err}}", err)
+	}
+
+	if err := b.execute(cfg, creationEntries); err != nil {
+		return nil, err
+	}
+
+	if err := framework.DeleteWAL(ctx, req.Storage, walID); err != nil {
+		return nil, errwrap.Wrapf("failed to commit WAL entry: {{err}}", err)
+	}
+
+	var dns []string
+	for _, entry := range creationEntries {
+		dns = append(dns, entry.DN)
+	}
+
+	resp := b.Secret(secretDynamicCredsType).Response(map[string]interface{}{
+		"username":            username,
+		"password":            password,
+		"distinguished_names": dns,
+	}, map[string]interface{}{
+		"role":          name,
+		"username":      username,
+		"deletion_ldif": deletionLDIF,
+	})
+	resp.Secret.TTL = role.DefaultTTL
+	resp.Secret.MaxTTL = role.MaxTTL
+
+	return resp, nil
+}
+
+// genUsername renders a username from the given template
+func genUsername(usernameTemplate, displayName, roleName string) (string, error) {
+	tmpl, err := template.New("username").Option("missingkey=error").Parse(usernameTemplate)
+	if err != nil {
+		return "", errwrap.Wrapf("unable to parse template: {{err}}", err)
+	}
+
+	suffix, err := base62.Random(10)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, usernameTemplateData{
+		DisplayName:  usernameDisallowedChars.ReplaceAllString(displayName, "_"),
+		RoleName:     usernameDisallowedChars.ReplaceAllString(roleName, "_"),
+		UnixTime:     time.Now().Unix(),
+		RandomSuffix: suffix,
+	})
+	if err != nil {
+		return "", errwrap.Wrapf("unable to render template: {{err}}", err)
+	}
+	if buf.Len() == 0 {
+		return "", fmt.Errorf("template rendered an empty username")
+	}
+
+	return buf.String(), nil
+}
+
+const pathDynamicRoleHelpSyn = `
+Manage roles that create LDAP entries on demand.
+`
+
+const pathDynamicRoleHelpDesc = `
+A dynamic role creates a new LDAP user each time credentials are requested by
+executing its creation_ldif, and removes the user by executing its
+deletion_ldif when the lease is revoked. The LDIF templates may reference the
+generated {{.Username}} and {{.Password}}.
+`
+
+const pathDynamicCredsHelpSyn = `
+Request credentials for a new LDAP user.
+`
+
+const pathDynamicCredsHelpDesc = `
+This endpoint creates a new LDAP user using the LDIF of the named role and
+returns its username and password under a lease.
+`
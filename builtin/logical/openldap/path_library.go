@@ -0,0 +1,670 @@
+package openldap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/helper/strutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	libraryPath = "library/"
+	accountPath = "account/"
+
+	defaultLibraryTTL = 24 * time.Hour
+)
+
+type librarySet struct {
+	ServiceAccountNames       []string      `json:"service_account_names"`
+	TTL                       time.Duration `json:"ttl"`
+	MaxTTL                    time.Duration `json:"max_ttl"`
+	DisableCheckInEnforcement bool          `json:"disable_check_in_enforcement"`
+}
+
+// serviceAccount is a directory account managed by a library set
+type serviceAccount struct {
+	SetName              string    `json:"set_name"`
+	DN                   string    `json:"dn"`
+	Password             string    `json:"password"`
+	LastPasswordRotation time.Time `json:"last_password_rotation"`
+	CheckOut             *checkOut `json:"check_out,omitempty"`
+}
+
+// checkOut records who currently holds a service account
+type checkOut struct {
+	ID                          string `json:"id"`
+	BorrowerEntityID            string `json:"borrower_entity_id"`
+	BorrowerClientTokenAccessor string `json:"borrower_client_token_accessor"`
+}
+
+func pathListLibrary(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: libraryPath + "?$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathLibraryList,
+		},
+
+		HelpSynopsis:    pathLibraryHelpSyn,
+		HelpDescription: pathLibraryHelpDesc,
+	}
+}
+
+func pathLibrary(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: libraryPath + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the set of service accounts.",
+			},
+			"service_account_names": &framework.FieldSchema{
+				Type: framework.TypeCommaStringSlice,
+				Description: `The names of the service accounts in the set, matched
+against the configured userattr below userdn. An account may only belong to
+one set.`,
+			},
+			"ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "The default duration of a check-out. Defaults to 24 hours.",
+			},
+			"max_ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "The maximum duration of a check-out, including renewals.",
+			},
+			"disable_check_in_enforcement": &framework.FieldSchema{
+				Type: framework.TypeBool,
+				Description: `If true, any caller with access to the check-in endpoint may
+check in an account, not only the entity or token that checked it out.`,
+			},
+		},
+
+		ExistenceCheck: b.pathLibraryExistenceCheck,
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathLibraryRead,
+			logical.CreateOperation: b.pathLibraryCreateUpdate,
+			logical.UpdateOperation: b.pathLibraryCreateUpdate,
+			logical.DeleteOperation: b.pathLibraryDelete,
+		},
+
+		HelpSynopsis:    pathLibraryHelpSyn,
+		HelpDescription: pathLibraryHelpDesc,
+	}
+}
+
+func pathLibraryCheckOut(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: libraryPath + framework.GenericNameRegex("name") + "/check-out$",
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the set of service accounts.",
+			},
+			"ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "The requested duration of the check-out, limited by the set's ttl.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathLibraryCheckOutUpdate,
+		},
+
+		HelpSynopsis:    pathLibraryCheckOutHelpSyn,
+		HelpDescription: pathLibraryCheckOutHelpDesc,
+	}
+}
+
+func pathLibraryCheckIn(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: libraryPath + framework.GenericNameRegex("name") + "/check-in$",
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the set of service accounts.",
+			},
+			"service_account_names": &framework.FieldSchema{
+				Type: framework.TypeCommaStringSlice,
+				Description: `The service accounts to check in. Optional if the caller has
+checked out exactly one account of the set.`,
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathLibraryCheckInUpdate(true),
+		},
+
+		HelpSynopsis:    pathLibraryCheckInHelpSyn,
+		HelpDescription: pathLibraryCheckInHelpDesc,
+	}
+}
+
+func pathLibraryManageCheckIn(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: libraryPath + "manage/" + framework.GenericNameRegex("name") + "/check-in$",
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the set of service accounts.",
+			},
+			"service_account_names": &framework.FieldSchema{
+				Type:        framework.TypeCommaStringSlice,
+				Description: "The service accounts to check in.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathLibraryCheckInUpdate(false),
+		},
+
+		HelpSynopsis:    pathLibraryManageCheckInHelpSyn,
+		HelpDescription: pathLibraryManageCheckInHelpDesc,
+	}
+}
+
+func pathLibraryStatus(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: libraryPath + framework.GenericNameRegex("name") + "/status$",
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the set of service accounts.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.pathLibraryStatusRead,
+		},
+
+		HelpSynopsis:    pathLibraryStatusHelpSyn,
+		HelpDescription: pathLibraryStatusHelpDesc,
+	}
+}
+
+func (b *backend) librarySet(ctx context.Context, s logical.Storage, name string) (*librarySet, error) {
+	entry, err := s.Get(ctx, libraryPath+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var result librarySet
+	if err := entry.DecodeJSON(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (b *backend) serviceAccount(ctx context.Context, s logical.Storage, name string) (*serviceAccount, error) {
+	entry, err := s.Get(ctx, accountPath+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var result serviceAccount
+	if err := entry.DecodeJSON(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func storeServiceAccount(ctx context.Context, s logical.Storage, name string, account *serviceAccount) error {
+	entry, err := logical.StorageEntryJSON(accountPath+name, account)
+	if err != nil {
+		return err
+	}
+	return s.Put(ctx, entry)
+}
+
+func (b *backend) pathLibraryExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+	set, err := b.librarySet(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return false, err
+	}
+	return set != nil, nil
+}
+
+func (b *backend) pathLibraryList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	entries, err := req.Storage.List(ctx, libraryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(entries), nil
+}
+
+func (b *backend) pathLibraryRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	set, err := b.librarySet(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"service_account_names":        set.ServiceAccountNames,
+			"ttl":                          int64(set.TTL.Seconds()),
+			"max_ttl":                      int64(set.MaxTTL.Seconds()),
+			"disable_check_in_enforcement": set.DisableCheckInEnforcement,
+		},
+	}, nil
+}
+
+func (b *backend) pathLibraryCreateUpdate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	lock := locksutil.LockForKey(b.roleLocks, libraryPath+name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	b.configLock.RLock()
+	defer b.configLock.RUnlock()
+
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return logical.ErrorResponse("the backend is not configured"), nil
+	}
+
+	set, err := b.librarySet(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		set = &librarySet{
+			TTL: defaultLibraryTTL,
+		}
+	}
+	previousNames := set.ServiceAccountNames
+
+	if namesRaw, ok := data.GetOk("service_account_names"); ok {
+		set.ServiceAccountNames = strutil.RemoveDuplicatesStable(namesRaw.([]string), false)
+	}
+	if ttlRaw, ok := data.GetOk("ttl"); ok {
+		set.TTL = time.Duration(ttlRaw.(int)) * time.Second
+	}
+	if maxTTLRaw, ok := data.GetOk("max_ttl"); ok {
+		set.MaxTTL = time.Duration(maxTTLRaw.(int)) * time.Second
+	}
+	if disableRaw, ok := data.GetOk("disable_check_in_enforcement"); ok {
+		set.DisableCheckInEnforcement = disableRaw.(bool)
+	}
+
+	if len(set.ServiceAccountNames) == 0 {
+		return logical.ErrorResponse("service_account_names is required"), nil
+	}
+	if set.MaxTTL > 0 && set.TTL > set.MaxTTL {
+		return logical.ErrorResponse("ttl cannot be greater than max_ttl"), nil
+	}
+
+	// Accounts leaving the set must not be checked out
+	var removed []string
+	for _, accountName := range previousNames {
+		if strutil.StrListContains(set.ServiceAccountNames, accountName) {
+			continue
+		}
+		account, err := b.serviceAccount(ctx, req.Storage, accountName)
+		if err != nil {
+			return nil, err
+		}
+		if account != nil && account.CheckOut != nil {
+			return logical.ErrorResponse(fmt.Sprintf("%q is checked out and cannot be removed from the set", accountName)), nil
+		}
+		removed = append(removed, accountName)
+	}
+
+	// Accounts joining the set must not belong to another set
+	var added []string
+	for _, accountName := range set.ServiceAccountNames {
+		account, err := b.serviceAccount(ctx, req.Storage, accountName)
+		if err != nil {
+			return nil, err
+		}
+		if account != nil {
+			if account.SetName != name {
+				return logical.ErrorResponse(fmt.Sprintf("%q already belongs to set %q", accountName, account.SetName)), nil
+			}
+			continue
+		}
+		added = append(added, accountName)
+	}
+
+	// Take over the passwords of new accounts, so that only Vault knows them
+	for _, accountName := range added {
+		dn, err := b.findDN(cfg, accountName)
+		if err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+		account := &serviceAccount{
+			SetName: name,
+			DN:      dn,
+		}
+		if err := b.rotateServiceAccount(ctx, req.Storage, cfg, accountName, account); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, accountName := range removed {
+		if err := req.Storage.Delete(ctx, accountPath+accountName); err != nil {
+			return nil, err
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON(libraryPath+name, set)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathLibraryDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	lock := locksutil.LockForKey(b.roleLocks, libraryPath+name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	set, err := b.librarySet(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return nil, nil
+	}
+
+	for _, accountName := range set.ServiceAccountNames {
+		account, err := b.serviceAccount(ctx, req.Storage, accountName)
+		if err != nil {
+			return nil, err
+		}
+		if account != nil && account.CheckOut != nil {
+			return logical.ErrorResponse(fmt.Sprintf("%q is checked out; all accounts must be checked in before the set is deleted", accountName)), nil
+		}
+	}
+
+	for _, accountName := range set.ServiceAccountNames {
+		if err := req.Storage.Delete(ctx, accountPath+accountName); err != nil {
+			return nil, err
+		}
+	}
+	if err := req.Storage.Delete(ctx, libraryPath+name); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathLibraryCheckOutUpdate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	lock := locksutil.LockForKey(b.roleLocks, libraryPath+name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	set, err := b.librarySet(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return logical.ErrorResponse(fmt.Sprintf("unknown library set: %s", name)), nil
+	}
+
+	ttl := set.TTL
+	if ttlRaw, ok := data.GetOk("ttl"); ok {
+		requested := time.Duration(ttlRaw.(int)) * time.Second
+		if requested < ttl {
+			ttl = requested
+		}
+	}
+
+	for _, accountName := range set.ServiceAccountNames {
+		account, err := b.serviceAccount(ctx, req.Storage, accountName)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil || account.CheckOut != nil {
+			continue
+		}
+
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			return nil, err
+		}
+		account.CheckOut = &checkOut{
+			ID:                          id,
+			BorrowerEntityID:            req.EntityID,
+			BorrowerClientTokenAccessor: req.ClientTokenAccessor,
+		}
+		if err := storeServiceAccount(ctx, req.Storage, accountName, account); err != nil {
+			return nil, err
+		}
+
+		resp := b.Secret(secretCheckOutType).Response(map[string]interface{}{
+			"service_account_name": accountName,
+			"dn":                   account.DN,
+			"password":             account.Password,
+		}, map[string]interface{}{
+			"set_name":             name,
+			"service_account_name": accountName,
+			"check_out_id":         id,
+		})
+		resp.Secret.TTL = ttl
+		resp.Secret.MaxTTL = set.MaxTTL
+
+		return resp, nil
+	}
+
+	return logical.ErrorResponse("no service accounts are available for check-out"), nil
+}
+
+func (b *backend) pathLibraryCheckInUpdate(enforce bool) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		name := data.Get("name").(string)
+
+		lock := locksutil.LockForKey(b.roleLocks, libraryPath+name)
+		lock.Lock()
+		defer lock.Unlock()
+
+		set, err := b.librarySet(ctx, req.Storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if set == nil {
+			return logical.ErrorResponse(fmt.Sprintf("unknown library set: %s", name)), nil
+		}
+		enforce = enforce && !set.DisableCheckInEnforcement
+
+		names := data.Get("service_account_names").([]string)
+		if len(names) == 0 {
+			if !enforce {
+				return logical.ErrorResponse("service_account_names is required"), nil
+			}
+			for _, accountName := range set.ServiceAccountNames {
+				account, err := b.serviceAccount(ctx, req.Storage, accountName)
+				if err != nil {
+					return nil, err
+				}
+				if account != nil && account.CheckOut != nil && account.CheckOut.borrowedBy(req) {
+					names = append(names, accountName)
+				}
+			}
+			if len(names) != 1 {
+				return logical.ErrorResponse(fmt.Sprintf("service_account_names is required when the caller has checked out %d accounts", len(names))), nil
+			}
+		}
+
+		b.configLock.RLock()
+		defer b.configLock.RUnlock()
+
+		cfg, err := b.config(ctx, req.Storage)
+		if err != nil {
+			return nil, err
+		}
+		if cfg == nil {
+			return logical.ErrorResponse("the backend is not configured"), nil
+		}
+
+		var checkedIn []string
+		for _, accountName := range names {
+			if !strutil.StrListContains(set.ServiceAccountNames, accountName) {
+				return logical.ErrorResponse(fmt.Sprintf("%q is not in set %q", accountName, name)), nil
+			}
+			account, err := b.serviceAccount(ctx, req.Storage, accountName)
+			if err != nil {
+				return nil, err
+			}
+			if account == nil || account.CheckOut == nil {
+				continue
+			}
+			if enforce && !account.CheckOut.borrowedBy(req) {
+				return logical.ErrorResponse(fmt.Sprintf("%q was not checked out by the caller", accountName)), nil
+			}
+
+			if err := b.checkIn(ctx, req.Storage, cfg, accountName, account); err != nil {
+				return nil, err
+			}
+			checkedIn = append(checkedIn, accountName)
+		}
+
+		return &logical.Response{
+			Data: map[string]interface{}{
+				"check_ins": checkedIn,
+			},
+		}, nil
+	}
+}
+
+func (b *backend) pathLibraryStatusRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	set, err := b.librarySet(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return logical.ErrorResponse(fmt.Sprintf("unknown library set: %s", name)), nil
+	}
+
+	status := make(map[string]interface{}, len(set.ServiceAccountNames))
+	for _, accountName := range set.ServiceAccountNames {
+		account, err := b.serviceAccount(ctx, req.Storage, accountName)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			continue
+		}
+
+		accountStatus := map[string]interface{}{
+			"available": account.CheckOut == nil,
+		}
+		if account.CheckOut != nil {
+			accountStatus["borrower_entity_id"] = account.CheckOut.BorrowerEntityID
+			accountStatus["borrower_client_token_accessor"] = account.CheckOut.BorrowerClientTokenAccessor
+		}
+		status[accountName] = accountStatus
+	}
+
+	return &logical.Response{
+		Data: status,
+	}, nil
+}
+
+// borrowedBy returns whether the caller of the request checked out the account
+func (c *checkOut) borrowedBy(req *logical.Request) bool {
+	if c.BorrowerEntityID != "" {
+		return c.BorrowerEntityID == req.EntityID
+	}
+	return c.BorrowerClientTokenAccessor != "" && c.BorrowerClientTokenAccessor == req.ClientTokenAccessor
+}
+
+// checkIn rotates the password of a checked out account and makes it
+// available again. The caller must hold the set's lock.
+func (b *backend) checkIn(ctx context.Context, s logical.Storage, cfg *config, name string, account *serviceAccount) error {
+	account.CheckOut = nil
+	return b.rotateServiceAccount(ctx, s, cfg, name, account)
+}
+
+// rotateServiceAccount sets a new password for a service account and stores
+// it. The caller must hold the set's lock.
+func (b *backend) rotateServiceAccount(ctx context.Context, s logical.Storage, cfg *config, name string, account *serviceAccount) error {
+	password, err := cfg.generatePassword()
+	if err != nil {
+		return err
+	}
+	if err := b.updatePassword(cfg, account.DN, password); err != nil {
+		return err
+	}
+
+	account.Password = password
+	account.LastPasswordRotation = time.Now()
+
+	return storeServiceAccount(ctx, s, name, account)
+}
+
+const pathLibraryHelpSyn = `
+Manage sets of service accounts that can be checked out.
+`
+
+const pathLibraryHelpDesc = `
+A library set is a pool of existing service accounts. Callers check out an
+available account for a limited time, and the account's password is rotated
+when it is checked in, either explicitly or when the check-out lease expires.
+`
+
+const pathLibraryCheckOutHelpSyn = `
+Check out a service account from a library set.
+`
+
+const pathLibraryCheckOutHelpDesc = `
+This endpoint returns the credentials of an available service account of the
+set under a lease. The account is checked in when the lease is revoked.
+`
+
+const pathLibraryCheckInHelpSyn = `
+Check in service accounts checked out by the caller.
+`
+
+const pathLibraryCheckInHelpDesc = `
+This endpoint checks in service accounts, rotating their passwords. Unless
+disable_check_in_enforcement is set on the set, only accounts checked out by
+the calling entity or token may be checked in.
+`
+
+const pathLibraryManageCheckInHelpSyn = `
+Check in any service account of a library set.
+`
+
+const pathLibraryManageCheckInHelpDesc = `
+This endpoint allows operators to check in service accounts regardless of who
+checked them out, rotating their passwords.
+`
+
+const pathLibraryStatusHelpSyn = `
+Report the check-out status of the accounts of a library set.
+`
+
+const pathLibraryStatusHelpDesc = `
+This endpoint returns whether each service account of the set is available,
+and who has checked out the accounts that are not.
+`
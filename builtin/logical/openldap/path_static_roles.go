@@ -0,0 +1,343 @@
+package openldap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	staticRolePath = "static-role/"
+
+	// minRotationPeriod is the shortest allowed rotation period of a static
+	// role. Rotations are checked for once a minute.
+	minRotationPeriod = time.Minute
+)
+
+type staticRole struct {
+	DN                string        `json:"dn"`
+	Username          string        `json:"username"`
+	RotationPeriod    time.Duration `json:"rotation_period"`
+	Password          string        `json:"password"`
+	LastVaultRotation time.Time     `json:"last_vault_rotation"`
+}
+
+// nextRotationTime returns when the password of the role is due to rotate
+func (r *staticRole) nextRotationTime() time.Time {
+	return r.LastVaultRotation.Add(r.RotationPeriod)
+}
+
+func pathListStaticRoles(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: staticRolePath + "?$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathStaticRoleList,
+		},
+
+		HelpSynopsis:    pathStaticRoleHelpSyn,
+		HelpDescription: pathStaticRoleHelpDesc,
+	}
+}
+
+func pathStaticRoles(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: staticRolePath + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the static role.",
+			},
+			"username": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "The username of the existing LDAP entry to manage.",
+			},
+			"dn": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `The DN of the existing LDAP entry to manage. If not set, the
+entry is found by searching for username below the configured userdn.`,
+			},
+			"rotation_period": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "Period for automatic rotation of the password. Must be at least one minute.",
+			},
+		},
+
+		ExistenceCheck: b.pathStaticRoleExistenceCheck,
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathStaticRoleRead,
+			logical.CreateOperation: b.pathStaticRoleCreateUpdate,
+			logical.UpdateOperation: b.pathStaticRoleCreateUpdate,
+			logical.DeleteOperation: b.pathStaticRoleDelete,
+		},
+
+		HelpSynopsis:    pathStaticRoleHelpSyn,
+		HelpDescription: pathStaticRoleHelpDesc,
+	}
+}
+
+func pathStaticCreds(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "static-cred/" + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the static role.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.pathStaticCredsRead,
+		},
+
+		HelpSynopsis:    pathStaticCredsHelpSyn,
+		HelpDescription: pathStaticCredsHelpDesc,
+	}
+}
+
+func pathRotateStaticRole(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "rotate-role/" + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the static role.",
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathRotateStaticRoleUpdate,
+		},
+
+		HelpSynopsis:    pathRotateStaticRoleHelpSyn,
+		HelpDescription: pathRotateStaticRoleHelpDesc,
+	}
+}
+
+func (b *backend) staticRole(ctx context.Context, s logical.Storage, name string) (*staticRole, error) {
+	entry, err := s.Get(ctx, staticRolePath+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var result staticRole
+	if err := entry.DecodeJSON(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (b *backend) pathStaticRoleExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+	role, err := b.staticRole(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return false, err
+	}
+	return role != nil, nil
+}
+
+func (b *backend) pathStaticRoleList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	entries, err := req.Storage.List(ctx, staticRolePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(entries), nil
+}
+
+func (b *backend) pathStaticRoleRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	role, err := b.staticRole(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"dn":                  role.DN,
+			"username":            role.Username,
+			"rotation_period":     int64(role.RotationPeriod.Seconds()),
+			"last_vault_rotation": role.LastVaultRotation,
+		},
+	}, nil
+}
+
+func (b *backend) pathStaticRoleCreateUpdate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	lock := locksutil.LockForKey(b.roleLocks, staticRolePath+name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	b.configLock.RLock()
+	defer b.configLock.RUnlock()
+
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return logical.ErrorResponse("the backend is not configured"), nil
+	}
+
+	role, err := b.staticRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		if req.Operation == logical.UpdateOperation {
+			return logical.ErrorResponse(fmt.Sprintf("static role %q does not exist", name)), nil
+		}
+		role = &staticRole{}
+	}
+
+	if usernameRaw, ok := data.GetOk("username"); ok {
+		username := usernameRaw.(string)
+		if req.Operation == logical.UpdateOperation && username != role.Username {
+			return logical.ErrorResponse("username cannot be changed"), nil
+		}
+		role.Username = username
+	}
+	if role.Username == "" {
+		return logical.ErrorResponse("username is required"), nil
+	}
+
+	if dnRaw, ok := data.GetOk("dn"); ok {
+		dn := dnRaw.(string)
+		if req.Operation == logical.UpdateOperation && dn != role.DN {
+			return logical.ErrorResponse("dn cannot be changed"), nil
+		}
+		role.DN = dn
+	}
+	if role.DN == "" {
+		role.DN, err = b.findDN(cfg, role.Username)
+		if err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	if rotationPeriodRaw, ok := data.GetOk("rotation_period"); ok {
+		role.RotationPeriod = time.Duration(rotationPeriodRaw.(int)) * time.Second
+	}
+	if role.RotationPeriod < minRotationPeriod {
+		return logical.ErrorResponse(fmt.Sprintf("rotation_period must be at least %s", minRotationPeriod)), nil
+	}
+
+	// Take over the password of a new role immediately, so that only Vault
+	// knows it
+	if req.Operation == logical.CreateOperation {
+		return nil, b.rotateStaticRole(ctx, req.Storage, cfg, name, role)
+	}
+
+	entry, err := logical.StorageEntryJSON(staticRolePath+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathStaticRoleDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	lock := locksutil.LockForKey(b.roleLocks, staticRolePath+name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := req.Storage.Delete(ctx, staticRolePath+name); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (b *backend) pathStaticCredsRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	role, err := b.staticRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse(fmt.Sprintf("unknown static role: %s", name)), nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"dn":                  role.DN,
+			"username":            role.Username,
+			"password":            role.Password,
+			"last_vault_rotation": role.LastVaultRotation,
+			"rotation_period":     int64(role.RotationPeriod.Seconds()),
+			"ttl":                 int64(time.Until(role.nextRotationTime()).Seconds()),
+		},
+	}, nil
+}
+
+func (b *backend) pathRotateStaticRoleUpdate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	lock := locksutil.LockForKey(b.roleLocks, staticRolePath+name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	b.configLock.RLock()
+	defer b.configLock.RUnlock()
+
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return logical.ErrorResponse("the backend is not configured"), nil
+	}
+
+	role, err := b.staticRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse(fmt.Sprintf("unknown static role: %s", name)), nil
+	}
+
+	return nil, b.rotateStaticRole(ctx, req.Storage, cfg, name, role)
+}
+
+const pathStaticRoleHelpSyn = `
+Manage roles that rotate the passwords of existing LDAP entries.
+`
+
+const pathStaticRoleHelpDesc = `
+A static role manages the password of a single existing LDAP entry. Vault sets
+a new password when the role is created, and rotates it again every
+rotation_period. The current password is read from the "static-cred" endpoint.
+`
+
+const pathStaticCredsHelpSyn = `
+Request the current password of a static role.
+`
+
+const pathStaticCredsHelpDesc = `
+This endpoint returns the current password of the entry managed by the static
+role, and the time until it will next be rotated.
+`
+
+const pathRotateStaticRoleHelpSyn = `
+Rotate the password of a static role.
+`
+
+const pathRotateStaticRoleHelpDesc = `
+This endpoint sets a new password for the entry managed by the static role
+immediately, and restarts its rotation period.
+`
@@ -0,0 +1,91 @@
+package openldap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/vault/sdk/helper/consts"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// periodicFunc is invoked once a minute by the RollbackManager and rotates the
+// passwords of static roles whose rotation period has elapsed
+func (b *backend) periodicFunc(ctx context.Context, req *logical.Request) error {
+	if !b.System().LocalMount() && b.System().ReplicationState().HasState(consts.ReplicationPerformanceSecondary|consts.ReplicationPerformanceStandby) {
+		return nil
+	}
+
+	names, err := req.Storage.List(ctx, staticRolePath)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	b.configLock.RLock()
+	defer b.configLock.RUnlock()
+
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	var errs *multierror.Error
+	for _, name := range names {
+		if err := b.rotateIfRequired(ctx, req.Storage, cfg, name); err != nil {
+			errs = multierror.Append(errs, errwrap.Wrapf(fmt.Sprintf("error rotating password of static role %q: {{err}}", name), err))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// rotateIfRequired rotates the password of the named static role if its
+// rotation period has elapsed
+func (b *backend) rotateIfRequired(ctx context.Context, s logical.Storage, cfg *config, name string) error {
+	lock := locksutil.LockForKey(b.roleLocks, staticRolePath+name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	role, err := b.staticRole(ctx, s, name)
+	if err != nil {
+		return err
+	}
+	if role == nil || time.Now().Before(role.nextRotationTime()) {
+		return nil
+	}
+
+	return b.rotateStaticRole(ctx, s, cfg, name, role)
+}
+
+// rotateStaticRole sets a new password for the entry of a static role and
+// stores the role. The caller must hold the role's lock.
+//
+// The bind account replaces passwords without knowing the previous one, so if
+// storing the role fails the rotation is simply retried with a new password.
+func (b *backend) rotateStaticRole(ctx context.Context, s logical.Storage, cfg *config, name string, role *staticRole) error {
+	password, err := cfg.generatePassword()
+	if err != nil {
+		return err
+	}
+	if err := b.updatePassword(cfg, role.DN, password); err != nil {
+		return err
+	}
+
+	role.Password = password
+	role.LastVaultRotation = time.Now()
+
+	entry, err := logical.StorageEntryJSON(staticRolePath+name, role)
+	if err != nil {
+		return err
+	}
+	return s.Put(ctx, entry)
+}
@@ -0,0 +1,115 @@
+package openldap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const secretCheckOutType = "check_out"
+
+func secretCheckOut(b *backend) *framework.Secret {
+	return &framework.Secret{
+		Type: secretCheckOutType,
+		Fields: map[string]*framework.FieldSchema{
+			"service_account_name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the checked out service account",
+			},
+			"password": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Password of the checked out service account",
+			},
+		},
+
+		Renew:  b.secretCheckOutRenew,
+		Revoke: b.secretCheckOutRevoke,
+	}
+}
+
+// checkOutInternalData returns the set, account and check-out ID recorded in
+// a check-out lease
+func checkOutInternalData(secret *logical.Secret) (string, string, string, error) {
+	var values []string
+	for _, key := range []string{"set_name", "service_account_name", "check_out_id"} {
+		raw, ok := secret.InternalData[key]
+		if !ok {
+			return "", "", "", fmt.Errorf("secret is missing %s internal data", key)
+		}
+		value, ok := raw.(string)
+		if !ok {
+			return "", "", "", fmt.Errorf("secret is missing %s internal data", key)
+		}
+		values = append(values, value)
+	}
+	return values[0], values[1], values[2], nil
+}
+
+func (b *backend) secretCheckOutRenew(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	setName, accountName, id, err := checkOutInternalData(req.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := locksutil.LockForKey(b.roleLocks, libraryPath+setName)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	set, err := b.librarySet(ctx, req.Storage, setName)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return nil, fmt.Errorf("error during renew: could not find library set %q", setName)
+	}
+
+	account, err := b.serviceAccount(ctx, req.Storage, accountName)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || account.CheckOut == nil || account.CheckOut.ID != id {
+		return nil, fmt.Errorf("error during renew: %q has already been checked in", accountName)
+	}
+
+	resp := &logical.Response{Secret: req.Secret}
+	resp.Secret.TTL = set.TTL
+	resp.Secret.MaxTTL = set.MaxTTL
+	return resp, nil
+}
+
+func (b *backend) secretCheckOutRevoke(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	setName, accountName, id, err := checkOutInternalData(req.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := locksutil.LockForKey(b.roleLocks, libraryPath+setName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// The account may have been checked in explicitly, and possibly checked
+	// out again since
+	account, err := b.serviceAccount(ctx, req.Storage, accountName)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || account.CheckOut == nil || account.CheckOut.ID != id {
+		return nil, nil
+	}
+
+	b.configLock.RLock()
+	defer b.configLock.RUnlock()
+
+	cfg, err := b.config(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("the backend is not configured")
+	}
+
+	return nil, b.checkIn(ctx, req.Storage, cfg, accountName, account)
+}
@@ -0,0 +1,126 @@
+package openldap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	secretDynamicCredsType = "dynamic_creds"
+
+	dynamicUserWALKind = "dynamicUser"
+
+	// walRollbackMinAge is how long a user creation may be in progress before
+	// its WAL entry is rolled back
+	walRollbackMinAge = 5 * time.Minute
+)
+
+// dynamicUserWAL records a user before it is created, so that a partially
+// created user can be removed
+type dynamicUserWAL struct {
+	Username     string `json:"username" mapstructure:"username"`
+	RollbackLDIF string `json:"rollback_ldif" mapstructure:"rollback_ldif"`
+}
+
+func secretDynamicCreds(b *backend) *framework.Secret {
+	return &framework.Secret{
+		Type: secretDynamicCredsType,
+		Fields: map[string]*framework.FieldSchema{
+			"username": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Username of the generated user",
+			},
+			"password": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Password of the generated user",
+			},
+		},
+
+		Renew:  b.secretDynamicCredsRenew,
+		Revoke: b.secretDynamicCredsRevoke,
+	}
+}
+
+func (b *backend) secretDynamicCredsRenew(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	roleNameRaw, ok := req.Secret.InternalData["role"]
+	if !ok {
+		return nil, fmt.Errorf("secret is missing role internal data")
+	}
+	roleName, ok := roleNameRaw.(string)
+	if !ok {
+		return nil, fmt.Errorf("secret is missing role internal data")
+	}
+
+	role, err := b.dynamicRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("error during renew: could not find role with name %q", roleName)
+	}
+
+	resp := &logical.Response{Secret: req.Secret}
+	resp.Secret.TTL = role.DefaultTTL
+	resp.Secret.MaxTTL = role.MaxTTL
+	return resp, nil
+}
+
+func (b *backend) secretDynamicCredsRevoke(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	deletionLDIFRaw, ok := req.Secret.InternalData["deletion_ldif"]
+	if !ok {
+		return nil, fmt.Errorf("secret is missing deletion_ldif internal data")
+	}
+	deletionLDIF, ok := deletionLDIFRaw.(string)
+	if !ok {
+		return nil, fmt.Errorf("secret is missing deletion_ldif internal data")
+	}
+
+	if err := b.executeLDIF(ctx, req.Storage, deletionLDIF); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// walRollback removes users whose creation was interrupted
+func (b *backend) walRollback(ctx context.Context, req *logical.Request, kind string, data interface{}) error {
+	switch kind {
+	case dynamicUserWALKind:
+		var entry dynamicUserWAL
+		if err := mapstructure.Decode(data, &entry); err != nil {
+			return err
+		}
+
+		b.Logger().Debug("rolling back creation of dynamic user", "username", entry.Username)
+		return b.executeLDIF(ctx, req.Storage, entry.RollbackLDIF)
+
+	default:
+		return fmt.Errorf("unknown type to rollback")
+	}
+}
+
+// executeLDIF applies a rendered LDIF document to the configured directory
+func (b *backend) executeLDIF(ctx context.Context, s logical.Storage, ldif string) error {
+	entries, err := parseLDIF(ldif)
+	if err != nil {
+		return err
+	}
+
+	b.configLock.RLock()
+	defer b.configLock.RUnlock()
+
+	cfg, err := b.config(ctx, s)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("the backend is not configured")
+	}
+
+	return b.execute(cfg, entries)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/consts"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
@@ -33,6 +34,8 @@ func Backend(conf *logical.BackendConfig) *backend {
 				"ca",
 				"crl/pem",
 				"crl",
+				"ocsp",
+				"ocsp/*",
 			},
 
 			LocalStorage: []string{
@@ -48,6 +51,7 @@ func Backend(conf *logical.BackendConfig) *backend {
 
 			SealWrapStorage: []string{
 				"config/ca_bundle",
+				"config/ocsp",
 			},
 		},
 
@@ -63,6 +67,8 @@ func Backend(conf *logical.BackendConfig) *backend {
 			pathConfigCA(&b),
 			pathConfigCRL(&b),
 			pathConfigURLs(&b),
+			pathConfigOCSP(&b),
+			pathConfigAutoTidy(&b),
 			pathSignVerbatim(&b),
 			pathSign(&b),
 			pathIssue(&b),
@@ -73,6 +79,8 @@ func Backend(conf *logical.BackendConfig) *backend {
 			pathFetchCRLViaCertPath(&b),
 			pathFetchValid(&b),
 			pathFetchListCerts(&b),
+			pathOCSP(&b),
+			pathOCSPGet(&b),
 			pathRevoke(&b),
 			pathTidy(&b),
 		},
@@ -81,11 +89,13 @@ func Backend(conf *logical.BackendConfig) *backend {
 			secretCerts(&b),
 		},
 
-		BackendType: logical.TypeLogical,
+		BackendType:  logical.TypeLogical,
+		PeriodicFunc: b.periodicFunc,
 	}
 
 	b.crlLifetime = time.Hour * 72
 	b.tidyCASGuard = new(uint32)
+	b.lastAutoTidy = time.Now()
 	b.storage = conf.StorageView
 
 	return &b
@@ -98,6 +108,36 @@ type backend struct {
 	crlLifetime       time.Duration
 	revokeStorageLock sync.RWMutex
 	tidyCASGuard      *uint32
+	lastAutoTidy      time.Time
+}
+
+// periodicFunc is invoked once a minute by the RollbackManager and starts a
+// tidy operation if auto-tidy is enabled and its interval has elapsed
+func (b *backend) periodicFunc(ctx context.Context, req *logical.Request) error {
+	// Tidying writes to storage, so leave it to the active node
+	if b.System().ReplicationState().HasState(consts.ReplicationPerformanceStandby | consts.ReplicationDRSecondary) {
+		return nil
+	}
+
+	config, err := b.autoTidyConfig(ctx, req.Storage)
+	if err != nil {
+		return err
+	}
+	if config == nil || !config.Enabled {
+		return nil
+	}
+
+	now := time.Now()
+	if now.Before(b.lastAutoTidy.Add(config.Interval)) {
+		return nil
+	}
+
+	if b.startTidy(req.Storage, config) {
+		b.Logger().Debug("started automatic tidy operation")
+		b.lastAutoTidy = now
+	}
+
+	return nil
 }
 
 const backendHelp = `
@@ -0,0 +1,168 @@
+package pki
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	defaultTidySafetyBuffer = 72 * time.Hour
+	defaultAutoTidyInterval = 12 * time.Hour
+)
+
+// tidyConfig holds the parameters of a tidy operation. When stored at
+// config/auto-tidy it additionally controls periodic tidying.
+type tidyConfig struct {
+	Enabled      bool          `json:"enabled"`
+	Interval     time.Duration `json:"interval_duration"`
+	CertStore    bool          `json:"tidy_cert_store"`
+	RevokedCerts bool          `json:"tidy_revoked_certs"`
+	SafetyBuffer time.Duration `json:"safety_buffer"`
+}
+
+func pathConfigAutoTidy(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "config/auto-tidy",
+		Fields: map[string]*framework.FieldSchema{
+			"enabled": &framework.FieldSchema{
+				Type:        framework.TypeBool,
+				Description: `Set to true to enable automatic tidy operations.`,
+			},
+			"interval_duration": &framework.FieldSchema{
+				Type: framework.TypeDurationSecond,
+				Description: `Interval at which to run an automatic tidy
+operation. Defaults to 12 hours.`,
+				Default: int(defaultAutoTidyInterval / time.Second),
+			},
+			"tidy_cert_store": &framework.FieldSchema{
+				Type: framework.TypeBool,
+				Description: `Set to true to enable tidying up
+the certificate store`,
+			},
+			"tidy_revoked_certs": &framework.FieldSchema{
+				Type: framework.TypeBool,
+				Description: `Set to true to expire all revoked
+and expired certificates, removing them both from the CRL and from storage. The
+CRL will be rotated if this causes any values to be removed.`,
+			},
+			"safety_buffer": &framework.FieldSchema{
+				Type: framework.TypeDurationSecond,
+				Description: `The amount of extra time that must have passed
+beyond certificate expiration before it is removed
+from the backend storage and/or revocation list.
+Defaults to 72 hours.`,
+				Default: int(defaultTidySafetyBuffer / time.Second),
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathAutoTidyRead,
+			logical.UpdateOperation: b.pathAutoTidyWrite,
+		},
+
+		HelpSynopsis:    pathConfigAutoTidyHelpSyn,
+		HelpDescription: pathConfigAutoTidyHelpDesc,
+	}
+}
+
+func (b *backend) autoTidyConfig(ctx context.Context, s logical.Storage) (*tidyConfig, error) {
+	entry, err := s.Get(ctx, "config/auto-tidy")
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var result tidyConfig
+	if err := entry.DecodeJSON(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (b *backend) pathAutoTidyRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.autoTidyConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"enabled":            config.Enabled,
+			"interval_duration":  int64(config.Interval.Seconds()),
+			"tidy_cert_store":    config.CertStore,
+			"tidy_revoked_certs": config.RevokedCerts,
+			"safety_buffer":      int64(config.SafetyBuffer.Seconds()),
+		},
+	}, nil
+}
+
+func (b *backend) pathAutoTidyWrite(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	config, err := b.autoTidyConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &tidyConfig{
+			Interval:     defaultAutoTidyInterval,
+			SafetyBuffer: defaultTidySafetyBuffer,
+		}
+	}
+
+	if enabledRaw, ok := d.GetOk("enabled"); ok {
+		config.Enabled = enabledRaw.(bool)
+	}
+	if intervalRaw, ok := d.GetOk("interval_duration"); ok {
+		interval := intervalRaw.(int)
+		if interval < 1 {
+			return logical.ErrorResponse("interval_duration must be greater than zero"), nil
+		}
+		config.Interval = time.Duration(interval) * time.Second
+	}
+	if certStoreRaw, ok := d.GetOk("tidy_cert_store"); ok {
+		config.CertStore = certStoreRaw.(bool)
+	}
+	if revokedCertsRaw, ok := d.GetOk("tidy_revoked_certs"); ok {
+		config.RevokedCerts = revokedCertsRaw.(bool)
+	}
+	if safetyBufferRaw, ok := d.GetOk("safety_buffer"); ok {
+		safetyBuffer := safetyBufferRaw.(int)
+		if safetyBuffer < 1 {
+			return logical.ErrorResponse("safety_buffer must be greater than zero"), nil
+		}
+		config.SafetyBuffer = time.Duration(safetyBuffer) * time.Second
+	}
+
+	if config.Enabled && !config.CertStore && !config.RevokedCerts {
+		return logical.ErrorResponse("auto-tidy enabled but no tidy operations were requested; enable tidy_cert_store and/or tidy_revoked_certs"), nil
+	}
+
+	entry, err := logical.StorageEntryJSON("config/auto-tidy", config)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+const pathConfigAutoTidyHelpSyn = `
+Configure automatic tidying of the backend.
+`
+
+const pathConfigAutoTidyHelpDesc = `
+This endpoint allows configuring a tidy operation to run periodically, using
+the same parameters as the "tidy" endpoint. The interval is measured from when
+the backend was loaded or the last automatic tidy operation started, and the
+periodic check runs roughly once a minute.
+`
@@ -0,0 +1,168 @@
+package pki
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/certutil"
+	"github.com/hashicorp/vault/sdk/helper/errutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// ocspConfig holds the OCSP responder configuration
+type ocspConfig struct {
+	Disable            bool   `json:"disable"`
+	ResponderPEMBundle string `json:"responder_pem_bundle"`
+}
+
+func pathConfigOCSP(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "config/ocsp",
+		Fields: map[string]*framework.FieldSchema{
+			"disable": &framework.FieldSchema{
+				Type:        framework.TypeBool,
+				Description: `If set to true, the OCSP responder answers every request as unauthorized.`,
+			},
+			"responder_pem_bundle": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `PEM-format, concatenated unencrypted secret key
+and certificate of a delegated OCSP responder. The certificate must be
+issued by this mount's CA and have the OCSPSigning extended key usage.
+If empty, responses are signed by the CA itself.`,
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathOCSPConfigRead,
+			logical.UpdateOperation: b.pathOCSPConfigWrite,
+		},
+
+		HelpSynopsis:    pathConfigOCSPHelpSyn,
+		HelpDescription: pathConfigOCSPHelpDesc,
+	}
+}
+
+func (b *backend) OCSP(ctx context.Context, s logical.Storage) (*ocspConfig, error) {
+	entry, err := s.Get(ctx, "config/ocsp")
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var result ocspConfig
+	if err := entry.DecodeJSON(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (b *backend) pathOCSPConfigRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := b.OCSP(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, nil
+	}
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"disable": config.Disable,
+		},
+	}
+
+	// Only return the responder certificate, never its key
+	if config.ResponderPEMBundle != "" {
+		parsedBundle, err := certutil.ParsePEMBundle(config.ResponderPEMBundle)
+		if err != nil {
+			return nil, err
+		}
+		resp.Data["responder_certificate"] = strings.TrimSpace(string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: parsedBundle.CertificateBytes,
+		})))
+	}
+
+	return resp, nil
+}
+
+func (b *backend) pathOCSPConfigWrite(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	config, err := b.OCSP(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &ocspConfig{}
+	}
+
+	if disableRaw, ok := d.GetOk("disable"); ok {
+		config.Disable = disableRaw.(bool)
+	}
+
+	if bundleRaw, ok := d.GetOk("responder_pem_bundle"); ok {
+		bundle := bundleRaw.(string)
+		if bundle != "" {
+			caInfo, err := fetchCAInfo(ctx, req)
+			switch err.(type) {
+			case errutil.UserError:
+				return logical.ErrorResponse(err.Error()), nil
+			case errutil.InternalError:
+				return nil, err
+			}
+
+			if err := validateOCSPResponder(bundle, caInfo.Certificate); err != nil {
+				return logical.ErrorResponse(err.Error()), nil
+			}
+		}
+		config.ResponderPEMBundle = bundle
+	}
+
+	entry, err := logical.StorageEntryJSON("config/ocsp", config)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// validateOCSPResponder checks that a delegated responder bundle contains a
+// key and a certificate that the CA authorized to sign OCSP responses
+func validateOCSPResponder(bundle string, ca *x509.Certificate) error {
+	parsedBundle, err := certutil.ParsePEMBundle(bundle)
+	if err != nil {
+		return err
+	}
+	if parsedBundle.Certificate == nil || parsedBundle.PrivateKey == nil {
+		return fmt.Errorf("responder bundle must contain both a certificate and a private key")
+	}
+
+	if err := parsedBundle.Certificate.CheckSignatureFrom(ca); err != nil {
+		return fmt.Errorf("responder certificate was not issued by this mount's CA: %v", err)
+	}
+
+	for _, usage := range parsedBundle.Certificate.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageOCSPSigning {
+			return nil
+		}
+	}
+	return fmt.Errorf("responder certificate does not have the OCSPSigning extended key usage")
+}
+
+const pathConfigOCSPHelpSyn = `
+Configure the OCSP responder.
+`
+
+const pathConfigOCSPHelpDesc = `
+This endpoint allows disabling the OCSP responder, and configuring a delegated
+responder certificate and key to sign OCSP responses instead of the CA.
+`
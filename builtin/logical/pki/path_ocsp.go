@@ -0,0 +1,214 @@
+package pki
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/certutil"
+	"github.com/hashicorp/vault/sdk/helper/errutil"
+	"github.com/hashicorp/vault/sdk/logical"
+	"golang.org/x/crypto/ocsp"
+)
+
+// maxOCSPRequestSize bounds the size of DER-encoded OCSP requests read from
+// the request body
+const maxOCSPRequestSize = 64 * 1024
+
+func pathOCSP(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "ocsp",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathOCSPPost,
+		},
+
+		HelpSynopsis:    pathOCSPHelpSyn,
+		HelpDescription: pathOCSPHelpDesc,
+	}
+}
+
+func pathOCSPGet(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "ocsp/(?P<req>.+)",
+		Fields: map[string]*framework.FieldSchema{
+			"req": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: `The base64 encoded, DER-encoded OCSP request.`,
+			},
+		},
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.pathOCSPRead,
+		},
+
+		HelpSynopsis:    pathOCSPHelpSyn,
+		HelpDescription: pathOCSPHelpDesc,
+	}
+}
+
+func (b *backend) pathOCSPRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	derReq, err := base64.StdEncoding.DecodeString(data.Get("req").(string))
+	if err != nil {
+		return ocspResponse(ocsp.MalformedRequestErrorResponse), nil
+	}
+
+	return b.respondOCSP(ctx, req, derReq)
+}
+
+func (b *backend) pathOCSPPost(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if req.HTTPRequest == nil || req.HTTPRequest.Body == nil {
+		return ocspResponse(ocsp.MalformedRequestErrorResponse), nil
+	}
+
+	derReq, err := ioutil.ReadAll(io.LimitReader(req.HTTPRequest.Body, maxOCSPRequestSize+1))
+	if err != nil || len(derReq) > maxOCSPRequestSize {
+		return ocspResponse(ocsp.MalformedRequestErrorResponse), nil
+	}
+
+	return b.respondOCSP(ctx, req, derReq)
+}
+
+// respondOCSP answers a DER-encoded OCSP request for a certificate issued by
+// this mount
+func (b *backend) respondOCSP(ctx context.Context, req *logical.Request, derReq []byte) (*logical.Response, error) {
+	config, err := b.OCSP(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if config != nil && config.Disable {
+		return ocspResponse(ocsp.UnauthorizedErrorResponse), nil
+	}
+
+	ocspReq, err := ocsp.ParseRequest(derReq)
+	if err != nil {
+		return ocspResponse(ocsp.MalformedRequestErrorResponse), nil
+	}
+
+	caInfo, err := fetchCAInfo(ctx, req)
+	switch err.(type) {
+	case errutil.UserError:
+		return ocspResponse(ocsp.UnauthorizedErrorResponse), nil
+	case errutil.InternalError:
+		b.Logger().Error("failed to fetch CA for OCSP response", "error", err)
+		return ocspResponse(ocsp.InternalErrorErrorResponse), nil
+	}
+
+	if !ocspIssuerMatches(ocspReq, caInfo.Certificate) {
+		return ocspResponse(ocsp.UnauthorizedErrorResponse), nil
+	}
+
+	template := ocsp.Response{
+		SerialNumber: ocspReq.SerialNumber,
+		ThisUpdate:   time.Now(),
+		IssuerHash:   ocspReq.HashAlgorithm,
+		Status:       ocsp.Good,
+	}
+
+	serial := certutil.GetHexFormatted(ocspReq.SerialNumber.Bytes(), ":")
+	revEntry, err := fetchCertBySerial(ctx, req, "revoked/", serial)
+	if err != nil {
+		b.Logger().Error("failed to fetch revocation entry for OCSP response", "serial", serial, "error", err)
+		return ocspResponse(ocsp.InternalErrorErrorResponse), nil
+	}
+	if revEntry != nil {
+		var revInfo revocationInfo
+		if err := revEntry.DecodeJSON(&revInfo); err != nil {
+			b.Logger().Error("failed to decode revocation entry for OCSP response", "serial", serial, "error", err)
+			return ocspResponse(ocsp.InternalErrorErrorResponse), nil
+		}
+		template.Status = ocsp.Revoked
+		template.RevocationReason = ocsp.Unspecified
+		if !revInfo.RevocationTimeUTC.IsZero() {
+			template.RevokedAt = revInfo.RevocationTimeUTC
+		} else {
+			template.RevokedAt = time.Unix(revInfo.RevocationTime, 0).UTC()
+		}
+	} else {
+		certEntry, err := fetchCertBySerial(ctx, req, "certs/", serial)
+		if err != nil {
+			b.Logger().Error("failed to fetch certificate for OCSP response", "serial", serial, "error", err)
+			return ocspResponse(ocsp.InternalErrorErrorResponse), nil
+		}
+		if certEntry == nil {
+			template.Status = ocsp.Unknown
+		}
+	}
+
+	responderCert := caInfo.Certificate
+	var signer crypto.Signer = caInfo.PrivateKey
+	if config != nil && config.ResponderPEMBundle != "" {
+		parsedBundle, err := certutil.ParsePEMBundle(config.ResponderPEMBundle)
+		if err != nil {
+			b.Logger().Error("failed to parse OCSP responder bundle", "error", err)
+			return ocspResponse(ocsp.InternalErrorErrorResponse), nil
+		}
+		responderCert = parsedBundle.Certificate
+		signer = parsedBundle.PrivateKey
+		template.Certificate = responderCert
+	}
+
+	resp, err := ocsp.CreateResponse(caInfo.Certificate, responderCert, template, signer)
+	if err != nil {
+		b.Logger().Error("failed to create OCSP response", "error", err)
+		return ocspResponse(ocsp.InternalErrorErrorResponse), nil
+	}
+
+	return ocspResponse(resp), nil
+}
+
+// ocspIssuerMatches returns whether the request's issuer name and key hashes
+// identify the given CA certificate
+func ocspIssuerMatches(ocspReq *ocsp.Request, ca *x509.Certificate) bool {
+	if !ocspReq.HashAlgorithm.Available() {
+		return false
+	}
+
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(ca.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return false
+	}
+
+	h := ocspReq.HashAlgorithm.New()
+	h.Write(ca.RawSubject)
+	nameHash := h.Sum(nil)
+
+	h.Reset()
+	h.Write(spki.PublicKey.RightAlign())
+	keyHash := h.Sum(nil)
+
+	return bytes.Equal(nameHash, ocspReq.IssuerNameHash) && bytes.Equal(keyHash, ocspReq.IssuerKeyHash)
+}
+
+func ocspResponse(body []byte) *logical.Response {
+	return &logical.Response{
+		Data: map[string]interface{}{
+			logical.HTTPContentType: "application/ocsp-response",
+			logical.HTTPRawBody:     body,
+			logical.HTTPStatusCode:  200,
+		},
+	}
+}
+
+const pathOCSPHelpSyn = `
+Query the revocation status of a certificate issued by this mount.
+`
+
+const pathOCSPHelpDesc = `
+This endpoint is an OCSP responder (RFC 6960) for certificates issued by this
+mount. Requests may be sent as a DER-encoded POST body with a Content-Type of
+"application/ocsp-request", or base64 encoded in the URL of a GET request.
+Responses are signed by the CA, or by the delegated responder configured at
+"config/ocsp".
+`
@@ -0,0 +1,182 @@
+package pki
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	vaulthttp "github.com/hashicorp/vault/http"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/vault"
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestBackend_OCSP(t *testing.T) {
+	coreConfig := &vault.CoreConfig{
+		LogicalBackends: map[string]logical.Factory{
+			"pki": Factory,
+		},
+	}
+	cluster := vault.NewTestCluster(t, coreConfig, &vault.TestClusterOptions{
+		HandlerFunc: vaulthttp.Handler,
+	})
+	cluster.Start()
+	defer cluster.Cleanup()
+
+	client := cluster.Cores[0].Client
+	err := client.Sys().Mount("pki", &api.MountInput{
+		Type: "pki",
+		Config: api.MountConfigInput{
+			DefaultLeaseTTL: "16h",
+			MaxLeaseTTL:     "60h",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Logical().Write("pki/root/generate/internal", map[string]interface{}{
+		"ttl":         "40h",
+		"common_name": "myvault.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert := parsePEMCert(t, resp.Data["certificate"].(string))
+
+	_, err = client.Logical().Write("pki/roles/test", map[string]interface{}{
+		"allowed_domains":  "foobar.com",
+		"allow_subdomains": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = client.Logical().Write("pki/issue/test", map[string]interface{}{
+		"common_name": "test.foobar.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := parsePEMCert(t, resp.Data["certificate"].(string))
+	serial := resp.Data["serial_number"].(string)
+
+	ocspReq, err := ocsp.CreateRequest(cert, caCert, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The responder is unauthenticated
+	client.ClearToken()
+	defer client.SetToken(cluster.RootToken)
+
+	encodedReq := base64.StdEncoding.EncodeToString(ocspReq)
+
+	query := func(post bool) *ocsp.Response {
+		t.Helper()
+		var r *api.Request
+		if post {
+			r = client.NewRequest("POST", "/v1/pki/ocsp")
+			r.Headers = http.Header{"Content-Type": []string{"application/ocsp-request"}}
+			r.BodyBytes = ocspReq
+		} else {
+			r = client.NewRequest("GET", "/v1/pki/ocsp/"+encodedReq)
+		}
+		httpResp, err := client.RawRequest(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer httpResp.Body.Close()
+		if httpResp.Header.Get("Content-Type") != "application/ocsp-response" {
+			t.Fatalf("bad content type: %q", httpResp.Header.Get("Content-Type"))
+		}
+		body, err := ioutil.ReadAll(httpResp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ocspResp, err := ocsp.ParseResponseForCert(body, cert, caCert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ocspResp
+	}
+
+	for _, post := range []bool{true, false} {
+		// Paths are cleaned by both the client and the server, so GET
+		// requests cannot carry an encoding containing "//"
+		if !post && strings.Contains(encodedReq, "//") {
+			continue
+		}
+		if status := query(post).Status; status != ocsp.Good {
+			t.Fatalf("expected good status, got %d", status)
+		}
+	}
+
+	client.SetToken(cluster.RootToken)
+	if _, err := client.Logical().Write("pki/revoke", map[string]interface{}{
+		"serial_number": serial,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Use a delegated responder
+	resp, err = client.Logical().Write("pki/roles/ocsp", map[string]interface{}{
+		"allowed_domains":    "ocsp.myvault.com",
+		"allow_bare_domains": true,
+		"ext_key_usage":      "OCSPSigning",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = client.Logical().Write("pki/issue/ocsp", map[string]interface{}{
+		"common_name": "ocsp.myvault.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	responderCert := parsePEMCert(t, resp.Data["certificate"].(string))
+	bundle := resp.Data["private_key"].(string) + "\n" + resp.Data["certificate"].(string)
+
+	// Certificates without the OCSPSigning usage are rejected
+	if _, err := client.Logical().Write("pki/config/ocsp", map[string]interface{}{
+		"responder_pem_bundle": resp.Data["private_key"].(string) + "\n" + caCertPEM(caCert),
+	}); err == nil {
+		t.Fatal("expected error configuring responder without OCSPSigning usage")
+	}
+
+	if _, err := client.Logical().Write("pki/config/ocsp", map[string]interface{}{
+		"responder_pem_bundle": bundle,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	client.ClearToken()
+
+	ocspResp := query(true)
+	if ocspResp.Status != ocsp.Revoked || ocspResp.RevokedAt.IsZero() {
+		t.Fatalf("expected revoked status, got %#v", ocspResp)
+	}
+	if ocspResp.Certificate == nil || !ocspResp.Certificate.Equal(responderCert) {
+		t.Fatal("expected response to be signed by the delegated responder")
+	}
+}
+
+func parsePEMCert(t *testing.T, certPEM string) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		t.Fatal("failed to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func caCertPEM(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
@@ -72,28 +72,44 @@ func (b *backend) pathTidyWrite(ctx context.Context, req *logical.Request, d *fr
 
 	bufferDuration := time.Duration(safetyBuffer) * time.Second
 
-	if !atomic.CompareAndSwapUint32(b.tidyCASGuard, 0, 1) {
+	if !b.startTidy(req.Storage, &tidyConfig{
+		CertStore:    tidyCertStore,
+		RevokedCerts: tidyRevokedCerts || tidyRevocationList,
+		SafetyBuffer: bufferDuration,
+	}) {
 		resp := &logical.Response{}
 		resp.AddWarning("Tidy operation already in progress.")
 		return resp, nil
 	}
 
+	resp := &logical.Response{}
+	resp.AddWarning("Tidy operation successfully started. Any information from the operation will be printed to Vault's server logs.")
+	return logical.RespondWithStatusCode(resp, req, http.StatusAccepted)
+}
+
+// startTidy runs a tidy operation in the background. It returns false if a
+// tidy operation is already in progress.
+func (b *backend) startTidy(s logical.Storage, config *tidyConfig) bool {
+	if !atomic.CompareAndSwapUint32(b.tidyCASGuard, 0, 1) {
+		return false
+	}
+
 	// Tests using framework will screw up the storage so make a locally
 	// scoped req to hold a reference
-	req = &logical.Request{
-		Storage: req.Storage,
+	req := &logical.Request{
+		Storage: s,
 	}
 
 	go func() {
 		defer atomic.StoreUint32(b.tidyCASGuard, 0)
 
 		// Don't cancel when the original client request goes away
-		ctx = context.Background()
+		ctx := context.Background()
 
 		logger := b.Logger().Named("tidy")
 
 		doTidy := func() error {
-			if tidyCertStore {
+			if config.CertStore {
 				serials, err := req.Storage.List(ctx, "certs/")
 				if err != nil {
 					return errwrap.Wrapf("error fetching list of certs: {{err}}", err)
@@ -125,7 +141,7 @@ func (b *backend) pathTidyWrite(ctx context.Context, req *logical.Request, d *fr
 						return errwrap.Wrapf(fmt.Sprintf("unable to parse stored certificate with serial %q: {{err}}", serial), err)
 					}
 
-					if time.Now().After(cert.NotAfter.Add(bufferDuration)) {
+					if time.Now().After(cert.NotAfter.Add(config.SafetyBuffer)) {
 						if err := req.Storage.Delete(ctx, "certs/"+serial); err != nil {
 							return errwrap.Wrapf(fmt.Sprintf("error deleting serial %q from storage: {{err}}", serial), err)
 						}
@@ -133,7 +149,7 @@ func (b *backend) pathTidyWrite(ctx context.Context, req *logical.Request, d *fr
 				}
 			}
 
-			if tidyRevokedCerts || tidyRevocationList {
+			if config.RevokedCerts {
 				b.revokeStorageLock.Lock()
 				defer b.revokeStorageLock.Unlock()
 
@@ -175,7 +191,7 @@ func (b *backend) pathTidyWrite(ctx context.Context, req *logical.Request, d *fr
 						return errwrap.Wrapf(fmt.Sprintf("unable to parse stored revoked certificate with serial %q: {{err}}", serial), err)
 					}
 
-					if time.Now().After(revokedCert.NotAfter.Add(bufferDuration)) {
+					if time.Now().After(revokedCert.NotAfter.Add(config.SafetyBuffer)) {
 						if err := req.Storage.Delete(ctx, "revoked/"+serial); err != nil {
 							return errwrap.Wrapf(fmt.Sprintf("error deleting serial %q from revoked list: {{err}}", serial), err)
 						}
@@ -202,9 +218,7 @@ func (b *backend) pathTidyWrite(ctx context.Context, req *logical.Request, d *fr
 		}
 	}()
 
-	resp := &logical.Response{}
-	resp.AddWarning("Tidy operation successfully started. Any information from the operation will be printed to Vault's server logs.")
-	return logical.RespondWithStatusCode(resp, req, http.StatusAccepted)
+	return true
 }
 
 const pathTidyHelpSyn = `
@@ -0,0 +1,99 @@
+package pki
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func TestBackend_AutoTidy(t *testing.T) {
+	b, storage := createBackendWithStorage(t)
+	ctx := context.Background()
+
+	handle := func(op logical.Operation, path string, data map[string]interface{}) *logical.Response {
+		t.Helper()
+		resp, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: op,
+			Path:      path,
+			Storage:   storage,
+			Data:      data,
+		})
+		if err != nil || (resp != nil && resp.IsError()) {
+			t.Fatalf("bad: err: %v resp: %#v", err, resp)
+		}
+		return resp
+	}
+
+	handle(logical.UpdateOperation, "root/generate/internal", map[string]interface{}{
+		"common_name": "myvault.com",
+		"ttl":         "40h",
+	})
+	handle(logical.UpdateOperation, "roles/test", map[string]interface{}{
+		"allowed_domains":  "myvault.com",
+		"allow_subdomains": true,
+	})
+	resp := handle(logical.UpdateOperation, "issue/test", map[string]interface{}{
+		"common_name": "foo.myvault.com",
+		"ttl":         "1s",
+	})
+	serial := resp.Data["serial_number"].(string)
+
+	// Enabling auto-tidy without any tidy operations is rejected
+	resp, err := b.HandleRequest(ctx, &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "config/auto-tidy",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"enabled": true,
+		},
+	})
+	if err != nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got: err: %v resp: %#v", err, resp)
+	}
+
+	handle(logical.UpdateOperation, "config/auto-tidy", map[string]interface{}{
+		"enabled":           true,
+		"interval_duration": "1s",
+		"tidy_cert_store":   true,
+		"safety_buffer":     "1s",
+	})
+	resp = handle(logical.ReadOperation, "config/auto-tidy", nil)
+	if resp.Data["enabled"] != true || resp.Data["interval_duration"].(int64) != 1 ||
+		resp.Data["tidy_cert_store"] != true || resp.Data["tidy_revoked_certs"] != false ||
+		resp.Data["safety_buffer"].(int64) != 1 {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	// Let the certificate expire and the safety buffer pass
+	time.Sleep(3 * time.Second)
+
+	if err := b.periodicFunc(ctx, &logical.Request{Storage: storage}); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entry, err := storage.Get(ctx, "certs/"+normalizeSerial(serial))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected expired certificate to be tidied")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// The CA certificate has not expired and is kept
+	certs, err := storage.List(ctx, "certs/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 1 {
+		t.Fatalf("expected only the CA certificate to remain, got %v", certs)
+	}
+}
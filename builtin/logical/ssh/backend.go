@@ -38,6 +38,7 @@ func Backend(conf *logical.BackendConfig) (*backend, error) {
 			Unauthenticated: []string{
 				"verify",
 				"public_key",
+				"host_public_key",
 			},
 
 			LocalStorage: []string{
@@ -47,6 +48,7 @@ func Backend(conf *logical.BackendConfig) (*backend, error) {
 			SealWrapStorage: []string{
 				caPrivateKey,
 				caPrivateKeyStoragePath,
+				hostCAPrivateKeyStoragePath,
 				"keys/",
 			},
 		},
@@ -60,8 +62,10 @@ func Backend(conf *logical.BackendConfig) (*backend, error) {
 			pathLookup(&b),
 			pathVerify(&b),
 			pathConfigCA(&b),
+			pathConfigHostCA(&b),
 			pathSign(&b),
 			pathFetchPublicKey(&b),
+			pathFetchHostPublicKey(&b),
 		},
 
 		Secrets: []*framework.Secret{
@@ -648,6 +648,155 @@ func TestBackend_ValidPrincipalsValidatedForHostCertificates(t *testing.T) {
 	logicaltest.Test(t, testCase)
 }
 
+func TestBackend_SeparateHostCA(t *testing.T) {
+	config := logical.TestBackendConfig()
+
+	b, err := Factory(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Cannot create backend: %s", err)
+	}
+
+	var hostCAPublicKey string
+	signingKey := func(resp *logical.Response) (ssh.PublicKey, error) {
+		signedKey := strings.TrimSpace(resp.Data["signed_key"].(string))
+		key, err := base64.StdEncoding.DecodeString(strings.Split(signedKey, " ")[1])
+		if err != nil {
+			return nil, err
+		}
+		parsedKey, err := ssh.ParsePublicKey(key)
+		if err != nil {
+			return nil, err
+		}
+		return parsedKey.(*ssh.Certificate).SignatureKey, nil
+	}
+
+	testCase := logicaltest.TestCase{
+		LogicalBackend: b,
+		Steps: []logicaltest.TestStep{
+			configCaStep(),
+
+			// Without a host CA, host certificates use the default CA
+			logicaltest.TestStep{
+				Operation:       logical.ReadOperation,
+				Path:            "host_public_key",
+				Unauthenticated: true,
+				Check: func(resp *logical.Response) error {
+					if key := string(resp.Data["http_raw_body"].([]byte)); key != publicKey {
+						return fmt.Errorf("host_public_key incorrect. Expected %v, actual %v", publicKey, key)
+					}
+					return nil
+				},
+			},
+
+			logicaltest.TestStep{
+				Operation: logical.UpdateOperation,
+				Path:      "config/host_ca",
+				Check: func(resp *logical.Response) error {
+					hostCAPublicKey = resp.Data["public_key"].(string)
+					if hostCAPublicKey == "" || hostCAPublicKey == publicKey {
+						return fmt.Errorf("expected a new host CA public key, got %q", hostCAPublicKey)
+					}
+					return nil
+				},
+			},
+
+			logicaltest.TestStep{
+				Operation: logical.ReadOperation,
+				Path:      "config/host_ca",
+				Check: func(resp *logical.Response) error {
+					if resp.Data["public_key"] != hostCAPublicKey {
+						return fmt.Errorf("expected host CA public key %q, got %q", hostCAPublicKey, resp.Data["public_key"])
+					}
+					return nil
+				},
+			},
+
+			logicaltest.TestStep{
+				Operation:       logical.ReadOperation,
+				Path:            "host_public_key",
+				Unauthenticated: true,
+				Check: func(resp *logical.Response) error {
+					if key := string(resp.Data["http_raw_body"].([]byte)); key != hostCAPublicKey {
+						return fmt.Errorf("host_public_key incorrect. Expected %v, actual %v", hostCAPublicKey, key)
+					}
+					return nil
+				},
+			},
+
+			createRoleStep("testing", map[string]interface{}{
+				"key_type":                "ca",
+				"allow_user_certificates": true,
+				"allow_host_certificates": true,
+				"allowed_users":           "*",
+				"allowed_domains":         "example.com",
+				"allow_subdomains":        true,
+			}),
+
+			logicaltest.TestStep{
+				Operation: logical.UpdateOperation,
+				Path:      "sign/testing",
+				Data: map[string]interface{}{
+					"public_key":       publicKey2,
+					"cert_type":        "host",
+					"valid_principals": "host.example.com",
+				},
+				Check: func(resp *logical.Response) error {
+					key, err := signingKey(resp)
+					if err != nil {
+						return err
+					}
+					if string(ssh.MarshalAuthorizedKey(key)) != hostCAPublicKey {
+						return fmt.Errorf("expected host certificate to be signed by the host CA")
+					}
+					return nil
+				},
+			},
+
+			logicaltest.TestStep{
+				Operation: logical.UpdateOperation,
+				Path:      "sign/testing",
+				Data: map[string]interface{}{
+					"public_key":       publicKey2,
+					"valid_principals": "admin",
+				},
+				Check: func(resp *logical.Response) error {
+					key, err := signingKey(resp)
+					if err != nil {
+						return err
+					}
+					publicSigningKey, err := getSigningPublicKey()
+					if err != nil {
+						return err
+					}
+					if !reflect.DeepEqual(key, publicSigningKey) {
+						return fmt.Errorf("expected user certificate to be signed by the default CA")
+					}
+					return nil
+				},
+			},
+
+			logicaltest.TestStep{
+				Operation: logical.DeleteOperation,
+				Path:      "config/host_ca",
+			},
+
+			logicaltest.TestStep{
+				Operation:       logical.ReadOperation,
+				Path:            "host_public_key",
+				Unauthenticated: true,
+				Check: func(resp *logical.Response) error {
+					if key := string(resp.Data["http_raw_body"].([]byte)); key != publicKey {
+						return fmt.Errorf("host_public_key incorrect. Expected %v, actual %v", publicKey, key)
+					}
+					return nil
+				},
+			},
+		},
+	}
+
+	logicaltest.Test(t, testCase)
+}
+
 func TestBackend_OptionsOverrideDefaults(t *testing.T) {
 	config := logical.TestBackendConfig()
 
@@ -22,6 +22,11 @@ const (
 	caPublicKeyStoragePathDeprecated  = "public_key"
 	caPrivateKeyStoragePath           = "config/ca_private_key"
 	caPrivateKeyStoragePathDeprecated = "config/ca_bundle"
+
+	hostCAPublicKey             = "host_ca_public_key"
+	hostCAPrivateKey            = "host_ca_private_key"
+	hostCAPublicKeyStoragePath  = "config/host_ca_public_key"
+	hostCAPrivateKeyStoragePath = "config/host_ca_private_key"
 )
 
 type keyStorageEntry struct {
@@ -31,26 +36,12 @@ type keyStorageEntry struct {
 func pathConfigCA(b *backend) *framework.Path {
 	return &framework.Path{
 		Pattern: "config/ca",
-		Fields: map[string]*framework.FieldSchema{
-			"private_key": &framework.FieldSchema{
-				Type:        framework.TypeString,
-				Description: `Private half of the SSH key that will be used to sign certificates.`,
-			},
-			"public_key": &framework.FieldSchema{
-				Type:        framework.TypeString,
-				Description: `Public half of the SSH key that will be used to sign certificates.`,
-			},
-			"generate_signing_key": &framework.FieldSchema{
-				Type:        framework.TypeBool,
-				Description: `Generate SSH key pair internally rather than use the private_key and public_key fields.`,
-				Default:     true,
-			},
-		},
+		Fields:  configCAFields(),
 
 		Callbacks: map[logical.Operation]framework.OperationFunc{
-			logical.UpdateOperation: b.pathConfigCAUpdate,
+			logical.UpdateOperation: b.pathConfigCAUpdate(caPublicKey, caPrivateKey),
 			logical.DeleteOperation: b.pathConfigCADelete,
-			logical.ReadOperation:   b.pathConfigCARead,
+			logical.ReadOperation:   b.pathConfigCARead(caPublicKey),
 		},
 
 		HelpSynopsis: `Set the SSH private key used for signing certificates.`,
@@ -63,23 +54,65 @@ Read operations will return the public key, if already stored/generated.`,
 	}
 }
 
-func (b *backend) pathConfigCARead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-	publicKeyEntry, err := caKey(ctx, req.Storage, caPublicKey)
-	if err != nil {
-		return nil, errwrap.Wrapf("failed to read CA public key: {{err}}", err)
-	}
+func pathConfigHostCA(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "config/host_ca",
+		Fields:  configCAFields(),
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathConfigCAUpdate(hostCAPublicKey, hostCAPrivateKey),
+			logical.DeleteOperation: b.pathConfigHostCADelete,
+			logical.ReadOperation:   b.pathConfigCARead(hostCAPublicKey),
+		},
+
+		HelpSynopsis: `Set the SSH private key used for signing host certificates.`,
+		HelpDescription: `This sets a separate CA used to sign host certificates. If it is not
+configured, host certificates are signed by the CA configured at "config/ca".
+The fields must be in the standard private and public SSH format.
+
+For security reasons, the private key cannot be retrieved later.
 
-	if publicKeyEntry == nil {
-		return logical.ErrorResponse("keys haven't been configured yet"), nil
+Read operations will return the public key, if already stored/generated.`,
 	}
+}
 
-	response := &logical.Response{
-		Data: map[string]interface{}{
-			"public_key": publicKeyEntry.Key,
+func configCAFields() map[string]*framework.FieldSchema {
+	return map[string]*framework.FieldSchema{
+		"private_key": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: `Private half of the SSH key that will be used to sign certificates.`,
+		},
+		"public_key": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: `Public half of the SSH key that will be used to sign certificates.`,
+		},
+		"generate_signing_key": &framework.FieldSchema{
+			Type:        framework.TypeBool,
+			Description: `Generate SSH key pair internally rather than use the private_key and public_key fields.`,
+			Default:     true,
 		},
 	}
+}
+
+func (b *backend) pathConfigCARead(publicKeyType string) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		publicKeyEntry, err := caKey(ctx, req.Storage, publicKeyType)
+		if err != nil {
+			return nil, errwrap.Wrapf("failed to read CA public key: {{err}}", err)
+		}
+
+		if publicKeyEntry == nil {
+			return logical.ErrorResponse("keys haven't been configured yet"), nil
+		}
 
-	return response, nil
+		response := &logical.Response{
+			Data: map[string]interface{}{
+				"public_key": publicKeyEntry.Key,
+			},
+		}
+
+		return response, nil
+	}
 }
 
 func (b *backend) pathConfigCADelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
@@ -92,17 +125,37 @@ func (b *backend) pathConfigCADelete(ctx context.Context, req *logical.Request,
 	return nil, nil
 }
 
-func caKey(ctx context.Context, storage logical.Storage, keyType string) (*keyStorageEntry, error) {
-	var path, deprecatedPath string
+func (b *backend) pathConfigHostCADelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, hostCAPrivateKeyStoragePath); err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Delete(ctx, hostCAPublicKeyStoragePath); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// caKeyStoragePaths returns the storage path of a CA key, and the path it
+// was stored at by older versions, if any
+func caKeyStoragePaths(keyType string) (string, string, error) {
 	switch keyType {
 	case caPrivateKey:
-		path = caPrivateKeyStoragePath
-		deprecatedPath = caPrivateKeyStoragePathDeprecated
+		return caPrivateKeyStoragePath, caPrivateKeyStoragePathDeprecated, nil
 	case caPublicKey:
-		path = caPublicKeyStoragePath
-		deprecatedPath = caPublicKeyStoragePathDeprecated
+		return caPublicKeyStoragePath, caPublicKeyStoragePathDeprecated, nil
+	case hostCAPrivateKey:
+		return hostCAPrivateKeyStoragePath, "", nil
+	case hostCAPublicKey:
+		return hostCAPublicKeyStoragePath, "", nil
 	default:
-		return nil, fmt.Errorf("unrecognized key type %q", keyType)
+		return "", "", fmt.Errorf("unrecognized key type %q", keyType)
+	}
+}
+
+func caKey(ctx context.Context, storage logical.Storage, keyType string) (*keyStorageEntry, error) {
+	path, deprecatedPath, err := caKeyStoragePaths(keyType)
+	if err != nil {
+		return nil, err
 	}
 
 	entry, err := storage.Get(ctx, path)
@@ -110,7 +163,7 @@ func caKey(ctx context.Context, storage logical.Storage, keyType string) (*keySt
 		return nil, errwrap.Wrapf(fmt.Sprintf("failed to read CA key of type %q: {{err}}", keyType), err)
 	}
 
-	if entry == nil {
+	if entry == nil && deprecatedPath != "" {
 		// If the entry is not found, look at an older path. If found, upgrade
 		// it.
 		entry, err = storage.Get(ctx, deprecatedPath)
@@ -144,8 +197,22 @@ func caKey(ctx context.Context, storage logical.Storage, keyType string) (*keySt
 	return &keyEntry, nil
 }
 
-func (b *backend) pathConfigCAUpdate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-	var err error
+func (b *backend) pathConfigCAUpdate(publicKeyType, privateKeyType string) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		return b.updateCAKeys(ctx, req, data, publicKeyType, privateKeyType)
+	}
+}
+
+func (b *backend) updateCAKeys(ctx context.Context, req *logical.Request, data *framework.FieldData, publicKeyType, privateKeyType string) (*logical.Response, error) {
+	publicKeyStoragePath, _, err := caKeyStoragePaths(publicKeyType)
+	if err != nil {
+		return nil, err
+	}
+	privateKeyStoragePath, _, err := caKeyStoragePaths(privateKeyType)
+	if err != nil {
+		return nil, err
+	}
+
 	publicKey := data.Get("public_key").(string)
 	privateKey := data.Get("private_key").(string)
 
@@ -201,12 +268,12 @@ func (b *backend) pathConfigCAUpdate(ctx context.Context, req *logical.Request,
 		return nil, fmt.Errorf("failed to generate or parse the keys")
 	}
 
-	publicKeyEntry, err := caKey(ctx, req.Storage, caPublicKey)
+	publicKeyEntry, err := caKey(ctx, req.Storage, publicKeyType)
 	if err != nil {
 		return nil, errwrap.Wrapf("failed to read CA public key: {{err}}", err)
 	}
 
-	privateKeyEntry, err := caKey(ctx, req.Storage, caPrivateKey)
+	privateKeyEntry, err := caKey(ctx, req.Storage, privateKeyType)
 	if err != nil {
 		return nil, errwrap.Wrapf("failed to read CA private key: {{err}}", err)
 	}
@@ -215,7 +282,7 @@ func (b *backend) pathConfigCAUpdate(ctx context.Context, req *logical.Request,
 		return logical.ErrorResponse("keys are already configured; delete them before reconfiguring"), nil
 	}
 
-	entry, err := logical.StorageEntryJSON(caPublicKeyStoragePath, &keyStorageEntry{
+	entry, err := logical.StorageEntryJSON(publicKeyStoragePath, &keyStorageEntry{
 		Key: publicKey,
 	})
 	if err != nil {
@@ -228,7 +295,7 @@ func (b *backend) pathConfigCAUpdate(ctx context.Context, req *logical.Request,
 		return nil, err
 	}
 
-	entry, err = logical.StorageEntryJSON(caPrivateKeyStoragePath, &keyStorageEntry{
+	entry, err = logical.StorageEntryJSON(privateKeyStoragePath, &keyStorageEntry{
 		Key: privateKey,
 	})
 	if err != nil {
@@ -244,7 +311,7 @@ func (b *backend) pathConfigCAUpdate(ctx context.Context, req *logical.Request,
 
 		// If storing private key fails, the corresponding public key should be
 		// removed
-		if delErr := req.Storage.Delete(ctx, caPublicKeyStoragePath); delErr != nil {
+		if delErr := req.Storage.Delete(ctx, publicKeyStoragePath); delErr != nil {
 			mErr = multierror.Append(mErr, errwrap.Wrapf("failed to cleanup CA public key: {{err}}", delErr))
 			return nil, mErr
 		}
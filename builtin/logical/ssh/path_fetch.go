@@ -20,22 +20,58 @@ func pathFetchPublicKey(b *backend) *framework.Path {
 	}
 }
 
+func pathFetchHostPublicKey(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: `host_public_key`,
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.pathFetchHostPublicKey,
+		},
+
+		HelpSynopsis: `Retrieve the public key of the CA signing host certificates.`,
+		HelpDescription: `This allows the public key used to verify host certificates to be fetched,
+for example to add it to known_hosts as a @cert-authority. This is the key
+configured at "config/host_ca", or the key configured at "config/ca" if no
+separate host CA is configured.`,
+	}
+}
+
 func (b *backend) pathFetchPublicKey(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 	publicKeyEntry, err := caKey(ctx, req.Storage, caPublicKey)
 	if err != nil {
 		return nil, err
 	}
+
+	return publicKeyResponse(publicKeyEntry), nil
+}
+
+func (b *backend) pathFetchHostPublicKey(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	publicKeyEntry, err := caKey(ctx, req.Storage, hostCAPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if publicKeyEntry == nil || publicKeyEntry.Key == "" {
+		publicKeyEntry, err = caKey(ctx, req.Storage, caPublicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return publicKeyResponse(publicKeyEntry), nil
+}
+
+// publicKeyResponse returns the public key as a plain text response, suitable
+// for use in known_hosts and TrustedUserCAKeys files
+func publicKeyResponse(publicKeyEntry *keyStorageEntry) *logical.Response {
 	if publicKeyEntry == nil || publicKeyEntry.Key == "" {
-		return nil, nil
+		return nil
 	}
 
-	response := &logical.Response{
+	return &logical.Response{
 		Data: map[string]interface{}{
 			logical.HTTPContentType: "text/plain",
 			logical.HTTPRawBody:     []byte(publicKeyEntry.Key),
 			logical.HTTPStatusCode:  200,
 		},
 	}
-
-	return response, nil
 }
@@ -159,9 +159,19 @@ func (b *backend) pathSignCertificate(ctx context.Context, req *logical.Request,
 		return logical.ErrorResponse(err.Error()), nil
 	}
 
-	privateKeyEntry, err := caKey(ctx, req.Storage, caPrivateKey)
-	if err != nil {
-		return nil, errwrap.Wrapf("failed to read CA private key: {{err}}", err)
+	// Host certificates are signed by the host CA if one is configured
+	var privateKeyEntry *keyStorageEntry
+	if certificateType == ssh.HostCert {
+		privateKeyEntry, err = caKey(ctx, req.Storage, hostCAPrivateKey)
+		if err != nil {
+			return nil, errwrap.Wrapf("failed to read host CA private key: {{err}}", err)
+		}
+	}
+	if privateKeyEntry == nil || privateKeyEntry.Key == "" {
+		privateKeyEntry, err = caKey(ctx, req.Storage, caPrivateKey)
+		if err != nil {
+			return nil, errwrap.Wrapf("failed to read CA private key: {{err}}", err)
+		}
 	}
 	if privateKeyEntry == nil || privateKeyEntry.Key == "" {
 		return nil, fmt.Errorf("failed to read CA private key")
@@ -0,0 +1,110 @@
+package transform
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/consts"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	// builtinPrefix prefixes the names of the alphabets and templates that
+	// ship with the backend, which cannot be modified
+	builtinPrefix = "builtin/"
+
+	// tidyInterval is how often expired tokens are removed
+	tidyInterval = time.Hour
+)
+
+func Factory(ctx context.Context, conf *logical.BackendConfig) (logical.Backend, error) {
+	b := Backend()
+	if err := b.Setup(ctx, conf); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func Backend() *backend {
+	var b backend
+	b.Backend = &framework.Backend{
+		Help: strings.TrimSpace(backendHelp),
+
+		PathsSpecial: &logical.Paths{
+			SealWrapStorage: []string{
+				transformationPath,
+			},
+		},
+
+		Paths: []*framework.Path{
+			pathListAlphabets(&b),
+			pathAlphabets(&b),
+			pathListTemplates(&b),
+			pathTemplates(&b),
+			pathListTransformations(&b),
+			pathTransformations(&b),
+			pathListRoles(&b),
+			pathRoles(&b),
+			pathEncode(&b),
+			pathDecode(&b),
+			pathTidy(&b),
+		},
+
+		PeriodicFunc: b.periodicFunc,
+		BackendType:  logical.TypeLogical,
+	}
+
+	b.tokenLocks = locksutil.CreateLocks()
+
+	return &b
+}
+
+type backend struct {
+	*framework.Backend
+
+	// tokenLocks guard the token stores of tokenization transformations
+	// against concurrent tidy operations
+	tokenLocks []*locksutil.LockEntry
+
+	tidyLock sync.Mutex
+	lastTidy time.Time
+}
+
+// periodicFunc removes expired tokens from the token stores
+func (b *backend) periodicFunc(ctx context.Context, req *logical.Request) error {
+	if !b.System().LocalMount() && b.System().ReplicationState().HasState(consts.ReplicationPerformanceSecondary|consts.ReplicationPerformanceStandby) {
+		return nil
+	}
+
+	b.tidyLock.Lock()
+	defer b.tidyLock.Unlock()
+
+	if time.Since(b.lastTidy) < tidyInterval {
+		return nil
+	}
+	if err := b.tidyTokens(ctx, req.Storage); err != nil {
+		return err
+	}
+	b.lastTidy = time.Now()
+	return nil
+}
+
+// nameWithBuiltinRegex matches the name of an alphabet or template, which may
+// refer to a builtin entry
+func nameWithBuiltinRegex(name string) string {
+	return `(?P<` + name + `>(` + builtinPrefix + `)?\w(([\w-.]+)?\w)?)`
+}
+
+const backendHelp = `
+The transform backend transforms sensitive values, such as credit card
+numbers and social security numbers, without storing them.
+
+Format preserving encryption transformations encrypt values to ciphertext of
+the same format, masking transformations replace characters of values, and
+tokenization transformations replace values with random tokens that can be
+exchanged back for the original value.
+`
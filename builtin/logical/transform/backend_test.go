@@ -0,0 +1,522 @@
+package transform
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func createBackendWithStorage(t *testing.T) (*backend, logical.Storage) {
+	config := logical.TestBackendConfig()
+	config.StorageView = &logical.InmemStorage{}
+
+	b := Backend()
+	err := b.Setup(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, config.StorageView
+}
+
+func TestBackend_Builtins(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	b, storage := createBackendWithStorage(t)
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "alphabet/builtin/numeric",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp.Data["alphabet"] != "0123456789" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "template/builtin/creditcardnumber",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp.Data["alphabet"] != "builtin/numeric" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "alphabet/builtin/numeric",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"alphabet": "01",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.DeleteOperation,
+		Path:      "template/builtin/creditcardnumber",
+		Storage:   storage,
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+
+	// Custom entries are listed alongside the builtin ones
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "alphabet/hex",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"alphabet": "0123456789abcdef",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ListOperation,
+		Path:      "alphabet/",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if keys := resp.Data["keys"].([]string); len(keys) != len(builtinAlphabets)+1 {
+		t.Fatalf("bad: %#v", keys)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "alphabet/dup",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"alphabet": "0120",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+	for _, data := range []map[string]interface{}{
+		{"pattern": `\d+`, "alphabet": "hex"},
+		{"pattern": `(\d+`, "alphabet": "hex"},
+		{"pattern": `(\d+)`, "alphabet": "missing"},
+	} {
+		resp, err = b.HandleRequest(context.Background(), &logical.Request{
+			Operation: logical.CreateOperation,
+			Path:      "template/bad",
+			Storage:   storage,
+			Data:      data,
+		})
+		if err == nil && (resp == nil || !resp.IsError()) {
+			t.Fatalf("expected error, resp: %#v", resp)
+		}
+	}
+}
+
+func TestBackend_FPE(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	b, storage := createBackendWithStorage(t)
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "transformation/ccn",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"type":          "fpe",
+			"template":      "builtin/creditcardnumber",
+			"allowed_roles": "payments",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "transformation/ccn-generated",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"type":          "fpe",
+			"template":      "builtin/creditcardnumber",
+			"tweak_source":  "generated",
+			"allowed_roles": "*",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "role/payments",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformations": "ccn,ccn-generated",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.ReadOperation,
+		Path:      "transformation/ccn",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if _, ok := resp.Data["key"]; ok {
+		t.Fatal("expected key to be omitted")
+	}
+
+	ccn := "4111-1111-1111-1111"
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "encode/payments",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformation": "ccn",
+			"value":          ccn,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	data := resp.Data
+	encoded := data["encoded_value"].(string)
+	if encoded == ccn || !regexp.MustCompile(`^\d{4}-\d{4}-\d{4}-\d{4}$`).MatchString(encoded) {
+		t.Fatalf("bad encoded value: %q", encoded)
+	}
+
+	// Encoding is deterministic with the internal tweak
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "encode/payments",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformation": "ccn",
+			"value":          ccn,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	data = resp.Data
+	if data["encoded_value"] != encoded {
+		t.Fatalf("expected %q, got %q", encoded, data["encoded_value"])
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "decode/payments",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformation": "ccn",
+			"value":          encoded,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	decoded := resp.Data["decoded_value"].(string)
+	if decoded != ccn {
+		t.Fatalf("expected %q, got %q", ccn, decoded)
+	}
+
+	// Generated tweaks must be supplied to decode
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "encode/payments",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformation": "ccn-generated",
+			"value":          ccn,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	data = resp.Data
+	tweak := data["tweak"].(string)
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "decode/payments",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformation": "ccn-generated",
+			"value":          data["encoded_value"],
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "decode/payments",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformation": "ccn-generated",
+			"value":          data["encoded_value"],
+			"tweak":          tweak,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	decoded = resp.Data["decoded_value"].(string)
+	if decoded != ccn {
+		t.Fatalf("expected %q, got %q", ccn, decoded)
+	}
+
+	// Values must match the template
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "encode/payments",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformation": "ccn",
+			"value":          "4111-1111",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+
+	// Roles may only use transformations that allow them
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "role/other",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformations": "ccn",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "encode/other",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"value": ccn,
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+
+	// The type of a transformation cannot change
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "transformation/ccn",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"type": "masking",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+}
+
+func TestBackend_Masking(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	b, storage := createBackendWithStorage(t)
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "transformation/ssn",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"type":              "masking",
+			"template":          "builtin/socialsecuritynumber",
+			"masking_character": "#",
+			"allowed_roles":     "hr",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "role/hr",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformations": "ssn",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "encode/hr",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"value": "123-45-6789",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	data := resp.Data
+	if data["encoded_value"] != "###-##-####" {
+		t.Fatalf("bad: %#v", data)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "decode/hr",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"value": "###-##-####",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+}
+
+func TestBackend_Tokenization(t *testing.T) {
+	var resp *logical.Response
+	var err error
+	b, storage := createBackendWithStorage(t)
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "transformation/tokens",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"type":          "tokenization",
+			"allowed_roles": "app",
+			"max_ttl":       "1h",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "role/app",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"transformations": "tokens",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+
+	value := "sensitive value"
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "encode/app",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"value": value,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	token := resp.Data["encoded_value"].(string)
+	if token == value {
+		t.Fatal("expected value to be tokenized")
+	}
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "decode/app",
+		Storage:   storage,
+		Data:      map[string]interface{}{"value": token},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	if resp.Data["decoded_value"] != value {
+		t.Fatalf("expected %q, got %q", value, resp.Data["decoded_value"])
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "decode/app",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"value": "unknown",
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+
+	// Expired tokens cannot be decoded and are removed by tidy
+	ctx := context.Background()
+	tr, err := b.transformation(ctx, storage, "tokens")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk, err := newTokenizer("tokens", tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := tk.tokenize(ctx, storage, value, time.Nanosecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "decode/app",
+		Storage:   storage,
+		Data: map[string]interface{}{
+			"value": expired,
+		},
+	})
+	if err == nil && (resp == nil || !resp.IsError()) {
+		t.Fatalf("expected error, resp: %#v", resp)
+	}
+
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "tidy",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	ids, err := storage.List(ctx, tokenPath+"tokens/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 {
+		t.Fatalf("expected 1 token, got %d", len(ids))
+	}
+
+	// Deleting the transformation deletes its tokens
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Operation: logical.DeleteOperation,
+		Path:      "transformation/tokens",
+		Storage:   storage,
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: err: %v\nresp: %#v", err, resp)
+	}
+	ids, err = storage.List(ctx, tokenPath+"tokens/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 0 {
+		t.Fatalf("expected no tokens, got %d", len(ids))
+	}
+}
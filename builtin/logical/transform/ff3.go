@@ -0,0 +1,217 @@
+package transform
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+const (
+	// ff3TweakSize is the size in bytes of FF3-1 tweaks
+	ff3TweakSize = 7
+
+	// ff3Rounds is the number of Feistel rounds
+	ff3Rounds = 8
+)
+
+// ff3 implements the FF3-1 format-preserving encryption mode of NIST SP
+// 800-38G Revision 1 over numeral strings of the given radix.
+type ff3 struct {
+	block  cipher.Block
+	radix  int
+	minLen int
+	maxLen int
+}
+
+func newFF3(key []byte, radix int) (*ff3, error) {
+	if radix < 2 || radix > 1<<16 {
+		return nil, fmt.Errorf("radix must be between 2 and 65536, got %d", radix)
+	}
+
+	// The key is used byte-reversed
+	block, err := aes.NewCipher(reverseBytes(key))
+	if err != nil {
+		return nil, err
+	}
+
+	// The domain must contain at least a million values, and the numeral
+	// string of each half must fit in 96 bits
+	minLen := int(math.Ceil(math.Log(1000000) / math.Log(float64(radix))))
+	if minLen < 2 {
+		minLen = 2
+	}
+	maxLen := 2 * int(math.Floor(96/math.Log2(float64(radix))))
+
+	return &ff3{
+		block:  block,
+		radix:  radix,
+		minLen: minLen,
+		maxLen: maxLen,
+	}, nil
+}
+
+// splitTweak splits a 56-bit FF3-1 tweak into its left and right halves
+func splitTweak(tweak []byte) ([]byte, []byte, error) {
+	if len(tweak) != ff3TweakSize {
+		return nil, nil, fmt.Errorf("tweak must be %d bytes, got %d", ff3TweakSize, len(tweak))
+	}
+
+	left := []byte{tweak[0], tweak[1], tweak[2], tweak[3] & 0xf0}
+	right := []byte{tweak[4], tweak[5], tweak[6], (tweak[3] & 0x0f) << 4}
+	return left, right, nil
+}
+
+func (f *ff3) validate(numerals []uint16) error {
+	if len(numerals) < f.minLen || len(numerals) > f.maxLen {
+		return fmt.Errorf("value must have between %d and %d characters to transform, got %d", f.minLen, f.maxLen, len(numerals))
+	}
+	for _, n := range numerals {
+		if int(n) >= f.radix {
+			return fmt.Errorf("numeral %d out of range for radix %d", n, f.radix)
+		}
+	}
+	return nil
+}
+
+// Encrypt encrypts a numeral string with a 7 byte tweak
+func (f *ff3) Encrypt(numerals []uint16, tweak []byte) ([]uint16, error) {
+	left, right, err := splitTweak(tweak)
+	if err != nil {
+		return nil, err
+	}
+	return f.encrypt(numerals, left, right)
+}
+
+// Decrypt decrypts a numeral string with a 7 byte tweak
+func (f *ff3) Decrypt(numerals []uint16, tweak []byte) ([]uint16, error) {
+	left, right, err := splitTweak(tweak)
+	if err != nil {
+		return nil, err
+	}
+	return f.decrypt(numerals, left, right)
+}
+
+func (f *ff3) encrypt(numerals []uint16, tweakLeft, tweakRight []byte) ([]uint16, error) {
+	if err := f.validate(numerals); err != nil {
+		return nil, err
+	}
+
+	n := len(numerals)
+	u := (n + 1) / 2
+	v := n - u
+
+	a := append([]uint16(nil), numerals[:u]...)
+	b := append([]uint16(nil), numerals[u:]...)
+
+	for i := 0; i < ff3Rounds; i++ {
+		m, w := u, tweakRight
+		if i%2 == 1 {
+			m, w = v, tweakLeft
+		}
+
+		y := f.roundFunction(w, i, b)
+
+		c := f.num(reverse(a))
+		c.Add(c, y)
+		c.Mod(c, f.modulus(m))
+
+		a, b = b, reverse(f.str(c, m))
+	}
+
+	return append(a, b...), nil
+}
+
+func (f *ff3) decrypt(numerals []uint16, tweakLeft, tweakRight []byte) ([]uint16, error) {
+	if err := f.validate(numerals); err != nil {
+		return nil, err
+	}
+
+	n := len(numerals)
+	u := (n + 1) / 2
+	v := n - u
+
+	a := append([]uint16(nil), numerals[:u]...)
+	b := append([]uint16(nil), numerals[u:]...)
+
+	for i := ff3Rounds - 1; i >= 0; i-- {
+		m, w := u, tweakRight
+		if i%2 == 1 {
+			m, w = v, tweakLeft
+		}
+
+		y := f.roundFunction(w, i, a)
+
+		c := f.num(reverse(b))
+		c.Sub(c, y)
+		c.Mod(c, f.modulus(m))
+
+		a, b = reverse(f.str(c, m)), a
+	}
+
+	return append(a, b...), nil
+}
+
+// roundFunction computes the pseudorandom value of a Feistel round from the
+// tweak half, the round number and the unmodified half of the input
+func (f *ff3) roundFunction(w []byte, round int, half []uint16) *big.Int {
+	var p [aes.BlockSize]byte
+	copy(p[:4], w)
+	p[3] ^= byte(round)
+
+	numBytes := f.num(reverse(half)).Bytes()
+	copy(p[aes.BlockSize-len(numBytes):], numBytes)
+
+	var s [aes.BlockSize]byte
+	f.block.Encrypt(s[:], reverseBytes(p[:]))
+
+	return new(big.Int).SetBytes(reverseBytes(s[:]))
+}
+
+func (f *ff3) modulus(m int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(int64(f.radix)), big.NewInt(int64(m)), nil)
+}
+
+// num interprets a numeral string as a number, most significant numeral
+// first
+func (f *ff3) num(numerals []uint16) *big.Int {
+	radix := big.NewInt(int64(f.radix))
+	x := new(big.Int)
+	for _, n := range numerals {
+		x.Mul(x, radix)
+		x.Add(x, big.NewInt(int64(n)))
+	}
+	return x
+}
+
+// str returns the m numeral representation of x, most significant numeral
+// first
+func (f *ff3) str(x *big.Int, m int) []uint16 {
+	radix := big.NewInt(int64(f.radix))
+	x = new(big.Int).Set(x)
+	mod := new(big.Int)
+
+	numerals := make([]uint16, m)
+	for i := m - 1; i >= 0; i-- {
+		x.DivMod(x, radix, mod)
+		numerals[i] = uint16(mod.Int64())
+	}
+	return numerals
+}
+
+func reverse(numerals []uint16) []uint16 {
+	reversed := make([]uint16, len(numerals))
+	for i, n := range numerals {
+		reversed[len(numerals)-1-i] = n
+	}
+	return reversed
+}
+
+func reverseBytes(b []byte) []byte {
+	reversed := make([]byte, len(b))
+	for i, c := range b {
+		reversed[len(b)-1-i] = c
+	}
+	return reversed
+}
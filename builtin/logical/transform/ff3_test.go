@@ -0,0 +1,120 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+const testDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+func toNumerals(t *testing.T, s string) []uint16 {
+	t.Helper()
+	numerals := make([]uint16, len(s))
+	for i, c := range s {
+		idx := strings.IndexRune(testDigits, c)
+		if idx < 0 {
+			t.Fatalf("invalid numeral %q", c)
+		}
+		numerals[i] = uint16(idx)
+	}
+	return numerals
+}
+
+func fromNumerals(numerals []uint16) string {
+	var b strings.Builder
+	for _, n := range numerals {
+		b.WriteByte(testDigits[n])
+	}
+	return b.String()
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// FF3-1 differs from FF3 only in how the tweak is split, so the Feistel rounds
+// are checked against the NIST FF3 samples, which use a 64 bit tweak split
+// into two 32 bit halves.
+func TestFF3_NISTSamples(t *testing.T) {
+	cases := []struct {
+		key        string
+		tweak      string
+		radix      int
+		plaintext  string
+		ciphertext string
+	}{
+		{"EF4359D8D580AA4F7F036D6F04FC6A94", "D8E7920AFA330A73", 10, "890121234567890000", "750918814058654607"},
+		{"EF4359D8D580AA4F7F036D6F04FC6A94", "9A768A92F60E12D8", 10, "890121234567890000", "018989839189395384"},
+		{"EF4359D8D580AA4F7F036D6F04FC6A94", "D8E7920AFA330A73", 10, "89012123456789000000789000000", "48598367162252569629397416226"},
+		{"EF4359D8D580AA4F7F036D6F04FC6A94", "0000000000000000", 10, "89012123456789000000789000000", "34695224821734535122613701434"},
+		{"EF4359D8D580AA4F7F036D6F04FC6A94", "9A768A92F60E12D8", 26, "0123456789abcdefghi", "g2pk40i992fn20cjakb"},
+	}
+
+	for _, tc := range cases {
+		f, err := newFF3(mustDecodeHex(t, tc.key), tc.radix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tweak := mustDecodeHex(t, tc.tweak)
+
+		ciphertext, err := f.encrypt(toNumerals(t, tc.plaintext), tweak[:4], tweak[4:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fromNumerals(ciphertext); got != tc.ciphertext {
+			t.Fatalf("encrypt %s: expected %s, got %s", tc.plaintext, tc.ciphertext, got)
+		}
+
+		plaintext, err := f.decrypt(ciphertext, tweak[:4], tweak[4:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fromNumerals(plaintext); got != tc.plaintext {
+			t.Fatalf("decrypt %s: expected %s, got %s", tc.ciphertext, tc.plaintext, got)
+		}
+	}
+}
+
+func TestFF3_RoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{0x2b}, 32)
+	tweak := mustDecodeHex(t, "D8E7920AFA330A")
+
+	f, err := newFF3(key, 36)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, plaintext := range []string{"1234", "0123456789abcdefghijklmnopqrstuvwxyz", "zzzzzzzzzzzzzzzzzzz"} {
+		ciphertext, err := f.Encrypt(toNumerals(t, plaintext), tweak)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fromNumerals(ciphertext) == plaintext {
+			t.Fatalf("expected %s to be transformed", plaintext)
+		}
+		decrypted, err := f.Decrypt(ciphertext, tweak)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fromNumerals(decrypted); got != plaintext {
+			t.Fatalf("expected %s, got %s", plaintext, got)
+		}
+	}
+
+	// Values outside the supported lengths are rejected
+	for _, plaintext := range []string{"123", strings.Repeat("1", f.maxLen+1)} {
+		if _, err := f.Encrypt(toNumerals(t, plaintext), tweak); err == nil {
+			t.Fatalf("expected error for %q", plaintext)
+		}
+	}
+	if _, err := f.Encrypt(toNumerals(t, "123456"), tweak[:6]); err == nil {
+		t.Fatal("expected error for short tweak")
+	}
+}
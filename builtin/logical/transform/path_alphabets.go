@@ -0,0 +1,231 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	alphabetPath = "alphabet/"
+
+	// minAlphabetSize is the smallest radix supported by FF3-1
+	minAlphabetSize = 2
+
+	// maxAlphabetSize is the largest radix supported by FF3-1
+	maxAlphabetSize = 1 << 16
+)
+
+// builtinAlphabets are the alphabets that ship with the backend, by name
+var builtinAlphabets = map[string]string{
+	builtinPrefix + "numeric":           "0123456789",
+	builtinPrefix + "alphalower":        "abcdefghijklmnopqrstuvwxyz",
+	builtinPrefix + "alphaupper":        "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	builtinPrefix + "alphanumericlower": "0123456789abcdefghijklmnopqrstuvwxyz",
+	builtinPrefix + "alphanumericupper": "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	builtinPrefix + "alphanumeric":      "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ",
+}
+
+// alphabetEntry is the set of characters that values may be transformed
+// into
+type alphabetEntry struct {
+	Alphabet string `json:"alphabet"`
+}
+
+// numerals maps each character of s to its index in the alphabet
+func (a *alphabetEntry) numerals(s string) ([]uint16, error) {
+	runes := []rune(a.Alphabet)
+	indexes := make(map[rune]uint16, len(runes))
+	for i, r := range runes {
+		indexes[r] = uint16(i)
+	}
+
+	numerals := make([]uint16, 0, len(s))
+	for _, r := range s {
+		idx, ok := indexes[r]
+		if !ok {
+			return nil, fmt.Errorf("character %q is not in the alphabet", r)
+		}
+		numerals = append(numerals, idx)
+	}
+	return numerals, nil
+}
+
+// characters maps numerals back to the characters of the alphabet
+func (a *alphabetEntry) characters(numerals []uint16) string {
+	runes := []rune(a.Alphabet)
+
+	var b strings.Builder
+	for _, n := range numerals {
+		b.WriteRune(runes[n])
+	}
+	return b.String()
+}
+
+func validateAlphabet(alphabet string) error {
+	runes := []rune(alphabet)
+	if len(runes) < minAlphabetSize || len(runes) > maxAlphabetSize {
+		return fmt.Errorf("alphabet must contain between %d and %d characters", minAlphabetSize, maxAlphabetSize)
+	}
+
+	seen := make(map[rune]bool, len(runes))
+	for _, r := range runes {
+		if seen[r] {
+			return fmt.Errorf("alphabet contains duplicate character %q", r)
+		}
+		seen[r] = true
+	}
+	return nil
+}
+
+func pathListAlphabets(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: alphabetPath + "?$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathAlphabetList,
+		},
+
+		HelpSynopsis:    pathAlphabetsHelpSyn,
+		HelpDescription: pathAlphabetsHelpDesc,
+	}
+}
+
+func pathAlphabets(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: alphabetPath + nameWithBuiltinRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the alphabet",
+			},
+			"alphabet": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Characters of the alphabet. Each character may only appear once.",
+			},
+		},
+
+		ExistenceCheck: b.pathAlphabetExistenceCheck,
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathAlphabetRead,
+			logical.CreateOperation: b.pathAlphabetWrite,
+			logical.UpdateOperation: b.pathAlphabetWrite,
+			logical.DeleteOperation: b.pathAlphabetDelete,
+		},
+
+		HelpSynopsis:    pathAlphabetsHelpSyn,
+		HelpDescription: pathAlphabetsHelpDesc,
+	}
+}
+
+func (b *backend) alphabet(ctx context.Context, s logical.Storage, name string) (*alphabetEntry, error) {
+	if alphabet, ok := builtinAlphabets[name]; ok {
+		return &alphabetEntry{Alphabet: alphabet}, nil
+	}
+
+	entry, err := s.Get(ctx, alphabetPath+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	alphabet := new(alphabetEntry)
+	if err := entry.DecodeJSON(alphabet); err != nil {
+		return nil, err
+	}
+	return alphabet, nil
+}
+
+func (b *backend) pathAlphabetExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+	alphabet, err := b.alphabet(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return false, err
+	}
+	return alphabet != nil, nil
+}
+
+func (b *backend) pathAlphabetList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	names, err := req.Storage.List(ctx, alphabetPath)
+	if err != nil {
+		return nil, err
+	}
+	for name := range builtinAlphabets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return logical.ListResponse(names), nil
+}
+
+func (b *backend) pathAlphabetRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	alphabet, err := b.alphabet(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if alphabet == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"alphabet": alphabet.Alphabet,
+		},
+	}, nil
+}
+
+func (b *backend) pathAlphabetWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+	if strings.HasPrefix(name, builtinPrefix) {
+		return logical.ErrorResponse("builtin alphabets cannot be modified"), nil
+	}
+
+	alphabet := &alphabetEntry{
+		Alphabet: data.Get("alphabet").(string),
+	}
+	if err := validateAlphabet(alphabet.Alphabet); err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	entry, err := logical.StorageEntryJSON(alphabetPath+name, alphabet)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathAlphabetDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+	if strings.HasPrefix(name, builtinPrefix) {
+		return logical.ErrorResponse("builtin alphabets cannot be deleted"), nil
+	}
+
+	if err := req.Storage.Delete(ctx, alphabetPath+name); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+const pathAlphabetsHelpSyn = `
+Manage the alphabets that values are transformed within.
+`
+
+const pathAlphabetsHelpDesc = `
+An alphabet is the set of characters that the transformed parts of a value
+are made of. Format preserving encryption maps characters of the alphabet to
+other characters of the same alphabet.
+
+The backend provides the builtin alphabets "builtin/numeric",
+"builtin/alphalower", "builtin/alphaupper", "builtin/alphanumericlower",
+"builtin/alphanumericupper" and "builtin/alphanumeric", which cannot be
+modified.
+`
@@ -0,0 +1,340 @@
+package transform
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/helper/strutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func transformFields() map[string]*framework.FieldSchema {
+	return map[string]*framework.FieldSchema{
+		"role_name": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: "Name of the role",
+		},
+		"value": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: "Value to transform.",
+		},
+		"transformation": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: "Name of the transformation. May be omitted if the role has a single transformation.",
+		},
+		"tweak": &framework.FieldSchema{
+			Type: framework.TypeString,
+			Description: `Base64 encoded 7 byte tweak, for fpe transformations with a tweak_source of
+"supplied", or "generated" when decoding.`,
+		},
+	}
+}
+
+func pathEncode(b *backend) *framework.Path {
+	fields := transformFields()
+	fields["ttl"] = &framework.FieldSchema{
+		Type: framework.TypeDurationSecond,
+		Description: `Lifetime of the token, for tokenization transformations. Defaults to the
+transformation's max_ttl.`,
+	}
+
+	return &framework.Path{
+		Pattern: "encode/" + framework.GenericNameRegex("role_name"),
+		Fields:  fields,
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathEncode,
+		},
+
+		HelpSynopsis:    pathEncodeHelpSyn,
+		HelpDescription: pathEncodeHelpDesc,
+	}
+}
+
+func pathDecode(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "decode/" + framework.GenericNameRegex("role_name"),
+		Fields:  transformFields(),
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathDecode,
+		},
+
+		HelpSynopsis:    pathDecodeHelpSyn,
+		HelpDescription: pathDecodeHelpDesc,
+	}
+}
+
+// roleTransformation returns the name and entry of the transformation
+// requested through a role, or an error response if the role may not use it
+func (b *backend) roleTransformation(ctx context.Context, s logical.Storage, data *framework.FieldData) (string, *transformationEntry, *logical.Response, error) {
+	roleName := data.Get("role_name").(string)
+	role, err := b.role(ctx, s, roleName)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if role == nil {
+		return "", nil, logical.ErrorResponse(fmt.Sprintf("role %q does not exist", roleName)), nil
+	}
+
+	name := data.Get("transformation").(string)
+	if name == "" {
+		if len(role.Transformations) != 1 {
+			return "", nil, logical.ErrorResponse("transformation is required"), nil
+		}
+		name = role.Transformations[0]
+	}
+	if !strutil.StrListContains(role.Transformations, name) {
+		return "", nil, logical.ErrorResponse(fmt.Sprintf("transformation %q is not allowed by role %q", name, roleName)), nil
+	}
+
+	transformation, err := b.transformation(ctx, s, name)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if transformation == nil {
+		return "", nil, logical.ErrorResponse(fmt.Sprintf("transformation %q does not exist", name)), nil
+	}
+	if !transformation.roleAllowed(roleName) {
+		return "", nil, logical.ErrorResponse(fmt.Sprintf("role %q is not allowed to use transformation %q", roleName, name)), nil
+	}
+
+	return name, transformation, nil, nil
+}
+
+// templateMatch matches the value against the template of an fpe or masking
+// transformation
+func (b *backend) templateMatch(ctx context.Context, s logical.Storage, transformation *transformationEntry, value string) (*templateMatch, *alphabetEntry, error) {
+	template, err := b.template(ctx, s, transformation.Template)
+	if err != nil {
+		return nil, nil, err
+	}
+	if template == nil {
+		return nil, nil, fmt.Errorf("template %q does not exist", transformation.Template)
+	}
+	alphabet, err := b.alphabet(ctx, s, template.Alphabet)
+	if err != nil {
+		return nil, nil, err
+	}
+	if alphabet == nil {
+		return nil, nil, fmt.Errorf("alphabet %q does not exist", template.Alphabet)
+	}
+
+	m, err := template.match(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, alphabet, nil
+}
+
+// fpe applies FF3-1 encryption or decryption to the parts of the value
+// matched by the transformation's template
+func (b *backend) fpe(ctx context.Context, s logical.Storage, transformation *transformationEntry, value string, tweak []byte, decrypt bool) (string, *logical.Response, error) {
+	m, alphabet, err := b.templateMatch(ctx, s, transformation, value)
+	if err != nil {
+		return "", logical.ErrorResponse(err.Error()), nil
+	}
+	numerals, err := alphabet.numerals(m.parts())
+	if err != nil {
+		return "", logical.ErrorResponse(err.Error()), nil
+	}
+
+	f, err := newFF3(transformation.Key, utf8.RuneCountInString(alphabet.Alphabet))
+	if err != nil {
+		return "", nil, err
+	}
+
+	if decrypt {
+		numerals, err = f.Decrypt(numerals, tweak)
+	} else {
+		numerals, err = f.Encrypt(numerals, tweak)
+	}
+	if err != nil {
+		return "", logical.ErrorResponse(err.Error()), nil
+	}
+
+	return m.replace(alphabet.characters(numerals)), nil, nil
+}
+
+// suppliedTweak decodes the tweak supplied with a request
+func suppliedTweak(data *framework.FieldData) ([]byte, error) {
+	raw := data.Get("tweak").(string)
+	if raw == "" {
+		return nil, fmt.Errorf("tweak is required")
+	}
+	tweak, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errwrap.Wrapf("error decoding tweak: {{err}}", err)
+	}
+	if len(tweak) != ff3TweakSize {
+		return nil, fmt.Errorf("tweak must be %d bytes", ff3TweakSize)
+	}
+	return tweak, nil
+}
+
+func (b *backend) pathEncode(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name, transformation, resp, err := b.roleTransformation(ctx, req.Storage, data)
+	if resp != nil || err != nil {
+		return resp, err
+	}
+
+	value := data.Get("value").(string)
+	if value == "" {
+		return logical.ErrorResponse("value is required"), nil
+	}
+
+	respData := make(map[string]interface{})
+
+	switch transformation.Type {
+	case typeFPE:
+		var tweak []byte
+		switch transformation.TweakSource {
+		case tweakSourceInternal:
+			tweak = transformation.Tweak
+		case tweakSourceSupplied:
+			tweak, err = suppliedTweak(data)
+			if err != nil {
+				return logical.ErrorResponse(err.Error()), nil
+			}
+		case tweakSourceGenerated:
+			tweak = make([]byte, ff3TweakSize)
+			if _, err := rand.Read(tweak); err != nil {
+				return nil, errwrap.Wrapf("error generating tweak: {{err}}", err)
+			}
+			respData["tweak"] = base64.StdEncoding.EncodeToString(tweak)
+		}
+
+		encoded, resp, err := b.fpe(ctx, req.Storage, transformation, value, tweak, false)
+		if resp != nil || err != nil {
+			return resp, err
+		}
+		respData["encoded_value"] = encoded
+
+	case typeMasking:
+		m, _, err := b.templateMatch(ctx, req.Storage, transformation, value)
+		if err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+		masked := strings.Repeat(transformation.MaskingCharacter, utf8.RuneCountInString(m.parts()))
+		respData["encoded_value"] = m.replace(masked)
+
+	case typeTokenization:
+		ttl := transformation.MaxTTL
+		if raw, ok := data.GetOk("ttl"); ok {
+			ttl = time.Duration(raw.(int)) * time.Second
+			if transformation.MaxTTL > 0 && (ttl == 0 || ttl > transformation.MaxTTL) {
+				ttl = transformation.MaxTTL
+			}
+		}
+
+		t, err := newTokenizer(name, transformation)
+		if err != nil {
+			return nil, err
+		}
+
+		lock := locksutil.LockForKey(b.tokenLocks, name)
+		lock.RLock()
+		token, err := t.tokenize(ctx, req.Storage, value, ttl)
+		lock.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		respData["encoded_value"] = token
+
+	default:
+		return nil, fmt.Errorf("unsupported transformation type %q", transformation.Type)
+	}
+
+	return &logical.Response{
+		Data: respData,
+	}, nil
+}
+
+func (b *backend) pathDecode(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name, transformation, resp, err := b.roleTransformation(ctx, req.Storage, data)
+	if resp != nil || err != nil {
+		return resp, err
+	}
+
+	value := data.Get("value").(string)
+	if value == "" {
+		return logical.ErrorResponse("value is required"), nil
+	}
+
+	var decoded string
+	switch transformation.Type {
+	case typeFPE:
+		tweak := transformation.Tweak
+		if transformation.TweakSource != tweakSourceInternal {
+			tweak, err = suppliedTweak(data)
+			if err != nil {
+				return logical.ErrorResponse(err.Error()), nil
+			}
+		}
+
+		decoded, resp, err = b.fpe(ctx, req.Storage, transformation, value, tweak, true)
+		if resp != nil || err != nil {
+			return resp, err
+		}
+
+	case typeMasking:
+		return logical.ErrorResponse("masked values cannot be decoded"), nil
+
+	case typeTokenization:
+		t, err := newTokenizer(name, transformation)
+		if err != nil {
+			return nil, err
+		}
+
+		lock := locksutil.LockForKey(b.tokenLocks, name)
+		lock.RLock()
+		decoded, err = t.detokenize(ctx, req.Storage, value)
+		lock.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		if decoded == "" {
+			return logical.ErrorResponse("token does not exist or has expired"), nil
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported transformation type %q", transformation.Type)
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"decoded_value": decoded,
+		},
+	}, nil
+}
+
+const pathEncodeHelpSyn = `
+Encode a value with a transformation.
+`
+
+const pathEncodeHelpDesc = `
+Encodes a value with one of the role's transformations. Format preserving
+encryption and masking only change the characters matched by the capture
+groups of the transformation's template. Tokenization returns a random token
+and stores the encrypted value until the token expires.
+
+For fpe transformations with a tweak_source of "generated", the generated
+tweak is returned and must be supplied to decode the value.
+`
+
+const pathDecodeHelpSyn = `
+Decode a value encoded with a transformation.
+`
+
+const pathDecodeHelpDesc = `
+Decodes a value encoded with one of the role's fpe or tokenization
+transformations. Masked values cannot be decoded.
+`
@@ -0,0 +1,300 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	templatePath = "template/"
+
+	templateTypeRegex = "regex"
+)
+
+// builtinTemplates are the templates that ship with the backend, by name
+var builtinTemplates = map[string]*templateEntry{
+	builtinPrefix + "creditcardnumber": &templateEntry{
+		Type:     templateTypeRegex,
+		Pattern:  `(\d{4})[- ]?(\d{4})[- ]?(\d{4})[- ]?(\d{4})`,
+		Alphabet: builtinPrefix + "numeric",
+	},
+	builtinPrefix + "socialsecuritynumber": &templateEntry{
+		Type:     templateTypeRegex,
+		Pattern:  `(\d{3})[- ]?(\d{2})[- ]?(\d{4})`,
+		Alphabet: builtinPrefix + "numeric",
+	},
+}
+
+// templateEntry describes the format of the values a transformation accepts.
+// The pattern must match the whole value, and only the characters matched by
+// its capture groups are transformed.
+type templateEntry struct {
+	Type     string `json:"type"`
+	Pattern  string `json:"pattern"`
+	Alphabet string `json:"alphabet"`
+}
+
+func (t *templateEntry) regexp() (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + t.Pattern + `)$`)
+}
+
+// templateMatch holds the parts of a value matched by the capture groups of
+// a template
+type templateMatch struct {
+	value   string
+	indexes [][2]int
+}
+
+// match returns the parts of value to transform. Capture groups nested within
+// other groups are ignored.
+func (t *templateEntry) match(value string) (*templateMatch, error) {
+	re, err := t.regexp()
+	if err != nil {
+		return nil, err
+	}
+
+	loc := re.FindStringSubmatchIndex(value)
+	if loc == nil {
+		return nil, fmt.Errorf("value does not match the template")
+	}
+
+	m := &templateMatch{value: value}
+	end := 0
+	for i := 2; i < len(loc); i += 2 {
+		if loc[i] < 0 || loc[i] < end {
+			continue
+		}
+		m.indexes = append(m.indexes, [2]int{loc[i], loc[i+1]})
+		end = loc[i+1]
+	}
+	return m, nil
+}
+
+// parts returns the concatenated characters of the capture groups
+func (m *templateMatch) parts() string {
+	var b strings.Builder
+	for _, idx := range m.indexes {
+		b.WriteString(m.value[idx[0]:idx[1]])
+	}
+	return b.String()
+}
+
+// replace returns the value with the characters of the capture groups
+// replaced, in order, by the characters of s, which must contain as many
+// characters as parts
+func (m *templateMatch) replace(s string) string {
+	runes := []rune(s)
+
+	var b strings.Builder
+	last := 0
+	for _, idx := range m.indexes {
+		b.WriteString(m.value[last:idx[0]])
+		n := len([]rune(m.value[idx[0]:idx[1]]))
+		b.WriteString(string(runes[:n]))
+		runes = runes[n:]
+		last = idx[1]
+	}
+	b.WriteString(m.value[last:])
+	return b.String()
+}
+
+func pathListTemplates(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: templatePath + "?$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathTemplateList,
+		},
+
+		HelpSynopsis:    pathTemplatesHelpSyn,
+		HelpDescription: pathTemplatesHelpDesc,
+	}
+}
+
+func pathTemplates(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: templatePath + nameWithBuiltinRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the template",
+			},
+			"type": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Default:     templateTypeRegex,
+				Description: `Type of the template. Only "regex" is supported.`,
+			},
+			"pattern": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `Regular expression that values must match in full. Only the characters
+matched by its capture groups are transformed.`,
+			},
+			"alphabet": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the alphabet of the characters matched by the capture groups.",
+			},
+		},
+
+		ExistenceCheck: b.pathTemplateExistenceCheck,
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathTemplateRead,
+			logical.CreateOperation: b.pathTemplateWrite,
+			logical.UpdateOperation: b.pathTemplateWrite,
+			logical.DeleteOperation: b.pathTemplateDelete,
+		},
+
+		HelpSynopsis:    pathTemplatesHelpSyn,
+		HelpDescription: pathTemplatesHelpDesc,
+	}
+}
+
+func (b *backend) template(ctx context.Context, s logical.Storage, name string) (*templateEntry, error) {
+	if template, ok := builtinTemplates[name]; ok {
+		return template, nil
+	}
+
+	entry, err := s.Get(ctx, templatePath+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	template := new(templateEntry)
+	if err := entry.DecodeJSON(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (b *backend) pathTemplateExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+	template, err := b.template(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return false, err
+	}
+	return template != nil, nil
+}
+
+func (b *backend) pathTemplateList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	names, err := req.Storage.List(ctx, templatePath)
+	if err != nil {
+		return nil, err
+	}
+	for name := range builtinTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return logical.ListResponse(names), nil
+}
+
+func (b *backend) pathTemplateRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	template, err := b.template(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"type":     template.Type,
+			"pattern":  template.Pattern,
+			"alphabet": template.Alphabet,
+		},
+	}, nil
+}
+
+func (b *backend) pathTemplateWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+	if strings.HasPrefix(name, builtinPrefix) {
+		return logical.ErrorResponse("builtin templates cannot be modified"), nil
+	}
+
+	template, err := b.template(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		template = new(templateEntry)
+	}
+
+	if _, ok := data.GetOk("type"); ok || template.Type == "" {
+		template.Type = data.Get("type").(string)
+	}
+	if raw, ok := data.GetOk("pattern"); ok {
+		template.Pattern = raw.(string)
+	}
+	if raw, ok := data.GetOk("alphabet"); ok {
+		template.Alphabet = raw.(string)
+	}
+
+	if template.Type != templateTypeRegex {
+		return logical.ErrorResponse(fmt.Sprintf("unsupported template type %q", template.Type)), nil
+	}
+	if template.Pattern == "" {
+		return logical.ErrorResponse("pattern is required"), nil
+	}
+	re, err := template.regexp()
+	if err != nil {
+		return logical.ErrorResponse(errwrap.Wrapf("invalid pattern: {{err}}", err).Error()), nil
+	}
+	if re.NumSubexp() == 0 {
+		return logical.ErrorResponse("pattern must contain at least one capture group"), nil
+	}
+	if template.Alphabet == "" {
+		return logical.ErrorResponse("alphabet is required"), nil
+	}
+	alphabet, err := b.alphabet(ctx, req.Storage, template.Alphabet)
+	if err != nil {
+		return nil, err
+	}
+	if alphabet == nil {
+		return logical.ErrorResponse(fmt.Sprintf("alphabet %q does not exist", template.Alphabet)), nil
+	}
+
+	entry, err := logical.StorageEntryJSON(templatePath+name, template)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathTemplateDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+	if strings.HasPrefix(name, builtinPrefix) {
+		return logical.ErrorResponse("builtin templates cannot be deleted"), nil
+	}
+
+	if err := req.Storage.Delete(ctx, templatePath+name); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+const pathTemplatesHelpSyn = `
+Manage the templates that describe the format of transformed values.
+`
+
+const pathTemplatesHelpDesc = `
+A template is a regular expression that values must match in full. The
+characters matched by its capture groups are transformed within the
+template's alphabet, while all other characters, such as separators, are
+left unchanged.
+
+The backend provides the builtin templates "builtin/creditcardnumber" and
+"builtin/socialsecuritynumber", which cannot be modified.
+`
@@ -0,0 +1,38 @@
+package transform
+
+import (
+	"context"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func pathTidy(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: "tidy$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.pathTidyWrite,
+		},
+
+		HelpSynopsis:    pathTidyHelpSyn,
+		HelpDescription: pathTidyHelpDesc,
+	}
+}
+
+func (b *backend) pathTidyWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if err := b.tidyTokens(ctx, req.Storage); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+const pathTidyHelpSyn = `
+Remove expired tokens from the token stores.
+`
+
+const pathTidyHelpDesc = `
+Expired tokens cannot be decoded, and are removed from the token stores of
+tokenization transformations periodically. This endpoint removes them
+immediately.
+`
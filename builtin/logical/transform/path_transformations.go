@@ -0,0 +1,308 @@
+package transform
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	transformationPath = "transformation/"
+
+	typeFPE          = "fpe"
+	typeMasking      = "masking"
+	typeTokenization = "tokenization"
+
+	tweakSourceInternal  = "internal"
+	tweakSourceSupplied  = "supplied"
+	tweakSourceGenerated = "generated"
+
+	// transformationKeySize is the size in bytes of the AES-256 key of a
+	// transformation
+	transformationKeySize = 32
+)
+
+// transformationEntry is a named transformation of values. Type, Key and
+// Tweak are set when the transformation is created and cannot be changed.
+type transformationEntry struct {
+	Type             string        `json:"type"`
+	Template         string        `json:"template"`
+	TweakSource      string        `json:"tweak_source"`
+	MaskingCharacter string        `json:"masking_character"`
+	AllowedRoles     []string      `json:"allowed_roles"`
+	MaxTTL           time.Duration `json:"max_ttl"`
+
+	Key   []byte `json:"key"`
+	Tweak []byte `json:"tweak"`
+}
+
+func (t *transformationEntry) toResponseData() map[string]interface{} {
+	data := map[string]interface{}{
+		"type":          t.Type,
+		"allowed_roles": t.AllowedRoles,
+	}
+	switch t.Type {
+	case typeFPE:
+		data["template"] = t.Template
+		data["tweak_source"] = t.TweakSource
+	case typeMasking:
+		data["template"] = t.Template
+		data["masking_character"] = t.MaskingCharacter
+	case typeTokenization:
+		data["max_ttl"] = int64(t.MaxTTL.Seconds())
+	}
+	return data
+}
+
+// roleAllowed returns whether the role may use the transformation
+func (t *transformationEntry) roleAllowed(role string) bool {
+	for _, allowed := range t.AllowedRoles {
+		if allowed == "*" || allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+func pathListTransformations(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: transformationPath + "?$",
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.pathTransformationList,
+		},
+
+		HelpSynopsis:    pathTransformationsHelpSyn,
+		HelpDescription: pathTransformationsHelpDesc,
+	}
+}
+
+func pathTransformations(b *backend) *framework.Path {
+	return &framework.Path{
+		Pattern: transformationPath + framework.GenericNameRegex("name"),
+		Fields: map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the transformation",
+			},
+			"type": &framework.FieldSchema{
+				Type: framework.TypeString,
+				Description: `Type of the transformation, one of "fpe", "masking" or "tokenization".
+Cannot be changed once set.`,
+			},
+			"template": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: "Name of the template of transformed values. Used by fpe and masking transformations.",
+			},
+			"tweak_source": &framework.FieldSchema{
+				Type:    framework.TypeString,
+				Default: tweakSourceInternal,
+				Description: `Source of the tweak of fpe transformations. "internal" uses a tweak
+stored with the transformation, "supplied" requires a tweak to be supplied
+when encoding and decoding, and "generated" generates a tweak when encoding
+that must be supplied when decoding.`,
+			},
+			"masking_character": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Default:     "*",
+				Description: "Character replacing the masked characters of masking transformations.",
+			},
+			"allowed_roles": &framework.FieldSchema{
+				Type:        framework.TypeCommaStringSlice,
+				Description: `Roles allowed to use the transformation. "*" allows all roles.`,
+			},
+			"max_ttl": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: "Maximum lifetime of the tokens of tokenization transformations. Tokens do not expire by default.",
+			},
+		},
+
+		ExistenceCheck: b.pathTransformationExistenceCheck,
+
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation:   b.pathTransformationRead,
+			logical.CreateOperation: b.pathTransformationWrite,
+			logical.UpdateOperation: b.pathTransformationWrite,
+			logical.DeleteOperation: b.pathTransformationDelete,
+		},
+
+		HelpSynopsis:    pathTransformationsHelpSyn,
+		HelpDescription: pathTransformationsHelpDesc,
+	}
+}
+
+func (b *backend) transformation(ctx context.Context, s logical.Storage, name string) (*transformationEntry, error) {
+	entry, err := s.Get(ctx, transformationPath+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	transformation := new(transformationEntry)
+	if err := entry.DecodeJSON(transformation); err != nil {
+		return nil, err
+	}
+	return transformation, nil
+}
+
+func (b *backend) pathTransformationExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+	transformation, err := b.transformation(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return false, err
+	}
+	return transformation != nil, nil
+}
+
+func (b *backend) pathTransformationList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	names, err := req.Storage.List(ctx, transformationPath)
+	if err != nil {
+		return nil, err
+	}
+	return logical.ListResponse(names), nil
+}
+
+func (b *backend) pathTransformationRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	transformation, err := b.transformation(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if transformation == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: transformation.toResponseData(),
+	}, nil
+}
+
+func (b *backend) pathTransformationWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	transformation, err := b.transformation(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if transformation == nil {
+		transformation = &transformationEntry{
+			Type: data.Get("type").(string),
+		}
+		switch transformation.Type {
+		case typeFPE, typeMasking, typeTokenization:
+		case "":
+			return logical.ErrorResponse("type is required"), nil
+		default:
+			return logical.ErrorResponse(fmt.Sprintf("unsupported transformation type %q", transformation.Type)), nil
+		}
+
+		transformation.Key = make([]byte, transformationKeySize)
+		if _, err := rand.Read(transformation.Key); err != nil {
+			return nil, errwrap.Wrapf("error generating transformation key: {{err}}", err)
+		}
+		transformation.Tweak = make([]byte, ff3TweakSize)
+		if _, err := rand.Read(transformation.Tweak); err != nil {
+			return nil, errwrap.Wrapf("error generating transformation tweak: {{err}}", err)
+		}
+	} else if raw, ok := data.GetOk("type"); ok && raw.(string) != transformation.Type {
+		return logical.ErrorResponse("the type of a transformation cannot be changed"), nil
+	}
+
+	if raw, ok := data.GetOk("template"); ok {
+		transformation.Template = raw.(string)
+	}
+	if _, ok := data.GetOk("tweak_source"); ok || transformation.TweakSource == "" {
+		transformation.TweakSource = data.Get("tweak_source").(string)
+	}
+	if _, ok := data.GetOk("masking_character"); ok || transformation.MaskingCharacter == "" {
+		transformation.MaskingCharacter = data.Get("masking_character").(string)
+	}
+	if raw, ok := data.GetOk("allowed_roles"); ok {
+		transformation.AllowedRoles = raw.([]string)
+	}
+	if raw, ok := data.GetOk("max_ttl"); ok {
+		transformation.MaxTTL = time.Duration(raw.(int)) * time.Second
+	}
+
+	switch transformation.Type {
+	case typeFPE, typeMasking:
+		if transformation.Template == "" {
+			return logical.ErrorResponse(fmt.Sprintf("template is required for %s transformations", transformation.Type)), nil
+		}
+		template, err := b.template(ctx, req.Storage, transformation.Template)
+		if err != nil {
+			return nil, err
+		}
+		if template == nil {
+			return logical.ErrorResponse(fmt.Sprintf("template %q does not exist", transformation.Template)), nil
+		}
+	}
+	switch transformation.TweakSource {
+	case tweakSourceInternal, tweakSourceSupplied, tweakSourceGenerated:
+	default:
+		return logical.ErrorResponse(fmt.Sprintf("unsupported tweak_source %q", transformation.TweakSource)), nil
+	}
+	if utf8.RuneCountInString(transformation.MaskingCharacter) != 1 {
+		return logical.ErrorResponse("masking_character must be a single character"), nil
+	}
+	if transformation.MaxTTL < 0 {
+		return logical.ErrorResponse("max_ttl cannot be negative"), nil
+	}
+
+	entry, err := logical.StorageEntryJSON(transformationPath+name, transformation)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *backend) pathTransformationDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	lock := locksutil.LockForKey(b.tokenLocks, name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := req.Storage.Delete(ctx, transformationPath+name); err != nil {
+		return nil, err
+	}
+
+	// The tokens of the transformation can no longer be decoded
+	if err := b.deleteTokens(ctx, req.Storage, name); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+const pathTransformationsHelpSyn = `
+Manage the transformations that encode and decode values.
+`
+
+const pathTransformationsHelpDesc = `
+Transformations are one of three types:
+
+  * "fpe" transformations encrypt the characters matched by a template with
+    FF3-1 format preserving encryption, producing a value in the same format
+    that can be decoded.
+  * "masking" transformations replace the characters matched by a template
+    with the masking character. Masked values cannot be decoded.
+  * "tokenization" transformations replace values with random tokens. The
+    encrypted value is kept in a token store until the token expires, so
+    that the token can be decoded.
+
+Each transformation has its own randomly generated key. Deleting a
+transformation deletes its tokens.
+`
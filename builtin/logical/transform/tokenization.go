@@ -0,0 +1,219 @@
+package transform
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+	"github.com/hashicorp/vault/sdk/helper/kdf"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	tokenPath = "tokens/"
+
+	// tokenLength is the number of base62 characters of a token
+	tokenLength = 32
+)
+
+// tokenEntry is the encrypted value of a token. Tokens are stored by their
+// HMAC, so the token store alone does not reveal the tokens.
+type tokenEntry struct {
+	Ciphertext     []byte    `json:"ciphertext"`
+	ExpirationTime time.Time `json:"expiration_time"`
+}
+
+func (t *tokenEntry) expired(now time.Time) bool {
+	return !t.ExpirationTime.IsZero() && now.After(t.ExpirationTime)
+}
+
+// tokenizer encodes values of a tokenization transformation into tokens
+type tokenizer struct {
+	name    string
+	hmacKey []byte
+	aead    cipher.AEAD
+}
+
+func newTokenizer(name string, transformation *transformationEntry) (*tokenizer, error) {
+	hmacKey, err := kdf.CounterMode(kdf.HMACSHA256PRF, 256, transformation.Key, []byte("hmac"), 256)
+	if err != nil {
+		return nil, errwrap.Wrapf("error deriving HMAC key: {{err}}", err)
+	}
+	encKey, err := kdf.CounterMode(kdf.HMACSHA256PRF, 256, transformation.Key, []byte("encryption"), 256)
+	if err != nil {
+		return nil, errwrap.Wrapf("error deriving encryption key: {{err}}", err)
+	}
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenizer{
+		name:    name,
+		hmacKey: hmacKey,
+		aead:    aead,
+	}, nil
+}
+
+// storagePath returns the path of the token store entry of the token
+func (t *tokenizer) storagePath(token string) string {
+	mac := hmac.New(sha256.New, t.hmacKey)
+	mac.Write([]byte(token))
+	return tokenPath + t.name + "/" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// tokenize stores the value under a new random token. A zero ttl creates a
+// token that does not expire.
+func (t *tokenizer) tokenize(ctx context.Context, s logical.Storage, value string, ttl time.Duration) (string, error) {
+	token, err := base62.Random(tokenLength)
+	if err != nil {
+		return "", errwrap.Wrapf("error generating token: {{err}}", err)
+	}
+	path := t.storagePath(token)
+
+	nonce := make([]byte, t.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errwrap.Wrapf("error generating nonce: {{err}}", err)
+	}
+
+	te := &tokenEntry{
+		// The storage path is authenticated so that ciphertexts cannot be
+		// swapped between tokens
+		Ciphertext: t.aead.Seal(nonce, nonce, []byte(value), []byte(path)),
+	}
+	if ttl > 0 {
+		te.ExpirationTime = time.Now().Add(ttl)
+	}
+
+	entry, err := logical.StorageEntryJSON(path, te)
+	if err != nil {
+		return "", err
+	}
+	if err := s.Put(ctx, entry); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// detokenize returns the value of the token, or an empty string if the token
+// does not exist or has expired
+func (t *tokenizer) detokenize(ctx context.Context, s logical.Storage, token string) (string, error) {
+	path := t.storagePath(token)
+
+	entry, err := s.Get(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	if entry == nil {
+		return "", nil
+	}
+
+	te := new(tokenEntry)
+	if err := entry.DecodeJSON(te); err != nil {
+		return "", err
+	}
+	if te.expired(time.Now()) {
+		return "", nil
+	}
+
+	nonceSize := t.aead.NonceSize()
+	if len(te.Ciphertext) < nonceSize {
+		return "", fmt.Errorf("invalid ciphertext for token")
+	}
+	value, err := t.aead.Open(nil, te.Ciphertext[:nonceSize], te.Ciphertext[nonceSize:], []byte(path))
+	if err != nil {
+		return "", errwrap.Wrapf("error decrypting token value: {{err}}", err)
+	}
+
+	return string(value), nil
+}
+
+// tidyTokens removes expired tokens from the token stores of all
+// transformations
+func (b *backend) tidyTokens(ctx context.Context, s logical.Storage) error {
+	names, err := s.List(ctx, tokenPath)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, name := range names {
+		// Token stores are listed as directories
+		name = strings.TrimSuffix(name, "/")
+		if err := b.tidyTransformationTokens(ctx, s, name, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *backend) tidyTransformationTokens(ctx context.Context, s logical.Storage, name string, now time.Time) error {
+	lock := locksutil.LockForKey(b.tokenLocks, name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	prefix := tokenPath + name + "/"
+	ids, err := s.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		entry, err := s.Get(ctx, prefix+id)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			continue
+		}
+
+		te := new(tokenEntry)
+		if err := entry.DecodeJSON(te); err != nil {
+			return err
+		}
+		if !te.expired(now) {
+			continue
+		}
+
+		if err := s.Delete(ctx, prefix+id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteTokens removes the token store of a transformation. The caller must
+// hold the transformation's token lock.
+func (b *backend) deleteTokens(ctx context.Context, s logical.Storage, name string) error {
+	prefix := tokenPath + name + "/"
+	ids, err := s.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.Delete(ctx, prefix+id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
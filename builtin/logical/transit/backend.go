@@ -2,9 +2,14 @@ package transit
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/errwrap"
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/consts"
 	"github.com/hashicorp/vault/sdk/helper/keysutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
@@ -49,9 +54,10 @@ func Backend(conf *logical.BackendConfig) *backend {
 			b.pathTrim(),
 		},
 
-		Secrets:     []*framework.Secret{},
-		Invalidate:  b.invalidate,
-		BackendType: logical.TypeLogical,
+		Secrets:      []*framework.Secret{},
+		Invalidate:   b.invalidate,
+		BackendType:  logical.TypeLogical,
+		PeriodicFunc: b.periodicFunc,
 	}
 
 	b.lm = keysutil.NewLockManager(conf.System.CachingDisabled())
@@ -64,6 +70,57 @@ type backend struct {
 	lm *keysutil.LockManager
 }
 
+// minAutoRotatePeriod is the shortest auto_rotate_period a key may be given
+const minAutoRotatePeriod = time.Hour
+
+// periodicFunc is invoked once a minute by the RollbackManager and rotates
+// any keys whose auto_rotate_period has elapsed since their latest version
+// was created
+func (b *backend) periodicFunc(ctx context.Context, req *logical.Request) error {
+	if !b.System().LocalMount() && b.System().ReplicationState().HasState(consts.ReplicationPerformanceSecondary|consts.ReplicationPerformanceStandby) {
+		return nil
+	}
+
+	names, err := req.Storage.List(ctx, "policy/")
+	if err != nil {
+		return err
+	}
+
+	var errs *multierror.Error
+	for _, name := range names {
+		if err := b.rotateIfRequired(ctx, req, name); err != nil {
+			errs = multierror.Append(errs, errwrap.Wrapf(fmt.Sprintf("error auto-rotating key %q: {{err}}", name), err))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// rotateIfRequired rotates the named key if its auto_rotate_period has
+// elapsed
+func (b *backend) rotateIfRequired(ctx context.Context, req *logical.Request, name string) error {
+	p, _, err := b.lm.GetPolicy(ctx, keysutil.PolicyRequest{
+		Storage: req.Storage,
+		Name:    name,
+	})
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return nil
+	}
+	if !b.System().CachingDisabled() {
+		p.Lock(true)
+	}
+	defer p.Unlock()
+
+	if !p.NeedsAutoRotate(time.Now()) {
+		return nil
+	}
+
+	return p.Rotate(ctx, req.Storage)
+}
+
 func (b *backend) invalidate(_ context.Context, key string) {
 	if b.Logger().IsDebug() {
 		b.Logger().Debug("invalidating key", "key", key)
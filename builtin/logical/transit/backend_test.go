@@ -374,6 +374,96 @@ func testBackendRotation(t *testing.T) {
 	})
 }
 
+func TestBackend_autoRotate(t *testing.T) {
+	b, storage := createBackendWithSysView(t)
+
+	req := &logical.Request{
+		Storage:   storage,
+		Operation: logical.UpdateOperation,
+		Path:      "keys/foo",
+		Data: map[string]interface{}{
+			"auto_rotate_period": "10m",
+		},
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	if err != nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error for a period under an hour, got: %v %#v", err, resp)
+	}
+
+	req.Data["auto_rotate_period"] = "24h"
+	if resp, err = b.HandleRequest(context.Background(), req); err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+
+	readKey := func() map[string]interface{} {
+		resp, err := b.HandleRequest(context.Background(), &logical.Request{
+			Storage:   storage,
+			Operation: logical.ReadOperation,
+			Path:      "keys/foo",
+		})
+		if err != nil || resp == nil {
+			t.Fatalf("err: %v %#v", err, resp)
+		}
+		return resp.Data
+	}
+
+	data := readKey()
+	if data["auto_rotate_period"].(int64) != 86400 {
+		t.Fatalf("bad: %#v", data)
+	}
+	created := data["latest_version_creation_time"].(time.Time)
+	if !data["next_rotation_time"].(time.Time).Equal(created.Add(24 * time.Hour)) {
+		t.Fatalf("bad: %#v", data)
+	}
+
+	// Nothing is due yet
+	if err := b.periodicFunc(context.Background(), &logical.Request{Storage: storage}); err != nil {
+		t.Fatal(err)
+	}
+	if data := readKey(); data["latest_version"].(int) != 1 {
+		t.Fatalf("bad: %#v", data)
+	}
+
+	// Backdate the key so that it is due for rotation
+	p, _, err := b.lm.GetPolicy(context.Background(), keysutil.PolicyRequest{
+		Storage: storage,
+		Name:    "foo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := p.Keys["1"]
+	entry.CreationTime = entry.CreationTime.Add(-25 * time.Hour)
+	p.Keys["1"] = entry
+	if err := p.Persist(context.Background(), storage); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.periodicFunc(context.Background(), &logical.Request{Storage: storage}); err != nil {
+		t.Fatal(err)
+	}
+	if data := readKey(); data["latest_version"].(int) != 2 {
+		t.Fatalf("bad: %#v", data)
+	}
+
+	// Disabling the period stops rotation
+	resp, err = b.HandleRequest(context.Background(), &logical.Request{
+		Storage:   storage,
+		Operation: logical.UpdateOperation,
+		Path:      "keys/foo/config",
+		Data: map[string]interface{}{
+			"auto_rotate_period": 0,
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+	data = readKey()
+	if data["auto_rotate_period"].(int64) != 0 || data["next_rotation_time"] != nil {
+		t.Fatalf("bad: %#v", data)
+	}
+}
+
 func TestBackend_basic_derived(t *testing.T) {
 	decryptData := make(map[string]interface{})
 	logicaltest.Test(t, logicaltest.TestCase{
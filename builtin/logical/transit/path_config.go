@@ -3,6 +3,7 @@ package transit
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/keysutil"
@@ -47,6 +48,11 @@ the latest version of the key is allowed.`,
 				Type:        framework.TypeBool,
 				Description: `Enables taking a backup of the named key in plaintext format. Once set, this cannot be disabled.`,
 			},
+
+			"auto_rotate_period": &framework.FieldSchema{
+				Type:        framework.TypeDurationSecond,
+				Description: `Amount of time the key should live before being automatically rotated. A value of 0 disables automatic rotation for the key. The minimum is one hour.`,
+			},
 		},
 
 		Callbacks: map[logical.Operation]framework.OperationFunc{
@@ -84,6 +90,7 @@ func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, d *
 	originalDeletionAllowed := p.DeletionAllowed
 	originalExportable := p.Exportable
 	originalAllowPlaintextBackup := p.AllowPlaintextBackup
+	originalAutoRotatePeriod := p.AutoRotatePeriod
 
 	defer func() {
 		if retErr != nil || (resp != nil && resp.IsError()) {
@@ -92,6 +99,7 @@ func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, d *
 			p.DeletionAllowed = originalDeletionAllowed
 			p.Exportable = originalExportable
 			p.AllowPlaintextBackup = originalAllowPlaintextBackup
+			p.AutoRotatePeriod = originalAutoRotatePeriod
 		}
 	}()
 
@@ -185,6 +193,18 @@ func (b *backend) pathConfigWrite(ctx context.Context, req *logical.Request, d *
 		}
 	}
 
+	autoRotatePeriodRaw, ok := d.GetOk("auto_rotate_period")
+	if ok {
+		autoRotatePeriod := time.Duration(autoRotatePeriodRaw.(int)) * time.Second
+		if autoRotatePeriod != 0 && autoRotatePeriod < minAutoRotatePeriod {
+			return logical.ErrorResponse("auto rotate period must be 0 to disable or at least an hour"), nil
+		}
+		if autoRotatePeriod != p.AutoRotatePeriod {
+			p.AutoRotatePeriod = autoRotatePeriod
+			persistNeeded = true
+		}
+	}
+
 	if !persistNeeded {
 		return nil, nil
 	}
@@ -208,5 +228,6 @@ const pathConfigHelpSyn = `Configure a named encryption key`
 const pathConfigHelpDesc = `
 This path is used to configure the named key. Currently, this
 supports adjusting the minimum version of the key allowed to
-be used for decryption via the min_decryption_version parameter.
+be used for decryption via the min_decryption_version parameter,
+and the automatic rotation period via auto_rotate_period.
 `
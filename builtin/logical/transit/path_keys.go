@@ -2,7 +2,6 @@ package transit
 
 import (
 	"context"
-	"crypto/elliptic"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
@@ -46,8 +45,9 @@ func (b *backend) pathKeys() *framework.Path {
 				Default: "aes256-gcm96",
 				Description: `
 The type of key to create. Currently, "aes256-gcm96" (symmetric), "ecdsa-p256"
-(asymmetric), 'ed25519' (asymmetric), 'rsa-2048' (asymmetric), 'rsa-4096'
-(asymmetric) are supported.  Defaults to "aes256-gcm96".
+(asymmetric), "ecdsa-p384" (asymmetric), "ecdsa-p521" (asymmetric), 'ed25519'
+(asymmetric), 'rsa-2048' (asymmetric), 'rsa-4096' (asymmetric) are supported.
+Defaults to "aes256-gcm96".
 `,
 			},
 
@@ -88,6 +88,14 @@ key in plaintext format. Once set,
 this cannot be disabled.`,
 			},
 
+			"auto_rotate_period": &framework.FieldSchema{
+				Type: framework.TypeDurationSecond,
+				Description: `Amount of time the key should live before
+being automatically rotated. A value of 0
+disables automatic rotation for the key. The
+minimum is one hour.`,
+			},
+
 			"context": &framework.FieldSchema{
 				Type: framework.TypeString,
 				Description: `Base64 encoded context for key derivation.
@@ -124,10 +132,14 @@ func (b *backend) pathPolicyWrite(ctx context.Context, req *logical.Request, d *
 	keyType := d.Get("type").(string)
 	exportable := d.Get("exportable").(bool)
 	allowPlaintextBackup := d.Get("allow_plaintext_backup").(bool)
+	autoRotatePeriod := time.Duration(d.Get("auto_rotate_period").(int)) * time.Second
 
 	if !derived && convergent {
 		return logical.ErrorResponse("convergent encryption requires derivation to be enabled"), nil
 	}
+	if autoRotatePeriod != 0 && autoRotatePeriod < minAutoRotatePeriod {
+		return logical.ErrorResponse("auto rotate period must be 0 to disable or at least an hour"), nil
+	}
 
 	polReq := keysutil.PolicyRequest{
 		Upsert:               true,
@@ -137,6 +149,7 @@ func (b *backend) pathPolicyWrite(ctx context.Context, req *logical.Request, d *
 		Convergent:           convergent,
 		Exportable:           exportable,
 		AllowPlaintextBackup: allowPlaintextBackup,
+		AutoRotatePeriod:     autoRotatePeriod,
 	}
 	switch keyType {
 	case "aes256-gcm96":
@@ -145,6 +158,10 @@ func (b *backend) pathPolicyWrite(ctx context.Context, req *logical.Request, d *
 		polReq.KeyType = keysutil.KeyType_ChaCha20_Poly1305
 	case "ecdsa-p256":
 		polReq.KeyType = keysutil.KeyType_ECDSA_P256
+	case "ecdsa-p384":
+		polReq.KeyType = keysutil.KeyType_ECDSA_P384
+	case "ecdsa-p521":
+		polReq.KeyType = keysutil.KeyType_ECDSA_P521
 	case "ed25519":
 		polReq.KeyType = keysutil.KeyType_ED25519
 	case "rsa-2048":
@@ -212,6 +229,7 @@ func (b *backend) pathPolicyRead(ctx context.Context, req *logical.Request, d *f
 			"latest_version":         p.LatestVersion,
 			"exportable":             p.Exportable,
 			"allow_plaintext_backup": p.AllowPlaintextBackup,
+			"auto_rotate_period":     int64(p.AutoRotatePeriod.Seconds()),
 			"supports_encryption":    p.Type.EncryptionSupported(),
 			"supports_decryption":    p.Type.DecryptionSupported(),
 			"supports_signing":       p.Type.SigningSupported(),
@@ -219,6 +237,17 @@ func (b *backend) pathPolicyRead(ctx context.Context, req *logical.Request, d *f
 		},
 	}
 
+	if latest, ok := p.Keys[strconv.Itoa(p.LatestVersion)]; ok {
+		created := latest.CreationTime
+		if created.IsZero() {
+			created = time.Unix(latest.DeprecatedCreationTime, 0)
+		}
+		resp.Data["latest_version_creation_time"] = created
+		if p.AutoRotatePeriod > 0 {
+			resp.Data["next_rotation_time"] = created.Add(p.AutoRotatePeriod)
+		}
+	}
+
 	if p.BackupInfo != nil {
 		resp.Data["backup_info"] = map[string]interface{}{
 			"time":    p.BackupInfo.Time,
@@ -263,7 +292,7 @@ func (b *backend) pathPolicyRead(ctx context.Context, req *logical.Request, d *f
 		}
 		resp.Data["keys"] = retKeys
 
-	case keysutil.KeyType_ECDSA_P256, keysutil.KeyType_ED25519, keysutil.KeyType_RSA2048, keysutil.KeyType_RSA4096:
+	case keysutil.KeyType_ECDSA_P256, keysutil.KeyType_ECDSA_P384, keysutil.KeyType_ECDSA_P521, keysutil.KeyType_ED25519, keysutil.KeyType_RSA2048, keysutil.KeyType_RSA4096:
 		retKeys := map[string]map[string]interface{}{}
 		for k, v := range p.Keys {
 			key := asymKey{
@@ -275,8 +304,8 @@ func (b *backend) pathPolicyRead(ctx context.Context, req *logical.Request, d *f
 			}
 
 			switch p.Type {
-			case keysutil.KeyType_ECDSA_P256:
-				key.Name = elliptic.P256().Params().Name
+			case keysutil.KeyType_ECDSA_P256, keysutil.KeyType_ECDSA_P384, keysutil.KeyType_ECDSA_P521:
+				key.Name = p.Type.ECDSACurve().Params().Name
 			case keysutil.KeyType_ED25519:
 				if p.Derived {
 					if len(context) == 0 {
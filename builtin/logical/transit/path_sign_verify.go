@@ -123,7 +123,7 @@ Options are 'pss' or 'pkcs1v15'. Defaults to 'pss'`,
 			"marshaling_algorithm": {
 				Type:        framework.TypeString,
 				Default:     "asn1",
-				Description: `The method by which to marshal the signature. The default is 'asn1' which is used by openssl and X.509. It can also be set to 'jws' which is used for JWT signatures; setting it to this will also cause the encoding of the signature to be url-safe base64 instead of using standard base64 encoding. Currently only valid for ECDSA P-256 key types".`,
+				Description: `The method by which to marshal the signature. The default is 'asn1' which is used by openssl and X.509. It can also be set to 'jws' which is used for JWT signatures; setting it to this will also cause the encoding of the signature to be url-safe base64 instead of using standard base64 encoding. Currently only valid for ECDSA key types".`,
 			},
 		},
 
@@ -205,7 +205,7 @@ Options are 'pss' or 'pkcs1v15'. Defaults to 'pss'`,
 			"marshaling_algorithm": {
 				Type:        framework.TypeString,
 				Default:     "asn1",
-				Description: `The method by which to unmarshal the signature when verifying. The default is 'asn1' which is used by openssl and X.509; can also be set to 'jws' which is used for JWT signatures in which case the signature is also expected to be url-safe base64 encoding instead of standard base64 encoding. Currently only valid for ECDSA P-256 key types".`,
+				Description: `The method by which to unmarshal the signature when verifying. The default is 'asn1' which is used by openssl and X.509; can also be set to 'jws' which is used for JWT signatures in which case the signature is also expected to be url-safe base64 encoding instead of standard base64 encoding. Currently only valid for ECDSA key types".`,
 			},
 		},
 
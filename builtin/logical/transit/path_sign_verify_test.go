@@ -235,6 +235,84 @@ func TestTransit_SignVerify_P256(t *testing.T) {
 	verifyRequest(req, true, "", v1sig)
 }
 
+func TestTransit_SignVerify_ECDSA_Curves(t *testing.T) {
+	b, storage := createBackendWithSysView(t)
+	input := base64.StdEncoding.EncodeToString([]byte("the quick brown fox"))
+
+	for keyType, curveName := range map[string]string{
+		"ecdsa-p384": "P-384",
+		"ecdsa-p521": "P-521",
+	} {
+		req := &logical.Request{
+			Storage:   storage,
+			Operation: logical.UpdateOperation,
+			Path:      "keys/" + keyType,
+			Data: map[string]interface{}{
+				"type": keyType,
+			},
+		}
+		if _, err := b.HandleRequest(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+
+		// Derivation is not supported for ECDSA keys
+		req.Path = "keys/" + keyType + "-derived"
+		req.Data["derived"] = true
+		if resp, err := b.HandleRequest(context.Background(), req); err == nil && !resp.IsError() {
+			t.Fatalf("%s: expected error creating derived key", keyType)
+		}
+
+		req = &logical.Request{
+			Storage:   storage,
+			Operation: logical.ReadOperation,
+			Path:      "keys/" + keyType,
+		}
+		resp, err := b.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Data["type"] != keyType {
+			t.Fatalf("bad: %#v", resp.Data)
+		}
+		keys := resp.Data["keys"].(map[string]map[string]interface{})
+		if keys["1"]["name"] != curveName || keys["1"]["public_key"] == "" {
+			t.Fatalf("bad: %#v", keys["1"])
+		}
+
+		for _, marshaling := range []string{"asn1", "jws"} {
+			for _, hash := range []string{"sha2-256", "sha2-512"} {
+				req = &logical.Request{
+					Storage:   storage,
+					Operation: logical.UpdateOperation,
+					Path:      "sign/" + keyType + "/" + hash,
+					Data: map[string]interface{}{
+						"input":                input,
+						"marshaling_algorithm": marshaling,
+					},
+				}
+				resp, err = b.HandleRequest(context.Background(), req)
+				if err != nil || resp.IsError() {
+					t.Fatalf("%s/%s/%s: err: %v resp: %#v", keyType, marshaling, hash, err, resp)
+				}
+				signature := resp.Data["signature"].(string)
+
+				req.Path = "verify/" + keyType + "/" + hash
+				req.Data["signature"] = signature
+				resp, err = b.HandleRequest(context.Background(), req)
+				if err != nil || resp.IsError() || !resp.Data["valid"].(bool) {
+					t.Fatalf("%s/%s/%s: expected valid signature: %v %#v", keyType, marshaling, hash, err, resp)
+				}
+
+				req.Data["input"] = base64.StdEncoding.EncodeToString([]byte("the quick brown fix"))
+				resp, err = b.HandleRequest(context.Background(), req)
+				if err != nil || resp.IsError() || resp.Data["valid"].(bool) {
+					t.Fatalf("%s/%s/%s: expected invalid signature: %v %#v", keyType, marshaling, hash, err, resp)
+				}
+			}
+		}
+	}
+}
+
 func validatePublicKey(t *testing.T, in string, sig string, pubKeyRaw []byte, expectValid bool, postpath string, b *backend) {
 	t.Helper()
 	input, _ := base64.StdEncoding.DecodeString(in)
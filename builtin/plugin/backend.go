@@ -91,13 +91,19 @@ type PluginBackend struct {
 	loaded bool
 }
 
-func (b *PluginBackend) reloadBackend(ctx context.Context) error {
+// Initialize is intentionally a no-op here, the backend will instead be
+// initialized when it is lazily loaded.
+func (b *PluginBackend) Initialize(ctx context.Context, req *logical.InitializationRequest) error {
+	return nil
+}
+
+func (b *PluginBackend) reloadBackend(ctx context.Context, storage logical.Storage) error {
 	b.Logger().Debug("reloading plugin backend", "plugin", b.config.Config["plugin_name"])
-	return b.startBackend(ctx)
+	return b.startBackend(ctx, storage)
 }
 
 // startBackend starts a plugin backend
-func (b *PluginBackend) startBackend(ctx context.Context) error {
+func (b *PluginBackend) startBackend(ctx context.Context, storage logical.Storage) error {
 	pluginName := b.config.Config["plugin_name"]
 	pluginType, err := consts.ParsePluginType(b.config.Config["plugin_type"])
 	if err != nil {
@@ -134,7 +140,10 @@ func (b *PluginBackend) startBackend(ctx context.Context) error {
 	b.Backend = nb
 	b.loaded = true
 
-	return nil
+	// The plugin process is new, so it has not been initialized yet
+	return b.Backend.Initialize(ctx, &logical.InitializationRequest{
+		Storage: storage,
+	})
 }
 
 // HandleRequest is a thin wrapper implementation of HandleRequest that includes automatic plugin reload.
@@ -149,7 +158,7 @@ func (b *PluginBackend) HandleRequest(ctx context.Context, req *logical.Request)
 		b.Lock()
 		// Check once more after lock swap
 		if !b.loaded {
-			err := b.startBackend(ctx)
+			err := b.startBackend(ctx, req.Storage)
 			if err != nil {
 				b.Unlock()
 				return nil, err
@@ -167,7 +176,7 @@ func (b *PluginBackend) HandleRequest(ctx context.Context, req *logical.Request)
 		// Reload plugin if it's an rpc.ErrShutdown
 		b.Lock()
 		if b.canary == canary {
-			err := b.reloadBackend(ctx)
+			err := b.reloadBackend(ctx, req.Storage)
 			if err != nil {
 				b.Unlock()
 				return nil, err
@@ -200,7 +209,7 @@ func (b *PluginBackend) HandleExistenceCheck(ctx context.Context, req *logical.R
 		b.Lock()
 		// Check once more after lock swap
 		if !b.loaded {
-			err := b.startBackend(ctx)
+			err := b.startBackend(ctx, req.Storage)
 			if err != nil {
 				b.Unlock()
 				return false, false, err
@@ -217,7 +226,7 @@ func (b *PluginBackend) HandleExistenceCheck(ctx context.Context, req *logical.R
 		// Reload plugin if it's an rpc.ErrShutdown
 		b.Lock()
 		if b.canary == canary {
-			err := b.reloadBackend(ctx)
+			err := b.reloadBackend(ctx, req.Storage)
 			if err != nil {
 				b.Unlock()
 				return false, false, err
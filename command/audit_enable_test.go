@@ -189,6 +189,8 @@ func TestAuditEnableCommand_Run(t *testing.T) {
 				args = append(args, "file_path=discard")
 			case "socket":
 				args = append(args, "address=127.0.0.1:8888")
+			case "otlp":
+				args = append(args, "endpoint=http://127.0.0.1:4318")
 			}
 			code := cmd.Run(args)
 			if exp := 0; code != exp {
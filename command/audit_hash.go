@@ -0,0 +1,87 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*AuditHashCommand)(nil)
+var _ cli.CommandAutocomplete = (*AuditHashCommand)(nil)
+
+type AuditHashCommand struct {
+	*BaseCommand
+}
+
+func (c *AuditHashCommand) Synopsis() string {
+	return "Hashes a value as an audit device would"
+}
+
+func (c *AuditHashCommand) Help() string {
+	helpText := `
+Usage: vault audit hash [options] PATH INPUT
+
+  Hashes the given input using the salt of the audit device at PATH. The
+  result matches the HMAC the audit device writes for the same value, which
+  allows searching audit logs for a known token or secret.
+
+  The argument corresponds to the PATH of audit device, not the TYPE!
+
+  Hash a token as the audit device enabled at "file/" would:
+
+      $ vault audit hash file/ s.xxxxxxxx
+
+` + c.Flags().Help()
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AuditHashCommand) Flags() *FlagSets {
+	return c.flagSet(FlagSetHTTP)
+}
+
+func (c *AuditHashCommand) AutocompleteArgs() complete.Predictor {
+	return c.PredictVaultAudits()
+}
+
+func (c *AuditHashCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *AuditHashCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = f.Args()
+	switch {
+	case len(args) < 2:
+		c.UI.Error(fmt.Sprintf("Not enough arguments (expected 2, got %d)", len(args)))
+		return 1
+	case len(args) > 2:
+		c.UI.Error(fmt.Sprintf("Too many arguments (expected 2, got %d)", len(args)))
+		return 1
+	}
+
+	path := ensureTrailingSlash(sanitizePath(args[0]))
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 2
+	}
+
+	hash, err := client.Sys().AuditHash(path, args[1])
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error hashing input: %s", err))
+		return 2
+	}
+
+	c.UI.Output(hash)
+	return 0
+}
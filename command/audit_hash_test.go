@@ -0,0 +1,140 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/mitchellh/cli"
+)
+
+func testAuditHashCommand(tb testing.TB) (*cli.MockUi, *AuditHashCommand) {
+	tb.Helper()
+
+	ui := cli.NewMockUi()
+	return ui, &AuditHashCommand{
+		BaseCommand: &BaseCommand{
+			UI: ui,
+		},
+	}
+}
+
+func TestAuditHashCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		args []string
+		out  string
+		code int
+	}{
+		{
+			"not_enough_args",
+			[]string{"file"},
+			"Not enough arguments",
+			1,
+		},
+		{
+			"too_many_args",
+			[]string{"file", "bar", "baz"},
+			"Too many arguments",
+			1,
+		},
+		{
+			"not_real",
+			[]string{"not_real", "foo"},
+			"Error hashing input",
+			2,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, closer := testVaultServer(t)
+			defer closer()
+
+			ui, cmd := testAuditHashCommand(t)
+			cmd.client = client
+
+			code := cmd.Run(tc.args)
+			if code != tc.code {
+				t.Errorf("expected %d to be %d", code, tc.code)
+			}
+
+			combined := ui.OutputWriter.String() + ui.ErrorWriter.String()
+			if !strings.Contains(combined, tc.out) {
+				t.Errorf("expected %q to contain %q", combined, tc.out)
+			}
+		})
+	}
+
+	t.Run("integration", func(t *testing.T) {
+		t.Parallel()
+
+		client, closer := testVaultServer(t)
+		defer closer()
+
+		if err := client.Sys().EnableAuditWithOptions("integration_audit_hash", &api.EnableAuditOptions{
+			Type: "file",
+			Options: map[string]string{
+				"file_path": "discard",
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := client.Sys().AuditHash("integration_audit_hash/", "foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ui, cmd := testAuditHashCommand(t)
+		cmd.client = client
+
+		code := cmd.Run([]string{
+			"integration_audit_hash/", "foo",
+		})
+		if exp := 0; code != exp {
+			t.Errorf("expected %d to be %d", code, exp)
+		}
+
+		combined := ui.OutputWriter.String() + ui.ErrorWriter.String()
+		if !strings.Contains(combined, expected) {
+			t.Errorf("expected %q to contain %q", combined, expected)
+		}
+	})
+
+	t.Run("communication_failure", func(t *testing.T) {
+		t.Parallel()
+
+		client, closer := testVaultServerBad(t)
+		defer closer()
+
+		ui, cmd := testAuditHashCommand(t)
+		cmd.client = client
+
+		code := cmd.Run([]string{
+			"file/", "foo",
+		})
+		if exp := 2; code != exp {
+			t.Errorf("expected %d to be %d", code, exp)
+		}
+
+		expected := "Error hashing input: "
+		combined := ui.OutputWriter.String() + ui.ErrorWriter.String()
+		if !strings.Contains(combined, expected) {
+			t.Errorf("expected %q to contain %q", combined, expected)
+		}
+	})
+
+	t.Run("no_tabs", func(t *testing.T) {
+		t.Parallel()
+
+		_, cmd := testAuditHashCommand(t)
+		assertNoTabs(t, cmd)
+	})
+}
@@ -25,6 +25,7 @@ type AuthTuneCommand struct {
 	flagListingVisibility        string
 	flagMaxLeaseTTL              time.Duration
 	flagOptions                  map[string]string
+	flagRollbackPeriod           string
 	flagTokenType                string
 	flagVersion                  int
 }
@@ -105,6 +106,14 @@ func (c *AuthTuneCommand) Flags() *FlagSets {
 			"or a previously configured value for the auth method.",
 	})
 
+	f.StringVar(&StringVar{
+		Name:   flagNameRollbackPeriod,
+		Target: &c.flagRollbackPeriod,
+		Usage: "How often the rollback manager acts on this auth method. Accepts a " +
+			"duration, \"system\" to use the server's rollback interval, or " +
+			"\"disable\" to turn off scheduled rollbacks.",
+	})
+
 	f.StringMapVar(&StringMapVar{
 		Name:       "options",
 		Target:     &c.flagOptions,
@@ -192,6 +201,10 @@ func (c *AuthTuneCommand) Run(args []string) int {
 			mountConfigInput.ListingVisibility = c.flagListingVisibility
 		}
 
+		if fl.Name == flagNameRollbackPeriod {
+			mountConfigInput.RollbackPeriod = c.flagRollbackPeriod
+		}
+
 		if fl.Name == flagNameTokenType {
 			mountConfigInput.TokenType = c.flagTokenType
 		}
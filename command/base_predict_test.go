@@ -339,6 +339,7 @@ func TestPredict_Plugins(t *testing.T) {
 				"hana-database-plugin",
 				"influxdb-database-plugin",
 				"jwt",
+				"kmip",
 				"kubernetes",
 				"kv",
 				"ldap",
@@ -354,6 +355,7 @@ func TestPredict_Plugins(t *testing.T) {
 				"nomad",
 				"oidc",
 				"okta",
+				"openldap",
 				"pki",
 				"postgresql",
 				"postgresql-database-plugin",
@@ -361,6 +363,7 @@ func TestPredict_Plugins(t *testing.T) {
 				"radius",
 				"ssh",
 				"totp",
+				"transform",
 				"transit",
 				"userpass",
 			},
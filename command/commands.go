@@ -20,12 +20,12 @@ import (
 	_ "github.com/hashicorp/vault/helper/builtinplugins"
 
 	auditFile "github.com/hashicorp/vault/builtin/audit/file"
+	auditOTLP "github.com/hashicorp/vault/builtin/audit/otlp"
 	auditSocket "github.com/hashicorp/vault/builtin/audit/socket"
 	auditSyslog "github.com/hashicorp/vault/builtin/audit/syslog"
 
 	credAliCloud "github.com/hashicorp/vault-plugin-auth-alicloud"
 	credCentrify "github.com/hashicorp/vault-plugin-auth-centrify"
-	credGcp "github.com/hashicorp/vault-plugin-auth-gcp/plugin"
 	credOIDC "github.com/hashicorp/vault-plugin-auth-jwt"
 	credAws "github.com/hashicorp/vault/builtin/credential/aws"
 	credCert "github.com/hashicorp/vault/builtin/credential/cert"
@@ -34,6 +34,9 @@ import (
 	credOkta "github.com/hashicorp/vault/builtin/credential/okta"
 	credToken "github.com/hashicorp/vault/builtin/credential/token"
 	credUserpass "github.com/hashicorp/vault/builtin/credential/userpass"
+	credAzure "github.com/hashicorp/vault/command/credential/azure"
+	credGcp "github.com/hashicorp/vault/command/credential/gcp"
+	credJWT "github.com/hashicorp/vault/command/credential/jwt"
 
 	logicalKv "github.com/hashicorp/vault-plugin-secrets-kv"
 	logicalDb "github.com/hashicorp/vault/builtin/logical/database"
@@ -98,11 +101,14 @@ const (
 	flagNameAllowedResponseHeaders = "allowed-response-headers"
 	// flagNameTokenType is the flag name used to force a specific token type
 	flagNameTokenType = "token-type"
+	// flagNameRollbackPeriod is the flag name used to tune how often a mount is rolled back
+	flagNameRollbackPeriod = "rollback-period"
 )
 
 var (
 	auditBackends = map[string]audit.Factory{
 		"file":   auditFile.Factory,
+		"otlp":   auditOTLP.Factory,
 		"socket": auditSocket.Factory,
 		"syslog": auditSyslog.Factory,
 	}
@@ -155,10 +161,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 	loginHandlers := map[string]LoginHandler{
 		"alicloud": &credAliCloud.CLIHandler{},
 		"aws":      &credAws.CLIHandler{},
+		"azure":    &credAzure.CLIHandler{},
 		"centrify": &credCentrify.CLIHandler{},
 		"cert":     &credCert.CLIHandler{},
 		"gcp":      &credGcp.CLIHandler{},
 		"github":   &credGitHub.CLIHandler{},
+		"jwt":      &credJWT.CLIHandler{},
 		"ldap":     &credLdap.CLIHandler{},
 		"oidc":     &credOIDC.CLIHandler{},
 		"okta":     &credOkta.CLIHandler{},
@@ -204,6 +212,11 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				BaseCommand: getBaseCommand(),
 			}, nil
 		},
+		"audit hash": func() (cli.Command, error) {
+			return &AuditHashCommand{
+				BaseCommand: getBaseCommand(),
+			}, nil
+		},
 		"audit list": func() (cli.Command, error) {
 			return &AuditListCommand{
 				BaseCommand: getBaseCommand(),
@@ -0,0 +1,172 @@
+package azure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+const (
+	// defaultMetadataAddress is the Azure Instance Metadata Service
+	defaultMetadataAddress = "http://169.254.169.254"
+
+	// defaultResource is the resource tokens are requested for if none is
+	// given; it must match the resource configured on the auth method
+	defaultResource = "https://management.azure.com/"
+
+	instanceAPIVersion = "2017-08-01"
+	tokenAPIVersion    = "2018-02-01"
+)
+
+// CLIHandler logs in to the Azure auth method from an Azure VM, using its
+// managed identity and the instance metadata service.
+type CLIHandler struct {
+	// for tests
+	metadataAddress string
+}
+
+func (h *CLIHandler) Auth(c *api.Client, m map[string]string) (*api.Secret, error) {
+	role, ok := m["role"]
+	if !ok {
+		return nil, errors.New("role is required")
+	}
+
+	mount, ok := m["mount"]
+	if !ok {
+		mount = "azure"
+	}
+
+	resource, ok := m["resource"]
+	if !ok {
+		resource = defaultResource
+	}
+
+	address := h.metadataAddress
+	if address == "" {
+		address = defaultMetadataAddress
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	query := url.Values{}
+	query.Set("api-version", tokenAPIVersion)
+	query.Set("resource", resource)
+	if clientID, ok := m["client_id"]; ok {
+		query.Set("client_id", clientID)
+	}
+	if err := getMetadata(address, "/metadata/identity/oauth2/token", query, &token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("metadata service returned an empty access token")
+	}
+
+	var instance struct {
+		Compute struct {
+			Name              string `json:"name"`
+			ResourceGroupName string `json:"resourceGroupName"`
+			SubscriptionID    string `json:"subscriptionId"`
+			VMScaleSetName    string `json:"vmScaleSetName"`
+		} `json:"compute"`
+	}
+	query = url.Values{}
+	query.Set("api-version", instanceAPIVersion)
+	if err := getMetadata(address, "/metadata/instance", query, &instance); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"role":                role,
+		"jwt":                 token.AccessToken,
+		"subscription_id":     instance.Compute.SubscriptionID,
+		"resource_group_name": instance.Compute.ResourceGroupName,
+	}
+	if instance.Compute.VMScaleSetName != "" {
+		data["vmss_name"] = instance.Compute.VMScaleSetName
+	} else {
+		data["vm_name"] = instance.Compute.Name
+	}
+
+	path := fmt.Sprintf("auth/%s/login", mount)
+	secret, err := c.Logical().Write(path, data)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("empty response from credential provider")
+	}
+
+	return secret, nil
+}
+
+// getMetadata queries the instance metadata service and decodes the JSON
+// response into out
+func getMetadata(address, path string, query url.Values, out interface{}) error {
+	req, err := http.NewRequest("GET", address+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Metadata", "true")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach the instance metadata service: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("instance metadata service returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+func (h *CLIHandler) Help() string {
+	help := `
+Usage: vault login -method=azure [CONFIG K=V...]
+
+  The Azure auth method allows Azure virtual machines to authenticate using
+  their managed service identity. The access token and the details of the
+  virtual machine are read from the instance metadata service, so this
+  command must be run on the virtual machine itself.
+
+  Authenticate as the "dev-role" role:
+
+      $ vault login -method=azure role=dev-role
+
+Configuration:
+
+  role=<string>
+      Required. Name of the role to request a token against.
+
+  mount=<string>
+      Path where the Azure auth method is mounted. This is usually provided
+      via the -path flag in the "vault login" command, but it can be specified
+      here as well. If specified here, it takes precedence over the value for
+      -path. The default value is "azure".
+
+  resource=<string>
+      The resource to request the access token for. Must match the resource
+      configured on the auth method. The default is
+      "https://management.azure.com/".
+
+  client_id=<string>
+      Client ID of a user-assigned managed identity to use instead of the
+      system-assigned identity.
+`
+
+	return strings.TrimSpace(help)
+}
@@ -0,0 +1,41 @@
+package azure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/metadata/instance":
+			w.Write([]byte(`{"compute": {"name": "vm1", "resourceGroupName": "rg", "subscriptionId": "sub"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var instance struct {
+		Compute struct {
+			Name              string `json:"name"`
+			ResourceGroupName string `json:"resourceGroupName"`
+			SubscriptionID    string `json:"subscriptionId"`
+		} `json:"compute"`
+	}
+	if err := getMetadata(srv.URL, "/metadata/instance", nil, &instance); err != nil {
+		t.Fatal(err)
+	}
+	if instance.Compute.Name != "vm1" || instance.Compute.ResourceGroupName != "rg" || instance.Compute.SubscriptionID != "sub" {
+		t.Fatalf("bad: %#v", instance)
+	}
+
+	if err := getMetadata(srv.URL, "/metadata/other", nil, &instance); err == nil {
+		t.Fatal("expected error")
+	}
+}
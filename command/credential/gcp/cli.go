@@ -0,0 +1,177 @@
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	gcpauth "github.com/hashicorp/vault-plugin-auth-gcp/plugin"
+	"github.com/hashicorp/vault/api"
+)
+
+const (
+	// defaultMetadataHost is the GCE metadata server, overridable with the
+	// same environment variable the GCP client libraries use
+	defaultMetadataHost = "metadata.google.internal"
+	metadataHostEnv     = "GCE_METADATA_HOST"
+
+	identityPath = "/computeMetadata/v1/instance/service-accounts/%s/identity"
+)
+
+// CLIHandler logs in to the GCP auth method. IAM logins are delegated to the
+// plugin's own handler; GCE logins fetch an instance identity token from the
+// metadata server.
+type CLIHandler struct {
+	iam gcpauth.CLIHandler
+}
+
+func (h *CLIHandler) Auth(c *api.Client, m map[string]string) (*api.Secret, error) {
+	switch m["type"] {
+	case "", "iam":
+		return h.iam.Auth(c, m)
+	case "gce":
+	default:
+		return nil, fmt.Errorf("unknown login type %q; must be iam or gce", m["type"])
+	}
+
+	role, ok := m["role"]
+	if !ok {
+		return nil, errors.New("role is required")
+	}
+
+	mount, ok := m["mount"]
+	if !ok {
+		mount = "gcp"
+	}
+
+	serviceAccount, ok := m["service_account"]
+	if !ok {
+		serviceAccount = "default"
+	}
+
+	host := os.Getenv(metadataHostEnv)
+	if host == "" {
+		host = defaultMetadataHost
+	}
+
+	loginToken, err := getInstanceIdentityToken(host, serviceAccount, role)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("auth/%s/login", mount)
+	secret, err := c.Logical().Write(path, map[string]interface{}{
+		"role": role,
+		"jwt":  loginToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("empty response from credential provider")
+	}
+
+	return secret, nil
+}
+
+// getInstanceIdentityToken requests a signed identity token for the instance
+// from the metadata server, with the audience the GCP auth method expects
+func getInstanceIdentityToken(host, serviceAccount, role string) (string, error) {
+	query := url.Values{}
+	query.Set("audience", fmt.Sprintf("http://vault/%s", role))
+	query.Set("format", "full")
+
+	u := url.URL{
+		Scheme:   "http",
+		Host:     host,
+		Path:     fmt.Sprintf(identityPath, serviceAccount),
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach the GCE metadata server: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata server returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+func (h *CLIHandler) Help() string {
+	help := `
+Usage: vault login -method=gcp [CONFIG K=V...]
+
+  The GCP auth method allows GCP IAM service accounts and GCE instances to
+  authenticate to Vault.
+
+  IAM logins use a JWT signed with the credentials of a service account, given
+  explicitly or found through Application Default Credentials. GCE logins use
+  the identity token of the instance the command runs on, fetched from the
+  metadata server.
+
+  Authenticate using Application Default Credentials:
+
+      $ vault login -method=gcp role=my-iam-role
+
+  Authenticate using explicitly passed-in credentials:
+
+      $ vault login -method=gcp role=my-iam-role credentials=@path/to/creds
+
+  Authenticate a GCE instance:
+
+      $ vault login -method=gcp type=gce role=my-gce-role
+
+Configuration:
+
+  role=<string>
+      Required. The name of the role you're requesting a token for.
+
+  mount=<string>
+      Path where the GCP auth method is mounted. This is usually provided via
+      the -path flag in the "vault login" command, but it can be specified
+      here as well. If specified here, it takes precedence over the value for
+      -path. The default value is "gcp".
+
+  type=<string>
+      The type of login, either "iam" or "gce". The default is "iam".
+
+  service_account=<string>
+      For IAM logins, the service account to generate a JWT for; defaults to
+      the "client_email" of the credentials. For GCE logins, the instance
+      service account to request the identity token for; defaults to the
+      instance's default service account.
+
+  credentials=<string>
+      IAM logins only. GCP credentials in JSON string format (not
+      recommended on the command line).
+
+  jwt_exp=<minutes>
+      IAM logins only. Time until the generated JWT expires in minutes. Must
+      be within the role's max_jwt_exp. The default is 15 minutes.
+
+  project=<string>
+      IAM logins only. Project of the service account. Defaults to the
+      credentials' "project_id".
+`
+
+	return strings.TrimSpace(help)
+}
@@ -0,0 +1,39 @@
+package gcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetInstanceIdentityToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/computeMetadata/v1/instance/service-accounts/default/identity" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if aud := r.URL.Query().Get("audience"); aud != "http://vault/my-role" {
+			t.Errorf("bad audience: %q", aud)
+		}
+		w.Write([]byte("signed.jwt.token\n"))
+	}))
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	token, err := getInstanceIdentityToken(host, "default", "my-role")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "signed.jwt.token" {
+		t.Fatalf("bad: %q", token)
+	}
+
+	if _, err := getInstanceIdentityToken(host, "other", "my-role"); err == nil {
+		t.Fatal("expected error")
+	}
+}
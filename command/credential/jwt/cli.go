@@ -0,0 +1,78 @@
+package jwt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// CLIHandler logs in to the JWT auth method with a token the client already
+// holds. Browser-based logins are handled by the "oidc" method instead.
+type CLIHandler struct{}
+
+func (h *CLIHandler) Auth(c *api.Client, m map[string]string) (*api.Secret, error) {
+	mount, ok := m["mount"]
+	if !ok {
+		mount = "jwt"
+	}
+
+	token := strings.TrimSpace(m["jwt"])
+	if token == "" {
+		return nil, fmt.Errorf("'jwt' must be specified")
+	}
+
+	data := map[string]interface{}{
+		"jwt": token,
+	}
+	if role, ok := m["role"]; ok {
+		data["role"] = role
+	}
+
+	path := fmt.Sprintf("auth/%s/login", mount)
+	secret, err := c.Logical().Write(path, data)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("empty response from credential provider")
+	}
+
+	return secret, nil
+}
+
+func (h *CLIHandler) Help() string {
+	help := `
+Usage: vault login -method=jwt [CONFIG K=V...]
+
+  The JWT auth method allows users to authenticate using a JSON Web Token
+  signed by a trusted issuer. The token is validated against the method's
+  configured keys and the bound claims of the given role.
+
+  Authenticate using a token read from a file:
+
+      $ vault login -method=jwt role=demo jwt=@token.jwt
+
+  Authenticate using a token read from stdin:
+
+      $ cat token.jwt | vault login -method=jwt role=demo jwt=-
+
+Configuration:
+
+  jwt=<string>
+      The signed JSON Web Token to log in with. Prefix with "@" to read the
+      token from a file, or use "-" to read it from stdin.
+
+  mount=<string>
+      Path where the JWT auth method is mounted. This is usually provided via
+      the -path flag in the "vault login" command, but it can be specified
+      here as well. If specified here, it takes precedence over the value for
+      -path. The default value is "jwt".
+
+  role=<string>
+      Name of the role to log in against. If unset, the method's configured
+      default role is used.
+`
+
+	return strings.TrimSpace(help)
+}
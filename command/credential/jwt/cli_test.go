@@ -0,0 +1,52 @@
+package jwt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+)
+
+func TestCLIHandler_Auth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/auth/my-jwt/login" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("bad body: %v", err)
+		}
+		if body["jwt"] != "signed.jwt.token" || body["role"] != "demo" {
+			t.Errorf("bad body: %#v", body)
+		}
+		w.Write([]byte(`{"auth": {"client_token": "s.token"}}`))
+	}))
+	defer srv.Close()
+
+	config := api.DefaultConfig()
+	config.Address = srv.URL
+	client, err := api.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &CLIHandler{}
+	secret, err := h.Auth(client, map[string]string{
+		"mount": "my-jwt",
+		"jwt":   " signed.jwt.token\n",
+		"role":  "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret.Auth == nil || secret.Auth.ClientToken != "s.token" {
+		t.Fatalf("bad: %#v", secret)
+	}
+
+	if _, err := h.Auth(client, map[string]string{"role": "demo"}); err == nil {
+		t.Fatal("expected error without a jwt")
+	}
+}
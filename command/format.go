@@ -335,6 +335,9 @@ func OutputSealStatus(ui cli.Ui, client *api.Client, status *api.SealStatusRespo
 	}
 
 	out = append(out, fmt.Sprintf("Version | %s", status.Version))
+	if status.BuildDate != "" {
+		out = append(out, fmt.Sprintf("Build Date | %s", status.BuildDate))
+	}
 
 	if status.ClusterName != "" && status.ClusterID != "" {
 		out = append(out, fmt.Sprintf("Cluster Name | %s", status.ClusterName))
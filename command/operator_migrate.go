@@ -7,6 +7,8 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/errwrap"
@@ -20,6 +22,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/pkg/errors"
 	"github.com/posener/complete"
+	"golang.org/x/sync/semaphore"
 )
 
 var _ cli.Command = (*OperatorMigrateCommand)(nil)
@@ -27,6 +30,9 @@ var _ cli.CommandAutocomplete = (*OperatorMigrateCommand)(nil)
 
 var errAbort = errors.New("Migration aborted")
 
+// defaultMigrateParallel is the default number of keys copied concurrently
+const defaultMigrateParallel = 10
+
 type OperatorMigrateCommand struct {
 	*BaseCommand
 
@@ -34,6 +40,7 @@ type OperatorMigrateCommand struct {
 	flagConfig       string
 	flagStart        string
 	flagReset        bool
+	flagMaxParallel  int
 	logger           log.Logger
 	ShutdownCh       chan struct{}
 }
@@ -86,6 +93,14 @@ func (c *OperatorMigrateCommand) Flags() *FlagSets {
 		Usage:  "Only copy keys lexicographically at or after this value.",
 	})
 
+	f.IntVar(&IntVar{
+		Name:    "max-parallel",
+		Target:  &c.flagMaxParallel,
+		Default: defaultMigrateParallel,
+		Usage: "Maximum number of keys copied concurrently. Increasing this " +
+			"can speed up migrations to backends with high latency.",
+	})
+
 	f.BoolVar(&BoolVar{
 		Name:   "reset",
 		Target: &c.flagReset,
@@ -117,6 +132,11 @@ func (c *OperatorMigrateCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.flagMaxParallel < 1 {
+		c.UI.Error(fmt.Sprintf("Argument to flag -max-parallel must be a positive integer, got %d", c.flagMaxParallel))
+		return 1
+	}
+
 	config, err := c.loadMigratorConfig(c.flagConfig)
 	if err != nil {
 		c.UI.Error(fmt.Sprintf("Error loading configuration from %s: %s", c.flagConfig, err))
@@ -194,29 +214,130 @@ func (c *OperatorMigrateCommand) migrate(config *migratorConfig) error {
 	}
 }
 
-// migrateAll copies all keys in lexicographic order.
+// migrateAll copies all keys, scanning them in lexicographic order. Up to
+// -max-parallel keys are copied concurrently, and the destination is checked
+// to hold at least as many keys as were found in the source once the scan
+// completes.
 func (c *OperatorMigrateCommand) migrateAll(ctx context.Context, from physical.Backend, to physical.Backend) error {
-	return dfsScan(ctx, from, func(ctx context.Context, path string) error {
-		if path < c.flagStart || path == storageMigrationLock || path == vault.CoreLockPath {
-			return nil
-		}
+	maxParallel := c.flagMaxParallel
+	if maxParallel < 1 {
+		maxParallel = defaultMigrateParallel
+	}
 
-		entry, err := from.Get(ctx, path)
+	copyCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-		if err != nil {
-			return errwrap.Wrapf("error reading entry: {{err}}", err)
+	var (
+		wg      sync.WaitGroup
+		errLock sync.Mutex
+		copyErr error
+		scanned uint64
+		copied  uint64
+		sem     = semaphore.NewWeighted(int64(maxParallel))
+	)
+
+	scanErr := dfsScan(copyCtx, from, func(ctx context.Context, path string) error {
+		if c.skipKey(path) {
+			return nil
 		}
+		scanned++
 
-		if entry == nil {
+		// Acquire only fails once the context is canceled, in which case the
+		// scan stops right after this key
+		if err := sem.Acquire(ctx, 1); err != nil {
 			return nil
 		}
 
-		if err := to.Put(ctx, entry); err != nil {
-			return errwrap.Wrapf("error writing entry: {{err}}", err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer sem.Release(1)
+
+			ok, err := c.migrateKey(ctx, from, to, path)
+			if err != nil {
+				errLock.Lock()
+				if copyErr == nil {
+					copyErr = err
+					cancel()
+				}
+				errLock.Unlock()
+				return
+			}
+			if ok {
+				atomic.AddUint64(&copied, 1)
+			}
+		}()
+		return nil
+	})
+
+	wg.Wait()
+
+	// A failed copy cancels the scan, so its error takes precedence over the
+	// scan failing because of the cancelation
+	if copyErr != nil {
+		return copyErr
+	}
+
+	// The migration was interrupted, so the key counts cannot be compared
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if scanErr != nil {
+		return scanErr
+	}
+
+	return c.validateCount(ctx, to, scanned, atomic.LoadUint64(&copied))
+}
+
+// skipKey returns whether the key at the given path must not be copied
+func (c *OperatorMigrateCommand) skipKey(path string) bool {
+	return path < c.flagStart || path == storageMigrationLock || path == vault.CoreLockPath
+}
+
+// migrateKey copies a single key, returning whether it still existed in the
+// source
+func (c *OperatorMigrateCommand) migrateKey(ctx context.Context, from physical.Backend, to physical.Backend, path string) (bool, error) {
+	entry, err := from.Get(ctx, path)
+	if err != nil {
+		return false, errwrap.Wrapf(fmt.Sprintf("error reading entry %q: {{err}}", path), err)
+	}
+
+	if entry == nil {
+		return false, nil
+	}
+
+	if err := to.Put(ctx, entry); err != nil {
+		return false, errwrap.Wrapf(fmt.Sprintf("error writing entry %q: {{err}}", path), err)
+	}
+	c.logger.Info("copied key", "path", path)
+	return true, nil
+}
+
+// validateCount verifies that the destination holds at least as many of the
+// migrated keys as were found in the source. The destination may hold more
+// keys if it was not empty before the migration.
+func (c *OperatorMigrateCommand) validateCount(ctx context.Context, to physical.Backend, scanned, copied uint64) error {
+	var found uint64
+	err := dfsScan(ctx, to, func(ctx context.Context, path string) error {
+		if !c.skipKey(path) {
+			found++
 		}
-		c.logger.Info("copied key", "path", path)
 		return nil
 	})
+	if err != nil {
+		return errwrap.Wrapf("error counting keys in the destination: {{err}}", err)
+	}
+
+	if found < scanned {
+		return fmt.Errorf("destination contains %d keys, but %d keys were found in the source and %d keys were copied", found, scanned, copied)
+	}
+	if copied < scanned {
+		c.logger.Warn("keys were deleted from the source during the migration", "scanned", scanned, "copied", copied)
+	}
+
+	c.logger.Info("migration complete", "scanned", scanned, "copied", copied)
+	return nil
 }
 
 func (c *OperatorMigrateCommand) newBackend(kind string, conf map[string]string) (physical.Backend, error) {
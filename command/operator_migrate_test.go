@@ -108,6 +108,98 @@ func TestMigration(t *testing.T) {
 		}
 	})
 
+	t.Run("Max parallel option", func(t *testing.T) {
+		for _, maxParallel := range []int{1, 50} {
+			data := generateData()
+
+			from, err := physicalBackends["inmem"](map[string]string{}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := storeData(from, data); err != nil {
+				t.Fatal(err)
+			}
+
+			to, err := physicalBackends["inmem"](map[string]string{}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := OperatorMigrateCommand{
+				logger:          log.NewNullLogger(),
+				flagMaxParallel: maxParallel,
+			}
+			if err := cmd.migrateAll(context.Background(), from, to); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := compareStoredData(to, data, ""); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	t.Run("Count validation", func(t *testing.T) {
+		data := generateData()
+
+		from, err := physicalBackends["inmem"](map[string]string{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := storeData(from, data); err != nil {
+			t.Fatal(err)
+		}
+
+		to, err := physicalBackends["inmem"](map[string]string{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cmd := OperatorMigrateCommand{
+			logger: log.NewNullLogger(),
+		}
+		err = cmd.migrateAll(context.Background(), from, droppingPutter{to})
+		if err == nil || !strings.Contains(err.Error(), "keys were found in the source") {
+			t.Fatalf("expected a key count mismatch, got: %v", err)
+		}
+
+		// Keys that cannot be read back from the source are not copied, but
+		// still count as found in the source
+		to, err = physicalBackends["inmem"](map[string]string{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = cmd.migrateAll(context.Background(), vanishingGetter{from}, to)
+		if err == nil || !strings.Contains(err.Error(), "keys were found in the source") {
+			t.Fatalf("expected a key count mismatch, got: %v", err)
+		}
+	})
+
+	t.Run("Copy error", func(t *testing.T) {
+		from, err := physicalBackends["inmem"](map[string]string{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := storeData(from, map[string][]byte{"a": []byte("a"), "b/c": []byte("c")}); err != nil {
+			t.Fatal(err)
+		}
+
+		to, err := physicalBackends["inmem"](map[string]string{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Copying "a" fails, and listing "b/" only returns once that failure
+		// has canceled the scan
+		cmd := OperatorMigrateCommand{
+			logger: log.NewNullLogger(),
+		}
+		err = cmd.migrateAll(context.Background(), &cancelingLister{Backend: from}, failingPutter{to})
+		if err == nil || !strings.Contains(err.Error(), "error writing entry") {
+			t.Fatalf("expected the write error, got: %v", err)
+		}
+	})
+
 	t.Run("Config parsing", func(t *testing.T) {
 		cmd := new(OperatorMigrateCommand)
 
@@ -254,6 +346,57 @@ func (l randomLister) Delete(ctx context.Context, path string) error {
 	return l.b.Delete(ctx, path)
 }
 
+// droppingPutter wraps a physical backend, silently discarding writes of
+// keys with an even length.
+type droppingPutter struct {
+	physical.Backend
+}
+
+func (d droppingPutter) Put(ctx context.Context, entry *physical.Entry) error {
+	if len(entry.Key)%2 == 0 {
+		return nil
+	}
+	return d.Backend.Put(ctx, entry)
+}
+
+// vanishingGetter wraps a physical backend, reporting keys with an even
+// length as missing.
+type vanishingGetter struct {
+	physical.Backend
+}
+
+func (v vanishingGetter) Get(ctx context.Context, key string) (*physical.Entry, error) {
+	if len(key)%2 == 0 {
+		return nil, nil
+	}
+	return v.Backend.Get(ctx, key)
+}
+
+// failingPutter wraps a physical backend, failing every write.
+type failingPutter struct {
+	physical.Backend
+}
+
+func (f failingPutter) Put(ctx context.Context, entry *physical.Entry) error {
+	return fmt.Errorf("put of %q failed", entry.Key)
+}
+
+// cancelingLister wraps a physical backend. Only the first List succeeds;
+// later calls wait for the context to be canceled and return its error.
+type cancelingLister struct {
+	physical.Backend
+	listed bool
+}
+
+func (l *cancelingLister) List(ctx context.Context, path string) ([]string, error) {
+	if !l.listed {
+		l.listed = true
+		return l.Backend.List(ctx, path)
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
 // generateData creates a map of 500 random keys and values
 func generateData() map[string][]byte {
 	result := make(map[string][]byte)
@@ -25,6 +25,7 @@ type SecretsTuneCommand struct {
 	flagListingVisibility        string
 	flagMaxLeaseTTL              time.Duration
 	flagOptions                  map[string]string
+	flagRollbackPeriod           string
 	flagVersion                  int
 }
 
@@ -104,6 +105,14 @@ func (c *SecretsTuneCommand) Flags() *FlagSets {
 			"TTL, or a previously configured value for the secrets engine.",
 	})
 
+	f.StringVar(&StringVar{
+		Name:   flagNameRollbackPeriod,
+		Target: &c.flagRollbackPeriod,
+		Usage: "How often the rollback manager acts on this secrets engine. Accepts a " +
+			"duration, \"system\" to use the server's rollback interval, or " +
+			"\"disable\" to turn off scheduled rollbacks.",
+	})
+
 	f.StringMapVar(&StringMapVar{
 		Name:       "options",
 		Target:     &c.flagOptions,
@@ -187,6 +196,10 @@ func (c *SecretsTuneCommand) Run(args []string) int {
 		if fl.Name == flagNameListingVisibility {
 			mountConfigInput.ListingVisibility = c.flagListingVisibility
 		}
+
+		if fl.Name == flagNameRollbackPeriod {
+			mountConfigInput.RollbackPeriod = c.flagRollbackPeriod
+		}
 	})
 
 	if err := client.Sys().TuneMount(mountPath, mountConfigInput); err != nil {
@@ -111,9 +111,19 @@ type ServerCommand struct {
 
 type ServerListener struct {
 	net.Listener
-	config             map[string]interface{}
-	maxRequestSize     int64
-	maxRequestDuration time.Duration
+	config                     map[string]interface{}
+	maxRequestSize             int64
+	maxRequestDuration         time.Duration
+	customResponseHeaders      map[string]http.Header
+	disableResponseCompression bool
+	responseCompressionMinSize int
+	telemetryOnly              bool
+	requestIDHeader            string
+	allowCacheBypass           bool
+	maxJSONDepth               int
+	maxJSONStringValueLength   int
+	maxJSONObjectEntryCount    int
+	maxJSONArrayElementCount   int
 }
 
 func (c *ServerCommand) Synopsis() string {
@@ -506,6 +516,28 @@ func (c *ServerCommand) Run(args []string) int {
 		return 1
 	}
 
+	// Wrap the backend so that every storage operation emits latency and
+	// error metrics tagged with the backend type
+	_, txnOK := backend.(physical.Transactional)
+	_, storagePaginated := backend.(physical.Paginated)
+	var wrappedBackend physical.Backend
+	if txnOK {
+		wrappedBackend = physical.NewTransactionalMetricsBackend(backend, config.Storage.Type)
+	} else {
+		wrappedBackend = physical.NewMetricsBackend(backend, config.Storage.Type)
+	}
+
+	// Retry transient storage errors if configured to do so
+	if config.Storage.Retry != nil {
+		retryLogger := namedStorageLogger.Named("retry")
+		allLoggers = append(allLoggers, retryLogger)
+		if txnOK {
+			wrappedBackend = physical.NewTransactionalRetryBackend(wrappedBackend, *config.Storage.Retry, retryLogger)
+		} else {
+			wrappedBackend = physical.NewRetryBackend(wrappedBackend, *config.Storage.Retry, retryLogger)
+		}
+	}
+
 	infoKeys := make([]string, 0, 10)
 	info := make(map[string]string)
 	info["log level"] = logLevelString
@@ -578,30 +610,36 @@ func (c *ServerCommand) Run(args []string) int {
 	}
 
 	coreConfig := &vault.CoreConfig{
-		Physical:                  backend,
-		RedirectAddr:              config.Storage.RedirectAddr,
-		HAPhysical:                nil,
-		Seal:                      barrierSeal,
-		AuditBackends:             c.AuditBackends,
-		CredentialBackends:        c.CredentialBackends,
-		LogicalBackends:           c.LogicalBackends,
-		Logger:                    c.logger,
-		DisableCache:              config.DisableCache,
-		DisableMlock:              config.DisableMlock,
-		MaxLeaseTTL:               config.MaxLeaseTTL,
-		DefaultLeaseTTL:           config.DefaultLeaseTTL,
-		ClusterName:               config.ClusterName,
-		CacheSize:                 config.CacheSize,
-		PluginDirectory:           config.PluginDirectory,
-		EnableUI:                  config.EnableUI,
-		EnableRaw:                 config.EnableRawEndpoint,
-		DisableSealWrap:           config.DisableSealWrap,
-		DisablePerformanceStandby: config.DisablePerformanceStandby,
-		DisableIndexing:           config.DisableIndexing,
-		AllLoggers:                allLoggers,
-		BuiltinRegistry:           builtinplugins.Registry,
-		DisableKeyEncodingChecks:  config.DisablePrintableCheck,
-		MetricsHelper:             metricsHelper,
+		Physical:                    wrappedBackend,
+		RedirectAddr:                config.Storage.RedirectAddr,
+		HAPhysical:                  nil,
+		Seal:                        barrierSeal,
+		AuditBackends:               c.AuditBackends,
+		CredentialBackends:          c.CredentialBackends,
+		LogicalBackends:             c.LogicalBackends,
+		Logger:                      c.logger,
+		DisableCache:                config.DisableCache,
+		DisableMlock:                config.DisableMlock,
+		MaxLeaseTTL:                 config.MaxLeaseTTL,
+		DefaultLeaseTTL:             config.DefaultLeaseTTL,
+		ClusterName:                 config.ClusterName,
+		CacheSize:                   config.CacheSize,
+		PluginDirectory:             config.PluginDirectory,
+		EnableUI:                    config.EnableUI,
+		EnableRaw:                   config.EnableRawEndpoint,
+		DisableSealWrap:             config.DisableSealWrap,
+		DisablePerformanceStandby:   config.DisablePerformanceStandby,
+		DisableIndexing:             config.DisableIndexing,
+		AllLoggers:                  allLoggers,
+		BuiltinRegistry:             builtinplugins.Registry,
+		DisableKeyEncodingChecks:    config.DisablePrintableCheck,
+		StorageType:                 config.Storage.Type,
+		StoragePaginated:            storagePaginated,
+		MaxEntrySize:                config.Storage.MaxEntrySize,
+		StorageCompressionType:      config.Storage.CompressionType,
+		StorageCompressionThreshold: config.Storage.CompressionThreshold,
+		MetricsHelper:               metricsHelper,
+		RollbackPeriod:              config.RollbackPeriod,
 	}
 	if c.flagDev {
 		coreConfig.DevToken = c.flagDevRootTokenID
@@ -613,10 +651,10 @@ func (c *ServerCommand) Run(args []string) int {
 		}
 		if c.flagDevLatency > 0 {
 			injectLatency := time.Duration(c.flagDevLatency) * time.Millisecond
-			if _, txnOK := backend.(physical.Transactional); txnOK {
-				coreConfig.Physical = physical.NewTransactionalLatencyInjector(backend, injectLatency, c.flagDevLatencyJitter, c.logger)
+			if txnOK {
+				coreConfig.Physical = physical.NewTransactionalLatencyInjector(wrappedBackend, injectLatency, c.flagDevLatencyJitter, c.logger)
 			} else {
-				coreConfig.Physical = physical.NewLatencyInjector(backend, injectLatency, c.flagDevLatencyJitter, c.logger)
+				coreConfig.Physical = physical.NewLatencyInjector(wrappedBackend, injectLatency, c.flagDevLatencyJitter, c.logger)
 			}
 		}
 	}
@@ -689,7 +727,7 @@ func (c *ServerCommand) Run(args []string) int {
 	if coreConfig.HAPhysical != nil && coreConfig.HAPhysical.HAEnabled() {
 		detect, ok = coreConfig.HAPhysical.(physical.RedirectDetect)
 	} else {
-		detect, ok = coreConfig.Physical.(physical.RedirectDetect)
+		detect, ok = backend.(physical.RedirectDetect)
 	}
 	if ok && coreConfig.RedirectAddr == "" {
 		redirect, err := c.detectRedirect(detect, config)
@@ -840,7 +878,19 @@ CLUSTER_SYNTHESIS_COMPLETE:
 			(*c.reloadFuncs)["listener|"+lnConfig.Type] = relSlice
 		}
 
-		if !disableClustering && lnConfig.Type == "tcp" {
+		var telemetryOnly bool
+		if valRaw, ok := lnConfig.Config["telemetry_only"]; ok {
+			telemetryOnly, err = parseutil.ParseBool(valRaw)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Could not parse telemetry_only value %v", valRaw))
+				return 1
+			}
+			props["telemetry_only"] = strconv.FormatBool(telemetryOnly)
+		}
+
+		// Telemetry listeners do not serve the API, so they are not used to
+		// derive cluster addresses
+		if !disableClustering && lnConfig.Type == "tcp" && !telemetryOnly {
 			var addrRaw interface{}
 			var addr string
 			var ok bool
@@ -896,11 +946,89 @@ CLUSTER_SYNTHESIS_COMPLETE:
 		}
 		props["max_request_duration"] = fmt.Sprintf("%s", maxRequestDuration.String())
 
+		var disableResponseCompression bool
+		if valRaw, ok := lnConfig.Config["disable_response_compression"]; ok {
+			disableResponseCompression, err = parseutil.ParseBool(valRaw)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Could not parse disable_response_compression value %v", valRaw))
+				return 1
+			}
+		}
+		props["disable_response_compression"] = strconv.FormatBool(disableResponseCompression)
+
+		responseCompressionMinSize := vaulthttp.DefaultResponseCompressionMinSize
+		if valRaw, ok := lnConfig.Config["response_compression_min_size"]; ok {
+			val, err := parseutil.ParseInt(valRaw)
+			if err != nil || val < 0 {
+				c.UI.Error(fmt.Sprintf("Could not parse response_compression_min_size value %v", valRaw))
+				return 1
+			}
+			responseCompressionMinSize = int(val)
+		}
+
+		var maxJSONDepth, maxJSONStringValueLength, maxJSONObjectEntryCount, maxJSONArrayElementCount int
+		for key, val := range map[string]*int{
+			"max_json_depth":               &maxJSONDepth,
+			"max_json_string_value_length": &maxJSONStringValueLength,
+			"max_json_object_entry_count":  &maxJSONObjectEntryCount,
+			"max_json_array_element_count": &maxJSONArrayElementCount,
+		} {
+			if valRaw, ok := lnConfig.Config[key]; ok {
+				parsed, err := parseutil.ParseInt(valRaw)
+				if err != nil {
+					c.UI.Error(fmt.Sprintf("Could not parse %s value %v", key, valRaw))
+					return 1
+				}
+				*val = int(parsed)
+				props[key] = strconv.Itoa(*val)
+			}
+		}
+
+		var requestIDHeader string
+		if valRaw, ok := lnConfig.Config["request_id_header"]; ok {
+			val, ok := valRaw.(string)
+			if !ok {
+				c.UI.Error(fmt.Sprintf("Could not parse request_id_header value %v", valRaw))
+				return 1
+			}
+			requestIDHeader = val
+			props["request_id_header"] = val
+		}
+
+		var allowCacheBypass bool
+		if valRaw, ok := lnConfig.Config["allow_cache_bypass"]; ok {
+			allowCacheBypass, err = parseutil.ParseBool(valRaw)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Could not parse allow_cache_bypass value %v", valRaw))
+				return 1
+			}
+			props["allow_cache_bypass"] = strconv.FormatBool(allowCacheBypass)
+		}
+
+		var customResponseHeaders map[string]http.Header
+		if valRaw, ok := lnConfig.Config["custom_response_headers"]; ok {
+			customResponseHeaders, err = vaulthttp.ParseCustomResponseHeaders(valRaw)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Could not parse custom_response_headers: %v", err))
+				return 1
+			}
+		}
+
 		lns = append(lns, ServerListener{
-			Listener:           ln,
-			config:             lnConfig.Config,
-			maxRequestSize:     maxRequestSize,
-			maxRequestDuration: maxRequestDuration,
+			Listener:                   ln,
+			config:                     lnConfig.Config,
+			maxRequestSize:             maxRequestSize,
+			maxRequestDuration:         maxRequestDuration,
+			customResponseHeaders:      customResponseHeaders,
+			disableResponseCompression: disableResponseCompression,
+			responseCompressionMinSize: responseCompressionMinSize,
+			telemetryOnly:              telemetryOnly,
+			requestIDHeader:            requestIDHeader,
+			allowCacheBypass:           allowCacheBypass,
+			maxJSONDepth:               maxJSONDepth,
+			maxJSONStringValueLength:   maxJSONStringValueLength,
+			maxJSONObjectEntryCount:    maxJSONObjectEntryCount,
+			maxJSONArrayElementCount:   maxJSONArrayElementCount,
 		})
 
 		// Store the listener props for output later
@@ -1136,12 +1264,25 @@ CLUSTER_SYNTHESIS_COMPLETE:
 
 	// Initialize the HTTP servers
 	for _, ln := range lns {
-		handler := vaulthttp.Handler(&vault.HandlerProperties{
+		handlerProps := &vault.HandlerProperties{
 			Core:                  core,
 			MaxRequestSize:        ln.maxRequestSize,
 			MaxRequestDuration:    ln.maxRequestDuration,
 			DisablePrintableCheck: config.DisablePrintableCheck,
-		})
+			RequestIDHeader:       ln.requestIDHeader,
+			AllowCacheBypass:      ln.allowCacheBypass,
+
+			MaxJSONDepth:             ln.maxJSONDepth,
+			MaxJSONStringValueLength: ln.maxJSONStringValueLength,
+			MaxJSONObjectEntryCount:  ln.maxJSONObjectEntryCount,
+			MaxJSONArrayElementCount: ln.maxJSONArrayElementCount,
+		}
+		var handler http.Handler
+		if ln.telemetryOnly {
+			handler = vaulthttp.TelemetryHandler(handlerProps)
+		} else {
+			handler = vaulthttp.Handler(handlerProps)
+		}
 
 		// We perform validation on the config earlier, we can just cast here
 		if _, ok := ln.config["x_forwarded_for_authorized_addrs"]; ok {
@@ -1154,6 +1295,16 @@ CLUSTER_SYNTHESIS_COMPLETE:
 			}
 		}
 
+		if !ln.disableResponseCompression {
+			handler, err = vaulthttp.WrapCompressionHandler(handler, ln.responseCompressionMinSize)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error setting up response compression: %v", err))
+				return 1
+			}
+		}
+
+		handler = vaulthttp.WrapCustomResponseHeadersHandler(handler, ln.customResponseHeaders)
+
 		// server defaults
 		server := &http.Server{
 			Handler:           handler,
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/hcl"
 	"github.com/hashicorp/hcl/hcl/ast"
 	"github.com/hashicorp/vault/sdk/helper/parseutil"
+	"github.com/hashicorp/vault/sdk/physical"
 )
 
 const (
@@ -53,6 +54,9 @@ type Config struct {
 	DefaultMaxRequestDuration    time.Duration `hcl:"-"`
 	DefaultMaxRequestDurationRaw interface{}   `hcl:"default_max_request_duration"`
 
+	RollbackPeriod    time.Duration `hcl:"-"`
+	RollbackPeriodRaw interface{}   `hcl:"rollback_period"`
+
 	ClusterName         string `hcl:"cluster_name"`
 	ClusterCipherSuites string `hcl:"cluster_cipher_suites"`
 
@@ -133,11 +137,15 @@ func (l *Listener) GoString() string {
 
 // Storage is the underlying storage configuration for the server.
 type Storage struct {
-	Type              string
-	RedirectAddr      string
-	ClusterAddr       string
-	DisableClustering bool
-	Config            map[string]string
+	Type                 string
+	RedirectAddr         string
+	ClusterAddr          string
+	DisableClustering    bool
+	MaxEntrySize         int
+	CompressionType      string
+	CompressionThreshold int
+	Retry                *physical.RetryConfig
+	Config               map[string]string
 }
 
 func (b *Storage) GoString() string {
@@ -326,6 +334,11 @@ func (c *Config) Merge(c2 *Config) *Config {
 		result.DefaultMaxRequestDuration = c2.DefaultMaxRequestDuration
 	}
 
+	result.RollbackPeriod = c.RollbackPeriod
+	if c2.RollbackPeriod != 0 {
+		result.RollbackPeriod = c2.RollbackPeriod
+	}
+
 	result.LogLevel = c.LogLevel
 	if c2.LogLevel != "" {
 		result.LogLevel = c2.LogLevel
@@ -476,6 +489,15 @@ func ParseConfig(d string, logger log.Logger) (*Config, error) {
 		}
 	}
 
+	if result.RollbackPeriodRaw != nil {
+		if result.RollbackPeriod, err = parseutil.ParseDurationSecond(result.RollbackPeriodRaw); err != nil {
+			return nil, err
+		}
+		if result.RollbackPeriod < 0 {
+			return nil, errors.New("rollback_period cannot be negative")
+		}
+	}
+
 	if result.EnableUIRaw != nil {
 		if result.EnableUI, err = parseutil.ParseBool(result.EnableUIRaw); err != nil {
 			return nil, err
@@ -707,6 +729,35 @@ func ParseStorage(result *Config, list *ast.ObjectList, name string) error {
 		delete(m, "disable_clustering")
 	}
 
+	var maxEntrySize int
+	if v, ok := m["max_entry_size"]; ok {
+		maxEntrySize, err = strconv.Atoi(v)
+		if err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("%s.%s:", name, key))
+		}
+		delete(m, "max_entry_size")
+	}
+
+	var compressionType string
+	if v, ok := m["compression_type"]; ok {
+		compressionType = strings.ToLower(v)
+		delete(m, "compression_type")
+	}
+
+	var compressionThreshold int
+	if v, ok := m["compression_threshold"]; ok {
+		compressionThreshold, err = strconv.Atoi(v)
+		if err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("%s.%s:", name, key))
+		}
+		delete(m, "compression_threshold")
+	}
+
+	retry, err := parseStorageRetry(m)
+	if err != nil {
+		return multierror.Prefix(err, fmt.Sprintf("%s.%s:", name, key))
+	}
+
 	// Override with top-level values if they are set
 	if result.APIAddr != "" {
 		redirectAddr = result.APIAddr
@@ -721,15 +772,68 @@ func ParseStorage(result *Config, list *ast.ObjectList, name string) error {
 	}
 
 	result.Storage = &Storage{
-		RedirectAddr:      redirectAddr,
-		ClusterAddr:       clusterAddr,
-		DisableClustering: disableClustering,
-		Type:              strings.ToLower(key),
-		Config:            m,
+		RedirectAddr:         redirectAddr,
+		ClusterAddr:          clusterAddr,
+		DisableClustering:    disableClustering,
+		MaxEntrySize:         maxEntrySize,
+		CompressionType:      compressionType,
+		CompressionThreshold: compressionThreshold,
+		Retry:                retry,
+		Type:                 strings.ToLower(key),
+		Config:               m,
 	}
 	return nil
 }
 
+// parseStorageRetry pulls the retry, timeout and circuit breaker options,
+// which are common to all backends, out of a storage stanza. It returns nil
+// if none of them are set.
+func parseStorageRetry(m map[string]string) (*physical.RetryConfig, error) {
+	var retry physical.RetryConfig
+	var found bool
+	var err error
+
+	if v, ok := m["max_retries"]; ok {
+		found = true
+		if retry.MaxRetries, err = strconv.Atoi(v); err != nil {
+			return nil, errwrap.Wrapf("invalid max_retries: {{err}}", err)
+		}
+		delete(m, "max_retries")
+	}
+
+	durations := []struct {
+		key   string
+		value *time.Duration
+	}{
+		{"retry_backoff", &retry.Backoff},
+		{"max_retry_backoff", &retry.MaxBackoff},
+		{"request_timeout", &retry.Timeout},
+		{"circuit_breaker_reset_timeout", &retry.CircuitBreakerResetTimeout},
+	}
+	for _, d := range durations {
+		if v, ok := m[d.key]; ok {
+			found = true
+			if *d.value, err = parseutil.ParseDurationSecond(v); err != nil {
+				return nil, errwrap.Wrapf(fmt.Sprintf("invalid %s: {{err}}", d.key), err)
+			}
+			delete(m, d.key)
+		}
+	}
+
+	if v, ok := m["circuit_breaker_threshold"]; ok {
+		found = true
+		if retry.CircuitBreakerThreshold, err = strconv.Atoi(v); err != nil {
+			return nil, errwrap.Wrapf("invalid circuit_breaker_threshold: {{err}}", err)
+		}
+		delete(m, "circuit_breaker_threshold")
+	}
+
+	if !found {
+		return nil, nil
+	}
+	return &retry, nil
+}
+
 func parseHAStorage(result *Config, list *ast.ObjectList, name string) error {
 	if len(list.Items) > 1 {
 		return fmt.Errorf("only one %q block is permitted", name)
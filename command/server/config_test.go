@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/hcl"
 	"github.com/hashicorp/hcl/hcl/ast"
 	"github.com/hashicorp/vault/sdk/helper/logging"
+	"github.com/hashicorp/vault/sdk/physical"
 )
 
 func TestLoadConfigFile(t *testing.T) {
@@ -408,3 +409,50 @@ listener "tcp" {
 	}
 
 }
+
+func TestParseStorage_retry(t *testing.T) {
+	obj, _ := hcl.Parse(strings.TrimSpace(`
+storage "consul" {
+	address = "127.0.0.1:8500"
+	max_retries = "3"
+	retry_backoff = "250ms"
+	max_retry_backoff = "2s"
+	request_timeout = "10s"
+	circuit_breaker_threshold = "5"
+	circuit_breaker_reset_timeout = "1m"
+}`))
+
+	var config Config
+	list, _ := obj.Node.(*ast.ObjectList)
+	if err := ParseStorage(&config, list.Filter("storage"), "storage"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Storage{
+		Type: "consul",
+		Retry: &physical.RetryConfig{
+			MaxRetries:                 3,
+			Backoff:                    250 * time.Millisecond,
+			MaxBackoff:                 2 * time.Second,
+			Timeout:                    10 * time.Second,
+			CircuitBreakerThreshold:    5,
+			CircuitBreakerResetTimeout: time.Minute,
+		},
+		Config: map[string]string{
+			"address": "127.0.0.1:8500",
+		},
+	}
+
+	if !reflect.DeepEqual(config.Storage, expected) {
+		t.Fatalf("expected \n\n%#v\n\n to be \n\n%#v\n\n", config.Storage, expected)
+	}
+
+	obj, _ = hcl.Parse(strings.TrimSpace(`
+storage "consul" {
+	max_retries = "three"
+}`))
+	list, _ = obj.Node.(*ast.ObjectList)
+	if err := ParseStorage(&config, list.Filter("storage"), "storage"); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -38,15 +38,19 @@ import (
 	logicalAws "github.com/hashicorp/vault/builtin/logical/aws"
 	logicalCass "github.com/hashicorp/vault/builtin/logical/cassandra"
 	logicalConsul "github.com/hashicorp/vault/builtin/logical/consul"
+	logicalKmip "github.com/hashicorp/vault/builtin/logical/kmip"
+	logicalKube "github.com/hashicorp/vault/builtin/logical/kubernetes"
 	logicalMongo "github.com/hashicorp/vault/builtin/logical/mongodb"
 	logicalMssql "github.com/hashicorp/vault/builtin/logical/mssql"
 	logicalMysql "github.com/hashicorp/vault/builtin/logical/mysql"
 	logicalNomad "github.com/hashicorp/vault/builtin/logical/nomad"
+	logicalOpenLDAP "github.com/hashicorp/vault/builtin/logical/openldap"
 	logicalPki "github.com/hashicorp/vault/builtin/logical/pki"
 	logicalPostgres "github.com/hashicorp/vault/builtin/logical/postgresql"
 	logicalRabbit "github.com/hashicorp/vault/builtin/logical/rabbitmq"
 	logicalSsh "github.com/hashicorp/vault/builtin/logical/ssh"
 	logicalTotp "github.com/hashicorp/vault/builtin/logical/totp"
+	logicalTransform "github.com/hashicorp/vault/builtin/logical/transform"
 	logicalTransit "github.com/hashicorp/vault/builtin/logical/transit"
 )
 
@@ -102,16 +106,20 @@ func newRegistry() *registry {
 			"consul":     logicalConsul.Factory,
 			"gcp":        logicalGcp.Factory,
 			"gcpkms":     logicalGcpKms.Factory,
+			"kmip":       logicalKmip.Factory,
 			"kv":         logicalKv.Factory,
+			"kubernetes": logicalKube.Factory,
 			"mongodb":    logicalMongo.Factory,
 			"mssql":      logicalMssql.Factory,
 			"mysql":      logicalMysql.Factory,
 			"nomad":      logicalNomad.Factory,
+			"openldap":   logicalOpenLDAP.Factory,
 			"pki":        logicalPki.Factory,
 			"postgresql": logicalPostgres.Factory,
 			"rabbitmq":   logicalRabbit.Factory,
 			"ssh":        logicalSsh.Factory,
 			"totp":       logicalTotp.Factory,
+			"transform":  logicalTransform.Factory,
 			"transit":    logicalTransit.Factory,
 		},
 	}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/helper/consts"
+)
+
+// customHeadersStatusRe matches the keys allowed in a custom_response_headers
+// block besides "default": a status code such as "404" or a status code class
+// such as "4xx"
+var customHeadersStatusRe = regexp.MustCompile(`^[1-5](xx|[0-9]{2})$`)
+
+// customHeadersReserved are the headers Vault relies on that custom response
+// headers may not set. Headers starting with "X-Vault-" are reserved as well.
+var customHeadersReserved = []string{
+	"Content-Type",
+	"Content-Length",
+	"Retry-After",
+	consts.AuthHeaderName,
+}
+
+// ParseCustomResponseHeaders parses the custom_response_headers block of a
+// listener. The block maps "default", a status code class such as "4xx", or
+// a status code such as "404" to the headers added to responses with that
+// status.
+func ParseCustomResponseHeaders(raw interface{}) (map[string]http.Header, error) {
+	statuses, err := customHeadersObject(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]http.Header, len(statuses))
+	for status, headersRaw := range statuses {
+		status = strings.ToLower(status)
+		if status != "default" && !customHeadersStatusRe.MatchString(status) {
+			return nil, fmt.Errorf("invalid status %q, must be \"default\", a status code class such as \"4xx\", or a status code", status)
+		}
+
+		headers, err := customHeadersObject(headersRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid headers for status %q: %v", status, err)
+		}
+
+		parsed := make(http.Header, len(headers))
+		for name, valuesRaw := range headers {
+			if err := validateCustomHeaderName(name); err != nil {
+				return nil, err
+			}
+
+			switch values := valuesRaw.(type) {
+			case string:
+				parsed.Add(name, values)
+			case []interface{}:
+				for _, v := range values {
+					s, ok := v.(string)
+					if !ok {
+						return nil, fmt.Errorf("invalid value for header %q, must be a string or a list of strings", name)
+					}
+					parsed.Add(name, s)
+				}
+			default:
+				return nil, fmt.Errorf("invalid value for header %q, must be a string or a list of strings", name)
+			}
+		}
+		result[status] = parsed
+	}
+
+	return result, nil
+}
+
+// customHeadersObject returns the keys of an HCL object, which is decoded
+// either as a map or as a list of maps
+func customHeadersObject(raw interface{}) (map[string]interface{}, error) {
+	switch obj := raw.(type) {
+	case map[string]interface{}:
+		return obj, nil
+	case []map[string]interface{}:
+		result := make(map[string]interface{})
+		for _, m := range obj {
+			for k, v := range m {
+				result[k] = v
+			}
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("must be an object")
+	}
+}
+
+func validateCustomHeaderName(name string) error {
+	if strings.HasPrefix(strings.ToLower(name), "x-vault-") {
+		return fmt.Errorf("header %q cannot be set, headers starting with \"X-Vault-\" are reserved", name)
+	}
+	for _, reserved := range customHeadersReserved {
+		if strings.EqualFold(name, reserved) {
+			return fmt.Errorf("header %q cannot be set, it is reserved", name)
+		}
+	}
+	return nil
+}
+
+// WrapCustomResponseHeadersHandler adds the custom response headers of a
+// listener to the responses of h. For each header, the value for the exact
+// status code takes precedence over the value for the status code class,
+// which takes precedence over the default.
+func WrapCustomResponseHeadersHandler(h http.Handler, headers map[string]http.Header) http.Handler {
+	if len(headers) == 0 {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(&customHeadersResponseWriter{
+			ResponseWriter: w,
+			headers:        headers,
+		}, r)
+	})
+}
+
+// customHeadersResponseWriter sets the custom headers once the status code
+// of the response is known
+type customHeadersResponseWriter struct {
+	http.ResponseWriter
+	headers     map[string]http.Header
+	wroteHeader bool
+}
+
+func (w *customHeadersResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+
+		code := strconv.Itoa(status)
+		for _, key := range []string{"default", code[:1] + "xx", code} {
+			for name, values := range w.headers[key] {
+				w.Header()[name] = values
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *customHeadersResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *customHeadersResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/hcl"
+)
+
+func TestParseCustomResponseHeaders(t *testing.T) {
+	var config map[string]interface{}
+	err := hcl.Decode(&config, `
+custom_response_headers {
+  "default" = {
+    "Strict-Transport-Security" = ["max-age=31536000", "includeSubDomains"]
+    "X-Frame-Options" = "deny"
+  }
+  "4xx" = {
+    "Cache-Control" = "no-cache"
+  }
+  "404" = {
+    "X-Frame-Options" = "sameorigin"
+  }
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers, err := ParseCustomResponseHeaders(config["custom_response_headers"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]http.Header{
+		"default": {
+			"Strict-Transport-Security": {"max-age=31536000", "includeSubDomains"},
+			"X-Frame-Options":           {"deny"},
+		},
+		"4xx": {
+			"Cache-Control": {"no-cache"},
+		},
+		"404": {
+			"X-Frame-Options": {"sameorigin"},
+		},
+	}
+	if !reflect.DeepEqual(headers, expected) {
+		t.Fatalf("bad: got\n%#v\nexpected\n%#v", headers, expected)
+	}
+
+	for _, invalid := range []map[string]interface{}{
+		{"4xx": map[string]interface{}{"X-Vault-Token": "foo"}},
+		{"default": map[string]interface{}{"content-type": "text/plain"}},
+		{"6xx": map[string]interface{}{"X-Frame-Options": "deny"}},
+		{"40": map[string]interface{}{"X-Frame-Options": "deny"}},
+		{"default": map[string]interface{}{"X-Frame-Options": 1}},
+	} {
+		if _, err := ParseCustomResponseHeaders(invalid); err == nil {
+			t.Fatalf("expected error for %#v", invalid)
+		}
+	}
+}
+
+func TestWrapCustomResponseHeadersHandler(t *testing.T) {
+	headers := map[string]http.Header{
+		"default": {
+			"Strict-Transport-Security": {"max-age=31536000"},
+			"X-Frame-Options":           {"deny"},
+		},
+		"4xx": {
+			"X-Frame-Options": {"sameorigin"},
+		},
+		"404": {
+			"X-Custom": {"not-found"},
+		},
+	}
+
+	cases := []struct {
+		status   int
+		expected http.Header
+	}{
+		{
+			http.StatusOK,
+			http.Header{
+				"Strict-Transport-Security": {"max-age=31536000"},
+				"X-Frame-Options":           {"deny"},
+			},
+		},
+		{
+			http.StatusBadRequest,
+			http.Header{
+				"Strict-Transport-Security": {"max-age=31536000"},
+				"X-Frame-Options":           {"sameorigin"},
+			},
+		},
+		{
+			http.StatusNotFound,
+			http.Header{
+				"Strict-Transport-Security": {"max-age=31536000"},
+				"X-Frame-Options":           {"sameorigin"},
+				"X-Custom":                  {"not-found"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		status := tc.status
+		handler := WrapCustomResponseHeadersHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if status != http.StatusOK {
+				w.WriteHeader(status)
+			}
+			w.Write([]byte("body"))
+		}), headers)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/v1/sys/health", nil))
+
+		if w.Code != tc.status {
+			t.Fatalf("bad status: %d", w.Code)
+		}
+		w.HeaderMap.Del("Content-Type")
+		if !reflect.DeepEqual(w.HeaderMap, tc.expected) {
+			t.Fatalf("status %d: got\n%#v\nexpected\n%#v", tc.status, w.HeaderMap, tc.expected)
+		}
+	}
+}
@@ -13,14 +13,15 @@ import (
 	"net/textproto"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
-	"time"
 
 	"github.com/NYTimes/gziphandler"
 	assetfs "github.com/elazarl/go-bindata-assetfs"
 	"github.com/hashicorp/errwrap"
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
 	sockaddr "github.com/hashicorp/go-sockaddr"
+	uuid "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/helper/namespace"
 	"github.com/hashicorp/vault/sdk/helper/consts"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
@@ -55,14 +56,25 @@ const (
 	// soft-mandatory Sentinel policies.
 	PolicyOverrideHeaderName = "X-Vault-Policy-Override"
 
+	// CacheBypassHeaderName is the header set to request that storage reads
+	// skip the physical cache
+	CacheBypassHeaderName = "X-Vault-Cache-Bypass"
+
 	// DefaultMaxRequestSize is the default maximum accepted request size. This
 	// is to prevent a denial of service attack where no Content-Length is
 	// provided and the server is fed ever more data until it exhausts memory.
 	// Can be overridden per listener.
 	DefaultMaxRequestSize = 32 * 1024 * 1024
+
+	// DefaultResponseCompressionMinSize is the default size, in bytes, above
+	// which responses are compressed. Can be overridden per listener.
+	DefaultResponseCompressionMinSize = gziphandler.DefaultMinSize
 )
 
 var (
+	// validRequestIDRe matches the request IDs accepted from a trusted proxy
+	validRequestIDRe = regexp.MustCompile(`^[a-zA-Z0-9._:-]+$`)
+
 	// Set to false by stub_asset if the ui build tag isn't enabled
 	uiBuiltIn = true
 
@@ -151,10 +163,32 @@ func Handler(props *vault.HandlerProperties) http.Handler {
 	return printablePathCheckHandler
 }
 
+// TelemetryHandler returns an http.Handler for a listener that only serves
+// the health and metrics endpoints. Neither requires authentication on this
+// handler, so it should only be bound to an interface reachable by scrapers
+// and load balancers.
+func TelemetryHandler(props *vault.HandlerProperties) http.Handler {
+	core := props.Core
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/sys/health", handleSysHealth(core))
+	mux.Handle("/v1/sys/metrics", handleSysMetrics(core))
+
+	genericWrappedHandler := wrapGenericHandler(core, mux, props)
+
+	if props.DisablePrintableCheck {
+		return genericWrappedHandler
+	}
+	return cleanhttp.PrintablePathCheckHandler(genericWrappedHandler, nil)
+}
+
 // wrapGenericHandler wraps the handler with an extra layer of handler where
 // tasks that should be commonly handled for all the requests and/or responses
 // are performed.
-func wrapGenericHandler(core *vault.Core, h http.Handler, maxRequestSize int64, maxRequestDuration time.Duration) http.Handler {
+func wrapGenericHandler(core *vault.Core, h http.Handler, props *vault.HandlerProperties) http.Handler {
+	maxRequestSize := props.MaxRequestSize
+	maxRequestDuration := props.MaxRequestDuration
+	jsonLimits := jsonLimitsFromProps(props)
 	if maxRequestDuration == 0 {
 		maxRequestDuration = vault.DefaultMaxRequestDuration
 	}
@@ -163,6 +197,15 @@ func wrapGenericHandler(core *vault.Core, h http.Handler, maxRequestSize int64,
 		// by Vault
 		w.Header().Set("Cache-Control", "no-store")
 
+		// Assign the request an ID and return it to the client, so that the
+		// request can be found in the audit log and the server log
+		requestID, err := requestIDFromHeader(r, props.RequestIDHeader)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set(consts.RequestIDHeaderName, requestID)
+
 		// Start with the request context
 		ctx := r.Context()
 		var cancelFunc context.CancelFunc
@@ -172,7 +215,10 @@ func wrapGenericHandler(core *vault.Core, h http.Handler, maxRequestSize int64,
 		if maxRequestSize > 0 {
 			ctx = context.WithValue(ctx, "max_request_size", maxRequestSize)
 		}
+		ctx = context.WithValue(ctx, "json_limits", jsonLimits)
 		ctx = context.WithValue(ctx, "original_request_path", r.URL.Path)
+		ctx = context.WithValue(ctx, "request_id", requestID)
+		ctx = context.WithValue(ctx, "allow_cache_bypass", props.AllowCacheBypass)
 		r = r.WithContext(ctx)
 
 		switch {
@@ -198,6 +244,37 @@ func wrapGenericHandler(core *vault.Core, h http.Handler, maxRequestSize int64,
 	})
 }
 
+// requestIDFromHeader returns the value of the given request header if it is
+// set to a valid request ID, or a new request ID otherwise
+func requestIDFromHeader(r *http.Request, header string) (string, error) {
+	if header != "" {
+		if id := r.Header.Get(header); id != "" && len(id) <= 128 && validRequestIDRe.MatchString(id) {
+			return id, nil
+		}
+	}
+	return uuid.GenerateUUID()
+}
+
+// requestIDFromContext returns the ID assigned to the request by the generic
+// handler, or a new request ID if there is none
+func requestIDFromContext(ctx context.Context) (string, error) {
+	if id, ok := ctx.Value("request_id").(string); ok && id != "" {
+		return id, nil
+	}
+	return uuid.GenerateUUID()
+}
+
+// WrapCompressionHandler gzip compresses the responses of h that are at
+// least minSize bytes long, if the client accepts gzip encoding. Responses
+// that are already encoded, such as UI assets, are passed through.
+func WrapCompressionHandler(h http.Handler, minSize int) (http.Handler, error) {
+	wrapper, err := gziphandler.GzipHandlerWithOpts(gziphandler.MinSize(minSize))
+	if err != nil {
+		return nil, err
+	}
+	return wrapper(h), nil
+}
+
 func WrapForwardedForHandler(h http.Handler, authorizedAddrs []*sockaddr.SockAddrMarshaler, rejectNotPresent, rejectNonAuthz bool, hopSkips int) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		headers, headersOK := r.Header[textproto.CanonicalMIMEHeaderKey("X-Forwarded-For")]
@@ -468,7 +545,19 @@ func parseRequest(core *vault.Core, r *http.Request, w http.ResponseWriter, out
 		origBody = new(bytes.Buffer)
 		reader = ioutil.NopCloser(io.TeeReader(reader, origBody))
 	}
-	err := jsonutil.DecodeJSONFromReader(reader, out)
+	var err error
+	if limits, ok := ctx.Value("json_limits").(jsonLimits); ok {
+		var body []byte
+		body, err = ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, errwrap.Wrapf("failed to read request body: {{err}}", err)
+		}
+		if err := verifyJSONLimits(body, limits); err != nil {
+			return nil, err
+		}
+		reader = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	err = jsonutil.DecodeJSONFromReader(reader, out)
 	if err != nil && err != io.EOF {
 		return nil, errwrap.Wrapf("failed to parse JSON input: {{err}}", err)
 	}
@@ -745,6 +834,31 @@ func requestPolicyOverride(r *http.Request, req *logical.Request) error {
 	return nil
 }
 
+func requestCacheBypass(r *http.Request, req *logical.Request) error {
+	raw := r.Header.Get(CacheBypassHeaderName)
+	if raw == "" {
+		return nil
+	}
+
+	bypass, err := parseutil.ParseBool(raw)
+	if err != nil {
+		return err
+	}
+	if !bypass {
+		return nil
+	}
+
+	// Bypassing the cache puts load on the storage backend, so it must be
+	// enabled on the listener
+	allowed, _ := r.Context().Value("allow_cache_bypass").(bool)
+	if !allowed {
+		return errors.New("cache bypass is not allowed on this listener")
+	}
+
+	req.BypassCache = true
+	return nil
+}
+
 // requestWrapInfo adds the WrapInfo value to the logical.Request if wrap info exists
 func requestWrapInfo(r *http.Request, req *logical.Request) (*logical.Request, error) {
 	// First try for the header value
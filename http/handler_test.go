@@ -1,6 +1,7 @@
 package http
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -668,3 +669,153 @@ func testNonPrintable(t *testing.T, disable bool) {
 		testResponseStatus(t, resp, 400)
 	}
 }
+
+func TestHandler_responseCompression(t *testing.T) {
+	core, _, token := vault.TestCoreUnsealed(t)
+	handler, err := WrapCompressionHandler(Handler(&vault.HandlerProperties{
+		Core:           core,
+		MaxRequestSize: DefaultMaxRequestSize,
+	}), 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doRequest := func(path string, acceptGzip bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set(consts.AuthHeaderName, token)
+		if acceptGzip {
+			req.Header.Set("Accept-Encoding", "gzip")
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Large responses are compressed if the client accepts gzip
+	w := doRequest("/v1/sys/mounts", true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("bad status: %d", w.Code)
+	}
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip encoding, got headers %#v", w.Header())
+	}
+	gr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mounts map[string]interface{}
+	if err := json.NewDecoder(gr).Decode(&mounts); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := mounts["sys/"]; !ok {
+		t.Fatalf("bad: %#v", mounts)
+	}
+
+	// Otherwise they are sent as-is
+	w = doRequest("/v1/sys/mounts", false)
+	if w.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("unexpected encoding, got headers %#v", w.Header())
+	}
+
+	// Small responses are not compressed
+	w = doRequest("/v1/sys/seal-status", true)
+	if w.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("unexpected encoding, got headers %#v", w.Header())
+	}
+}
+
+func TestHandler_requestID(t *testing.T) {
+	core, _, token := vault.TestCoreUnsealed(t)
+	handler := Handler(&vault.HandlerProperties{
+		Core:            core,
+		MaxRequestSize:  DefaultMaxRequestSize,
+		RequestIDHeader: "X-Request-Id",
+	})
+
+	doRequest := func(path, token, requestID string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest("GET", path, nil)
+		if token != "" {
+			req.Header.Set(consts.AuthHeaderName, token)
+		}
+		if requestID != "" {
+			req.Header.Set("X-Request-Id", requestID)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		return w, body
+	}
+
+	// A new ID is assigned and returned in the header and the body
+	w, body := doRequest("/v1/sys/auth", token, "")
+	requestID := w.Header().Get(consts.RequestIDHeaderName)
+	if requestID == "" {
+		t.Fatal("missing request ID header")
+	}
+	if body["request_id"] != requestID {
+		t.Fatalf("expected request ID %q in body, got %#v", requestID, body["request_id"])
+	}
+
+	// The ID set by a proxy in the configured header is used
+	w, body = doRequest("/v1/sys/auth", token, "proxy-1234")
+	if w.Header().Get(consts.RequestIDHeaderName) != "proxy-1234" || body["request_id"] != "proxy-1234" {
+		t.Fatalf("expected proxy request ID, got header %q body %#v", w.Header().Get(consts.RequestIDHeaderName), body["request_id"])
+	}
+
+	// Invalid IDs are replaced
+	w, _ = doRequest("/v1/sys/auth", token, "bad id\n")
+	if requestID := w.Header().Get(consts.RequestIDHeaderName); requestID == "" || requestID == "bad id\n" {
+		t.Fatalf("bad request ID %q", requestID)
+	}
+
+	// Error responses include the ID as well
+	w, body = doRequest("/v1/sys/auth", "", "")
+	if w.Code < 400 {
+		t.Fatalf("bad status: %d", w.Code)
+	}
+	requestID = w.Header().Get(consts.RequestIDHeaderName)
+	if requestID == "" || body["request_id"] != requestID {
+		t.Fatalf("expected request ID %q in error body, got %#v", requestID, body)
+	}
+}
+
+func TestHandler_cacheBypass(t *testing.T) {
+	core, _, token := vault.TestCoreUnsealed(t)
+
+	doRequest := func(allow bool, bypass string) int {
+		handler := Handler(&vault.HandlerProperties{
+			Core:             core,
+			MaxRequestSize:   DefaultMaxRequestSize,
+			AllowCacheBypass: allow,
+		})
+		req := httptest.NewRequest("GET", "/v1/secret/foo", nil)
+		req.Header.Set(consts.AuthHeaderName, token)
+		if bypass != "" {
+			req.Header.Set(CacheBypassHeaderName, bypass)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	cases := []struct {
+		allow  bool
+		bypass string
+		status int
+	}{
+		{false, "", 404},
+		{false, "false", 404},
+		{false, "true", 400},
+		{true, "true", 404},
+		{true, "maybe", 400},
+	}
+	for _, tc := range cases {
+		if status := doRequest(tc.allow, tc.bypass); status != tc.status {
+			t.Fatalf("allow %t, header %q: expected status %d, got %d", tc.allow, tc.bypass, tc.status, status)
+		}
+	}
+}
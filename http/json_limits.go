@@ -0,0 +1,140 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/vault/vault"
+)
+
+const (
+	// DefaultMaxJSONDepth is the default maximum nesting depth of objects and
+	// arrays in a JSON request body
+	DefaultMaxJSONDepth = 300
+
+	// DefaultMaxJSONStringValueLength is the default maximum length, in
+	// bytes, of a string, including object keys, in a JSON request body
+	DefaultMaxJSONStringValueLength = 1024 * 1024
+
+	// DefaultMaxJSONObjectEntryCount is the default maximum number of entries
+	// in a single object of a JSON request body
+	DefaultMaxJSONObjectEntryCount = 10000
+
+	// DefaultMaxJSONArrayElementCount is the default maximum number of
+	// elements in a single array of a JSON request body
+	DefaultMaxJSONArrayElementCount = 10000
+)
+
+// jsonLimits bounds the structure of JSON request bodies, so that small
+// payloads cannot make Vault allocate large amounts of memory decoding them.
+// A negative limit disables the check.
+type jsonLimits struct {
+	maxDepth             int
+	maxStringValueLength int
+	maxObjectEntryCount  int
+	maxArrayElementCount int
+}
+
+// jsonLimitsFromProps returns the JSON limits of a listener, using the
+// defaults for limits that are not set
+func jsonLimitsFromProps(props *vault.HandlerProperties) jsonLimits {
+	orDefault := func(val, def int) int {
+		if val == 0 {
+			return def
+		}
+		return val
+	}
+	return jsonLimits{
+		maxDepth:             orDefault(props.MaxJSONDepth, DefaultMaxJSONDepth),
+		maxStringValueLength: orDefault(props.MaxJSONStringValueLength, DefaultMaxJSONStringValueLength),
+		maxObjectEntryCount:  orDefault(props.MaxJSONObjectEntryCount, DefaultMaxJSONObjectEntryCount),
+		maxArrayElementCount: orDefault(props.MaxJSONArrayElementCount, DefaultMaxJSONArrayElementCount),
+	}
+}
+
+// jsonContainer is an object or array being scanned
+type jsonContainer struct {
+	object    bool
+	expectKey bool
+	count     int
+}
+
+// verifyJSONLimits scans the JSON input and returns an error if it exceeds
+// any of the limits. Malformed input is left for the decoder to report.
+func verifyJSONLimits(data []byte, limits jsonLimits) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var stack []*jsonContainer
+
+	// beginValue accounts for a value in the enclosing container
+	beginValue := func() error {
+		if len(stack) == 0 {
+			return nil
+		}
+		top := stack[len(stack)-1]
+		if top.object {
+			top.expectKey = true
+			return nil
+		}
+		top.count++
+		if limits.maxArrayElementCount >= 0 && top.count > limits.maxArrayElementCount {
+			return fmt.Errorf("JSON input exceeds the maximum of %d array elements", limits.maxArrayElementCount)
+		}
+		return nil
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return nil
+		}
+
+		if s, ok := tok.(string); ok && limits.maxStringValueLength >= 0 && len(s) > limits.maxStringValueLength {
+			return fmt.Errorf("JSON input exceeds the maximum string length of %d bytes", limits.maxStringValueLength)
+		}
+
+		// Object keys are returned as strings, and are counted as entries
+		if len(stack) > 0 {
+			if top := stack[len(stack)-1]; top.object && top.expectKey {
+				if _, ok := tok.(string); ok {
+					top.expectKey = false
+					top.count++
+					if limits.maxObjectEntryCount >= 0 && top.count > limits.maxObjectEntryCount {
+						return fmt.Errorf("JSON input exceeds the maximum of %d object entries", limits.maxObjectEntryCount)
+					}
+					continue
+				}
+			}
+		}
+
+		delim, ok := tok.(json.Delim)
+		if !ok {
+			if err := beginValue(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch delim {
+		case '{', '[':
+			if err := beginValue(); err != nil {
+				return err
+			}
+			stack = append(stack, &jsonContainer{
+				object:    delim == '{',
+				expectKey: delim == '{',
+			})
+			if limits.maxDepth >= 0 && len(stack) > limits.maxDepth {
+				return fmt.Errorf("JSON input exceeds the maximum nesting depth of %d", limits.maxDepth)
+			}
+		case '}', ']':
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault/vault"
+)
+
+func TestVerifyJSONLimits(t *testing.T) {
+	limits := jsonLimits{
+		maxDepth:             3,
+		maxStringValueLength: 8,
+		maxObjectEntryCount:  2,
+		maxArrayElementCount: 3,
+	}
+
+	cases := map[string]struct {
+		input string
+		err   string
+	}{
+		"empty":          {"", ""},
+		"within limits":  {`{"a": [1, 2, {"b": "12345678"}], "c": null}`, ""},
+		"malformed":      {`{"a": [1, 2`, ""},
+		"max depth":      {`{"a": {"b": [1]}}`, ""},
+		"too deep":       {`{"a": {"b": [[1]]}}`, "nesting depth"},
+		"long value":     {`{"a": "123456789"}`, "string length"},
+		"long key":       {`{"123456789": 1}`, "string length"},
+		"too many keys":  {`{"a": 1, "b": 2, "c": 3}`, "object entries"},
+		"nested objects": {`{"a": {"x": 1, "y": 2}, "b": {"z": 1}}`, ""},
+		"long array":     {`[1, 2, 3, 4]`, "array elements"},
+		"array entries":  {`[{"a": 1, "b": 2}, [1, 2, 3], "x"]`, ""},
+	}
+
+	for name, tc := range cases {
+		err := verifyJSONLimits([]byte(tc.input), limits)
+		switch {
+		case tc.err == "" && err != nil:
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		case tc.err != "" && err == nil:
+			t.Fatalf("%s: expected error", name)
+		case tc.err != "" && !strings.Contains(err.Error(), tc.err):
+			t.Fatalf("%s: expected error containing %q, got %v", name, tc.err, err)
+		}
+	}
+
+	// Negative limits disable the checks
+	disabled := jsonLimits{-1, -1, -1, -1}
+	if err := verifyJSONLimits([]byte(`{"a": {"b": [[1, 2, 3, 4]]}, "c": "123456789", "d": 1}`), disabled); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONLimitsFromProps(t *testing.T) {
+	limits := jsonLimitsFromProps(&vault.HandlerProperties{
+		MaxJSONDepth:             10,
+		MaxJSONArrayElementCount: -1,
+	})
+	expected := jsonLimits{
+		maxDepth:             10,
+		maxStringValueLength: DefaultMaxJSONStringValueLength,
+		maxObjectEntryCount:  DefaultMaxJSONObjectEntryCount,
+		maxArrayElementCount: -1,
+	}
+	if limits != expected {
+		t.Fatalf("bad: %#v", limits)
+	}
+}
+
+func TestHandler_jsonLimits(t *testing.T) {
+	core, _, token := vault.TestCoreUnsealed(t)
+	ln, addr := TestListener(t)
+	props := &vault.HandlerProperties{
+		Core:                     core,
+		MaxJSONStringValueLength: 16,
+	}
+	TestServerWithListenerAndProperties(t, ln, addr, core, props)
+	defer ln.Close()
+
+	resp := testHttpPut(t, token, addr+"/v1/secret/foo", map[string]interface{}{
+		"data": "bar",
+	})
+	testResponseStatus(t, resp, 204)
+
+	resp = testHttpPut(t, token, addr+"/v1/secret/foo", map[string]interface{}{
+		"data": strings.Repeat("a", 17),
+	})
+	testResponseStatus(t, resp, 400)
+}
@@ -5,16 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"mime"
 	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/errwrap"
-	uuid "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/helper/namespace"
 	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/sdk/physical"
 	"github.com/hashicorp/vault/vault"
 )
 
@@ -27,6 +30,7 @@ func buildLogicalRequest(core *vault.Core, w http.ResponseWriter, r *http.Reques
 
 	var data map[string]interface{}
 	var origBody io.ReadCloser
+	var passHTTPReq bool
 
 	// Determine the operation
 	var op logical.Operation
@@ -50,6 +54,10 @@ func buildLogicalRequest(core *vault.Core, w http.ResponseWriter, r *http.Reques
 				if !strings.HasSuffix(path, "/") {
 					path += "/"
 				}
+				data, err = parseListPagination(queryVals)
+				if err != nil {
+					return nil, nil, http.StatusBadRequest, err
+				}
 			}
 		}
 
@@ -78,8 +86,12 @@ func buildLogicalRequest(core *vault.Core, w http.ResponseWriter, r *http.Reques
 
 	case "POST", "PUT":
 		op = logical.UpdateOperation
-		// Parse the request if we can
-		if op == logical.UpdateOperation {
+
+		// OCSP requests are DER encoded, so rather than parsing the body it
+		// is passed through to the backend
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/ocsp-request" {
+			passHTTPReq = true
+		} else {
 			origBody, err = parseRequest(core, r, w, &data)
 			if err == io.EOF {
 				data = nil
@@ -90,18 +102,40 @@ func buildLogicalRequest(core *vault.Core, w http.ResponseWriter, r *http.Reques
 			}
 		}
 
+	case "PATCH":
+		op = logical.PatchOperation
+
+		// Only JSON merge patches are supported
+		contentType := r.Header.Get("Content-Type")
+		if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "application/merge-patch+json" {
+			return nil, nil, http.StatusUnsupportedMediaType, fmt.Errorf("PATCH requires Content-Type of application/merge-patch+json, got %q", contentType)
+		}
+
+		origBody, err = parseRequest(core, r, w, &data)
+		if err == io.EOF {
+			data = nil
+			err = nil
+		}
+		if err != nil {
+			return nil, nil, http.StatusBadRequest, err
+		}
+
 	case "LIST":
 		op = logical.ListOperation
 		if !strings.HasSuffix(path, "/") {
 			path += "/"
 		}
+		data, err = parseListPagination(r.URL.Query())
+		if err != nil {
+			return nil, nil, http.StatusBadRequest, err
+		}
 
 	case "OPTIONS":
 	default:
 		return nil, nil, http.StatusMethodNotAllowed, nil
 	}
 
-	request_id, err := uuid.GenerateUUID()
+	request_id, err := requestIDFromContext(r.Context())
 	if err != nil {
 		return nil, nil, http.StatusBadRequest, errwrap.Wrapf("failed to generate identifier for the request: {{err}}", err)
 	}
@@ -120,6 +154,9 @@ func buildLogicalRequest(core *vault.Core, w http.ResponseWriter, r *http.Reques
 		}
 		return nil, nil, http.StatusBadRequest, errwrap.Wrapf("error performing token check: {{err}}", err)
 	}
+	if passHTTPReq {
+		req.HTTPRequest = r
+	}
 
 	req, err = requestWrapInfo(r, req)
 	if err != nil {
@@ -136,9 +173,69 @@ func buildLogicalRequest(core *vault.Core, w http.ResponseWriter, r *http.Reques
 		return nil, nil, http.StatusBadRequest, errwrap.Wrapf(fmt.Sprintf(`failed to parse %s header: {{err}}`, PolicyOverrideHeaderName), err)
 	}
 
+	err = requestCacheBypass(r, req)
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, errwrap.Wrapf(fmt.Sprintf(`failed to parse %s header: {{err}}`, CacheBypassHeaderName), err)
+	}
+
 	return req, origBody, 0, nil
 }
 
+// parseListPagination returns the request data holding the after and limit
+// query parameters of a list request, or nil if neither is set.
+func parseListPagination(queryVals url.Values) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	if after := queryVals.Get("after"); after != "" {
+		data["after"] = after
+	}
+	if limitStr := queryVals.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid limit %q: must be a non-negative integer", limitStr)
+		}
+		data["limit"] = limit
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// paginateListResponse applies the after and limit parameters of a list
+// request to the keys of its response. Backends page their listings in
+// storage with logical.ListRequestPage; this is a fallback for backends that
+// return the full list, and leaves the keys of a paged listing unchanged.
+func paginateListResponse(req *logical.Request, resp *logical.Response) {
+	if req.Operation != logical.ListOperation || req.Data == nil || resp == nil {
+		return
+	}
+
+	after, _ := req.Data["after"].(string)
+	limit, _ := req.Data["limit"].(int)
+	if after == "" && limit == 0 {
+		return
+	}
+
+	keys, ok := resp.Data["keys"].([]string)
+	if !ok {
+		return
+	}
+	keys = physical.PaginateKeys(keys, after, limit)
+	resp.Data["keys"] = keys
+
+	// Drop the info of keys that are not part of the page
+	if keyInfo, ok := resp.Data["key_info"].(map[string]interface{}); ok {
+		page := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			if info, ok := keyInfo[key]; ok {
+				page[key] = info
+			}
+		}
+		resp.Data["key_info"] = page
+	}
+}
+
 func handleLogical(core *vault.Core) http.Handler {
 	return handleLogicalInternal(core, false)
 }
@@ -155,6 +252,14 @@ func handleLogicalInternal(core *vault.Core, injectDataIntoTopLevel bool) http.H
 			return
 		}
 
+		// Reject the request if it exceeds a rate limit quota, telling the
+		// client when it may try again
+		if allowed, retryAfter := core.ApplyRateLimitQuota(r.Context(), req); !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			respondError(w, http.StatusTooManyRequests, vault.ErrRateLimitQuotaExceeded)
+			return
+		}
+
 		// Always forward requests that are using a limited use count token.
 		if core.PerfStandby() && req.ClientTokenRemainingUses > 0 {
 			if origBody != nil {
@@ -285,6 +390,8 @@ func handleLogicalInternal(core *vault.Core, injectDataIntoTopLevel bool) http.H
 			return
 		}
 
+		paginateListResponse(req, resp)
+
 		// Build the proper response
 		respondLogical(w, r, req, resp, injectDataIntoTopLevel)
 	})
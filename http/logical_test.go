@@ -259,6 +259,48 @@ func TestLogical_RawHTTP(t *testing.T) {
 	}
 }
 
+func TestLogical_Patch(t *testing.T) {
+	core, _, token := vault.TestCoreUnsealed(t)
+	ln, addr := TestServer(t, core)
+	defer ln.Close()
+	TestServerAuth(t, addr, token)
+
+	resp := testHttpPut(t, token, addr+"/v1/secret/foo", map[string]interface{}{
+		"data":  "bar",
+		"other": "baz",
+	})
+	testResponseStatus(t, resp, 204)
+
+	// Only merge patches are accepted
+	resp = testHttpData(t, "PATCH", token, addr+"/v1/secret/foo", map[string]interface{}{
+		"data": "zip",
+	}, false)
+	testResponseStatus(t, resp, http.StatusUnsupportedMediaType)
+
+	req, err := http.NewRequest("PATCH", addr+"/v1/secret/foo", strings.NewReader(`{"data": "zip", "other": null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(consts.AuthHeaderName, token)
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testResponseStatus(t, resp, 204)
+
+	resp = testHttpGet(t, token, addr+"/v1/secret/foo")
+	var actual map[string]interface{}
+	testResponseStatus(t, resp, 200)
+	testResponseBody(t, resp, &actual)
+	expected := map[string]interface{}{
+		"data": "zip",
+	}
+	if diff := deep.Equal(actual["data"], expected); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
 func TestLogical_RequestSizeLimit(t *testing.T) {
 	core, _, token := vault.TestCoreUnsealed(t)
 	ln, addr := TestServer(t, core)
@@ -317,6 +359,42 @@ func TestLogical_ListSuffix(t *testing.T) {
 	}
 }
 
+func TestLogical_ListPage(t *testing.T) {
+	core, _, token := vault.TestCoreUnsealed(t)
+	ln, addr := TestServer(t, core)
+	defer ln.Close()
+	TestServerAuth(t, addr, token)
+
+	for _, key := range []string{"a", "b", "c/d", "e"} {
+		resp := testHttpPut(t, token, addr+"/v1/secret/"+key, map[string]interface{}{
+			"data": "bar",
+		})
+		testResponseStatus(t, resp, 204)
+	}
+
+	listKeys := func(query string) []interface{} {
+		t.Helper()
+		resp := testHttpGet(t, token, addr+"/v1/secret/?list=true"+query)
+		testResponseStatus(t, resp, 200)
+		var actual map[string]interface{}
+		testResponseBody(t, resp, &actual)
+		return actual["data"].(map[string]interface{})["keys"].([]interface{})
+	}
+
+	if diff := deep.Equal(listKeys("&limit=2"), []interface{}{"a", "b"}); diff != nil {
+		t.Fatal(diff)
+	}
+	if diff := deep.Equal(listKeys("&after=b&limit=2"), []interface{}{"c/", "e"}); diff != nil {
+		t.Fatal(diff)
+	}
+	if diff := deep.Equal(listKeys("&after=c/"), []interface{}{"e"}); diff != nil {
+		t.Fatal(diff)
+	}
+
+	resp := testHttpGet(t, token, addr+"/v1/secret/?list=true&limit=-1")
+	testResponseStatus(t, resp, 400)
+}
+
 func TestLogical_RespondWithStatusCode(t *testing.T) {
 	resp := &logical.Response{
 		Data: map[string]interface{}{
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/hashicorp/vault/vault"
+)
+
+func TestSysConfigAuditing(t *testing.T) {
+	core, _, token := vault.TestCoreUnsealed(t)
+	ln, addr := TestServer(t, core)
+	defer ln.Close()
+
+	config := api.DefaultConfig()
+	config.Address = addr
+
+	client, err := api.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetToken(token)
+
+	if err := client.Sys().EnableAuditedRequestHeader("X-Forwarded-For", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Sys().EnableAuditedRequestHeader("X-Custom-Header", true); err != nil {
+		t.Fatal(err)
+	}
+
+	headers, err := client.Sys().ListAuditedRequestHeaders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 2 || headers["x-forwarded-for"] == nil || headers["x-forwarded-for"].HMAC || headers["x-custom-header"] == nil || !headers["x-custom-header"].HMAC {
+		t.Fatalf("bad: %#v", headers)
+	}
+
+	header, err := client.Sys().ReadAuditedRequestHeader("X-Custom-Header")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !header.HMAC {
+		t.Fatalf("bad: %#v", header)
+	}
+
+	// Enabling an existing header updates it
+	if err := client.Sys().EnableAuditedRequestHeader("X-Custom-Header", false); err != nil {
+		t.Fatal(err)
+	}
+	header, err = client.Sys().ReadAuditedRequestHeader("x-custom-header")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.HMAC {
+		t.Fatalf("bad: %#v", header)
+	}
+
+	if err := client.Sys().DisableAuditedRequestHeader("X-Custom-Header"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Sys().ReadAuditedRequestHeader("X-Custom-Header"); err == nil {
+		t.Fatal("expected error reading a disabled header")
+	}
+	headers, err = client.Sys().ListAuditedRequestHeaders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 1 || headers["x-forwarded-for"] == nil {
+		t.Fatalf("bad: %#v", headers)
+	}
+}
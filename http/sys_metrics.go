@@ -0,0 +1,42 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/vault/helper/metricsutil"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/vault"
+)
+
+// handleSysMetrics serves the telemetry of this node without requiring a
+// token. It is only mounted on telemetry listeners; on API listeners
+// sys/metrics is served by the system backend and is subject to ACLs.
+func handleSysMetrics(core *vault.Core) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			respondError(w, http.StatusMethodNotAllowed, nil)
+			return
+		}
+
+		helper := core.MetricsHelper()
+		if helper == nil {
+			respondError(w, http.StatusNotFound, fmt.Errorf("metrics are not available"))
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = metricsutil.FormatFromRequest(&logical.Request{
+				Headers: r.Header,
+			})
+		}
+
+		resp, err := helper.ResponseForFormat(format)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err)
+			return
+		}
+		respondRaw(w, r, resp)
+	})
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/vault/helper/metricsutil"
+	"github.com/hashicorp/vault/vault"
+)
+
+func TestTelemetryHandler(t *testing.T) {
+	inm := metrics.NewInmemSink(10*time.Second, time.Minute)
+	core, _, _ := vault.TestCoreUnsealedWithConfig(t, &vault.CoreConfig{
+		MetricsHelper: metricsutil.NewMetricsHelper(inm, false),
+	})
+	inm.SetGauge([]string{"test", "gauge"}, 1)
+
+	handler := TelemetryHandler(&vault.HandlerProperties{
+		Core:           core,
+		MaxRequestSize: DefaultMaxRequestSize,
+	})
+
+	doRequest := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	// Metrics are served without a token
+	w := doRequest("GET", "/v1/sys/metrics")
+	if w.Code != http.StatusOK {
+		t.Fatalf("bad status: %d %s", w.Code, w.Body.String())
+	}
+	var summary map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := summary["Gauges"]; !ok {
+		t.Fatalf("bad: %#v", summary)
+	}
+
+	// Prometheus is not enabled
+	w = doRequest("GET", "/v1/sys/metrics?format=prometheus")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("bad status: %d", w.Code)
+	}
+
+	w = doRequest("GET", "/v1/sys/health")
+	if w.Code != http.StatusOK {
+		t.Fatalf("bad status: %d", w.Code)
+	}
+
+	// Nothing else is served
+	for _, path := range []string{"/v1/sys/mounts", "/v1/secret/foo", "/v1/sys/seal-status", "/ui/"} {
+		if w = doRequest("GET", path); w.Code != http.StatusNotFound {
+			t.Fatalf("%s: bad status: %d", path, w.Code)
+		}
+	}
+	if w = doRequest("PUT", "/v1/sys/metrics"); w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("bad status: %d", w.Code)
+	}
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/vault/vault"
+)
+
+func TestSysQuotasRateLimit(t *testing.T) {
+	core, _, token := vault.TestCoreUnsealed(t)
+	ln, addr := TestServer(t, core)
+	defer ln.Close()
+	TestServerAuth(t, addr, token)
+
+	resp := testHttpPut(t, token, addr+"/v1/sys/quotas/rate-limit/cubbyhole", map[string]interface{}{
+		"path":     "cubbyhole/",
+		"rate":     1,
+		"interval": "1h",
+	})
+	testResponseStatus(t, resp, 204)
+
+	resp = testHttpGet(t, token, addr+"/v1/cubbyhole/foo")
+	testResponseStatus(t, resp, 404)
+
+	resp = testHttpGet(t, token, addr+"/v1/cubbyhole/foo")
+	testResponseStatus(t, resp, http.StatusTooManyRequests)
+	retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retryAfter <= 0 || retryAfter > 3600 {
+		t.Fatalf("bad Retry-After: %d", retryAfter)
+	}
+
+	// The quota can still be removed
+	resp = testHttpDelete(t, token, addr+"/v1/sys/quotas/rate-limit/cubbyhole")
+	testResponseStatus(t, resp, 204)
+
+	resp = testHttpGet(t, token, addr+"/v1/cubbyhole/foo")
+	testResponseStatus(t, resp, 404)
+}
@@ -197,6 +197,8 @@ func handleSysSealStatusRaw(core *vault.Core, w http.ResponseWriter, r *http.Req
 			Type:         core.SealAccess().BarrierType(),
 			Initialized:  false,
 			Sealed:       true,
+			Version:      version.GetVersion().VersionNumber(),
+			BuildDate:    version.BuildDate,
 			RecoverySeal: core.SealAccess().RecoveryKeySupported(),
 		})
 		return
@@ -229,6 +231,7 @@ func handleSysSealStatusRaw(core *vault.Core, w http.ResponseWriter, r *http.Req
 		Progress:     progress,
 		Nonce:        nonce,
 		Version:      version.GetVersion().VersionNumber(),
+		BuildDate:    version.BuildDate,
 		Migration:    core.IsInSealMigration(),
 		ClusterName:  clusterName,
 		ClusterID:    clusterID,
@@ -245,6 +248,7 @@ type SealStatusResponse struct {
 	Progress     int    `json:"progress"`
 	Nonce        string `json:"nonce"`
 	Version      string `json:"version"`
+	BuildDate    string `json:"build_date,omitempty"`
 	Migration    bool   `json:"migration"`
 	ClusterName  string `json:"cluster_name,omitempty"`
 	ClusterID    string `json:"cluster_id,omitempty"`
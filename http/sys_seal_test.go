@@ -68,6 +68,12 @@ func TestSysSealStatus_uninit(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 	testResponseStatus(t, resp, 200)
+
+	var actual map[string]interface{}
+	testResponseBody(t, resp, &actual)
+	if actual["initialized"] != false || actual["version"] == nil {
+		t.Fatalf("bad: %#v", actual)
+	}
 }
 
 func TestSysSeal(t *testing.T) {
@@ -136,6 +136,10 @@ func NewCassandraBackend(conf map[string]string, logger log.Logger) (physical.Ba
 		return nil, err
 	}
 
+	if err := setupCassandraHostSelection(conf, cluster); err != nil {
+		return nil, err
+	}
+
 	sess, err := cluster.CreateSession()
 	if err != nil {
 		return nil, err
@@ -151,6 +155,29 @@ func NewCassandraBackend(conf map[string]string, logger log.Logger) (physical.Ba
 	return impl, nil
 }
 
+// setupCassandraHostSelection configures which hosts queries are sent to.
+// With token aware routing, queries are sent to a replica of the partition
+// they read or write, which saves a hop through a coordinator node.
+func setupCassandraHostSelection(conf map[string]string, cluster *gocql.ClusterConfig) error {
+	policy := gocql.RoundRobinHostPolicy()
+	if localDC, ok := conf["local_datacenter"]; ok && localDC != "" {
+		policy = gocql.DCAwareRoundRobinPolicy(localDC)
+	}
+
+	if tokenAwareStr, ok := conf["token_aware_routing"]; ok {
+		tokenAware, err := strconv.Atoi(tokenAwareStr)
+		if err != nil {
+			return fmt.Errorf("'token_aware_routing' must be an integer (0 or 1)")
+		}
+		if tokenAware != 0 {
+			policy = gocql.TokenAwareHostPolicy(policy)
+		}
+	}
+
+	cluster.PoolConfig.HostSelectionPolicy = policy
+	return nil
+}
+
 func setupCassandraTLS(conf map[string]string, cluster *gocql.ClusterConfig) error {
 	tlsOnStr, ok := conf["tls"]
 	if !ok {
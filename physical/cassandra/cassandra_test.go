@@ -55,6 +55,26 @@ func TestCassandraBackendBuckets(t *testing.T) {
 	}
 }
 
+func TestCassandraBackendHostSelection(t *testing.T) {
+	cluster := gocql.NewCluster("localhost")
+	if err := setupCassandraHostSelection(map[string]string{
+		"token_aware_routing": "1",
+		"local_datacenter":    "dc1",
+	}, cluster); err != nil {
+		t.Fatal(err)
+	}
+	if cluster.PoolConfig.HostSelectionPolicy == nil {
+		t.Fatal("expected a host selection policy")
+	}
+
+	err := setupCassandraHostSelection(map[string]string{
+		"token_aware_routing": "yes",
+	}, gocql.NewCluster("localhost"))
+	if err == nil {
+		t.Fatal("expected an error for a non-integer token_aware_routing")
+	}
+}
+
 func prepareCassandraTestContainer(t *testing.T) (func(), string) {
 	if os.Getenv("CASSANDRA_HOSTS") != "" {
 		return func() {}, os.Getenv("CASSANDRA_HOSTS")
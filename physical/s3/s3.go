@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -34,6 +35,7 @@ var _ physical.Backend = (*S3Backend)(nil)
 // within an S3 bucket.
 type S3Backend struct {
 	bucket     string
+	path       string
 	kmsKeyId   string
 	client     *s3.S3
 	logger     log.Logger
@@ -52,6 +54,8 @@ func NewS3Backend(conf map[string]string, logger log.Logger) (physical.Backend,
 		}
 	}
 
+	path := conf["path"]
+
 	accessKey, ok := conf["access_key"]
 	if !ok {
 		accessKey = ""
@@ -144,6 +148,7 @@ func NewS3Backend(conf map[string]string, logger log.Logger) (physical.Backend,
 	s := &S3Backend{
 		client:     s3conn,
 		bucket:     bucket,
+		path:       path,
 		kmsKeyId:   kmsKeyId,
 		logger:     logger,
 		permitPool: physical.NewPermitPool(maxParInt),
@@ -160,7 +165,7 @@ func (s *S3Backend) Put(ctx context.Context, entry *physical.Entry) error {
 
 	putObjectInput := &s3.PutObjectInput{
 		Bucket: aws.String(s.bucket),
-		Key:    aws.String(entry.Key),
+		Key:    aws.String(path.Join(s.path, entry.Key)),
 		Body:   bytes.NewReader(entry.Value),
 	}
 
@@ -187,7 +192,7 @@ func (s *S3Backend) Get(ctx context.Context, key string) (*physical.Entry, error
 
 	resp, err := s.client.GetObject(&s3.GetObjectInput{
 		Bucket: aws.String(s.bucket),
-		Key:    aws.String(key),
+		Key:    aws.String(path.Join(s.path, key)),
 	})
 	if resp != nil && resp.Body != nil {
 		defer resp.Body.Close()
@@ -232,7 +237,7 @@ func (s *S3Backend) Delete(ctx context.Context, key string) error {
 
 	_, err := s.client.DeleteObject(&s3.DeleteObjectInput{
 		Bucket: aws.String(s.bucket),
-		Key:    aws.String(key),
+		Key:    aws.String(path.Join(s.path, key)),
 	})
 
 	if err != nil {
@@ -250,6 +255,13 @@ func (s *S3Backend) List(ctx context.Context, prefix string) ([]string, error) {
 	s.permitPool.Acquire()
 	defer s.permitPool.Release()
 
+	// Keys are stored below the configured path, and the prefix of a
+	// non-empty list is always a "folder"
+	prefix = path.Join(s.path, prefix)
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
 	params := &s3.ListObjectsV2Input{
 		Bucket:    aws.String(s.bucket),
 		Prefix:    aws.String(prefix),
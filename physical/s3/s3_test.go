@@ -18,14 +18,18 @@ import (
 )
 
 func TestDefaultS3Backend(t *testing.T) {
-	DoS3BackendTest(t, "")
+	DoS3BackendTest(t, "", "")
 }
 
 func TestS3BackendSseKms(t *testing.T) {
-	DoS3BackendTest(t, "alias/aws/s3")
+	DoS3BackendTest(t, "alias/aws/s3", "")
 }
 
-func DoS3BackendTest(t *testing.T, kmsKeyId string) {
+func TestS3BackendPath(t *testing.T) {
+	DoS3BackendTest(t, "", "test/vault")
+}
+
+func DoS3BackendTest(t *testing.T, kmsKeyId string, path string) {
 	if enabled := os.Getenv("VAULT_ACC"); enabled == "" {
 		t.Skip()
 	}
@@ -97,6 +101,7 @@ func DoS3BackendTest(t *testing.T, kmsKeyId string) {
 	b, err := NewS3Backend(map[string]string{
 		"bucket":   bucket,
 		"kmsKeyId": kmsKeyId,
+		"path":     path,
 	}, logger)
 	if err != nil {
 		t.Fatalf("err: %s", err)
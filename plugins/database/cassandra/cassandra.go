@@ -2,6 +2,7 @@ package cassandra
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
@@ -194,6 +195,57 @@ func (c *Cassandra) RevokeUser(ctx context.Context, statements dbplugin.Statemen
 	return result.ErrorOrNil()
 }
 
+// SetCredentials uses the rotation statements to set the password of an
+// existing user managed by a static role
+func (c *Cassandra) SetCredentials(ctx context.Context, statements dbplugin.Statements, staticUser dbplugin.StaticUserConfig) (username, password string, err error) {
+	rotateStatements := statements.Rotation
+	if len(rotateStatements) == 0 {
+		rotateStatements = []string{defaultRootCredentialRotationCQL}
+	}
+
+	username = staticUser.Username
+	password = staticUser.Password
+	if username == "" || password == "" {
+		return "", "", errors.New("must provide both username and password")
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	session, err := c.getConnection(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	var result *multierror.Error
+	for _, stmt := range rotateStatements {
+		for _, query := range strutil.ParseArbitraryStringSlice(stmt, ";") {
+			query = strings.TrimSpace(query)
+			if len(query) == 0 {
+				continue
+			}
+
+			err := session.Query(dbutil.QueryHelper(query, map[string]string{
+				"username": username,
+				"password": password,
+			})).Exec()
+
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if err := result.ErrorOrNil(); err != nil {
+		return "", "", err
+	}
+
+	return username, password, nil
+}
+
+// GenerateCredentials returns a new password for use with SetCredentials
+func (c *Cassandra) GenerateCredentials(ctx context.Context) (string, error) {
+	return c.GeneratePassword()
+}
+
 func (c *Cassandra) RotateRootCredentials(ctx context.Context, statements []string) (map[string]interface{}, error) {
 	// Grab the lock
 	c.Lock()
@@ -290,6 +290,16 @@ func (h *HANA) revokeUserDefault(ctx context.Context, username string) error {
 }
 
 // RotateRootCredentials is not currently supported on HANA
+// SetCredentials is not currently supported on HANA
+func (h *HANA) SetCredentials(ctx context.Context, statements dbplugin.Statements, staticUser dbplugin.StaticUserConfig) (username, password string, err error) {
+	return "", "", dbplugin.ErrPluginStaticUnsupported
+}
+
+// GenerateCredentials is not currently supported on HANA
+func (h *HANA) GenerateCredentials(ctx context.Context) (string, error) {
+	return "", dbplugin.ErrPluginStaticUnsupported
+}
+
 func (h *HANA) RotateRootCredentials(ctx context.Context, statements []string) (map[string]interface{}, error) {
 	return nil, errors.New("root credentaion rotation is not currently implemented in this database secrets engine")
 }
@@ -2,6 +2,7 @@ package influxdb
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
@@ -196,6 +197,58 @@ func (i *Influxdb) RevokeUser(ctx context.Context, statements dbplugin.Statement
 	return result.ErrorOrNil()
 }
 
+// SetCredentials uses the rotation statements to set the password of an
+// existing user managed by a static role
+func (i *Influxdb) SetCredentials(ctx context.Context, statements dbplugin.Statements, staticUser dbplugin.StaticUserConfig) (username, password string, err error) {
+	rotateStatements := statements.Rotation
+	if len(rotateStatements) == 0 {
+		rotateStatements = []string{defaultRootCredentialRotationIFQL}
+	}
+
+	username = staticUser.Username
+	password = staticUser.Password
+	if username == "" || password == "" {
+		return "", "", errors.New("must provide both username and password")
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	cli, err := i.getConnection(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	var result *multierror.Error
+	for _, stmt := range rotateStatements {
+		for _, query := range strutil.ParseArbitraryStringSlice(stmt, ";") {
+			query = strings.TrimSpace(query)
+			if len(query) == 0 {
+				continue
+			}
+
+			q := influx.NewQuery(dbutil.QueryHelper(query, map[string]string{
+				"username": username,
+				"password": password,
+			}), "", "")
+			response, err := cli.Query(q)
+			result = multierror.Append(result, err)
+			result = multierror.Append(result, response.Error())
+		}
+	}
+
+	if err := result.ErrorOrNil(); err != nil {
+		return "", "", err
+	}
+
+	return username, password, nil
+}
+
+// GenerateCredentials returns a new password for use with SetCredentials
+func (i *Influxdb) GenerateCredentials(ctx context.Context) (string, error) {
+	return i.GeneratePassword()
+}
+
 // RotateRootCredentials is useful when we try to change root credential
 func (i *Influxdb) RotateRootCredentials(ctx context.Context, statements []string) (map[string]interface{}, error) {
 	// Grab the lock
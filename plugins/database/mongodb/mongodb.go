@@ -220,6 +220,16 @@ func (m *MongoDB) RevokeUser(ctx context.Context, statements dbplugin.Statements
 	return nil
 }
 
+// SetCredentials is not currently supported on MongoDB
+func (m *MongoDB) SetCredentials(ctx context.Context, statements dbplugin.Statements, staticUser dbplugin.StaticUserConfig) (username, password string, err error) {
+	return "", "", dbplugin.ErrPluginStaticUnsupported
+}
+
+// GenerateCredentials is not currently supported on MongoDB
+func (m *MongoDB) GenerateCredentials(ctx context.Context) (string, error) {
+	return "", dbplugin.ErrPluginStaticUnsupported
+}
+
 // RotateRootCredentials is not currently supported on MongoDB
 func (m *MongoDB) RotateRootCredentials(ctx context.Context, statements []string) (map[string]interface{}, error) {
 	return nil, errors.New("root credential rotation is not currently implemented in this database secrets engine")
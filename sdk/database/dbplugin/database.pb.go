@@ -314,6 +314,53 @@ func (m *RotateRootCredentialsRequest) GetStatements() []string {
 	return nil
 }
 
+type SetCredentialsRequest struct {
+	Statements           *Statements       `protobuf:"bytes,1,opt,name=statements,proto3" json:"statements,omitempty"`
+	StaticUserConfig     *StaticUserConfig `protobuf:"bytes,2,opt,name=static_user_config,json=staticUserConfig,proto3" json:"static_user_config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetCredentialsRequest) Reset()         { *m = SetCredentialsRequest{} }
+func (m *SetCredentialsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetCredentialsRequest) ProtoMessage()    {}
+func (*SetCredentialsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cfa445f4444c6876, []int{6}
+}
+
+func (m *SetCredentialsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCredentialsRequest.Unmarshal(m, b)
+}
+func (m *SetCredentialsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCredentialsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetCredentialsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCredentialsRequest.Merge(m, src)
+}
+func (m *SetCredentialsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetCredentialsRequest.Size(m)
+}
+func (m *SetCredentialsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCredentialsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCredentialsRequest proto.InternalMessageInfo
+
+func (m *SetCredentialsRequest) GetStatements() *Statements {
+	if m != nil {
+		return m.Statements
+	}
+	return nil
+}
+
+func (m *SetCredentialsRequest) GetStaticUserConfig() *StaticUserConfig {
+	if m != nil {
+		return m.StaticUserConfig
+	}
+	return nil
+}
+
 type Statements struct {
 	// DEPRECATED, will be removed in 0.12
 	CreationStatements string `protobuf:"bytes,1,opt,name=creation_statements,json=creationStatements,proto3" json:"creation_statements,omitempty"` // Deprecated: Do not use.
@@ -327,6 +374,7 @@ type Statements struct {
 	Revocation           []string `protobuf:"bytes,6,rep,name=revocation,proto3" json:"revocation,omitempty"`
 	Rollback             []string `protobuf:"bytes,7,rep,name=rollback,proto3" json:"rollback,omitempty"`
 	Renewal              []string `protobuf:"bytes,8,rep,name=renewal,proto3" json:"renewal,omitempty"`
+	Rotation             []string `protobuf:"bytes,9,rep,name=rotation,proto3" json:"rotation,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -336,7 +384,7 @@ func (m *Statements) Reset()         { *m = Statements{} }
 func (m *Statements) String() string { return proto.CompactTextString(m) }
 func (*Statements) ProtoMessage()    {}
 func (*Statements) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cfa445f4444c6876, []int{6}
+	return fileDescriptor_cfa445f4444c6876, []int{7}
 }
 
 func (m *Statements) XXX_Unmarshal(b []byte) error {
@@ -417,6 +465,13 @@ func (m *Statements) GetRenewal() []string {
 	return nil
 }
 
+func (m *Statements) GetRotation() []string {
+	if m != nil {
+		return m.Rotation
+	}
+	return nil
+}
+
 type UsernameConfig struct {
 	DisplayName          string   `protobuf:"bytes,1,opt,name=DisplayName,proto3" json:"DisplayName,omitempty"`
 	RoleName             string   `protobuf:"bytes,2,opt,name=RoleName,proto3" json:"RoleName,omitempty"`
@@ -429,7 +484,7 @@ func (m *UsernameConfig) Reset()         { *m = UsernameConfig{} }
 func (m *UsernameConfig) String() string { return proto.CompactTextString(m) }
 func (*UsernameConfig) ProtoMessage()    {}
 func (*UsernameConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cfa445f4444c6876, []int{7}
+	return fileDescriptor_cfa445f4444c6876, []int{8}
 }
 
 func (m *UsernameConfig) XXX_Unmarshal(b []byte) error {
@@ -464,6 +519,53 @@ func (m *UsernameConfig) GetRoleName() string {
 	return ""
 }
 
+type StaticUserConfig struct {
+	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StaticUserConfig) Reset()         { *m = StaticUserConfig{} }
+func (m *StaticUserConfig) String() string { return proto.CompactTextString(m) }
+func (*StaticUserConfig) ProtoMessage()    {}
+func (*StaticUserConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cfa445f4444c6876, []int{9}
+}
+
+func (m *StaticUserConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StaticUserConfig.Unmarshal(m, b)
+}
+func (m *StaticUserConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StaticUserConfig.Marshal(b, m, deterministic)
+}
+func (m *StaticUserConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StaticUserConfig.Merge(m, src)
+}
+func (m *StaticUserConfig) XXX_Size() int {
+	return xxx_messageInfo_StaticUserConfig.Size(m)
+}
+func (m *StaticUserConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_StaticUserConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StaticUserConfig proto.InternalMessageInfo
+
+func (m *StaticUserConfig) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *StaticUserConfig) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
 type InitResponse struct {
 	Config               []byte   `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -475,7 +577,7 @@ func (m *InitResponse) Reset()         { *m = InitResponse{} }
 func (m *InitResponse) String() string { return proto.CompactTextString(m) }
 func (*InitResponse) ProtoMessage()    {}
 func (*InitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cfa445f4444c6876, []int{8}
+	return fileDescriptor_cfa445f4444c6876, []int{10}
 }
 
 func (m *InitResponse) XXX_Unmarshal(b []byte) error {
@@ -515,7 +617,7 @@ func (m *CreateUserResponse) Reset()         { *m = CreateUserResponse{} }
 func (m *CreateUserResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateUserResponse) ProtoMessage()    {}
 func (*CreateUserResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cfa445f4444c6876, []int{9}
+	return fileDescriptor_cfa445f4444c6876, []int{11}
 }
 
 func (m *CreateUserResponse) XXX_Unmarshal(b []byte) error {
@@ -561,7 +663,7 @@ func (m *TypeResponse) Reset()         { *m = TypeResponse{} }
 func (m *TypeResponse) String() string { return proto.CompactTextString(m) }
 func (*TypeResponse) ProtoMessage()    {}
 func (*TypeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cfa445f4444c6876, []int{10}
+	return fileDescriptor_cfa445f4444c6876, []int{12}
 }
 
 func (m *TypeResponse) XXX_Unmarshal(b []byte) error {
@@ -600,7 +702,7 @@ func (m *RotateRootCredentialsResponse) Reset()         { *m = RotateRootCredent
 func (m *RotateRootCredentialsResponse) String() string { return proto.CompactTextString(m) }
 func (*RotateRootCredentialsResponse) ProtoMessage()    {}
 func (*RotateRootCredentialsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cfa445f4444c6876, []int{11}
+	return fileDescriptor_cfa445f4444c6876, []int{13}
 }
 
 func (m *RotateRootCredentialsResponse) XXX_Unmarshal(b []byte) error {
@@ -628,6 +730,92 @@ func (m *RotateRootCredentialsResponse) GetConfig() []byte {
 	return nil
 }
 
+type GenerateCredentialsResponse struct {
+	Password             string   `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateCredentialsResponse) Reset()         { *m = GenerateCredentialsResponse{} }
+func (m *GenerateCredentialsResponse) String() string { return proto.CompactTextString(m) }
+func (*GenerateCredentialsResponse) ProtoMessage()    {}
+func (*GenerateCredentialsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cfa445f4444c6876, []int{14}
+}
+
+func (m *GenerateCredentialsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenerateCredentialsResponse.Unmarshal(m, b)
+}
+func (m *GenerateCredentialsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenerateCredentialsResponse.Marshal(b, m, deterministic)
+}
+func (m *GenerateCredentialsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateCredentialsResponse.Merge(m, src)
+}
+func (m *GenerateCredentialsResponse) XXX_Size() int {
+	return xxx_messageInfo_GenerateCredentialsResponse.Size(m)
+}
+func (m *GenerateCredentialsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateCredentialsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateCredentialsResponse proto.InternalMessageInfo
+
+func (m *GenerateCredentialsResponse) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type SetCredentialsResponse struct {
+	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetCredentialsResponse) Reset()         { *m = SetCredentialsResponse{} }
+func (m *SetCredentialsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetCredentialsResponse) ProtoMessage()    {}
+func (*SetCredentialsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cfa445f4444c6876, []int{15}
+}
+
+func (m *SetCredentialsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCredentialsResponse.Unmarshal(m, b)
+}
+func (m *SetCredentialsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCredentialsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetCredentialsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCredentialsResponse.Merge(m, src)
+}
+func (m *SetCredentialsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetCredentialsResponse.Size(m)
+}
+func (m *SetCredentialsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCredentialsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCredentialsResponse proto.InternalMessageInfo
+
+func (m *SetCredentialsResponse) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *SetCredentialsResponse) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -638,7 +826,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cfa445f4444c6876, []int{12}
+	return fileDescriptor_cfa445f4444c6876, []int{16}
 }
 
 func (m *Empty) XXX_Unmarshal(b []byte) error {
@@ -666,12 +854,16 @@ func init() {
 	proto.RegisterType((*RenewUserRequest)(nil), "dbplugin.RenewUserRequest")
 	proto.RegisterType((*RevokeUserRequest)(nil), "dbplugin.RevokeUserRequest")
 	proto.RegisterType((*RotateRootCredentialsRequest)(nil), "dbplugin.RotateRootCredentialsRequest")
+	proto.RegisterType((*SetCredentialsRequest)(nil), "dbplugin.SetCredentialsRequest")
 	proto.RegisterType((*Statements)(nil), "dbplugin.Statements")
 	proto.RegisterType((*UsernameConfig)(nil), "dbplugin.UsernameConfig")
+	proto.RegisterType((*StaticUserConfig)(nil), "dbplugin.StaticUserConfig")
 	proto.RegisterType((*InitResponse)(nil), "dbplugin.InitResponse")
 	proto.RegisterType((*CreateUserResponse)(nil), "dbplugin.CreateUserResponse")
 	proto.RegisterType((*TypeResponse)(nil), "dbplugin.TypeResponse")
 	proto.RegisterType((*RotateRootCredentialsResponse)(nil), "dbplugin.RotateRootCredentialsResponse")
+	proto.RegisterType((*GenerateCredentialsResponse)(nil), "dbplugin.GenerateCredentialsResponse")
+	proto.RegisterType((*SetCredentialsResponse)(nil), "dbplugin.SetCredentialsResponse")
 	proto.RegisterType((*Empty)(nil), "dbplugin.Empty")
 }
 
@@ -680,52 +872,59 @@ func init() {
 }
 
 var fileDescriptor_cfa445f4444c6876 = []byte{
-	// 716 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xd1, 0x4e, 0xdb, 0x4a,
-	0x10, 0x95, 0x93, 0x00, 0xc9, 0x80, 0x80, 0xec, 0x05, 0x64, 0xf9, 0x72, 0xef, 0x45, 0xd6, 0x15,
-	0xa5, 0xaa, 0x6a, 0x57, 0xd0, 0x8a, 0x8a, 0x87, 0x56, 0x25, 0x54, 0x55, 0xa5, 0x8a, 0x87, 0x05,
-	0x5e, 0xaa, 0x4a, 0x68, 0xe3, 0x2c, 0x89, 0x85, 0xe3, 0x75, 0xbd, 0xeb, 0xd0, 0xf4, 0x07, 0xda,
-	0xcf, 0xe8, 0xe7, 0xf4, 0xb1, 0x9f, 0x54, 0x79, 0xe3, 0xf5, 0x6e, 0xe2, 0x50, 0x1e, 0x68, 0xdf,
-	0x3c, 0x3b, 0x73, 0x66, 0xce, 0x1c, 0xcf, 0xce, 0xc2, 0xff, 0xbc, 0x77, 0xed, 0xf7, 0x88, 0x20,
-	0x5d, 0xc2, 0xa9, 0xdf, 0xeb, 0x26, 0x51, 0xd6, 0x0f, 0xe3, 0xf2, 0xc4, 0x4b, 0x52, 0x26, 0x18,
-	0x6a, 0x2a, 0x87, 0xf3, 0x5f, 0x9f, 0xb1, 0x7e, 0x44, 0x7d, 0x79, 0xde, 0xcd, 0xae, 0x7c, 0x11,
-	0x0e, 0x29, 0x17, 0x64, 0x98, 0x4c, 0x42, 0xdd, 0x0f, 0xd0, 0x7e, 0x1b, 0x87, 0x22, 0x24, 0x51,
-	0xf8, 0x99, 0x62, 0xfa, 0x31, 0xa3, 0x5c, 0xa0, 0x2d, 0x58, 0x0c, 0x58, 0x7c, 0x15, 0xf6, 0x6d,
-	0x6b, 0xc7, 0xda, 0x5b, 0xc1, 0x85, 0x85, 0x1e, 0x41, 0x7b, 0x44, 0xd3, 0xf0, 0x6a, 0x7c, 0x19,
-	0xb0, 0x38, 0xa6, 0x81, 0x08, 0x59, 0x6c, 0xd7, 0x76, 0xac, 0xbd, 0x26, 0x5e, 0x9f, 0x38, 0x3a,
-	0xe5, 0xf9, 0x51, 0xcd, 0xb6, 0x5c, 0x0c, 0xcb, 0x79, 0xf6, 0xdf, 0x99, 0xd7, 0xfd, 0x6e, 0x41,
-	0xbb, 0x93, 0x52, 0x22, 0xe8, 0x05, 0xa7, 0xa9, 0x4a, 0xfd, 0x14, 0x80, 0x0b, 0x22, 0xe8, 0x90,
-	0xc6, 0x82, 0xcb, 0xf4, 0xcb, 0xfb, 0x1b, 0x9e, 0xd2, 0xc1, 0x3b, 0x2b, 0x7d, 0xd8, 0x88, 0x43,
-	0xaf, 0x60, 0x2d, 0xe3, 0x34, 0x8d, 0xc9, 0x90, 0x5e, 0x16, 0xcc, 0x6a, 0x12, 0x6a, 0x6b, 0xe8,
-	0x45, 0x11, 0xd0, 0x91, 0x7e, 0xbc, 0x9a, 0x4d, 0xd9, 0xe8, 0x08, 0x80, 0x7e, 0x4a, 0xc2, 0x94,
-	0x48, 0xd2, 0x75, 0x89, 0x76, 0xbc, 0x89, 0xec, 0x9e, 0x92, 0xdd, 0x3b, 0x57, 0xb2, 0x63, 0x23,
-	0xda, 0xfd, 0x66, 0xc1, 0x3a, 0xa6, 0x31, 0xbd, 0xb9, 0x7f, 0x27, 0x0e, 0x34, 0x15, 0x31, 0xd9,
-	0x42, 0x0b, 0x97, 0xf6, 0xbd, 0x28, 0x52, 0x68, 0x63, 0x3a, 0x62, 0xd7, 0xf4, 0x8f, 0x52, 0x74,
-	0x5f, 0xc0, 0x36, 0x66, 0x79, 0x28, 0x66, 0x4c, 0x74, 0x52, 0xda, 0xa3, 0x71, 0x3e, 0x93, 0x5c,
-	0x55, 0xfc, 0x77, 0xa6, 0x62, 0x7d, 0xaf, 0x65, 0xe6, 0x76, 0x7f, 0xd4, 0x00, 0x74, 0x59, 0x74,
-	0x00, 0x7f, 0x05, 0xf9, 0x88, 0x84, 0x2c, 0xbe, 0x9c, 0x61, 0xda, 0x3a, 0xae, 0xd9, 0x16, 0x46,
-	0xca, 0x6d, 0x80, 0x0e, 0x61, 0x33, 0xa5, 0x23, 0x16, 0x54, 0x60, 0xb5, 0x12, 0xb6, 0xa1, 0x03,
-	0xa6, 0xab, 0xa5, 0x2c, 0x8a, 0xba, 0x24, 0xb8, 0x36, 0x61, 0x75, 0x5d, 0x4d, 0xb9, 0x0d, 0xd0,
-	0x63, 0x58, 0x4f, 0xf3, 0x5f, 0x6f, 0x22, 0x1a, 0x25, 0x62, 0x4d, 0xfa, 0xce, 0xa6, 0xc4, 0x53,
-	0x94, 0xed, 0x05, 0xd9, 0x7e, 0x69, 0xe7, 0xe2, 0x68, 0x5e, 0xf6, 0xe2, 0x44, 0x1c, 0x7d, 0x92,
-	0x63, 0x15, 0x01, 0x7b, 0x69, 0x82, 0x55, 0x36, 0xb2, 0x61, 0x49, 0x96, 0x22, 0x91, 0xdd, 0x94,
-	0x2e, 0x65, 0xba, 0xa7, 0xb0, 0x3a, 0x3d, 0xfa, 0x68, 0x07, 0x96, 0x4f, 0x42, 0x9e, 0x44, 0x64,
-	0x7c, 0x9a, 0xff, 0x43, 0xa9, 0x26, 0x36, 0x8f, 0xf2, 0x4a, 0x98, 0x45, 0xf4, 0xd4, 0xf8, 0xc5,
-	0xca, 0x76, 0x77, 0x61, 0x65, 0xb2, 0x0b, 0x78, 0xc2, 0x62, 0x4e, 0x6f, 0x5b, 0x06, 0xee, 0x3b,
-	0x40, 0xe6, 0xf5, 0x2e, 0xa2, 0xcd, 0xe1, 0xb1, 0x66, 0xe6, 0xdb, 0x81, 0x66, 0x42, 0x38, 0xbf,
-	0x61, 0x69, 0x4f, 0x55, 0x55, 0xb6, 0xeb, 0xc2, 0xca, 0xf9, 0x38, 0xa1, 0x65, 0x1e, 0x04, 0x0d,
-	0x31, 0x4e, 0x54, 0x0e, 0xf9, 0xed, 0x1e, 0xc2, 0x3f, 0xb7, 0x0c, 0xdf, 0x1d, 0x54, 0x97, 0x60,
-	0xe1, 0xf5, 0x30, 0x11, 0xe3, 0xfd, 0x2f, 0x0d, 0x68, 0x9e, 0x14, 0x3b, 0x18, 0xf9, 0xd0, 0xc8,
-	0x4b, 0xa2, 0x35, 0x7d, 0x23, 0x64, 0x94, 0xb3, 0xa5, 0x0f, 0xa6, 0x38, 0xbd, 0x01, 0xd0, 0x1d,
-	0xa3, 0xbf, 0x75, 0x54, 0x65, 0xcd, 0x39, 0xdb, 0xf3, 0x9d, 0x45, 0xa2, 0xe7, 0xd0, 0x2a, 0xd7,
-	0x09, 0x72, 0x74, 0xe8, 0xec, 0x8e, 0x71, 0x66, 0xa9, 0xe5, 0x2b, 0x42, 0x5f, 0x73, 0x93, 0x42,
-	0xe5, 0xf2, 0x57, 0xb1, 0x03, 0xd8, 0x9c, 0x2b, 0x1f, 0xda, 0x35, 0xd2, 0xfc, 0xe2, 0x72, 0x3b,
-	0x0f, 0xee, 0x8c, 0x2b, 0xfa, 0x7b, 0x06, 0x8d, 0x7c, 0x84, 0xd0, 0xa6, 0x06, 0x18, 0xcf, 0x8b,
-	0xa9, 0xef, 0xd4, 0xa4, 0x3d, 0x84, 0x85, 0x4e, 0xc4, 0xf8, 0x9c, 0x3f, 0x52, 0xe9, 0xe5, 0x25,
-	0x80, 0x7e, 0x0e, 0x4d, 0x1d, 0x2a, 0x8f, 0x64, 0x05, 0xeb, 0xd6, 0xbf, 0xd6, 0xac, 0xe3, 0xfd,
-	0xf7, 0x4f, 0xfa, 0xa1, 0x18, 0x64, 0x5d, 0x2f, 0x60, 0x43, 0x7f, 0x40, 0xf8, 0x20, 0x0c, 0x58,
-	0x9a, 0xf8, 0x23, 0x92, 0x45, 0xc2, 0x9f, 0xfb, 0x7a, 0x77, 0x17, 0xe5, 0x0e, 0x3e, 0xf8, 0x19,
-	0x00, 0x00, 0xff, 0xff, 0xdb, 0x96, 0x8b, 0x5c, 0xdd, 0x07, 0x00, 0x00,
+	// 824 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdd, 0x4e, 0xeb, 0x46,
+	0x10, 0x96, 0xf3, 0x03, 0xc9, 0x80, 0x20, 0x59, 0x48, 0x64, 0x19, 0x5a, 0x22, 0xab, 0xa5, 0x54,
+	0x55, 0xe3, 0x0a, 0x5a, 0xd1, 0x72, 0xd1, 0xaa, 0x84, 0x8a, 0xb6, 0x6a, 0x51, 0xe5, 0xc0, 0x4d,
+	0x55, 0x29, 0xda, 0x38, 0x4b, 0x62, 0xe1, 0x78, 0x5d, 0xef, 0x26, 0x34, 0x7d, 0x82, 0xbe, 0x41,
+	0x6f, 0xcf, 0xfd, 0x79, 0x91, 0xf3, 0x30, 0xe7, 0x21, 0x8e, 0xfc, 0xb3, 0xf6, 0xfa, 0x07, 0x90,
+	0xe0, 0x9c, 0x3b, 0xcf, 0xcf, 0x37, 0xf3, 0xed, 0xcc, 0xec, 0xac, 0xe1, 0x13, 0x36, 0xb9, 0x33,
+	0x26, 0x98, 0xe3, 0x31, 0x66, 0xc4, 0x98, 0x8c, 0x3d, 0x67, 0x31, 0xb5, 0xdd, 0x44, 0xd3, 0xf7,
+	0x7c, 0xca, 0x29, 0x6a, 0x08, 0x83, 0x76, 0x30, 0xa5, 0x74, 0xea, 0x10, 0x23, 0xd4, 0x8f, 0x17,
+	0xb7, 0x06, 0xb7, 0xe7, 0x84, 0x71, 0x3c, 0xf7, 0x22, 0x57, 0xfd, 0x2f, 0x68, 0xff, 0xe2, 0xda,
+	0xdc, 0xc6, 0x8e, 0xfd, 0x2f, 0x31, 0xc9, 0xdf, 0x0b, 0xc2, 0x38, 0xea, 0xc2, 0x9a, 0x45, 0xdd,
+	0x5b, 0x7b, 0xaa, 0x2a, 0x3d, 0xe5, 0x68, 0xd3, 0x8c, 0x25, 0xf4, 0x05, 0xb4, 0x97, 0xc4, 0xb7,
+	0x6f, 0x57, 0x23, 0x8b, 0xba, 0x2e, 0xb1, 0xb8, 0x4d, 0x5d, 0xb5, 0xd2, 0x53, 0x8e, 0x1a, 0x66,
+	0x2b, 0x32, 0x0c, 0x12, 0xfd, 0x59, 0x45, 0x55, 0x74, 0x13, 0x36, 0x82, 0xe8, 0xef, 0x33, 0xae,
+	0xfe, 0x46, 0x81, 0xf6, 0xc0, 0x27, 0x98, 0x93, 0x1b, 0x46, 0x7c, 0x11, 0xfa, 0x6b, 0x00, 0xc6,
+	0x31, 0x27, 0x73, 0xe2, 0x72, 0x16, 0x86, 0xdf, 0x38, 0xde, 0xed, 0x8b, 0x3a, 0xf4, 0x87, 0x89,
+	0xcd, 0x94, 0xfc, 0xd0, 0x8f, 0xb0, 0xbd, 0x60, 0xc4, 0x77, 0xf1, 0x9c, 0x8c, 0x62, 0x66, 0x95,
+	0x10, 0xaa, 0xa6, 0xd0, 0x9b, 0xd8, 0x61, 0x10, 0xda, 0xcd, 0xad, 0x45, 0x46, 0x46, 0x67, 0x00,
+	0xe4, 0x1f, 0xcf, 0xf6, 0x71, 0x48, 0xba, 0x1a, 0xa2, 0xb5, 0x7e, 0x54, 0xf6, 0xbe, 0x28, 0x7b,
+	0xff, 0x5a, 0x94, 0xdd, 0x94, 0xbc, 0xf5, 0x57, 0x0a, 0xb4, 0x4c, 0xe2, 0x92, 0xfb, 0x97, 0x9f,
+	0x44, 0x83, 0x86, 0x20, 0x16, 0x1e, 0xa1, 0x69, 0x26, 0xf2, 0x8b, 0x28, 0x12, 0x68, 0x9b, 0x64,
+	0x49, 0xef, 0xc8, 0x07, 0xa5, 0xa8, 0x7f, 0x0f, 0xfb, 0x26, 0x0d, 0x5c, 0x4d, 0x4a, 0xf9, 0xc0,
+	0x27, 0x13, 0xe2, 0x06, 0x33, 0xc9, 0x44, 0xc6, 0x8f, 0x73, 0x19, 0xab, 0x47, 0x4d, 0x39, 0xb6,
+	0xfe, 0xbf, 0x02, 0x9d, 0x21, 0x29, 0x43, 0x3e, 0x8f, 0xeb, 0xcf, 0x80, 0x02, 0xc9, 0xb6, 0x46,
+	0x01, 0xc5, 0xec, 0x6c, 0x68, 0x59, 0xb4, 0x6d, 0x05, 0xa5, 0x89, 0xa7, 0xa3, 0xc5, 0x72, 0x1a,
+	0xfd, 0x6d, 0x05, 0x20, 0x4d, 0x82, 0x4e, 0x60, 0xc7, 0x0a, 0x86, 0xd7, 0xa6, 0xee, 0x28, 0xc7,
+	0xab, 0x79, 0x5e, 0x51, 0x15, 0x13, 0x09, 0xb3, 0x04, 0x3a, 0x85, 0x8e, 0x4f, 0x96, 0xd4, 0x2a,
+	0xc0, 0x2a, 0x09, 0x6c, 0x37, 0x75, 0xc8, 0x66, 0xf3, 0xa9, 0xe3, 0x8c, 0xb1, 0x75, 0x27, 0xc3,
+	0xaa, 0x69, 0x36, 0x61, 0x96, 0x40, 0x5f, 0x42, 0xcb, 0x0f, 0x86, 0x52, 0x46, 0xd4, 0x12, 0xc4,
+	0x76, 0x68, 0x1b, 0x66, 0xda, 0x2a, 0x28, 0xab, 0xf5, 0xb0, 0x31, 0x89, 0x1c, 0xb4, 0x2d, 0xe5,
+	0xa5, 0xae, 0x45, 0x6d, 0x4b, 0x35, 0x01, 0x56, 0x10, 0x50, 0xd7, 0x23, 0xac, 0x90, 0x91, 0x0a,
+	0xeb, 0x61, 0x2a, 0xec, 0xa8, 0x8d, 0xd0, 0x24, 0xc4, 0x08, 0xc5, 0xa3, 0x98, 0x4d, 0x81, 0x8a,
+	0x64, 0xfd, 0x0a, 0xb6, 0xb2, 0x17, 0x16, 0xf5, 0x60, 0xe3, 0xc2, 0x66, 0x9e, 0x83, 0x57, 0x57,
+	0xc1, 0xe4, 0x85, 0x95, 0x36, 0x65, 0x55, 0x10, 0xcf, 0xa4, 0x0e, 0xb9, 0x92, 0x06, 0x53, 0xc8,
+	0xfa, 0xaf, 0xd0, 0xca, 0x37, 0x39, 0x33, 0xc8, 0x4a, 0xee, 0xae, 0x69, 0xd0, 0xf0, 0x30, 0x63,
+	0xf7, 0xd4, 0x9f, 0x88, 0x58, 0x42, 0xd6, 0x0f, 0x61, 0x33, 0xda, 0x86, 0xcc, 0xa3, 0x2e, 0x23,
+	0x0f, 0xad, 0x43, 0xfd, 0x37, 0x40, 0xf2, 0x82, 0x8b, 0xbd, 0x9f, 0x9b, 0x55, 0x87, 0xcd, 0xeb,
+	0x95, 0x47, 0x92, 0x38, 0x08, 0x6a, 0x7c, 0xe5, 0x89, 0x18, 0xe1, 0xb7, 0x7e, 0x0a, 0x1f, 0x3d,
+	0x70, 0xfd, 0x9e, 0xa0, 0xfa, 0x1d, 0xec, 0x5d, 0x12, 0x97, 0xf8, 0x98, 0x93, 0x32, 0x98, 0xcc,
+	0x4b, 0xc9, 0xf1, 0xfa, 0x03, 0xba, 0xf9, 0x1b, 0xfb, 0xc2, 0x93, 0xae, 0x43, 0xfd, 0xa7, 0xb9,
+	0xc7, 0x57, 0xc7, 0xaf, 0xeb, 0xd0, 0xb8, 0x88, 0x9f, 0x44, 0x64, 0x40, 0x2d, 0x38, 0x3f, 0xda,
+	0x4e, 0xaf, 0x6d, 0xe8, 0xa5, 0x75, 0x53, 0x45, 0xa6, 0x40, 0x97, 0x00, 0x69, 0xf9, 0xd1, 0x5e,
+	0xea, 0x55, 0x78, 0x75, 0xb4, 0xfd, 0x72, 0x63, 0x1c, 0xe8, 0x5b, 0x68, 0x26, 0xdb, 0x1d, 0x49,
+	0x5b, 0x23, 0xbf, 0xf2, 0xb5, 0x3c, 0xb5, 0x60, 0x63, 0xa7, 0x5b, 0x57, 0xa6, 0x50, 0xd8, 0xc5,
+	0x45, 0xec, 0x0c, 0x3a, 0xa5, 0xbd, 0x44, 0x87, 0x52, 0x98, 0x47, 0x76, 0xad, 0xf6, 0xd9, 0x93,
+	0x7e, 0xf1, 0xf9, 0xbe, 0x81, 0x5a, 0x30, 0xcf, 0xa8, 0x93, 0x02, 0xa4, 0xd7, 0x5e, 0xeb, 0xe6,
+	0xd5, 0x31, 0xec, 0x73, 0xa8, 0x0f, 0x1c, 0xca, 0x4a, 0x3a, 0x52, 0x38, 0xcb, 0x10, 0xb6, 0xb2,
+	0x33, 0x82, 0x0e, 0xa4, 0xe5, 0x5b, 0xb6, 0xef, 0xb5, 0xde, 0xc3, 0x0e, 0x71, 0xfe, 0xdf, 0x61,
+	0xa7, 0x64, 0x66, 0x8b, 0x6c, 0x3e, 0x4d, 0x15, 0x8f, 0xcd, 0xf8, 0x0f, 0x00, 0xe9, 0x1f, 0x94,
+	0xdc, 0xab, 0xc2, 0x7f, 0x55, 0xe1, 0x7c, 0x7a, 0xf5, 0xbf, 0x8a, 0x72, 0x7e, 0xfc, 0xe7, 0x57,
+	0x53, 0x9b, 0xcf, 0x16, 0xe3, 0xbe, 0x45, 0xe7, 0xc6, 0x0c, 0xb3, 0x99, 0x6d, 0x51, 0xdf, 0x33,
+	0x96, 0x78, 0xe1, 0x70, 0xa3, 0xf4, 0x87, 0x6f, 0xbc, 0x16, 0x3e, 0xdb, 0x27, 0xef, 0x06, 0x00,
+	0x9a, 0x1c, 0x38, 0x4e, 0x10, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -747,6 +946,8 @@ type DatabaseClient interface {
 	RotateRootCredentials(ctx context.Context, in *RotateRootCredentialsRequest, opts ...grpc.CallOption) (*RotateRootCredentialsResponse, error)
 	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitResponse, error)
 	Close(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	SetCredentials(ctx context.Context, in *SetCredentialsRequest, opts ...grpc.CallOption) (*SetCredentialsResponse, error)
+	GenerateCredentials(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GenerateCredentialsResponse, error)
 	Initialize(ctx context.Context, in *InitializeRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
@@ -821,6 +1022,24 @@ func (c *databaseClient) Close(ctx context.Context, in *Empty, opts ...grpc.Call
 	return out, nil
 }
 
+func (c *databaseClient) SetCredentials(ctx context.Context, in *SetCredentialsRequest, opts ...grpc.CallOption) (*SetCredentialsResponse, error) {
+	out := new(SetCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/dbplugin.Database/SetCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) GenerateCredentials(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GenerateCredentialsResponse, error) {
+	out := new(GenerateCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/dbplugin.Database/GenerateCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Deprecated: Do not use.
 func (c *databaseClient) Initialize(ctx context.Context, in *InitializeRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
@@ -840,6 +1059,8 @@ type DatabaseServer interface {
 	RotateRootCredentials(context.Context, *RotateRootCredentialsRequest) (*RotateRootCredentialsResponse, error)
 	Init(context.Context, *InitRequest) (*InitResponse, error)
 	Close(context.Context, *Empty) (*Empty, error)
+	SetCredentials(context.Context, *SetCredentialsRequest) (*SetCredentialsResponse, error)
+	GenerateCredentials(context.Context, *Empty) (*GenerateCredentialsResponse, error)
 	Initialize(context.Context, *InitializeRequest) (*Empty, error)
 }
 
@@ -973,6 +1194,42 @@ func _Database_Close_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_SetCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SetCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dbplugin.Database/SetCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SetCredentials(ctx, req.(*SetCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_GenerateCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).GenerateCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dbplugin.Database/GenerateCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).GenerateCredentials(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_Initialize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InitializeRequest)
 	if err := dec(in); err != nil {
@@ -1023,6 +1280,14 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Close",
 			Handler:    _Database_Close_Handler,
 		},
+		{
+			MethodName: "SetCredentials",
+			Handler:    _Database_SetCredentials_Handler,
+		},
+		{
+			MethodName: "GenerateCredentials",
+			Handler:    _Database_GenerateCredentials_Handler,
+		},
 		{
 			MethodName: "Initialize",
 			Handler:    _Database_Initialize_Handler,
@@ -63,6 +63,24 @@ func (mw *databaseTracingMiddleware) RotateRootCredentials(ctx context.Context,
 	return mw.next.RotateRootCredentials(ctx, statements)
 }
 
+func (mw *databaseTracingMiddleware) SetCredentials(ctx context.Context, statements Statements, staticConfig StaticUserConfig) (username string, password string, err error) {
+	defer func(then time.Time) {
+		mw.logger.Trace("set credentials", "status", "finished", "err", err, "took", time.Since(then))
+	}(time.Now())
+
+	mw.logger.Trace("set credentials", "status", "started")
+	return mw.next.SetCredentials(ctx, statements, staticConfig)
+}
+
+func (mw *databaseTracingMiddleware) GenerateCredentials(ctx context.Context) (password string, err error) {
+	defer func(then time.Time) {
+		mw.logger.Trace("generate credentials", "status", "finished", "err", err, "took", time.Since(then))
+	}(time.Now())
+
+	mw.logger.Trace("generate credentials", "status", "started")
+	return mw.next.GenerateCredentials(ctx)
+}
+
 func (mw *databaseTracingMiddleware) Initialize(ctx context.Context, conf map[string]interface{}, verifyConnection bool) error {
 	_, err := mw.Init(ctx, conf, verifyConnection)
 	return err
@@ -164,6 +182,38 @@ func (mw *databaseMetricsMiddleware) RotateRootCredentials(ctx context.Context,
 	return mw.next.RotateRootCredentials(ctx, statements)
 }
 
+func (mw *databaseMetricsMiddleware) SetCredentials(ctx context.Context, statements Statements, staticConfig StaticUserConfig) (username string, password string, err error) {
+	defer func(now time.Time) {
+		metrics.MeasureSince([]string{"database", "SetCredentials"}, now)
+		metrics.MeasureSince([]string{"database", mw.typeStr, "SetCredentials"}, now)
+
+		if err != nil {
+			metrics.IncrCounter([]string{"database", "SetCredentials", "error"}, 1)
+			metrics.IncrCounter([]string{"database", mw.typeStr, "SetCredentials", "error"}, 1)
+		}
+	}(time.Now())
+
+	metrics.IncrCounter([]string{"database", "SetCredentials"}, 1)
+	metrics.IncrCounter([]string{"database", mw.typeStr, "SetCredentials"}, 1)
+	return mw.next.SetCredentials(ctx, statements, staticConfig)
+}
+
+func (mw *databaseMetricsMiddleware) GenerateCredentials(ctx context.Context) (password string, err error) {
+	defer func(now time.Time) {
+		metrics.MeasureSince([]string{"database", "GenerateCredentials"}, now)
+		metrics.MeasureSince([]string{"database", mw.typeStr, "GenerateCredentials"}, now)
+
+		if err != nil {
+			metrics.IncrCounter([]string{"database", "GenerateCredentials", "error"}, 1)
+			metrics.IncrCounter([]string{"database", mw.typeStr, "GenerateCredentials", "error"}, 1)
+		}
+	}(time.Now())
+
+	metrics.IncrCounter([]string{"database", "GenerateCredentials"}, 1)
+	metrics.IncrCounter([]string{"database", mw.typeStr, "GenerateCredentials"}, 1)
+	return mw.next.GenerateCredentials(ctx)
+}
+
 func (mw *databaseMetricsMiddleware) Initialize(ctx context.Context, conf map[string]interface{}, verifyConnection bool) error {
 	_, err := mw.Init(ctx, conf, verifyConnection)
 	return err
@@ -241,6 +291,16 @@ func (mw *DatabaseErrorSanitizerMiddleware) RotateRootCredentials(ctx context.Co
 	return conf, mw.sanitize(err)
 }
 
+func (mw *DatabaseErrorSanitizerMiddleware) SetCredentials(ctx context.Context, statements Statements, staticConfig StaticUserConfig) (username string, password string, err error) {
+	username, password, err = mw.next.SetCredentials(ctx, statements, staticConfig)
+	return username, password, mw.sanitize(err)
+}
+
+func (mw *DatabaseErrorSanitizerMiddleware) GenerateCredentials(ctx context.Context) (password string, err error) {
+	password, err = mw.next.GenerateCredentials(ctx)
+	return password, mw.sanitize(err)
+}
+
 func (mw *DatabaseErrorSanitizerMiddleware) Initialize(ctx context.Context, conf map[string]interface{}, verifyConnection bool) error {
 	_, err := mw.Init(ctx, conf, verifyConnection)
 	return err
@@ -80,6 +80,29 @@ func (s *gRPCServer) RotateRootCredentials(ctx context.Context, req *RotateRootC
 	}, err
 }
 
+func (s *gRPCServer) SetCredentials(ctx context.Context, req *SetCredentialsRequest) (*SetCredentialsResponse, error) {
+	username, password, err := s.impl.SetCredentials(ctx, *req.Statements, *req.StaticUserConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetCredentialsResponse{
+		Username: username,
+		Password: password,
+	}, nil
+}
+
+func (s *gRPCServer) GenerateCredentials(ctx context.Context, _ *Empty) (*GenerateCredentialsResponse, error) {
+	password, err := s.impl.GenerateCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GenerateCredentialsResponse{
+		Password: password,
+	}, nil
+}
+
 func (s *gRPCServer) Initialize(ctx context.Context, req *InitializeRequest) (*Empty, error) {
 	_, err := s.Init(ctx, &InitRequest{
 		Config:           req.Config,
@@ -234,6 +257,54 @@ func (c *gRPCClient) RotateRootCredentials(ctx context.Context, statements []str
 	return conf, nil
 }
 
+func (c *gRPCClient) SetCredentials(ctx context.Context, statements Statements, staticConfig StaticUserConfig) (username string, password string, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	quitCh := pluginutil.CtxCancelIfCanceled(cancel, c.doneCtx)
+	defer close(quitCh)
+	defer cancel()
+
+	resp, err := c.client.SetCredentials(ctx, &SetCredentialsRequest{
+		Statements:       &statements,
+		StaticUserConfig: &staticConfig,
+	})
+	if err != nil {
+		// Plugins built before static roles existed do not implement the call
+		if grpcStatus, ok := status.FromError(err); ok && grpcStatus.Code() == codes.Unimplemented {
+			return "", "", ErrPluginStaticUnsupported
+		}
+
+		if c.doneCtx.Err() != nil {
+			return "", "", ErrPluginShutdown
+		}
+
+		return "", "", err
+	}
+
+	return resp.Username, resp.Password, nil
+}
+
+func (c *gRPCClient) GenerateCredentials(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	quitCh := pluginutil.CtxCancelIfCanceled(cancel, c.doneCtx)
+	defer close(quitCh)
+	defer cancel()
+
+	resp, err := c.client.GenerateCredentials(ctx, &Empty{})
+	if err != nil {
+		if grpcStatus, ok := status.FromError(err); ok && grpcStatus.Code() == codes.Unimplemented {
+			return "", ErrPluginStaticUnsupported
+		}
+
+		if c.doneCtx.Err() != nil {
+			return "", ErrPluginShutdown
+		}
+
+		return "", err
+	}
+
+	return resp.Password, nil
+}
+
 func (c *gRPCClient) Initialize(ctx context.Context, conf map[string]interface{}, verifyConnection bool) error {
 	_, err := c.Init(ctx, conf, verifyConnection)
 	return err
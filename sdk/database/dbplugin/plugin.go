@@ -2,6 +2,7 @@ package dbplugin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -44,6 +45,16 @@ type Database interface {
 	// the API.
 	RotateRootCredentials(ctx context.Context, statements []string) (config map[string]interface{}, err error)
 
+	// SetCredentials sets the password of an existing database user managed
+	// by a static role, using the rotation statements. Unlike CreateUser, the
+	// username and password are provided by the caller, which allows the
+	// backend to record the new password before it is applied.
+	SetCredentials(ctx context.Context, statements Statements, staticConfig StaticUserConfig) (username string, password string, err error)
+
+	// GenerateCredentials returns a new password that satisfies the password
+	// requirements of the database, to be used with SetCredentials.
+	GenerateCredentials(ctx context.Context) (password string, err error)
+
 	// Init is called on `$ vault write database/config/:db-name`, or when you
 	// do a creds call after Vault's been restarted. The config provided won't
 	// hold all the keys and values provided in the API call, some will be
@@ -61,6 +72,10 @@ type Database interface {
 	Initialize(ctx context.Context, config map[string]interface{}, verifyConnection bool) (err error)
 }
 
+// ErrPluginStaticUnsupported is returned by plugins that do not support
+// static roles.
+var ErrPluginStaticUnsupported = errors.New("database plugin does not support static roles")
+
 // PluginFactory is used to build plugin database types. It wraps the database
 // object in a logging and metrics middleware.
 func PluginFactory(ctx context.Context, pluginName string, sys pluginutil.LookRunnerUtil, logger log.Logger) (Database, error) {
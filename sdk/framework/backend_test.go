@@ -44,6 +44,32 @@ func TestBackend_impl(t *testing.T) {
 	var _ logical.Backend = new(Backend)
 }
 
+func TestBackend_Initialize(t *testing.T) {
+	// A backend without an initialize callback is a no-op
+	if err := new(Backend).Initialize(context.Background(), &logical.InitializationRequest{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	b := &Backend{
+		InitializeFunc: func(ctx context.Context, req *logical.InitializationRequest) error {
+			return req.Storage.Put(ctx, &logical.StorageEntry{Key: "initialized", Value: []byte("true")})
+		},
+	}
+
+	storage := new(logical.InmemStorage)
+	if err := b.Initialize(context.Background(), &logical.InitializationRequest{Storage: storage}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	entry, err := storage.Get(context.Background(), "initialized")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if entry == nil {
+		t.Fatal("expected the initialize callback to be invoked")
+	}
+}
+
 func TestBackendHandleRequest(t *testing.T) {
 	callback := func(ctx context.Context, req *logical.Request, data *FieldData) (*logical.Response, error) {
 		return &logical.Response{
@@ -106,6 +106,7 @@ type OASPathItem struct {
 
 	Get    *OASOperation `json:"get,omitempty"`
 	Post   *OASOperation `json:"post,omitempty"`
+	Patch  *OASOperation `json:"patch,omitempty"`
 	Delete *OASOperation `json:"delete,omitempty"`
 }
 
@@ -310,8 +311,9 @@ func documentPath(p *Path, specialPaths *logical.Paths, backendType logical.Back
 			op.Description = props.Description
 			op.Deprecated = props.Deprecated
 
-			// Add any fields not present in the path as body parameters for POST.
-			if opType == logical.CreateOperation || opType == logical.UpdateOperation {
+			// Add any fields not present in the path as body parameters for POST
+			// and PATCH.
+			if opType == logical.CreateOperation || opType == logical.UpdateOperation || opType == logical.PatchOperation {
 				s := &OASSchema{
 					Type:       "object",
 					Properties: make(map[string]*OASSchema),
@@ -350,11 +352,16 @@ func documentPath(p *Path, specialPaths *logical.Paths, backendType logical.Back
 					s.Example = props.Examples[0].Data
 				}
 
-				// Set the final request body. Only JSON request data is supported.
+				// Set the final request body. Only JSON request data is supported,
+				// sent as a JSON merge patch for PATCH.
 				if len(s.Properties) > 0 || s.Example != nil {
+					mediaType := "application/json"
+					if opType == logical.PatchOperation {
+						mediaType = "application/merge-patch+json"
+					}
 					op.RequestBody = &OASRequestBody{
 						Content: OASContent{
-							"application/json": &OASMediaTypeObject{
+							mediaType: &OASMediaTypeObject{
 								Schema: s,
 							},
 						},
@@ -432,6 +439,8 @@ func documentPath(p *Path, specialPaths *logical.Paths, backendType logical.Back
 				pi.Post = op
 			case logical.ReadOperation, logical.ListOperation:
 				pi.Get = op
+			case logical.PatchOperation:
+				pi.Patch = op
 			case logical.DeleteOperation:
 				pi.Delete = op
 			}
@@ -176,9 +176,15 @@ func (p *PathMap) Delete(ctx context.Context, s logical.Storage, k string) error
 
 // List reads the keys under a given path
 func (p *PathMap) List(ctx context.Context, s logical.Storage, prefix string) ([]string, error) {
+	return p.ListPage(ctx, s, prefix, "", 0)
+}
+
+// ListPage reads at most limit keys under a given path that sort after the
+// given key. A limit of zero reads all remaining keys.
+func (p *PathMap) ListPage(ctx context.Context, s logical.Storage, prefix string, after string, limit int) ([]string, error) {
 	stripPrefix := fmt.Sprintf("struct/map/%s/", p.Name)
 	fullPrefix := fmt.Sprintf("%s%s", stripPrefix, prefix)
-	out, err := s.List(ctx, fullPrefix)
+	out, err := logical.ListPage(ctx, s, fullPrefix, after, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +242,8 @@ func (p *PathMap) Paths() []*Path {
 
 func (p *PathMap) pathList() OperationFunc {
 	return func(ctx context.Context, req *logical.Request, d *FieldData) (*logical.Response, error) {
-		keys, err := p.List(ctx, req.Storage, "")
+		after, limit := logical.ListPagination(req)
+		keys, err := p.ListPage(ctx, req.Storage, "", after, limit)
 		if err != nil {
 			return nil, err
 		}
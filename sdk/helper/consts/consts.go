@@ -12,6 +12,10 @@ const (
 	// AuthHeaderName is the name of the header containing the token.
 	AuthHeaderName = "X-Vault-Token"
 
+	// RequestIDHeaderName is the name of the response header containing the
+	// ID assigned to the request.
+	RequestIDHeaderName = "X-Vault-Request-Id"
+
 	// PerformanceReplicationALPN is the negotiated protocol used for
 	// performance replication.
 	PerformanceReplicationALPN = "replication_v1"
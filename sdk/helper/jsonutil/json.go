@@ -98,3 +98,31 @@ func DecodeJSONFromReader(r io.Reader, out interface{}) error {
 	// Since 'out' is an interface representing a pointer, pass it to the decoder without an '&'
 	return dec.Decode(out)
 }
+
+// MergePatch applies patch to target following the JSON merge patch rules of
+// RFC 7386: keys with a null value are removed, objects are merged
+// recursively and any other value replaces the existing one. The target is not
+// modified; the merged result is returned.
+func MergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(target))
+	for k, v := range target {
+		result[k] = v
+	}
+
+	for k, v := range patch {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+
+		patchObj, ok := v.(map[string]interface{})
+		if !ok {
+			result[k] = v
+			continue
+		}
+		targetObj, _ := result[k].(map[string]interface{})
+		result[k] = MergePatch(targetObj, patchObj)
+	}
+
+	return result
+}
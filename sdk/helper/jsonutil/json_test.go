@@ -139,3 +139,45 @@ func TestJSONUtil_DecodeJSONFromReader(t *testing.T) {
 		t.Fatalf("bad: expected:%#v\nactual:%#v", expected, actual)
 	}
 }
+
+func TestJSONUtil_MergePatch(t *testing.T) {
+	target := map[string]interface{}{
+		"a": "b",
+		"c": map[string]interface{}{
+			"d": "e",
+			"f": "g",
+		},
+		"h": []interface{}{"i"},
+	}
+	patch := map[string]interface{}{
+		"a": "z",
+		"c": map[string]interface{}{
+			"f": nil,
+		},
+		"h": []interface{}{"j", "k"},
+		"l": map[string]interface{}{
+			"m": nil,
+			"n": "o",
+		},
+	}
+
+	actual := MergePatch(target, patch)
+	expected := map[string]interface{}{
+		"a": "z",
+		"c": map[string]interface{}{
+			"d": "e",
+		},
+		"h": []interface{}{"j", "k"},
+		"l": map[string]interface{}{
+			"n": "o",
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: expected:%#v\nactual:%#v", expected, actual)
+	}
+
+	// The target is left untouched
+	if target["a"] != "b" || len(target["c"].(map[string]interface{})) != 2 {
+		t.Fatalf("target was modified: %#v", target)
+	}
+}
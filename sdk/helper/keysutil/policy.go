@@ -55,6 +55,8 @@ const (
 	KeyType_RSA2048
 	KeyType_RSA4096
 	KeyType_ChaCha20_Poly1305
+	KeyType_ECDSA_P384
+	KeyType_ECDSA_P521
 )
 
 const (
@@ -105,7 +107,7 @@ func (kt KeyType) DecryptionSupported() bool {
 
 func (kt KeyType) SigningSupported() bool {
 	switch kt {
-	case KeyType_ECDSA_P256, KeyType_ED25519, KeyType_RSA2048, KeyType_RSA4096:
+	case KeyType_ECDSA_P256, KeyType_ECDSA_P384, KeyType_ECDSA_P521, KeyType_ED25519, KeyType_RSA2048, KeyType_RSA4096:
 		return true
 	}
 	return false
@@ -113,7 +115,7 @@ func (kt KeyType) SigningSupported() bool {
 
 func (kt KeyType) HashSignatureInput() bool {
 	switch kt {
-	case KeyType_ECDSA_P256, KeyType_RSA2048, KeyType_RSA4096:
+	case KeyType_ECDSA_P256, KeyType_ECDSA_P384, KeyType_ECDSA_P521, KeyType_RSA2048, KeyType_RSA4096:
 		return true
 	}
 	return false
@@ -135,6 +137,10 @@ func (kt KeyType) String() string {
 		return "chacha20-poly1305"
 	case KeyType_ECDSA_P256:
 		return "ecdsa-p256"
+	case KeyType_ECDSA_P384:
+		return "ecdsa-p384"
+	case KeyType_ECDSA_P521:
+		return "ecdsa-p521"
 	case KeyType_ED25519:
 		return "ed25519"
 	case KeyType_RSA2048:
@@ -146,6 +152,20 @@ func (kt KeyType) String() string {
 	return "[unknown]"
 }
 
+// ECDSACurve returns the elliptic curve used by ECDSA key types, or nil for
+// all other key types
+func (kt KeyType) ECDSACurve() elliptic.Curve {
+	switch kt {
+	case KeyType_ECDSA_P256:
+		return elliptic.P256()
+	case KeyType_ECDSA_P384:
+		return elliptic.P384()
+	case KeyType_ECDSA_P521:
+		return elliptic.P521()
+	}
+	return nil
+}
+
 type KeyData struct {
 	Policy       *Policy       `json:"policy"`
 	ArchivedKeys *archivedKeys `json:"archived_keys"`
@@ -362,6 +382,10 @@ type Policy struct {
 	// policy object.
 	StoragePrefix string `json:"storage_prefix"`
 
+	// AutoRotatePeriod defines how frequently the key should automatically
+	// rotate. Setting this to zero disables automatic rotation.
+	AutoRotatePeriod time.Duration `json:"auto_rotate_period"`
+
 	// versionPrefixCache stores caches of version prefix strings and the split
 	// version template.
 	versionPrefixCache sync.Map
@@ -1063,12 +1087,13 @@ func (p *Policy) Sign(ver int, context, input []byte, hashAlgorithm HashType, si
 	var pubKey []byte
 	var err error
 	switch p.Type {
-	case KeyType_ECDSA_P256:
-		curveBits := 256
+	case KeyType_ECDSA_P256, KeyType_ECDSA_P384, KeyType_ECDSA_P521:
+		curve := p.Type.ECDSACurve()
+		curveBits := curve.Params().BitSize
 		keyParams := p.Keys[strconv.Itoa(ver)]
 		key := &ecdsa.PrivateKey{
 			PublicKey: ecdsa.PublicKey{
-				Curve: elliptic.P256(),
+				Curve: curve,
 				X:     keyParams.EC_X,
 				Y:     keyParams.EC_Y,
 			},
@@ -1094,11 +1119,9 @@ func (p *Policy) Sign(ver int, context, input []byte, hashAlgorithm HashType, si
 		case MarshalingTypeJWS:
 			// This is used by JWS
 
-			// First we have to get the length of the curve in bytes. Although
-			// we only support 256 now, we'll do this in an agnostic way so we
-			// can reuse this marshaling if we support e.g. 521. Getting the
-			// number of bytes without rounding up would be 65.125 so we need
-			// to add one in that case.
+			// First we have to get the length of the curve in bytes. For
+			// P-521 the number of bytes without rounding up would be 65.125
+			// so we need to add one in that case.
 			keyLen := curveBits / 8
 			if curveBits%8 > 0 {
 				keyLen++
@@ -1242,7 +1265,7 @@ func (p *Policy) VerifySignature(context, input []byte, hashAlgorithm HashType,
 	}
 
 	switch p.Type {
-	case KeyType_ECDSA_P256:
+	case KeyType_ECDSA_P256, KeyType_ECDSA_P384, KeyType_ECDSA_P521:
 		var ecdsaSig ecdsaSignature
 
 		switch marshaling {
@@ -1267,7 +1290,7 @@ func (p *Policy) VerifySignature(context, input []byte, hashAlgorithm HashType,
 
 		keyParams := p.Keys[strconv.Itoa(ver)]
 		key := &ecdsa.PublicKey{
-			Curve: elliptic.P256(),
+			Curve: p.Type.ECDSACurve(),
 			X:     keyParams.EC_X,
 			Y:     keyParams.EC_Y,
 		}
@@ -1329,6 +1352,25 @@ func (p *Policy) VerifySignature(context, input []byte, hashAlgorithm HashType,
 	}
 }
 
+// NeedsAutoRotate returns whether the policy has an automatic rotation period
+// and the latest key version is older than it
+func (p *Policy) NeedsAutoRotate(now time.Time) bool {
+	if p.AutoRotatePeriod <= 0 {
+		return false
+	}
+
+	latest, ok := p.Keys[strconv.Itoa(p.LatestVersion)]
+	if !ok {
+		return false
+	}
+	created := latest.CreationTime
+	if created.IsZero() {
+		created = time.Unix(latest.DeprecatedCreationTime, 0)
+	}
+
+	return !now.Before(created.Add(p.AutoRotatePeriod))
+}
+
 func (p *Policy) Rotate(ctx context.Context, storage logical.Storage) (retErr error) {
 	priorLatestVersion := p.LatestVersion
 	priorMinDecryptionVersion := p.MinDecryptionVersion
@@ -1378,8 +1420,8 @@ func (p *Policy) Rotate(ctx context.Context, storage logical.Storage) (retErr er
 		}
 		entry.Key = newKey
 
-	case KeyType_ECDSA_P256:
-		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyType_ECDSA_P256, KeyType_ECDSA_P384, KeyType_ECDSA_P521:
+		privKey, err := ecdsa.GenerateKey(p.Type.ECDSACurve(), rand.Reader)
 		if err != nil {
 			return err
 		}
@@ -339,6 +339,9 @@ func (c *Client) GetLdapGroups(cfg *ConfigEntry, conn Connection, userDN string,
 		entries, err = c.performLdapTokenGroupsSearch(cfg, conn, userDN)
 	} else {
 		entries, err = c.performLdapFilterGroupsSearch(cfg, conn, userDN, username)
+		if err == nil && cfg.MaxGroupDepth > 0 {
+			entries, err = c.resolveNestedGroups(cfg, conn, userDN, entries)
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -375,6 +378,51 @@ func (c *Client) GetLdapGroups(cfg *ConfigEntry, conn Connection, userDN string,
 	return ldapGroups, nil
 }
 
+// resolveNestedGroups follows the membership of the groups found for a user
+// up to the configured depth, by re-running the group filter with each
+// group's DN in place of the user's DN and username. Groups already seen are
+// not searched again, so membership cycles terminate.
+func (c *Client) resolveNestedGroups(cfg *ConfigEntry, conn Connection, userDN string, entries []*ldap.Entry) ([]*ldap.Entry, error) {
+	seen := map[string]bool{
+		strings.ToLower(userDN): true,
+	}
+
+	var frontier []*ldap.Entry
+	for _, e := range entries {
+		dn := strings.ToLower(e.DN)
+		if !seen[dn] {
+			seen[dn] = true
+			frontier = append(frontier, e)
+		}
+	}
+
+	for depth := 0; depth < cfg.MaxGroupDepth && len(frontier) > 0; depth++ {
+		var next []*ldap.Entry
+		for _, group := range frontier {
+			parents, err := c.performLdapFilterGroupsSearch(cfg, conn, group.DN, group.DN)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range parents {
+				dn := strings.ToLower(p.DN)
+				if seen[dn] {
+					continue
+				}
+				seen[dn] = true
+				next = append(next, p)
+				entries = append(entries, p)
+			}
+		}
+
+		if c.Logger.IsDebug() {
+			c.Logger.Debug("resolved nested groups", "depth", depth+1, "new_groups", len(next))
+		}
+		frontier = next
+	}
+
+	return entries, nil
+}
+
 // EscapeLDAPValue is exported because a plugin uses it outside this package.
 func EscapeLDAPValue(input string) string {
 	if input == "" {
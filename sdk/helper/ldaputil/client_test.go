@@ -1,7 +1,12 @@
 package ldaputil
 
 import (
+	"reflect"
+	"sort"
 	"testing"
+
+	"github.com/go-ldap/ldap"
+	hclog "github.com/hashicorp/go-hclog"
 )
 
 func TestLDAPEscape(t *testing.T) {
@@ -62,3 +67,59 @@ func TestSIDBytesToString(t *testing.T) {
 		}
 	}
 }
+
+// groupSearchConn answers group searches from a map of member DN to the DNs
+// of the groups it belongs to
+type groupSearchConn struct {
+	Connection
+	memberOf map[string][]string
+	searches int
+}
+
+func (c *groupSearchConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	c.searches++
+	result := &ldap.SearchResult{}
+	for member, groups := range c.memberOf {
+		if req.Filter != "(member="+ldap.EscapeFilter(member)+")" {
+			continue
+		}
+		for _, group := range groups {
+			result.Entries = append(result.Entries, ldap.NewEntry(group, nil))
+		}
+	}
+	return result, nil
+}
+
+func TestGetLdapGroups_nested(t *testing.T) {
+	conn := &groupSearchConn{
+		memberOf: map[string][]string{
+			"cn=alice,ou=people,dc=example,dc=org": {"cn=dev,ou=groups,dc=example,dc=org"},
+			"cn=dev,ou=groups,dc=example,dc=org":   {"cn=eng,ou=groups,dc=example,dc=org"},
+			"cn=eng,ou=groups,dc=example,dc=org":   {"cn=all,ou=groups,dc=example,dc=org"},
+			"cn=all,ou=groups,dc=example,dc=org":   {"cn=dev,ou=groups,dc=example,dc=org"},
+		},
+	}
+	cfg := testConfig()
+	cfg.GroupDN = "ou=groups,dc=example,dc=org"
+	cfg.GroupFilter = "(member={{.UserDN}})"
+	cfg.GroupAttr = "cn"
+
+	c := &Client{Logger: hclog.NewNullLogger()}
+
+	cases := map[int][]string{
+		0: {"dev"},
+		1: {"dev", "eng"},
+		5: {"all", "dev", "eng"},
+	}
+	for depth, expected := range cases {
+		cfg.MaxGroupDepth = depth
+		groups, err := c.GetLdapGroups(cfg, conn, "cn=alice,ou=people,dc=example,dc=org", "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(groups)
+		if !reflect.DeepEqual(groups, expected) {
+			t.Fatalf("depth %d: expected %v, got %v", depth, expected, groups)
+		}
+	}
+}
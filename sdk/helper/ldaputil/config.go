@@ -136,6 +136,16 @@ Default: cn`,
 			Default:     false,
 			Description: "If true, use the Active Directory tokenGroups constructed attribute of the user to find the group memberships. This will find all security groups including nested ones.",
 		},
+
+		"max_group_depth": {
+			Type:    framework.TypeInt,
+			Default: 0,
+			Description: `Number of levels of nested groups to resolve using <groupfilter>. Each level
+re-runs the filter with the DN of every group found so far as UserDN and
+Username. Defaults to 0, which only returns the groups the user is a direct
+member of.`,
+			DisplayName: "Maximum Group Depth",
+		},
 	}
 }
 
@@ -257,6 +267,12 @@ func NewConfigEntry(d *framework.FieldData) (*ConfigEntry, error) {
 		cfg.UseTokenGroups = useTokenGroups
 	}
 
+	maxGroupDepth := d.Get("max_group_depth").(int)
+	if maxGroupDepth < 0 {
+		return nil, fmt.Errorf("'max_group_depth' cannot be negative")
+	}
+	cfg.MaxGroupDepth = maxGroupDepth
+
 	return cfg, nil
 }
 
@@ -278,6 +294,7 @@ type ConfigEntry struct {
 	TLSMinVersion  string `json:"tls_min_version"`
 	TLSMaxVersion  string `json:"tls_max_version"`
 	UseTokenGroups bool   `json:"use_token_groups"`
+	MaxGroupDepth  int    `json:"max_group_depth"`
 
 	// This json tag deviates from snake case because there was a past issue
 	// where the tag was being ignored, causing it to be jsonified as "CaseSensitiveNames".
@@ -310,6 +327,7 @@ func (c *ConfigEntry) PasswordlessMap() map[string]interface{} {
 		"tls_min_version":  c.TLSMinVersion,
 		"tls_max_version":  c.TLSMaxVersion,
 		"use_token_groups": c.UseTokenGroups,
+		"max_group_depth":  c.MaxGroupDepth,
 	}
 	if c.CaseSensitiveNames != nil {
 		m["case_sensitive_names"] = *c.CaseSensitiveNames
@@ -0,0 +1,66 @@
+package random
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/vault/sdk/helper/hclutil"
+)
+
+// ParsePolicy parses an HCL password policy into a StringGenerator. A policy
+// specifies the length of generated strings and one or more rules:
+//
+//	length = 20
+//
+//	rule "charset" {
+//	  charset = "abcdefghijklmnopqrstuvwxyz"
+//	  min_chars = 1
+//	}
+func ParsePolicy(raw string) (*StringGenerator, error) {
+	root, err := hcl.Parse(raw)
+	if err != nil {
+		return nil, errwrap.Wrapf("failed to parse policy: {{err}}", err)
+	}
+
+	list, ok := root.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse policy: does not contain a root object")
+	}
+
+	if err := hclutil.CheckHCLKeys(list, []string{"length", "rule"}); err != nil {
+		return nil, errwrap.Wrapf("failed to parse policy: {{err}}", err)
+	}
+
+	var config struct {
+		Length int `hcl:"length"`
+	}
+	if err := hcl.DecodeObject(&config, list); err != nil {
+		return nil, errwrap.Wrapf("failed to parse policy: {{err}}", err)
+	}
+
+	var rules []Rule
+	for _, item := range list.Filter("rule").Items {
+		if len(item.Keys) == 0 {
+			return nil, fmt.Errorf("failed to parse policy: rule must specify a type")
+		}
+		ruleType := item.Keys[0].Token.Value().(string)
+
+		switch ruleType {
+		case "charset":
+			if err := hclutil.CheckHCLKeys(item.Val, []string{"charset", "min_chars"}); err != nil {
+				return nil, errwrap.Wrapf("failed to parse charset rule: {{err}}", err)
+			}
+			var rule CharsetRule
+			if err := hcl.DecodeObject(&rule, item.Val); err != nil {
+				return nil, errwrap.Wrapf("failed to parse charset rule: {{err}}", err)
+			}
+			rules = append(rules, rule)
+		default:
+			return nil, fmt.Errorf("failed to parse policy: unknown rule type %q", ruleType)
+		}
+	}
+
+	return NewStringGenerator(config.Length, rules)
+}
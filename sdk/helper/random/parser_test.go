@@ -0,0 +1,52 @@
+package random
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePolicy(t *testing.T) {
+	g, err := ParsePolicy(`
+length = 12
+
+rule "charset" {
+  charset = "abcdefghijklmnopqrstuvwxyz"
+  min_chars = 1
+}
+
+rule "charset" {
+  charset = "0123456789"
+  min_chars = 2
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Length != 12 {
+		t.Fatalf("expected length 12, got %d", g.Length)
+	}
+	expected := []Rule{
+		CharsetRule{Charset: "abcdefghijklmnopqrstuvwxyz", MinChars: 1},
+		CharsetRule{Charset: "0123456789", MinChars: 2},
+	}
+	if !reflect.DeepEqual(g.Rules, expected) {
+		t.Fatalf("bad rules: %#v", g.Rules)
+	}
+
+	invalid := map[string]string{
+		"unknown key":       `length = 12` + "\n" + `foo = "bar"`,
+		"unknown rule":      `length = 12` + "\n" + `rule "foo" {}`,
+		"unknown rule key":  `length = 12` + "\n" + `rule "charset" { charset = "abc" foo = 1 }`,
+		"missing length":    `rule "charset" { charset = "abc" }`,
+		"missing charset":   `length = 12` + "\n" + `rule "charset" { min_chars = 1 }`,
+		"no rules":          `length = 12`,
+		"invalid hcl":       `length = `,
+		"wrong length type": `length = "twelve"` + "\n" + `rule "charset" { charset = "abc" }`,
+	}
+	for name, raw := range invalid {
+		if _, err := ParsePolicy(raw); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
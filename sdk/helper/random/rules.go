@@ -0,0 +1,69 @@
+package random
+
+import (
+	"fmt"
+)
+
+// Rule is a constraint that a generated or supplied string must satisfy.
+type Rule interface {
+	// Pass returns whether the value satisfies the rule
+	Pass(value []rune) bool
+
+	// Type returns the name of the rule as used in policy documents
+	Type() string
+}
+
+// CharsetRule requires a minimum number of characters from a given charset.
+// The charsets of all CharsetRules in a policy make up the set of characters
+// that passwords are generated from.
+type CharsetRule struct {
+	// Charset is the set of characters this rule applies to
+	Charset string `hcl:"charset"`
+
+	// MinChars is the minimum number of characters from Charset that must be
+	// present
+	MinChars int `hcl:"min_chars"`
+}
+
+// Type returns "charset"
+func (c CharsetRule) Type() string {
+	return "charset"
+}
+
+// Pass returns whether value contains at least MinChars characters from
+// the rule's charset.
+func (c CharsetRule) Pass(value []rune) bool {
+	if c.MinChars <= 0 {
+		return true
+	}
+
+	count := 0
+	for _, r := range value {
+		if containsRune(c.Charset, r) {
+			count++
+			if count >= c.MinChars {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c CharsetRule) validate() error {
+	if c.Charset == "" {
+		return fmt.Errorf("charset rule must specify a charset")
+	}
+	if c.MinChars < 0 {
+		return fmt.Errorf("min_chars cannot be negative")
+	}
+	return nil
+}
+
+func containsRune(s string, r rune) bool {
+	for _, c := range s {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+// Package random provides generation and validation of random strings, such
+// as passwords, according to a policy made up of a length and a set of rules.
+package random
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+const (
+	// MaxLength is the largest length a policy may specify
+	MaxLength = 4096
+
+	// maxAttempts bounds the number of candidates Generate will try before
+	// giving up on a policy whose rules are too difficult to satisfy
+	maxAttempts = 10000
+)
+
+// StringGenerator generates random strings of a fixed length, drawn from the
+// union of the charsets of its rules, that satisfy all of its rules.
+type StringGenerator struct {
+	// Length of the generated string
+	Length int
+
+	// Rules the generated string must satisfy
+	Rules []Rule
+
+	// charset is the deduplicated union of the charsets of all rules
+	charset []rune
+}
+
+// NewStringGenerator returns a StringGenerator after validating that its
+// rules can be satisfied.
+func NewStringGenerator(length int, rules []Rule) (*StringGenerator, error) {
+	if length <= 0 {
+		return nil, fmt.Errorf("length must be greater than zero")
+	}
+	if length > MaxLength {
+		return nil, fmt.Errorf("length cannot be greater than %d", MaxLength)
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("at least one charset rule must be specified")
+	}
+
+	var charset strings.Builder
+	minChars := 0
+	for _, rule := range rules {
+		cr, ok := rule.(CharsetRule)
+		if !ok {
+			continue
+		}
+		if err := cr.validate(); err != nil {
+			return nil, err
+		}
+		charset.WriteString(cr.Charset)
+		minChars += cr.MinChars
+	}
+	if charset.Len() == 0 {
+		return nil, fmt.Errorf("at least one charset rule must be specified")
+	}
+	if minChars > length {
+		return nil, fmt.Errorf("length %d is less than the %d characters required by the rules", length, minChars)
+	}
+
+	return &StringGenerator{
+		Length:  length,
+		Rules:   rules,
+		charset: dedupeRunes(charset.String()),
+	}, nil
+}
+
+// Generate returns a random string that satisfies all of the generator's
+// rules. If rng is nil, crypto/rand is used.
+func (g *StringGenerator) Generate(ctx context.Context, rng io.Reader) (string, error) {
+	if rng == nil {
+		rng = rand.Reader
+	}
+
+	max := big.NewInt(int64(len(g.charset)))
+	candidate := make([]rune, g.Length)
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		default:
+		}
+
+		for i := range candidate {
+			n, err := rand.Int(rng, max)
+			if err != nil {
+				return "", err
+			}
+			candidate[i] = g.charset[n.Int64()]
+		}
+
+		if g.passes(candidate) {
+			return string(candidate), nil
+		}
+	}
+
+	return "", fmt.Errorf("unable to generate a string satisfying all rules after %d attempts", maxAttempts)
+}
+
+// Validate checks that value could have been produced by the generator: it
+// must be at least Length characters long, contain only characters from the
+// rules' charsets, and satisfy every rule.
+func (g *StringGenerator) Validate(value string) error {
+	runes := []rune(value)
+	if len(runes) < g.Length {
+		return fmt.Errorf("must be at least %d characters long", g.Length)
+	}
+	for _, r := range runes {
+		if !containsRune(string(g.charset), r) {
+			return fmt.Errorf("contains disallowed character %q", r)
+		}
+	}
+	for _, rule := range g.Rules {
+		if !rule.Pass(runes) {
+			if cr, ok := rule.(CharsetRule); ok {
+				return fmt.Errorf("must contain at least %d characters from %q", cr.MinChars, cr.Charset)
+			}
+			return fmt.Errorf("does not satisfy %s rule", rule.Type())
+		}
+	}
+	return nil
+}
+
+func (g *StringGenerator) passes(value []rune) bool {
+	for _, rule := range g.Rules {
+		if !rule.Pass(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func dedupeRunes(s string) []rune {
+	seen := make(map[rune]bool, len(s))
+	var result []rune
+	for _, r := range s {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		result = append(result, r)
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package random
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const (
+	lowercase = "abcdefghijklmnopqrstuvwxyz"
+	uppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits    = "0123456789"
+)
+
+func TestNewStringGenerator(t *testing.T) {
+	cases := map[string]struct {
+		length int
+		rules  []Rule
+		valid  bool
+	}{
+		"valid":          {10, []Rule{CharsetRule{Charset: lowercase, MinChars: 2}}, true},
+		"zero length":    {0, []Rule{CharsetRule{Charset: lowercase}}, false},
+		"too long":       {MaxLength + 1, []Rule{CharsetRule{Charset: lowercase}}, false},
+		"no rules":       {10, nil, false},
+		"empty charset":  {10, []Rule{CharsetRule{}}, false},
+		"negative min":   {10, []Rule{CharsetRule{Charset: lowercase, MinChars: -1}}, false},
+		"unsatisfiable":  {3, []Rule{CharsetRule{Charset: lowercase, MinChars: 2}, CharsetRule{Charset: digits, MinChars: 2}}, false},
+		"exactly enough": {4, []Rule{CharsetRule{Charset: lowercase, MinChars: 2}, CharsetRule{Charset: digits, MinChars: 2}}, true},
+	}
+
+	for name, tc := range cases {
+		_, err := NewStringGenerator(tc.length, tc.rules)
+		if tc.valid && err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestStringGenerator_Generate(t *testing.T) {
+	g, err := NewStringGenerator(20, []Rule{
+		CharsetRule{Charset: lowercase, MinChars: 1},
+		CharsetRule{Charset: uppercase, MinChars: 1},
+		CharsetRule{Charset: digits, MinChars: 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		value, err := g.Generate(context.Background(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(value) != 20 {
+			t.Fatalf("expected length 20, got %q", value)
+		}
+		if err := g.Validate(value); err != nil {
+			t.Fatalf("generated value %q does not validate: %v", value, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := g.Generate(ctx, nil); err == nil {
+		t.Fatal("expected error with canceled context")
+	}
+}
+
+func TestStringGenerator_Validate(t *testing.T) {
+	g, err := NewStringGenerator(8, []Rule{
+		CharsetRule{Charset: lowercase, MinChars: 1},
+		CharsetRule{Charset: digits, MinChars: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]bool{
+		"abcdef12":                      true,
+		"abcdefghij1234":                true,
+		"abc12":                         false,
+		"abcdefg1":                      false,
+		"12345678":                      false,
+		"abcdef12!":                     false,
+		strings.Repeat("a1", MaxLength): true,
+	}
+	for value, valid := range cases {
+		err := g.Validate(value)
+		if valid && err != nil {
+			t.Fatalf("expected %q to be valid: %v", value, err)
+		}
+		if !valid && err == nil {
+			t.Fatalf("expected %q to be invalid", value)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package tokenutil
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/parseutil"
+	"github.com/hashicorp/vault/sdk/helper/policyutil"
+	"github.com/hashicorp/vault/sdk/helper/strutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// TokenParams contains a set of common parameters that auth plugins can use
+// for setting token behavior
+type TokenParams struct {
+	// The set of CIDRs that tokens generated using this role will be bound to
+	TokenBoundCIDRs []*sockaddr.SockAddrMarshaler `json:"token_bound_cidrs"`
+
+	// If set, the token entry will have an explicit maximum TTL set, rather
+	// than deferring to role/mount values
+	TokenExplicitMaxTTL time.Duration `json:"token_explicit_max_ttl" mapstructure:"token_explicit_max_ttl"`
+
+	// The max TTL to use for the token
+	TokenMaxTTL time.Duration `json:"token_max_ttl" mapstructure:"token_max_ttl"`
+
+	// The maximum number of times a token issued from this role may be used.
+	TokenNumUses int `json:"token_num_uses" mapstructure:"token_num_uses"`
+
+	// If non-zero, tokens created using this role will be able to be renewed
+	// forever, but will have a fixed renewal period of this value
+	TokenPeriod time.Duration `json:"token_period" mapstructure:"token_period"`
+
+	// The policies to set
+	TokenPolicies []string `json:"token_policies" mapstructure:"token_policies"`
+
+	// The type of token this role should issue
+	TokenType logical.TokenType `json:"token_type" mapstructure:"token_type"`
+
+	// The TTL to use for the token
+	TokenTTL time.Duration `json:"token_ttl" mapstructure:"token_ttl"`
+}
+
+// AddTokenFields adds fields to an existing role. It panics if it would
+// overwrite an existing field.
+func AddTokenFields(m map[string]*framework.FieldSchema) {
+	AddTokenFieldsWithAllowList(m, nil)
+}
+
+// AddTokenFieldsWithAllowList adds fields to an existing role. It panics if
+// it would overwrite an existing field. Allowed can be use to restrict the
+// set, e.g. if there would be conflicts.
+func AddTokenFieldsWithAllowList(m map[string]*framework.FieldSchema, allowed []string) {
+	r := TokenFields()
+	for k, v := range r {
+		if len(allowed) > 0 && !strutil.StrListContains(allowed, k) {
+			continue
+		}
+		if _, has := m[k]; has {
+			panic(fmt.Sprintf("adding role field %s would overwrite existing field", k))
+		}
+		m[k] = v
+	}
+}
+
+// TokenFields provides a set of field schemas for the parameters
+func TokenFields() map[string]*framework.FieldSchema {
+	return map[string]*framework.FieldSchema{
+		"token_bound_cidrs": &framework.FieldSchema{
+			Type:        framework.TypeCommaStringSlice,
+			Description: `Comma separated string or JSON list of CIDR blocks. If set, specifies the blocks of IP addresses which are allowed to use the generated token.`,
+		},
+
+		"token_explicit_max_ttl": &framework.FieldSchema{
+			Type:        framework.TypeDurationSecond,
+			Description: "If set, tokens created via this role carry an explicit maximum TTL. During renewal, the current maximum TTL values of the role and the mount are not checked for changes, and any updates to these values will have no effect on the token being renewed.",
+		},
+
+		"token_max_ttl": &framework.FieldSchema{
+			Type:        framework.TypeDurationSecond,
+			Description: "The maximum lifetime of the generated token",
+		},
+
+		"token_period": &framework.FieldSchema{
+			Type:        framework.TypeDurationSecond,
+			Description: "If set, tokens created via this role will have no max lifetime; instead, their renewal period will be fixed to this value. This takes an integer number of seconds, or a string duration (e.g. \"24h\").",
+		},
+
+		"token_policies": &framework.FieldSchema{
+			Type:        framework.TypeCommaStringSlice,
+			Description: "Comma-separated list of policies",
+		},
+
+		"token_type": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Default:     "default",
+			Description: "The type of token to generate, service or batch",
+		},
+
+		"token_ttl": &framework.FieldSchema{
+			Type:        framework.TypeDurationSecond,
+			Description: "The initial ttl of the token to generate",
+		},
+
+		"token_num_uses": &framework.FieldSchema{
+			Type:        framework.TypeInt,
+			Description: "The maximum number of times a token may be used, a value of zero means unlimited",
+		},
+	}
+}
+
+// ParseTokenFields provides common field parsing functionality into a
+// TokenParams struct. Fields that are not present in the request are left
+// unchanged, so the same struct can be used on create and update.
+func (t *TokenParams) ParseTokenFields(req *logical.Request, d *framework.FieldData) error {
+	if boundCIDRsRaw, ok := d.GetOk("token_bound_cidrs"); ok {
+		boundCIDRs, err := parseutil.ParseAddrs(boundCIDRsRaw.([]string))
+		if err != nil {
+			return err
+		}
+		t.TokenBoundCIDRs = boundCIDRs
+	}
+
+	if explicitMaxTTLRaw, ok := d.GetOk("token_explicit_max_ttl"); ok {
+		t.TokenExplicitMaxTTL = time.Duration(explicitMaxTTLRaw.(int)) * time.Second
+	}
+
+	if maxTTLRaw, ok := d.GetOk("token_max_ttl"); ok {
+		t.TokenMaxTTL = time.Duration(maxTTLRaw.(int)) * time.Second
+	}
+	if t.TokenMaxTTL < 0 {
+		return errors.New("'token_max_ttl' cannot be negative")
+	}
+
+	if numUsesRaw, ok := d.GetOk("token_num_uses"); ok {
+		t.TokenNumUses = numUsesRaw.(int)
+	}
+	if t.TokenNumUses < 0 {
+		return errors.New("'token_num_uses' cannot be negative")
+	}
+
+	if periodRaw, ok := d.GetOk("token_period"); ok {
+		t.TokenPeriod = time.Duration(periodRaw.(int)) * time.Second
+	}
+	if t.TokenPeriod < 0 {
+		return errors.New("'token_period' cannot be negative")
+	}
+
+	if policiesRaw, ok := d.GetOk("token_policies"); ok {
+		t.TokenPolicies = policyutil.SanitizePolicies(policiesRaw.([]string), policyutil.DoNotAddDefaultPolicy)
+	}
+
+	if tokenTypeRaw, ok := d.GetOk("token_type"); ok {
+		var tokenType logical.TokenType
+		tokenTypeStr := tokenTypeRaw.(string)
+		switch tokenTypeStr {
+		case "service":
+			tokenType = logical.TokenTypeService
+		case "batch":
+			tokenType = logical.TokenTypeBatch
+		case "", "default":
+			tokenType = logical.TokenTypeDefault
+		default:
+			return fmt.Errorf("invalid 'token_type' value %q", tokenTypeStr)
+		}
+		t.TokenType = tokenType
+	}
+
+	if t.TokenType == logical.TokenTypeBatch {
+		if t.TokenPeriod != 0 {
+			return errors.New("'token_type' cannot be 'batch' when set to generate periodic tokens")
+		}
+		if t.TokenNumUses != 0 {
+			return errors.New("'token_type' cannot be 'batch' when set to generate tokens with limited use count")
+		}
+	}
+
+	if ttlRaw, ok := d.GetOk("token_ttl"); ok {
+		t.TokenTTL = time.Duration(ttlRaw.(int)) * time.Second
+	}
+	if t.TokenTTL < 0 {
+		return errors.New("'token_ttl' cannot be negative")
+	}
+	if t.TokenTTL > 0 && t.TokenMaxTTL > 0 && t.TokenTTL > t.TokenMaxTTL {
+		return errors.New("'token_ttl' cannot be greater than 'token_max_ttl'")
+	}
+
+	return nil
+}
+
+// PopulateTokenData adds information from TokenParams into the map
+func (t *TokenParams) PopulateTokenData(m map[string]interface{}) {
+	policies := t.TokenPolicies
+	if policies == nil {
+		policies = []string{}
+	}
+
+	m["token_bound_cidrs"] = t.TokenBoundCIDRs
+	m["token_explicit_max_ttl"] = int64(t.TokenExplicitMaxTTL.Seconds())
+	m["token_max_ttl"] = int64(t.TokenMaxTTL.Seconds())
+	m["token_period"] = int64(t.TokenPeriod.Seconds())
+	m["token_policies"] = policies
+	m["token_type"] = t.TokenType.String()
+	m["token_ttl"] = int64(t.TokenTTL.Seconds())
+	m["token_num_uses"] = t.TokenNumUses
+}
+
+// PopulateTokenAuth populates Auth with parameters
+func (t *TokenParams) PopulateTokenAuth(auth *logical.Auth) {
+	auth.BoundCIDRs = t.TokenBoundCIDRs
+	auth.ExplicitMaxTTL = t.TokenExplicitMaxTTL
+	auth.MaxTTL = t.TokenMaxTTL
+	auth.NumUses = t.TokenNumUses
+	auth.Period = t.TokenPeriod
+	auth.Policies = t.TokenPolicies
+	auth.Renewable = true
+	auth.TokenType = t.TokenType
+	auth.TTL = t.TokenTTL
+}
+
+// DeprecationText returns the description of a field that has been
+// superseded by one of the shared token fields
+func DeprecationText(param string) string {
+	return fmt.Sprintf("Use %q instead. If this and %q are both specified, only %q will be used.", param, param, param)
+}
+
+// UpgradeValue handles a field that has been superseded by one of the shared
+// token fields. If only the old field is present in the request its value is
+// used for both; if the new field is present the old value is kept in sync
+// with it, or cleared when the old field was not sent, so that reads stop
+// reporting it. oldVal and newVal must be pointers of the same type; string
+// slices are treated as policy lists.
+func UpgradeValue(d *framework.FieldData, oldKey, newKey string, oldVal, newVal interface{}) error {
+	_, ok := d.GetOk(newKey)
+	if !ok {
+		raw, ok := d.GetOk(oldKey)
+		if !ok {
+			return nil
+		}
+
+		switch o := oldVal.(type) {
+		case *time.Duration:
+			n, isDuration := newVal.(*time.Duration)
+			if !isDuration {
+				return errors.New("mismatched upgrade value types")
+			}
+			*o = time.Duration(raw.(int)) * time.Second
+			*n = *o
+
+		case *int:
+			n, isInt := newVal.(*int)
+			if !isInt {
+				return errors.New("mismatched upgrade value types")
+			}
+			*o = raw.(int)
+			*n = *o
+
+		case *[]string:
+			n, isStrings := newVal.(*[]string)
+			if !isStrings {
+				return errors.New("mismatched upgrade value types")
+			}
+			*o = policyutil.ParsePolicies(raw)
+			*n = *o
+
+		case *[]*sockaddr.SockAddrMarshaler:
+			n, isAddrs := newVal.(*[]*sockaddr.SockAddrMarshaler)
+			if !isAddrs {
+				return errors.New("mismatched upgrade value types")
+			}
+			addrs, err := parseutil.ParseAddrs(raw)
+			if err != nil {
+				return err
+			}
+			*o = addrs
+			*n = *o
+
+		default:
+			return errors.New("unknown upgrade value type")
+		}
+		return nil
+	}
+
+	_, oldOk := d.GetOk(oldKey)
+	switch o := oldVal.(type) {
+	case *time.Duration:
+		if oldOk {
+			*o = *(newVal.(*time.Duration))
+		} else {
+			*o = 0
+		}
+	case *int:
+		if oldOk {
+			*o = *(newVal.(*int))
+		} else {
+			*o = 0
+		}
+	case *[]string:
+		if oldOk {
+			*o = *(newVal.(*[]string))
+		} else {
+			*o = nil
+		}
+	case *[]*sockaddr.SockAddrMarshaler:
+		if oldOk {
+			*o = *(newVal.(*[]*sockaddr.SockAddrMarshaler))
+		} else {
+			*o = nil
+		}
+	default:
+		return errors.New("unknown upgrade value type")
+	}
+	return nil
+}
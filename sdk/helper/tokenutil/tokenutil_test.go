@@ -0,0 +1,132 @@
+package tokenutil
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func testFieldData(raw map[string]interface{}) *framework.FieldData {
+	schema := map[string]*framework.FieldSchema{
+		"policies": &framework.FieldSchema{
+			Type: framework.TypeCommaStringSlice,
+		},
+		"ttl": &framework.FieldSchema{
+			Type: framework.TypeDurationSecond,
+		},
+	}
+	AddTokenFields(schema)
+	return &framework.FieldData{
+		Raw:    raw,
+		Schema: schema,
+	}
+}
+
+func TestTokenParams_ParseTokenFields(t *testing.T) {
+	var p TokenParams
+	d := testFieldData(map[string]interface{}{
+		"token_bound_cidrs": "127.0.0.1/32,10.0.0.0/8",
+		"token_max_ttl":     "1h",
+		"token_num_uses":    5,
+		"token_policies":    "foo,bar,foo",
+		"token_ttl":         60,
+		"token_type":        "service",
+	})
+	if err := p.ParseTokenFields(&logical.Request{}, d); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(p.TokenBoundCIDRs) != 2 {
+		t.Fatalf("bad: bound cidrs: %v", p.TokenBoundCIDRs)
+	}
+	if p.TokenMaxTTL != time.Hour || p.TokenTTL != time.Minute || p.TokenNumUses != 5 {
+		t.Fatalf("bad: %#v", p)
+	}
+	if !reflect.DeepEqual(p.TokenPolicies, []string{"bar", "foo"}) {
+		t.Fatalf("bad: policies: %v", p.TokenPolicies)
+	}
+	if p.TokenType != logical.TokenTypeService {
+		t.Fatalf("bad: token type: %v", p.TokenType)
+	}
+
+	// Fields not in the request are left alone
+	if err := p.ParseTokenFields(&logical.Request{}, testFieldData(map[string]interface{}{"token_num_uses": 1})); err != nil {
+		t.Fatal(err)
+	}
+	if p.TokenNumUses != 1 || p.TokenTTL != time.Minute {
+		t.Fatalf("bad: %#v", p)
+	}
+
+	for _, raw := range []map[string]interface{}{
+		{"token_ttl": 120, "token_max_ttl": 60},
+		{"token_num_uses": -1},
+		{"token_type": "bogus"},
+		{"token_type": "batch", "token_period": 60},
+		{"token_bound_cidrs": "not-a-cidr"},
+	} {
+		var p TokenParams
+		if err := p.ParseTokenFields(&logical.Request{}, testFieldData(raw)); err == nil {
+			t.Fatalf("expected error for %v", raw)
+		}
+	}
+}
+
+func TestTokenParams_PopulateTokenAuth(t *testing.T) {
+	p := TokenParams{
+		TokenMaxTTL:   time.Hour,
+		TokenPolicies: []string{"foo"},
+		TokenType:     logical.TokenTypeBatch,
+		TokenTTL:      time.Minute,
+	}
+
+	auth := &logical.Auth{}
+	p.PopulateTokenAuth(auth)
+	if !auth.Renewable || auth.TTL != time.Minute || auth.MaxTTL != time.Hour ||
+		auth.TokenType != logical.TokenTypeBatch || !reflect.DeepEqual(auth.Policies, []string{"foo"}) {
+		t.Fatalf("bad: %#v", auth)
+	}
+
+	data := map[string]interface{}{}
+	p.PopulateTokenData(data)
+	if data["token_ttl"].(int64) != 60 || data["token_max_ttl"].(int64) != 3600 || data["token_type"].(string) != "batch" {
+		t.Fatalf("bad: %#v", data)
+	}
+}
+
+func TestUpgradeValue(t *testing.T) {
+	// Only the old field is given
+	var oldTTL, newTTL time.Duration
+	if err := UpgradeValue(testFieldData(map[string]interface{}{"ttl": 30}), "ttl", "token_ttl", &oldTTL, &newTTL); err != nil {
+		t.Fatal(err)
+	}
+	if oldTTL != 30*time.Second || newTTL != 30*time.Second {
+		t.Fatalf("bad: old: %v new: %v", oldTTL, newTTL)
+	}
+
+	// Only the new field is given, which clears the old value
+	newTTL = 45 * time.Second
+	if err := UpgradeValue(testFieldData(map[string]interface{}{"token_ttl": 45}), "ttl", "token_ttl", &oldTTL, &newTTL); err != nil {
+		t.Fatal(err)
+	}
+	if oldTTL != 0 || newTTL != 45*time.Second {
+		t.Fatalf("bad: old: %v new: %v", oldTTL, newTTL)
+	}
+
+	// Both are given, the new field wins
+	oldPolicies := []string{"old"}
+	newPolicies := []string{"new"}
+	d := testFieldData(map[string]interface{}{"policies": "old", "token_policies": "new"})
+	if err := UpgradeValue(d, "policies", "token_policies", &oldPolicies, &newPolicies); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(oldPolicies, []string{"new"}) {
+		t.Fatalf("bad: old policies: %v", oldPolicies)
+	}
+
+	if err := UpgradeValue(testFieldData(map[string]interface{}{"ttl": 30}), "ttl", "token_ttl", &oldTTL, &newPolicies); err == nil {
+		t.Fatal("expected error for mismatched types")
+	}
+}
@@ -38,6 +38,9 @@ func (b BackendType) String() string {
 // allows for a "procfs" like interaction, as internal state can be exposed by
 // acting like a logical backend and being mounted.
 type Backend interface {
+	// Initialize is used to initialize a plugin after it has been mounted.
+	Initialize(context.Context, *InitializationRequest) error
+
 	// HandleRequest is used to handle a request and generate a response.
 	// The backends must check the operation type and handle appropriately.
 	HandleRequest(context.Context, *Request) (*Response, error)
@@ -104,6 +107,13 @@ type BackendConfig struct {
 	Config map[string]string
 }
 
+// InitializationRequest stores the parameters and context of an Initialize()
+// call being made to a logical.Backend.
+type InitializationRequest struct {
+	// Storage can be used to durably store and retrieve state.
+	Storage Storage
+}
+
 // Factory is the factory function to create a logical backend.
 type Factory func(context.Context, *BackendConfig) (Backend, error)
 
@@ -2,6 +2,7 @@ package logical
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 )
@@ -99,6 +100,11 @@ type Request struct {
 	// authentication/protection.
 	Connection *Connection `json:"connection" structs:"connection" mapstructure:"connection"`
 
+	// HTTPRequest is set when the request body is not parsed into Data, so
+	// that builtin backends can consume binary request bodies directly. It
+	// is not passed to external plugins.
+	HTTPRequest *http.Request `json:"-" structs:"-" mapstructure:"-" sentinel:""`
+
 	// ClientToken is provided to the core so that the identity
 	// can be verified and ACLs applied. This value is passed
 	// through to the logical backends but after being salted and
@@ -143,6 +149,10 @@ type Request struct {
 	// soft-mandatory Sentinel policies
 	PolicyOverride bool `json:"policy_override" structs:"policy_override" mapstructure:"policy_override"`
 
+	// BypassCache indicates that the requestor wishes storage reads made
+	// while serving the request to skip the physical cache
+	BypassCache bool `json:"bypass_cache" structs:"bypass_cache" mapstructure:"bypass_cache"`
+
 	// Whether the request is unauthenticated, as in, had no client token
 	// attached. Useful in some situations where the client token is not made
 	// accessible.
@@ -284,6 +294,7 @@ const (
 	UpdateOperation                   = "update"
 	DeleteOperation                   = "delete"
 	ListOperation                     = "list"
+	PatchOperation                    = "patch"
 	HelpOperation                     = "help"
 	AliasLookaheadOperation           = "alias-lookahead"
 
@@ -154,9 +154,13 @@ func RespondError(w http.ResponseWriter, status int, err error) {
 	w.WriteHeader(status)
 
 	type ErrorResponse struct {
-		Errors []string `json:"errors"`
+		Errors    []string `json:"errors"`
+		RequestID string   `json:"request_id,omitempty"`
+	}
+	resp := &ErrorResponse{
+		Errors:    make([]string, 0, 1),
+		RequestID: w.Header().Get(consts.RequestIDHeaderName),
 	}
-	resp := &ErrorResponse{Errors: make([]string, 0, 1)}
 	if err != nil {
 		resp.Errors = append(resp.Errors, err.Error())
 	}
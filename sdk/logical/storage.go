@@ -2,12 +2,15 @@ package logical
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
+	"github.com/hashicorp/vault/sdk/physical"
 )
 
 // ErrReadOnly is returned when a backend does not support
@@ -27,6 +30,67 @@ type Storage interface {
 	Delete(context.Context, string) error
 }
 
+// PaginatedStorage is an optional interface for storage that can list the
+// keys under a prefix a page at a time. See physical.Paginated.
+type PaginatedStorage interface {
+	ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error)
+}
+
+// ListPage lists the keys under the given prefix, up to the next prefix,
+// that sort after the given key, returning at most limit keys. The storage's
+// own pagination is used if it supports it, otherwise the full list is
+// paginated.
+func ListPage(ctx context.Context, s Storage, prefix string, after string, limit int) ([]string, error) {
+	if p, ok := s.(PaginatedStorage); ok {
+		return p.ListPage(ctx, prefix, after, limit)
+	}
+
+	keys, err := s.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return physical.PaginateKeys(keys, after, limit), nil
+}
+
+// ListPagination returns the after and limit parameters of a list request,
+// which the HTTP layer passes in the request data. A zero limit means no
+// limit.
+func ListPagination(req *Request) (string, int) {
+	if req == nil || req.Operation != ListOperation || req.Data == nil {
+		return "", 0
+	}
+
+	after, _ := req.Data["after"].(string)
+
+	var limit int
+	switch v := req.Data["limit"].(type) {
+	case int:
+		limit = v
+	case float64:
+		limit = int(v)
+	case json.Number:
+		n, _ := v.Int64()
+		limit = int(n)
+	case string:
+		limit, _ = strconv.Atoi(v)
+	}
+	if limit < 0 {
+		limit = 0
+	}
+
+	return after, limit
+}
+
+// ListRequestPage lists the keys under the given prefix, paged according to
+// the after and limit parameters of the list request
+func ListRequestPage(ctx context.Context, s Storage, req *Request, prefix string) ([]string, error) {
+	after, limit := ListPagination(req)
+	if after == "" && limit == 0 {
+		return s.List(ctx, prefix)
+	}
+	return ListPage(ctx, s, prefix, after, limit)
+}
+
 // StorageEntry is the entry for an item in a Storage implementation.
 type StorageEntry struct {
 	Key      string
@@ -2,6 +2,7 @@ package logical
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/go-test/deep"
@@ -34,6 +35,33 @@ func TestScanView(t *testing.T) {
 	}
 }
 
+func TestListPagination(t *testing.T) {
+	req := &Request{
+		Operation: ListOperation,
+		Data: map[string]interface{}{
+			"after": "b",
+			"limit": json.Number("2"),
+		},
+	}
+	if after, limit := ListPagination(req); after != "b" || limit != 2 {
+		t.Fatalf("bad: %q %d", after, limit)
+	}
+
+	keys, err := ListRequestPage(context.Background(), prepKeyStorage(t), req, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(keys, []string{"c/", "d"}); diff != nil {
+		t.Fatal(diff)
+	}
+
+	// Only list requests are paged
+	req.Operation = ReadOperation
+	if after, limit := ListPagination(req); after != "" || limit != 0 {
+		t.Fatalf("bad: %q %d", after, limit)
+	}
+}
+
 func TestCollectKeys(t *testing.T) {
 	s := prepKeyStorage(t)
 
@@ -3,11 +3,13 @@ package logical
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/vault/sdk/helper/consts"
 	"github.com/hashicorp/vault/sdk/helper/license"
 	"github.com/hashicorp/vault/sdk/helper/pluginutil"
+	"github.com/hashicorp/vault/sdk/helper/random"
 	"github.com/hashicorp/vault/sdk/helper/wrapping"
 )
 
@@ -70,6 +72,19 @@ type SystemView interface {
 	PluginEnv(context.Context) (*PluginEnvironment, error)
 }
 
+// PasswordPolicySystemView is implemented by system views that can generate
+// and validate passwords using the password policies configured in Vault.
+// Backends should type assert for it, since not every SystemView does.
+type PasswordPolicySystemView interface {
+	// GeneratePasswordFromPolicy generates a password from the named password
+	// policy
+	GeneratePasswordFromPolicy(ctx context.Context, policyName string) (string, error)
+
+	// ValidatePasswordFromPolicy returns an error if the password does not
+	// satisfy the named password policy
+	ValidatePasswordFromPolicy(ctx context.Context, policyName, password string) error
+}
+
 type StaticSystemView struct {
 	DefaultLeaseTTLVal  time.Duration
 	MaxLeaseTTLVal      time.Duration
@@ -84,6 +99,7 @@ type StaticSystemView struct {
 	Features            license.Features
 	VaultVersion        string
 	PluginEnvironment   *PluginEnvironment
+	PasswordPolicies    map[string]string
 }
 
 func (d StaticSystemView) DefaultLeaseTTL() time.Duration {
@@ -137,3 +153,27 @@ func (d StaticSystemView) HasFeature(feature license.Features) bool {
 func (d StaticSystemView) PluginEnv(_ context.Context) (*PluginEnvironment, error) {
 	return d.PluginEnvironment, nil
 }
+
+func (d StaticSystemView) passwordPolicy(policyName string) (*random.StringGenerator, error) {
+	raw, ok := d.PasswordPolicies[policyName]
+	if !ok {
+		return nil, fmt.Errorf("password policy %q not found", policyName)
+	}
+	return random.ParsePolicy(raw)
+}
+
+func (d StaticSystemView) GeneratePasswordFromPolicy(ctx context.Context, policyName string) (string, error) {
+	policy, err := d.passwordPolicy(policyName)
+	if err != nil {
+		return "", err
+	}
+	return policy.Generate(ctx, nil)
+}
+
+func (d StaticSystemView) ValidatePasswordFromPolicy(_ context.Context, policyName, password string) error {
+	policy, err := d.passwordPolicy(policyName)
+	if err != nil {
+		return err
+	}
+	return policy.Validate(password)
+}
@@ -1,6 +1,8 @@
 package logical
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	sockaddr "github.com/hashicorp/go-sockaddr"
@@ -45,6 +47,50 @@ func (t TokenType) String() string {
 	}
 }
 
+// UnmarshalJSON accepts both the numeric encoding of a TokenType and its
+// string form, which some auth backends persisted before token parameters
+// were shared between them.
+func (t *TokenType) UnmarshalJSON(b []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	switch v := raw.(type) {
+	case float64:
+		*t = TokenType(v)
+		return nil
+	case string:
+		tokenType, err := ParseTokenType(v)
+		if err != nil {
+			return err
+		}
+		*t = tokenType
+		return nil
+	default:
+		return fmt.Errorf("invalid token type %v", raw)
+	}
+}
+
+// ParseTokenType returns the TokenType for its string form. An empty string
+// is treated as TokenTypeDefault.
+func ParseTokenType(str string) (TokenType, error) {
+	switch str {
+	case "", "default":
+		return TokenTypeDefault, nil
+	case "service":
+		return TokenTypeService, nil
+	case "batch":
+		return TokenTypeBatch, nil
+	case "default-service":
+		return TokenTypeDefaultService, nil
+	case "default-batch":
+		return TokenTypeDefaultBatch, nil
+	default:
+		return TokenTypeDefault, fmt.Errorf("invalid token type %q", str)
+	}
+}
+
 // TokenEntry is used to represent a given token
 type TokenEntry struct {
 	Type TokenType `json:"type" mapstructure:"type" structs:"type" sentinel:""`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync/atomic"
 
+	metrics "github.com/armon/go-metrics"
 	log "github.com/hashicorp/go-hclog"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/vault/sdk/helper/locksutil"
@@ -25,6 +26,22 @@ var cacheExceptionsPaths = []string{
 	"core/poison-pill",
 }
 
+type cacheBypassKey struct{}
+
+// CacheBypassContext returns a context that makes reads through a Cache skip
+// the cached value and read from the underlying backend instead. The cache
+// is refreshed with the value that was read.
+func CacheBypassContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+// CacheBypassFromContext reports whether reads made with ctx should bypass
+// the cache
+func CacheBypassFromContext(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
 // Cache is used to wrap an underlying physical backend
 // and provide an LRU cache layer on top. Most of the reads done by
 // Vault are for policy objects so there is a large read reduction
@@ -49,6 +66,7 @@ var _ ToggleablePurgemonster = (*Cache)(nil)
 var _ ToggleablePurgemonster = (*TransactionalCache)(nil)
 var _ Backend = (*Cache)(nil)
 var _ Transactional = (*TransactionalCache)(nil)
+var _ Paginated = (*Cache)(nil)
 
 // NewCache returns a physical cache of the given size.
 // If no size is provided, the default size is used.
@@ -102,6 +120,11 @@ func (c *Cache) SetEnabled(enabled bool) {
 	atomic.StoreUint32(c.enabled, 0)
 }
 
+// Enabled reports whether the cache is on
+func (c *Cache) Enabled() bool {
+	return atomic.LoadUint32(c.enabled) == 1
+}
+
 // Purge is used to clear the cache
 func (c *Cache) Purge(ctx context.Context) {
 	// Lock the world
@@ -138,12 +161,16 @@ func (c *Cache) Get(ctx context.Context, key string) (*Entry, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
-	// Check the LRU first
-	if raw, ok := c.lru.Get(key); ok {
-		if raw == nil {
-			return nil, nil
+	// Check the LRU first, unless the request asked for a fresh read
+	if !CacheBypassFromContext(ctx) {
+		if raw, ok := c.lru.Get(key); ok {
+			metrics.IncrCounter([]string{"cache", "hit"}, 1)
+			if raw == nil {
+				return nil, nil
+			}
+			return raw.(*Entry), nil
 		}
-		return raw.(*Entry), nil
+		metrics.IncrCounter([]string{"cache", "miss"}, 1)
 	}
 
 	// Read from the underlying backend
@@ -181,6 +208,11 @@ func (c *Cache) List(ctx context.Context, prefix string) ([]string, error) {
 	return c.backend.List(ctx, prefix)
 }
 
+func (c *Cache) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	// Always pass-through, like List
+	return ListPage(ctx, c.backend, prefix, after, limit)
+}
+
 func (c *TransactionalCache) Locks() []*locksutil.LockEntry {
 	return c.locks
 }
@@ -26,6 +26,7 @@ type TransactionalStorageEncoding struct {
 // Verify StorageEncoding satisfies the correct interfaces
 var _ Backend = (*StorageEncoding)(nil)
 var _ Transactional = (*TransactionalStorageEncoding)(nil)
+var _ Paginated = (*StorageEncoding)(nil)
 
 // NewStorageEncoding returns a wrapped physical backend and verifies the key
 // encoding
@@ -91,6 +92,10 @@ func (e *TransactionalStorageEncoding) Transaction(ctx context.Context, txns []*
 	return e.Transactional.Transaction(ctx, txns)
 }
 
+func (e *StorageEncoding) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	return ListPage(ctx, e.Backend, prefix, after, limit)
+}
+
 func (e *StorageEncoding) Purge(ctx context.Context) {
 	if purgeable, ok := e.Backend.(ToggleablePurgemonster); ok {
 		purgeable.Purge(ctx)
@@ -102,3 +107,10 @@ func (e *StorageEncoding) SetEnabled(enabled bool) {
 		purgeable.SetEnabled(enabled)
 	}
 }
+
+func (e *StorageEncoding) Enabled() bool {
+	if purgeable, ok := e.Backend.(ToggleablePurgemonster); ok {
+		return purgeable.Enabled()
+	}
+	return false
+}
@@ -31,6 +31,7 @@ type TransactionalErrorInjector struct {
 // Verify ErrorInjector satisfies the correct interfaces
 var _ Backend = (*ErrorInjector)(nil)
 var _ Transactional = (*TransactionalErrorInjector)(nil)
+var _ Paginated = (*ErrorInjector)(nil)
 
 // NewErrorInjector returns a wrapped physical backend to inject error
 func NewErrorInjector(b Backend, errorPercent int, logger log.Logger) *ErrorInjector {
@@ -95,6 +96,13 @@ func (e *ErrorInjector) List(ctx context.Context, prefix string) ([]string, erro
 	return e.backend.List(ctx, prefix)
 }
 
+func (e *ErrorInjector) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	if err := e.addError(); err != nil {
+		return nil, err
+	}
+	return ListPage(ctx, e.backend, prefix, after, limit)
+}
+
 func (e *TransactionalErrorInjector) Transaction(ctx context.Context, txns []*TxnEntry) error {
 	if err := e.addError(); err != nil {
 		return err
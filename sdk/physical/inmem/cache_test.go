@@ -68,6 +68,55 @@ func TestCache_Purge(t *testing.T) {
 	}
 }
 
+func TestCache_Bypass(t *testing.T) {
+	logger := logging.NewVaultLogger(log.Debug)
+
+	inm, err := NewInmem(nil, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := physical.NewCache(inm, 0, logger)
+	cache.SetEnabled(true)
+
+	err = cache.Put(context.Background(), &physical.Entry{Key: "foo", Value: []byte("bar")})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Change the value from under the cache
+	err = inm.Put(context.Background(), &physical.Entry{Key: "foo", Value: []byte("baz")})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// A normal read returns the stale cached value
+	out, err := cache.Get(context.Background(), "foo")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if string(out.Value) != "bar" {
+		t.Fatalf("expected cached value, got %q", out.Value)
+	}
+
+	// A bypassing read returns the stored value
+	out, err = cache.Get(physical.CacheBypassContext(context.Background()), "foo")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if string(out.Value) != "baz" {
+		t.Fatalf("expected stored value, got %q", out.Value)
+	}
+
+	// and refreshes the cache
+	out, err = cache.Get(context.Background(), "foo")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if string(out.Value) != "baz" {
+		t.Fatalf("expected refreshed value, got %q", out.Value)
+	}
+}
+
 func TestCache_Disable(t *testing.T) {
 	logger := logging.NewVaultLogger(log.Debug)
 
@@ -76,6 +125,9 @@ func TestCache_Disable(t *testing.T) {
 		t.Fatal(err)
 	}
 	cache := physical.NewCache(inm, 0, logger)
+	if cache.Enabled() {
+		t.Fatal("cache should start disabled")
+	}
 
 	disabledTests := func() {
 		ent := &physical.Entry{
@@ -23,6 +23,8 @@ var _ physical.HABackend = (*TransactionalInmemHABackend)(nil)
 var _ physical.Lock = (*InmemLock)(nil)
 var _ physical.Transactional = (*TransactionalInmemBackend)(nil)
 var _ physical.Transactional = (*TransactionalInmemHABackend)(nil)
+var _ physical.Paginated = (*InmemBackend)(nil)
+var _ physical.Paginated = (*InmemHABackend)(nil)
 
 var (
 	PutDisabledError    = errors.New("put operations disabled in inmem backend")
@@ -270,6 +272,52 @@ func (i *InmemBackend) ListInternal(ctx context.Context, prefix string) ([]strin
 	return out, nil
 }
 
+// ListPage is used to list a page of the keys under a given prefix, up to
+// the next prefix. Keys are walked in order, so the walk stops as soon as
+// the page is full.
+func (i *InmemBackend) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	i.permitPool.Acquire()
+	defer i.permitPool.Release()
+
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.ListPageInternal(ctx, prefix, after, limit)
+}
+
+func (i *InmemBackend) ListPageInternal(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	if i.logOps {
+		i.logger.Trace("list page", "prefix", prefix, "after", after, "limit", limit)
+	}
+	if atomic.LoadUint32(i.failList) != 0 {
+		return nil, ListDisabledError
+	}
+
+	// Keys sharing a folder are contiguous in the walk, so a folder only has
+	// to be compared against the last key added
+	var out []string
+	walkFn := func(s string, v interface{}) bool {
+		trimmed := strings.TrimPrefix(s, prefix)
+		if sep := strings.Index(trimmed, "/"); sep != -1 {
+			trimmed = trimmed[:sep+1]
+		}
+		if (after != "" && trimmed <= after) || (len(out) > 0 && out[len(out)-1] == trimmed) {
+			return false
+		}
+		out = append(out, trimmed)
+		return limit > 0 && len(out) >= limit
+	}
+	i.root.WalkPrefix(prefix, walkFn)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	return out, nil
+}
+
 func (i *InmemBackend) FailList(fail bool) {
 	var val uint32
 	if fail {
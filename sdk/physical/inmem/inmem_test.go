@@ -1,6 +1,8 @@
 package inmem
 
 import (
+	"context"
+	"reflect"
 	"testing"
 
 	log "github.com/hashicorp/go-hclog"
@@ -18,3 +20,54 @@ func TestInmem(t *testing.T) {
 	physical.ExerciseBackend(t, inm)
 	physical.ExerciseBackend_ListPrefix(t, inm)
 }
+
+func TestInmem_ListPage(t *testing.T) {
+	logger := logging.NewVaultLogger(log.Debug)
+
+	inm, err := NewInmem(nil, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, key := range []string{"foo/a", "foo/b/c", "foo/b/d", "foo/b0", "foo/c", "foo/d/e", "bar"} {
+		if err := inm.Put(ctx, &physical.Entry{Key: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		after    string
+		limit    int
+		expected []string
+	}{
+		{"", 0, []string{"a", "b/", "b0", "c", "d/"}},
+		{"", 2, []string{"a", "b/"}},
+		{"b/", 2, []string{"b0", "c"}},
+		{"b", 0, []string{"b/", "b0", "c", "d/"}},
+		{"c", 10, []string{"d/"}},
+		{"d/", 0, nil},
+	}
+	for _, tc := range cases {
+		keys, err := inm.(physical.Paginated).ListPage(ctx, "foo/", tc.after, tc.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(keys, tc.expected) {
+			t.Fatalf("after %q limit %d: expected %v, got %v", tc.after, tc.limit, tc.expected, keys)
+		}
+
+		// Paginating the full list must give the same page
+		all, err := inm.List(ctx, "foo/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = physical.PaginateKeys(all, tc.after, tc.limit)
+		if len(keys) == 0 {
+			keys = nil
+		}
+		if !reflect.DeepEqual(keys, tc.expected) {
+			t.Fatalf("after %q limit %d: expected %v from the full list, got %v", tc.after, tc.limit, tc.expected, keys)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package inmem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/vault/sdk/helper/logging"
+	"github.com/hashicorp/vault/sdk/physical"
+)
+
+func TestMetricsBackend(t *testing.T) {
+	logger := logging.NewVaultLogger(log.Debug)
+
+	inm, err := NewInmem(nil, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := physical.NewMetricsBackend(inm, "inmem")
+	physical.ExerciseBackend(t, b)
+	physical.ExerciseBackend_ListPrefix(t, b)
+}
+
+func TestTransactionalMetricsBackend(t *testing.T) {
+	logger := logging.NewVaultLogger(log.Debug)
+
+	inm, err := NewTransactionalInmem(nil, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := physical.NewTransactionalMetricsBackend(inm, "inmem")
+	physical.ExerciseBackend(t, b)
+	physical.ExerciseBackend_ListPrefix(t, b)
+	physical.ExerciseTransactionalBackend(t, b)
+}
+
+func TestMetricsBackend_Emit(t *testing.T) {
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+	conf := metrics.DefaultConfig("")
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+	if _, err := metrics.NewGlobal(conf, sink); err != nil {
+		t.Fatal(err)
+	}
+	defer metrics.NewGlobal(conf, &metrics.BlackholeSink{})
+
+	logger := logging.NewVaultLogger(log.Debug)
+	inm, err := NewInmem(nil, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := physical.NewMetricsBackend(inm, "inmem")
+
+	ctx := context.Background()
+	if err := b.Put(ctx, &physical.Entry{Key: "foo", Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Get(ctx, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.List(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Delete(ctx, "foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Force an error from the underlying backend
+	inm.(*InmemBackend).FailPut(true)
+	if err := b.Put(ctx, &physical.Entry{Key: "foo", Value: []byte("bar")}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	intervals := sink.Data()
+	if len(intervals) != 1 {
+		t.Fatalf("expected one interval, got %d", len(intervals))
+	}
+	samples := intervals[0].Samples
+	for _, op := range []string{"get", "put", "delete", "list"} {
+		key := "storage." + op + ";backend=inmem"
+		if _, ok := samples[key]; !ok {
+			t.Fatalf("missing latency sample %q", key)
+		}
+	}
+	if put := samples["storage.put;backend=inmem"]; put.Count != 2 {
+		t.Fatalf("expected 2 put samples, got %d", put.Count)
+	}
+
+	errCount, ok := intervals[0].Counters["storage.put.error;backend=inmem"]
+	if !ok {
+		t.Fatal("missing put error counter")
+	}
+	if errCount.Count != 1 {
+		t.Fatalf("expected 1 put error, got %d", errCount.Count)
+	}
+	if _, ok := intervals[0].Counters["storage.get.error;backend=inmem"]; ok {
+		t.Fatal("unexpected get error counter")
+	}
+}
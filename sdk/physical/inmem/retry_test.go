@@ -0,0 +1,161 @@
+package inmem
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/vault/sdk/helper/logging"
+	"github.com/hashicorp/vault/sdk/physical"
+)
+
+// flakyBackend fails the first failures calls to Get with err
+type flakyBackend struct {
+	physical.Backend
+	failures int32
+	calls    int32
+	err      error
+}
+
+func (f *flakyBackend) Get(ctx context.Context, key string) (*physical.Entry, error) {
+	if atomic.AddInt32(&f.calls, 1) <= atomic.LoadInt32(&f.failures) {
+		return nil, f.err
+	}
+	return f.Backend.Get(ctx, key)
+}
+
+func newFlakyBackend(t *testing.T, failures int32, err error) *flakyBackend {
+	inm, inmErr := NewInmem(nil, logging.NewVaultLogger(log.Debug))
+	if inmErr != nil {
+		t.Fatal(inmErr)
+	}
+	return &flakyBackend{Backend: inm, failures: failures, err: err}
+}
+
+func TestRetryBackend(t *testing.T) {
+	logger := logging.NewVaultLogger(log.Debug)
+
+	inm, err := NewInmem(nil, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := physical.NewRetryBackend(inm, physical.RetryConfig{MaxRetries: 2}, logger)
+	physical.ExerciseBackend(t, b)
+	physical.ExerciseBackend_ListPrefix(t, b)
+
+	inmTxn, err := NewTransactionalInmem(nil, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txn := physical.NewTransactionalRetryBackend(inmTxn, physical.RetryConfig{MaxRetries: 2}, logger)
+	physical.ExerciseTransactionalBackend(t, txn)
+}
+
+func TestRetryBackend_Retries(t *testing.T) {
+	logger := logging.NewVaultLogger(log.Debug)
+	conf := physical.RetryConfig{
+		MaxRetries: 3,
+		Backoff:    time.Millisecond,
+	}
+
+	// Transient errors are retried until the call succeeds
+	flaky := newFlakyBackend(t, 2, errors.New("read tcp: connection reset by peer"))
+	b := physical.NewRetryBackend(flaky, conf, logger)
+	if _, err := b.Get(context.Background(), "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if flaky.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", flaky.calls)
+	}
+
+	// Retries stop after MaxRetries
+	flaky = newFlakyBackend(t, 10, errors.New("Throttling: Rate exceeded"))
+	b = physical.NewRetryBackend(flaky, conf, logger)
+	if _, err := b.Get(context.Background(), "foo"); err == nil {
+		t.Fatal("expected error")
+	}
+	if flaky.calls != 4 {
+		t.Fatalf("expected 4 calls, got %d", flaky.calls)
+	}
+
+	// Other errors are returned immediately
+	flaky = newFlakyBackend(t, 10, errors.New("permission denied"))
+	b = physical.NewRetryBackend(flaky, conf, logger)
+	if _, err := b.Get(context.Background(), "foo"); err == nil {
+		t.Fatal("expected error")
+	}
+	if flaky.calls != 1 {
+		t.Fatalf("expected 1 call, got %d", flaky.calls)
+	}
+}
+
+func TestRetryBackend_CircuitBreaker(t *testing.T) {
+	logger := logging.NewVaultLogger(log.Debug)
+	conf := physical.RetryConfig{
+		CircuitBreakerThreshold:    2,
+		CircuitBreakerResetTimeout: 50 * time.Millisecond,
+	}
+
+	flaky := newFlakyBackend(t, 2, context.DeadlineExceeded)
+	b := physical.NewRetryBackend(flaky, conf, logger)
+
+	for i := 0; i < 2; i++ {
+		if _, err := b.Get(context.Background(), "foo"); err != context.DeadlineExceeded {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+	}
+
+	// The breaker is now open and the backend is not called
+	if _, err := b.Get(context.Background(), "foo"); err != physical.ErrCircuitOpen {
+		t.Fatalf("expected open circuit, got %v", err)
+	}
+	if flaky.calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", flaky.calls)
+	}
+
+	// Once the reset timeout passes, calls go through again
+	time.Sleep(100 * time.Millisecond)
+	if _, err := b.Get(context.Background(), "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Get(context.Background(), "foo"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryBackend_Timeout(t *testing.T) {
+	logger := logging.NewVaultLogger(log.Debug)
+
+	inm, err := NewInmem(nil, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocking := &blockingBackend{Backend: inm}
+	b := physical.NewRetryBackend(blocking, physical.RetryConfig{
+		MaxRetries: 1,
+		Backoff:    time.Millisecond,
+		Timeout:    10 * time.Millisecond,
+	}, logger)
+
+	if _, err := b.Get(context.Background(), "foo"); err != context.DeadlineExceeded {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if blocking.calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", blocking.calls)
+	}
+}
+
+// blockingBackend blocks Get until the context is done
+type blockingBackend struct {
+	physical.Backend
+	calls int32
+}
+
+func (b *blockingBackend) Get(ctx context.Context, key string) (*physical.Entry, error) {
+	atomic.AddInt32(&b.calls, 1)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
@@ -32,6 +32,7 @@ type TransactionalLatencyInjector struct {
 // Verify LatencyInjector satisfies the correct interfaces
 var _ Backend = (*LatencyInjector)(nil)
 var _ Transactional = (*TransactionalLatencyInjector)(nil)
+var _ Paginated = (*LatencyInjector)(nil)
 
 // NewLatencyInjector returns a wrapped physical backend to simulate latency
 func NewLatencyInjector(b Backend, latency time.Duration, jitter int, logger log.Logger) *LatencyInjector {
@@ -98,6 +99,12 @@ func (l *LatencyInjector) List(ctx context.Context, prefix string) ([]string, er
 	return l.backend.List(ctx, prefix)
 }
 
+// ListPage is a latent list page request
+func (l *LatencyInjector) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	l.addLatency()
+	return ListPage(ctx, l.backend, prefix, after, limit)
+}
+
 // Transaction is a latent transaction request
 func (l *TransactionalLatencyInjector) Transaction(ctx context.Context, txns []*TxnEntry) error {
 	l.addLatency()
@@ -0,0 +1,105 @@
+package physical
+
+import (
+	"context"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+)
+
+// MetricsBackend wraps a physical backend and emits latency and error
+// metrics for every storage operation, labeled by backend type
+type MetricsBackend struct {
+	backend Backend
+	labels  []metrics.Label
+}
+
+// TransactionalMetricsBackend is the transactional version of the metrics
+// backend
+type TransactionalMetricsBackend struct {
+	*MetricsBackend
+	Transactional
+}
+
+// Verify MetricsBackend satisfies the correct interfaces
+var _ Backend = (*MetricsBackend)(nil)
+var _ Transactional = (*TransactionalMetricsBackend)(nil)
+var _ Paginated = (*MetricsBackend)(nil)
+
+// NewMetricsBackend returns a wrapped physical backend that emits per
+// operation metrics tagged with the given backend type
+func NewMetricsBackend(b Backend, backendType string) *MetricsBackend {
+	return &MetricsBackend{
+		backend: b,
+		labels:  []metrics.Label{{Name: "backend", Value: backendType}},
+	}
+}
+
+// NewTransactionalMetricsBackend creates a new transactional MetricsBackend
+func NewTransactionalMetricsBackend(b Backend, backendType string) *TransactionalMetricsBackend {
+	return &TransactionalMetricsBackend{
+		MetricsBackend: NewMetricsBackend(b, backendType),
+		Transactional:  b.(Transactional),
+	}
+}
+
+func (m *MetricsBackend) measure(op string, now time.Time, err error) {
+	metrics.MeasureSinceWithLabels([]string{"storage", op}, now, m.labels)
+	if err != nil {
+		metrics.IncrCounterWithLabels([]string{"storage", op, "error"}, 1, m.labels)
+	}
+}
+
+// Put is used to insert or update an entry
+func (m *MetricsBackend) Put(ctx context.Context, entry *Entry) (err error) {
+	defer func(now time.Time) {
+		m.measure("put", now, err)
+	}(time.Now())
+
+	return m.backend.Put(ctx, entry)
+}
+
+// Get is used to fetch an entry
+func (m *MetricsBackend) Get(ctx context.Context, key string) (entry *Entry, err error) {
+	defer func(now time.Time) {
+		m.measure("get", now, err)
+	}(time.Now())
+
+	return m.backend.Get(ctx, key)
+}
+
+// Delete is used to permanently delete an entry
+func (m *MetricsBackend) Delete(ctx context.Context, key string) (err error) {
+	defer func(now time.Time) {
+		m.measure("delete", now, err)
+	}(time.Now())
+
+	return m.backend.Delete(ctx, key)
+}
+
+// List is used to list all the keys under a given prefix
+func (m *MetricsBackend) List(ctx context.Context, prefix string) (keys []string, err error) {
+	defer func(now time.Time) {
+		m.measure("list", now, err)
+	}(time.Now())
+
+	return m.backend.List(ctx, prefix)
+}
+
+// ListPage is used to list a page of keys under a given prefix
+func (m *MetricsBackend) ListPage(ctx context.Context, prefix string, after string, limit int) (keys []string, err error) {
+	defer func(now time.Time) {
+		m.measure("list", now, err)
+	}(time.Now())
+
+	return ListPage(ctx, m.backend, prefix, after, limit)
+}
+
+// Transaction is used to run multiple entries via a transaction
+func (m *TransactionalMetricsBackend) Transaction(ctx context.Context, txns []*TxnEntry) (err error) {
+	defer func(now time.Time) {
+		m.measure("transaction", now, err)
+	}(time.Now())
+
+	return m.Transactional.Transaction(ctx, txns)
+}
@@ -0,0 +1,57 @@
+package physical
+
+import (
+	"context"
+	"sort"
+)
+
+// Paginated is an optional interface for backends that can list the keys
+// under a prefix a page at a time, without materializing every key.
+type Paginated interface {
+	// ListPage lists the keys under the given prefix, up to the next prefix,
+	// that sort after the given key. The after key is relative to the
+	// prefix, as the keys returned by List are. At most limit keys are
+	// returned in lexicographic order; a limit of zero or less returns all
+	// remaining keys.
+	ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error)
+}
+
+// PaginatedBackend is a Backend that also supports paginated listing.
+type PaginatedBackend interface {
+	Backend
+	Paginated
+}
+
+// ListPage lists a page of the keys under the given prefix. The backend's
+// own pagination is used if it supports it, otherwise the full list is
+// paginated.
+func ListPage(ctx context.Context, b Backend, prefix string, after string, limit int) ([]string, error) {
+	if p, ok := b.(Paginated); ok {
+		return p.ListPage(ctx, prefix, after, limit)
+	}
+
+	keys, err := b.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return PaginateKeys(keys, after, limit), nil
+}
+
+// PaginateKeys sorts the given keys and returns those that sort after the
+// given key, up to limit keys. A limit of zero or less returns all of them.
+func PaginateKeys(keys []string, after string, limit int) []string {
+	sort.Strings(keys)
+
+	if after != "" {
+		idx := sort.SearchStrings(keys, after)
+		if idx < len(keys) && keys[idx] == after {
+			idx++
+		}
+		keys = keys[idx:]
+	}
+
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
@@ -66,6 +66,7 @@ type HABackend interface {
 type ToggleablePurgemonster interface {
 	Purge(ctx context.Context)
 	SetEnabled(bool)
+	Enabled() bool
 }
 
 // RedirectDetect is an optional interface that an HABackend
@@ -12,6 +12,7 @@ type PhysicalAccess struct {
 }
 
 var _ Backend = (*PhysicalAccess)(nil)
+var _ Paginated = (*PhysicalAccess)(nil)
 
 func NewPhysicalAccess(physical Backend) *PhysicalAccess {
 	return &PhysicalAccess{physical: physical}
@@ -33,6 +34,10 @@ func (p *PhysicalAccess) List(ctx context.Context, prefix string) ([]string, err
 	return p.physical.List(ctx, prefix)
 }
 
+func (p *PhysicalAccess) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	return ListPage(ctx, p.physical, prefix, after, limit)
+}
+
 func (p *PhysicalAccess) Purge(ctx context.Context) {
 	if purgeable, ok := p.physical.(ToggleablePurgemonster); ok {
 		purgeable.Purge(ctx)
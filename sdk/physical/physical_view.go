@@ -18,6 +18,7 @@ type View struct {
 
 // Verify View satisfies the correct interfaces
 var _ Backend = (*View)(nil)
+var _ Paginated = (*View)(nil)
 
 // NewView takes an underlying physical backend and returns
 // a view of it that can only operate with the given prefix.
@@ -36,6 +37,14 @@ func (v *View) List(ctx context.Context, prefix string) ([]string, error) {
 	return v.backend.List(ctx, v.expandKey(prefix))
 }
 
+// ListPage lists a page of the contents of the prefixed view
+func (v *View) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	if err := v.sanityCheck(prefix); err != nil {
+		return nil, err
+	}
+	return ListPage(ctx, v.backend, v.expandKey(prefix), after, limit)
+}
+
 // Get the key of the prefixed view
 func (v *View) Get(ctx context.Context, key string) (*Entry, error) {
 	if err := v.sanityCheck(key); err != nil {
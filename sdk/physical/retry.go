@@ -0,0 +1,307 @@
+package physical
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultRetryBackoff is the initial delay between retries if none is
+	// specified
+	DefaultRetryBackoff = 100 * time.Millisecond
+
+	// DefaultMaxRetryBackoff caps the exponential backoff between retries if
+	// no maximum is specified
+	DefaultMaxRetryBackoff = 5 * time.Second
+
+	// DefaultCircuitBreakerResetTimeout is how long the circuit breaker stays
+	// open if no reset timeout is specified
+	DefaultCircuitBreakerResetTimeout = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without calling the underlying backend while the
+// circuit breaker is open
+var ErrCircuitOpen = errors.New("storage circuit breaker is open after repeated failures")
+
+// RetryConfig configures a RetryBackend. A zero value for MaxRetries disables
+// retries, a zero Timeout disables the per-call timeout, and a zero
+// CircuitBreakerThreshold disables the circuit breaker.
+type RetryConfig struct {
+	// MaxRetries is the number of times a call failing with a transient
+	// error is retried
+	MaxRetries int
+
+	// Backoff is the delay before the first retry; it doubles on each
+	// subsequent retry up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Timeout bounds each individual call to the underlying backend. It is
+	// applied through the context, so it only takes effect for backends that
+	// honor context cancelation.
+	Timeout time.Duration
+
+	// CircuitBreakerThreshold is the number of consecutive failed calls,
+	// after retries, that open the circuit breaker. While open, calls fail
+	// with ErrCircuitOpen until CircuitBreakerResetTimeout has passed.
+	CircuitBreakerThreshold    int
+	CircuitBreakerResetTimeout time.Duration
+}
+
+// RetryBackend is used to retry transient failures of underlying physical
+// requests with backoff, bound each request with a timeout, and stop calling
+// a failing backend for a while to avoid retry storms
+type RetryBackend struct {
+	backend Backend
+	logger  log.Logger
+	config  RetryConfig
+
+	l         sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// TransactionalRetryBackend is the transactional version of the retry
+// backend
+type TransactionalRetryBackend struct {
+	*RetryBackend
+	Transactional
+}
+
+// Verify RetryBackend satisfies the correct interfaces
+var _ Backend = (*RetryBackend)(nil)
+var _ Transactional = (*TransactionalRetryBackend)(nil)
+var _ Paginated = (*RetryBackend)(nil)
+
+// NewRetryBackend returns a wrapped physical backend that retries transient
+// errors according to the given configuration
+func NewRetryBackend(b Backend, conf RetryConfig, logger log.Logger) *RetryBackend {
+	if conf.MaxRetries < 0 {
+		conf.MaxRetries = 0
+	}
+	if conf.Backoff <= 0 {
+		conf.Backoff = DefaultRetryBackoff
+	}
+	if conf.MaxBackoff <= 0 {
+		conf.MaxBackoff = DefaultMaxRetryBackoff
+	}
+	if conf.MaxBackoff < conf.Backoff {
+		conf.MaxBackoff = conf.Backoff
+	}
+	if conf.CircuitBreakerResetTimeout <= 0 {
+		conf.CircuitBreakerResetTimeout = DefaultCircuitBreakerResetTimeout
+	}
+
+	return &RetryBackend{
+		backend: b,
+		logger:  logger,
+		config:  conf,
+	}
+}
+
+// NewTransactionalRetryBackend creates a new transactional RetryBackend
+func NewTransactionalRetryBackend(b Backend, conf RetryConfig, logger log.Logger) *TransactionalRetryBackend {
+	return &TransactionalRetryBackend{
+		RetryBackend:  NewRetryBackend(b, conf, logger),
+		Transactional: b.(Transactional),
+	}
+}
+
+// do runs f, retrying transient errors with exponential backoff, and records
+// the outcome with the circuit breaker
+func (r *RetryBackend) do(ctx context.Context, op string, f func(context.Context) error) error {
+	if !r.allow() {
+		return ErrCircuitOpen
+	}
+
+	backoff := r.config.Backoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = r.call(ctx, f)
+		if err == nil || attempt >= r.config.MaxRetries || ctx.Err() != nil || !IsTransientError(err) {
+			break
+		}
+
+		r.logger.Debug("retrying storage operation after transient error", "operation", op, "attempt", attempt+1, "backoff", backoff, "error", err)
+
+		select {
+		case <-ctx.Done():
+			r.record(err)
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > r.config.MaxBackoff {
+			backoff = r.config.MaxBackoff
+		}
+	}
+
+	r.record(err)
+	return err
+}
+
+// call runs a single attempt, bounded by the configured timeout
+func (r *RetryBackend) call(ctx context.Context, f func(context.Context) error) error {
+	if r.config.Timeout <= 0 {
+		return f(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+	return f(ctx)
+}
+
+// allow reports whether a call may proceed, that is whether the circuit
+// breaker is closed or its reset timeout has passed
+func (r *RetryBackend) allow() bool {
+	if r.config.CircuitBreakerThreshold <= 0 {
+		return true
+	}
+
+	r.l.Lock()
+	defer r.l.Unlock()
+	return r.failures < r.config.CircuitBreakerThreshold || !time.Now().Before(r.openUntil)
+}
+
+// record updates the circuit breaker with the outcome of a call. Only
+// transient errors count as failures; other errors, such as a key failing
+// validation, show that the backend is responding. Calls canceled by the
+// caller say nothing either way.
+func (r *RetryBackend) record(err error) {
+	if r.config.CircuitBreakerThreshold <= 0 {
+		return
+	}
+
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	if err == context.Canceled {
+		return
+	}
+	if err == nil || !IsTransientError(err) {
+		r.failures = 0
+		return
+	}
+
+	r.failures++
+	if r.failures >= r.config.CircuitBreakerThreshold {
+		if r.failures == r.config.CircuitBreakerThreshold {
+			r.logger.Warn("opening storage circuit breaker after repeated failures", "failures", r.failures, "reset_timeout", r.config.CircuitBreakerResetTimeout)
+		}
+		r.openUntil = time.Now().Add(r.config.CircuitBreakerResetTimeout)
+	}
+}
+
+// Put is used to insert or update an entry
+func (r *RetryBackend) Put(ctx context.Context, entry *Entry) error {
+	return r.do(ctx, "put", func(ctx context.Context) error {
+		return r.backend.Put(ctx, entry)
+	})
+}
+
+// Get is used to fetch an entry
+func (r *RetryBackend) Get(ctx context.Context, key string) (*Entry, error) {
+	var entry *Entry
+	err := r.do(ctx, "get", func(ctx context.Context) error {
+		var err error
+		entry, err = r.backend.Get(ctx, key)
+		return err
+	})
+	return entry, err
+}
+
+// Delete is used to permanently delete an entry
+func (r *RetryBackend) Delete(ctx context.Context, key string) error {
+	return r.do(ctx, "delete", func(ctx context.Context) error {
+		return r.backend.Delete(ctx, key)
+	})
+}
+
+// List is used to list all the keys under a given prefix
+func (r *RetryBackend) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := r.do(ctx, "list", func(ctx context.Context) error {
+		var err error
+		keys, err = r.backend.List(ctx, prefix)
+		return err
+	})
+	return keys, err
+}
+
+// ListPage is used to list a page of keys under a given prefix
+func (r *RetryBackend) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	var keys []string
+	err := r.do(ctx, "list", func(ctx context.Context) error {
+		var err error
+		keys, err = ListPage(ctx, r.backend, prefix, after, limit)
+		return err
+	})
+	return keys, err
+}
+
+// Transaction is used to run multiple entries via a transaction
+func (r *TransactionalRetryBackend) Transaction(ctx context.Context, txns []*TxnEntry) error {
+	return r.do(ctx, "transaction", func(ctx context.Context) error {
+		return r.Transactional.Transaction(ctx, txns)
+	})
+}
+
+// transientErrorStrings are matched, case insensitively, against error
+// messages from backends whose client libraries do not return typed errors
+// for throttling and dropped connections
+var transientErrorStrings = []string{
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"throttl",
+	"rate exceeded",
+	"too many requests",
+	"service unavailable",
+}
+
+// IsTransientError reports whether err is likely to succeed if retried, such
+// as a timeout, a dropped connection or a throttling response
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch err {
+	case context.DeadlineExceeded, io.EOF, io.ErrUnexpectedEOF:
+		return true
+	case context.Canceled, ErrCircuitOpen:
+		return false
+	}
+
+	if netErr, ok := err.(net.Error); ok && (netErr.Timeout() || netErr.Temporary()) {
+		return true
+	}
+	if opErr, ok := err.(*net.OpError); ok {
+		return IsTransientError(opErr.Err)
+	}
+	if sysErr, ok := err.(*os.SyscallError); ok {
+		return IsTransientError(sysErr.Err)
+	}
+	switch err {
+	case syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.EPIPE:
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, s := range transientErrorStrings {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -59,6 +59,22 @@ func (b *backendGRPCPluginServer) Setup(ctx context.Context, args *pb.SetupArgs)
 	return &pb.SetupReply{}, nil
 }
 
+func (b *backendGRPCPluginServer) Initialize(ctx context.Context, _ *pb.InitializeArgs) (*pb.InitializeReply, error) {
+	if pluginutil.InMetadataMode() {
+		return &pb.InitializeReply{}, ErrServerInMetadataMode
+	}
+
+	req := &logical.InitializationRequest{
+		Storage: newGRPCStorageClient(b.brokeredClient),
+	}
+
+	respErr := b.backend.Initialize(ctx, req)
+
+	return &pb.InitializeReply{
+		Err: pb.ErrToProtoErr(respErr),
+	}, nil
+}
+
 func (b *backendGRPCPluginServer) HandleRequest(ctx context.Context, args *pb.HandleRequestArgs) (*pb.HandleRequestReply, error) {
 	if pluginutil.InMetadataMode() {
 		return &pb.HandleRequestReply{}, ErrServerInMetadataMode
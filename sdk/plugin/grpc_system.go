@@ -16,6 +16,8 @@ import (
 	"google.golang.org/grpc"
 )
 
+var errPasswordPoliciesUnsupported = errors.New("password policies are not supported by the system view")
+
 func newGRPCSystemView(conn *grpc.ClientConn) *gRPCSystemViewClient {
 	return &gRPCSystemViewClient{
 		client: pb.NewSystemViewClient(conn),
@@ -113,6 +115,35 @@ func (s *gRPCSystemViewClient) LookupPlugin(_ context.Context, _ string, _ const
 	return nil, fmt.Errorf("cannot call LookupPlugin from a plugin backend")
 }
 
+func (s *gRPCSystemViewClient) GeneratePasswordFromPolicy(ctx context.Context, policyName string) (string, error) {
+	reply, err := s.client.GeneratePasswordFromPolicy(ctx, &pb.GeneratePasswordFromPolicyArgs{
+		PolicyName: policyName,
+	})
+	if err != nil {
+		return "", err
+	}
+	if reply.Err != "" {
+		return "", errors.New(reply.Err)
+	}
+
+	return reply.Password, nil
+}
+
+func (s *gRPCSystemViewClient) ValidatePasswordFromPolicy(ctx context.Context, policyName, password string) error {
+	reply, err := s.client.ValidatePasswordFromPolicy(ctx, &pb.ValidatePasswordFromPolicyArgs{
+		PolicyName: policyName,
+		Password:   password,
+	})
+	if err != nil {
+		return err
+	}
+	if reply.Err != "" {
+		return errors.New(reply.Err)
+	}
+
+	return nil
+}
+
 func (s *gRPCSystemViewClient) MlockEnabled() bool {
 	reply, err := s.client.MlockEnabled(context.Background(), &pb.Empty{})
 	if err != nil {
@@ -267,3 +298,37 @@ func (s *gRPCSystemViewServer) PluginEnv(ctx context.Context, _ *pb.Empty) (*pb.
 		PluginEnvironment: pluginEnv,
 	}, nil
 }
+
+func (s *gRPCSystemViewServer) GeneratePasswordFromPolicy(ctx context.Context, args *pb.GeneratePasswordFromPolicyArgs) (*pb.GeneratePasswordFromPolicyReply, error) {
+	policySys, ok := s.impl.(logical.PasswordPolicySystemView)
+	if !ok {
+		return &pb.GeneratePasswordFromPolicyReply{
+			Err: pb.ErrToString(errPasswordPoliciesUnsupported),
+		}, nil
+	}
+	password, err := policySys.GeneratePasswordFromPolicy(ctx, args.PolicyName)
+	if err != nil {
+		return &pb.GeneratePasswordFromPolicyReply{
+			Err: pb.ErrToString(err),
+		}, nil
+	}
+	return &pb.GeneratePasswordFromPolicyReply{
+		Password: password,
+	}, nil
+}
+
+func (s *gRPCSystemViewServer) ValidatePasswordFromPolicy(ctx context.Context, args *pb.ValidatePasswordFromPolicyArgs) (*pb.ValidatePasswordFromPolicyReply, error) {
+	policySys, ok := s.impl.(logical.PasswordPolicySystemView)
+	if !ok {
+		return &pb.ValidatePasswordFromPolicyReply{
+			Err: pb.ErrToString(errPasswordPoliciesUnsupported),
+		}, nil
+	}
+	err := policySys.ValidatePasswordFromPolicy(ctx, args.PolicyName, args.Password)
+	if err != nil {
+		return &pb.ValidatePasswordFromPolicyReply{
+			Err: pb.ErrToString(err),
+		}, nil
+	}
+	return &pb.ValidatePasswordFromPolicyReply{}, nil
+}
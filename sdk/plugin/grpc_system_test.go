@@ -17,6 +17,7 @@ import (
 
 func TestSystem_GRPC_GRPC_impl(t *testing.T) {
 	var _ logical.SystemView = new(gRPCSystemViewClient)
+	var _ logical.PasswordPolicySystemView = new(gRPCSystemViewClient)
 }
 
 func TestSystem_GRPC_defaultLeaseTTL(t *testing.T) {
@@ -230,3 +231,41 @@ func TestSystem_GRPC_pluginEnv(t *testing.T) {
 		t.Fatalf("expected: %v, got: %v", expected, actual)
 	}
 }
+
+func TestSystem_GRPC_passwordPolicy(t *testing.T) {
+	sys := logical.TestSystemView()
+	sys.PasswordPolicies = map[string]string{
+		"digits": `
+length = 8
+rule "charset" {
+  charset = "0123456789"
+}`,
+	}
+	client, _ := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		pb.RegisterSystemViewServer(s, &gRPCSystemViewServer{
+			impl: sys,
+		})
+	})
+	defer client.Close()
+
+	testSystemView := newGRPCSystemView(client)
+
+	password, err := testSystemView.GeneratePasswordFromPolicy(context.Background(), "digits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sys.ValidatePasswordFromPolicy(context.Background(), "digits", password); err != nil {
+		t.Fatalf("generated password %q does not match the policy: %v", password, err)
+	}
+
+	if err := testSystemView.ValidatePasswordFromPolicy(context.Background(), "digits", password); err != nil {
+		t.Fatal(err)
+	}
+	if err := testSystemView.ValidatePasswordFromPolicy(context.Background(), "digits", "abcdefgh"); err == nil {
+		t.Fatal("expected error validating a password that does not match the policy")
+	}
+
+	if _, err := testSystemView.GeneratePasswordFromPolicy(context.Background(), "missing"); err == nil {
+		t.Fatal("expected error generating from a missing policy")
+	}
+}
@@ -1680,6 +1680,78 @@ func (m *InvalidateKeyArgs) GetKey() string {
 	return ""
 }
 
+// InitializeArgs is the args for Initialize method.
+type InitializeArgs struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InitializeArgs) Reset()         { *m = InitializeArgs{} }
+func (m *InitializeArgs) String() string { return proto.CompactTextString(m) }
+func (*InitializeArgs) ProtoMessage()    {}
+func (*InitializeArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4dbf1dfe0c11846b, []int{21}
+}
+
+func (m *InitializeArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InitializeArgs.Unmarshal(m, b)
+}
+func (m *InitializeArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InitializeArgs.Marshal(b, m, deterministic)
+}
+func (m *InitializeArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InitializeArgs.Merge(m, src)
+}
+func (m *InitializeArgs) XXX_Size() int {
+	return xxx_messageInfo_InitializeArgs.Size(m)
+}
+func (m *InitializeArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_InitializeArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InitializeArgs proto.InternalMessageInfo
+
+// InitializeReply is the reply for Initialize method.
+type InitializeReply struct {
+	Err                  *ProtoError `sentinel:"" protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *InitializeReply) Reset()         { *m = InitializeReply{} }
+func (m *InitializeReply) String() string { return proto.CompactTextString(m) }
+func (*InitializeReply) ProtoMessage()    {}
+func (*InitializeReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4dbf1dfe0c11846b, []int{22}
+}
+
+func (m *InitializeReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InitializeReply.Unmarshal(m, b)
+}
+func (m *InitializeReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InitializeReply.Marshal(b, m, deterministic)
+}
+func (m *InitializeReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InitializeReply.Merge(m, src)
+}
+func (m *InitializeReply) XXX_Size() int {
+	return xxx_messageInfo_InitializeReply.Size(m)
+}
+func (m *InitializeReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_InitializeReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InitializeReply proto.InternalMessageInfo
+
+func (m *InitializeReply) GetErr() *ProtoError {
+	if m != nil {
+		return m.Err
+	}
+	return nil
+}
+
 type StorageEntry struct {
 	Key                  string   `sentinel:"" protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value                []byte   `sentinel:"" protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -1693,7 +1765,7 @@ func (m *StorageEntry) Reset()         { *m = StorageEntry{} }
 func (m *StorageEntry) String() string { return proto.CompactTextString(m) }
 func (*StorageEntry) ProtoMessage()    {}
 func (*StorageEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{21}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{23}
 }
 
 func (m *StorageEntry) XXX_Unmarshal(b []byte) error {
@@ -1746,7 +1818,7 @@ func (m *StorageListArgs) Reset()         { *m = StorageListArgs{} }
 func (m *StorageListArgs) String() string { return proto.CompactTextString(m) }
 func (*StorageListArgs) ProtoMessage()    {}
 func (*StorageListArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{22}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{24}
 }
 
 func (m *StorageListArgs) XXX_Unmarshal(b []byte) error {
@@ -1786,7 +1858,7 @@ func (m *StorageListReply) Reset()         { *m = StorageListReply{} }
 func (m *StorageListReply) String() string { return proto.CompactTextString(m) }
 func (*StorageListReply) ProtoMessage()    {}
 func (*StorageListReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{23}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{25}
 }
 
 func (m *StorageListReply) XXX_Unmarshal(b []byte) error {
@@ -1832,7 +1904,7 @@ func (m *StorageGetArgs) Reset()         { *m = StorageGetArgs{} }
 func (m *StorageGetArgs) String() string { return proto.CompactTextString(m) }
 func (*StorageGetArgs) ProtoMessage()    {}
 func (*StorageGetArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{24}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{26}
 }
 
 func (m *StorageGetArgs) XXX_Unmarshal(b []byte) error {
@@ -1872,7 +1944,7 @@ func (m *StorageGetReply) Reset()         { *m = StorageGetReply{} }
 func (m *StorageGetReply) String() string { return proto.CompactTextString(m) }
 func (*StorageGetReply) ProtoMessage()    {}
 func (*StorageGetReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{25}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{27}
 }
 
 func (m *StorageGetReply) XXX_Unmarshal(b []byte) error {
@@ -1918,7 +1990,7 @@ func (m *StoragePutArgs) Reset()         { *m = StoragePutArgs{} }
 func (m *StoragePutArgs) String() string { return proto.CompactTextString(m) }
 func (*StoragePutArgs) ProtoMessage()    {}
 func (*StoragePutArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{26}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{28}
 }
 
 func (m *StoragePutArgs) XXX_Unmarshal(b []byte) error {
@@ -1957,7 +2029,7 @@ func (m *StoragePutReply) Reset()         { *m = StoragePutReply{} }
 func (m *StoragePutReply) String() string { return proto.CompactTextString(m) }
 func (*StoragePutReply) ProtoMessage()    {}
 func (*StoragePutReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{27}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{29}
 }
 
 func (m *StoragePutReply) XXX_Unmarshal(b []byte) error {
@@ -1996,7 +2068,7 @@ func (m *StorageDeleteArgs) Reset()         { *m = StorageDeleteArgs{} }
 func (m *StorageDeleteArgs) String() string { return proto.CompactTextString(m) }
 func (*StorageDeleteArgs) ProtoMessage()    {}
 func (*StorageDeleteArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{28}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{30}
 }
 
 func (m *StorageDeleteArgs) XXX_Unmarshal(b []byte) error {
@@ -2035,7 +2107,7 @@ func (m *StorageDeleteReply) Reset()         { *m = StorageDeleteReply{} }
 func (m *StorageDeleteReply) String() string { return proto.CompactTextString(m) }
 func (*StorageDeleteReply) ProtoMessage()    {}
 func (*StorageDeleteReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{29}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{31}
 }
 
 func (m *StorageDeleteReply) XXX_Unmarshal(b []byte) error {
@@ -2074,7 +2146,7 @@ func (m *TTLReply) Reset()         { *m = TTLReply{} }
 func (m *TTLReply) String() string { return proto.CompactTextString(m) }
 func (*TTLReply) ProtoMessage()    {}
 func (*TTLReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{30}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{32}
 }
 
 func (m *TTLReply) XXX_Unmarshal(b []byte) error {
@@ -2114,7 +2186,7 @@ func (m *SudoPrivilegeArgs) Reset()         { *m = SudoPrivilegeArgs{} }
 func (m *SudoPrivilegeArgs) String() string { return proto.CompactTextString(m) }
 func (*SudoPrivilegeArgs) ProtoMessage()    {}
 func (*SudoPrivilegeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{31}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{33}
 }
 
 func (m *SudoPrivilegeArgs) XXX_Unmarshal(b []byte) error {
@@ -2160,7 +2232,7 @@ func (m *SudoPrivilegeReply) Reset()         { *m = SudoPrivilegeReply{} }
 func (m *SudoPrivilegeReply) String() string { return proto.CompactTextString(m) }
 func (*SudoPrivilegeReply) ProtoMessage()    {}
 func (*SudoPrivilegeReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{32}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{34}
 }
 
 func (m *SudoPrivilegeReply) XXX_Unmarshal(b []byte) error {
@@ -2199,7 +2271,7 @@ func (m *TaintedReply) Reset()         { *m = TaintedReply{} }
 func (m *TaintedReply) String() string { return proto.CompactTextString(m) }
 func (*TaintedReply) ProtoMessage()    {}
 func (*TaintedReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{33}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{35}
 }
 
 func (m *TaintedReply) XXX_Unmarshal(b []byte) error {
@@ -2238,7 +2310,7 @@ func (m *CachingDisabledReply) Reset()         { *m = CachingDisabledReply{} }
 func (m *CachingDisabledReply) String() string { return proto.CompactTextString(m) }
 func (*CachingDisabledReply) ProtoMessage()    {}
 func (*CachingDisabledReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{34}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{36}
 }
 
 func (m *CachingDisabledReply) XXX_Unmarshal(b []byte) error {
@@ -2277,7 +2349,7 @@ func (m *ReplicationStateReply) Reset()         { *m = ReplicationStateReply{} }
 func (m *ReplicationStateReply) String() string { return proto.CompactTextString(m) }
 func (*ReplicationStateReply) ProtoMessage()    {}
 func (*ReplicationStateReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{35}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{37}
 }
 
 func (m *ReplicationStateReply) XXX_Unmarshal(b []byte) error {
@@ -2318,7 +2390,7 @@ func (m *ResponseWrapDataArgs) Reset()         { *m = ResponseWrapDataArgs{} }
 func (m *ResponseWrapDataArgs) String() string { return proto.CompactTextString(m) }
 func (*ResponseWrapDataArgs) ProtoMessage()    {}
 func (*ResponseWrapDataArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{36}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{38}
 }
 
 func (m *ResponseWrapDataArgs) XXX_Unmarshal(b []byte) error {
@@ -2372,7 +2444,7 @@ func (m *ResponseWrapDataReply) Reset()         { *m = ResponseWrapDataReply{} }
 func (m *ResponseWrapDataReply) String() string { return proto.CompactTextString(m) }
 func (*ResponseWrapDataReply) ProtoMessage()    {}
 func (*ResponseWrapDataReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{37}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{39}
 }
 
 func (m *ResponseWrapDataReply) XXX_Unmarshal(b []byte) error {
@@ -2418,7 +2490,7 @@ func (m *MlockEnabledReply) Reset()         { *m = MlockEnabledReply{} }
 func (m *MlockEnabledReply) String() string { return proto.CompactTextString(m) }
 func (*MlockEnabledReply) ProtoMessage()    {}
 func (*MlockEnabledReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{38}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{40}
 }
 
 func (m *MlockEnabledReply) XXX_Unmarshal(b []byte) error {
@@ -2457,7 +2529,7 @@ func (m *LocalMountReply) Reset()         { *m = LocalMountReply{} }
 func (m *LocalMountReply) String() string { return proto.CompactTextString(m) }
 func (*LocalMountReply) ProtoMessage()    {}
 func (*LocalMountReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{39}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{41}
 }
 
 func (m *LocalMountReply) XXX_Unmarshal(b []byte) error {
@@ -2496,7 +2568,7 @@ func (m *EntityInfoArgs) Reset()         { *m = EntityInfoArgs{} }
 func (m *EntityInfoArgs) String() string { return proto.CompactTextString(m) }
 func (*EntityInfoArgs) ProtoMessage()    {}
 func (*EntityInfoArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{40}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{42}
 }
 
 func (m *EntityInfoArgs) XXX_Unmarshal(b []byte) error {
@@ -2536,7 +2608,7 @@ func (m *EntityInfoReply) Reset()         { *m = EntityInfoReply{} }
 func (m *EntityInfoReply) String() string { return proto.CompactTextString(m) }
 func (*EntityInfoReply) ProtoMessage()    {}
 func (*EntityInfoReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{41}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{43}
 }
 
 func (m *EntityInfoReply) XXX_Unmarshal(b []byte) error {
@@ -2583,7 +2655,7 @@ func (m *PluginEnvReply) Reset()         { *m = PluginEnvReply{} }
 func (m *PluginEnvReply) String() string { return proto.CompactTextString(m) }
 func (*PluginEnvReply) ProtoMessage()    {}
 func (*PluginEnvReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{42}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{44}
 }
 
 func (m *PluginEnvReply) XXX_Unmarshal(b []byte) error {
@@ -2618,6 +2690,178 @@ func (m *PluginEnvReply) GetErr() string {
 	return ""
 }
 
+type GeneratePasswordFromPolicyArgs struct {
+	PolicyName           string   `sentinel:"" protobuf:"bytes,1,opt,name=policy_name,json=policyName,proto3" json:"policy_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GeneratePasswordFromPolicyArgs) Reset()         { *m = GeneratePasswordFromPolicyArgs{} }
+func (m *GeneratePasswordFromPolicyArgs) String() string { return proto.CompactTextString(m) }
+func (*GeneratePasswordFromPolicyArgs) ProtoMessage()    {}
+func (*GeneratePasswordFromPolicyArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4dbf1dfe0c11846b, []int{45}
+}
+
+func (m *GeneratePasswordFromPolicyArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeneratePasswordFromPolicyArgs.Unmarshal(m, b)
+}
+func (m *GeneratePasswordFromPolicyArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeneratePasswordFromPolicyArgs.Marshal(b, m, deterministic)
+}
+func (m *GeneratePasswordFromPolicyArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeneratePasswordFromPolicyArgs.Merge(m, src)
+}
+func (m *GeneratePasswordFromPolicyArgs) XXX_Size() int {
+	return xxx_messageInfo_GeneratePasswordFromPolicyArgs.Size(m)
+}
+func (m *GeneratePasswordFromPolicyArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeneratePasswordFromPolicyArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeneratePasswordFromPolicyArgs proto.InternalMessageInfo
+
+func (m *GeneratePasswordFromPolicyArgs) GetPolicyName() string {
+	if m != nil {
+		return m.PolicyName
+	}
+	return ""
+}
+
+type GeneratePasswordFromPolicyReply struct {
+	Password             string   `sentinel:"" protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	Err                  string   `sentinel:"" protobuf:"bytes,2,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GeneratePasswordFromPolicyReply) Reset()         { *m = GeneratePasswordFromPolicyReply{} }
+func (m *GeneratePasswordFromPolicyReply) String() string { return proto.CompactTextString(m) }
+func (*GeneratePasswordFromPolicyReply) ProtoMessage()    {}
+func (*GeneratePasswordFromPolicyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4dbf1dfe0c11846b, []int{46}
+}
+
+func (m *GeneratePasswordFromPolicyReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeneratePasswordFromPolicyReply.Unmarshal(m, b)
+}
+func (m *GeneratePasswordFromPolicyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeneratePasswordFromPolicyReply.Marshal(b, m, deterministic)
+}
+func (m *GeneratePasswordFromPolicyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeneratePasswordFromPolicyReply.Merge(m, src)
+}
+func (m *GeneratePasswordFromPolicyReply) XXX_Size() int {
+	return xxx_messageInfo_GeneratePasswordFromPolicyReply.Size(m)
+}
+func (m *GeneratePasswordFromPolicyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeneratePasswordFromPolicyReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeneratePasswordFromPolicyReply proto.InternalMessageInfo
+
+func (m *GeneratePasswordFromPolicyReply) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+func (m *GeneratePasswordFromPolicyReply) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type ValidatePasswordFromPolicyArgs struct {
+	PolicyName           string   `sentinel:"" protobuf:"bytes,1,opt,name=policy_name,json=policyName,proto3" json:"policy_name,omitempty"`
+	Password             string   `sentinel:"" protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatePasswordFromPolicyArgs) Reset()         { *m = ValidatePasswordFromPolicyArgs{} }
+func (m *ValidatePasswordFromPolicyArgs) String() string { return proto.CompactTextString(m) }
+func (*ValidatePasswordFromPolicyArgs) ProtoMessage()    {}
+func (*ValidatePasswordFromPolicyArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4dbf1dfe0c11846b, []int{47}
+}
+
+func (m *ValidatePasswordFromPolicyArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatePasswordFromPolicyArgs.Unmarshal(m, b)
+}
+func (m *ValidatePasswordFromPolicyArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatePasswordFromPolicyArgs.Marshal(b, m, deterministic)
+}
+func (m *ValidatePasswordFromPolicyArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatePasswordFromPolicyArgs.Merge(m, src)
+}
+func (m *ValidatePasswordFromPolicyArgs) XXX_Size() int {
+	return xxx_messageInfo_ValidatePasswordFromPolicyArgs.Size(m)
+}
+func (m *ValidatePasswordFromPolicyArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatePasswordFromPolicyArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatePasswordFromPolicyArgs proto.InternalMessageInfo
+
+func (m *ValidatePasswordFromPolicyArgs) GetPolicyName() string {
+	if m != nil {
+		return m.PolicyName
+	}
+	return ""
+}
+
+func (m *ValidatePasswordFromPolicyArgs) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type ValidatePasswordFromPolicyReply struct {
+	Err                  string   `sentinel:"" protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatePasswordFromPolicyReply) Reset()         { *m = ValidatePasswordFromPolicyReply{} }
+func (m *ValidatePasswordFromPolicyReply) String() string { return proto.CompactTextString(m) }
+func (*ValidatePasswordFromPolicyReply) ProtoMessage()    {}
+func (*ValidatePasswordFromPolicyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4dbf1dfe0c11846b, []int{48}
+}
+
+func (m *ValidatePasswordFromPolicyReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatePasswordFromPolicyReply.Unmarshal(m, b)
+}
+func (m *ValidatePasswordFromPolicyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatePasswordFromPolicyReply.Marshal(b, m, deterministic)
+}
+func (m *ValidatePasswordFromPolicyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatePasswordFromPolicyReply.Merge(m, src)
+}
+func (m *ValidatePasswordFromPolicyReply) XXX_Size() int {
+	return xxx_messageInfo_ValidatePasswordFromPolicyReply.Size(m)
+}
+func (m *ValidatePasswordFromPolicyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatePasswordFromPolicyReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatePasswordFromPolicyReply proto.InternalMessageInfo
+
+func (m *ValidatePasswordFromPolicyReply) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
 type Connection struct {
 	// RemoteAddr is the network address that sent the request.
 	RemoteAddr           string   `sentinel:"" protobuf:"bytes,1,opt,name=remote_addr,json=remoteAddr,proto3" json:"remote_addr,omitempty"`
@@ -2630,7 +2874,7 @@ func (m *Connection) Reset()         { *m = Connection{} }
 func (m *Connection) String() string { return proto.CompactTextString(m) }
 func (*Connection) ProtoMessage()    {}
 func (*Connection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dbf1dfe0c11846b, []int{43}
+	return fileDescriptor_4dbf1dfe0c11846b, []int{49}
 }
 
 func (m *Connection) XXX_Unmarshal(b []byte) error {
@@ -2685,6 +2929,8 @@ func init() {
 	proto.RegisterType((*SetupReply)(nil), "pb.SetupReply")
 	proto.RegisterType((*TypeReply)(nil), "pb.TypeReply")
 	proto.RegisterType((*InvalidateKeyArgs)(nil), "pb.InvalidateKeyArgs")
+	proto.RegisterType((*InitializeArgs)(nil), "pb.InitializeArgs")
+	proto.RegisterType((*InitializeReply)(nil), "pb.InitializeReply")
 	proto.RegisterType((*StorageEntry)(nil), "pb.StorageEntry")
 	proto.RegisterType((*StorageListArgs)(nil), "pb.StorageListArgs")
 	proto.RegisterType((*StorageListReply)(nil), "pb.StorageListReply")
@@ -2707,170 +2953,183 @@ func init() {
 	proto.RegisterType((*EntityInfoArgs)(nil), "pb.EntityInfoArgs")
 	proto.RegisterType((*EntityInfoReply)(nil), "pb.EntityInfoReply")
 	proto.RegisterType((*PluginEnvReply)(nil), "pb.PluginEnvReply")
+	proto.RegisterType((*GeneratePasswordFromPolicyArgs)(nil), "pb.GeneratePasswordFromPolicyArgs")
+	proto.RegisterType((*GeneratePasswordFromPolicyReply)(nil), "pb.GeneratePasswordFromPolicyReply")
+	proto.RegisterType((*ValidatePasswordFromPolicyArgs)(nil), "pb.ValidatePasswordFromPolicyArgs")
+	proto.RegisterType((*ValidatePasswordFromPolicyReply)(nil), "pb.ValidatePasswordFromPolicyReply")
 	proto.RegisterType((*Connection)(nil), "pb.Connection")
 }
 
 func init() { proto.RegisterFile("sdk/plugin/pb/backend.proto", fileDescriptor_4dbf1dfe0c11846b) }
 
 var fileDescriptor_4dbf1dfe0c11846b = []byte{
-	// 2499 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xdb, 0x72, 0x1b, 0xc7,
-	0xd1, 0x2e, 0x00, 0xc4, 0xa9, 0x71, 0x22, 0x46, 0xb4, 0xfe, 0x15, 0x24, 0xff, 0x82, 0xd7, 0x91,
-	0x0c, 0x2b, 0x36, 0x68, 0x51, 0x71, 0x2c, 0x27, 0x65, 0xa7, 0x68, 0x8a, 0x96, 0x19, 0x93, 0x36,
-	0x6b, 0x09, 0xc5, 0x39, 0x55, 0xc1, 0x83, 0xdd, 0x21, 0xb8, 0xc5, 0xc5, 0xee, 0x66, 0x76, 0x96,
-	0x22, 0xae, 0xf2, 0x16, 0x79, 0x8d, 0xdc, 0xa6, 0x72, 0x93, 0xbb, 0x94, 0x2b, 0xf7, 0x79, 0x8d,
-	0x3c, 0x43, 0x6a, 0x7a, 0x66, 0x4f, 0x00, 0x68, 0xc9, 0x55, 0xce, 0xdd, 0x4c, 0x77, 0xcf, 0xa9,
-	0xe7, 0xeb, 0xaf, 0x7b, 0x76, 0xe1, 0x6e, 0xe4, 0x5c, 0xee, 0x86, 0x5e, 0x3c, 0x77, 0xfd, 0xdd,
-	0x70, 0xb6, 0x3b, 0xa3, 0xf6, 0x25, 0xf3, 0x9d, 0x71, 0xc8, 0x03, 0x11, 0x90, 0x72, 0x38, 0x1b,
-	0xdc, 0x9f, 0x07, 0xc1, 0xdc, 0x63, 0xbb, 0x28, 0x99, 0xc5, 0xe7, 0xbb, 0xc2, 0x5d, 0xb0, 0x48,
-	0xd0, 0x45, 0xa8, 0x8c, 0x06, 0x03, 0x39, 0x83, 0x17, 0xcc, 0x5d, 0x9b, 0x7a, 0xbb, 0xae, 0xc3,
-	0x7c, 0xe1, 0x8a, 0xa5, 0xd6, 0x19, 0x79, 0x9d, 0x5a, 0x45, 0x69, 0xcc, 0x3a, 0x54, 0x0f, 0x17,
-	0xa1, 0x58, 0x9a, 0x43, 0xa8, 0x7d, 0xc1, 0xa8, 0xc3, 0x38, 0xb9, 0x0d, 0xb5, 0x0b, 0x6c, 0x19,
-	0xa5, 0x61, 0x65, 0xd4, 0xb4, 0x74, 0xcf, 0xfc, 0x03, 0xc0, 0xa9, 0x1c, 0x73, 0xc8, 0x79, 0xc0,
-	0xc9, 0x1d, 0x68, 0x30, 0xce, 0xa7, 0x62, 0x19, 0x32, 0xa3, 0x34, 0x2c, 0x8d, 0x3a, 0x56, 0x9d,
-	0x71, 0x3e, 0x59, 0x86, 0x8c, 0xfc, 0x1f, 0xc8, 0xe6, 0x74, 0x11, 0xcd, 0x8d, 0xf2, 0xb0, 0x24,
-	0x67, 0x60, 0x9c, 0x9f, 0x44, 0xf3, 0x64, 0x8c, 0x1d, 0x38, 0xcc, 0xa8, 0x0c, 0x4b, 0xa3, 0x0a,
-	0x8e, 0x39, 0x08, 0x1c, 0x66, 0xfe, 0xa5, 0x04, 0xd5, 0x53, 0x2a, 0x2e, 0x22, 0x42, 0x60, 0x8b,
-	0x07, 0x81, 0xd0, 0x8b, 0x63, 0x9b, 0x8c, 0xa0, 0x17, 0xfb, 0x34, 0x16, 0x17, 0xf2, 0x54, 0x36,
-	0x15, 0xcc, 0x31, 0xca, 0xa8, 0x5e, 0x15, 0x93, 0xb7, 0xa1, 0xe3, 0x05, 0x36, 0xf5, 0xa6, 0x91,
-	0x08, 0x38, 0x9d, 0xcb, 0x75, 0xa4, 0x5d, 0x1b, 0x85, 0x67, 0x4a, 0x46, 0x1e, 0x41, 0x3f, 0x62,
-	0xd4, 0x9b, 0xbe, 0xe4, 0x34, 0x4c, 0x0d, 0xb7, 0xd4, 0x84, 0x52, 0xf1, 0x0d, 0xa7, 0xa1, 0xb6,
-	0x35, 0xff, 0x51, 0x83, 0xba, 0xc5, 0xfe, 0x14, 0xb3, 0x48, 0x90, 0x2e, 0x94, 0x5d, 0x07, 0x4f,
-	0xdb, 0xb4, 0xca, 0xae, 0x43, 0xc6, 0x40, 0x2c, 0x16, 0x7a, 0x72, 0x69, 0x37, 0xf0, 0x0f, 0xbc,
-	0x38, 0x12, 0x8c, 0xeb, 0x33, 0x6f, 0xd0, 0x90, 0x7b, 0xd0, 0x0c, 0x42, 0xc6, 0x51, 0x86, 0x0e,
-	0x68, 0x5a, 0x99, 0x40, 0x1e, 0x3c, 0xa4, 0xe2, 0xc2, 0xd8, 0x42, 0x05, 0xb6, 0xa5, 0xcc, 0xa1,
-	0x82, 0x1a, 0x55, 0x25, 0x93, 0x6d, 0x62, 0x42, 0x2d, 0x62, 0x36, 0x67, 0xc2, 0xa8, 0x0d, 0x4b,
-	0xa3, 0xd6, 0x1e, 0x8c, 0xc3, 0xd9, 0xf8, 0x0c, 0x25, 0x96, 0xd6, 0x90, 0x7b, 0xb0, 0x25, 0xfd,
-	0x62, 0xd4, 0xd1, 0xa2, 0x21, 0x2d, 0xf6, 0x63, 0x71, 0x61, 0xa1, 0x94, 0xec, 0x41, 0x5d, 0xdd,
-	0x69, 0x64, 0x34, 0x86, 0x95, 0x51, 0x6b, 0xcf, 0x90, 0x06, 0xfa, 0x94, 0x63, 0x05, 0x83, 0xe8,
-	0xd0, 0x17, 0x7c, 0x69, 0x25, 0x86, 0xe4, 0x2d, 0x68, 0xdb, 0x9e, 0xcb, 0x7c, 0x31, 0x15, 0xc1,
-	0x25, 0xf3, 0x8d, 0x26, 0xee, 0xa8, 0xa5, 0x64, 0x13, 0x29, 0x22, 0x7b, 0xf0, 0x46, 0xde, 0x64,
-	0x4a, 0x6d, 0x9b, 0x45, 0x51, 0xc0, 0x0d, 0x40, 0xdb, 0x5b, 0x39, 0xdb, 0x7d, 0xad, 0x92, 0xd3,
-	0x3a, 0x6e, 0x14, 0x7a, 0x74, 0x39, 0xf5, 0xe9, 0x82, 0x19, 0x2d, 0x35, 0xad, 0x96, 0x7d, 0x45,
-	0x17, 0x8c, 0xdc, 0x87, 0xd6, 0x22, 0x88, 0x7d, 0x31, 0x0d, 0x03, 0xd7, 0x17, 0x46, 0x1b, 0x2d,
-	0x00, 0x45, 0xa7, 0x52, 0x42, 0xde, 0x04, 0xd5, 0x53, 0x60, 0xec, 0x28, 0xbf, 0xa2, 0x04, 0xe1,
-	0xf8, 0x00, 0xba, 0x4a, 0x9d, 0xee, 0xa7, 0x8b, 0x26, 0x1d, 0x94, 0xa6, 0x3b, 0xf9, 0x00, 0x9a,
-	0x88, 0x07, 0xd7, 0x3f, 0x0f, 0x8c, 0x1e, 0xfa, 0xed, 0x56, 0xce, 0x2d, 0x12, 0x13, 0x47, 0xfe,
-	0x79, 0x60, 0x35, 0x5e, 0xea, 0x16, 0xf9, 0x04, 0xee, 0x16, 0xce, 0xcb, 0xd9, 0x82, 0xba, 0xbe,
-	0xeb, 0xcf, 0xa7, 0x71, 0xc4, 0x22, 0x63, 0x1b, 0x11, 0x6e, 0xe4, 0x4e, 0x6d, 0x25, 0x06, 0x2f,
-	0x22, 0x16, 0x91, 0xbb, 0xd0, 0x54, 0x41, 0x3a, 0x75, 0x1d, 0xa3, 0x8f, 0x5b, 0x6a, 0x28, 0xc1,
-	0x91, 0x43, 0xde, 0x81, 0x5e, 0x18, 0x78, 0xae, 0xbd, 0x9c, 0x06, 0x57, 0x8c, 0x73, 0xd7, 0x61,
-	0x06, 0x19, 0x96, 0x46, 0x0d, 0xab, 0xab, 0xc4, 0x5f, 0x6b, 0xe9, 0xa6, 0xd0, 0xb8, 0x85, 0x86,
-	0x6b, 0xa1, 0x31, 0x06, 0xb0, 0x03, 0xdf, 0x67, 0x36, 0xc2, 0x6f, 0x07, 0x4f, 0xd8, 0x95, 0x27,
-	0x3c, 0x48, 0xa5, 0x56, 0xce, 0x62, 0xf0, 0x39, 0xb4, 0xf3, 0x50, 0x20, 0xdb, 0x50, 0xb9, 0x64,
-	0x4b, 0x0d, 0x7f, 0xd9, 0x24, 0x43, 0xa8, 0x5e, 0x51, 0x2f, 0x66, 0x08, 0x79, 0x0d, 0x44, 0x35,
-	0xc4, 0x52, 0x8a, 0x5f, 0x94, 0x9f, 0x96, 0xcc, 0xbf, 0x57, 0x61, 0x4b, 0x82, 0x8f, 0x7c, 0x08,
-	0x1d, 0x8f, 0xd1, 0x88, 0x4d, 0x83, 0x50, 0x2e, 0x10, 0xe1, 0x54, 0xad, 0xbd, 0x6d, 0x39, 0xec,
-	0x58, 0x2a, 0xbe, 0x56, 0x72, 0xab, 0xed, 0xe5, 0x7a, 0x32, 0xa4, 0x5d, 0x5f, 0x30, 0xee, 0x53,
-	0x6f, 0x8a, 0xc1, 0xa0, 0x02, 0xac, 0x9d, 0x08, 0x9f, 0xc9, 0xa0, 0x58, 0xc5, 0x51, 0x65, 0x1d,
-	0x47, 0x03, 0x68, 0xa0, 0xef, 0x5c, 0x16, 0xe9, 0x60, 0x4f, 0xfb, 0x64, 0x0f, 0x1a, 0x0b, 0x26,
-	0xa8, 0x8e, 0x35, 0x19, 0x12, 0xb7, 0x93, 0x98, 0x19, 0x9f, 0x68, 0x85, 0x0a, 0x88, 0xd4, 0x6e,
-	0x2d, 0x22, 0x6a, 0xeb, 0x11, 0x31, 0x80, 0x46, 0x0a, 0xba, 0xba, 0xba, 0xe1, 0xa4, 0x2f, 0x69,
-	0x36, 0x64, 0xdc, 0x0d, 0x1c, 0xa3, 0x81, 0x40, 0xd1, 0x3d, 0x49, 0x92, 0x7e, 0xbc, 0x50, 0x10,
-	0x6a, 0x2a, 0x92, 0xf4, 0xe3, 0xc5, 0x3a, 0x62, 0x60, 0x05, 0x31, 0x3f, 0x81, 0x2a, 0xf5, 0x5c,
-	0x1a, 0x61, 0x08, 0xc9, 0x9b, 0xd5, 0x7c, 0x3f, 0xde, 0x97, 0x52, 0x4b, 0x29, 0xc9, 0x13, 0xe8,
-	0xcc, 0x79, 0x10, 0x87, 0x53, 0xec, 0xb2, 0xc8, 0x68, 0xe3, 0x69, 0x57, 0xad, 0xdb, 0x68, 0xb4,
-	0xaf, 0x6c, 0x64, 0x04, 0xce, 0x82, 0xd8, 0x77, 0xa6, 0xb6, 0xeb, 0xf0, 0xc8, 0xe8, 0xa0, 0xf3,
-	0x00, 0x45, 0x07, 0x52, 0x22, 0x43, 0x4c, 0x85, 0x40, 0xea, 0xe0, 0x2e, 0xda, 0x74, 0x50, 0x7a,
-	0x9a, 0x78, 0xf9, 0xa7, 0xd0, 0x4f, 0x12, 0x53, 0x66, 0xd9, 0x43, 0xcb, 0xed, 0x44, 0x91, 0x1a,
-	0x8f, 0x60, 0x9b, 0x5d, 0x4b, 0x0a, 0x75, 0xc5, 0x74, 0x41, 0xaf, 0xa7, 0x42, 0x78, 0x3a, 0xa4,
-	0xba, 0x89, 0xfc, 0x84, 0x5e, 0x4f, 0x84, 0x27, 0xe3, 0x5f, 0xad, 0x8e, 0xf1, 0xdf, 0xc7, 0x64,
-	0xd4, 0x44, 0x89, 0x8c, 0xff, 0xc1, 0x2f, 0xa1, 0x53, 0xb8, 0xc2, 0x0d, 0x40, 0xde, 0xc9, 0x03,
-	0xb9, 0x99, 0x07, 0xef, 0xbf, 0xb6, 0x00, 0xf0, 0x2e, 0xd5, 0xd0, 0xd5, 0x0c, 0x90, 0xbf, 0xe0,
-	0xf2, 0x86, 0x0b, 0xa6, 0x9c, 0xf9, 0x42, 0x83, 0x51, 0xf7, 0xbe, 0x17, 0x87, 0x49, 0x0e, 0xa8,
-	0xe6, 0x72, 0xc0, 0x7b, 0xb0, 0x25, 0x31, 0x67, 0xd4, 0x32, 0xaa, 0xce, 0x76, 0x84, 0xe8, 0x54,
-	0xc8, 0x44, 0xab, 0xb5, 0x40, 0xa8, 0xaf, 0x07, 0x42, 0x1e, 0x61, 0x8d, 0x22, 0xc2, 0xde, 0x86,
-	0x8e, 0xcd, 0x19, 0xe6, 0xa3, 0xa9, 0x2c, 0x30, 0x34, 0x02, 0xdb, 0x89, 0x70, 0xe2, 0x2e, 0x98,
-	0xf4, 0x9f, 0xbc, 0x0c, 0x40, 0x95, 0x6c, 0x6e, 0xbc, 0xab, 0xd6, 0xc6, 0xbb, 0xc2, 0xec, 0xee,
-	0x31, 0xcd, 0xe2, 0xd8, 0xce, 0x45, 0x42, 0xa7, 0x10, 0x09, 0x05, 0xb8, 0x77, 0x57, 0xe0, 0xbe,
-	0x82, 0xc9, 0xde, 0x1a, 0x26, 0xdf, 0x82, 0xb6, 0x74, 0x40, 0x14, 0x52, 0x9b, 0xc9, 0x09, 0xb6,
-	0x95, 0x23, 0x52, 0xd9, 0x91, 0x83, 0x11, 0x1c, 0xcf, 0x66, 0xcb, 0x8b, 0xc0, 0x63, 0x19, 0x09,
-	0xb7, 0x52, 0xd9, 0x91, 0x23, 0xf7, 0x8b, 0xa8, 0x22, 0x88, 0x2a, 0x6c, 0x0f, 0x3e, 0x82, 0x66,
-	0xea, 0xf5, 0x1f, 0x04, 0xa6, 0xbf, 0x96, 0xa0, 0x9d, 0x27, 0x3a, 0x39, 0x78, 0x32, 0x39, 0xc6,
-	0xc1, 0x15, 0x4b, 0x36, 0x65, 0x89, 0xc0, 0x99, 0xcf, 0x5e, 0xd2, 0x99, 0xa7, 0x26, 0x68, 0x58,
-	0x99, 0x40, 0x6a, 0x5d, 0xdf, 0xe6, 0x6c, 0x91, 0xa0, 0xaa, 0x62, 0x65, 0x02, 0xf2, 0x31, 0x80,
-	0x1b, 0x45, 0x31, 0x53, 0x37, 0xb7, 0x85, 0x34, 0x30, 0x18, 0xab, 0xba, 0x71, 0x9c, 0xd4, 0x8d,
-	0xe3, 0x49, 0x52, 0x37, 0x5a, 0x4d, 0xb4, 0xc6, 0x2b, 0xbd, 0x0d, 0x35, 0x79, 0x41, 0x93, 0x63,
-	0x44, 0x5e, 0xc5, 0xd2, 0x3d, 0xf3, 0xcf, 0x50, 0x53, 0x95, 0xc5, 0xff, 0x94, 0xbc, 0xef, 0x40,
-	0x43, 0xcd, 0xed, 0x3a, 0x3a, 0x56, 0xea, 0xd8, 0x3f, 0x72, 0xcc, 0xef, 0xca, 0xd0, 0xb0, 0x58,
-	0x14, 0x06, 0x7e, 0xc4, 0x72, 0x95, 0x4f, 0xe9, 0x95, 0x95, 0x4f, 0x79, 0x63, 0xe5, 0x93, 0xd4,
-	0x53, 0x95, 0x5c, 0x3d, 0x35, 0x80, 0x06, 0x67, 0x8e, 0xcb, 0x99, 0x2d, 0x74, 0xed, 0x95, 0xf6,
-	0xa5, 0xee, 0x25, 0xe5, 0x32, 0x65, 0x47, 0x98, 0x17, 0x9a, 0x56, 0xda, 0x27, 0x8f, 0xf3, 0x05,
-	0x83, 0x2a, 0xc5, 0x76, 0x54, 0xc1, 0xa0, 0xb6, 0xbb, 0xa1, 0x62, 0x78, 0x92, 0x15, 0x5e, 0x75,
-	0x8c, 0xe6, 0x3b, 0xf9, 0x01, 0x9b, 0x2b, 0xaf, 0x1f, 0x2d, 0x0f, 0x7f, 0x57, 0x86, 0xed, 0xd5,
-	0xbd, 0x6d, 0x40, 0xe0, 0x0e, 0x54, 0x55, 0x3e, 0xd3, 0xf0, 0x15, 0x6b, 0x99, 0xac, 0xb2, 0x42,
-	0x74, 0xbf, 0x5a, 0x25, 0x8d, 0x57, 0x43, 0xaf, 0x48, 0x28, 0xef, 0xc2, 0xb6, 0x74, 0x51, 0xc8,
-	0x9c, 0xac, 0x46, 0x53, 0x0c, 0xd8, 0xd3, 0xf2, 0xb4, 0x4a, 0x7b, 0x04, 0xfd, 0xc4, 0x34, 0xe3,
-	0x86, 0x5a, 0xc1, 0xf6, 0x30, 0xa1, 0x88, 0xdb, 0x50, 0x3b, 0x0f, 0xf8, 0x82, 0x0a, 0x4d, 0x82,
-	0xba, 0x57, 0x20, 0x39, 0x64, 0xdb, 0x86, 0xc2, 0x64, 0x22, 0x94, 0xef, 0x10, 0x49, 0x3e, 0xe9,
-	0x1b, 0x01, 0x59, 0xb0, 0x61, 0x35, 0x92, 0xb7, 0x81, 0xf9, 0x5b, 0xe8, 0xad, 0x94, 0x85, 0x1b,
-	0x1c, 0x99, 0x2d, 0x5f, 0x2e, 0x2c, 0x5f, 0x98, 0xb9, 0xb2, 0x32, 0xf3, 0xef, 0xa0, 0xff, 0x05,
-	0xf5, 0x1d, 0x8f, 0xe9, 0xf9, 0xf7, 0xf9, 0x3c, 0x92, 0x09, 0x4e, 0xbf, 0x52, 0xa6, 0x3a, 0xfb,
-	0x74, 0xac, 0xa6, 0x96, 0x1c, 0x39, 0xe4, 0x01, 0xd4, 0xb9, 0xb2, 0xd6, 0x00, 0x68, 0xe5, 0xea,
-	0x56, 0x2b, 0xd1, 0x99, 0xdf, 0x02, 0x29, 0x4c, 0x2d, 0x1f, 0x28, 0x4b, 0x32, 0x92, 0xe8, 0x57,
-	0xa0, 0xd0, 0x51, 0xd5, 0xce, 0x63, 0xd2, 0x4a, 0xb5, 0x64, 0x08, 0x15, 0xc6, 0xb9, 0x5e, 0x02,
-	0x0b, 0xc7, 0xec, 0x39, 0x68, 0x49, 0x95, 0xf9, 0x33, 0xe8, 0x9f, 0x85, 0xcc, 0x76, 0xa9, 0x87,
-	0x4f, 0x39, 0xb5, 0xc0, 0x7d, 0xa8, 0x4a, 0x27, 0x27, 0x84, 0xd1, 0xc4, 0x81, 0xa8, 0x56, 0x72,
-	0xf3, 0x5b, 0x30, 0xd4, 0xbe, 0x0e, 0xaf, 0xdd, 0x48, 0x30, 0xdf, 0x66, 0x07, 0x17, 0xcc, 0xbe,
-	0xfc, 0x11, 0x4f, 0x7e, 0x05, 0x77, 0x36, 0xad, 0x90, 0xec, 0xaf, 0x65, 0xcb, 0xde, 0xf4, 0x5c,
-	0xe6, 0x0e, 0x5c, 0xa3, 0x61, 0x01, 0x8a, 0x3e, 0x97, 0x12, 0x79, 0x8f, 0x4c, 0x8e, 0x8b, 0x34,
-	0x1f, 0xeb, 0x5e, 0xe2, 0x8f, 0xca, 0xcd, 0xfe, 0xf8, 0x5b, 0x09, 0x9a, 0x67, 0x4c, 0xc4, 0x21,
-	0x9e, 0xe5, 0x2e, 0x34, 0x67, 0x3c, 0xb8, 0x64, 0x3c, 0x3b, 0x4a, 0x43, 0x09, 0x8e, 0x1c, 0xf2,
-	0x18, 0x6a, 0x07, 0x81, 0x7f, 0xee, 0xce, 0xf1, 0x61, 0xab, 0x89, 0x21, 0x1d, 0x3b, 0x56, 0x3a,
-	0x45, 0x0c, 0xda, 0x90, 0x0c, 0xa1, 0xa5, 0x3f, 0x13, 0xbc, 0x78, 0x71, 0xf4, 0x2c, 0xa9, 0x78,
-	0x73, 0xa2, 0xc1, 0xc7, 0xd0, 0xca, 0x0d, 0xfc, 0x41, 0xa9, 0xea, 0xff, 0x01, 0x70, 0x75, 0xe5,
-	0xa3, 0x6d, 0x75, 0x54, 0x3d, 0x52, 0x1e, 0xed, 0x3e, 0x34, 0x65, 0x71, 0xa5, 0xd4, 0x49, 0x92,
-	0x2c, 0x65, 0x49, 0xd2, 0x7c, 0x00, 0xfd, 0x23, 0xff, 0x8a, 0x7a, 0xae, 0x43, 0x05, 0xfb, 0x92,
-	0x2d, 0xd1, 0x05, 0x6b, 0x3b, 0x30, 0xcf, 0xa0, 0xad, 0x5f, 0xda, 0xaf, 0xb5, 0xc7, 0xb6, 0xde,
-	0xe3, 0xf7, 0x07, 0xd1, 0xbb, 0xd0, 0xd3, 0x93, 0x1e, 0xbb, 0x3a, 0x84, 0x64, 0x8d, 0xc1, 0xd9,
-	0xb9, 0x7b, 0xad, 0xa7, 0xd6, 0x3d, 0xf3, 0x29, 0x6c, 0xe7, 0x4c, 0xd3, 0xe3, 0x5c, 0xb2, 0x65,
-	0x94, 0x7c, 0x81, 0x90, 0xed, 0xc4, 0x03, 0xe5, 0xcc, 0x03, 0x26, 0x74, 0xf5, 0xc8, 0xe7, 0x4c,
-	0xdc, 0x70, 0xba, 0x2f, 0xd3, 0x8d, 0x3c, 0x67, 0x7a, 0xf2, 0x87, 0x50, 0x65, 0xf2, 0xa4, 0xf9,
-	0xfc, 0x99, 0xf7, 0x80, 0xa5, 0xd4, 0x1b, 0x16, 0x7c, 0x9a, 0x2e, 0x78, 0x1a, 0xab, 0x05, 0x5f,
-	0x73, 0x2e, 0xf3, 0xed, 0x74, 0x1b, 0xa7, 0xb1, 0xb8, 0xe9, 0x46, 0x1f, 0x40, 0x5f, 0x1b, 0x3d,
-	0x63, 0x1e, 0x13, 0xec, 0x86, 0x23, 0x3d, 0x04, 0x52, 0x30, 0xbb, 0x69, 0xba, 0x7b, 0xd0, 0x98,
-	0x4c, 0x8e, 0x53, 0x6d, 0x91, 0x1b, 0xcd, 0x4f, 0xa0, 0x7f, 0x16, 0x3b, 0xc1, 0x29, 0x77, 0xaf,
-	0x5c, 0x8f, 0xcd, 0xd5, 0x62, 0x49, 0xf1, 0x5b, 0xca, 0x15, 0xbf, 0x1b, 0xb3, 0x91, 0x39, 0x02,
-	0x52, 0x18, 0x9e, 0xde, 0x5b, 0x14, 0x3b, 0x81, 0x0e, 0x61, 0x6c, 0x9b, 0x23, 0x68, 0x4f, 0xa8,
-	0x2c, 0x36, 0x1c, 0x65, 0x63, 0x40, 0x5d, 0xa8, 0xbe, 0x36, 0x4b, 0xba, 0xe6, 0x1e, 0xec, 0x1c,
-	0x50, 0xfb, 0xc2, 0xf5, 0xe7, 0xcf, 0xdc, 0x48, 0x56, 0x5b, 0x7a, 0xc4, 0x00, 0x1a, 0x8e, 0x16,
-	0xe8, 0x21, 0x69, 0xdf, 0x7c, 0x1f, 0xde, 0xc8, 0x7d, 0xe6, 0x39, 0x13, 0x34, 0xf1, 0xc7, 0x0e,
-	0x54, 0x23, 0xd9, 0xc3, 0x11, 0x55, 0x4b, 0x75, 0xcc, 0xaf, 0x60, 0x27, 0x9f, 0x80, 0x65, 0xed,
-	0x93, 0x1c, 0x1c, 0xab, 0x92, 0x52, 0xae, 0x2a, 0xd1, 0x3e, 0x2b, 0x67, 0xf9, 0x64, 0x1b, 0x2a,
-	0xbf, 0xfe, 0x66, 0xa2, 0xc1, 0x2e, 0x9b, 0xe6, 0x1f, 0xe5, 0xf2, 0xc5, 0xf9, 0xd4, 0xf2, 0x85,
-	0xd2, 0xa4, 0xf4, 0x5a, 0xa5, 0xc9, 0x3a, 0xde, 0xde, 0x87, 0xfe, 0x89, 0x17, 0xd8, 0x97, 0x87,
-	0x7e, 0xce, 0x1b, 0x06, 0xd4, 0x99, 0x9f, 0x77, 0x46, 0xd2, 0x35, 0xdf, 0x81, 0xde, 0x71, 0x60,
-	0x53, 0xef, 0x24, 0x88, 0x7d, 0x91, 0x7a, 0x01, 0xbf, 0xbb, 0x69, 0x53, 0xd5, 0x31, 0xdf, 0x87,
-	0xae, 0x4e, 0xd1, 0xfe, 0x79, 0x90, 0x30, 0x63, 0x96, 0xcc, 0x4b, 0xc5, 0x42, 0xdf, 0x3c, 0x86,
-	0x5e, 0x66, 0xae, 0xe6, 0x7d, 0x07, 0x6a, 0x4a, 0xad, 0xcf, 0xd6, 0x4b, 0x5f, 0xaf, 0xca, 0xd2,
-	0xd2, 0xea, 0x0d, 0x87, 0x5a, 0x40, 0xf7, 0x14, 0xbf, 0x7f, 0x1e, 0xfa, 0x57, 0x6a, 0xb2, 0x23,
-	0x20, 0xea, 0x8b, 0xe8, 0x94, 0xf9, 0x57, 0x2e, 0x0f, 0x7c, 0x2c, 0xae, 0x4b, 0xba, 0x84, 0x49,
-	0x26, 0x4e, 0x07, 0x25, 0x16, 0x56, 0x3f, 0x5c, 0x15, 0x6d, 0xf4, 0x21, 0x64, 0x5f, 0x57, 0x64,
-	0xaa, 0xe1, 0x6c, 0x11, 0x08, 0x36, 0xa5, 0x8e, 0x93, 0x44, 0x0b, 0x28, 0xd1, 0xbe, 0xe3, 0xf0,
-	0xbd, 0xff, 0x94, 0xa1, 0xfe, 0x99, 0x22, 0x70, 0xf2, 0x29, 0x74, 0x0a, 0xe9, 0x9a, 0xbc, 0x81,
-	0x65, 0xdd, 0x6a, 0x71, 0x30, 0xb8, 0xbd, 0x26, 0x56, 0xe7, 0xfa, 0x00, 0xda, 0xf9, 0x64, 0x4c,
-	0x30, 0xf1, 0xe2, 0xb7, 0xde, 0x01, 0xce, 0xb4, 0x9e, 0xa9, 0xcf, 0x60, 0x67, 0x53, 0x9a, 0x24,
-	0xf7, 0xb2, 0x15, 0xd6, 0x53, 0xf4, 0xe0, 0xcd, 0x9b, 0xb4, 0x49, 0x7a, 0xad, 0x1f, 0x78, 0x8c,
-	0xfa, 0x71, 0x98, 0xdf, 0x41, 0xd6, 0x24, 0x8f, 0xa1, 0x53, 0x48, 0x14, 0xea, 0x9c, 0x6b, 0xb9,
-	0x23, 0x3f, 0xe4, 0x21, 0x54, 0x31, 0x39, 0x91, 0x4e, 0x21, 0x4b, 0x0e, 0xba, 0x69, 0x57, 0xad,
-	0x3d, 0x84, 0x2d, 0xfc, 0x02, 0x98, 0x5b, 0x18, 0x47, 0xa4, 0x99, 0x6b, 0xef, 0xdf, 0x25, 0xa8,
-	0x27, 0x5f, 0x85, 0x1f, 0xc3, 0x96, 0xcc, 0x01, 0xe4, 0x56, 0x8e, 0x46, 0x93, 0xfc, 0x31, 0xd8,
-	0x59, 0x11, 0xaa, 0x05, 0xc6, 0x50, 0x79, 0xce, 0x04, 0x21, 0x39, 0xa5, 0x4e, 0x06, 0x83, 0x5b,
-	0x45, 0x59, 0x6a, 0x7f, 0x1a, 0x17, 0xed, 0x35, 0x97, 0x17, 0xec, 0x53, 0x96, 0xfe, 0x08, 0x6a,
-	0x8a, 0x65, 0x95, 0x53, 0xd6, 0xf8, 0x59, 0x5d, 0xfe, 0x3a, 0x1f, 0xef, 0xfd, 0x73, 0x0b, 0xe0,
-	0x6c, 0x19, 0x09, 0xb6, 0xf8, 0x8d, 0xcb, 0x5e, 0x92, 0x47, 0xd0, 0x7b, 0xc6, 0xce, 0x69, 0xec,
-	0x09, 0x7c, 0xaa, 0x49, 0x36, 0xc9, 0xf9, 0x04, 0x0b, 0xbe, 0x94, 0xac, 0x1f, 0x42, 0xeb, 0x84,
-	0x5e, 0xbf, 0xda, 0xee, 0x53, 0xe8, 0x14, 0x38, 0x58, 0x6f, 0x71, 0x95, 0xd5, 0xf5, 0x16, 0xd7,
-	0xd9, 0xfa, 0x21, 0xd4, 0x35, 0x33, 0xe7, 0xd7, 0xc0, 0x1c, 0x56, 0x60, 0xec, 0x9f, 0x43, 0x6f,
-	0x85, 0x97, 0xf3, 0xf6, 0xf8, 0x39, 0x64, 0x23, 0x6f, 0x3f, 0x95, 0xaf, 0x9d, 0x22, 0x37, 0xe7,
-	0x07, 0xea, 0x97, 0xd7, 0x26, 0xf2, 0x7e, 0x5e, 0x7c, 0x27, 0xe1, 0x13, 0xd5, 0x58, 0xa5, 0xcf,
-	0x84, 0xbc, 0x07, 0x77, 0x36, 0x69, 0xd2, 0x10, 0xcc, 0x33, 0xe8, 0x5a, 0x08, 0xae, 0xd3, 0xeb,
-	0x7b, 0x00, 0x19, 0x89, 0xe6, 0xed, 0x11, 0x1e, 0xab, 0xfc, 0xfa, 0x21, 0x40, 0x46, 0x8d, 0x0a,
-	0x55, 0x45, 0x66, 0x55, 0xc3, 0x56, 0xe9, 0xf3, 0x11, 0x34, 0x53, 0x3a, 0xcb, 0xaf, 0x81, 0x13,
-	0x14, 0xd9, 0xf1, 0xb3, 0x47, 0xbf, 0x1f, 0xcd, 0x5d, 0x71, 0x11, 0xcf, 0xc6, 0x76, 0xb0, 0xd8,
-	0xbd, 0xa0, 0xd1, 0x85, 0x6b, 0x07, 0x3c, 0xdc, 0xbd, 0x92, 0x60, 0xda, 0x2d, 0xfc, 0xb4, 0x9a,
-	0xd5, 0xf0, 0xa1, 0xf7, 0xe4, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x5b, 0x0c, 0x01, 0xf3, 0xcc,
-	0x1a, 0x00, 0x00,
+	// 2642 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x4b, 0x73, 0xdc, 0xc6,
+	0x11, 0xae, 0xdd, 0xe5, 0xbe, 0x7a, 0x9f, 0x1c, 0x52, 0x0c, 0x04, 0xc9, 0xe6, 0x1a, 0x8a, 0x64,
+	0x5a, 0xb1, 0x96, 0x16, 0x15, 0xc7, 0x72, 0x52, 0x76, 0x8a, 0xa6, 0x68, 0x99, 0x31, 0x65, 0xb3,
+	0xc0, 0xb5, 0x9d, 0x67, 0xad, 0xb1, 0xc0, 0x70, 0x89, 0x22, 0x16, 0x40, 0x06, 0x03, 0x4a, 0x9b,
+	0x4b, 0xfe, 0x44, 0x2a, 0xff, 0x20, 0xe7, 0x5c, 0x53, 0xb9, 0xe4, 0xea, 0xca, 0x3d, 0xff, 0x27,
+	0x35, 0x3d, 0x83, 0xd7, 0x3e, 0x68, 0x3b, 0xe5, 0xdc, 0x66, 0xba, 0x7b, 0xba, 0x67, 0x1a, 0xdd,
+	0xfd, 0xf5, 0x0c, 0xe0, 0x4e, 0xe4, 0x5c, 0xed, 0x87, 0x5e, 0x3c, 0x75, 0xfd, 0xfd, 0x70, 0xb2,
+	0x3f, 0xb1, 0xec, 0x2b, 0xea, 0x3b, 0xc3, 0x90, 0x05, 0x3c, 0x20, 0xe5, 0x70, 0xa2, 0xef, 0x4e,
+	0x83, 0x60, 0xea, 0xd1, 0x7d, 0xa4, 0x4c, 0xe2, 0x8b, 0x7d, 0xee, 0xce, 0x68, 0xc4, 0xad, 0x59,
+	0x28, 0x85, 0x74, 0x5d, 0x68, 0xf0, 0x82, 0xa9, 0x6b, 0x5b, 0xde, 0xbe, 0xeb, 0x50, 0x9f, 0xbb,
+	0x7c, 0xae, 0x78, 0x5a, 0x9e, 0x27, 0xad, 0x48, 0x8e, 0x51, 0x87, 0xea, 0xf1, 0x2c, 0xe4, 0x73,
+	0x63, 0x00, 0xb5, 0x4f, 0xa8, 0xe5, 0x50, 0x46, 0x76, 0xa0, 0x76, 0x89, 0x23, 0xad, 0x34, 0xa8,
+	0xec, 0x35, 0x4d, 0x35, 0x33, 0x7e, 0x07, 0x70, 0x26, 0xd6, 0x1c, 0x33, 0x16, 0x30, 0x72, 0x1b,
+	0x1a, 0x94, 0xb1, 0x31, 0x9f, 0x87, 0x54, 0x2b, 0x0d, 0x4a, 0x7b, 0x1d, 0xb3, 0x4e, 0x19, 0x1b,
+	0xcd, 0x43, 0x4a, 0x7e, 0x04, 0x62, 0x38, 0x9e, 0x45, 0x53, 0xad, 0x3c, 0x28, 0x09, 0x0d, 0x94,
+	0xb1, 0x17, 0xd1, 0x34, 0x59, 0x63, 0x07, 0x0e, 0xd5, 0x2a, 0x83, 0xd2, 0x5e, 0x05, 0xd7, 0x1c,
+	0x05, 0x0e, 0x35, 0xfe, 0x5a, 0x82, 0xea, 0x99, 0xc5, 0x2f, 0x23, 0x42, 0x60, 0x83, 0x05, 0x01,
+	0x57, 0xc6, 0x71, 0x4c, 0xf6, 0xa0, 0x17, 0xfb, 0x56, 0xcc, 0x2f, 0xc5, 0xa9, 0x6c, 0x8b, 0x53,
+	0x47, 0x2b, 0x23, 0x7b, 0x91, 0x4c, 0xee, 0x41, 0xc7, 0x0b, 0x6c, 0xcb, 0x1b, 0x47, 0x3c, 0x60,
+	0xd6, 0x54, 0xd8, 0x11, 0x72, 0x6d, 0x24, 0x9e, 0x4b, 0x1a, 0x79, 0x08, 0x9b, 0x11, 0xb5, 0xbc,
+	0xf1, 0x4b, 0x66, 0x85, 0xa9, 0xe0, 0x86, 0x54, 0x28, 0x18, 0x5f, 0x31, 0x2b, 0x54, 0xb2, 0xc6,
+	0xbf, 0x6a, 0x50, 0x37, 0xe9, 0x1f, 0x63, 0x1a, 0x71, 0xd2, 0x85, 0xb2, 0xeb, 0xe0, 0x69, 0x9b,
+	0x66, 0xd9, 0x75, 0xc8, 0x10, 0x88, 0x49, 0x43, 0x4f, 0x98, 0x76, 0x03, 0xff, 0xc8, 0x8b, 0x23,
+	0x4e, 0x99, 0x3a, 0xf3, 0x0a, 0x0e, 0xb9, 0x0b, 0xcd, 0x20, 0xa4, 0x0c, 0x69, 0xe8, 0x80, 0xa6,
+	0x99, 0x11, 0xc4, 0xc1, 0x43, 0x8b, 0x5f, 0x6a, 0x1b, 0xc8, 0xc0, 0xb1, 0xa0, 0x39, 0x16, 0xb7,
+	0xb4, 0xaa, 0xa4, 0x89, 0x31, 0x31, 0xa0, 0x16, 0x51, 0x9b, 0x51, 0xae, 0xd5, 0x06, 0xa5, 0xbd,
+	0xd6, 0x01, 0x0c, 0xc3, 0xc9, 0xf0, 0x1c, 0x29, 0xa6, 0xe2, 0x90, 0xbb, 0xb0, 0x21, 0xfc, 0xa2,
+	0xd5, 0x51, 0xa2, 0x21, 0x24, 0x0e, 0x63, 0x7e, 0x69, 0x22, 0x95, 0x1c, 0x40, 0x5d, 0x7e, 0xd3,
+	0x48, 0x6b, 0x0c, 0x2a, 0x7b, 0xad, 0x03, 0x4d, 0x08, 0xa8, 0x53, 0x0e, 0x65, 0x18, 0x44, 0xc7,
+	0x3e, 0x67, 0x73, 0x33, 0x11, 0x24, 0x6f, 0x40, 0xdb, 0xf6, 0x5c, 0xea, 0xf3, 0x31, 0x0f, 0xae,
+	0xa8, 0xaf, 0x35, 0x71, 0x47, 0x2d, 0x49, 0x1b, 0x09, 0x12, 0x39, 0x80, 0x5b, 0x79, 0x91, 0xb1,
+	0x65, 0xdb, 0x34, 0x8a, 0x02, 0xa6, 0x01, 0xca, 0x6e, 0xe5, 0x64, 0x0f, 0x15, 0x4b, 0xa8, 0x75,
+	0xdc, 0x28, 0xf4, 0xac, 0xf9, 0xd8, 0xb7, 0x66, 0x54, 0x6b, 0x49, 0xb5, 0x8a, 0xf6, 0x99, 0x35,
+	0xa3, 0x64, 0x17, 0x5a, 0xb3, 0x20, 0xf6, 0xf9, 0x38, 0x0c, 0x5c, 0x9f, 0x6b, 0x6d, 0x94, 0x00,
+	0x24, 0x9d, 0x09, 0x0a, 0x79, 0x0d, 0xe4, 0x4c, 0x06, 0x63, 0x47, 0xfa, 0x15, 0x29, 0x18, 0x8e,
+	0xf7, 0xa1, 0x2b, 0xd9, 0xe9, 0x7e, 0xba, 0x28, 0xd2, 0x41, 0x6a, 0xba, 0x93, 0x77, 0xa0, 0x89,
+	0xf1, 0xe0, 0xfa, 0x17, 0x81, 0xd6, 0x43, 0xbf, 0x6d, 0xe5, 0xdc, 0x22, 0x62, 0xe2, 0xc4, 0xbf,
+	0x08, 0xcc, 0xc6, 0x4b, 0x35, 0x22, 0x1f, 0xc0, 0x9d, 0xc2, 0x79, 0x19, 0x9d, 0x59, 0xae, 0xef,
+	0xfa, 0xd3, 0x71, 0x1c, 0xd1, 0x48, 0xeb, 0x63, 0x84, 0x6b, 0xb9, 0x53, 0x9b, 0x89, 0xc0, 0x17,
+	0x11, 0x8d, 0xc8, 0x1d, 0x68, 0xca, 0x24, 0x1d, 0xbb, 0x8e, 0xb6, 0x89, 0x5b, 0x6a, 0x48, 0xc2,
+	0x89, 0x43, 0xde, 0x84, 0x5e, 0x18, 0x78, 0xae, 0x3d, 0x1f, 0x07, 0xd7, 0x94, 0x31, 0xd7, 0xa1,
+	0x1a, 0x19, 0x94, 0xf6, 0x1a, 0x66, 0x57, 0x92, 0x3f, 0x57, 0xd4, 0x55, 0xa9, 0xb1, 0x85, 0x82,
+	0x8b, 0x64, 0x32, 0x04, 0xb0, 0x03, 0xdf, 0xa7, 0x36, 0x86, 0xdf, 0x36, 0x9e, 0xb0, 0x2b, 0x4e,
+	0x78, 0x94, 0x52, 0xcd, 0x9c, 0x84, 0xfe, 0x31, 0xb4, 0xf3, 0xa1, 0x40, 0xfa, 0x50, 0xb9, 0xa2,
+	0x73, 0x15, 0xfe, 0x62, 0x48, 0x06, 0x50, 0xbd, 0xb6, 0xbc, 0x98, 0x6a, 0xe5, 0x2c, 0x10, 0xe5,
+	0x12, 0x53, 0x32, 0x7e, 0x5e, 0x7e, 0x5a, 0x32, 0xfe, 0x59, 0x85, 0x0d, 0x11, 0x7c, 0xe4, 0x5d,
+	0xe8, 0x78, 0xd4, 0x8a, 0xe8, 0x38, 0x08, 0x85, 0x81, 0x08, 0x55, 0xb5, 0x0e, 0xfa, 0x62, 0xd9,
+	0xa9, 0x60, 0x7c, 0x2e, 0xe9, 0x66, 0xdb, 0xcb, 0xcd, 0x44, 0x4a, 0xbb, 0x3e, 0xa7, 0xcc, 0xb7,
+	0xbc, 0x31, 0x26, 0x83, 0x4c, 0xb0, 0x76, 0x42, 0x7c, 0x26, 0x92, 0x62, 0x31, 0x8e, 0x2a, 0xcb,
+	0x71, 0xa4, 0x43, 0x03, 0x7d, 0xe7, 0xd2, 0x48, 0x25, 0x7b, 0x3a, 0x27, 0x07, 0xd0, 0x98, 0x51,
+	0x6e, 0xa9, 0x5c, 0x13, 0x29, 0xb1, 0x93, 0xe4, 0xcc, 0xf0, 0x85, 0x62, 0xc8, 0x84, 0x48, 0xe5,
+	0x96, 0x32, 0xa2, 0xb6, 0x9c, 0x11, 0x3a, 0x34, 0xd2, 0xa0, 0xab, 0xcb, 0x2f, 0x9c, 0xcc, 0x45,
+	0x99, 0x0d, 0x29, 0x73, 0x03, 0x47, 0x6b, 0x60, 0xa0, 0xa8, 0x99, 0x28, 0x92, 0x7e, 0x3c, 0x93,
+	0x21, 0xd4, 0x94, 0x45, 0xd2, 0x8f, 0x67, 0xcb, 0x11, 0x03, 0x0b, 0x11, 0xf3, 0x63, 0xa8, 0x5a,
+	0x9e, 0x6b, 0x45, 0x5a, 0x4b, 0x7d, 0x59, 0x55, 0xef, 0x87, 0x87, 0x82, 0x6a, 0x4a, 0x26, 0x79,
+	0x02, 0x9d, 0x29, 0x0b, 0xe2, 0x70, 0x8c, 0x53, 0x1a, 0x69, 0xed, 0x41, 0x65, 0x85, 0x74, 0x1b,
+	0x85, 0x0e, 0xa5, 0x8c, 0xc8, 0xc0, 0x49, 0x10, 0xfb, 0xce, 0xd8, 0x76, 0x1d, 0x16, 0x69, 0x1d,
+	0x74, 0x1e, 0x20, 0xe9, 0x48, 0x50, 0x44, 0x8a, 0xc9, 0x14, 0x48, 0x1d, 0xdc, 0x45, 0x99, 0x0e,
+	0x52, 0xcf, 0x12, 0x2f, 0xff, 0x04, 0x36, 0x13, 0x60, 0xca, 0x24, 0x7b, 0x28, 0xd9, 0x4f, 0x18,
+	0xa9, 0xf0, 0x1e, 0xf4, 0xe9, 0x2b, 0x51, 0x42, 0x5d, 0x3e, 0x9e, 0x59, 0xaf, 0xc6, 0x9c, 0x7b,
+	0x2a, 0xa5, 0xba, 0x09, 0xfd, 0x85, 0xf5, 0x6a, 0xc4, 0x3d, 0x91, 0xff, 0xd2, 0x3a, 0xe6, 0xff,
+	0x26, 0x82, 0x51, 0x13, 0x29, 0x22, 0xff, 0xf5, 0x5f, 0x40, 0xa7, 0xf0, 0x09, 0x57, 0x04, 0xf2,
+	0x76, 0x3e, 0x90, 0x9b, 0xf9, 0xe0, 0xfd, 0xf7, 0x06, 0x00, 0x7e, 0x4b, 0xb9, 0x74, 0x11, 0x01,
+	0xf2, 0x1f, 0xb8, 0xbc, 0xe2, 0x03, 0x5b, 0x8c, 0xfa, 0x5c, 0x05, 0xa3, 0x9a, 0xdd, 0x18, 0x87,
+	0x09, 0x06, 0x54, 0x73, 0x18, 0xf0, 0x36, 0x6c, 0x88, 0x98, 0xd3, 0x6a, 0x59, 0xa9, 0xce, 0x76,
+	0x84, 0xd1, 0x89, 0x23, 0x13, 0xa5, 0x96, 0x12, 0xa1, 0xbe, 0x9c, 0x08, 0xf9, 0x08, 0x6b, 0x14,
+	0x23, 0xec, 0x1e, 0x74, 0x6c, 0x46, 0x11, 0x8f, 0xc6, 0xa2, 0xc1, 0x50, 0x11, 0xd8, 0x4e, 0x88,
+	0x23, 0x77, 0x46, 0x85, 0xff, 0xc4, 0xc7, 0x00, 0x64, 0x89, 0xe1, 0xca, 0x6f, 0xd5, 0x5a, 0xf9,
+	0xad, 0x10, 0xdd, 0x3d, 0xaa, 0xaa, 0x38, 0x8e, 0x73, 0x99, 0xd0, 0x29, 0x64, 0x42, 0x21, 0xdc,
+	0xbb, 0x0b, 0xe1, 0xbe, 0x10, 0x93, 0xbd, 0xa5, 0x98, 0x7c, 0x03, 0xda, 0xc2, 0x01, 0x51, 0x68,
+	0xd9, 0x54, 0x28, 0xe8, 0x4b, 0x47, 0xa4, 0xb4, 0x13, 0x07, 0x33, 0x38, 0x9e, 0x4c, 0xe6, 0x97,
+	0x81, 0x47, 0xb3, 0x22, 0xdc, 0x4a, 0x69, 0x27, 0x8e, 0xd8, 0x2f, 0x46, 0x15, 0xc1, 0xa8, 0xc2,
+	0xb1, 0xfe, 0x1e, 0x34, 0x53, 0xaf, 0x7f, 0xaf, 0x60, 0xfa, 0x7b, 0x09, 0xda, 0xf9, 0x42, 0x27,
+	0x16, 0x8f, 0x46, 0xa7, 0xb8, 0xb8, 0x62, 0x8a, 0xa1, 0x68, 0x11, 0x18, 0xf5, 0xe9, 0x4b, 0x6b,
+	0xe2, 0x49, 0x05, 0x0d, 0x33, 0x23, 0x08, 0xae, 0xeb, 0xdb, 0x8c, 0xce, 0x92, 0xa8, 0xaa, 0x98,
+	0x19, 0x81, 0xbc, 0x0f, 0xe0, 0x46, 0x51, 0x4c, 0xe5, 0x97, 0xdb, 0xc0, 0x32, 0xa0, 0x0f, 0x65,
+	0xdf, 0x38, 0x4c, 0xfa, 0xc6, 0xe1, 0x28, 0xe9, 0x1b, 0xcd, 0x26, 0x4a, 0xe3, 0x27, 0xdd, 0x81,
+	0x9a, 0xf8, 0x40, 0xa3, 0x53, 0x8c, 0xbc, 0x8a, 0xa9, 0x66, 0xc6, 0x9f, 0xa1, 0x26, 0x3b, 0x8b,
+	0xff, 0x6b, 0xf1, 0xbe, 0x0d, 0x0d, 0xa9, 0xdb, 0x75, 0x54, 0xae, 0xd4, 0x71, 0x7e, 0xe2, 0x18,
+	0xdf, 0x94, 0xa1, 0x61, 0xd2, 0x28, 0x0c, 0xfc, 0x88, 0xe6, 0x3a, 0x9f, 0xd2, 0xb7, 0x76, 0x3e,
+	0xe5, 0x95, 0x9d, 0x4f, 0xd2, 0x4f, 0x55, 0x72, 0xfd, 0x94, 0x0e, 0x0d, 0x46, 0x1d, 0x97, 0x51,
+	0x9b, 0xab, 0xde, 0x2b, 0x9d, 0x0b, 0xde, 0x4b, 0x8b, 0x09, 0xc8, 0x8e, 0x10, 0x17, 0x9a, 0x66,
+	0x3a, 0x27, 0x8f, 0xf3, 0x0d, 0x83, 0x6c, 0xc5, 0xb6, 0x65, 0xc3, 0x20, 0xb7, 0xbb, 0xa2, 0x63,
+	0x78, 0x92, 0x35, 0x5e, 0x75, 0xcc, 0xe6, 0xdb, 0xf9, 0x05, 0xab, 0x3b, 0xaf, 0x1f, 0x0c, 0x87,
+	0xbf, 0x29, 0x43, 0x7f, 0x71, 0x6f, 0x2b, 0x22, 0x70, 0x1b, 0xaa, 0x12, 0xcf, 0x54, 0xf8, 0xf2,
+	0x25, 0x24, 0xab, 0x2c, 0x14, 0xba, 0x5f, 0x2e, 0x16, 0x8d, 0x6f, 0x0f, 0xbd, 0x62, 0x41, 0x79,
+	0x0b, 0xfa, 0xc2, 0x45, 0x21, 0x75, 0xb2, 0x1e, 0x4d, 0x56, 0xc0, 0x9e, 0xa2, 0xa7, 0x5d, 0xda,
+	0x43, 0xd8, 0x4c, 0x44, 0xb3, 0xda, 0x50, 0x2b, 0xc8, 0x1e, 0x27, 0x25, 0x62, 0x07, 0x6a, 0x17,
+	0x01, 0x9b, 0x59, 0x5c, 0x15, 0x41, 0x35, 0x2b, 0x14, 0x39, 0xac, 0xb6, 0x0d, 0x19, 0x93, 0x09,
+	0x51, 0xdc, 0x43, 0x44, 0xf1, 0x49, 0xef, 0x08, 0x58, 0x05, 0x1b, 0x66, 0x23, 0xb9, 0x1b, 0x18,
+	0xbf, 0x86, 0xde, 0x42, 0x5b, 0xb8, 0xc2, 0x91, 0x99, 0xf9, 0x72, 0xc1, 0x7c, 0x41, 0x73, 0x65,
+	0x41, 0xf3, 0x6f, 0x60, 0xf3, 0x13, 0xcb, 0x77, 0x3c, 0xaa, 0xf4, 0x1f, 0xb2, 0x69, 0x24, 0x00,
+	0x4e, 0xdd, 0x52, 0xc6, 0x0a, 0x7d, 0x3a, 0x66, 0x53, 0x51, 0x4e, 0x1c, 0x72, 0x1f, 0xea, 0x4c,
+	0x4a, 0xab, 0x00, 0x68, 0xe5, 0xfa, 0x56, 0x33, 0xe1, 0x19, 0x5f, 0x03, 0x29, 0xa8, 0x16, 0x17,
+	0x94, 0x39, 0xd9, 0x13, 0xd1, 0x2f, 0x83, 0x42, 0x65, 0x55, 0x3b, 0x1f, 0x93, 0x66, 0xca, 0x25,
+	0x03, 0xa8, 0x50, 0xc6, 0xb4, 0x72, 0xd6, 0x38, 0x66, 0xd7, 0x41, 0x53, 0xb0, 0x8c, 0x9f, 0xc2,
+	0xe6, 0x79, 0x48, 0x6d, 0xd7, 0xf2, 0xf0, 0x2a, 0x27, 0x0d, 0xec, 0x42, 0x55, 0x38, 0x39, 0x29,
+	0x18, 0x4d, 0x5c, 0x88, 0x6c, 0x49, 0x37, 0xbe, 0x06, 0x4d, 0xee, 0xeb, 0xf8, 0x95, 0x1b, 0x71,
+	0xea, 0xdb, 0xf4, 0xe8, 0x92, 0xda, 0x57, 0x3f, 0xe0, 0xc9, 0xaf, 0xe1, 0xf6, 0x2a, 0x0b, 0xc9,
+	0xfe, 0x5a, 0xb6, 0x98, 0x8d, 0x2f, 0x04, 0x76, 0xa0, 0x8d, 0x86, 0x09, 0x48, 0xfa, 0x58, 0x50,
+	0xc4, 0x77, 0xa4, 0x62, 0x5d, 0xa4, 0xea, 0xb1, 0x9a, 0x25, 0xfe, 0xa8, 0xac, 0xf7, 0xc7, 0x3f,
+	0x4a, 0xd0, 0x3c, 0xa7, 0x3c, 0x0e, 0xf1, 0x2c, 0x77, 0xa0, 0x39, 0x61, 0xc1, 0x15, 0x65, 0xd9,
+	0x51, 0x1a, 0x92, 0x70, 0xe2, 0x90, 0xc7, 0x50, 0x3b, 0x0a, 0xfc, 0x0b, 0x77, 0xaa, 0x95, 0xb3,
+	0xc2, 0x90, 0xae, 0x1d, 0x4a, 0x9e, 0x2c, 0x0c, 0x4a, 0x90, 0x0c, 0xa0, 0xa5, 0x9e, 0x09, 0xbe,
+	0xf8, 0xe2, 0xe4, 0x59, 0xd2, 0xf1, 0xe6, 0x48, 0xfa, 0xfb, 0xd0, 0xca, 0x2d, 0xfc, 0x5e, 0x50,
+	0xf5, 0x3a, 0x00, 0x5a, 0x97, 0x3e, 0xea, 0xcb, 0xa3, 0xaa, 0x95, 0xe2, 0x68, 0xbb, 0xd0, 0x14,
+	0xcd, 0x95, 0x64, 0x27, 0x20, 0x59, 0xca, 0x40, 0xd2, 0xb8, 0x0f, 0x9b, 0x27, 0xfe, 0xb5, 0xe5,
+	0xb9, 0x8e, 0xc5, 0xe9, 0xa7, 0x74, 0x8e, 0x2e, 0x58, 0xda, 0x81, 0xd1, 0x87, 0xee, 0x89, 0xef,
+	0x72, 0xd7, 0xf2, 0xdc, 0x3f, 0x51, 0x21, 0x63, 0x3c, 0x81, 0x5e, 0x46, 0x91, 0xfa, 0x07, 0x99,
+	0xf9, 0x35, 0x9e, 0x3e, 0x87, 0xb6, 0xba, 0xb0, 0x7f, 0xa7, 0xa3, 0xb6, 0xd5, 0x51, 0x6f, 0xce,
+	0xc5, 0xb7, 0xa0, 0xa7, 0x94, 0x9e, 0xba, 0x2a, 0x13, 0x45, 0xab, 0xc2, 0xe8, 0x85, 0xfb, 0x4a,
+	0xa9, 0x56, 0x33, 0xe3, 0x29, 0xf4, 0x73, 0xa2, 0xa9, 0x57, 0xae, 0xe8, 0x3c, 0x4a, 0x1e, 0x32,
+	0xc4, 0x38, 0x71, 0x64, 0x39, 0x73, 0xa4, 0x01, 0x5d, 0xb5, 0xf2, 0x39, 0xe5, 0x6b, 0x9c, 0xf4,
+	0x69, 0xba, 0x91, 0xe7, 0x54, 0x29, 0x7f, 0x00, 0x55, 0x2a, 0x4e, 0x9a, 0x87, 0xe1, 0xbc, 0x07,
+	0x4c, 0xc9, 0x5e, 0x61, 0xf0, 0x69, 0x6a, 0xf0, 0x2c, 0x96, 0x06, 0xbf, 0xa3, 0x2e, 0xe3, 0x5e,
+	0xba, 0x8d, 0xb3, 0x98, 0xaf, 0x0b, 0x8c, 0xfb, 0xb0, 0xa9, 0x84, 0x9e, 0x51, 0x8f, 0x72, 0xba,
+	0xe6, 0x48, 0x0f, 0x80, 0x14, 0xc4, 0xd6, 0xa9, 0xbb, 0x0b, 0x8d, 0xd1, 0xe8, 0x34, 0xe5, 0x16,
+	0x4b, 0xac, 0xf1, 0x01, 0x6c, 0x9e, 0xc7, 0x4e, 0x70, 0xc6, 0xdc, 0x6b, 0xd7, 0xa3, 0x53, 0x69,
+	0x2c, 0xe9, 0xa1, 0x4b, 0xb9, 0x1e, 0x7a, 0x25, 0xa8, 0x19, 0x7b, 0x40, 0x0a, 0xcb, 0xd3, 0xef,
+	0x16, 0xc5, 0x4e, 0xa0, 0x2a, 0x01, 0x8e, 0x8d, 0x3d, 0x68, 0x8f, 0x2c, 0xd1, 0xb3, 0x38, 0x52,
+	0x46, 0x83, 0x3a, 0x97, 0x73, 0x25, 0x96, 0x4c, 0x8d, 0x03, 0xd8, 0x3e, 0xb2, 0xec, 0x4b, 0xd7,
+	0x9f, 0x3e, 0x73, 0x23, 0xd1, 0xb4, 0xa9, 0x15, 0x3a, 0x34, 0x1c, 0x45, 0x50, 0x4b, 0xd2, 0xb9,
+	0xf1, 0x08, 0x6e, 0xe5, 0x5e, 0x8b, 0xce, 0xb9, 0x95, 0xf8, 0x63, 0x1b, 0xaa, 0x91, 0x98, 0xe1,
+	0x8a, 0xaa, 0x29, 0x27, 0xc6, 0x67, 0xb0, 0x9d, 0xc7, 0x71, 0xd1, 0x42, 0x25, 0x07, 0xc7, 0xe6,
+	0xa6, 0x94, 0x6b, 0x6e, 0x94, 0xcf, 0xca, 0x19, 0x2c, 0xf5, 0xa1, 0xf2, 0xab, 0xaf, 0x46, 0x2a,
+	0xd8, 0xc5, 0xd0, 0xf8, 0x3d, 0xdc, 0x5a, 0xd4, 0x27, 0xcd, 0x17, 0x3a, 0x9c, 0xd2, 0x77, 0xea,
+	0x70, 0x96, 0xe3, 0xed, 0x11, 0x6c, 0xbe, 0xf0, 0x02, 0xfb, 0xea, 0xd8, 0xcf, 0x79, 0x43, 0x83,
+	0x3a, 0xf5, 0xf3, 0xce, 0x48, 0xa6, 0xc6, 0x9b, 0xd0, 0x3b, 0x15, 0x6f, 0x75, 0x2f, 0xc4, 0xe3,
+	0x4c, 0xea, 0x05, 0x7c, 0xbe, 0x53, 0xa2, 0x72, 0x62, 0x3c, 0x82, 0xae, 0x42, 0x7a, 0xff, 0x22,
+	0x48, 0x0a, 0x6c, 0xd6, 0x13, 0x94, 0x8a, 0xf7, 0x05, 0xe3, 0x14, 0x7a, 0x99, 0xb8, 0xd4, 0xfb,
+	0x26, 0xd4, 0x24, 0x5b, 0x9d, 0xad, 0x97, 0x5e, 0x82, 0xa5, 0xa4, 0xa9, 0xd8, 0x2b, 0x0e, 0x35,
+	0x83, 0xee, 0x19, 0x3e, 0xa3, 0x1e, 0xfb, 0xd7, 0x52, 0xd9, 0x09, 0x10, 0xf9, 0xb0, 0x3a, 0xa6,
+	0xfe, 0xb5, 0xcb, 0x02, 0x1f, 0x7b, 0xf4, 0x92, 0xea, 0x84, 0x12, 0xc5, 0xe9, 0xa2, 0x44, 0xc2,
+	0xdc, 0x0c, 0x17, 0x49, 0x2b, 0xcc, 0x1d, 0xc2, 0xeb, 0xcf, 0xa9, 0x4f, 0x99, 0xc5, 0xe9, 0x99,
+	0x15, 0x45, 0x2f, 0x03, 0xe6, 0x7c, 0xcc, 0x82, 0x19, 0xde, 0x95, 0x65, 0x65, 0xdd, 0x85, 0x96,
+	0x7a, 0x2f, 0xc2, 0x5b, 0x9f, 0x3c, 0x3d, 0x48, 0x92, 0xb8, 0xf4, 0x19, 0x9f, 0xc3, 0xee, 0x7a,
+	0x15, 0x69, 0x88, 0x86, 0x8a, 0x95, 0xb8, 0x2f, 0x99, 0xaf, 0xd8, 0xd3, 0x1f, 0xe0, 0xf5, 0x2f,
+	0x55, 0x79, 0xff, 0x1f, 0xf7, 0x54, 0x30, 0x58, 0x2e, 0x1a, 0x34, 0x9e, 0xc0, 0xee, 0x7a, 0xf5,
+	0xeb, 0xaa, 0xc5, 0x23, 0x80, 0xec, 0x31, 0x4b, 0xd8, 0x67, 0x74, 0x16, 0x70, 0x3a, 0xb6, 0x1c,
+	0x27, 0x91, 0x03, 0x49, 0x3a, 0x74, 0x1c, 0x76, 0xf0, 0xb7, 0x0a, 0xd4, 0x3f, 0x92, 0x78, 0x49,
+	0x3e, 0x84, 0x4e, 0xa1, 0x3b, 0x22, 0xb7, 0xb0, 0x8b, 0x5e, 0xec, 0xc5, 0xf4, 0x9d, 0x25, 0xb2,
+	0xdc, 0xcc, 0x3b, 0xd0, 0xce, 0xf7, 0x3e, 0x04, 0xfb, 0x1c, 0x7c, 0x5a, 0xd7, 0x51, 0xd3, 0x72,
+	0x63, 0x74, 0x0e, 0xdb, 0xab, 0xba, 0x12, 0x72, 0x37, 0xb3, 0xb0, 0xdc, 0x11, 0xe9, 0xaf, 0xad,
+	0xe3, 0x26, 0xdd, 0x4c, 0xfd, 0xc8, 0xa3, 0x96, 0x1f, 0x87, 0xf9, 0x1d, 0x64, 0x43, 0xf2, 0x18,
+	0x3a, 0x05, 0x5c, 0x96, 0xe7, 0x5c, 0x82, 0xea, 0xfc, 0x92, 0x07, 0x50, 0xc5, 0x5e, 0x80, 0x74,
+	0x0a, 0x4d, 0x89, 0xde, 0x4d, 0xa7, 0xd2, 0xf6, 0xbb, 0x00, 0x19, 0x72, 0x13, 0x22, 0xf5, 0xe6,
+	0xb1, 0x5d, 0xdf, 0x2a, 0xd2, 0x12, 0x74, 0xdf, 0xc0, 0x77, 0xda, 0xdc, 0x7e, 0xd1, 0x50, 0xda,
+	0x5f, 0x1c, 0xfc, 0xa7, 0x04, 0xf5, 0xe4, 0xed, 0xfe, 0x31, 0x6c, 0x08, 0x88, 0x25, 0x5b, 0x39,
+	0x94, 0x4a, 0xe0, 0x59, 0xdf, 0x5e, 0x20, 0x4a, 0x03, 0x43, 0xa8, 0x3c, 0xa7, 0x9c, 0x90, 0x1c,
+	0x53, 0x61, 0xad, 0xbe, 0x55, 0xa4, 0xa5, 0xf2, 0x67, 0x71, 0x51, 0xfe, 0x2c, 0x5e, 0x96, 0x4f,
+	0x41, 0xf0, 0x3d, 0xa8, 0x49, 0x10, 0x23, 0xb7, 0x72, 0xec, 0x0c, 0xfe, 0xf4, 0x9d, 0x25, 0xb2,
+	0x3c, 0xd7, 0x5f, 0x6a, 0x00, 0xe7, 0xf3, 0x88, 0xd3, 0xd9, 0x97, 0x2e, 0x7d, 0x49, 0x1e, 0x42,
+	0xef, 0x19, 0xbd, 0xb0, 0x62, 0x8f, 0xe3, 0x85, 0x5a, 0x14, 0xeb, 0x9c, 0x4f, 0xb0, 0x2d, 0x4f,
+	0xb1, 0xf0, 0x01, 0xb4, 0x5e, 0x58, 0xaf, 0xbe, 0x5d, 0xee, 0x43, 0xe8, 0x14, 0x20, 0x4e, 0x6d,
+	0x71, 0x11, 0x34, 0xf5, 0x9d, 0x25, 0x72, 0x62, 0xa7, 0xae, 0x80, 0x2f, 0x6f, 0x03, 0x5b, 0x84,
+	0x02, 0x20, 0xfe, 0x0c, 0x7a, 0x0b, 0xb0, 0x97, 0x97, 0xc7, 0x47, 0xab, 0x95, 0xb0, 0xf8, 0x14,
+	0xfa, 0x8b, 0xd0, 0x97, 0x5f, 0xa8, 0xee, 0xc7, 0xab, 0xb0, 0xf1, 0x39, 0xf4, 0x17, 0x51, 0x8b,
+	0x68, 0x8b, 0xe8, 0x94, 0x60, 0xa3, 0x7e, 0x7b, 0x15, 0x27, 0xcd, 0xdc, 0x3c, 0x40, 0x2d, 0x65,
+	0xee, 0x32, 0x7a, 0xbd, 0x0d, 0x90, 0x61, 0x54, 0x5e, 0x1e, 0xc3, 0x63, 0x11, 0xbe, 0xde, 0x05,
+	0xc8, 0x90, 0x47, 0x46, 0x55, 0x11, 0xb8, 0xf4, 0xad, 0x22, 0x4d, 0x2e, 0x7b, 0x08, 0xcd, 0x14,
+	0x2d, 0xf2, 0x36, 0x50, 0xc1, 0x02, 0xf8, 0x50, 0xd0, 0xd7, 0x17, 0x77, 0x62, 0x88, 0x15, 0x37,
+	0xe3, 0x87, 0x7e, 0xef, 0x66, 0x99, 0xd4, 0xcc, 0xfa, 0x9a, 0x2c, 0xcd, 0xdc, 0x0c, 0x09, 0xfa,
+	0xbd, 0x9b, 0x65, 0xd0, 0xcc, 0x47, 0x0f, 0x7f, 0xbb, 0x37, 0x75, 0xf9, 0x65, 0x3c, 0x19, 0xda,
+	0xc1, 0x6c, 0xff, 0xd2, 0x8a, 0x2e, 0x5d, 0x3b, 0x60, 0xe1, 0xfe, 0xb5, 0x48, 0x8d, 0xfd, 0xc2,
+	0x8f, 0xd2, 0x49, 0x0d, 0x1f, 0x17, 0x9e, 0xfc, 0x77, 0x00, 0x4a, 0xc9, 0xea, 0x39, 0x40, 0x1d,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2915,6 +3174,9 @@ type BackendClient interface {
 	// broker_id to create a connection back to Vault for use with the Storage
 	// and SystemView clients.
 	Setup(ctx context.Context, in *SetupArgs, opts ...grpc.CallOption) (*SetupReply, error)
+	// Initialize is invoked just after mounting a backend to allow it to
+	// handle any initialization tasks that need to be performed.
+	Initialize(ctx context.Context, in *InitializeArgs, opts ...grpc.CallOption) (*InitializeReply, error)
 	// Type returns the BackendType for the particular backend
 	Type(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TypeReply, error)
 }
@@ -2981,6 +3243,15 @@ func (c *backendClient) Setup(ctx context.Context, in *SetupArgs, opts ...grpc.C
 	return out, nil
 }
 
+func (c *backendClient) Initialize(ctx context.Context, in *InitializeArgs, opts ...grpc.CallOption) (*InitializeReply, error) {
+	out := new(InitializeReply)
+	err := c.cc.Invoke(ctx, "/pb.Backend/Initialize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backendClient) Type(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TypeReply, error) {
 	out := new(TypeReply)
 	err := c.cc.Invoke(ctx, "/pb.Backend/Type", in, out, opts...)
@@ -3022,6 +3293,9 @@ type BackendServer interface {
 	// broker_id to create a connection back to Vault for use with the Storage
 	// and SystemView clients.
 	Setup(context.Context, *SetupArgs) (*SetupReply, error)
+	// Initialize is invoked just after mounting a backend to allow it to
+	// handle any initialization tasks that need to be performed.
+	Initialize(context.Context, *InitializeArgs) (*InitializeReply, error)
 	// Type returns the BackendType for the particular backend
 	Type(context.Context, *Empty) (*TypeReply, error)
 }
@@ -3138,6 +3412,24 @@ func _Backend_Setup_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Backend_Initialize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitializeArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServer).Initialize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Backend/Initialize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServer).Initialize(ctx, req.(*InitializeArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Backend_Type_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -3184,6 +3476,10 @@ var _Backend_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Setup",
 			Handler:    _Backend_Setup_Handler,
 		},
+		{
+			MethodName: "Initialize",
+			Handler:    _Backend_Initialize_Handler,
+		},
 		{
 			MethodName: "Type",
 			Handler:    _Backend_Type_Handler,
@@ -3396,6 +3692,12 @@ type SystemViewClient interface {
 	EntityInfo(ctx context.Context, in *EntityInfoArgs, opts ...grpc.CallOption) (*EntityInfoReply, error)
 	// PluginEnv returns Vault environment information used by plugins
 	PluginEnv(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PluginEnvReply, error)
+	// GeneratePasswordFromPolicy generates a password from the named
+	// password policy
+	GeneratePasswordFromPolicy(ctx context.Context, in *GeneratePasswordFromPolicyArgs, opts ...grpc.CallOption) (*GeneratePasswordFromPolicyReply, error)
+	// ValidatePasswordFromPolicy checks the given password against the named
+	// password policy
+	ValidatePasswordFromPolicy(ctx context.Context, in *ValidatePasswordFromPolicyArgs, opts ...grpc.CallOption) (*ValidatePasswordFromPolicyReply, error)
 }
 
 type systemViewClient struct {
@@ -3505,6 +3807,24 @@ func (c *systemViewClient) PluginEnv(ctx context.Context, in *Empty, opts ...grp
 	return out, nil
 }
 
+func (c *systemViewClient) GeneratePasswordFromPolicy(ctx context.Context, in *GeneratePasswordFromPolicyArgs, opts ...grpc.CallOption) (*GeneratePasswordFromPolicyReply, error) {
+	out := new(GeneratePasswordFromPolicyReply)
+	err := c.cc.Invoke(ctx, "/pb.SystemView/GeneratePasswordFromPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemViewClient) ValidatePasswordFromPolicy(ctx context.Context, in *ValidatePasswordFromPolicyArgs, opts ...grpc.CallOption) (*ValidatePasswordFromPolicyReply, error) {
+	out := new(ValidatePasswordFromPolicyReply)
+	err := c.cc.Invoke(ctx, "/pb.SystemView/ValidatePasswordFromPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SystemViewServer is the server API for SystemView service.
 type SystemViewServer interface {
 	// DefaultLeaseTTL returns the default lease TTL set in Vault configuration
@@ -3543,6 +3863,12 @@ type SystemViewServer interface {
 	EntityInfo(context.Context, *EntityInfoArgs) (*EntityInfoReply, error)
 	// PluginEnv returns Vault environment information used by plugins
 	PluginEnv(context.Context, *Empty) (*PluginEnvReply, error)
+	// GeneratePasswordFromPolicy generates a password from the named
+	// password policy
+	GeneratePasswordFromPolicy(context.Context, *GeneratePasswordFromPolicyArgs) (*GeneratePasswordFromPolicyReply, error)
+	// ValidatePasswordFromPolicy checks the given password against the named
+	// password policy
+	ValidatePasswordFromPolicy(context.Context, *ValidatePasswordFromPolicyArgs) (*ValidatePasswordFromPolicyReply, error)
 }
 
 func RegisterSystemViewServer(s *grpc.Server, srv SystemViewServer) {
@@ -3747,6 +4073,42 @@ func _SystemView_PluginEnv_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SystemView_GeneratePasswordFromPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeneratePasswordFromPolicyArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemViewServer).GeneratePasswordFromPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.SystemView/GeneratePasswordFromPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemViewServer).GeneratePasswordFromPolicy(ctx, req.(*GeneratePasswordFromPolicyArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemView_ValidatePasswordFromPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePasswordFromPolicyArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemViewServer).ValidatePasswordFromPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.SystemView/ValidatePasswordFromPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemViewServer).ValidatePasswordFromPolicy(ctx, req.(*ValidatePasswordFromPolicyArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SystemView_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.SystemView",
 	HandlerType: (*SystemViewServer)(nil),
@@ -3795,6 +4157,14 @@ var _SystemView_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PluginEnv",
 			Handler:    _SystemView_PluginEnv_Handler,
 		},
+		{
+			MethodName: "GeneratePasswordFromPolicy",
+			Handler:    _SystemView_GeneratePasswordFromPolicy_Handler,
+		},
+		{
+			MethodName: "ValidatePasswordFromPolicy",
+			Handler:    _SystemView_ValidatePasswordFromPolicy_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "sdk/plugin/pb/backend.proto",
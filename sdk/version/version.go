@@ -11,6 +11,7 @@ type VersionInfo struct {
 	Version           string
 	VersionPrerelease string
 	VersionMetadata   string
+	BuildDate         string
 }
 
 func GetVersion() *VersionInfo {
@@ -29,6 +30,7 @@ func GetVersion() *VersionInfo {
 		Version:           ver,
 		VersionPrerelease: rel,
 		VersionMetadata:   md,
+		BuildDate:         BuildDate,
 	}
 }
 
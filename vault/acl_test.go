@@ -257,6 +257,11 @@ func testACLSingle(t *testing.T, ns *namespace.Namespace) {
 		{logical.UpdateOperation, "1/2/3", false, false},
 		{logical.UpdateOperation, "1/2/3/4", true, false},
 		{logical.CreateOperation, "1/2/3/4/5", true, false},
+
+		// Patch is a distinct capability
+		{logical.PatchOperation, "patch/foo", true, false},
+		{logical.UpdateOperation, "patch/foo", false, false},
+		{logical.PatchOperation, "dev/foo", false, true},
 	}
 
 	for _, tc := range tcases {
@@ -884,6 +889,9 @@ path "1/2/+" {
 path "1/2/+/+" {
 	capabilities = ["update"]
 }
+path "patch/*" {
+	capabilities = ["read", "patch"]
+}
 `
 
 var aclPolicy2 = `
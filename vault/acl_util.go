@@ -5,10 +5,26 @@ package vault
 import (
 	"context"
 
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/vault/helper/identity"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
 func (c *Core) performEntPolicyChecks(ctx context.Context, acl *ACL, te *logical.TokenEntry, req *logical.Request, inEntity *identity.Entity, opts *PolicyCheckOpts, ret *AuthResults) {
 	ret.Allowed = true
+
+	// Enforce step-up MFA for paths whose policies require it
+	if ret.ACLResults == nil || len(ret.ACLResults.MFAMethods) == 0 {
+		return
+	}
+	var remoteAddr string
+	if req.Connection != nil {
+		remoteAddr = req.Connection.RemoteAddr
+	}
+	if err := c.validateMFACreds(ctx, inEntity, ret.ACLResults.MFAMethods, req.MFACreds, remoteAddr); err != nil {
+		c.logger.Debug("MFA validation failed", "path", req.Path, "error", err)
+		ret.Allowed = false
+		ret.DeniedError = true
+		ret.Error = multierror.Append(ret.Error, err)
+	}
 }
@@ -5,8 +5,10 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/errwrap"
 	uuid "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/audit"
 	"github.com/hashicorp/vault/helper/namespace"
@@ -93,6 +95,16 @@ func (c *Core) enableAudit(ctx context.Context, entry *MountEntry, updateStorage
 	view.setReadOnlyErr(logical.ErrSetupReadOnly)
 	defer view.setReadOnlyErr(origViewReadOnlyErr)
 
+	opts, err := parseAuditDeviceOptions(entry.Options)
+	if err != nil {
+		return err
+	}
+	if opts.fallback {
+		if existing := c.auditBroker.FallbackName(); existing != "" {
+			return fmt.Errorf("audit device %q is already the fallback device", existing)
+		}
+	}
+
 	// Lookup the new backend
 	backend, err := c.newAuditBackend(ctx, entry, view, entry.Options)
 	if err != nil {
@@ -121,7 +133,7 @@ func (c *Core) enableAudit(ctx context.Context, entry *MountEntry, updateStorage
 	c.audit = newTable
 
 	// Register the backend
-	c.auditBroker.Register(entry.Path, backend, view, entry.Local)
+	c.auditBroker.Register(entry.Path, backend, view, entry.Local, opts)
 	if c.logger.IsInfo() {
 		c.logger.Info("enabled audit backend", "path", entry.Path, "type", entry.Type)
 	}
@@ -372,6 +384,12 @@ func (c *Core) setupAudits(ctx context.Context) error {
 			view.setReadOnlyErr(origViewReadOnlyErr)
 		})
 
+		opts, err := parseAuditDeviceOptions(entry.Options)
+		if err != nil {
+			c.logger.Error("failed to parse audit entry options", "path", entry.Path, "error", err)
+			continue
+		}
+
 		// Initialize the backend
 		backend, err := c.newAuditBackend(ctx, entry, view, entry.Options)
 		if err != nil {
@@ -384,7 +402,7 @@ func (c *Core) setupAudits(ctx context.Context) error {
 		}
 
 		// Mount the backend
-		broker.Register(entry.Path, backend, view, entry.Local)
+		broker.Register(entry.Path, backend, view, entry.Local, opts)
 
 		successCount++
 	}
@@ -410,6 +428,12 @@ func (c *Core) teardownAudits() error {
 		}
 	}
 
+	if c.auditBroker != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), auditShutdownTimeout)
+		c.auditBroker.Shutdown(ctx)
+		cancel()
+	}
+
 	c.audit = nil
 	c.auditBroker = nil
 	return nil
@@ -433,6 +457,40 @@ func (c *Core) removeAuditReloadFunc(entry *MountEntry) {
 	}
 }
 
+// parseAuditDeviceOptions reads the options controlling which entries the
+// broker hands to an audit device, and how
+func parseAuditDeviceOptions(conf map[string]string) (auditDeviceOptions, error) {
+	var opts auditDeviceOptions
+	var err error
+
+	opts.filter, err = audit.ParseFilter(conf["filter"])
+	if err != nil {
+		return opts, errwrap.Wrapf("invalid audit filter: {{err}}", err)
+	}
+
+	if raw, ok := conf["fallback"]; ok {
+		opts.fallback, err = strconv.ParseBool(raw)
+		if err != nil {
+			return opts, errwrap.Wrapf("invalid value for fallback: {{err}}", err)
+		}
+	}
+	if raw, ok := conf["non_blocking"]; ok {
+		opts.nonBlocking, err = strconv.ParseBool(raw)
+		if err != nil {
+			return opts, errwrap.Wrapf("invalid value for non_blocking: {{err}}", err)
+		}
+	}
+
+	switch {
+	case opts.fallback && opts.filter != nil:
+		return opts, errors.New("a fallback audit device cannot have a filter")
+	case opts.fallback && opts.nonBlocking:
+		return opts, errors.New("a fallback audit device cannot be non-blocking")
+	}
+
+	return opts, nil
+}
+
 // newAuditBackend is used to create and configure a new audit backend by name
 func (c *Core) newAuditBackend(ctx context.Context, entry *MountEntry, view logical.Storage, conf map[string]string) (audit.Backend, error) {
 	f, ok := c.auditBackends[entry.Type]
@@ -10,12 +10,38 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/vault/audit"
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mitchellh/copystructure"
 )
 
+// nonBlockingAuditQueueSize is the number of entries buffered for each
+// non-blocking audit backend before new entries are dropped
+const nonBlockingAuditQueueSize = 1024
+
+// auditShutdownTimeout bounds how long tearing down the audit devices waits
+// for non-blocking backends to write the entries already queued
+const auditShutdownTimeout = 10 * time.Second
+
+// auditDeviceOptions controls how the broker hands entries to a backend
+type auditDeviceOptions struct {
+	// filter restricts the entries the backend receives; nil matches all
+	filter *audit.Filter
+
+	// fallback backends only receive entries no other backend logged
+	fallback bool
+
+	// nonBlocking backends are written to asynchronously and their
+	// failures never fail a request
+	nonBlocking bool
+}
+
 type backendEntry struct {
 	backend audit.Backend
 	view    *BarrierView
 	local   bool
+	opts    auditDeviceOptions
+	queue   chan func()
 }
 
 // AuditBroker is used to provide a single ingest interface to auditable
@@ -24,6 +50,14 @@ type AuditBroker struct {
 	sync.RWMutex
 	backends map[string]backendEntry
 	logger   log.Logger
+
+	// workers tracks the goroutines writing to non-blocking backends
+	workers sync.WaitGroup
+
+	// stopCh is closed when Shutdown gives up waiting for the workers, which
+	// then discard the entries left in their queues
+	stopCh  chan struct{}
+	stopped bool
 }
 
 // NewAuditBroker creates a new audit broker
@@ -31,28 +65,96 @@ func NewAuditBroker(log log.Logger) *AuditBroker {
 	b := &AuditBroker{
 		backends: make(map[string]backendEntry),
 		logger:   log,
+		stopCh:   make(chan struct{}),
 	}
 	return b
 }
 
 // Register is used to add new audit backend to the broker
-func (a *AuditBroker) Register(name string, b audit.Backend, v *BarrierView, local bool) {
+func (a *AuditBroker) Register(name string, b audit.Backend, v *BarrierView, local bool, opts auditDeviceOptions) {
 	a.Lock()
 	defer a.Unlock()
-	a.backends[name] = backendEntry{
+	if old, ok := a.backends[name]; ok && old.queue != nil {
+		close(old.queue)
+	}
+
+	be := backendEntry{
 		backend: b,
 		view:    v,
 		local:   local,
+		opts:    opts,
 	}
+	if opts.nonBlocking {
+		be.queue = make(chan func(), nonBlockingAuditQueueSize)
+		a.workers.Add(1)
+		go func(queue chan func()) {
+			defer a.workers.Done()
+			for f := range queue {
+				select {
+				case <-a.stopCh:
+					continue
+				default:
+				}
+				f()
+			}
+		}(be.queue)
+	}
+	a.backends[name] = be
 }
 
 // Deregister is used to remove an audit backend from the broker
 func (a *AuditBroker) Deregister(name string) {
 	a.Lock()
 	defer a.Unlock()
+	if be, ok := a.backends[name]; ok && be.queue != nil {
+		close(be.queue)
+	}
 	delete(a.backends, name)
 }
 
+// Shutdown stops the workers of all non-blocking backends, waiting for them
+// to write the entries already queued until ctx is done. Entries still
+// queued at that point, and entries sent afterwards, are dropped.
+func (a *AuditBroker) Shutdown(ctx context.Context) {
+	queues := make(map[string]chan func())
+	a.Lock()
+	for name, be := range a.backends {
+		if be.queue != nil {
+			close(be.queue)
+			queues[name] = be.queue
+			be.queue = nil
+			a.backends[name] = be
+		}
+	}
+	a.Unlock()
+
+	doneCh := make(chan struct{})
+	go func() {
+		a.workers.Wait()
+		close(doneCh)
+	}()
+
+	select {
+	case <-doneCh:
+		return
+	case <-ctx.Done():
+	}
+
+	a.Lock()
+	if !a.stopped {
+		a.stopped = true
+		close(a.stopCh)
+	}
+	a.Unlock()
+
+	for name, queue := range queues {
+		if dropped := len(queue); dropped > 0 {
+			a.logger.Warn("timed out writing to non-blocking backend, dropping queued entries", "backend", name, "dropped", dropped)
+			metrics.IncrCounter([]string{"audit", name, "dropped"}, float32(dropped))
+		}
+	}
+}
+
 // IsRegistered is used to check if a given audit backend is registered
 func (a *AuditBroker) IsRegistered(name string) bool {
 	a.RLock()
@@ -61,6 +163,19 @@ func (a *AuditBroker) IsRegistered(name string) bool {
 	return ok
 }
 
+// FallbackName returns the name of the registered fallback backend, or ""
+// if there is none
+func (a *AuditBroker) FallbackName() string {
+	a.RLock()
+	defer a.RUnlock()
+	for name, be := range a.backends {
+		if be.opts.fallback {
+			return name
+		}
+	}
+	return ""
+}
+
 // IsLocal is used to check if a given audit backend is registered
 func (a *AuditBroker) IsLocal(name string) (bool, error) {
 	a.RLock()
@@ -119,14 +234,13 @@ func (a *AuditBroker) LogRequest(ctx context.Context, in *audit.LogInput, header
 		in.Request.Headers = headers
 	}()
 
-	// Ensure at least one backend logs
-	anyLogged := false
-	for name, be := range a.backends {
+	logTo := func(name string, be backendEntry) bool {
 		in.Request.Headers = nil
 		transHeaders, thErr := headersConfig.ApplyConfig(ctx, headers, be.backend.GetHash)
 		if thErr != nil {
 			a.logger.Error("backend failed to include headers", "backend", name, "error", thErr)
-			continue
+			metrics.IncrCounter([]string{"audit", name, "log_request_failure"}, 1)
+			return false
 		}
 		in.Request.Headers = transHeaders
 
@@ -135,11 +249,49 @@ func (a *AuditBroker) LogRequest(ctx context.Context, in *audit.LogInput, header
 		metrics.MeasureSince([]string{"audit", name, "log_request"}, start)
 		if lrErr != nil {
 			a.logger.Error("backend failed to log request", "backend", name, "error", lrErr)
-		} else {
+			metrics.IncrCounter([]string{"audit", name, "log_request_failure"}, 1)
+			return false
+		}
+		return true
+	}
+
+	// Ensure at least one backend whose filter matches logs
+	anyLogged := false
+	anyMatched := false
+	primaries := 0
+	fallback := ""
+	for name, be := range a.backends {
+		if be.opts.fallback {
+			fallback = name
+			continue
+		}
+		if be.opts.nonBlocking {
+			if be.opts.filter.Match(ctx, in) {
+				a.logAsync(ctx, name, be, in, headers, headersConfig, false)
+			}
+			continue
+		}
+		primaries++
+		if !be.opts.filter.Match(ctx, in) {
+			continue
+		}
+		anyMatched = true
+
+		if logTo(name, be) {
 			anyLogged = true
 		}
 	}
-	if !anyLogged && len(a.backends) > 0 {
+
+	// The fallback backend only sees entries that would otherwise have
+	// failed the request
+	if !anyLogged && fallback != "" && (anyMatched || primaries == 0) {
+		if primaries > 0 {
+			a.logger.Warn("no audit backend succeeded in logging the request, using fallback", "backend", fallback)
+		}
+		anyMatched = true
+		anyLogged = logTo(fallback, a.backends[fallback])
+	}
+	if !anyLogged && anyMatched {
 		retErr = multierror.Append(retErr, fmt.Errorf("no audit backend succeeded in logging the request"))
 	}
 
@@ -175,14 +327,13 @@ func (a *AuditBroker) LogResponse(ctx context.Context, in *audit.LogInput, heade
 		in.Request.Headers = headers
 	}()
 
-	// Ensure at least one backend logs
-	anyLogged := false
-	for name, be := range a.backends {
+	logTo := func(name string, be backendEntry) bool {
 		in.Request.Headers = nil
 		transHeaders, thErr := headersConfig.ApplyConfig(ctx, headers, be.backend.GetHash)
 		if thErr != nil {
 			a.logger.Error("backend failed to include headers", "backend", name, "error", thErr)
-			continue
+			metrics.IncrCounter([]string{"audit", name, "log_response_failure"}, 1)
+			return false
 		}
 		in.Request.Headers = transHeaders
 
@@ -191,17 +342,137 @@ func (a *AuditBroker) LogResponse(ctx context.Context, in *audit.LogInput, heade
 		metrics.MeasureSince([]string{"audit", name, "log_response"}, start)
 		if lrErr != nil {
 			a.logger.Error("backend failed to log response", "backend", name, "error", lrErr)
-		} else {
+			metrics.IncrCounter([]string{"audit", name, "log_response_failure"}, 1)
+			return false
+		}
+		return true
+	}
+
+	// Ensure at least one backend whose filter matches logs
+	anyLogged := false
+	anyMatched := false
+	primaries := 0
+	fallback := ""
+	for name, be := range a.backends {
+		if be.opts.fallback {
+			fallback = name
+			continue
+		}
+		if be.opts.nonBlocking {
+			if be.opts.filter.Match(ctx, in) {
+				a.logAsync(ctx, name, be, in, headers, headersConfig, true)
+			}
+			continue
+		}
+		primaries++
+		if !be.opts.filter.Match(ctx, in) {
+			continue
+		}
+		anyMatched = true
+
+		if logTo(name, be) {
 			anyLogged = true
 		}
 	}
-	if !anyLogged && len(a.backends) > 0 {
+
+	// The fallback backend only sees entries that would otherwise have
+	// failed the request
+	if !anyLogged && fallback != "" && (anyMatched || primaries == 0) {
+		if primaries > 0 {
+			a.logger.Warn("no audit backend succeeded in logging the response, using fallback", "backend", fallback)
+		}
+		anyMatched = true
+		anyLogged = logTo(fallback, a.backends[fallback])
+	}
+	if !anyLogged && anyMatched {
 		retErr = multierror.Append(retErr, fmt.Errorf("no audit backend succeeded in logging the response"))
 	}
 
 	return retErr.ErrorOrNil()
 }
 
+// logAsync hands a copy of the input to the queue of a non-blocking backend.
+// The entry is dropped if the backend has fallen too far behind.
+func (a *AuditBroker) logAsync(ctx context.Context, name string, be backendEntry, in *audit.LogInput, headers map[string][]string, headersConfig *AuditedHeadersConfig, response bool) {
+	metricName := "log_request"
+	if response {
+		metricName = "log_response"
+	}
+
+	in.Request.Headers = nil
+	cp, err := copyLogInput(in)
+	if err != nil {
+		a.logger.Error("failed to copy audit entry for non-blocking backend", "backend", name, "error", err)
+		metrics.IncrCounter([]string{"audit", name, metricName + "_failure"}, 1)
+		return
+	}
+	transHeaders, thErr := headersConfig.ApplyConfig(ctx, headers, be.backend.GetHash)
+	if thErr != nil {
+		a.logger.Error("backend failed to include headers", "backend", name, "error", thErr)
+		metrics.IncrCounter([]string{"audit", name, metricName + "_failure"}, 1)
+		return
+	}
+	cp.Request.Headers = transHeaders
+
+	// The entry outlives the request, so only carry over the namespace
+	logCtx := context.Background()
+	if ns, err := namespace.FromContext(ctx); err == nil {
+		logCtx = namespace.ContextWithNamespace(logCtx, ns)
+	}
+
+	write := func() {
+		start := time.Now()
+		var lrErr error
+		if response {
+			lrErr = be.backend.LogResponse(logCtx, cp)
+		} else {
+			lrErr = be.backend.LogRequest(logCtx, cp)
+		}
+		metrics.MeasureSince([]string{"audit", name, metricName}, start)
+		if lrErr != nil {
+			a.logger.Error("non-blocking backend failed to log entry", "backend", name, "operation", metricName, "error", lrErr)
+			metrics.IncrCounter([]string{"audit", name, metricName + "_failure"}, 1)
+		}
+	}
+
+	select {
+	case be.queue <- write:
+	default:
+		a.logger.Warn("non-blocking backend queue is full, dropping entry", "backend", name)
+		metrics.IncrCounter([]string{"audit", name, "dropped"}, 1)
+	}
+}
+
+// copyLogInput deep copies the parts of the input that may be modified once
+// the request completes
+func copyLogInput(in *audit.LogInput) (*audit.LogInput, error) {
+	cp := *in
+
+	if in.Auth != nil {
+		raw, err := copystructure.Copy(in.Auth)
+		if err != nil {
+			return nil, err
+		}
+		cp.Auth = raw.(*logical.Auth)
+	}
+
+	raw, err := copystructure.Copy(in.Request)
+	if err != nil {
+		return nil, err
+	}
+	cp.Request = raw.(*logical.Request)
+
+	if in.Response != nil {
+		raw, err := copystructure.Copy(in.Response)
+		if err != nil {
+			return nil, err
+		}
+		cp.Response = raw.(*logical.Response)
+	}
+
+	return &cp, nil
+}
+
 func (a *AuditBroker) Invalidate(ctx context.Context, key string) {
 	// For now we ignore the key as this would only apply to salts. We just
 	// sort of brute force it on each one.
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -441,8 +442,8 @@ func TestAuditBroker_LogRequest(t *testing.T) {
 	b := NewAuditBroker(l)
 	a1 := &NoopAudit{}
 	a2 := &NoopAudit{}
-	b.Register("foo", a1, nil, false)
-	b.Register("bar", a2, nil, false)
+	b.Register("foo", a1, nil, false, auditDeviceOptions{})
+	b.Register("bar", a2, nil, false, auditDeviceOptions{})
 
 	auth := &logical.Auth{
 		ClientToken: "foo",
@@ -527,8 +528,8 @@ func TestAuditBroker_LogResponse(t *testing.T) {
 	b := NewAuditBroker(l)
 	a1 := &NoopAudit{}
 	a2 := &NoopAudit{}
-	b.Register("foo", a1, nil, false)
-	b.Register("bar", a2, nil, false)
+	b.Register("foo", a1, nil, false, auditDeviceOptions{})
+	b.Register("bar", a2, nil, false, auditDeviceOptions{})
 
 	auth := &logical.Auth{
 		NumUses:     10,
@@ -633,8 +634,8 @@ func TestAuditBroker_AuditHeaders(t *testing.T) {
 	view := NewBarrierView(barrier, "headers/")
 	a1 := &NoopAudit{}
 	a2 := &NoopAudit{}
-	b.Register("foo", a1, nil, false)
-	b.Register("bar", a2, nil, false)
+	b.Register("foo", a1, nil, false, auditDeviceOptions{})
+	b.Register("bar", a2, nil, false, auditDeviceOptions{})
 
 	auth := &logical.Auth{
 		ClientToken: "foo",
@@ -708,3 +709,177 @@ func TestAuditBroker_AuditHeaders(t *testing.T) {
 		t.Fatalf("err: %v", err)
 	}
 }
+
+func TestAuditBroker_FilterFallback(t *testing.T) {
+	l := logging.NewVaultLogger(log.Trace)
+	b := NewAuditBroker(l)
+	filter, err := audit.ParseFilter("path == sys/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a1 := &NoopAudit{}
+	a2 := &NoopAudit{}
+	fb := &NoopAudit{}
+	b.Register("foo", a1, nil, false, auditDeviceOptions{filter: filter})
+	b.Register("bar", a2, nil, false, auditDeviceOptions{})
+	b.Register("fallback", fb, nil, false, auditDeviceOptions{fallback: true})
+
+	if name := b.FallbackName(); name != "fallback" {
+		t.Fatalf("bad: %q", name)
+	}
+
+	headersConf := &AuditedHeadersConfig{
+		Headers: make(map[string]*auditedHeaderSettings),
+	}
+	logInput := &audit.LogInput{
+		Request: &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "secret/foo",
+		},
+	}
+
+	// Only the unfiltered backend should see the request
+	if err := b.LogRequest(context.Background(), logInput, headersConf); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(a1.Req) != 0 || len(a2.Req) != 1 || len(fb.Req) != 0 {
+		t.Fatalf("bad: %d %d %d", len(a1.Req), len(a2.Req), len(fb.Req))
+	}
+
+	// The fallback should pick up the request once the matching backend fails
+	a2.ReqErr = fmt.Errorf("failed")
+	if err := b.LogRequest(context.Background(), logInput, headersConf); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(fb.Req) != 1 {
+		t.Fatalf("bad: %d", len(fb.Req))
+	}
+
+	// Failing the fallback too should fail the request
+	fb.ReqErr = fmt.Errorf("failed")
+	if err := b.LogRequest(context.Background(), logInput, headersConf); !errwrap.Contains(err, "no audit backend succeeded in logging the request") {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+// blockingAudit blocks logging requests until unblock is closed
+type blockingAudit struct {
+	NoopAudit
+	unblock chan struct{}
+	calls   int32
+}
+
+func (b *blockingAudit) LogRequest(ctx context.Context, in *audit.LogInput) error {
+	atomic.AddInt32(&b.calls, 1)
+	<-b.unblock
+	return nil
+}
+
+func TestAuditBroker_NonBlockingShutdownTimeout(t *testing.T) {
+	l := logging.NewVaultLogger(log.Trace)
+	b := NewAuditBroker(l)
+	ba := &blockingAudit{unblock: make(chan struct{})}
+	b.Register("foo", &NoopAudit{}, nil, false, auditDeviceOptions{})
+	b.Register("bar", ba, nil, false, auditDeviceOptions{nonBlocking: true})
+
+	headersConf := &AuditedHeadersConfig{
+		Headers: make(map[string]*auditedHeaderSettings),
+	}
+	logInput := &audit.LogInput{
+		Request: &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "sys/mounts",
+		},
+	}
+	for i := 0; i < 3; i++ {
+		if err := b.LogRequest(context.Background(), logInput, headersConf); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+
+	// Shutdown returns once its context is done even though the backend is
+	// stuck
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	doneCh := make(chan struct{})
+	go func() {
+		b.Shutdown(ctx)
+		close(doneCh)
+	}()
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not time out")
+	}
+
+	// The entries left in the queue are dropped rather than written
+	close(ba.unblock)
+	b.workers.Wait()
+	if calls := atomic.LoadInt32(&ba.calls); calls != 1 {
+		t.Fatalf("expected 1 write, got %d", calls)
+	}
+}
+
+func TestAuditBroker_NonBlocking(t *testing.T) {
+	l := logging.NewVaultLogger(log.Trace)
+	b := NewAuditBroker(l)
+	a1 := &NoopAudit{}
+	nb := &NoopAudit{
+		ReqErr: fmt.Errorf("failed"),
+	}
+	b.Register("foo", a1, nil, false, auditDeviceOptions{})
+	b.Register("bar", nb, nil, false, auditDeviceOptions{nonBlocking: true})
+
+	headersConf := &AuditedHeadersConfig{
+		Headers: make(map[string]*auditedHeaderSettings),
+	}
+	logInput := &audit.LogInput{
+		Request: &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "sys/mounts",
+		},
+	}
+
+	// A failing non-blocking backend should not fail the request
+	if err := b.LogRequest(context.Background(), logInput, headersConf); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Nor should it count towards the backends that logged the request
+	a1.ReqErr = fmt.Errorf("failed")
+	if err := b.LogRequest(context.Background(), logInput, headersConf); !errwrap.Contains(err, "no audit backend succeeded in logging the request") {
+		t.Fatalf("err: %v", err)
+	}
+
+	logInput.Response = &logical.Response{
+		Data: map[string]interface{}{
+			"foo": "bar",
+		},
+	}
+	if err := b.LogResponse(context.Background(), logInput, headersConf); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Shutdown waits for the queued entries to be written
+	b.Shutdown(context.Background())
+
+	if len(nb.Req) != 2 {
+		t.Fatalf("expected 2 requests logged by the non-blocking backend, got %d", len(nb.Req))
+	}
+	for _, req := range nb.Req {
+		if req.Path != "sys/mounts" || req.Operation != logical.ReadOperation {
+			t.Fatalf("bad request: %#v", req)
+		}
+	}
+	if len(nb.Resp) != 1 || nb.Resp[0].Data["foo"] != "bar" {
+		t.Fatalf("bad responses: %#v", nb.Resp)
+	}
+
+	// Entries are dropped once the broker is shut down
+	if err := b.LogResponse(context.Background(), logInput, headersConf); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(nb.Resp) != 1 {
+		t.Fatalf("expected no responses logged after shutdown, got %d", len(nb.Resp))
+	}
+}
@@ -184,6 +184,16 @@ func (c *Core) enableCredentialInternal(ctx context.Context, entry *MountEntry,
 		return err
 	}
 
+	if !nilMount {
+		// Restore the original read-only state so that the backend can
+		// write to its view during initialization, and use the active
+		// context since the backend may outlive this request
+		view.setReadOnlyErr(origViewReadOnlyErr)
+		if err := backend.Initialize(c.activeContext, &logical.InitializationRequest{Storage: view}); err != nil {
+			return err
+		}
+	}
+
 	if c.logger.IsInfo() {
 		c.logger.Info("enabled credential backend", "path", entry.Path, "type", entry.Type)
 	}
@@ -284,6 +294,12 @@ func (c *Core) disableCredentialInternal(ctx context.Context, path string, updat
 		return err
 	}
 
+	if c.rollback != nil {
+		if err := c.rollback.forgetPath(ctx, path); err != nil {
+			return err
+		}
+	}
+
 	removePathCheckers(c, entry, viewPath)
 
 	if c.logger.IsInfo() {
@@ -644,6 +660,16 @@ func (c *Core) setupCredentials(ctx context.Context) error {
 			c.logger.Info("successfully enabled credential backend", "type", entry.Type, "path", entry.Path)
 		}
 
+		// Initialize the backend once the view is writable again
+		if backend != nil {
+			initBackend, initPath := backend, entry.Path
+			c.postUnsealFuncs = append(c.postUnsealFuncs, func() {
+				if err := initBackend.Initialize(ctx, &logical.InitializationRequest{Storage: view}); err != nil {
+					c.logger.Error("failed to initialize auth entry", "path", initPath, "error", err)
+				}
+			})
+		}
+
 		// Ensure the path is tainted if set in the mount table
 		if entry.Tainted {
 			c.router.Taint(ctx, path)
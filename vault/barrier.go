@@ -132,6 +132,9 @@ type SecurityBarrier interface {
 	// SecurityBarrier must provide the storage APIs
 	logical.Storage
 
+	// SecurityBarrier must be able to list keys a page at a time
+	logical.PaginatedStorage
+
 	// SecurityBarrier must provide the encryption APIs
 	BarrierEncryptor
 }
@@ -1,6 +1,7 @@
 package vault
 
 import (
+	"compress/gzip"
 	"context"
 	"crypto/aes"
 	"crypto/cipher"
@@ -9,12 +10,14 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
 	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/helper/compressutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	"github.com/hashicorp/vault/sdk/helper/strutil"
 	"github.com/hashicorp/vault/sdk/logical"
@@ -34,8 +37,16 @@ const (
 const (
 	AESGCMVersion1 = 0x1
 	AESGCMVersion2 = 0x2
+
+	// AESGCMVersion3 is AESGCMVersion2 with a plaintext compressed by
+	// compressutil, whose canary byte identifies the compression type
+	AESGCMVersion3 = 0x3
 )
 
+// DefaultCompressionThreshold is the size in bytes above which entries are
+// compressed, if compression is enabled and no threshold is specified
+const DefaultCompressionThreshold = 16 * 1024
+
 // barrierInit is the JSON encoded value stored
 type barrierInit struct {
 	Version int    // Version is the current format version
@@ -69,6 +80,15 @@ type AESGCMBarrier struct {
 	// future versioning of barrier implementations. It's var instead
 	// of const to allow for testing
 	currentAESGCMVersionByte byte
+
+	// maxEntrySize is the largest encrypted value that Put will write to the
+	// physical backend, or zero for no limit
+	maxEntrySize int
+
+	// compressionConfig, if set, is used to compress values written by Put
+	// that are larger than compressionThreshold before they are encrypted
+	compressionConfig    *compressutil.CompressionConfig
+	compressionThreshold int
 }
 
 // NewAESGCMBarrier is used to construct a new barrier that uses
@@ -692,6 +712,29 @@ func (b *AESGCMBarrier) updateMasterKeyCommon(key []byte) (*Keyring, error) {
 	return b.keyring.SetMasterKey(key), nil
 }
 
+// setCompression enables compression of entries larger than threshold using
+// the given compression type. An empty type disables compression.
+func (b *AESGCMBarrier) setCompression(compressionType string, threshold int) error {
+	switch compressionType {
+	case "":
+		b.compressionConfig = nil
+		return nil
+	case compressutil.CompressionTypeSnappy, compressutil.CompressionTypeGzip, compressutil.CompressionTypeLZW, compressutil.CompressionTypeLZ4:
+	default:
+		return fmt.Errorf("unsupported storage compression type %q", compressionType)
+	}
+
+	if threshold <= 0 {
+		threshold = DefaultCompressionThreshold
+	}
+	b.compressionConfig = &compressutil.CompressionConfig{
+		Type:                 compressionType,
+		GzipCompressionLevel: gzip.DefaultCompression,
+	}
+	b.compressionThreshold = threshold
+	return nil
+}
+
 // Put is used to insert or update an entry
 func (b *AESGCMBarrier) Put(ctx context.Context, entry *logical.StorageEntry) error {
 	defer metrics.MeasureSince([]string{"barrier", "put"}, time.Now())
@@ -708,10 +751,26 @@ func (b *AESGCMBarrier) Put(ctx context.Context, entry *logical.StorageEntry) er
 		return err
 	}
 
-	value, err := b.encrypt(entry.Key, term, primary, entry.Value)
+	// Compress large values, keeping the original if compression does not
+	// make it any smaller
+	plain, version := entry.Value, b.currentAESGCMVersionByte
+	if b.compressionConfig != nil && len(plain) > b.compressionThreshold {
+		compressed, err := compressutil.Compress(plain, b.compressionConfig)
+		if err != nil {
+			return errwrap.Wrapf("failed to compress entry: {{err}}", err)
+		}
+		if len(compressed) < len(plain) {
+			plain, version = compressed, AESGCMVersion3
+		}
+	}
+
+	value, err := b.encryptVersion(entry.Key, term, primary, version, plain)
 	if err != nil {
 		return err
 	}
+	if b.maxEntrySize > 0 && len(value) > b.maxEntrySize {
+		return logical.CodedError(http.StatusRequestEntityTooLarge, fmt.Sprintf("storage entry %q is %d bytes after encryption, which exceeds the maximum entry size of %d bytes", entry.Key, len(value), b.maxEntrySize))
+	}
 	pe := &physical.Entry{
 		Key:      entry.Key,
 		Value:    value,
@@ -817,6 +876,20 @@ func (b *AESGCMBarrier) List(ctx context.Context, prefix string) ([]string, erro
 	return b.backend.List(ctx, prefix)
 }
 
+// ListPage is used to list a page of the keys under a prefix, up to the
+// next prefix, that sort after the given key.
+func (b *AESGCMBarrier) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	defer metrics.MeasureSince([]string{"barrier", "list_page"}, time.Now())
+	b.l.RLock()
+	sealed := b.sealed
+	b.l.RUnlock()
+	if sealed {
+		return nil, ErrBarrierSealed
+	}
+
+	return physical.ListPage(ctx, b.backend, prefix, after, limit)
+}
+
 // aeadForTerm returns the AES-GCM AEAD for the given term
 func (b *AESGCMBarrier) aeadForTerm(term uint32) (cipher.AEAD, error) {
 	// Check for the keyring
@@ -870,6 +943,12 @@ func (b *AESGCMBarrier) aeadFromKey(key []byte) (cipher.AEAD, error) {
 
 // encrypt is used to encrypt a value
 func (b *AESGCMBarrier) encrypt(path string, term uint32, gcm cipher.AEAD, plain []byte) ([]byte, error) {
+	return b.encryptVersion(path, term, gcm, b.currentAESGCMVersionByte, plain)
+}
+
+// encryptVersion is used to encrypt a value using the given version of the
+// storage methodology
+func (b *AESGCMBarrier) encryptVersion(path string, term uint32, gcm cipher.AEAD, version byte, plain []byte) ([]byte, error) {
 	// Allocate the output buffer with room for tern, version byte,
 	// nonce, GCM tag and the plaintext
 	capacity := termSize + 1 + gcm.NonceSize() + gcm.Overhead() + len(plain)
@@ -880,7 +959,7 @@ func (b *AESGCMBarrier) encrypt(path string, term uint32, gcm cipher.AEAD, plain
 	binary.BigEndian.PutUint32(out[:4], term)
 
 	// Set the version byte
-	out[4] = b.currentAESGCMVersionByte
+	out[4] = version
 
 	// Generate a random nonce
 	nonce := out[5 : 5+gcm.NonceSize()]
@@ -893,10 +972,10 @@ func (b *AESGCMBarrier) encrypt(path string, term uint32, gcm cipher.AEAD, plain
 	}
 
 	// Seal the output
-	switch b.currentAESGCMVersionByte {
+	switch version {
 	case AESGCMVersion1:
 		out = gcm.Seal(out, nonce, plain, nil)
-	case AESGCMVersion2:
+	case AESGCMVersion2, AESGCMVersion3:
 		aad := []byte(nil)
 		if path != "" {
 			aad = []byte(path)
@@ -926,6 +1005,23 @@ func (b *AESGCMBarrier) decrypt(path string, gcm cipher.AEAD, cipher []byte) ([]
 			aad = []byte(path)
 		}
 		return gcm.Open(out, nonce, raw, aad)
+	case AESGCMVersion3:
+		aad := []byte(nil)
+		if path != "" {
+			aad = []byte(path)
+		}
+		compressed, err := gcm.Open(out, nonce, raw, aad)
+		if err != nil {
+			return nil, err
+		}
+		plain, notCompressed, err := compressutil.Decompress(compressed)
+		if err != nil {
+			return nil, errwrap.Wrapf("failed to decompress entry: {{err}}", err)
+		}
+		if notCompressed {
+			return nil, fmt.Errorf("compressed entry is missing its compression type")
+		}
+		return plain, nil
 	default:
 		return nil, fmt.Errorf("version bytes mis-match")
 	}
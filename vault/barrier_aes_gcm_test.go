@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
 
 	log "github.com/hashicorp/go-hclog"
@@ -360,6 +362,148 @@ func TestAESGCMBarrier_MoveIntegrityV2(t *testing.T) {
 	}
 }
 
+func TestAESGCMBarrier_MaxEntrySize(t *testing.T) {
+	inm, err := inmem.NewInmem(nil, logger)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	b, err := NewAESGCMBarrier(inm)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	b.maxEntrySize = 128
+
+	// Initialize and unseal
+	key, _ := b.GenerateKey()
+	err = b.Initialize(context.Background(), key)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	err = b.Unseal(context.Background(), key)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// An entry that fits after encryption is written
+	entry := &logical.StorageEntry{Key: "small", Value: make([]byte, 64)}
+	err = b.Put(context.Background(), entry)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// An entry that does not fit is rejected before reaching storage
+	entry = &logical.StorageEntry{Key: "large", Value: make([]byte, 128)}
+	err = b.Put(context.Background(), entry)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), `"large"`) || !strings.Contains(err.Error(), "161 bytes") {
+		t.Fatalf("expected error naming the path and size, got: %v", err)
+	}
+	if coded, ok := err.(logical.HTTPCodedError); !ok || coded.Code() != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected coded error, got: %#v", err)
+	}
+	pe, err := inm.Get(context.Background(), "large")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if pe != nil {
+		t.Fatalf("entry should not have been written")
+	}
+}
+
+func TestAESGCMBarrier_Compression(t *testing.T) {
+	inm, err := inmem.NewInmem(nil, logger)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	b, err := NewAESGCMBarrier(inm)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if err := b.setCompression("bogus", 0); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	// Initialize and unseal
+	key, _ := b.GenerateKey()
+	err = b.Initialize(context.Background(), key)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	err = b.Unseal(context.Background(), key)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Write an uncompressed entry before enabling compression
+	large := bytes.Repeat([]byte(`{"key":"value"}`), 1024)
+	err = b.Put(context.Background(), &logical.StorageEntry{Key: "before", Value: large})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for _, compressionType := range []string{"snappy", "gzip", "lzw", "lz4"} {
+		if err := b.setCompression(compressionType, 1024); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		for _, tc := range []struct {
+			key        string
+			value      []byte
+			compressed bool
+		}{
+			{"small", []byte(`{"key":"value"}`), false},
+			{"large", large, true},
+		} {
+			err = b.Put(context.Background(), &logical.StorageEntry{Key: tc.key, Value: tc.value})
+			if err != nil {
+				t.Fatalf("%s: err: %v", compressionType, err)
+			}
+
+			pe, err := inm.Get(context.Background(), tc.key)
+			if err != nil {
+				t.Fatalf("%s: err: %v", compressionType, err)
+			}
+			if compressed := pe.Value[4] == AESGCMVersion3; compressed != tc.compressed {
+				t.Fatalf("%s: %s: expected compressed %t", compressionType, tc.key, tc.compressed)
+			}
+			if tc.compressed && len(pe.Value) >= len(tc.value) {
+				t.Fatalf("%s: entry was not compressed: %d bytes", compressionType, len(pe.Value))
+			}
+
+			out, err := b.Get(context.Background(), tc.key)
+			if err != nil {
+				t.Fatalf("%s: err: %v", compressionType, err)
+			}
+			if !bytes.Equal(out.Value, tc.value) {
+				t.Fatalf("%s: %s: value mismatch", compressionType, tc.key)
+			}
+		}
+	}
+
+	// Entries written before compression was enabled are still readable
+	out, err := b.Get(context.Background(), "before")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !bytes.Equal(out.Value, large) {
+		t.Fatalf("value mismatch")
+	}
+
+	// Compressed entries are bound to their path like any other entry
+	pe, _ := inm.Get(context.Background(), "large")
+	pe.Key = "moved"
+	err = inm.Put(context.Background(), pe)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	_, err = b.Get(context.Background(), "moved")
+	if err == nil {
+		t.Fatalf("should fail with compressed entry at a different path")
+	}
+}
+
 func TestAESGCMBarrier_UpgradeV1toV2(t *testing.T) {
 	inm, err := inmem.NewInmem(nil, logger)
 	if err != nil {
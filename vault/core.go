@@ -275,6 +275,9 @@ type Core struct {
 	// rollback manager is used to run rollbacks periodically
 	rollback *RollbackManager
 
+	// rollbackPeriod is how often the rollback manager triggers rollbacks
+	rollbackPeriod time.Duration
+
 	// policy store is used to manage named ACL policies
 	policyStore *PolicyStore
 
@@ -304,6 +307,15 @@ type Core struct {
 	// Cache stores the actual cache; we always have this but may bypass it if
 	// disabled
 	physicalCache physical.ToggleablePurgemonster
+	// physicalCacheSize is the number of entries the cache can hold
+	physicalCacheSize int
+
+	// storageType and storagePaginated describe the storage backend for
+	// sys/storage/status, and lastStorageWrite is the time of the last
+	// successful write to it in Unix nanoseconds
+	storageType      string
+	storagePaginated bool
+	lastStorageWrite *int64
 
 	// reloadFuncs is a map containing reload functions
 	reloadFuncs map[string][]reload.ReloadFunc
@@ -380,6 +392,17 @@ type Core struct {
 	// pluginCatalog is used to manage plugin configurations
 	pluginCatalog *PluginCatalog
 
+	// userLockouts tracks failed logins to lock out users of auth methods
+	// that are subject to brute forcing
+	userLockouts *userLockouts
+
+	// rateLimitQuotas holds the rate limit quotas enforced on requests
+	rateLimitQuotas *rateLimitQuotas
+
+	// mfaUsedPasscodes tracks TOTP passcodes that were used for MFA so they
+	// cannot be replayed while still valid
+	mfaUsedPasscodes *cache.Cache
+
 	enableMlock bool
 
 	// This can be used to trigger operations to stop running when Vault is
@@ -459,6 +482,19 @@ type CoreConfig struct {
 	// Custom cache size for the LRU cache on the physical backend, or zero for default
 	CacheSize int `json:"cache_size" structs:"cache_size" mapstructure:"cache_size"`
 
+	// The type of the storage backend and whether it lists keys a page at a
+	// time natively, as reported by sys/storage/status
+	StorageType      string `json:"storage_type" structs:"storage_type" mapstructure:"storage_type"`
+	StoragePaginated bool   `json:"storage_paginated" structs:"storage_paginated" mapstructure:"storage_paginated"`
+
+	// Maximum size in bytes of an encrypted storage entry, or zero for no limit
+	MaxEntrySize int `json:"max_entry_size" structs:"max_entry_size" mapstructure:"max_entry_size"`
+
+	// Compression type for large storage entries, or empty to disable
+	// compression, and the size in bytes above which entries are compressed
+	StorageCompressionType      string `json:"storage_compression_type" structs:"storage_compression_type" mapstructure:"storage_compression_type"`
+	StorageCompressionThreshold int    `json:"storage_compression_threshold" structs:"storage_compression_threshold" mapstructure:"storage_compression_threshold"`
+
 	// Set as the leader address for HA
 	RedirectAddr string `json:"redirect_addr" structs:"redirect_addr" mapstructure:"redirect_addr"`
 
@@ -500,6 +536,10 @@ type CoreConfig struct {
 	MetricsHelper *metricsutil.MetricsHelper
 
 	CounterSyncInterval time.Duration
+
+	// RollbackPeriod overrides how often the rollback manager runs, or zero
+	// for the default
+	RollbackPeriod time.Duration
 }
 
 func (c *CoreConfig) Clone() *CoreConfig {
@@ -533,6 +573,7 @@ func (c *CoreConfig) Clone() *CoreConfig {
 		DisableIndexing:           c.DisableIndexing,
 		AllLoggers:                c.AllLoggers,
 		CounterSyncInterval:       c.CounterSyncInterval,
+		RollbackPeriod:            c.RollbackPeriod,
 	}
 }
 
@@ -576,6 +617,11 @@ func NewCore(conf *CoreConfig) (*Core, error) {
 		syncInterval = 30 * time.Second
 	}
 
+	rollbackPeriod := conf.RollbackPeriod
+	if rollbackPeriod == 0 {
+		rollbackPeriod = defaultRollbackPeriod
+	}
+
 	// Setup the core
 	c := &Core{
 		entCore:                      entCore{},
@@ -592,6 +638,10 @@ func NewCore(conf *CoreConfig) (*Core, error) {
 		defaultLeaseTTL:              conf.DefaultLeaseTTL,
 		maxLeaseTTL:                  conf.MaxLeaseTTL,
 		cachingDisabled:              conf.DisableCache,
+		physicalCacheSize:            conf.CacheSize,
+		storageType:                  conf.StorageType,
+		storagePaginated:             conf.StoragePaginated,
+		lastStorageWrite:             new(int64),
 		clusterName:                  conf.ClusterName,
 		clusterPeerClusterAddrsCache: cache.New(3*cluster.HeartbeatInterval, time.Second),
 		enableMlock:                  !conf.DisableMlock,
@@ -612,6 +662,10 @@ func NewCore(conf *CoreConfig) (*Core, error) {
 		neverBecomeActive:            new(uint32),
 		clusterLeaderParams:          new(atomic.Value),
 		metricsHelper:                conf.MetricsHelper,
+		rollbackPeriod:               rollbackPeriod,
+		userLockouts:                 newUserLockouts(),
+		rateLimitQuotas:              newRateLimitQuotas(),
+		mfaUsedPasscodes:             cache.New(0, 30*time.Second),
 		counters: counters{
 			requests:     new(uint64),
 			syncInterval: syncInterval,
@@ -644,6 +698,10 @@ func NewCore(conf *CoreConfig) (*Core, error) {
 		Enabled: new(uint32),
 	}
 
+	if c.physicalCacheSize <= 0 {
+		c.physicalCacheSize = physical.DefaultCacheSize
+	}
+
 	if c.seal == nil {
 		c.seal = NewDefaultSeal()
 	}
@@ -678,11 +736,19 @@ func NewCore(conf *CoreConfig) (*Core, error) {
 		}
 	}
 
+	// Record successful writes for sys/storage/status
+	c.physical = newStorageStatusBackend(c.physical, c.lastStorageWrite)
+
 	// Construct a new AES-GCM barrier
-	c.barrier, err = NewAESGCMBarrier(c.physical)
+	barrier, err := NewAESGCMBarrier(c.physical)
 	if err != nil {
 		return nil, errwrap.Wrapf("barrier setup failed: {{err}}", err)
 	}
+	barrier.maxEntrySize = conf.MaxEntrySize
+	if err := barrier.setCompression(conf.StorageCompressionType, conf.StorageCompressionThreshold); err != nil {
+		return nil, errwrap.Wrapf("barrier setup failed: {{err}}", err)
+	}
+	c.barrier = barrier
 
 	createSecondaries(c, conf)
 
@@ -1475,6 +1541,9 @@ func (s standardUnsealStrategy) unseal(ctx context.Context, logger log.Logger, c
 	if err := c.loadCORSConfig(ctx); err != nil {
 		return err
 	}
+	if err := c.loadRateLimitQuotas(ctx); err != nil {
+		return err
+	}
 	if err := c.loadCurrentRequestCounters(ctx, time.Now()); err != nil {
 		return err
 	}
@@ -1622,6 +1691,7 @@ func (c *Core) preSeal() error {
 	if err := c.unloadMounts(context.Background()); err != nil {
 		result = multierror.Append(result, errwrap.Wrapf("error unloading mounts: {{err}}", err))
 	}
+	c.rateLimitQuotas.reset(make(map[string]*RateLimitQuota))
 	if err := enterprisePreSeal(c); err != nil {
 		result = multierror.Append(result, err)
 	}
@@ -1652,6 +1722,7 @@ func stopReplicationImpl(c *Core) error {
 func (c *Core) emitMetrics(stopCh chan struct{}) {
 	emitTimer := time.Tick(time.Second)
 	writeTimer := time.Tick(c.counters.syncInterval)
+	userLockoutPruneTimer := time.Tick(userLockoutPruneInterval)
 
 	for {
 		select {
@@ -1672,6 +1743,9 @@ func (c *Core) emitMetrics(stopCh chan struct{}) {
 				}
 			}
 
+		case <-userLockoutPruneTimer:
+			c.userLockouts.prune(time.Now())
+
 		case <-stopCh:
 			return
 		}
@@ -1694,6 +1768,12 @@ func (c *Core) Logger() log.Logger {
 	return c.logger
 }
 
+// MetricsHelper returns the helper used to serve the telemetry collected by
+// this node
+func (c *Core) MetricsHelper() *metricsutil.MetricsHelper {
+	return c.metricsHelper
+}
+
 func (c *Core) BarrierKeyLength() (min, max int) {
 	min, max = c.barrier.KeyLength()
 	max += shamir.ShareOverhead
@@ -253,3 +253,19 @@ func (d dynamicSystemView) PluginEnv(_ context.Context) (*logical.PluginEnvironm
 		VaultVersion: version.GetVersion().Version,
 	}, nil
 }
+
+func (d dynamicSystemView) GeneratePasswordFromPolicy(ctx context.Context, policyName string) (string, error) {
+	generator, err := d.core.passwordGenerator(ctx, policyName)
+	if err != nil {
+		return "", err
+	}
+	return generator.Generate(ctx, nil)
+}
+
+func (d dynamicSystemView) ValidatePasswordFromPolicy(ctx context.Context, policyName, password string) error {
+	generator, err := d.core.passwordGenerator(ctx, policyName)
+	if err != nil {
+		return err
+	}
+	return generator.Validate(password)
+}
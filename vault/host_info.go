@@ -0,0 +1,67 @@
+package vault
+
+import (
+	"os"
+	"runtime"
+	"time"
+)
+
+// processStartTime is used to report the uptime of the Vault process
+var processStartTime = time.Now()
+
+// HostInfo describes the host and the Go runtime of the node handling a
+// request, for sys/host-info
+type HostInfo struct {
+	Timestamp    time.Time
+	Hostname     string
+	CPUCount     int
+	Uptime       time.Duration
+	GoVersion    string
+	GoOS         string
+	GoArch       string
+	NumGoroutine int
+	Memory       runtime.MemStats
+	Disk         []*DiskUsage
+}
+
+// DiskUsage describes the usage of the file system holding a path
+type DiskUsage struct {
+	Path  string
+	Total uint64
+	Free  uint64
+	Used  uint64
+	Error string
+}
+
+// hostInfo collects the host information of this node. The disk usage is
+// reported for the working directory and the plugin directory, if set.
+func (c *Core) hostInfo() *HostInfo {
+	info := &HostInfo{
+		Timestamp:    time.Now().UTC(),
+		CPUCount:     runtime.NumCPU(),
+		Uptime:       time.Since(processStartTime),
+		GoVersion:    runtime.Version(),
+		GoOS:         runtime.GOOS,
+		GoArch:       runtime.GOARCH,
+		NumGoroutine: runtime.NumGoroutine(),
+	}
+	info.Hostname, _ = os.Hostname()
+	runtime.ReadMemStats(&info.Memory)
+
+	var paths []string
+	if wd, err := os.Getwd(); err == nil {
+		paths = append(paths, wd)
+	}
+	if c.pluginDirectory != "" {
+		paths = append(paths, c.pluginDirectory)
+	}
+	for _, path := range paths {
+		usage := &DiskUsage{Path: path}
+		if err := diskUsage(path, usage); err != nil {
+			usage.Error = err.Error()
+		}
+		info.Disk = append(info.Disk, usage)
+	}
+
+	return info
+}
@@ -0,0 +1,10 @@
+// +build !linux,!darwin,!freebsd
+
+package vault
+
+import "errors"
+
+// diskUsage is not supported on this platform
+func diskUsage(path string, usage *DiskUsage) error {
+	return errors.New("disk usage is not supported on this platform")
+}
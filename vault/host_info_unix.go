@@ -0,0 +1,19 @@
+// +build linux darwin freebsd
+
+package vault
+
+import "syscall"
+
+// diskUsage fills in the usage of the file system holding path
+func diskUsage(path string, usage *DiskUsage) error {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return err
+	}
+
+	blockSize := uint64(stat.Bsize)
+	usage.Total = uint64(stat.Blocks) * blockSize
+	usage.Free = uint64(stat.Bavail) * blockSize
+	usage.Used = usage.Total - uint64(stat.Bfree)*blockSize
+	return nil
+}
@@ -2,24 +2,42 @@ package vault
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/helper/parseutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
+// cubbyholeEntrySecretType is the type of the leases that remove entries
+// written with a TTL
+const cubbyholeEntrySecretType = "cubbyhole_entry"
+
 // CubbyholeBackendFactory constructs a new cubbyhole backend
 func CubbyholeBackendFactory(ctx context.Context, conf *logical.BackendConfig) (logical.Backend, error) {
-	b := &CubbyholeBackend{}
+	b := &CubbyholeBackend{
+		locks: locksutil.CreateLocks(),
+	}
 	b.Backend = &framework.Backend{
 		Help: strings.TrimSpace(cubbyholeHelp),
 	}
 
 	b.Backend.Paths = append(b.Backend.Paths, b.paths()...)
+	b.Backend.Secrets = []*framework.Secret{
+		{
+			Type:   cubbyholeEntrySecretType,
+			Revoke: b.handleEntryRevoke,
+		},
+	}
 
 	if conf == nil {
 		return nil, fmt.Errorf("configuration passed into backend is nil")
@@ -38,6 +56,9 @@ type CubbyholeBackend struct {
 
 	saltUUID    string
 	storageView logical.Storage
+
+	// locks serialize writes to a key so that patches are not lost
+	locks []*locksutil.LockEntry
 }
 
 func (b *CubbyholeBackend) paths() []*framework.Path {
@@ -68,6 +89,11 @@ func (b *CubbyholeBackend) paths() []*framework.Path {
 					Callback: b.handleDelete,
 					Summary:  "Deletes the secret at the specified location.",
 				},
+				logical.PatchOperation: &framework.PathOperation{
+					Callback:    b.handlePatch,
+					Summary:     "Update fields of the secret at the specified location.",
+					Description: "The request data is applied to the existing secret as a JSON merge patch. Fields set to null are removed.",
+				},
 				logical.ListOperation: &framework.PathOperation{
 					Callback:    b.handleList,
 					Summary:     "List secret entries at the specified location.",
@@ -145,27 +171,150 @@ func (b *CubbyholeBackend) handleWrite(ctx context.Context, req *logical.Request
 		return nil, fmt.Errorf("missing data fields")
 	}
 
+	// An optional ttl removes the entry once it elapses
+	var ttl time.Duration
+	if ttlRaw, ok := req.Data["ttl"]; ok {
+		var err error
+		ttl, err = parseutil.ParseDurationSecond(ttlRaw)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprintf("invalid ttl: %v", err)), nil
+		}
+		if ttl < 0 {
+			return logical.ErrorResponse("ttl cannot be negative"), nil
+		}
+	}
+
+	key := req.ClientToken + "/" + data.Get("path").(string)
+
+	lock := locksutil.LockForKey(b.locks, key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := b.put(ctx, req, key, req.Data); err != nil {
+		return nil, err
+	}
+
+	if ttl == 0 {
+		return nil, nil
+	}
+
+	entry, err := req.Storage.Get(ctx, key)
+	if err != nil {
+		return nil, errwrap.Wrapf("read failed: {{err}}", err)
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("entry not found after write")
+	}
+
+	// The lease records the written value so that it only removes the entry
+	// if it has not been changed since
+	resp := b.Secret(cubbyholeEntrySecretType).Response(nil, map[string]interface{}{
+		"key":  key,
+		"hash": cubbyholeEntryHash(entry.Value),
+	})
+	resp.Secret.TTL = ttl
+	resp.Secret.MaxTTL = ttl
+	resp.Secret.Renewable = false
+
+	return resp, nil
+}
+
+// handleEntryRevoke removes an entry written with a TTL when its lease
+// expires or is revoked. Revocations carry no client token, so the entry is
+// located from the internal data of the lease.
+func (b *CubbyholeBackend) handleEntryRevoke(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	key, ok := req.Secret.InternalData["key"].(string)
+	if !ok || key == "" {
+		return nil, fmt.Errorf("secret is missing key internal data")
+	}
+	hash, _ := req.Secret.InternalData["hash"].(string)
+
+	lock := locksutil.LockForKey(b.locks, key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	entry, err := req.Storage.Get(ctx, key)
+	if err != nil {
+		return nil, errwrap.Wrapf("read failed: {{err}}", err)
+	}
+	if entry == nil || cubbyholeEntryHash(entry.Value) != hash {
+		return nil, nil
+	}
+
+	if err := req.Storage.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func cubbyholeEntryHash(value []byte) string {
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:])
+}
+
+// handlePatch applies the request data to the existing secret as a JSON merge
+// patch
+func (b *CubbyholeBackend) handlePatch(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if req.ClientToken == "" {
+		return nil, fmt.Errorf("client token empty")
+	}
+
 	path := data.Get("path").(string)
+	key := req.ClientToken + "/" + path
+
+	lock := locksutil.LockForKey(b.locks, key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	out, err := req.Storage.Get(ctx, key)
+	if err != nil {
+		return nil, errwrap.Wrapf("read failed: {{err}}", err)
+	}
+	if out == nil {
+		return nil, logical.CodedError(http.StatusNotFound, "no secret found at path")
+	}
 
+	var rawData map[string]interface{}
+	if err := jsonutil.DecodeJSON(out.Value, &rawData); err != nil {
+		return nil, errwrap.Wrapf("json decoding failed: {{err}}", err)
+	}
+
+	// The path is captured from the URL and is not part of the secret
+	patch := make(map[string]interface{}, len(req.Data))
+	for k, v := range req.Data {
+		patch[k] = v
+	}
+	delete(patch, "path")
+
+	patched := jsonutil.MergePatch(rawData, patch)
+	if len(patched) == 0 {
+		return logical.ErrorResponse("patch would remove all data fields"), nil
+	}
+
+	return nil, b.put(ctx, req, key, patched)
+}
+
+func (b *CubbyholeBackend) put(ctx context.Context, req *logical.Request, key string, data map[string]interface{}) error {
 	// JSON encode the data
-	buf, err := json.Marshal(req.Data)
+	buf, err := json.Marshal(data)
 	if err != nil {
-		return nil, errwrap.Wrapf("json encoding failed: {{err}}", err)
+		return errwrap.Wrapf("json encoding failed: {{err}}", err)
 	}
 
 	// Write out a new key
 	entry := &logical.StorageEntry{
-		Key:   req.ClientToken + "/" + path,
+		Key:   key,
 		Value: buf,
 	}
 	if req.WrapInfo != nil && req.WrapInfo.SealWrap {
 		entry.SealWrap = true
 	}
 	if err := req.Storage.Put(ctx, entry); err != nil {
-		return nil, errwrap.Wrapf("failed to write: {{err}}", err)
+		return errwrap.Wrapf("failed to write: {{err}}", err)
 	}
 
-	return nil, nil
+	return nil
 }
 
 func (b *CubbyholeBackend) handleDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
@@ -173,10 +322,14 @@ func (b *CubbyholeBackend) handleDelete(ctx context.Context, req *logical.Reques
 		return nil, fmt.Errorf("client token empty")
 	}
 
-	path := data.Get("path").(string)
+	key := req.ClientToken + "/" + data.Get("path").(string)
+
+	lock := locksutil.LockForKey(b.locks, key)
+	lock.Lock()
+	defer lock.Unlock()
 
 	// Delete the key at the request path
-	if err := req.Storage.Delete(ctx, req.ClientToken+"/"+path); err != nil {
+	if err := req.Storage.Delete(ctx, key); err != nil {
 		return nil, err
 	}
 
@@ -197,7 +350,7 @@ func (b *CubbyholeBackend) handleList(ctx context.Context, req *logical.Request,
 	}
 
 	// List the keys at the prefix given by the request
-	keys, err := req.Storage.List(ctx, req.ClientToken+"/"+path)
+	keys, err := logical.ListRequestPage(ctx, req.Storage, req, req.ClientToken+"/"+path)
 	if err != nil {
 		return nil, err
 	}
@@ -224,6 +377,9 @@ sharing possible (per-token cubbyholes). This can be useful for implementing
 certain authentication workflows, as well as "scratch" areas for individual
 clients. When the token is revoked, the entire set of stored values for that
 token is also removed.
+
+Writes may include a "ttl" field, after which the entry is removed
+automatically.
 `
 
 const cubbyholeHelpSynopsis = `
@@ -237,4 +393,7 @@ encrypting it along the way.
 
 The view into the cubbyhole storage space is different for each token; it is
 a per-token cubbyhole. When the token is revoked all values are removed.
+
+If a "ttl" field is written, the entry is removed once the TTL elapses, unless
+it has been changed since. The field is stored along with the other data.
 `
@@ -2,12 +2,14 @@ package vault
 
 import (
 	"context"
+	"net/http"
 	"reflect"
 	"sort"
 	"testing"
 	"time"
 
 	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/helper/namespace"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
@@ -112,6 +114,177 @@ func TestCubbyholeBackend_Delete(t *testing.T) {
 	}
 }
 
+func TestCubbyholeBackend_Patch(t *testing.T) {
+	b := testCubbyholeBackend()
+	req := logical.TestRequest(t, logical.UpdateOperation, "foo")
+	req.Data["raw"] = "test"
+	req.Data["keep"] = "this"
+	storage := req.Storage
+	clientToken, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.ClientToken = clientToken
+
+	if _, err := b.HandleRequest(context.Background(), req); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	req = logical.TestRequest(t, logical.PatchOperation, "foo")
+	req.Storage = storage
+	req.ClientToken = clientToken
+	req.Data["raw"] = nil
+	req.Data["new"] = "value"
+	if _, err := b.HandleRequest(context.Background(), req); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "foo")
+	req.Storage = storage
+	req.ClientToken = clientToken
+	resp, err := b.HandleRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	expected := map[string]interface{}{
+		"keep": "this",
+		"new":  "value",
+	}
+	if !reflect.DeepEqual(resp.Data, expected) {
+		t.Fatalf("bad response.\n\nexpected: %#v\n\nGot: %#v", expected, resp.Data)
+	}
+
+	// Patching a missing secret fails
+	req = logical.TestRequest(t, logical.PatchOperation, "bar")
+	req.Storage = storage
+	req.ClientToken = clientToken
+	req.Data["raw"] = "test"
+	_, err = b.HandleRequest(context.Background(), req)
+	if coded, ok := err.(logical.HTTPCodedError); !ok || coded.Code() != http.StatusNotFound {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+}
+
+func TestCubbyholeBackend_TTL(t *testing.T) {
+	b := testCubbyholeBackend()
+	req := logical.TestRequest(t, logical.UpdateOperation, "foo")
+	req.Data["raw"] = "test"
+	req.Data["ttl"] = "1h"
+	storage := req.Storage
+	clientToken, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.ClientToken = clientToken
+
+	resp, err := b.HandleRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp == nil || resp.Secret == nil || resp.Secret.TTL != time.Hour || resp.Secret.Renewable {
+		t.Fatalf("bad: %#v", resp)
+	}
+	secret := resp.Secret
+
+	read := func() *logical.Response {
+		t.Helper()
+		req := logical.TestRequest(t, logical.ReadOperation, "foo")
+		req.Storage = storage
+		req.ClientToken = clientToken
+		resp, err := b.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		return resp
+	}
+	if resp := read(); resp == nil || resp.Data["raw"] != "test" {
+		t.Fatalf("bad: %#v", resp)
+	}
+
+	// Revocation carries no client token
+	req = logical.TestRequest(t, logical.RevokeOperation, "foo")
+	req.Storage = storage
+	req.Secret = secret
+	if _, err := b.HandleRequest(context.Background(), req); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp := read(); resp != nil {
+		t.Fatalf("expected entry to be removed, got: %#v", resp)
+	}
+
+	// An entry changed after being written with a TTL is kept
+	req = logical.TestRequest(t, logical.UpdateOperation, "foo")
+	req.Storage = storage
+	req.ClientToken = clientToken
+	req.Data["raw"] = "test"
+	req.Data["ttl"] = 3600
+	resp, err = b.HandleRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	secret = resp.Secret
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "foo")
+	req.Storage = storage
+	req.ClientToken = clientToken
+	req.Data["raw"] = "changed"
+	if resp, err := b.HandleRequest(context.Background(), req); err != nil || resp != nil {
+		t.Fatalf("bad: resp: %#v err: %v", resp, err)
+	}
+
+	req = logical.TestRequest(t, logical.RevokeOperation, "foo")
+	req.Storage = storage
+	req.Secret = secret
+	if _, err := b.HandleRequest(context.Background(), req); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp := read(); resp == nil || resp.Data["raw"] != "changed" {
+		t.Fatalf("expected changed entry to be kept, got: %#v", resp)
+	}
+
+	for _, ttl := range []interface{}{"bogus", "-1h"} {
+		req = logical.TestRequest(t, logical.UpdateOperation, "bar")
+		req.Storage = storage
+		req.ClientToken = clientToken
+		req.Data["raw"] = "test"
+		req.Data["ttl"] = ttl
+		resp, err := b.HandleRequest(context.Background(), req)
+		if err != nil || resp == nil || !resp.IsError() {
+			t.Fatalf("expected error for ttl %v, got resp: %#v err: %v", ttl, resp, err)
+		}
+	}
+}
+
+func TestCubbyhole_TTLExpiration(t *testing.T) {
+	c, _, root := TestCoreUnsealed(t)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "cubbyhole/foo")
+	req.ClientToken = root
+	req.Data["raw"] = "test"
+	req.Data["ttl"] = "1h"
+	resp, err := c.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: resp: %#v err: %v", resp, err)
+	}
+	if resp == nil || resp.Secret == nil || resp.Secret.LeaseID == "" {
+		t.Fatalf("expected a lease, got: %#v", resp)
+	}
+
+	if err := c.expiration.Revoke(namespace.RootContext(nil), resp.Secret.LeaseID); err != nil {
+		t.Fatal(err)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "cubbyhole/foo")
+	req.ClientToken = root
+	resp, err = c.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp != nil {
+		t.Fatalf("expected entry to be removed, got: %#v", resp)
+	}
+}
+
 func TestCubbyholeBackend_List(t *testing.T) {
 	b := testCubbyholeBackend()
 	req := logical.TestRequest(t, logical.UpdateOperation, "foo")
@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
 	"github.com/hashicorp/vault/sdk/helper/parseutil"
 	"github.com/hashicorp/vault/sdk/helper/wrapping"
 	"github.com/hashicorp/vault/sdk/logical"
@@ -31,6 +33,7 @@ func LeasedPassthroughBackendFactory(ctx context.Context, conf *logical.BackendC
 func LeaseSwitchedPassthroughBackend(ctx context.Context, conf *logical.BackendConfig, leases bool) (logical.Backend, error) {
 	var b PassthroughBackend
 	b.generateLeases = leases
+	b.locks = locksutil.CreateLocks()
 	b.Backend = &framework.Backend{
 		Help: strings.TrimSpace(passthroughHelp),
 
@@ -50,6 +53,7 @@ func LeaseSwitchedPassthroughBackend(ctx context.Context, conf *logical.BackendC
 					logical.UpdateOperation: b.handleWrite,
 					logical.DeleteOperation: b.handleDelete,
 					logical.ListOperation:   b.handleList,
+					logical.PatchOperation:  b.handlePatch,
 				},
 
 				ExistenceCheck: b.handleExistenceCheck,
@@ -85,6 +89,9 @@ func LeaseSwitchedPassthroughBackend(ctx context.Context, conf *logical.BackendC
 type PassthroughBackend struct {
 	*framework.Backend
 	generateLeases bool
+
+	// locks serialize writes to a key so that patches are not lost
+	locks []*locksutil.LockEntry
 }
 
 func (b *PassthroughBackend) handleRevoke(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
@@ -177,10 +184,50 @@ func (b *PassthroughBackend) handleWrite(ctx context.Context, req *logical.Reque
 		return logical.ErrorResponse("missing data fields"), nil
 	}
 
+	lock := locksutil.LockForKey(b.locks, req.Path)
+	lock.Lock()
+	defer lock.Unlock()
+
+	return nil, b.put(ctx, req, req.Data)
+}
+
+// handlePatch applies the request data to the existing secret as a JSON merge
+// patch
+func (b *PassthroughBackend) handlePatch(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if req.Path == "" {
+		return logical.ErrorResponse("missing path"), nil
+	}
+
+	lock := locksutil.LockForKey(b.locks, req.Path)
+	lock.Lock()
+	defer lock.Unlock()
+
+	out, err := req.Storage.Get(ctx, req.Path)
+	if err != nil {
+		return nil, errwrap.Wrapf("read failed: {{err}}", err)
+	}
+	if out == nil {
+		return nil, logical.CodedError(http.StatusNotFound, "no secret found at path")
+	}
+
+	var rawData map[string]interface{}
+	if err := jsonutil.DecodeJSON(out.Value, &rawData); err != nil {
+		return nil, errwrap.Wrapf("json decoding failed: {{err}}", err)
+	}
+
+	patched := jsonutil.MergePatch(rawData, req.Data)
+	if len(patched) == 0 {
+		return logical.ErrorResponse("patch would remove all data fields"), nil
+	}
+
+	return nil, b.put(ctx, req, patched)
+}
+
+func (b *PassthroughBackend) put(ctx context.Context, req *logical.Request, data map[string]interface{}) error {
 	// JSON encode the data
-	buf, err := json.Marshal(req.Data)
+	buf, err := json.Marshal(data)
 	if err != nil {
-		return nil, errwrap.Wrapf("json encoding failed: {{err}}", err)
+		return errwrap.Wrapf("json encoding failed: {{err}}", err)
 	}
 
 	// Write out a new key
@@ -189,13 +236,17 @@ func (b *PassthroughBackend) handleWrite(ctx context.Context, req *logical.Reque
 		Value: buf,
 	}
 	if err := req.Storage.Put(ctx, entry); err != nil {
-		return nil, errwrap.Wrapf("failed to write: {{err}}", err)
+		return errwrap.Wrapf("failed to write: {{err}}", err)
 	}
 
-	return nil, nil
+	return nil
 }
 
 func (b *PassthroughBackend) handleDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	lock := locksutil.LockForKey(b.locks, req.Path)
+	lock.Lock()
+	defer lock.Unlock()
+
 	// Delete the key at the request path
 	if err := req.Storage.Delete(ctx, req.Path); err != nil {
 		return nil, err
@@ -213,8 +264,9 @@ func (b *PassthroughBackend) handleList(ctx context.Context, req *logical.Reques
 		path = path + "/"
 	}
 
-	// List the keys at the prefix given by the request
-	keys, err := req.Storage.List(ctx, path)
+	// List the keys at the prefix given by the request, a page at a time if
+	// the request asks for one
+	keys, err := logical.ListRequestPage(ctx, req.Storage, req, path)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,8 @@ package vault
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -163,6 +165,55 @@ func TestPassthroughBackend_Delete(t *testing.T) {
 	test(b)
 }
 
+func TestPassthroughBackend_Patch(t *testing.T) {
+	test := func(b logical.Backend) {
+		req := logical.TestRequest(t, logical.PatchOperation, "foo")
+		req.Data["raw"] = "test"
+		storage := req.Storage
+
+		// Patching a missing secret fails
+		_, err := b.HandleRequest(context.Background(), req)
+		if coded, ok := err.(logical.HTTPCodedError); !ok || coded.Code() != http.StatusNotFound {
+			t.Fatalf("expected not found error, got: %v", err)
+		}
+
+		req = logical.TestRequest(t, logical.UpdateOperation, "foo")
+		req.Storage = storage
+		req.Data["raw"] = "test"
+		req.Data["other"] = map[string]interface{}{"a": "b", "c": "d"}
+		if _, err := b.HandleRequest(context.Background(), req); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		req = logical.TestRequest(t, logical.PatchOperation, "foo")
+		req.Storage = storage
+		req.Data["raw"] = nil
+		req.Data["other"] = map[string]interface{}{"c": "e"}
+		req.Data["new"] = "value"
+		if _, err := b.HandleRequest(context.Background(), req); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		req = logical.TestRequest(t, logical.ReadOperation, "foo")
+		req.Storage = storage
+		resp, err := b.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		expected := map[string]interface{}{
+			"other": map[string]interface{}{"a": "b", "c": "e"},
+			"new":   "value",
+		}
+		if !reflect.DeepEqual(resp.Data, expected) {
+			t.Fatalf("bad response.\n\nexpected: %#v\n\nGot: %#v", expected, resp.Data)
+		}
+	}
+	b := testPassthroughBackend()
+	test(b)
+	b = testPassthroughLeasedBackend()
+	test(b)
+}
+
 func TestPassthroughBackend_List(t *testing.T) {
 	test := func(b logical.Backend) {
 		req := logical.TestRequest(t, logical.UpdateOperation, "foo")
@@ -196,6 +247,41 @@ func TestPassthroughBackend_List(t *testing.T) {
 	test(b)
 }
 
+// pageOnlyStorage fails full listings, so that only paged listings succeed
+type pageOnlyStorage struct {
+	*logical.InmemStorage
+}
+
+func (s *pageOnlyStorage) List(ctx context.Context, prefix string) ([]string, error) {
+	return nil, fmt.Errorf("full listing of %q", prefix)
+}
+
+func TestPassthroughBackend_ListPage(t *testing.T) {
+	b := testPassthroughBackend()
+	storage := &pageOnlyStorage{InmemStorage: new(logical.InmemStorage)}
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		req := logical.TestRequest(t, logical.UpdateOperation, key)
+		req.Data["raw"] = "test"
+		req.Storage = storage
+		if _, err := b.HandleRequest(context.Background(), req); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+
+	req := logical.TestRequest(t, logical.ListOperation, "")
+	req.Storage = storage
+	req.Data["after"] = "a"
+	req.Data["limit"] = 2
+	resp, err := b.HandleRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !reflect.DeepEqual(resp.Data["keys"], []string{"b", "c"}) {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+}
+
 func TestPassthroughBackend_Revoke(t *testing.T) {
 	test := func(b logical.Backend) {
 		req := logical.TestRequest(t, logical.RevokeOperation, "kv")
@@ -1,6 +1,7 @@
 package vault
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"crypto/sha512"
@@ -12,6 +13,8 @@ import (
 	"hash"
 	"net/http"
 	"path/filepath"
+	"runtime/pprof"
+	"runtime/trace"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,6 +26,7 @@ import (
 	memdb "github.com/hashicorp/go-memdb"
 	uuid "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/helper/identity"
+	"github.com/hashicorp/vault/helper/identity/mfa"
 	"github.com/hashicorp/vault/helper/metricsutil"
 	"github.com/hashicorp/vault/helper/namespace"
 	"github.com/hashicorp/vault/sdk/framework"
@@ -34,6 +38,7 @@ import (
 	"github.com/hashicorp/vault/sdk/helper/wrapping"
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/mitchellh/mapstructure"
+	otplib "github.com/pquerna/otp"
 )
 
 var (
@@ -96,6 +101,7 @@ func NewSystemBackend(core *Core, logger log.Logger) *SystemBackend {
 				"replication/performance/reindex",
 				"rotate",
 				"config/cors",
+				"config/cache",
 				"config/auditing/*",
 				"config/ui/headers/*",
 				"plugins/catalog/*",
@@ -104,6 +110,8 @@ func NewSystemBackend(core *Core, logger log.Logger) *SystemBackend {
 				"leases/revoke-prefix/*",
 				"leases/revoke-force/*",
 				"leases/lookup/*",
+				"pprof",
+				"pprof/*",
 			},
 
 			Unauthenticated: []string{
@@ -154,12 +162,18 @@ func NewSystemBackend(core *Core, logger log.Logger) *SystemBackend {
 	b.Backend.Paths = append(b.Backend.Paths, b.authPaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.leasePaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.policyPaths()...)
+	b.Backend.Paths = append(b.Backend.Paths, b.lockedUsersPaths()...)
+	b.Backend.Paths = append(b.Backend.Paths, b.storagePaths()...)
+	b.Backend.Paths = append(b.Backend.Paths, b.hostInfoPath())
+	b.Backend.Paths = append(b.Backend.Paths, b.quotaPaths()...)
+	b.Backend.Paths = append(b.Backend.Paths, b.mfaPaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.wrappingPaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.toolsPaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.capabilitiesPaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.internalPaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.remountPath())
 	b.Backend.Paths = append(b.Backend.Paths, b.metricsPath())
+	b.Backend.Paths = append(b.Backend.Paths, b.pprofPaths()...)
 
 	if core.rawEnabled {
 		b.Backend.Paths = append(b.Backend.Paths, &framework.Path{
@@ -172,6 +186,14 @@ func NewSystemBackend(core *Core, logger log.Logger) *SystemBackend {
 				"value": &framework.FieldSchema{
 					Type: framework.TypeString,
 				},
+				"after": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "Only list keys that sort after this key.",
+				},
+				"limit": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "Maximum number of keys to list. Zero lists all keys.",
+				},
 			},
 
 			Operations: map[logical.Operation]framework.OperationHandler{
@@ -251,6 +273,216 @@ func (b *SystemBackend) handleCORSDelete(ctx context.Context, req *logical.Reque
 	return nil, b.Core.corsConfig.Disable(ctx)
 }
 
+// handleCacheConfigRead returns the state of the physical storage cache
+func (b *SystemBackend) handleCacheConfigRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"enabled":            b.Core.physicalCache.Enabled(),
+			"disabled_by_config": b.Core.cachingDisabled,
+			"size":               b.Core.physicalCacheSize,
+		},
+	}, nil
+}
+
+// handleCacheConfigUpdate turns the physical storage cache on or off. The
+// setting is not persisted; on the next unseal the cache is enabled again
+// unless it is disabled in the configuration file.
+func (b *SystemBackend) handleCacheConfigUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	enabledRaw, ok := d.GetOk("enabled")
+	if !ok {
+		return logical.ErrorResponse("enabled must be specified"), logical.ErrInvalidRequest
+	}
+
+	// Writes made while the cache is disabled do not update it, so it is
+	// always purged before it is turned back on
+	if enabledRaw.(bool) {
+		b.Core.physicalCache.Purge(ctx)
+		b.Core.physicalCache.SetEnabled(true)
+	} else {
+		b.Core.physicalCache.SetEnabled(false)
+		b.Core.physicalCache.Purge(ctx)
+	}
+
+	b.Core.logger.Info("physical cache toggled", "enabled", enabledRaw.(bool))
+	return nil, nil
+}
+
+// handleStorageStatus reports the health and capabilities of the storage
+// backend
+func (b *SystemBackend) handleStorageStatus(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	status := b.Core.storageStatus(ctx)
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"type":              status.Type,
+			"reachable":         status.Reachable,
+			"probe_duration_ms": status.ProbeDuration.Nanoseconds() / int64(time.Millisecond),
+			"ha_enabled":        status.HAEnabled,
+			"transactional":     status.Transactional,
+			"paginated":         status.Paginated,
+		},
+	}
+	if status.Error != "" {
+		resp.Data["error"] = status.Error
+	}
+	if !status.LastSuccessfulWrite.IsZero() {
+		resp.Data["last_successful_write"] = status.LastSuccessfulWrite.UTC().Format(time.RFC3339Nano)
+	}
+	if status.HAEnabled {
+		resp.Data["ha_lock_holder"] = status.HALockHolder
+	}
+
+	return resp, nil
+}
+
+// handleHostInfo reports the host information of the node handling the
+// request
+func (b *SystemBackend) handleHostInfo(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	info := b.Core.hostInfo()
+
+	disk := make([]map[string]interface{}, 0, len(info.Disk))
+	for _, usage := range info.Disk {
+		entry := map[string]interface{}{
+			"path": usage.Path,
+		}
+		if usage.Error != "" {
+			entry["error"] = usage.Error
+		} else {
+			entry["total"] = usage.Total
+			entry["free"] = usage.Free
+			entry["used"] = usage.Used
+		}
+		disk = append(disk, entry)
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"timestamp":      info.Timestamp.Format(time.RFC3339Nano),
+			"hostname":       info.Hostname,
+			"cpu_count":      info.CPUCount,
+			"uptime_seconds": int64(info.Uptime.Seconds()),
+			"runtime": map[string]interface{}{
+				"go_version":    info.GoVersion,
+				"goos":          info.GoOS,
+				"goarch":        info.GoArch,
+				"num_goroutine": info.NumGoroutine,
+			},
+			"memory": map[string]interface{}{
+				"alloc":          info.Memory.Alloc,
+				"total_alloc":    info.Memory.TotalAlloc,
+				"sys":            info.Memory.Sys,
+				"heap_alloc":     info.Memory.HeapAlloc,
+				"heap_inuse":     info.Memory.HeapInuse,
+				"heap_objects":   info.Memory.HeapObjects,
+				"num_gc":         info.Memory.NumGC,
+				"pause_total_ns": info.Memory.PauseTotalNs,
+			},
+			"disk": disk,
+		},
+	}, nil
+}
+
+// handleRateLimitQuotasList lists the configured rate limit quotas
+func (b *SystemBackend) handleRateLimitQuotasList(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	return logical.ListResponse(b.Core.rateLimitQuotas.names()), nil
+}
+
+// handleRateLimitQuotaRead returns the named rate limit quota
+func (b *SystemBackend) handleRateLimitQuotaRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	q := b.Core.rateLimitQuotas.get(d.Get("name").(string))
+	if q == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"name":             q.Name,
+			"path":             q.Path,
+			"rate":             q.Rate,
+			"interval":         int64(q.Interval.Seconds()),
+			"burst":            q.limiter.Burst(),
+			"audit_rejections": q.AuditRejections,
+		},
+	}, nil
+}
+
+// handleRateLimitQuotaSet creates or updates the named rate limit quota.
+// Fields that are not given keep their current value on update.
+func (b *SystemBackend) handleRateLimitQuotaSet(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	name := d.Get("name").(string)
+
+	q := &RateLimitQuota{Name: name}
+	if existing := b.Core.rateLimitQuotas.get(name); existing != nil {
+		q.Path = existing.Path
+		q.Rate = existing.Rate
+		q.Interval = existing.Interval
+		q.Burst = existing.Burst
+		q.AuditRejections = existing.AuditRejections
+	}
+
+	if pathRaw, ok := d.GetOk("path"); ok {
+		path := strings.TrimPrefix(pathRaw.(string), "/")
+		if path != "" {
+			// Quotas on a mount apply to everything under it, so make sure
+			// that the path refers to a mount and that a mount path ends
+			// with a slash
+			lookup := path
+			if !strings.HasSuffix(lookup, "/") {
+				lookup += "/"
+			}
+			mount := b.Core.router.MatchingMount(ctx, lookup)
+			if mount == "" {
+				return logical.ErrorResponse(fmt.Sprintf("no mount found for path %q", path)), logical.ErrInvalidRequest
+			}
+			if path == strings.TrimSuffix(mount, "/") {
+				path = mount
+			}
+		}
+		q.Path = path
+	}
+	if rateRaw, ok := d.GetOk("rate"); ok {
+		q.Rate = rateRaw.(int)
+	}
+	if intervalRaw, ok := d.GetOk("interval"); ok {
+		q.Interval = time.Duration(intervalRaw.(int)) * time.Second
+	}
+	if burstRaw, ok := d.GetOk("burst"); ok {
+		q.Burst = burstRaw.(int)
+	}
+	if auditRaw, ok := d.GetOk("audit_rejections"); ok {
+		q.AuditRejections = auditRaw.(bool)
+	}
+
+	if q.Rate <= 0 {
+		return logical.ErrorResponse("rate must be greater than zero"), logical.ErrInvalidRequest
+	}
+	if q.Interval < 0 {
+		return logical.ErrorResponse("interval cannot be negative"), logical.ErrInvalidRequest
+	}
+	if q.Interval == 0 {
+		q.Interval = time.Second
+	}
+	if q.Burst < 0 {
+		return logical.ErrorResponse("burst cannot be negative"), logical.ErrInvalidRequest
+	}
+	if other := b.Core.rateLimitQuotas.byPath(q.Path); other != "" && other != name {
+		return logical.ErrorResponse(fmt.Sprintf("rate limit quota %q already applies to path %q", other, q.Path)), logical.ErrInvalidRequest
+	}
+
+	if err := b.Core.setRateLimitQuota(ctx, q); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// handleRateLimitQuotaDelete deletes the named rate limit quota
+func (b *SystemBackend) handleRateLimitQuotaDelete(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	if err := b.Core.deleteRateLimitQuota(ctx, d.Get("name").(string)); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
 func (b *SystemBackend) handleTidyLeases(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
 	ns, err := namespace.FromContext(ctx)
 	if err != nil {
@@ -504,14 +736,16 @@ func (b *SystemBackend) handleAuditedHeaderRead(ctx context.Context, req *logica
 	}
 
 	headerConfig := b.Core.AuditedHeadersConfig()
+	headerConfig.RLock()
 	settings, ok := headerConfig.Headers[strings.ToLower(header)]
+	headerConfig.RUnlock()
 	if !ok {
 		return logical.ErrorResponse("Could not find header in config"), nil
 	}
 
 	return &logical.Response{
 		Data: map[string]interface{}{
-			header: settings,
+			header: &auditedHeaderSettings{HMAC: settings.HMAC},
 		},
 	}, nil
 }
@@ -520,9 +754,17 @@ func (b *SystemBackend) handleAuditedHeaderRead(ctx context.Context, req *logica
 func (b *SystemBackend) handleAuditedHeadersRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
 	headerConfig := b.Core.AuditedHeadersConfig()
 
+	// Copy the config so it isn't modified while the response is encoded
+	headerConfig.RLock()
+	headers := make(map[string]*auditedHeaderSettings, len(headerConfig.Headers))
+	for k, v := range headerConfig.Headers {
+		headers[k] = &auditedHeaderSettings{HMAC: v.HMAC}
+	}
+	headerConfig.RUnlock()
+
 	return &logical.Response{
 		Data: map[string]interface{}{
-			"headers": headerConfig.Headers,
+			"headers": headers,
 		},
 	}, nil
 }
@@ -1087,6 +1329,26 @@ func (b *SystemBackend) handleTuneReadCommon(ctx context.Context, path string) (
 		resp.Data["allowed_response_headers"] = rawVal.([]string)
 	}
 
+	if config := effectiveUserLockoutConfig(mountEntry); config != nil {
+		resp.Data["user_lockout_config"] = map[string]interface{}{
+			"lockout_threshold":     config.LockoutThreshold,
+			"lockout_duration":      int(config.LockoutDuration.Seconds()),
+			"lockout_counter_reset": int(config.LockoutCounterReset.Seconds()),
+		}
+	} else if mountEntry.Config.UserLockoutConfig != nil && mountEntry.Config.UserLockoutConfig.DisableLockout {
+		resp.Data["user_lockout_config"] = map[string]interface{}{
+			"disable_lockout": true,
+		}
+	}
+
+	// A negative value is reported as -1 to indicate rollbacks are disabled
+	switch {
+	case mountEntry.Config.RollbackPeriod < 0:
+		resp.Data["rollback_period"] = -1
+	case mountEntry.Config.RollbackPeriod > 0:
+		resp.Data["rollback_period"] = int(mountEntry.Config.RollbackPeriod.Seconds())
+	}
+
 	if len(mountEntry.Options) > 0 {
 		resp.Data["options"] = mountEntry.Options
 	}
@@ -1396,6 +1658,68 @@ func (b *SystemBackend) handleTuneWriteCommon(ctx context.Context, path string,
 		}
 	}
 
+	if rawVal, ok := data.GetOk("user_lockout_config"); ok {
+		if mountEntry.Table != credentialTableType || !userLockoutSupportedTypes[mountEntry.Type] {
+			return logical.ErrorResponse(fmt.Sprintf("'user_lockout_config' is not supported for mounts of type %q", mountEntry.Type)), logical.ErrInvalidRequest
+		}
+
+		userLockoutConfig, err := parseUserLockoutConfig(rawVal.(map[string]interface{}))
+		if err != nil {
+			return logical.ErrorResponse(err.Error()), logical.ErrInvalidRequest
+		}
+
+		oldVal := mountEntry.Config.UserLockoutConfig
+		mountEntry.Config.UserLockoutConfig = userLockoutConfig
+
+		// Update the mount table
+		if err := b.Core.persistAuth(ctx, b.Core.auth, &mountEntry.Local); err != nil {
+			mountEntry.Config.UserLockoutConfig = oldVal
+			return handleError(err)
+		}
+
+		if b.Core.logger.IsInfo() {
+			b.Core.logger.Info("mount tuning of user_lockout_config successful", "path", path)
+		}
+	}
+
+	if rawVal, ok := data.GetOk("rollback_period"); ok {
+		var rollbackPeriod time.Duration
+		switch rpString := rawVal.(string); rpString {
+		case "", "system":
+		case "disable":
+			rollbackPeriod = -1
+		default:
+			tmpPeriod, err := parseutil.ParseDurationSecond(rpString)
+			if err != nil {
+				return logical.ErrorResponse(fmt.Sprintf("invalid rollback_period %q: %s", rpString, err)), logical.ErrInvalidRequest
+			}
+			if tmpPeriod < 0 {
+				return logical.ErrorResponse("rollback_period cannot be negative"), logical.ErrInvalidRequest
+			}
+			rollbackPeriod = tmpPeriod
+		}
+
+		oldVal := mountEntry.Config.RollbackPeriod
+		mountEntry.Config.RollbackPeriod = rollbackPeriod
+
+		// Update the mount table
+		var err error
+		switch {
+		case strings.HasPrefix(path, "auth/"):
+			err = b.Core.persistAuth(ctx, b.Core.auth, &mountEntry.Local)
+		default:
+			err = b.Core.persistMounts(ctx, b.Core.mounts, &mountEntry.Local)
+		}
+		if err != nil {
+			mountEntry.Config.RollbackPeriod = oldVal
+			return handleError(err)
+		}
+
+		if b.Core.logger.IsInfo() {
+			b.Core.logger.Info("mount tuning of rollback_period successful", "path", path)
+		}
+	}
+
 	var err error
 	var resp *logical.Response
 	var options map[string]string
@@ -1976,60 +2300,532 @@ func (b *SystemBackend) handlePoliciesSet(policyType PolicyType) framework.Opera
 			return logical.ErrorResponse("policy name must be provided in the URL"), nil
 		}
 
-		policy.Raw = data.Get("policy").(string)
-		if policy.Raw == "" && policyType == PolicyTypeACL && strings.HasPrefix(req.Path, "policy") {
-			policy.Raw = data.Get("rules").(string)
-			if resp == nil {
-				resp = &logical.Response{}
-			}
-			resp.AddWarning("'rules' is deprecated, please use 'policy' instead")
+		policy.Raw = data.Get("policy").(string)
+		if policy.Raw == "" && policyType == PolicyTypeACL && strings.HasPrefix(req.Path, "policy") {
+			policy.Raw = data.Get("rules").(string)
+			if resp == nil {
+				resp = &logical.Response{}
+			}
+			resp.AddWarning("'rules' is deprecated, please use 'policy' instead")
+		}
+		if policy.Raw == "" {
+			return logical.ErrorResponse("'policy' parameter not supplied or empty"), nil
+		}
+
+		if polBytes, err := base64.StdEncoding.DecodeString(policy.Raw); err == nil {
+			policy.Raw = string(polBytes)
+		}
+
+		switch policyType {
+		case PolicyTypeACL:
+			p, err := ParseACLPolicy(ns, policy.Raw)
+			if err != nil {
+				return handleError(err)
+			}
+			policy.Paths = p.Paths
+			policy.Templated = p.Templated
+
+		case PolicyTypeRGP, PolicyTypeEGP:
+
+		default:
+			return logical.ErrorResponse("unknown policy type"), nil
+		}
+
+		if policy.Type == PolicyTypeRGP || policy.Type == PolicyTypeEGP {
+			if errResp := inputSentinelPolicyData(data, policy); errResp != nil {
+				return errResp, nil
+			}
+		}
+
+		// Update the policy
+		if err := b.Core.policyStore.SetPolicy(ctx, policy); err != nil {
+			return handleError(err)
+		}
+		return resp, nil
+	}
+}
+
+func (b *SystemBackend) handlePoliciesDelete(policyType PolicyType) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		name := data.Get("name").(string)
+
+		if err := b.Core.policyStore.DeletePolicy(ctx, name, policyType); err != nil {
+			return handleError(err)
+		}
+		return nil, nil
+	}
+}
+
+// handlePasswordPoliciesList handles the "policies/password" endpoint to
+// list the configured password policies
+func (b *SystemBackend) handlePasswordPoliciesList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	keys, err := b.Core.listPasswordPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return logical.ListResponse(keys), nil
+}
+
+// handlePasswordPolicyRead returns the raw rules of the named password policy
+func (b *SystemBackend) handlePasswordPolicyRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	raw, err := b.Core.getPasswordPolicy(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if raw == "" {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"policy": raw,
+		},
+	}, nil
+}
+
+// handlePasswordPolicySet validates and stores the named password policy
+func (b *SystemBackend) handlePasswordPolicySet(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	policy := data.Get("policy").(string)
+	if policy == "" {
+		return logical.ErrorResponse("missing policy"), logical.ErrInvalidRequest
+	}
+
+	// If the policy is base64 encoded, decode it first
+	if decoded, err := base64.StdEncoding.DecodeString(policy); err == nil {
+		policy = string(decoded)
+	}
+
+	if err := b.Core.setPasswordPolicy(ctx, name, policy); err != nil {
+		return logical.ErrorResponse(fmt.Sprintf("invalid password policy: %v", err)), logical.ErrInvalidRequest
+	}
+	return nil, nil
+}
+
+// handlePasswordPolicyDelete deletes the named password policy
+func (b *SystemBackend) handlePasswordPolicyDelete(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if err := b.Core.deletePasswordPolicy(ctx, data.Get("name").(string)); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// handlePasswordPolicyGenerate generates a password from the named password
+// policy
+func (b *SystemBackend) handlePasswordPolicyGenerate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	raw, err := b.Core.getPasswordPolicy(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if raw == "" {
+		return logical.ErrorResponse(fmt.Sprintf("password policy %q not found", name)), logical.ErrInvalidRequest
+	}
+
+	generator, err := b.Core.passwordGenerator(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	password, err := generator.Generate(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"password": password,
+		},
+	}, nil
+}
+
+// handleLockedUsersList lists the users that are currently locked out,
+// optionally limited to a single auth mount
+func (b *SystemBackend) handleLockedUsersList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	mountAccessor := data.Get("mount_accessor").(string)
+	if mountAccessor != "" && b.Core.router.MatchingMountByAccessor(mountAccessor) == nil {
+		return logical.ErrorResponse(fmt.Sprintf("no auth mount found for accessor %q", mountAccessor)), logical.ErrInvalidRequest
+	}
+
+	lockedUsers := b.Core.userLockouts.locked(mountAccessor, time.Now())
+	users := make([]map[string]interface{}, 0, len(lockedUsers))
+	for _, u := range lockedUsers {
+		var mountPath string
+		if entry := b.Core.router.MatchingMountByAccessor(u.MountAccessor); entry != nil {
+			mountPath = credentialRoutePrefix + entry.Path
+		}
+		users = append(users, map[string]interface{}{
+			"mount_accessor":        u.MountAccessor,
+			"mount_path":            mountPath,
+			"alias_identifier":      u.AliasName,
+			"failed_login_attempts": u.FailedLogins,
+			"locked_at":             u.LockedAt.Format(time.RFC3339),
+			"lockout_end":           u.LockoutEnd.Format(time.RFC3339),
+		})
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"locked_users": users,
+			"total":        len(users),
+		},
+	}, nil
+}
+
+// handleUnlockUser clears the lockout of a user on the given auth mount
+func (b *SystemBackend) handleUnlockUser(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	mountAccessor := data.Get("mount_accessor").(string)
+	aliasIdentifier := data.Get("alias_identifier").(string)
+	if aliasIdentifier == "" {
+		return logical.ErrorResponse("missing alias_identifier"), logical.ErrInvalidRequest
+	}
+	if b.Core.router.MatchingMountByAccessor(mountAccessor) == nil {
+		return logical.ErrorResponse(fmt.Sprintf("no auth mount found for accessor %q", mountAccessor)), logical.ErrInvalidRequest
+	}
+
+	if b.Core.userLockouts.reset(mountAccessor, strings.ToLower(aliasIdentifier)) {
+		b.Core.logger.Info("unlocked user", "mount_accessor", mountAccessor)
+	}
+	return nil, nil
+}
+
+// handleMFAMethodList lists the configured MFA methods along with their type
+func (b *SystemBackend) handleMFAMethodList(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	b.mfaLock.RLock()
+	defer b.mfaLock.RUnlock()
+
+	keys, err := b.Core.listMFAMethods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keyInfo := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		config, err := b.Core.getMFAMethod(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if config == nil {
+			continue
+		}
+		keyInfo[key] = map[string]interface{}{
+			"id":   config.ID,
+			"type": config.Type,
+		}
+	}
+
+	return logical.ListResponseWithInfo(keys, keyInfo), nil
+}
+
+// getMFAMethodOfType loads the named MFA method, returning an error response
+// if it exists with a different type
+func (b *SystemBackend) getMFAMethodOfType(ctx context.Context, name, methodType string) (*mfa.Config, *logical.Response, error) {
+	config, err := b.Core.getMFAMethod(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if config != nil && config.Type != methodType {
+		return nil, logical.ErrorResponse(fmt.Sprintf("MFA method %q already exists with type %q", name, config.Type)), logical.ErrInvalidRequest
+	}
+	return config, nil, nil
+}
+
+// handleMFAMethodRead returns the configuration of the named MFA method
+func (b *SystemBackend) handleMFAMethodRead(methodType string) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		b.mfaLock.RLock()
+		defer b.mfaLock.RUnlock()
+
+		config, resp, err := b.getMFAMethodOfType(ctx, data.Get("name").(string), methodType)
+		if resp != nil || err != nil {
+			return resp, err
+		}
+		if config == nil {
+			return nil, nil
+		}
+
+		return &logical.Response{
+			Data: mfaMethodData(config),
+		}, nil
+	}
+}
+
+// handleMFAMethodUpdate creates or updates the named MFA method
+func (b *SystemBackend) handleMFAMethodUpdate(methodType string) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		b.mfaLock.Lock()
+		defer b.mfaLock.Unlock()
+
+		name := data.Get("name").(string)
+		config, resp, err := b.getMFAMethodOfType(ctx, name, methodType)
+		if resp != nil || err != nil {
+			return resp, err
+		}
+		if config == nil {
+			config = &mfa.Config{
+				Name: strings.ToLower(name),
+				Type: methodType,
+			}
+		}
+
+		if mountAccessorRaw, ok := data.GetOk("mount_accessor"); ok {
+			config.MountAccessor = mountAccessorRaw.(string)
+		}
+		if config.MountAccessor != "" && b.Core.router.MatchingMountByAccessor(config.MountAccessor) == nil {
+			return logical.ErrorResponse(fmt.Sprintf("no auth mount found for accessor %q", config.MountAccessor)), logical.ErrInvalidRequest
+		}
+		if usernameFormatRaw, ok := data.GetOk("username_format"); ok {
+			config.UsernameFormat = usernameFormatRaw.(string)
+		}
+
+		switch methodType {
+		case mfaMethodTypeTOTP:
+			resp = parseTOTPMethodConfig(config, data)
+		case mfaMethodTypeDuo:
+			resp = parseDuoMethodConfig(config, data)
+		case mfaMethodTypePingID:
+			resp = parsePingIDMethodConfig(config, data)
+		}
+		if resp != nil {
+			return resp, logical.ErrInvalidRequest
+		}
+
+		if err := b.Core.setMFAMethod(ctx, config); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+}
+
+func parseTOTPMethodConfig(config *mfa.Config, data *framework.FieldData) *logical.Response {
+	totpConfig := config.GetTOTPConfig()
+	if totpConfig == nil {
+		totpConfig = &mfa.TOTPConfig{
+			Period:    uint32(data.Get("period").(int)),
+			Digits:    int32(data.Get("digits").(int)),
+			Skew:      uint32(data.Get("skew").(int)),
+			KeySize:   uint32(data.Get("key_size").(int)),
+			QRSize:    int32(data.Get("qr_size").(int)),
+			Algorithm: int32(otplib.AlgorithmSHA1),
+		}
+		config.Config = &mfa.Config_TOTPConfig{TOTPConfig: totpConfig}
+	}
+
+	if issuerRaw, ok := data.GetOk("issuer"); ok {
+		totpConfig.Issuer = issuerRaw.(string)
+	}
+	if totpConfig.Issuer == "" {
+		return logical.ErrorResponse("missing issuer")
+	}
+
+	if periodRaw, ok := data.GetOk("period"); ok {
+		if periodRaw.(int) <= 0 {
+			return logical.ErrorResponse("the period value must be greater than zero")
+		}
+		totpConfig.Period = uint32(periodRaw.(int))
+	}
+
+	if keySizeRaw, ok := data.GetOk("key_size"); ok {
+		if keySizeRaw.(int) <= 0 {
+			return logical.ErrorResponse("the key_size value must be greater than zero")
+		}
+		totpConfig.KeySize = uint32(keySizeRaw.(int))
+	}
+
+	if qrSizeRaw, ok := data.GetOk("qr_size"); ok {
+		if qrSizeRaw.(int) < 0 {
+			return logical.ErrorResponse("the qr_size value must be greater than or equal to zero")
+		}
+		totpConfig.QRSize = int32(qrSizeRaw.(int))
+	}
+
+	if algorithmRaw, ok := data.GetOk("algorithm"); ok {
+		algorithm, err := parseTOTPAlgorithm(algorithmRaw.(string))
+		if err != nil {
+			return logical.ErrorResponse(err.Error())
+		}
+		totpConfig.Algorithm = int32(algorithm)
+	}
+
+	if digitsRaw, ok := data.GetOk("digits"); ok {
+		totpConfig.Digits = int32(digitsRaw.(int))
+	}
+	if totpConfig.Digits != 6 && totpConfig.Digits != 8 {
+		return logical.ErrorResponse("the digits value can only be 6 or 8")
+	}
+
+	if skewRaw, ok := data.GetOk("skew"); ok {
+		totpConfig.Skew = uint32(skewRaw.(int))
+	}
+	if totpConfig.Skew > 1 {
+		return logical.ErrorResponse("the skew value must be 0 or 1")
+	}
+
+	return nil
+}
+
+func parseDuoMethodConfig(config *mfa.Config, data *framework.FieldData) *logical.Response {
+	duoConfig := config.GetDuoConfig()
+	if duoConfig == nil {
+		duoConfig = &mfa.DuoConfig{}
+		config.Config = &mfa.Config_DuoConfig{DuoConfig: duoConfig}
+	}
+
+	if integrationKeyRaw, ok := data.GetOk("integration_key"); ok {
+		duoConfig.IntegrationKey = integrationKeyRaw.(string)
+	}
+	if secretKeyRaw, ok := data.GetOk("secret_key"); ok {
+		duoConfig.SecretKey = secretKeyRaw.(string)
+	}
+	if apiHostnameRaw, ok := data.GetOk("api_hostname"); ok {
+		duoConfig.APIHostname = apiHostnameRaw.(string)
+	}
+	if pushInfoRaw, ok := data.GetOk("push_info"); ok {
+		duoConfig.PushInfo = pushInfoRaw.(string)
+	}
+
+	switch {
+	case config.MountAccessor == "":
+		return logical.ErrorResponse("missing mount_accessor")
+	case duoConfig.IntegrationKey == "":
+		return logical.ErrorResponse("missing integration_key")
+	case duoConfig.SecretKey == "":
+		return logical.ErrorResponse("missing secret_key")
+	case duoConfig.APIHostname == "":
+		return logical.ErrorResponse("missing api_hostname")
+	}
+
+	return nil
+}
+
+func parsePingIDMethodConfig(config *mfa.Config, data *framework.FieldData) *logical.Response {
+	if settingsRaw, ok := data.GetOk("settings_file_base64"); ok {
+		settings, err := base64.StdEncoding.DecodeString(settingsRaw.(string))
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprintf("failed to decode settings_file_base64: %v", err))
 		}
-		if policy.Raw == "" {
-			return logical.ErrorResponse("'policy' parameter not supplied or empty"), nil
+		pingConfig, err := parsePingIDSettings(string(settings))
+		if err != nil {
+			return logical.ErrorResponse(err.Error())
 		}
+		config.Config = &mfa.Config_PingIDConfig{PingIDConfig: pingConfig}
+	}
 
-		if polBytes, err := base64.StdEncoding.DecodeString(policy.Raw); err == nil {
-			policy.Raw = string(polBytes)
-		}
+	switch {
+	case config.MountAccessor == "":
+		return logical.ErrorResponse("missing mount_accessor")
+	case config.GetPingIDConfig() == nil:
+		return logical.ErrorResponse("missing settings_file_base64")
+	}
 
-		switch policyType {
-		case PolicyTypeACL:
-			p, err := ParseACLPolicy(ns, policy.Raw)
-			if err != nil {
-				return handleError(err)
-			}
-			policy.Paths = p.Paths
-			policy.Templated = p.Templated
+	return nil
+}
 
-		case PolicyTypeRGP, PolicyTypeEGP:
+// handleMFAMethodDelete deletes the named MFA method. Secrets generated for
+// it are keyed by the method ID and are not reused by a recreated method.
+func (b *SystemBackend) handleMFAMethodDelete(methodType string) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		b.mfaLock.Lock()
+		defer b.mfaLock.Unlock()
 
-		default:
-			return logical.ErrorResponse("unknown policy type"), nil
+		name := data.Get("name").(string)
+		config, resp, err := b.getMFAMethodOfType(ctx, name, methodType)
+		if resp != nil || err != nil || config == nil {
+			return resp, err
 		}
 
-		if policy.Type == PolicyTypeRGP || policy.Type == PolicyTypeEGP {
-			if errResp := inputSentinelPolicyData(data, policy); errResp != nil {
-				return errResp, nil
-			}
+		if err := b.Core.deleteMFAMethod(ctx, name); err != nil {
+			return nil, err
 		}
+		return nil, nil
+	}
+}
 
-		// Update the policy
-		if err := b.Core.policyStore.SetPolicy(ctx, policy); err != nil {
-			return handleError(err)
-		}
+// handleMFAMethodTOTPGenerate generates a TOTP secret for the entity of the
+// calling token
+func (b *SystemBackend) handleMFAMethodTOTPGenerate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if req.EntityID == "" {
+		return logical.ErrorResponse("the token is not associated with an entity"), logical.ErrInvalidRequest
+	}
+	return b.generateTOTPSecret(ctx, data.Get("name").(string), req.EntityID)
+}
+
+// handleMFAMethodTOTPAdminGenerate generates a TOTP secret for the given
+// entity
+func (b *SystemBackend) handleMFAMethodTOTPAdminGenerate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	entityID := data.Get("entity_id").(string)
+	if entityID == "" {
+		return logical.ErrorResponse("missing entity_id"), logical.ErrInvalidRequest
+	}
+	return b.generateTOTPSecret(ctx, data.Get("name").(string), entityID)
+}
+
+func (b *SystemBackend) generateTOTPSecret(ctx context.Context, name, entityID string) (*logical.Response, error) {
+	b.mfaLock.RLock()
+	defer b.mfaLock.RUnlock()
+
+	config, resp, err := b.getMFAMethodOfType(ctx, name, mfaMethodTypeTOTP)
+	if resp != nil || err != nil {
+		return resp, err
+	}
+	if config == nil {
+		return logical.ErrorResponse(fmt.Sprintf("MFA method %q not found", name)), logical.ErrInvalidRequest
+	}
+
+	entity, err := b.Core.identityStore.MemDBEntityByID(entityID, false)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return logical.ErrorResponse(fmt.Sprintf("entity %q not found", entityID)), logical.ErrInvalidRequest
+	}
+
+	secret, respData, err := generateTOTPSecret(config, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	existed, err := b.Core.setEntityMFASecret(ctx, entityID, config, secret)
+	if err != nil {
+		return nil, err
+	}
+	if existed {
+		resp := &logical.Response{}
+		resp.AddWarning("Entity already has a secret for the MFA method")
 		return resp, nil
 	}
+
+	return &logical.Response{
+		Data: respData,
+	}, nil
 }
 
-func (b *SystemBackend) handlePoliciesDelete(policyType PolicyType) framework.OperationFunc {
-	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-		name := data.Get("name").(string)
+// handleMFAMethodTOTPAdminDestroy removes the TOTP secret of the given entity
+func (b *SystemBackend) handleMFAMethodTOTPAdminDestroy(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	b.mfaLock.RLock()
+	defer b.mfaLock.RUnlock()
 
-		if err := b.Core.policyStore.DeletePolicy(ctx, name, policyType); err != nil {
-			return handleError(err)
-		}
-		return nil, nil
+	name := data.Get("name").(string)
+	entityID := data.Get("entity_id").(string)
+	if entityID == "" {
+		return logical.ErrorResponse("missing entity_id"), logical.ErrInvalidRequest
+	}
+
+	config, resp, err := b.getMFAMethodOfType(ctx, name, mfaMethodTypeTOTP)
+	if resp != nil || err != nil {
+		return resp, err
+	}
+	if config == nil {
+		return logical.ErrorResponse(fmt.Sprintf("MFA method %q not found", name)), logical.ErrInvalidRequest
 	}
+
+	if _, err := b.Core.setEntityMFASecret(ctx, entityID, config, nil); err != nil {
+		return nil, err
+	}
+	return nil, nil
 }
 
 // handleAuditTable handles the "audit" endpoint to provide the audit table
@@ -2333,7 +3129,12 @@ func (b *SystemBackend) handleRawList(ctx context.Context, req *logical.Request,
 		return logical.ErrorResponse("cannot list '%s'", path), logical.ErrInvalidRequest
 	}
 
-	keys, err := b.Core.barrier.List(ctx, path)
+	limit := data.Get("limit").(int)
+	if limit < 0 {
+		return logical.ErrorResponse("limit must not be negative"), logical.ErrInvalidRequest
+	}
+
+	keys, err := b.Core.barrier.ListPage(ctx, path, data.Get("after").(string), limit)
 	if err != nil {
 		return handleErrorNoReadOnlyForward(err)
 	}
@@ -2598,6 +3399,84 @@ func (b *SystemBackend) handleMetrics(ctx context.Context, req *logical.Request,
 	return b.Core.metricsHelper.ResponseForFormat(format)
 }
 
+// handlePprofIndex lists the profiles that can be read under sys/pprof
+func (b *SystemBackend) handlePprofIndex(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"profiles": []string{"allocs", "block", "goroutine", "heap", "mutex", "profile", "threadcreate", "trace"},
+		},
+	}, nil
+}
+
+// handlePprofLookup writes out one of the runtime profiles
+func (b *SystemBackend) handlePprofLookup(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+	debug := data.Get("debug").(int)
+
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return nil, logical.ErrUnsupportedPath
+	}
+
+	var buf bytes.Buffer
+	if err := profile.WriteTo(&buf, debug); err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("failed to write %s profile: {{err}}", name), err)
+	}
+
+	return pprofResponse(buf.Bytes(), debug > 0), nil
+}
+
+// handlePprofCapture records a CPU profile or an execution trace for the
+// requested duration
+func (b *SystemBackend) handlePprofCapture(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+	seconds := data.Get("seconds").(int)
+	if seconds <= 0 {
+		return logical.ErrorResponse("seconds must be greater than zero"), logical.ErrInvalidRequest
+	}
+
+	var buf bytes.Buffer
+	var stop func()
+	switch name {
+	case "profile":
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return logical.ErrorResponse(fmt.Sprintf("could not start CPU profile: %v", err)), logical.ErrInvalidRequest
+		}
+		stop = pprof.StopCPUProfile
+	case "trace":
+		if err := trace.Start(&buf); err != nil {
+			return logical.ErrorResponse(fmt.Sprintf("could not start execution trace: %v", err)), logical.ErrInvalidRequest
+		}
+		stop = trace.Stop
+	}
+
+	timer := time.NewTimer(time.Duration(seconds) * time.Second)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		stop()
+	case <-ctx.Done():
+		stop()
+		return nil, ctx.Err()
+	}
+
+	return pprofResponse(buf.Bytes(), false), nil
+}
+
+func pprofResponse(body []byte, text bool) *logical.Response {
+	contentType := "application/octet-stream"
+	if text {
+		contentType = "text/plain; charset=utf-8"
+	}
+	return &logical.Response{
+		Data: map[string]interface{}{
+			logical.HTTPContentType: contentType,
+			logical.HTTPRawBody:     body,
+			logical.HTTPStatusCode:  200,
+		},
+	}
+}
+
 func (b *SystemBackend) handleWrappingLookup(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 	// This ordering of lookups has been validated already in the wrapping
 	// validation func, we're just doing this for a safety check
@@ -2907,7 +3786,8 @@ func hasMountAccess(ctx context.Context, acl *ACL, path string) bool {
 			perms.CapabilitiesBitmap&ListCapabilityInt > 0,
 			perms.CapabilitiesBitmap&ReadCapabilityInt > 0,
 			perms.CapabilitiesBitmap&SudoCapabilityInt > 0,
-			perms.CapabilitiesBitmap&UpdateCapabilityInt > 0:
+			perms.CapabilitiesBitmap&UpdateCapabilityInt > 0,
+			perms.CapabilitiesBitmap&PatchCapabilityInt > 0:
 
 			aclCapabilitiesGiven = true
 
@@ -3168,6 +4048,9 @@ func (b *SystemBackend) pathInternalUIResultantACL(ctx context.Context, req *log
 		if perms.CapabilitiesBitmap&UpdateCapabilityInt > 0 {
 			capabilities = append(capabilities, UpdateCapability)
 		}
+		if perms.CapabilitiesBitmap&PatchCapabilityInt > 0 {
+			capabilities = append(capabilities, PatchCapability)
+		}
 
 		// If "deny" is explicitly set or if the path has no capabilities at all,
 		// set the path capabilities to "deny"
@@ -3384,6 +4267,93 @@ This path responds to the following HTTP methods.
         Clears the CORS configuration and disables acceptance of CORS requests.
 		`,
 	},
+	"storage/status": {
+		"Reports the health and capabilities of the storage backend.",
+		`
+This path responds to the following HTTP methods.
+
+    GET /
+        Returns the storage backend type, whether it is reachable, when it
+        was last written to successfully, the holder of the HA lock, and
+        whether it supports transactions and paginated listing.
+		`,
+	},
+	"pprof": {
+		"Returns Go runtime profiles of the node handling the request.",
+		`
+This path responds to the following HTTP methods.
+
+    GET /
+        Lists the available profiles.
+
+    GET /<name>
+        Returns the goroutine, heap, allocs, block, mutex or threadcreate
+        profile. Set debug to a value greater than zero for a text format.
+
+    GET /profile
+        Captures a CPU profile for the given number of seconds.
+
+    GET /trace
+        Captures an execution trace for the given number of seconds.
+		`,
+	},
+	"host-info": {
+		"Reports information about the host of the node handling the request.",
+		`
+This path responds to the following HTTP methods.
+
+    GET /
+        Returns the hostname, CPU count, process uptime, Go runtime and
+        memory statistics, and the disk usage of the working and plugin
+        directories of the node handling the request.
+		`,
+	},
+	"rate-limit-quota-list": {
+		"List the configured rate limit quotas.",
+		"",
+	},
+	"rate-limit-quota": {
+		"Read, Modify, or Delete a rate limit quota.",
+		`
+Rate limit quotas limit the rate of requests to Vault with a token bucket.
+A quota applies to every request whose path falls under its path; a quota
+with an empty path applies to all requests, and when several quotas cover a
+request the one with the most specific path is used. Requests over the limit
+are rejected with a 429 status code and a Retry-After header.
+		`,
+	},
+	"rate-limit-quota-name": {
+		"The name of the rate limit quota.",
+	},
+	"rate-limit-quota-path": {
+		`Path the quota applies to, either a mount such as "secret/" or a path
+under a mount. If empty, the quota applies to all requests.`,
+	},
+	"rate-limit-quota-rate": {
+		"The number of requests allowed by the quota in each interval.",
+	},
+	"rate-limit-quota-interval": {
+		"The interval over which the rate applies. Defaults to one second.",
+	},
+	"rate-limit-quota-burst": {
+		`The number of requests that may be made at once before the rate applies.
+Defaults to the rate.`,
+	},
+	"rate-limit-quota-audit-rejections": {
+		"If set, requests rejected by the quota are logged to the audit devices.",
+	},
+	"config/cache": {
+		"Configures or returns the current state of the physical storage cache.",
+		`
+This path responds to the following HTTP methods.
+
+    GET /
+        Returns whether the cache is enabled and its size.
+
+    POST /
+        Enables or disables the cache until the next unseal.
+		`,
+	},
 	"config/ui/headers": {
 		"Configures response headers that should be returned from the UI.",
 		`
@@ -3744,6 +4714,182 @@ or delete a policy.
 		"",
 	},
 
+	"locked-users": {
+		"List the users that are locked out after repeated failed logins.",
+		`
+Users of the userpass, ldap, and approle auth methods are locked out for a
+period of time after a number of failed logins, as configured by the mount's
+user_lockout_config. This endpoint lists the users that are currently locked
+out, optionally limited to the mount with the given accessor.
+		`,
+	},
+
+	"locked-users-mount-accessor": {
+		"The accessor of the auth mount.",
+		"",
+	},
+
+	"locked-users-alias-identifier": {
+		"The name of the alias to unlock, as returned by the auth method's login, for example the username.",
+		"",
+	},
+
+	"unlock-user": {
+		"Unlock a user that is locked out after repeated failed logins.",
+		"",
+	},
+
+	"mfa-method-list": {
+		"List the configured MFA methods.",
+		"",
+	},
+
+	"mfa-method": {
+		`Read, Modify, or Delete an MFA method.`,
+		`
+MFA methods define how a second factor is validated. Policies require them for
+a path by listing their names in "mfa_methods", after which requests to that
+path must carry the credentials of each method in the X-Vault-MFA header.
+		`,
+	},
+
+	"mfa-method-name": {
+		"The name of the MFA method.",
+		"",
+	},
+
+	"mfa-method-mount-accessor": {
+		`The accessor of the auth mount whose alias of the entity is used to derive
+the username at the MFA provider. Required for Duo and PingID.`,
+		"",
+	},
+
+	"mfa-method-username-format": {
+		`The format of the username at the MFA provider, e.g.
+"{{alias.name}}@example.com". Supports "alias.name", "entity.name",
+"alias.metadata.<key>" and "entity.metadata.<key>". Defaults to the name of the
+alias.`,
+		"",
+	},
+
+	"mfa-entity-id": {
+		"The ID of the entity.",
+		"",
+	},
+
+	"mfa-totp-issuer": {
+		"The name of the key's issuing organization.",
+		"",
+	},
+
+	"mfa-totp-period": {
+		"The length of time used to generate a counter for the TOTP token calculation.",
+		"",
+	},
+
+	"mfa-totp-key-size": {
+		"The size in bytes of the generated key.",
+		"",
+	},
+
+	"mfa-totp-qr-size": {
+		"The pixel size of the generated square QR code. A value of 0 disables the QR code.",
+		"",
+	},
+
+	"mfa-totp-algorithm": {
+		`The hashing algorithm used to generate the TOTP token. Options include
+SHA1, SHA256 and SHA512.`,
+		"",
+	},
+
+	"mfa-totp-digits": {
+		"The number of digits in the generated TOTP token. This value can either be 6 or 8.",
+		"",
+	},
+
+	"mfa-totp-skew": {
+		"The number of delay periods that are allowed when validating a TOTP token. This value can either be 0 or 1.",
+		"",
+	},
+
+	"mfa-duo-integration-key": {
+		"The integration key for Duo.",
+		"",
+	},
+
+	"mfa-duo-secret-key": {
+		"The secret key for Duo.",
+		"",
+	},
+
+	"mfa-duo-api-hostname": {
+		"The API host name for Duo.",
+		"",
+	},
+
+	"mfa-duo-push-info": {
+		"Push information for Duo, sent along with push notifications.",
+		"",
+	},
+
+	"mfa-pingid-settings-file": {
+		"The settings file provided by PingID, base64 encoded.",
+		"",
+	},
+
+	"mfa-totp-generate": {
+		"Generate a TOTP secret for the entity of the calling token.",
+		`
+Generates a TOTP secret under the given method for the entity associated with
+the calling token. A secret is only generated once; an administrator can
+destroy it with the admin-destroy endpoint.
+		`,
+	},
+
+	"mfa-totp-admin-generate": {
+		"Generate a TOTP secret for the given entity.",
+		`
+Generates a TOTP secret under the given method for the given entity. An
+existing secret has to be destroyed first with the admin-destroy endpoint.
+		`,
+	},
+
+	"mfa-totp-admin-destroy": {
+		"Destroy the TOTP secret of the given entity.",
+		"",
+	},
+
+	"password-policy-list": {
+		"List the configured password policies.",
+		"",
+	},
+
+	"password-policy": {
+		`Read, Modify, or Delete a password policy.`,
+		`
+Read the rules of an existing password policy, create or update the rules of a
+password policy, or delete a password policy. Password policies control the
+length and character composition of passwords generated or validated by
+secrets engines and auth methods.
+		`,
+	},
+
+	"password-policy-name": {
+		`The name of the password policy.`,
+		"",
+	},
+
+	"password-policy-policy": {
+		`The HCL rules of the password policy. May be base64 encoded.`,
+		"",
+	},
+
+	"password-policy-generate": {
+		"Generate a password from the named password policy.",
+		"",
+	},
+
 	"policy-enforcement-level": {
 		`The enforcement level to apply to the policy.`,
 		"",
@@ -4000,6 +5146,14 @@ This path responds to the following HTTP methods.
 		"The type of token to issue (service or batch).",
 		"",
 	},
+	"user_lockout_config": {
+		"Overrides the settings used to lock out users after repeated failed logins. Accepts lockout_threshold, lockout_duration, lockout_counter_reset, and disable_lockout.",
+		"",
+	},
+	"rollback_period": {
+		"How often the rollback manager should trigger rollbacks and periodic functions on the mount. Accepted values are a duration, 'system' to use the server's rollback interval, or 'disable'.",
+		"",
+	},
 	"raw": {
 		"Write, Read, and Delete data directly in the Storage backend.",
 		"",
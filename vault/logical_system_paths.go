@@ -48,6 +48,31 @@ func (b *SystemBackend) configPaths() []*framework.Path {
 			HelpSynopsis:    strings.TrimSpace(sysHelp["config/cors"][1]),
 		},
 
+		{
+			Pattern: "config/cache$",
+
+			Fields: map[string]*framework.FieldSchema{
+				"enabled": &framework.FieldSchema{
+					Type:        framework.TypeBool,
+					Description: "Enables or disables the physical storage cache.",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handleCacheConfigRead,
+					Summary:  "Return the current physical storage cache settings.",
+				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.handleCacheConfigUpdate,
+					Summary:  "Enable or disable the physical storage cache until the next unseal.",
+				},
+			},
+
+			HelpDescription: strings.TrimSpace(sysHelp["config/cache"][0]),
+			HelpSynopsis:    strings.TrimSpace(sysHelp["config/cache"][1]),
+		},
+
 		{
 			Pattern: "config/ui/headers/" + framework.GenericNameRegex("header"),
 
@@ -1111,6 +1136,104 @@ func (b *SystemBackend) remountPath() *framework.Path {
 	}
 }
 
+func (b *SystemBackend) storagePaths() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "storage/status$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handleStorageStatus,
+					Summary:  "Report the reachability, HA lock holder and capabilities of the storage backend.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["storage/status"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["storage/status"][1]),
+		},
+	}
+}
+
+func (b *SystemBackend) hostInfoPath() *framework.Path {
+	return &framework.Path{
+		Pattern: "host-info/?$",
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: b.handleHostInfo,
+				Summary:  "Report CPU, memory, disk and Go runtime information for the node handling the request.",
+			},
+		},
+
+		HelpSynopsis:    strings.TrimSpace(sysHelp["host-info"][0]),
+		HelpDescription: strings.TrimSpace(sysHelp["host-info"][1]),
+	}
+}
+
+func (b *SystemBackend) quotaPaths() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "quotas/rate-limit/?$",
+
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.ListOperation: b.handleRateLimitQuotasList,
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["rate-limit-quota-list"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["rate-limit-quota-list"][1]),
+		},
+
+		{
+			Pattern: "quotas/rate-limit/" + framework.GenericNameRegex("name") + "$",
+
+			Fields: map[string]*framework.FieldSchema{
+				"name": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["rate-limit-quota-name"][0]),
+				},
+				"path": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["rate-limit-quota-path"][0]),
+				},
+				"rate": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: strings.TrimSpace(sysHelp["rate-limit-quota-rate"][0]),
+				},
+				"interval": &framework.FieldSchema{
+					Type:        framework.TypeDurationSecond,
+					Description: strings.TrimSpace(sysHelp["rate-limit-quota-interval"][0]),
+				},
+				"burst": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: strings.TrimSpace(sysHelp["rate-limit-quota-burst"][0]),
+				},
+				"audit_rejections": &framework.FieldSchema{
+					Type:        framework.TypeBool,
+					Description: strings.TrimSpace(sysHelp["rate-limit-quota-audit-rejections"][0]),
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handleRateLimitQuotaRead,
+					Summary:  "Retrieve the named rate limit quota.",
+				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.handleRateLimitQuotaSet,
+					Summary:  "Add a new or update an existing rate limit quota.",
+				},
+				logical.DeleteOperation: &framework.PathOperation{
+					Callback: b.handleRateLimitQuotaDelete,
+					Summary:  "Delete the named rate limit quota.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["rate-limit-quota"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["rate-limit-quota"][1]),
+		},
+	}
+}
+
 func (b *SystemBackend) metricsPath() *framework.Path {
 	return &framework.Path{
 		Pattern: "metrics",
@@ -1130,6 +1253,75 @@ func (b *SystemBackend) metricsPath() *framework.Path {
 
 }
 
+func (b *SystemBackend) pprofPaths() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "pprof/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handlePprofIndex,
+					Summary:  "List the available profiles.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["pprof"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["pprof"][1]),
+		},
+		{
+			Pattern: "pprof/(?P<name>goroutine|heap|allocs|block|mutex|threadcreate)$",
+
+			Fields: map[string]*framework.FieldSchema{
+				"name": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "The name of the profile.",
+				},
+				"debug": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Description: "If greater than zero, returns the profile in a human readable text format.",
+					Query:       true,
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handlePprofLookup,
+					Summary:  "Return the named runtime profile.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["pprof"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["pprof"][1]),
+		},
+		{
+			Pattern: "pprof/(?P<name>profile|trace)$",
+
+			Fields: map[string]*framework.FieldSchema{
+				"name": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: "The name of the profile.",
+				},
+				"seconds": &framework.FieldSchema{
+					Type:        framework.TypeDurationSecond,
+					Default:     30,
+					Description: "The duration of the CPU profile or execution trace.",
+					Query:       true,
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handlePprofCapture,
+					Summary:  "Capture a CPU profile or an execution trace for the given duration.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["pprof"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["pprof"][1]),
+		},
+	}
+}
+
 func (b *SystemBackend) authPaths() []*framework.Path {
 	return []*framework.Path{
 		{
@@ -1187,6 +1379,14 @@ func (b *SystemBackend) authPaths() []*framework.Path {
 					Type:        framework.TypeString,
 					Description: strings.TrimSpace(sysHelp["token_type"][0]),
 				},
+				"rollback_period": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["rollback_period"][0]),
+				},
+				"user_lockout_config": &framework.FieldSchema{
+					Type:        framework.TypeMap,
+					Description: strings.TrimSpace(sysHelp["user_lockout_config"][0]),
+				},
 			},
 			Operations: map[logical.Operation]framework.OperationHandler{
 				logical.ReadOperation: &framework.PathOperation{
@@ -1355,6 +1555,331 @@ func (b *SystemBackend) policyPaths() []*framework.Path {
 			HelpSynopsis:    strings.TrimSpace(sysHelp["policy"][0]),
 			HelpDescription: strings.TrimSpace(sysHelp["policy"][1]),
 		},
+
+		{
+			Pattern: "policies/password/?$",
+
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.ListOperation: b.handlePasswordPoliciesList,
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["password-policy-list"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["password-policy-list"][1]),
+		},
+
+		{
+			Pattern: "policies/password/" + framework.GenericNameRegex("name") + "/generate$",
+
+			Fields: map[string]*framework.FieldSchema{
+				"name": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["password-policy-name"][0]),
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handlePasswordPolicyGenerate,
+					Summary:  "Generate a password from the named password policy.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["password-policy-generate"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["password-policy-generate"][1]),
+		},
+
+		{
+			Pattern: "policies/password/" + framework.GenericNameRegex("name") + "$",
+
+			Fields: map[string]*framework.FieldSchema{
+				"name": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["password-policy-name"][0]),
+				},
+				"policy": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["password-policy-policy"][0]),
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handlePasswordPolicyRead,
+					Summary:  "Retrieve the named password policy.",
+				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.handlePasswordPolicySet,
+					Summary:  "Add a new or update an existing password policy.",
+				},
+				logical.DeleteOperation: &framework.PathOperation{
+					Callback: b.handlePasswordPolicyDelete,
+					Summary:  "Delete the named password policy.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["password-policy"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["password-policy"][1]),
+		},
+	}
+}
+
+func (b *SystemBackend) lockedUsersPaths() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "locked-users/?$",
+
+			Fields: map[string]*framework.FieldSchema{
+				"mount_accessor": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["locked-users-mount-accessor"][0]),
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handleLockedUsersList,
+					Summary:  "List the users that are locked out after repeated failed logins.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["locked-users"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["locked-users"][1]),
+		},
+
+		{
+			Pattern: "locked-users/(?P<mount_accessor>[^/]+)/unlock/(?P<alias_identifier>.+)",
+
+			Fields: map[string]*framework.FieldSchema{
+				"mount_accessor": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["locked-users-mount-accessor"][0]),
+				},
+				"alias_identifier": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["locked-users-alias-identifier"][0]),
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.handleUnlockUser,
+					Summary:  "Unlock a user that is locked out after repeated failed logins.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["unlock-user"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["unlock-user"][1]),
+		},
+	}
+}
+
+func (b *SystemBackend) mfaPaths() []*framework.Path {
+	methodFields := func(extra map[string]*framework.FieldSchema) map[string]*framework.FieldSchema {
+		fields := map[string]*framework.FieldSchema{
+			"name": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: strings.TrimSpace(sysHelp["mfa-method-name"][0]),
+			},
+			"mount_accessor": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: strings.TrimSpace(sysHelp["mfa-method-mount-accessor"][0]),
+			},
+			"username_format": &framework.FieldSchema{
+				Type:        framework.TypeString,
+				Description: strings.TrimSpace(sysHelp["mfa-method-username-format"][0]),
+			},
+		}
+		for k, v := range extra {
+			fields[k] = v
+		}
+		return fields
+	}
+
+	methodOperations := func(methodType string) map[logical.Operation]framework.OperationHandler {
+		return map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: b.handleMFAMethodRead(methodType),
+				Summary:  "Read the configuration of the MFA method.",
+			},
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback: b.handleMFAMethodUpdate(methodType),
+				Summary:  "Create or update the configuration of the MFA method.",
+			},
+			logical.DeleteOperation: &framework.PathOperation{
+				Callback: b.handleMFAMethodDelete(methodType),
+				Summary:  "Delete the MFA method.",
+			},
+		}
+	}
+
+	entityIDField := map[string]*framework.FieldSchema{
+		"name": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: strings.TrimSpace(sysHelp["mfa-method-name"][0]),
+		},
+		"entity_id": &framework.FieldSchema{
+			Type:        framework.TypeString,
+			Description: strings.TrimSpace(sysHelp["mfa-entity-id"][0]),
+		},
+	}
+
+	return []*framework.Path{
+		{
+			Pattern: "mfa/method/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{
+					Callback: b.handleMFAMethodList,
+					Summary:  "List the configured MFA methods.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["mfa-method-list"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["mfa-method-list"][1]),
+		},
+
+		{
+			Pattern: "mfa/method/totp/" + framework.GenericNameRegex("name") + "/generate$",
+
+			Fields: map[string]*framework.FieldSchema{
+				"name": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["mfa-method-name"][0]),
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handleMFAMethodTOTPGenerate,
+					Summary:  "Generate a TOTP secret for the entity of the calling token.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["mfa-totp-generate"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["mfa-totp-generate"][1]),
+		},
+
+		{
+			Pattern: "mfa/method/totp/" + framework.GenericNameRegex("name") + "/admin-generate$",
+
+			Fields: entityIDField,
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.handleMFAMethodTOTPAdminGenerate,
+					Summary:  "Generate a TOTP secret for the given entity.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["mfa-totp-admin-generate"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["mfa-totp-admin-generate"][1]),
+		},
+
+		{
+			Pattern: "mfa/method/totp/" + framework.GenericNameRegex("name") + "/admin-destroy$",
+
+			Fields: entityIDField,
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.handleMFAMethodTOTPAdminDestroy,
+					Summary:  "Destroy the TOTP secret of the given entity.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["mfa-totp-admin-destroy"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["mfa-totp-admin-destroy"][1]),
+		},
+
+		{
+			Pattern: "mfa/method/totp/" + framework.GenericNameRegex("name") + "$",
+
+			Fields: methodFields(map[string]*framework.FieldSchema{
+				"issuer": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["mfa-totp-issuer"][0]),
+				},
+				"period": &framework.FieldSchema{
+					Type:        framework.TypeDurationSecond,
+					Default:     30,
+					Description: strings.TrimSpace(sysHelp["mfa-totp-period"][0]),
+				},
+				"key_size": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Default:     20,
+					Description: strings.TrimSpace(sysHelp["mfa-totp-key-size"][0]),
+				},
+				"qr_size": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Default:     200,
+					Description: strings.TrimSpace(sysHelp["mfa-totp-qr-size"][0]),
+				},
+				"algorithm": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Default:     "SHA1",
+					Description: strings.TrimSpace(sysHelp["mfa-totp-algorithm"][0]),
+				},
+				"digits": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Default:     6,
+					Description: strings.TrimSpace(sysHelp["mfa-totp-digits"][0]),
+				},
+				"skew": &framework.FieldSchema{
+					Type:        framework.TypeInt,
+					Default:     1,
+					Description: strings.TrimSpace(sysHelp["mfa-totp-skew"][0]),
+				},
+			}),
+
+			Operations: methodOperations(mfaMethodTypeTOTP),
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["mfa-method"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["mfa-method"][1]),
+		},
+
+		{
+			Pattern: "mfa/method/duo/" + framework.GenericNameRegex("name") + "$",
+
+			Fields: methodFields(map[string]*framework.FieldSchema{
+				"integration_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["mfa-duo-integration-key"][0]),
+				},
+				"secret_key": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["mfa-duo-secret-key"][0]),
+				},
+				"api_hostname": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["mfa-duo-api-hostname"][0]),
+				},
+				"push_info": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["mfa-duo-push-info"][0]),
+				},
+			}),
+
+			Operations: methodOperations(mfaMethodTypeDuo),
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["mfa-method"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["mfa-method"][1]),
+		},
+
+		{
+			Pattern: "mfa/method/pingid/" + framework.GenericNameRegex("name") + "$",
+
+			Fields: methodFields(map[string]*framework.FieldSchema{
+				"settings_file_base64": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["mfa-pingid-settings-file"][0]),
+				},
+			}),
+
+			Operations: methodOperations(mfaMethodTypePingID),
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["mfa-method"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["mfa-method"][1]),
+		},
 	}
 }
 
@@ -1481,6 +2006,14 @@ func (b *SystemBackend) mountPaths() []*framework.Path {
 					Type:        framework.TypeString,
 					Description: strings.TrimSpace(sysHelp["token_type"][0]),
 				},
+				"rollback_period": &framework.FieldSchema{
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["rollback_period"][0]),
+				},
+				"user_lockout_config": &framework.FieldSchema{
+					Type:        framework.TypeMap,
+					Description: strings.TrimSpace(sysHelp["user_lockout_config"][0]),
+				},
 			},
 
 			Callbacks: map[logical.Operation]framework.OperationFunc{
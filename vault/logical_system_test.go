@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -26,6 +27,8 @@ import (
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	"github.com/hashicorp/vault/sdk/helper/salt"
 	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/sdk/physical"
+	"github.com/hashicorp/vault/sdk/physical/inmem"
 	"github.com/hashicorp/vault/sdk/version"
 	"github.com/mitchellh/mapstructure"
 )
@@ -46,6 +49,7 @@ func TestSystemBackend_RootPaths(t *testing.T) {
 		"replication/performance/reindex",
 		"rotate",
 		"config/cors",
+		"config/cache",
 		"config/auditing/*",
 		"config/ui/headers/*",
 		"plugins/catalog/*",
@@ -54,6 +58,8 @@ func TestSystemBackend_RootPaths(t *testing.T) {
 		"leases/revoke-prefix/*",
 		"leases/revoke-force/*",
 		"leases/lookup/*",
+		"pprof",
+		"pprof/*",
 	}
 
 	b := testSystemBackend(t)
@@ -138,6 +144,254 @@ func TestSystemConfigCORS(t *testing.T) {
 
 }
 
+func TestSystemBackend_HostInfo(t *testing.T) {
+	_, b, _ := testCoreSystemBackend(t)
+
+	req := logical.TestRequest(t, logical.ReadOperation, "host-info")
+	resp, err := b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data["cpu_count"].(int) != runtime.NumCPU() {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+	if resp.Data["runtime"].(map[string]interface{})["go_version"] != runtime.Version() {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+	if resp.Data["memory"].(map[string]interface{})["sys"].(uint64) == 0 {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+	disk := resp.Data["disk"].([]map[string]interface{})
+	if len(disk) == 0 || disk[0]["path"] == "" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+}
+
+func TestSystemBackend_Pprof(t *testing.T) {
+	_, b, _ := testCoreSystemBackend(t)
+
+	req := logical.TestRequest(t, logical.ReadOperation, "pprof")
+	resp, err := b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Data["profiles"].([]string)) == 0 {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "pprof/goroutine")
+	req.Data["debug"] = 1
+	resp, err = b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(resp.Data[logical.HTTPRawBody].([]byte)), "goroutine") {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "pprof/profile")
+	req.Data["seconds"] = 1
+	resp, err = b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Data[logical.HTTPRawBody].([]byte)) == 0 {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+}
+
+func TestSystemBackend_StorageStatus(t *testing.T) {
+	c, b, _ := testCoreSystemBackend(t)
+
+	req := logical.TestRequest(t, logical.ReadOperation, "storage/status")
+	resp, err := b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Data["reachable"].(bool) {
+		t.Fatalf("expected storage to be reachable: %#v", resp.Data)
+	}
+	if _, ok := resp.Data["error"]; ok {
+		t.Fatalf("unexpected error: %#v", resp.Data)
+	}
+	_, txnOK := c.physical.(physical.Transactional)
+	if resp.Data["transactional"].(bool) != txnOK {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	// Initialization and unsealing wrote to storage
+	lastWrite, err := time.Parse(time.RFC3339Nano, resp.Data["last_successful_write"].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(lastWrite) > time.Minute {
+		t.Fatalf("bad: last write %s", lastWrite)
+	}
+
+	// A failing backend is reported as unreachable
+	c.sealUnwrapper.(*sealUnwrapper).underlying.(*inmem.InmemBackend).FailGet(true)
+	resp, err = b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data["reachable"].(bool) {
+		t.Fatalf("expected storage to be unreachable: %#v", resp.Data)
+	}
+	if resp.Data["error"] == "" {
+		t.Fatalf("expected error: %#v", resp.Data)
+	}
+}
+
+func TestSystemBackend_RateLimitQuotas(t *testing.T) {
+	c, b, _ := testCoreSystemBackend(t)
+	ctx := namespace.RootContext(nil)
+
+	// The path must refer to a mount
+	req := logical.TestRequest(t, logical.UpdateOperation, "quotas/rate-limit/bad")
+	req.Data["path"] = "nonexistent/"
+	req.Data["rate"] = 1
+	resp, err := b.HandleRequest(ctx, req)
+	if err != logical.ErrInvalidRequest || !resp.IsError() {
+		t.Fatalf("expected invalid request, got %v: %#v", err, resp)
+	}
+
+	// A mount path without the trailing slash is normalized
+	req = logical.TestRequest(t, logical.UpdateOperation, "quotas/rate-limit/cubbyhole")
+	req.Data["path"] = "cubbyhole"
+	req.Data["rate"] = 1
+	req.Data["interval"] = "1h"
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil || resp != nil {
+		t.Fatalf("err: %v resp: %#v", err, resp)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "quotas/rate-limit/cubbyhole")
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"name":             "cubbyhole",
+		"path":             "cubbyhole/",
+		"rate":             1,
+		"interval":         int64(3600),
+		"burst":            1,
+		"audit_rejections": false,
+	}
+	if !reflect.DeepEqual(resp.Data, expected) {
+		t.Fatalf("bad: got\n%#v\nexpected\n%#v", resp.Data, expected)
+	}
+
+	// Only one quota may apply to a path
+	req = logical.TestRequest(t, logical.UpdateOperation, "quotas/rate-limit/other")
+	req.Data["path"] = "cubbyhole/"
+	req.Data["rate"] = 1
+	resp, err = b.HandleRequest(ctx, req)
+	if err != logical.ErrInvalidRequest || !resp.IsError() {
+		t.Fatalf("expected invalid request, got %v: %#v", err, resp)
+	}
+
+	// The first request uses up the bucket, the second must wait
+	allowed, _ := c.ApplyRateLimitQuota(ctx, &logical.Request{Path: "cubbyhole/foo"})
+	if !allowed {
+		t.Fatal("expected first request to be allowed")
+	}
+	allowed, retryAfter := c.ApplyRateLimitQuota(ctx, &logical.Request{Path: "cubbyhole/foo"})
+	if allowed {
+		t.Fatal("expected second request to be rejected")
+	}
+	if retryAfter <= 0 || retryAfter > time.Hour {
+		t.Fatalf("bad retry after: %s", retryAfter)
+	}
+
+	// Other paths and the quota endpoints themselves are not limited
+	if allowed, _ := c.ApplyRateLimitQuota(ctx, &logical.Request{Path: "sys/mounts"}); !allowed {
+		t.Fatal("expected unrelated request to be allowed")
+	}
+	if allowed, _ := c.ApplyRateLimitQuota(ctx, &logical.Request{Path: "sys/quotas/rate-limit/cubbyhole"}); !allowed {
+		t.Fatal("expected quota request to be allowed")
+	}
+
+	// Quotas are persisted
+	c.rateLimitQuotas.reset(make(map[string]*RateLimitQuota))
+	if err := c.loadRateLimitQuotas(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if c.rateLimitQuotas.get("cubbyhole") == nil {
+		t.Fatal("expected quota to be loaded")
+	}
+
+	req = logical.TestRequest(t, logical.ListOperation, "quotas/rate-limit")
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp.Data["keys"], []string{"cubbyhole"}) {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	req = logical.TestRequest(t, logical.DeleteOperation, "quotas/rate-limit/cubbyhole")
+	if _, err := b.HandleRequest(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if allowed, _ := c.ApplyRateLimitQuota(ctx, &logical.Request{Path: "cubbyhole/foo"}); !allowed {
+		t.Fatal("expected request to be allowed after deleting the quota")
+	}
+}
+
+func TestSystemConfigCache(t *testing.T) {
+	c, b, _ := testCoreSystemBackend(t)
+
+	req := logical.TestRequest(t, logical.ReadOperation, "config/cache")
+	resp, err := b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"enabled":            true,
+		"disabled_by_config": false,
+		"size":               physical.DefaultCacheSize,
+	}
+	if !reflect.DeepEqual(resp.Data, expected) {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "config/cache")
+	req.Data["enabled"] = false
+	_, err = b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.physicalCache.Enabled() {
+		t.Fatal("expected cache to be disabled")
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "config/cache")
+	resp, err = b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data["enabled"].(bool) {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "config/cache")
+	req.Data["enabled"] = true
+	_, err = b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.physicalCache.Enabled() {
+		t.Fatal("expected cache to be enabled")
+	}
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "config/cache")
+	resp, err = b.HandleRequest(namespace.RootContext(nil), req)
+	if err != logical.ErrInvalidRequest {
+		t.Fatalf("expected invalid request, got %v: %#v", err, resp)
+	}
+}
+
 func TestSystemBackend_mounts(t *testing.T) {
 	b := testSystemBackend(t)
 	req := logical.TestRequest(t, logical.ReadOperation, "mounts")
@@ -1684,6 +1938,74 @@ func TestSystemBackend_policyCRUD(t *testing.T) {
 	}
 }
 
+func TestSystemBackend_passwordPolicyCRUD(t *testing.T) {
+	b := testSystemBackend(t)
+	ctx := namespace.RootContext(nil)
+
+	// Invalid policies are rejected
+	req := logical.TestRequest(t, logical.UpdateOperation, "policies/password/test")
+	req.Data["policy"] = `length = 0`
+	resp, err := b.HandleRequest(ctx, req)
+	if err != logical.ErrInvalidRequest || resp == nil || !resp.IsError() {
+		t.Fatalf("expected invalid request, got: %#v %v", resp, err)
+	}
+
+	policy := `
+length = 16
+rule "charset" {
+  charset = "abcdefghijklmnopqrstuvwxyz"
+  min_chars = 1
+}
+rule "charset" {
+  charset = "0123456789"
+  min_chars = 1
+}`
+	req = logical.TestRequest(t, logical.UpdateOperation, "policies/password/test")
+	req.Data["policy"] = policy
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "policies/password/test")
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp == nil || resp.Data["policy"] != policy {
+		t.Fatalf("bad: %#v", resp)
+	}
+
+	req = logical.TestRequest(t, logical.ListOperation, "policies/password")
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !reflect.DeepEqual(resp.Data["keys"], []string{"test"}) {
+		t.Fatalf("bad: %#v", resp)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "policies/password/test/generate")
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if password := resp.Data["password"].(string); len(password) != 16 {
+		t.Fatalf("bad password: %q", password)
+	}
+
+	req = logical.TestRequest(t, logical.DeleteOperation, "policies/password/test")
+	if _, err := b.HandleRequest(ctx, req); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "policies/password/test/generate")
+	resp, err = b.HandleRequest(ctx, req)
+	if err != logical.ErrInvalidRequest || resp == nil || !resp.IsError() {
+		t.Fatalf("expected invalid request, got: %#v %v", resp, err)
+	}
+}
+
 func TestSystemBackend_enableAudit(t *testing.T) {
 	c, b, _ := testCoreSystemBackend(t)
 	c.auditBackends["noop"] = func(ctx context.Context, config *audit.BackendConfig) (audit.Backend, error) {
@@ -1892,6 +2214,29 @@ func TestSystemBackend_rawReadWrite(t *testing.T) {
 	// simply parse this out directly via GetPolicy, so the test now ends here.
 }
 
+func TestSystemBackend_rawListPage(t *testing.T) {
+	_, b, _ := testCoreSystemBackendRaw(t)
+
+	for _, key := range []string{"a", "b", "c/d", "e"} {
+		req := logical.TestRequest(t, logical.UpdateOperation, "raw/test/"+key)
+		req.Data["value"] = "foo"
+		if _, err := b.HandleRequest(namespace.RootContext(nil), req); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+
+	req := logical.TestRequest(t, logical.ListOperation, "raw/test/")
+	req.Data["after"] = "a"
+	req.Data["limit"] = 2
+	resp, err := b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if keys := resp.Data["keys"].([]string); !reflect.DeepEqual(keys, []string{"b", "c/"}) {
+		t.Fatalf("bad: %v", keys)
+	}
+}
+
 func TestSystemBackend_rawDelete_Protected(t *testing.T) {
 	b := testSystemBackendRaw(t)
 
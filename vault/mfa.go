@@ -0,0 +1,633 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	duoapi "github.com/duosecurity/duo_api_golang"
+	"github.com/duosecurity/duo_api_golang/authapi"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/errwrap"
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/helper/identity"
+	"github.com/hashicorp/vault/helper/identity/mfa"
+	"github.com/hashicorp/vault/sdk/logical"
+	otplib "github.com/pquerna/otp"
+	totplib "github.com/pquerna/otp/totp"
+)
+
+const (
+	// mfaMethodSubPath is the sub-path used for the MFA method view. This is
+	// nested under the system view.
+	mfaMethodSubPath = "mfa/method/"
+
+	mfaMethodTypeTOTP   = "totp"
+	mfaMethodTypeDuo    = "duo"
+	mfaMethodTypePingID = "pingid"
+
+	// mfaDefaultUsernameFormat is used to derive the name of the user at the
+	// external MFA provider when the method does not specify a format
+	mfaDefaultUsernameFormat = "{{alias.name}}"
+)
+
+func (c *Core) mfaMethodView() (*BarrierView, error) {
+	if c.systemBarrierView == nil {
+		return nil, fmt.Errorf("system barrier view is not available")
+	}
+	return c.systemBarrierView.SubView(mfaMethodSubPath), nil
+}
+
+// getMFAMethod returns the configuration of the named MFA method, or nil if
+// it does not exist
+func (c *Core) getMFAMethod(ctx context.Context, name string) (*mfa.Config, error) {
+	view, err := c.mfaMethodView()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := view.Get(ctx, strings.ToLower(name))
+	if err != nil {
+		return nil, errwrap.Wrapf("failed to read MFA method: {{err}}", err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var config mfa.Config
+	if err := proto.Unmarshal(entry.Value, &config); err != nil {
+		return nil, errwrap.Wrapf("failed to decode MFA method: {{err}}", err)
+	}
+	return &config, nil
+}
+
+// setMFAMethod stores the given MFA method configuration, assigning it an ID
+// if it does not have one yet
+func (c *Core) setMFAMethod(ctx context.Context, config *mfa.Config) error {
+	if config.ID == "" {
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			return err
+		}
+		config.ID = id
+	}
+
+	view, err := c.mfaMethodView()
+	if err != nil {
+		return err
+	}
+
+	value, err := proto.Marshal(config)
+	if err != nil {
+		return errwrap.Wrapf("failed to encode MFA method: {{err}}", err)
+	}
+	return view.Put(ctx, &logical.StorageEntry{
+		Key:   strings.ToLower(config.Name),
+		Value: value,
+	})
+}
+
+func (c *Core) deleteMFAMethod(ctx context.Context, name string) error {
+	view, err := c.mfaMethodView()
+	if err != nil {
+		return err
+	}
+	return view.Delete(ctx, strings.ToLower(name))
+}
+
+func (c *Core) listMFAMethods(ctx context.Context) ([]string, error) {
+	view, err := c.mfaMethodView()
+	if err != nil {
+		return nil, err
+	}
+	return logical.CollectKeys(ctx, view)
+}
+
+// mfaMethodData returns the API representation of an MFA method. Secrets of
+// the external providers are not returned.
+func mfaMethodData(config *mfa.Config) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":              config.ID,
+		"name":            config.Name,
+		"type":            config.Type,
+		"mount_accessor":  config.MountAccessor,
+		"username_format": config.UsernameFormat,
+	}
+
+	switch config.Type {
+	case mfaMethodTypeTOTP:
+		totpConfig := config.GetTOTPConfig()
+		data["issuer"] = totpConfig.Issuer
+		data["period"] = int64(totpConfig.Period)
+		data["algorithm"] = otplib.Algorithm(totpConfig.Algorithm).String()
+		data["digits"] = totpConfig.Digits
+		data["skew"] = totpConfig.Skew
+		data["key_size"] = totpConfig.KeySize
+		data["qr_size"] = totpConfig.QRSize
+
+	case mfaMethodTypeDuo:
+		duoConfig := config.GetDuoConfig()
+		data["integration_key"] = duoConfig.IntegrationKey
+		data["api_hostname"] = duoConfig.APIHostname
+		data["push_info"] = duoConfig.PushInfo
+
+	case mfaMethodTypePingID:
+		pingConfig := config.GetPingIDConfig()
+		data["use_signature"] = pingConfig.UseSignature
+		data["idp_url"] = pingConfig.IDPURL
+		data["org_alias"] = pingConfig.OrgAlias
+		data["admin_url"] = pingConfig.AdminURL
+		data["authenticator_url"] = pingConfig.AuthenticatorURL
+	}
+
+	return data
+}
+
+// parseTOTPAlgorithm translates the name of a hash algorithm to the value
+// used by the TOTP library
+func parseTOTPAlgorithm(name string) (otplib.Algorithm, error) {
+	switch strings.ToUpper(name) {
+	case "SHA1":
+		return otplib.AlgorithmSHA1, nil
+	case "SHA256":
+		return otplib.AlgorithmSHA256, nil
+	case "SHA512":
+		return otplib.AlgorithmSHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported algorithm %q", name)
+	}
+}
+
+// parsePingIDSettings parses the contents of the properties file that PingID
+// provides for API access
+func parsePingIDSettings(raw string) (*mfa.PingIDConfig, error) {
+	config := &mfa.PingIDConfig{}
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.IndexAny(line, "=:")
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid line in settings file: %q", line)
+		}
+		key := strings.TrimSpace(line[:idx])
+		// Properties files escape some characters, e.g. the colon in URLs
+		value := strings.Replace(strings.TrimSpace(line[idx+1:]), `\`, "", -1)
+
+		switch key {
+		case "use_base64_key":
+			config.UseBase64Key = value
+		case "use_signature":
+			useSignature, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errwrap.Wrapf("invalid use_signature value: {{err}}", err)
+			}
+			config.UseSignature = useSignature
+		case "token":
+			config.Token = value
+		case "idp_url":
+			config.IDPURL = value
+		case "org_alias":
+			config.OrgAlias = value
+		case "admin_url":
+			config.AdminURL = value
+		case "authenticator_url":
+			config.AuthenticatorURL = value
+		}
+	}
+
+	switch {
+	case config.UseBase64Key == "":
+		return nil, errors.New("settings file is missing use_base64_key")
+	case config.Token == "":
+		return nil, errors.New("settings file is missing token")
+	case config.IDPURL == "":
+		return nil, errors.New("settings file is missing idp_url")
+	case config.OrgAlias == "":
+		return nil, errors.New("settings file is missing org_alias")
+	}
+	if _, err := base64.StdEncoding.DecodeString(config.UseBase64Key); err != nil {
+		return nil, errwrap.Wrapf("invalid use_base64_key value: {{err}}", err)
+	}
+
+	return config, nil
+}
+
+// generateTOTPSecret creates a new TOTP secret for the entity using the
+// parameters of the given method. It returns the secret along with the
+// response data that allows the user to enroll it in an authenticator app.
+func generateTOTPSecret(config *mfa.Config, entity *identity.Entity) (*mfa.Secret, map[string]interface{}, error) {
+	totpConfig := config.GetTOTPConfig()
+	if totpConfig == nil {
+		return nil, nil, fmt.Errorf("MFA method %q is not a TOTP method", config.Name)
+	}
+
+	accountName := entity.Name
+	if accountName == "" {
+		accountName = entity.ID
+	}
+
+	key, err := totplib.Generate(totplib.GenerateOpts{
+		Issuer:      totpConfig.Issuer,
+		AccountName: accountName,
+		Period:      uint(totpConfig.Period),
+		Digits:      otplib.Digits(totpConfig.Digits),
+		Algorithm:   otplib.Algorithm(totpConfig.Algorithm),
+		SecretSize:  uint(totpConfig.KeySize),
+	})
+	if err != nil {
+		return nil, nil, errwrap.Wrapf("failed to generate TOTP key: {{err}}", err)
+	}
+
+	data := map[string]interface{}{
+		"url": key.String(),
+	}
+	if totpConfig.QRSize > 0 {
+		barcode, err := key.Image(int(totpConfig.QRSize), int(totpConfig.QRSize))
+		if err != nil {
+			return nil, nil, errwrap.Wrapf("failed to generate QR code image: {{err}}", err)
+		}
+		var buff bytes.Buffer
+		if err := png.Encode(&buff, barcode); err != nil {
+			return nil, nil, errwrap.Wrapf("failed to encode QR code image: {{err}}", err)
+		}
+		data["barcode"] = base64.StdEncoding.EncodeToString(buff.Bytes())
+	}
+
+	secret := &mfa.Secret{
+		MethodName: config.Name,
+		Value: &mfa.Secret_TOTPSecret{
+			TOTPSecret: &mfa.TOTPSecret{
+				Issuer:      totpConfig.Issuer,
+				Period:      totpConfig.Period,
+				Algorithm:   totpConfig.Algorithm,
+				Digits:      totpConfig.Digits,
+				Skew:        totpConfig.Skew,
+				KeySize:     totpConfig.KeySize,
+				AccountName: accountName,
+				Key:         key.Secret(),
+			},
+		},
+	}
+
+	return secret, data, nil
+}
+
+// setEntityMFASecret stores or, if secret is nil, removes the MFA secret of
+// the given method on the entity. An existing secret is never overwritten; it
+// has to be removed first. It returns whether the entity already had a secret
+// for the method.
+func (c *Core) setEntityMFASecret(ctx context.Context, entityID string, config *mfa.Config, secret *mfa.Secret) (bool, error) {
+	i := c.identityStore
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	entity, err := i.MemDBEntityByID(entityID, true)
+	if err != nil {
+		return false, err
+	}
+	if entity == nil {
+		return false, fmt.Errorf("entity %q not found", entityID)
+	}
+	if entity.MFASecrets == nil {
+		entity.MFASecrets = make(map[string]*mfa.Secret)
+	}
+
+	_, exists := entity.MFASecrets[config.ID]
+	switch {
+	case secret == nil:
+		if !exists {
+			return false, nil
+		}
+		delete(entity.MFASecrets, config.ID)
+	case exists:
+		return true, nil
+	default:
+		entity.MFASecrets[config.ID] = secret
+	}
+
+	return exists, i.upsertEntity(ctx, entity, nil, true)
+}
+
+// validateMFACreds checks the credentials supplied in the request against
+// each of the named MFA methods on behalf of the entity. All methods must be
+// satisfied.
+func (c *Core) validateMFACreds(ctx context.Context, entity *identity.Entity, methodNames []string, mfaCreds logical.MFACreds, remoteAddr string) error {
+	if len(methodNames) == 0 {
+		return nil
+	}
+	if entity == nil {
+		return errors.New("MFA validation requires the token to be associated with an entity")
+	}
+
+	for _, name := range methodNames {
+		config, err := c.getMFAMethod(ctx, name)
+		if err != nil {
+			return err
+		}
+		if config == nil {
+			return fmt.Errorf("MFA method %q not found", name)
+		}
+
+		creds, ok := mfaCreds[config.Name]
+		if !ok {
+			return fmt.Errorf("MFA credentials not supplied for method %q", config.Name)
+		}
+
+		switch config.Type {
+		case mfaMethodTypeTOTP:
+			err = c.validateTOTP(entity, config, creds)
+		case mfaMethodTypeDuo:
+			err = validateDuo(entity, config, creds, remoteAddr)
+		case mfaMethodTypePingID:
+			err = validatePingID(ctx, entity, config)
+		default:
+			err = fmt.Errorf("unsupported MFA method type %q", config.Type)
+		}
+		if err != nil {
+			return errwrap.Wrapf(fmt.Sprintf("failed to validate MFA method %q: {{err}}", config.Name), err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Core) validateTOTP(entity *identity.Entity, config *mfa.Config, creds []string) error {
+	if len(creds) == 0 || creds[0] == "" {
+		return errors.New("missing TOTP passcode")
+	}
+	if len(creds) > 1 {
+		return errors.New("more than one TOTP passcode supplied")
+	}
+	passcode := creds[0]
+
+	secret := entity.MFASecrets[config.ID].GetTOTPSecret()
+	if secret == nil {
+		return errors.New("entity does not have a TOTP secret for the method")
+	}
+
+	// A passcode can only be used once while it is valid
+	usedKey := fmt.Sprintf("%s_%s_%s", config.ID, entity.ID, passcode)
+	if _, used := c.mfaUsedPasscodes.Get(usedKey); used {
+		return errors.New("passcode already used")
+	}
+
+	valid, err := totplib.ValidateCustom(passcode, secret.Key, time.Now(), totplib.ValidateOpts{
+		Period:    uint(secret.Period),
+		Skew:      uint(secret.Skew),
+		Digits:    otplib.Digits(secret.Digits),
+		Algorithm: otplib.Algorithm(secret.Algorithm),
+	})
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("invalid passcode")
+	}
+
+	validity := time.Duration(secret.Period*(2*secret.Skew+1)) * time.Second
+	c.mfaUsedPasscodes.Set(usedKey, nil, validity)
+	return nil
+}
+
+// mfaUsernameTemplateRegex matches the placeholders of a username format
+var mfaUsernameTemplateRegex = regexp.MustCompile(`{{([^}]+)}}`)
+
+// mfaUsername derives the name of the user at the external MFA provider from
+// the entity and its alias on the method's auth mount
+func mfaUsername(entity *identity.Entity, config *mfa.Config) (string, error) {
+	var alias *identity.Alias
+	for _, a := range entity.Aliases {
+		if a.MountAccessor == config.MountAccessor {
+			alias = a
+			break
+		}
+	}
+	if alias == nil {
+		return "", fmt.Errorf("entity does not have an alias on mount %q", config.MountAccessor)
+	}
+
+	format := config.UsernameFormat
+	if format == "" {
+		format = mfaDefaultUsernameFormat
+	}
+
+	var err error
+	username := mfaUsernameTemplateRegex.ReplaceAllStringFunc(format, func(placeholder string) string {
+		key := strings.TrimSpace(placeholder[2 : len(placeholder)-2])
+		switch {
+		case key == "alias.name":
+			return alias.Name
+		case key == "entity.name":
+			return entity.Name
+		case strings.HasPrefix(key, "alias.metadata."):
+			return alias.Metadata[strings.TrimPrefix(key, "alias.metadata.")]
+		case strings.HasPrefix(key, "entity.metadata."):
+			return entity.Metadata[strings.TrimPrefix(key, "entity.metadata.")]
+		default:
+			err = fmt.Errorf("unsupported username format placeholder %q", placeholder)
+			return ""
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	if username == "" {
+		return "", errors.New("empty username")
+	}
+	return username, nil
+}
+
+func validateDuo(entity *identity.Entity, config *mfa.Config, creds []string, remoteAddr string) error {
+	duoConfig := config.GetDuoConfig()
+	username, err := mfaUsername(entity, config)
+	if err != nil {
+		return err
+	}
+
+	client := authapi.NewAuthApi(*duoapi.NewDuoApi(
+		duoConfig.IntegrationKey,
+		duoConfig.SecretKey,
+		duoConfig.APIHostname,
+		"vault",
+	))
+
+	preauth, err := client.Preauth(
+		authapi.PreauthUsername(username),
+		authapi.PreauthIpAddr(remoteAddr),
+	)
+	if err != nil || preauth == nil {
+		return errors.New("could not call Duo preauth")
+	}
+	if preauth.StatResult.Stat != "OK" {
+		return duoStatError("could not look up Duo user information", preauth.StatResult)
+	}
+
+	switch preauth.Response.Result {
+	case "allow":
+		return nil
+	case "deny":
+		return errors.New(preauth.Response.Status_Msg)
+	case "enroll":
+		return fmt.Errorf("%s (%s)", preauth.Response.Status_Msg, preauth.Response.Enroll_Portal_Url)
+	case "auth":
+	default:
+		return fmt.Errorf("invalid Duo preauth response: %s", preauth.Response.Result)
+	}
+
+	// A passcode can be supplied as "passcode=<value>", otherwise a push
+	// notification is sent
+	var passcode string
+	if len(creds) > 0 {
+		passcode = strings.TrimPrefix(creds[0], "passcode=")
+	}
+
+	factor := "push"
+	options := []func(*url.Values){authapi.AuthUsername(username), authapi.AuthIpAddr(remoteAddr)}
+	if passcode != "" {
+		factor = "passcode"
+		options = append(options, authapi.AuthPasscode(passcode))
+	} else {
+		options = append(options, authapi.AuthDevice("auto"))
+		if duoConfig.PushInfo != "" {
+			options = append(options, authapi.AuthPushinfo(duoConfig.PushInfo))
+		}
+	}
+
+	result, err := client.Auth(factor, options...)
+	if err != nil || result == nil {
+		return errors.New("could not call Duo auth")
+	}
+	if result.StatResult.Stat != "OK" {
+		return duoStatError("could not authenticate Duo user", result.StatResult)
+	}
+	if result.Response.Result != "allow" {
+		return errors.New(result.Response.Status_Msg)
+	}
+
+	return nil
+}
+
+func duoStatError(msg string, stat duoapi.StatResult) error {
+	if stat.Message != nil {
+		msg = msg + ": " + *stat.Message
+	}
+	if stat.Message_Detail != nil {
+		msg = msg + " (" + *stat.Message_Detail + ")"
+	}
+	return errors.New(msg)
+}
+
+// pingIDResponse is the subset of the PingID API response that is inspected
+type pingIDResponse struct {
+	ResponseBody struct {
+		ErrorID  int64  `json:"errorId"`
+		ErrorMsg string `json:"errorMsg"`
+	} `json:"responseBody"`
+}
+
+// validatePingID starts an online PingID authentication for the user, which
+// returns once the user has approved or denied it on their device
+func validatePingID(ctx context.Context, entity *identity.Entity, config *mfa.Config) error {
+	pingConfig := config.GetPingIDConfig()
+	username, err := mfaUsername(entity, config)
+	if err != nil {
+		return err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(pingConfig.UseBase64Key)
+	if err != nil {
+		return errwrap.Wrapf("invalid PingID key: {{err}}", err)
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"alg":       "HS256",
+		"org_alias": pingConfig.OrgAlias,
+		"token":     pingConfig.Token,
+	})
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"reqHeader": map[string]string{
+			"locale":    "en",
+			"orgAlias":  pingConfig.OrgAlias,
+			"secretKey": pingConfig.Token,
+			"timestamp": time.Now().UTC().Format("2006-01-02 15:04:05.000"),
+			"version":   "4.9",
+		},
+		"reqBody": map[string]string{
+			"spAlias":  "web",
+			"userName": username,
+			"authType": "CONFIRM",
+		},
+	})
+	if err != nil {
+		return err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	body := signingInput + "." + base64.RawURLEncoding.EncodeToString(pingIDSign(key, signingInput))
+
+	endpoint := strings.TrimSuffix(pingConfig.IDPURL, "/") + "/rest/4/authonline/do"
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := cleanhttp.DefaultClient()
+	client.Timeout = 60 * time.Second
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errwrap.Wrapf("failed to call PingID: {{err}}", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// The response is signed with the same key
+	parts := strings.Split(strings.TrimSpace(string(respBody)), ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("unexpected PingID response with status %d", resp.StatusCode)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, pingIDSign(key, parts[0]+"."+parts[1])) {
+		return errors.New("invalid signature on PingID response")
+	}
+	rawPayload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return errwrap.Wrapf("failed to decode PingID response: {{err}}", err)
+	}
+	var pingResp pingIDResponse
+	if err := json.Unmarshal(rawPayload, &pingResp); err != nil {
+		return errwrap.Wrapf("failed to decode PingID response: {{err}}", err)
+	}
+	if pingResp.ResponseBody.ErrorID != 200 {
+		return fmt.Errorf("PingID authentication failed: %s", pingResp.ResponseBody.ErrorMsg)
+	}
+
+	return nil
+}
+
+func pingIDSign(key []byte, input string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(input))
+	return mac.Sum(nil)
+}
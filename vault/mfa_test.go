@@ -0,0 +1,243 @@
+package vault
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/helper/identity"
+	"github.com/hashicorp/vault/helper/identity/mfa"
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+	totplib "github.com/pquerna/otp/totp"
+)
+
+func TestParsePingIDSettings(t *testing.T) {
+	raw := `
+#Auto-Generated from PingOne
+use_base64_key=` + base64.StdEncoding.EncodeToString([]byte("key")) + `
+use_signature=true
+token=abc
+idp_url=https\://idpxnyl3m.pingidentity.com/pingid
+org_alias=org
+`
+	config, err := parsePingIDSettings(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.UseSignature || config.Token != "abc" || config.OrgAlias != "org" ||
+		config.IDPURL != "https://idpxnyl3m.pingidentity.com/pingid" {
+		t.Fatalf("bad: %#v", config)
+	}
+
+	if _, err := parsePingIDSettings("token=abc"); err == nil {
+		t.Fatal("expected error for incomplete settings")
+	}
+}
+
+func TestMFAUsername(t *testing.T) {
+	entity := &identity.Entity{
+		Name:     "alice",
+		Metadata: map[string]string{"team": "dev"},
+		Aliases: []*identity.Alias{
+			{MountAccessor: "other", Name: "bob"},
+			{MountAccessor: "acc", Name: "al", Metadata: map[string]string{"region": "eu"}},
+		},
+	}
+
+	for format, expected := range map[string]string{
+		"":                           "al",
+		"{{alias.name}}@example.com": "al@example.com",
+		"{{entity.name}}-{{entity.metadata.team}}": "alice-dev",
+		"{{ alias.metadata.region }}":              "eu",
+	} {
+		username, err := mfaUsername(entity, &mfa.Config{MountAccessor: "acc", UsernameFormat: format})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if username != expected {
+			t.Fatalf("bad: format %q: expected %q, got %q", format, expected, username)
+		}
+	}
+
+	if _, err := mfaUsername(entity, &mfa.Config{MountAccessor: "missing"}); err == nil {
+		t.Fatal("expected error for missing alias")
+	}
+	if _, err := mfaUsername(entity, &mfa.Config{MountAccessor: "acc", UsernameFormat: "{{bogus}}"}); err == nil {
+		t.Fatal("expected error for unsupported placeholder")
+	}
+}
+
+func TestSystemBackend_MFAMethodTOTP(t *testing.T) {
+	c, _, root := TestCoreUnsealed(t)
+	ctx := namespace.RootContext(nil)
+
+	handle := func(token string, op logical.Operation, path string, data map[string]interface{}, mfaCreds logical.MFACreds) (*logical.Response, error) {
+		req := logical.TestRequest(t, op, path)
+		req.ClientToken = token
+		req.Data = data
+		req.MFACreds = mfaCreds
+		return c.HandleRequest(ctx, req)
+	}
+
+	resp, err := handle(root, logical.UpdateOperation, "sys/mfa/method/totp/my_totp", map[string]interface{}{
+		"issuer": "vault",
+		"digits": 8,
+	}, nil)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+
+	// Invalid parameters are rejected
+	resp, err = handle(root, logical.UpdateOperation, "sys/mfa/method/totp/bad_totp", map[string]interface{}{
+		"issuer": "vault",
+		"skew":   5,
+	}, nil)
+	if err == nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got: %v %#v", err, resp)
+	}
+
+	// A name can only be used by one method type
+	resp, err = handle(root, logical.UpdateOperation, "sys/mfa/method/duo/my_totp", map[string]interface{}{
+		"integration_key": "ikey",
+	}, nil)
+	if err == nil || resp == nil || !resp.IsError() {
+		t.Fatalf("expected error, got: %v %#v", err, resp)
+	}
+
+	resp, err = handle(root, logical.ReadOperation, "sys/mfa/method/totp/my_totp", nil, nil)
+	if err != nil || resp == nil {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+	if resp.Data["issuer"] != "vault" || resp.Data["digits"].(int32) != 8 || resp.Data["algorithm"] != "SHA1" || resp.Data["period"].(int64) != 30 {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+	methodID := resp.Data["id"].(string)
+
+	resp, err = handle(root, logical.ListOperation, "sys/mfa/method", nil, nil)
+	if err != nil || resp == nil {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+	keyInfo := resp.Data["key_info"].(map[string]interface{})["my_totp"].(map[string]interface{})
+	if keyInfo["type"] != "totp" || keyInfo["id"] != methodID {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	// Require the method for a path and create a token for an entity that
+	// can use it
+	policy, _ := ParseACLPolicy(namespace.RootNamespace, `
+path "secret/foo" {
+	capabilities = ["create", "read", "update"]
+	mfa_methods  = ["my_totp"]
+}
+path "sys/mfa/method/totp/my_totp/generate" {
+	capabilities = ["read"]
+}`)
+	policy.Name = "mfa"
+	if err := c.policyStore.SetPolicy(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err = c.identityStore.HandleRequest(ctx, &logical.Request{
+		Path:      "entity",
+		Operation: logical.UpdateOperation,
+		Data: map[string]interface{}{
+			"name": "alice",
+		},
+	})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+	entityID := resp.Data["id"].(string)
+
+	testMakeTokenDirectly(t, c.tokenStore, &logical.TokenEntry{
+		ID:       "mfatoken",
+		Path:     "auth/token/create",
+		Policies: []string{"mfa"},
+		EntityID: entityID,
+		TTL:      time.Hour,
+	})
+
+	resp, err = handle("mfatoken", logical.ReadOperation, "sys/mfa/method/totp/my_totp/generate", nil, nil)
+	if err != nil || resp == nil {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+	if resp.Data["barcode"] == "" {
+		t.Fatalf("expected barcode: %#v", resp.Data)
+	}
+	keyURL, err := url.Parse(resp.Data["url"].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := keyURL.Query().Get("secret")
+
+	// Secrets are only generated once
+	resp, err = handle("mfatoken", logical.ReadOperation, "sys/mfa/method/totp/my_totp/generate", nil, nil)
+	if err != nil || resp == nil || len(resp.Warnings) != 1 || resp.Data["url"] != nil {
+		t.Fatalf("expected warning, got: %v %#v", err, resp)
+	}
+
+	// Requests without a valid passcode are denied
+	data := map[string]interface{}{"value": "bar"}
+	if _, err := handle("mfatoken", logical.UpdateOperation, "secret/foo", data, nil); err == nil {
+		t.Fatal("expected request without MFA credentials to fail")
+	}
+	if _, err := handle("mfatoken", logical.UpdateOperation, "secret/foo", data, logical.MFACreds{"my_totp": {"00000000"}}); err == nil {
+		t.Fatal("expected request with an invalid passcode to fail")
+	}
+
+	code, err := totplib.GenerateCodeCustom(key, time.Now(), totplib.ValidateOpts{
+		Period: 30,
+		Digits: 8,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = handle("mfatoken", logical.UpdateOperation, "secret/foo", data, logical.MFACreds{"my_totp": {code}})
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+
+	// Passcodes cannot be replayed
+	if _, err := handle("mfatoken", logical.ReadOperation, "secret/foo", nil, logical.MFACreds{"my_totp": {code}}); err == nil {
+		t.Fatal("expected replayed passcode to fail")
+	}
+
+	// Destroying the secret prevents further use of the method
+	resp, err = handle(root, logical.UpdateOperation, "sys/mfa/method/totp/my_totp/admin-destroy", map[string]interface{}{
+		"entity_id": entityID,
+	}, nil)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+	entity, err := c.identityStore.MemDBEntityByID(entityID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := entity.MFASecrets[methodID]; ok {
+		t.Fatal("expected secret to be destroyed")
+	}
+
+	resp, err = handle(root, logical.UpdateOperation, "sys/mfa/method/totp/my_totp/admin-generate", map[string]interface{}{
+		"entity_id": entityID,
+	}, nil)
+	if err != nil || resp == nil || resp.Data["url"] == nil {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+	resp, err = handle(root, logical.UpdateOperation, "sys/mfa/method/totp/my_totp/admin-generate", map[string]interface{}{
+		"entity_id": entityID,
+	}, nil)
+	if err != nil || resp == nil || len(resp.Warnings) != 1 || resp.Data["url"] != nil {
+		t.Fatalf("expected warning, got: %v %#v", err, resp)
+	}
+
+	resp, err = handle(root, logical.DeleteOperation, "sys/mfa/method/totp/my_totp", nil, nil)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+	resp, err = handle(root, logical.ReadOperation, "sys/mfa/method/totp/my_totp", nil, nil)
+	if err != nil || resp != nil {
+		t.Fatalf("expected method to be deleted, got: %v %#v", err, resp)
+	}
+}
@@ -245,6 +245,14 @@ type MountConfig struct {
 	AllowedResponseHeaders    []string              `json:"allowed_response_headers,omitempty" structs:"allowed_response_headers" mapstructure:"allowed_response_headers"`
 	TokenType                 logical.TokenType     `json:"token_type" structs:"token_type" mapstructure:"token_type"`
 
+	// RollbackPeriod controls how often the rollback manager acts on the
+	// mount. Zero means every run of the manager; negative disables it.
+	RollbackPeriod time.Duration `json:"rollback_period,omitempty" structs:"rollback_period" mapstructure:"rollback_period"`
+
+	// UserLockoutConfig overrides the default lockout settings of auth
+	// methods that support locking out users after failed logins
+	UserLockoutConfig *UserLockoutConfig `json:"user_lockout_config,omitempty" structs:"user_lockout_config" mapstructure:"user_lockout_config"`
+
 	// PluginName is the name of the plugin registered in the catalog.
 	//
 	// Deprecated: MountEntry.Type should be used instead for Vault 1.0.0 and beyond.
@@ -497,6 +505,16 @@ func (c *Core) mountInternal(ctx context.Context, entry *MountEntry, updateStora
 		return err
 	}
 
+	if !nilMount {
+		// Restore the original read-only state so that the backend can
+		// write to its view during initialization, and use the active
+		// context since the backend may outlive this request
+		view.setReadOnlyErr(origReadOnlyErr)
+		if err := backend.Initialize(c.activeContext, &logical.InitializationRequest{Storage: view}); err != nil {
+			return err
+		}
+	}
+
 	if c.logger.IsInfo() {
 		c.logger.Info("successful mount", "namespace", entry.Namespace().Path, "path", entry.Path, "type", entry.Type)
 	}
@@ -598,6 +616,12 @@ func (c *Core) unmountInternal(ctx context.Context, path string, updateStorage b
 		return err
 	}
 
+	if c.rollback != nil {
+		if err := c.rollback.forgetPath(ctx, path); err != nil {
+			return err
+		}
+	}
+
 	removePathCheckers(c, entry, viewPath)
 
 	if c.logger.IsInfo() {
@@ -794,6 +818,12 @@ func (c *Core) remount(ctx context.Context, src, dst string) error {
 		return err
 	}
 
+	if c.rollback != nil {
+		if err := c.rollback.forgetPath(ctx, src); err != nil {
+			return err
+		}
+	}
+
 	// Un-taint the path
 	if err := c.router.Untaint(ctx, dst); err != nil {
 		return err
@@ -1111,6 +1141,16 @@ func (c *Core) setupMounts(ctx context.Context) error {
 			c.logger.Info("successfully mounted backend", "type", entry.Type, "path", entry.Path)
 		}
 
+		// Initialize the backend once the view is writable again
+		if backend != nil {
+			initBackend, initPath := backend, entry.Path
+			c.postUnsealFuncs = append(c.postUnsealFuncs, func() {
+				if err := initBackend.Initialize(ctx, &logical.InitializationRequest{Storage: view}); err != nil {
+					c.logger.Error("failed to initialize mount entry", "path", initPath, "error", err)
+				}
+			})
+		}
+
 		// Ensure the path is tainted if set in the mount table
 		if entry.Tainted {
 			c.router.Taint(ctx, entry.Path)
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-test/deep"
 	"github.com/hashicorp/vault/audit"
 	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/compressutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	"github.com/hashicorp/vault/sdk/logical"
@@ -40,6 +42,55 @@ func TestMount_ReadOnlyViewDuringMount(t *testing.T) {
 	}
 }
 
+func TestMount_Initialize(t *testing.T) {
+	c, keys, root := TestCoreUnsealed(t)
+
+	var initialized uint32
+	c.logicalBackends["initialize"] = func(ctx context.Context, config *logical.BackendConfig) (logical.Backend, error) {
+		b := &framework.Backend{
+			BackendType: logical.TypeLogical,
+			InitializeFunc: func(ctx context.Context, req *logical.InitializationRequest) error {
+				atomic.AddUint32(&initialized, 1)
+
+				// The view must be writable during initialization
+				return req.Storage.Put(ctx, &logical.StorageEntry{
+					Key:   "bar",
+					Value: []byte("baz"),
+				})
+			},
+		}
+		if err := b.Setup(ctx, config); err != nil {
+			return nil, err
+		}
+		return b, nil
+	}
+
+	me := &MountEntry{
+		Table: mountTableType,
+		Path:  "foo",
+		Type:  "initialize",
+	}
+	if err := c.mount(namespace.RootContext(nil), me); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if v := atomic.LoadUint32(&initialized); v != 1 {
+		t.Fatalf("expected backend to be initialized once on mount, got %d", v)
+	}
+
+	// The backend is initialized again when the mount table is loaded
+	if err := c.Seal(root); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	for _, key := range keys {
+		if _, err := TestCoreUnseal(c, key); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+	if v := atomic.LoadUint32(&initialized); v != 2 {
+		t.Fatalf("expected backend to be initialized again on unseal, got %d", v)
+	}
+}
+
 func TestCore_DefaultMountTable(t *testing.T) {
 	c, keys, _ := TestCoreUnsealed(t)
 	verifyDefaultTable(t, c.mounts, 4)
@@ -0,0 +1,105 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/helper/random"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	// passwordPolicySubPath is the sub-path used for the password policy
+	// view. This is nested under the system view.
+	passwordPolicySubPath = "password_policy/"
+)
+
+// passwordPolicyEntry is the storage representation of a password policy
+type passwordPolicyEntry struct {
+	Policy string `json:"policy"`
+}
+
+func (c *Core) passwordPolicyView() (*BarrierView, error) {
+	if c.systemBarrierView == nil {
+		return nil, fmt.Errorf("system barrier view is not available")
+	}
+	return c.systemBarrierView.SubView(passwordPolicySubPath), nil
+}
+
+// getPasswordPolicy returns the raw HCL of the named password policy, or an
+// empty string if it does not exist.
+func (c *Core) getPasswordPolicy(ctx context.Context, name string) (string, error) {
+	view, err := c.passwordPolicyView()
+	if err != nil {
+		return "", err
+	}
+
+	entry, err := view.Get(ctx, strings.ToLower(name))
+	if err != nil {
+		return "", errwrap.Wrapf("failed to read password policy: {{err}}", err)
+	}
+	if entry == nil {
+		return "", nil
+	}
+
+	var policy passwordPolicyEntry
+	if err := entry.DecodeJSON(&policy); err != nil {
+		return "", errwrap.Wrapf("failed to decode password policy: {{err}}", err)
+	}
+	return policy.Policy, nil
+}
+
+// setPasswordPolicy validates and stores the named password policy
+func (c *Core) setPasswordPolicy(ctx context.Context, name, raw string) error {
+	if _, err := random.ParsePolicy(raw); err != nil {
+		return err
+	}
+
+	view, err := c.passwordPolicyView()
+	if err != nil {
+		return err
+	}
+
+	entry, err := logical.StorageEntryJSON(strings.ToLower(name), &passwordPolicyEntry{
+		Policy: raw,
+	})
+	if err != nil {
+		return errwrap.Wrapf("failed to encode password policy: {{err}}", err)
+	}
+	return view.Put(ctx, entry)
+}
+
+func (c *Core) deletePasswordPolicy(ctx context.Context, name string) error {
+	view, err := c.passwordPolicyView()
+	if err != nil {
+		return err
+	}
+	return view.Delete(ctx, strings.ToLower(name))
+}
+
+func (c *Core) listPasswordPolicies(ctx context.Context) ([]string, error) {
+	view, err := c.passwordPolicyView()
+	if err != nil {
+		return nil, err
+	}
+	return logical.CollectKeys(ctx, view)
+}
+
+// passwordGenerator loads and parses the named password policy
+func (c *Core) passwordGenerator(ctx context.Context, name string) (*random.StringGenerator, error) {
+	if name == "" {
+		return nil, fmt.Errorf("missing password policy name")
+	}
+
+	raw, err := c.getPasswordPolicy(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if raw == "" {
+		return nil, fmt.Errorf("password policy %q not found", name)
+	}
+
+	return random.ParsePolicy(raw)
+}
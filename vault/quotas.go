@@ -0,0 +1,267 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/audit"
+	"github.com/hashicorp/vault/sdk/logical"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimitQuotaSubPath is the sub-path used for the rate limit quota
+	// view. This is nested under the system view.
+	rateLimitQuotaSubPath = "quotas/rate-limit/"
+)
+
+// ErrRateLimitQuotaExceeded is returned when a request is rejected by a rate
+// limit quota
+var ErrRateLimitQuotaExceeded = errors.New("rate limit quota exceeded")
+
+// rateLimitQuotaExemptPaths are never limited, so that a misconfigured quota
+// can always be corrected
+var rateLimitQuotaExemptPaths = []string{
+	"sys/quotas/",
+}
+
+// RateLimitQuota limits the rate of requests whose path falls under Path
+// using a token bucket that refills at Rate requests per Interval and holds
+// up to Burst requests. An empty Path applies the quota to all requests.
+type RateLimitQuota struct {
+	Name            string        `json:"name"`
+	Path            string        `json:"path"`
+	Rate            int           `json:"rate"`
+	Interval        time.Duration `json:"interval"`
+	Burst           int           `json:"burst"`
+	AuditRejections bool          `json:"audit_rejections"`
+
+	limiter *rate.Limiter
+}
+
+// init creates the token bucket of the quota. A zero Interval defaults to
+// one second and a zero Burst defaults to the rate.
+func (q *RateLimitQuota) init() {
+	interval := q.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	burst := q.Burst
+	if burst <= 0 {
+		burst = q.Rate
+	}
+	q.limiter = rate.NewLimiter(rate.Limit(float64(q.Rate)/interval.Seconds()), burst)
+}
+
+// rateLimitQuotas holds the rate limit quotas in effect on the active node.
+// The token buckets are kept in memory and start full after each unseal.
+type rateLimitQuotas struct {
+	l      sync.RWMutex
+	quotas map[string]*RateLimitQuota
+}
+
+func newRateLimitQuotas() *rateLimitQuotas {
+	return &rateLimitQuotas{
+		quotas: make(map[string]*RateLimitQuota),
+	}
+}
+
+// match returns the quota with the most specific path covering the given
+// request path, or nil if none applies
+func (r *rateLimitQuotas) match(path string) *RateLimitQuota {
+	for _, exempt := range rateLimitQuotaExemptPaths {
+		if strings.HasPrefix(path, exempt) {
+			return nil
+		}
+	}
+
+	r.l.RLock()
+	defer r.l.RUnlock()
+
+	var match *RateLimitQuota
+	for _, q := range r.quotas {
+		if !strings.HasPrefix(path, q.Path) {
+			continue
+		}
+		if match == nil || len(q.Path) > len(match.Path) {
+			match = q
+		}
+	}
+	return match
+}
+
+// byPath returns the name of the quota configured for the given path, if any
+func (r *rateLimitQuotas) byPath(path string) string {
+	r.l.RLock()
+	defer r.l.RUnlock()
+
+	for name, q := range r.quotas {
+		if q.Path == path {
+			return name
+		}
+	}
+	return ""
+}
+
+func (r *rateLimitQuotas) get(name string) *RateLimitQuota {
+	r.l.RLock()
+	defer r.l.RUnlock()
+	return r.quotas[name]
+}
+
+func (r *rateLimitQuotas) set(q *RateLimitQuota) {
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.quotas[q.Name] = q
+}
+
+func (r *rateLimitQuotas) delete(name string) {
+	r.l.Lock()
+	defer r.l.Unlock()
+	delete(r.quotas, name)
+}
+
+func (r *rateLimitQuotas) reset(quotas map[string]*RateLimitQuota) {
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.quotas = quotas
+}
+
+func (r *rateLimitQuotas) names() []string {
+	r.l.RLock()
+	defer r.l.RUnlock()
+
+	names := make([]string, 0, len(r.quotas))
+	for name := range r.quotas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *Core) rateLimitQuotaView() (*BarrierView, error) {
+	if c.systemBarrierView == nil {
+		return nil, fmt.Errorf("system barrier view is not available")
+	}
+	return c.systemBarrierView.SubView(rateLimitQuotaSubPath), nil
+}
+
+// This should only be called with the core state lock held for writing
+func (c *Core) loadRateLimitQuotas(ctx context.Context) error {
+	view, err := c.rateLimitQuotaView()
+	if err != nil {
+		return err
+	}
+
+	names, err := logical.CollectKeys(ctx, view)
+	if err != nil {
+		return errwrap.Wrapf("failed to list rate limit quotas: {{err}}", err)
+	}
+
+	quotas := make(map[string]*RateLimitQuota, len(names))
+	for _, name := range names {
+		entry, err := view.Get(ctx, name)
+		if err != nil {
+			return errwrap.Wrapf(fmt.Sprintf("failed to read rate limit quota %q: {{err}}", name), err)
+		}
+		if entry == nil {
+			continue
+		}
+
+		q := new(RateLimitQuota)
+		if err := entry.DecodeJSON(q); err != nil {
+			return errwrap.Wrapf(fmt.Sprintf("failed to decode rate limit quota %q: {{err}}", name), err)
+		}
+		q.init()
+		quotas[name] = q
+	}
+
+	c.rateLimitQuotas.reset(quotas)
+	return nil
+}
+
+// setRateLimitQuota stores the quota and puts it into effect
+func (c *Core) setRateLimitQuota(ctx context.Context, q *RateLimitQuota) error {
+	view, err := c.rateLimitQuotaView()
+	if err != nil {
+		return err
+	}
+
+	q.init()
+	entry, err := logical.StorageEntryJSON(q.Name, q)
+	if err != nil {
+		return errwrap.Wrapf("failed to encode rate limit quota: {{err}}", err)
+	}
+	if err := view.Put(ctx, entry); err != nil {
+		return errwrap.Wrapf("failed to save rate limit quota: {{err}}", err)
+	}
+
+	c.rateLimitQuotas.set(q)
+	return nil
+}
+
+func (c *Core) deleteRateLimitQuota(ctx context.Context, name string) error {
+	view, err := c.rateLimitQuotaView()
+	if err != nil {
+		return err
+	}
+	if err := view.Delete(ctx, name); err != nil {
+		return errwrap.Wrapf("failed to delete rate limit quota: {{err}}", err)
+	}
+
+	c.rateLimitQuotas.delete(name)
+	return nil
+}
+
+// ApplyRateLimitQuota charges the request against the rate limit quota that
+// applies to its path. If the quota is exhausted the request must be
+// rejected, and the returned duration is how long the client should wait
+// before retrying.
+func (c *Core) ApplyRateLimitQuota(ctx context.Context, req *logical.Request) (bool, time.Duration) {
+	q := c.rateLimitQuotas.match(req.Path)
+	if q == nil {
+		return true, 0
+	}
+
+	now := time.Now()
+	reservation := q.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if reservation.OK() && delay == 0 {
+		return true, 0
+	}
+	reservation.CancelAt(now)
+
+	metrics.IncrCounterWithLabels([]string{"quota", "rate_limit", "violation"}, 1, []metrics.Label{{Name: "name", Value: q.Name}})
+
+	if q.AuditRejections {
+		c.auditRateLimitRejection(ctx, req)
+	}
+
+	return false, delay
+}
+
+// auditRateLimitRejection records a request rejected by a rate limit quota
+// with the audit devices
+func (c *Core) auditRateLimitRejection(ctx context.Context, req *logical.Request) {
+	c.stateLock.RLock()
+	defer c.stateLock.RUnlock()
+
+	if c.Sealed() || c.auditBroker == nil {
+		return
+	}
+
+	logInput := &audit.LogInput{
+		Request:  req,
+		OuterErr: ErrRateLimitQuotaExceeded,
+	}
+	if err := c.auditBroker.LogRequest(ctx, logInput, c.auditedHeaders); err != nil {
+		c.logger.Error("failed to audit rate limited request", "request_id", req.ID, "path", req.Path, "error", err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/vault/sdk/helper/strutil"
 	"github.com/hashicorp/vault/sdk/helper/wrapping"
 	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/sdk/physical"
 )
 
 const (
@@ -44,6 +45,22 @@ type HandlerProperties struct {
 	MaxRequestSize        int64
 	MaxRequestDuration    time.Duration
 	DisablePrintableCheck bool
+
+	// MaxJSONDepth, MaxJSONStringValueLength, MaxJSONObjectEntryCount and
+	// MaxJSONArrayElementCount limit the structure of JSON request bodies.
+	// Zero uses the default limit and a negative value disables the limit.
+	MaxJSONDepth             int
+	MaxJSONStringValueLength int
+	MaxJSONObjectEntryCount  int
+	MaxJSONArrayElementCount int
+
+	// RequestIDHeader is the name of a request header, set by a trusted
+	// proxy, whose value is used as the request ID instead of a new one
+	RequestIDHeader string
+
+	// AllowCacheBypass allows requests to skip the physical cache using the
+	// X-Vault-Cache-Bypass header
+	AllowCacheBypass bool
 }
 
 // fetchEntityAndDerivedPolicies returns the entity object for the given entity
@@ -417,6 +434,9 @@ func (c *Core) switchedLockHandleRequest(httpCtx context.Context, req *logical.R
 		return nil, errwrap.Wrapf("could not parse namespace from http context: {{err}}", err)
 	}
 	ctx = namespace.ContextWithNamespace(ctx, ns)
+	if req.BypassCache {
+		ctx = physical.CacheBypassContext(ctx)
+	}
 
 	resp, err = c.handleCancelableRequest(ctx, ns, req)
 
@@ -435,7 +455,8 @@ func (c *Core) handleCancelableRequest(ctx context.Context, ns *namespace.Namesp
 	// backends. Basically, it's all just terrible, so don't allow it.
 	if strings.HasSuffix(req.Path, "/") &&
 		(req.Operation == logical.UpdateOperation ||
-			req.Operation == logical.CreateOperation) {
+			req.Operation == logical.CreateOperation ||
+			req.Operation == logical.PatchOperation) {
 		return logical.ErrorResponse("cannot write to a path ending in '/'"), nil
 	}
 
@@ -546,7 +567,7 @@ func (c *Core) handleCancelableRequest(ctx context.Context, ns *namespace.Namesp
 			NonHMACRespDataKeys: nonHMACRespDataKeys,
 		}
 		if auditErr := c.auditBroker.LogResponse(ctx, logInput, c.auditedHeaders); auditErr != nil {
-			c.logger.Error("failed to audit response", "request_path", req.Path, "error", auditErr)
+			c.logger.Error("failed to audit response", "request_id", req.ID, "request_path", req.Path, "error", auditErr)
 			return nil, ErrInternalError
 		}
 	}
@@ -580,6 +601,13 @@ func (c *Core) handleRequest(ctx context.Context, req *logical.Request) (retResp
 		if rawVals, ok := entry.synthesizedConfigCache.Load("audit_non_hmac_request_keys"); ok {
 			nonHMACReqDataKeys = rawVals.([]string)
 		}
+
+		// Populate the mount ahead of routing so that audit filters can
+		// match on it when logging the request
+		if req.MountPoint == "" {
+			req.MountPoint = c.router.MatchingMount(ctx, req.Path)
+			req.MountType = entry.Type
+		}
 	}
 
 	ns, err := namespace.FromContext(ctx)
@@ -675,7 +703,7 @@ func (c *Core) handleRequest(ctx context.Context, req *logical.Request) (retResp
 				NonHMACReqDataKeys: nonHMACReqDataKeys,
 			}
 			if err := c.auditBroker.LogRequest(ctx, logInput, c.auditedHeaders); err != nil {
-				c.logger.Error("failed to audit request", "path", req.Path, "error", err)
+				c.logger.Error("failed to audit request", "request_id", req.ID, "path", req.Path, "error", err)
 			}
 		}
 
@@ -696,7 +724,7 @@ func (c *Core) handleRequest(ctx context.Context, req *logical.Request) (retResp
 			NonHMACReqDataKeys: nonHMACReqDataKeys,
 		}
 		if err := c.auditBroker.LogRequest(ctx, logInput, c.auditedHeaders); err != nil {
-			c.logger.Error("failed to audit request", "path", req.Path, "error", err)
+			c.logger.Error("failed to audit request", "request_id", req.ID, "path", req.Path, "error", err)
 			retErr = multierror.Append(retErr, ErrInternalError)
 			return nil, auth, retErr
 		}
@@ -796,7 +824,7 @@ func (c *Core) handleRequest(ctx context.Context, req *logical.Request) (retResp
 		if registerLease {
 			sysView := c.router.MatchingSystemView(ctx, req.Path)
 			if sysView == nil {
-				c.logger.Error("unable to look up sys view for login path", "request_path", req.Path)
+				c.logger.Error("unable to look up sys view for login path", "request_id", req.ID, "request_path", req.Path)
 				return nil, nil, ErrInternalError
 			}
 
@@ -817,7 +845,7 @@ func (c *Core) handleRequest(ctx context.Context, req *logical.Request) (retResp
 
 			leaseID, err := registerFunc(ctx, req, resp)
 			if err != nil {
-				c.logger.Error("failed to register lease", "request_path", req.Path, "error", err)
+				c.logger.Error("failed to register lease", "request_id", req.ID, "request_path", req.Path, "error", err)
 				retErr = multierror.Append(retErr, ErrInternalError)
 				return nil, auth, retErr
 			}
@@ -826,7 +854,7 @@ func (c *Core) handleRequest(ctx context.Context, req *logical.Request) (retResp
 			// Get the actual time of the lease
 			le, err := c.expiration.FetchLeaseTimes(ctx, leaseID)
 			if err != nil {
-				c.logger.Error("failed to fetch updated lease time", "request_path", req.Path, "error", err)
+				c.logger.Error("failed to fetch updated lease time", "request_id", req.ID, "request_path", req.Path, "error", err)
 				retErr = multierror.Append(retErr, ErrInternalError)
 				return nil, auth, retErr
 			}
@@ -843,7 +871,7 @@ func (c *Core) handleRequest(ctx context.Context, req *logical.Request) (retResp
 	// since it does not need to be re-registered
 	if resp != nil && resp.Auth != nil && !strings.HasPrefix(req.Path, "auth/token/renew") {
 		if !strings.HasPrefix(req.Path, "auth/token/") {
-			c.logger.Error("unexpected Auth response for non-token backend", "request_path", req.Path)
+			c.logger.Error("unexpected Auth response for non-token backend", "request_id", req.ID, "request_path", req.Path)
 			retErr = multierror.Append(retErr, ErrInternalError)
 			return nil, auth, retErr
 		}
@@ -876,7 +904,7 @@ func (c *Core) handleRequest(ctx context.Context, req *logical.Request) (retResp
 				NamespaceID: ns.ID,
 			}, resp.Auth); err != nil {
 				c.tokenStore.revokeOrphan(ctx, te.ID)
-				c.logger.Error("failed to register token lease", "request_path", req.Path, "error", err)
+				c.logger.Error("failed to register token lease", "request_id", req.ID, "request_path", req.Path, "error", err)
 				retErr = multierror.Append(retErr, ErrInternalError)
 				return nil, auth, retErr
 			}
@@ -921,6 +949,13 @@ func (c *Core) handleLoginRequest(ctx context.Context, req *logical.Request) (re
 		if rawVals, ok := entry.synthesizedConfigCache.Load("audit_non_hmac_request_keys"); ok {
 			nonHMACReqDataKeys = rawVals.([]string)
 		}
+
+		// Populate the mount ahead of routing so that audit filters can
+		// match on it when logging the request
+		if req.MountPoint == "" {
+			req.MountPoint = c.router.MatchingMount(ctx, req.Path)
+			req.MountType = entry.Type
+		}
 	}
 
 	// Do an unauth check. This will cause EGP policies to be checked
@@ -948,7 +983,7 @@ func (c *Core) handleLoginRequest(ctx context.Context, req *logical.Request) (re
 			NonHMACReqDataKeys: nonHMACReqDataKeys,
 		}
 		if err := c.auditBroker.LogRequest(ctx, logInput, c.auditedHeaders); err != nil {
-			c.logger.Error("failed to audit request", "path", req.Path, "error", err)
+			c.logger.Error("failed to audit request", "request_id", req.ID, "path", req.Path, "error", err)
 			return nil, nil, ErrInternalError
 		}
 
@@ -969,19 +1004,42 @@ func (c *Core) handleLoginRequest(ctx context.Context, req *logical.Request) (re
 		NonHMACReqDataKeys: nonHMACReqDataKeys,
 	}
 	if err := c.auditBroker.LogRequest(ctx, logInput, c.auditedHeaders); err != nil {
-		c.logger.Error("failed to audit request", "path", req.Path, "error", err)
+		c.logger.Error("failed to audit request", "request_id", req.ID, "path", req.Path, "error", err)
 		return nil, nil, ErrInternalError
 	}
 
 	// The token store uses authentication even when creating a new token,
 	// so it's handled in handleRequest. It should not be reached here.
 	if strings.HasPrefix(req.Path, "auth/token/") {
-		c.logger.Error("unexpected login request for token backend", "request_path", req.Path)
+		c.logger.Error("unexpected login request for token backend", "request_id", req.ID, "request_path", req.Path)
 		return nil, nil, ErrInternalError
 	}
 
+	// Reject logins for users that are locked out after too many failed
+	// attempts
+	var lockoutAlias string
+	lockoutConfig := effectiveUserLockoutConfig(entry)
+	if lockoutConfig != nil {
+		lockoutAlias = c.loginAliasName(ctx, req)
+		if lockoutAlias != "" && c.userLockouts.isLocked(entry.Accessor, lockoutAlias, time.Now()) {
+			c.logger.Warn("login attempt for locked out user", "mount_path", req.MountPoint, "request_id", req.ID, "request_path", req.Path)
+			return logical.ErrorResponse(logical.ErrPermissionDenied.Error()), nil, logical.ErrPermissionDenied
+		}
+	}
+
 	// Route the request
 	resp, routeErr := c.doRouting(ctx, req)
+
+	if lockoutAlias != "" {
+		switch {
+		case resp != nil && resp.Auth != nil:
+			c.userLockouts.reset(entry.Accessor, lockoutAlias)
+		case (resp != nil && resp.IsError()) || routeErr == logical.ErrPermissionDenied || routeErr == logical.ErrInvalidRequest:
+			if c.userLockouts.recordFailure(entry.Accessor, lockoutAlias, lockoutConfig, time.Now()) {
+				c.logger.Warn("user locked out after repeated failed logins", "mount_path", req.MountPoint, "lockout_duration", lockoutConfig.LockoutDuration.String())
+			}
+		}
+	}
 	if resp != nil {
 		// If wrapping is used, use the shortest between the request and response
 		var wrapTTL time.Duration
@@ -1025,7 +1083,7 @@ func (c *Core) handleLoginRequest(ctx context.Context, req *logical.Request) (re
 
 	// A login request should never return a secret!
 	if resp != nil && resp.Secret != nil {
-		c.logger.Error("unexpected Secret response for login path", "request_path", req.Path)
+		c.logger.Error("unexpected Secret response for login path", "request_id", req.ID, "request_path", req.Path)
 		return nil, nil, ErrInternalError
 	}
 
@@ -1089,7 +1147,7 @@ func (c *Core) handleLoginRequest(ctx context.Context, req *logical.Request) (re
 
 		sysView := c.router.MatchingSystemView(ctx, req.Path)
 		if sysView == nil {
-			c.logger.Error("unable to look up sys view for login path", "request_path", req.Path)
+			c.logger.Error("unable to look up sys view for login path", "request_id", req.ID, "request_path", req.Path)
 			return nil, nil, ErrInternalError
 		}
 
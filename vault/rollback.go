@@ -15,8 +15,9 @@ import (
 )
 
 const (
-	// rollbackPeriod is how often we attempt rollbacks for all the backends
-	rollbackPeriod = time.Minute
+	// defaultRollbackPeriod is how often we attempt rollbacks for all the
+	// backends unless overridden in the core config
+	defaultRollbackPeriod = time.Minute
 )
 
 // RollbackManager is responsible for performing rollbacks of partial
@@ -30,7 +31,9 @@ const (
 //
 // The RollbackManager periodically initiates a logical.RollbackOperation
 // on every mounted logical backend. It ensures that only one rollback operation
-// is in-flight at any given time within a single seal/unseal phase.
+// is in-flight at any given time within a single seal/unseal phase. Mounts can
+// be tuned to be rolled back less frequently than the manager runs, or to be
+// skipped entirely.
 type RollbackManager struct {
 	logger log.Logger
 
@@ -46,6 +49,11 @@ type RollbackManager struct {
 	inflight     map[string]*rollbackState
 	inflightLock sync.RWMutex
 
+	// lastRollback tracks when a scheduled rollback was last started for
+	// mounts that are tuned with their own rollback period. It is protected
+	// by inflightLock.
+	lastRollback map[string]time.Time
+
 	doneCh       chan struct{}
 	shutdown     bool
 	shutdownCh   chan struct{}
@@ -65,16 +73,22 @@ type rollbackState struct {
 
 // NewRollbackManager is used to create a new rollback manager
 func NewRollbackManager(ctx context.Context, logger log.Logger, backendsFunc func() []*MountEntry, router *Router, core *Core) *RollbackManager {
+	period := defaultRollbackPeriod
+	if core != nil && core.rollbackPeriod > 0 {
+		period = core.rollbackPeriod
+	}
+
 	r := &RollbackManager{
-		logger:      logger,
-		backends:    backendsFunc,
-		router:      router,
-		period:      rollbackPeriod,
-		inflight:    make(map[string]*rollbackState),
-		doneCh:      make(chan struct{}),
-		shutdownCh:  make(chan struct{}),
-		quitContext: ctx,
-		core:        core,
+		logger:       logger,
+		backends:     backendsFunc,
+		router:       router,
+		period:       period,
+		inflight:     make(map[string]*rollbackState),
+		lastRollback: make(map[string]time.Time),
+		doneCh:       make(chan struct{}),
+		shutdownCh:   make(chan struct{}),
+		quitContext:  ctx,
+		core:         core,
 	}
 	return r
 }
@@ -134,11 +148,41 @@ func (m *RollbackManager) triggerRollbacks() {
 		}
 		fullPath := e.namespace.Path + path
 
+		// Honor the mount's tuned rollback period, if any
+		if !m.rollbackDue(fullPath, e.Config.RollbackPeriod) {
+			continue
+		}
+
 		// Start a rollback if necessary
 		m.startOrLookupRollback(ctx, fullPath, true)
 	}
 }
 
+// rollbackDue returns whether a scheduled rollback should be started for the
+// given path based on the mount's configured rollback period. A zero period
+// means the mount is rolled back every time the manager runs and a negative
+// period means scheduled rollbacks are disabled for the mount.
+func (m *RollbackManager) rollbackDue(fullPath string, period time.Duration) bool {
+	switch {
+	case period < 0:
+		return false
+	case period == 0:
+		return true
+	}
+
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+
+	// Allow for a half-period of slack so that ticker jitter doesn't push
+	// the rollback out by an entire extra period
+	now := time.Now()
+	if last, ok := m.lastRollback[fullPath]; ok && now.Sub(last)+m.period/2 < period {
+		return false
+	}
+	m.lastRollback[fullPath] = now
+	return true
+}
+
 // startOrLookupRollback is used to start an async rollback attempt.
 // This must be called with the inflightLock held.
 func (m *RollbackManager) startOrLookupRollback(ctx context.Context, fullPath string, grabStatelock bool) *rollbackState {
@@ -274,6 +318,20 @@ func (m *RollbackManager) Rollback(ctx context.Context, path string) error {
 	return rs.lastError
 }
 
+// forgetPath removes the scheduling state kept for the given path, which
+// must be called when the mount at the path is removed
+func (m *RollbackManager) forgetPath(ctx context.Context, path string) error {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	delete(m.lastRollback, ns.Path+path)
+	return nil
+}
+
 // The methods below are the hooks from core that are called pre/post seal.
 
 // startRollback is used to start the rollback manager after unsealing
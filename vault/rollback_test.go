@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/vault/helper/namespace"
 	"github.com/hashicorp/vault/sdk/helper/logging"
+	"github.com/hashicorp/vault/sdk/logical"
 )
 
 // mockRollback returns a mock rollback manager
@@ -118,3 +119,76 @@ func TestRollbackManager_Join(t *testing.T) {
 		t.Fatalf("Error on rollback:%v", err)
 	}
 }
+
+func TestRollbackManager_MountRollbackPeriod(t *testing.T) {
+	m, backend := mockRollback(t)
+
+	mounts := m.backends()
+	mounts[0].Config.RollbackPeriod = -1
+
+	m.Start()
+	time.Sleep(50 * time.Millisecond)
+	m.Stop()
+
+	if len(backend.Paths) != 0 {
+		t.Fatalf("rollbacks should be disabled for the mount: %#v", backend)
+	}
+
+	m, backend = mockRollback(t)
+	mounts = m.backends()
+	mounts[0].Config.RollbackPeriod = time.Hour
+
+	m.Start()
+	time.Sleep(50 * time.Millisecond)
+	m.Stop()
+
+	if len(backend.Paths) != 1 {
+		t.Fatalf("expected a single rollback within the mount's period: %#v", backend)
+	}
+}
+
+func TestRollbackManager_forgetRemovedMounts(t *testing.T) {
+	c, _, _ := TestCoreUnsealed(t)
+	ctx := namespace.RootContext(nil)
+	c.credentialBackends["noop"] = func(context.Context, *logical.BackendConfig) (logical.Backend, error) {
+		return &NoopBackend{
+			BackendType: logical.TypeCredential,
+		}, nil
+	}
+
+	hasLastRollback := func(path string) bool {
+		c.rollback.inflightLock.RLock()
+		defer c.rollback.inflightLock.RUnlock()
+		_, ok := c.rollback.lastRollback[path]
+		return ok
+	}
+
+	for _, path := range []string{"foo/", "bar/"} {
+		if err := c.mount(ctx, &MountEntry{Table: mountTableType, Path: path, Type: "kv"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.enableCredential(ctx, &MountEntry{Table: credentialTableType, Path: "foo/", Type: "noop"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"foo/", "bar/", "auth/foo/"} {
+		if !c.rollback.rollbackDue(path, time.Hour) || !hasLastRollback(path) {
+			t.Fatalf("expected a scheduled rollback for %q", path)
+		}
+	}
+
+	if err := c.unmount(ctx, "foo/"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.remount(ctx, "bar/", "baz/"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.disableCredential(ctx, "foo/"); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"foo/", "bar/", "auth/foo/"} {
+		if hasLastRollback(path) {
+			t.Fatalf("expected rollback state for %q to be removed", path)
+		}
+	}
+}
@@ -548,6 +548,12 @@ func (r *Router) routeCommon(ctx context.Context, req *logical.Request, existenc
 			// cubbyhole ID so just return here
 			return nil, false, false, nil
 		}
+		if req.Operation == logical.RevokeOperation {
+			// Entries written with a TTL are revoked by the expiration
+			// manager without a token; the backend locates the entry from
+			// the lease's internal data
+			break
+		}
 
 		te := req.TokenEntry()
 
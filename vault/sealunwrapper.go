@@ -34,6 +34,7 @@ func NewSealUnwrapper(underlying physical.Backend, logger log.Logger) physical.B
 
 var _ physical.Backend = (*sealUnwrapper)(nil)
 var _ physical.Transactional = (*transactionalSealUnwrapper)(nil)
+var _ physical.Paginated = (*sealUnwrapper)(nil)
 
 type sealUnwrapper struct {
 	underlying   physical.Backend
@@ -147,6 +148,10 @@ func (d *sealUnwrapper) List(ctx context.Context, prefix string) ([]string, erro
 	return d.underlying.List(ctx, prefix)
 }
 
+func (d *sealUnwrapper) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	return physical.ListPage(ctx, d.underlying, prefix, after, limit)
+}
+
 func (d *transactionalSealUnwrapper) Transaction(ctx context.Context, txns []*physical.TxnEntry) error {
 	// Collect keys that need to be locked
 	var keys []string
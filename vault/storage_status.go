@@ -0,0 +1,122 @@
+package vault
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/physical"
+)
+
+// storageStatusBackend wraps the physical backend used by the core and
+// records when a write last succeeded, for sys/storage/status
+type storageStatusBackend struct {
+	physical.Backend
+
+	// lastWrite is the time of the last successful write, in Unix
+	// nanoseconds, or zero if there has been none
+	lastWrite *int64
+}
+
+// transactionalStorageStatusBackend is the transactional version of the
+// storage status backend
+type transactionalStorageStatusBackend struct {
+	*storageStatusBackend
+	physical.Transactional
+}
+
+var _ physical.Backend = (*storageStatusBackend)(nil)
+var _ physical.Transactional = (*transactionalStorageStatusBackend)(nil)
+var _ physical.Paginated = (*storageStatusBackend)(nil)
+
+func newStorageStatusBackend(b physical.Backend, lastWrite *int64) physical.Backend {
+	ret := &storageStatusBackend{
+		Backend:   b,
+		lastWrite: lastWrite,
+	}
+
+	if txn, ok := b.(physical.Transactional); ok {
+		return &transactionalStorageStatusBackend{
+			storageStatusBackend: ret,
+			Transactional:        txn,
+		}
+	}
+
+	return ret
+}
+
+func (s *storageStatusBackend) recordWrite(err error) {
+	if err == nil {
+		atomic.StoreInt64(s.lastWrite, time.Now().UnixNano())
+	}
+}
+
+func (s *storageStatusBackend) Put(ctx context.Context, entry *physical.Entry) error {
+	err := s.Backend.Put(ctx, entry)
+	s.recordWrite(err)
+	return err
+}
+
+func (s *storageStatusBackend) Delete(ctx context.Context, key string) error {
+	err := s.Backend.Delete(ctx, key)
+	s.recordWrite(err)
+	return err
+}
+
+func (s *storageStatusBackend) ListPage(ctx context.Context, prefix string, after string, limit int) ([]string, error) {
+	return physical.ListPage(ctx, s.Backend, prefix, after, limit)
+}
+
+func (s *transactionalStorageStatusBackend) Transaction(ctx context.Context, txns []*physical.TxnEntry) error {
+	err := s.Transactional.Transaction(ctx, txns)
+	s.recordWrite(err)
+	return err
+}
+
+// StorageStatus describes the health and capabilities of the storage
+// backend
+type StorageStatus struct {
+	Type                string
+	Reachable           bool
+	Error               string
+	ProbeDuration       time.Duration
+	LastSuccessfulWrite time.Time
+	HAEnabled           bool
+	HALockHolder        string
+	Transactional       bool
+	Paginated           bool
+}
+
+// storageStatus probes the storage backend and reports its status. The
+// caller must hold the state lock for reading.
+func (c *Core) storageStatus(ctx context.Context) *StorageStatus {
+	status := &StorageStatus{
+		Type:      c.storageType,
+		Paginated: c.storagePaginated,
+	}
+	if nanos := atomic.LoadInt64(c.lastStorageWrite); nanos != 0 {
+		status.LastSuccessfulWrite = time.Unix(0, nanos)
+	}
+	_, status.Transactional = c.physical.(physical.Transactional)
+
+	// Read a key that always exists once Vault is initialized, skipping the
+	// cache so that the read reaches the backend
+	start := time.Now()
+	_, err := c.physical.Get(physical.CacheBypassContext(ctx), barrierSealConfigPath)
+	status.ProbeDuration = time.Since(start)
+	status.Reachable = err == nil
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	if c.ha != nil && c.ha.HAEnabled() {
+		status.HAEnabled = true
+		if !c.standby {
+			status.HALockHolder = c.redirectAddr
+		} else if params := c.clusterLeaderParams.Load().(*ClusterLeaderParams); params != nil {
+			status.HALockHolder = params.LeaderRedirectAddr
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,317 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/vault/sdk/helper/parseutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	defaultUserLockoutThreshold    = 5
+	defaultUserLockoutDuration     = 15 * time.Minute
+	defaultUserLockoutCounterReset = 15 * time.Minute
+
+	// userLockoutPruneInterval is how often failed login state that no
+	// longer matters is removed
+	userLockoutPruneInterval = time.Minute
+
+	// maxUserLockoutEntries caps the number of aliases whose failed logins
+	// are tracked, since failures are recorded for names that need not exist
+	maxUserLockoutEntries = 100000
+)
+
+// userLockoutSupportedTypes are the auth method types for which failed
+// logins are tracked. These methods authenticate with a secret chosen by, or
+// issued to, a user and are therefore subject to brute forcing.
+var userLockoutSupportedTypes = map[string]bool{
+	"userpass": true,
+	"ldap":     true,
+	"approle":  true,
+}
+
+// UserLockoutConfig holds the lockout settings tuned on an auth mount. Zero
+// values fall back to the defaults.
+type UserLockoutConfig struct {
+	LockoutThreshold    uint64        `json:"lockout_threshold,omitempty" structs:"lockout_threshold" mapstructure:"lockout_threshold"`
+	LockoutDuration     time.Duration `json:"lockout_duration,omitempty" structs:"lockout_duration" mapstructure:"lockout_duration"`
+	LockoutCounterReset time.Duration `json:"lockout_counter_reset,omitempty" structs:"lockout_counter_reset" mapstructure:"lockout_counter_reset"`
+	DisableLockout      bool          `json:"disable_lockout,omitempty" structs:"disable_lockout" mapstructure:"disable_lockout"`
+}
+
+// parseUserLockoutConfig parses the user_lockout_config tuning parameter
+func parseUserLockoutConfig(raw map[string]interface{}) (*UserLockoutConfig, error) {
+	config := &UserLockoutConfig{}
+	for k, v := range raw {
+		var err error
+		switch k {
+		case "lockout_threshold":
+			var threshold int64
+			threshold, err = parseutil.ParseInt(v)
+			if err == nil && threshold < 0 {
+				err = fmt.Errorf("cannot be negative")
+			}
+			config.LockoutThreshold = uint64(threshold)
+		case "lockout_duration":
+			config.LockoutDuration, err = parseutil.ParseDurationSecond(v)
+			if err == nil && config.LockoutDuration < 0 {
+				err = fmt.Errorf("cannot be negative")
+			}
+		case "lockout_counter_reset":
+			config.LockoutCounterReset, err = parseutil.ParseDurationSecond(v)
+			if err == nil && config.LockoutCounterReset < 0 {
+				err = fmt.Errorf("cannot be negative")
+			}
+		case "disable_lockout":
+			config.DisableLockout, err = parseutil.ParseBool(v)
+		default:
+			return nil, fmt.Errorf("unknown user_lockout_config key %q", k)
+		}
+		if err != nil {
+			return nil, errwrap.Wrapf(fmt.Sprintf("invalid %s: {{err}}", k), err)
+		}
+	}
+	return config, nil
+}
+
+// effectiveUserLockoutConfig returns the lockout settings in effect for the
+// given mount, or nil if lockout does not apply to it.
+func effectiveUserLockoutConfig(entry *MountEntry) *UserLockoutConfig {
+	if entry == nil || entry.Table != credentialTableType || !userLockoutSupportedTypes[entry.Type] {
+		return nil
+	}
+
+	config := &UserLockoutConfig{
+		LockoutThreshold:    defaultUserLockoutThreshold,
+		LockoutDuration:     defaultUserLockoutDuration,
+		LockoutCounterReset: defaultUserLockoutCounterReset,
+	}
+	if tuned := entry.Config.UserLockoutConfig; tuned != nil {
+		if tuned.DisableLockout {
+			return nil
+		}
+		if tuned.LockoutThreshold > 0 {
+			config.LockoutThreshold = tuned.LockoutThreshold
+		}
+		if tuned.LockoutDuration > 0 {
+			config.LockoutDuration = tuned.LockoutDuration
+		}
+		if tuned.LockoutCounterReset > 0 {
+			config.LockoutCounterReset = tuned.LockoutCounterReset
+		}
+	}
+	return config
+}
+
+type userLockoutKey struct {
+	mountAccessor string
+	aliasName     string
+}
+
+type failedLoginInfo struct {
+	count        uint64
+	lastFailed   time.Time
+	counterReset time.Duration
+	lockedAt     time.Time
+	lockoutEnd   time.Time
+}
+
+// expired returns whether the failed login state no longer has any effect:
+// the alias is not locked out and its failure counter would be reset
+func (i *failedLoginInfo) expired(now time.Time) bool {
+	if !i.lockoutEnd.IsZero() {
+		return !now.Before(i.lockoutEnd)
+	}
+	return now.Sub(i.lastFailed) > i.counterReset
+}
+
+// userLockouts tracks failed login attempts per auth mount and alias. The
+// state is kept in memory on the node handling logins and is not persisted
+// across restarts or leadership changes.
+type userLockouts struct {
+	l          sync.Mutex
+	entries    map[userLockoutKey]*failedLoginInfo
+	maxEntries int
+}
+
+func newUserLockouts() *userLockouts {
+	return &userLockouts{
+		entries:    make(map[userLockoutKey]*failedLoginInfo),
+		maxEntries: maxUserLockoutEntries,
+	}
+}
+
+// prune removes the failed login state that has expired
+func (u *userLockouts) prune(now time.Time) {
+	u.l.Lock()
+	defer u.l.Unlock()
+	u.pruneLocked(now)
+}
+
+func (u *userLockouts) pruneLocked(now time.Time) {
+	for key, info := range u.entries {
+		if info.expired(now) {
+			delete(u.entries, key)
+		}
+	}
+}
+
+// makeRoomLocked ensures a new entry can be added without exceeding the
+// maximum number of entries, evicting the unlocked alias whose last failure
+// is the oldest if needed. It returns false if every entry is locked out.
+func (u *userLockouts) makeRoomLocked(now time.Time) bool {
+	if len(u.entries) < u.maxEntries {
+		return true
+	}
+	u.pruneLocked(now)
+	if len(u.entries) < u.maxEntries {
+		return true
+	}
+
+	var oldestKey userLockoutKey
+	var oldest *failedLoginInfo
+	for key, info := range u.entries {
+		if !info.lockoutEnd.IsZero() {
+			continue
+		}
+		if oldest == nil || info.lastFailed.Before(oldest.lastFailed) {
+			oldestKey, oldest = key, info
+		}
+	}
+	if oldest == nil {
+		return false
+	}
+	delete(u.entries, oldestKey)
+	return true
+}
+
+// isLocked returns whether the alias is currently locked out, clearing any
+// lockout that has expired.
+func (u *userLockouts) isLocked(mountAccessor, aliasName string, now time.Time) bool {
+	u.l.Lock()
+	defer u.l.Unlock()
+
+	key := userLockoutKey{mountAccessor: mountAccessor, aliasName: aliasName}
+	info, ok := u.entries[key]
+	if !ok || info.lockoutEnd.IsZero() {
+		return false
+	}
+	if now.Before(info.lockoutEnd) {
+		return true
+	}
+
+	delete(u.entries, key)
+	return false
+}
+
+// recordFailure records a failed login and returns whether it caused the
+// alias to become locked out.
+func (u *userLockouts) recordFailure(mountAccessor, aliasName string, config *UserLockoutConfig, now time.Time) bool {
+	u.l.Lock()
+	defer u.l.Unlock()
+
+	key := userLockoutKey{mountAccessor: mountAccessor, aliasName: aliasName}
+	info, ok := u.entries[key]
+	if !ok || now.Sub(info.lastFailed) > config.LockoutCounterReset {
+		if !ok && !u.makeRoomLocked(now) {
+			return false
+		}
+		info = &failedLoginInfo{}
+		u.entries[key] = info
+	}
+
+	info.count++
+	info.lastFailed = now
+	info.counterReset = config.LockoutCounterReset
+	if info.count >= config.LockoutThreshold && info.lockoutEnd.IsZero() {
+		info.lockedAt = now
+		info.lockoutEnd = now.Add(config.LockoutDuration)
+		return true
+	}
+	return false
+}
+
+// reset clears the failed login state of the alias, returning whether it
+// was locked out.
+func (u *userLockouts) reset(mountAccessor, aliasName string) bool {
+	u.l.Lock()
+	defer u.l.Unlock()
+
+	key := userLockoutKey{mountAccessor: mountAccessor, aliasName: aliasName}
+	info, ok := u.entries[key]
+	if !ok {
+		return false
+	}
+	delete(u.entries, key)
+	return !info.lockoutEnd.IsZero()
+}
+
+type lockedUser struct {
+	MountAccessor string
+	AliasName     string
+	FailedLogins  uint64
+	LockedAt      time.Time
+	LockoutEnd    time.Time
+}
+
+// locked returns the aliases that are currently locked out, optionally
+// limited to a single mount.
+func (u *userLockouts) locked(mountAccessor string, now time.Time) []lockedUser {
+	u.l.Lock()
+	defer u.l.Unlock()
+
+	var users []lockedUser
+	for key, info := range u.entries {
+		if mountAccessor != "" && key.mountAccessor != mountAccessor {
+			continue
+		}
+		if info.lockoutEnd.IsZero() || !now.Before(info.lockoutEnd) {
+			continue
+		}
+		users = append(users, lockedUser{
+			MountAccessor: key.mountAccessor,
+			AliasName:     key.aliasName,
+			FailedLogins:  info.count,
+			LockedAt:      info.lockedAt,
+			LockoutEnd:    info.lockoutEnd,
+		})
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].MountAccessor != users[j].MountAccessor {
+			return users[i].MountAccessor < users[j].MountAccessor
+		}
+		return users[i].AliasName < users[j].AliasName
+	})
+	return users
+}
+
+// loginAliasName returns the name of the alias a login request is for by
+// issuing an alias lookahead request to the auth method. An empty string is
+// returned if the method cannot determine the alias ahead of login. The name
+// is lowercased so that case variations of a username share a counter.
+func (c *Core) loginAliasName(ctx context.Context, req *logical.Request) string {
+	lookaheadReq := &logical.Request{
+		Operation:     logical.AliasLookaheadOperation,
+		Path:          req.Path,
+		Data:          req.Data,
+		Storage:       req.Storage,
+		Connection:    req.Connection,
+		Headers:       req.Headers,
+		MountPoint:    req.MountPoint,
+		MountType:     req.MountType,
+		MountAccessor: req.MountAccessor,
+	}
+
+	resp, err := c.router.Route(ctx, lookaheadReq)
+	if err != nil || resp == nil || resp.IsError() || resp.Auth == nil || resp.Auth.Alias == nil {
+		return ""
+	}
+	return strings.ToLower(resp.Auth.Alias.Name)
+}
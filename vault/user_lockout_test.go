@@ -0,0 +1,239 @@
+package vault
+
+import (
+	"testing"
+	"time"
+
+	credUserpass "github.com/hashicorp/vault/builtin/credential/userpass"
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func TestUserLockouts(t *testing.T) {
+	u := newUserLockouts()
+	config := &UserLockoutConfig{
+		LockoutThreshold:    3,
+		LockoutDuration:     time.Minute,
+		LockoutCounterReset: time.Minute,
+	}
+	now := time.Now()
+
+	// Failures spaced further apart than the counter reset never lock out
+	for i := 0; i < 5; i++ {
+		if u.recordFailure("acc", "alice", config, now.Add(time.Duration(i)*2*time.Minute)) {
+			t.Fatalf("unexpected lockout after failure %d", i)
+		}
+	}
+	now = now.Add(time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if u.recordFailure("acc", "bob", config, now) {
+			t.Fatalf("unexpected lockout after failure %d", i)
+		}
+	}
+	if u.isLocked("acc", "bob", now) {
+		t.Fatal("expected bob to not be locked")
+	}
+	if !u.recordFailure("acc", "bob", config, now) {
+		t.Fatal("expected bob to be locked")
+	}
+	if !u.isLocked("acc", "bob", now.Add(30*time.Second)) {
+		t.Fatal("expected bob to be locked")
+	}
+	if u.isLocked("other", "bob", now) {
+		t.Fatal("expected lockout to be scoped to the mount")
+	}
+
+	locked := u.locked("", now)
+	if len(locked) != 1 || locked[0].AliasName != "bob" || locked[0].FailedLogins != 3 {
+		t.Fatalf("bad: %#v", locked)
+	}
+	if len(u.locked("other", now)) != 0 {
+		t.Fatal("expected no locked users on other mount")
+	}
+
+	// Lockouts expire
+	if u.isLocked("acc", "bob", now.Add(2*time.Minute)) {
+		t.Fatal("expected lockout to have expired")
+	}
+
+	// Lockouts can be cleared
+	for i := 0; i < 3; i++ {
+		u.recordFailure("acc", "bob", config, now)
+	}
+	if !u.reset("acc", "bob") {
+		t.Fatal("expected reset to report a lockout")
+	}
+	if u.isLocked("acc", "bob", now) {
+		t.Fatal("expected bob to be unlocked")
+	}
+}
+
+func TestUserLockouts_prune(t *testing.T) {
+	u := newUserLockouts()
+	config := &UserLockoutConfig{
+		LockoutThreshold:    2,
+		LockoutDuration:     10 * time.Minute,
+		LockoutCounterReset: time.Minute,
+	}
+	now := time.Now()
+
+	u.recordFailure("acc", "alice", config, now)
+	u.recordFailure("acc", "bob", config, now)
+	u.recordFailure("acc", "bob", config, now)
+	u.recordFailure("acc", "carol", config, now.Add(5*time.Minute))
+
+	// alice's counter would have been reset; bob is still locked out
+	u.prune(now.Add(6 * time.Minute))
+	if _, ok := u.entries[userLockoutKey{"acc", "alice"}]; ok {
+		t.Fatal("expected alice to be pruned")
+	}
+	if !u.isLocked("acc", "bob", now.Add(6*time.Minute)) {
+		t.Fatal("expected bob to still be locked")
+	}
+	if len(u.entries) != 2 {
+		t.Fatalf("bad: %d entries", len(u.entries))
+	}
+
+	// bob's lockout has expired
+	u.prune(now.Add(11 * time.Minute))
+	if len(u.entries) != 0 {
+		t.Fatalf("bad: %d entries", len(u.entries))
+	}
+}
+
+func TestUserLockouts_maxEntries(t *testing.T) {
+	u := newUserLockouts()
+	u.maxEntries = 2
+	config := &UserLockoutConfig{
+		LockoutThreshold:    5,
+		LockoutDuration:     time.Minute,
+		LockoutCounterReset: time.Minute,
+	}
+	lockConfig := &UserLockoutConfig{
+		LockoutThreshold:    1,
+		LockoutDuration:     time.Minute,
+		LockoutCounterReset: time.Minute,
+	}
+	now := time.Now()
+
+	u.recordFailure("acc", "alice", config, now)
+	u.recordFailure("acc", "bob", config, now.Add(time.Second))
+
+	// The oldest unlocked entry is evicted to make room
+	u.recordFailure("acc", "carol", lockConfig, now.Add(2*time.Second))
+	if len(u.entries) != 2 {
+		t.Fatalf("bad: %d entries", len(u.entries))
+	}
+	if _, ok := u.entries[userLockoutKey{"acc", "alice"}]; ok {
+		t.Fatal("expected alice to be evicted")
+	}
+	u.recordFailure("acc", "dave", lockConfig, now.Add(3*time.Second))
+	if !u.isLocked("acc", "carol", now.Add(3*time.Second)) || !u.isLocked("acc", "dave", now.Add(3*time.Second)) {
+		t.Fatal("expected carol and dave to be locked")
+	}
+
+	// Locked out aliases are never evicted
+	if u.recordFailure("acc", "erin", lockConfig, now.Add(4*time.Second)) {
+		t.Fatal("expected erin to not be tracked")
+	}
+	if len(u.entries) != 2 {
+		t.Fatalf("bad: %d entries", len(u.entries))
+	}
+}
+
+func TestParseUserLockoutConfig(t *testing.T) {
+	config, err := parseUserLockoutConfig(map[string]interface{}{
+		"lockout_threshold":     "10",
+		"lockout_duration":      "30m",
+		"lockout_counter_reset": 60,
+		"disable_lockout":       false,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.LockoutThreshold != 10 || config.LockoutDuration != 30*time.Minute || config.LockoutCounterReset != time.Minute {
+		t.Fatalf("bad: %#v", config)
+	}
+
+	for _, raw := range []map[string]interface{}{
+		{"lockout_threshold": -1},
+		{"lockout_duration": "soon"},
+		{"unknown": true},
+	} {
+		if _, err := parseUserLockoutConfig(raw); err == nil {
+			t.Fatalf("expected error for %v", raw)
+		}
+	}
+}
+
+func TestCore_UserLockout(t *testing.T) {
+	err := AddTestCredentialBackend("userpass", credUserpass.Factory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, _, root := TestCoreUnsealed(t)
+	ctx := namespace.RootContext(nil)
+
+	me := &MountEntry{
+		Table: credentialTableType,
+		Path:  "userpass/",
+		Type:  "userpass",
+		Config: MountConfig{
+			UserLockoutConfig: &UserLockoutConfig{
+				LockoutThreshold: 2,
+			},
+		},
+	}
+	if err := c.enableCredential(ctx, me); err != nil {
+		t.Fatal(err)
+	}
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "auth/userpass/users/alice")
+	req.ClientToken = root
+	req.Data["password"] = "correct"
+	if resp, err := c.HandleRequest(ctx, req); err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+
+	login := func(password string) (*logical.Response, error) {
+		req := logical.TestRequest(t, logical.UpdateOperation, "auth/userpass/login/alice")
+		req.Data["password"] = password
+		req.Connection = &logical.Connection{RemoteAddr: "127.0.0.1"}
+		return c.HandleRequest(ctx, req)
+	}
+
+	for i := 0; i < 2; i++ {
+		if resp, err := login("wrong"); err == nil && (resp == nil || !resp.IsError()) {
+			t.Fatalf("expected login to fail, got: %#v", resp)
+		}
+	}
+
+	// The correct password is now rejected as well
+	if _, err := login("correct"); err != logical.ErrPermissionDenied {
+		t.Fatalf("expected permission denied, got: %v", err)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "sys/locked-users")
+	req.ClientToken = root
+	resp, err := c.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := resp.Data["locked_users"].([]map[string]interface{})
+	if len(users) != 1 || users[0]["alias_identifier"] != "alice" || users[0]["mount_path"] != "auth/userpass/" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "sys/locked-users/"+me.Accessor+"/unlock/alice")
+	req.ClientToken = root
+	if resp, err := c.HandleRequest(ctx, req); err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+
+	resp, err = login("correct")
+	if err != nil || resp == nil || resp.Auth == nil {
+		t.Fatalf("expected successful login, got: %v %#v", err, resp)
+	}
+}